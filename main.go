@@ -6,12 +6,14 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	stderrors "errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
@@ -24,6 +26,7 @@ import (
 	"github.com/bborbe/dark-factory/pkg/config"
 	"github.com/bborbe/dark-factory/pkg/factory"
 	"github.com/bborbe/dark-factory/pkg/globalconfig"
+	"github.com/bborbe/dark-factory/pkg/log"
 	"github.com/bborbe/dark-factory/pkg/preflightconditions"
 	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/version"
@@ -43,6 +46,30 @@ func run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	logFormat, filteredArgs, err := parseLogFormatFlag(ctx, filteredArgs)
+	if err != nil {
+		return err
+	}
+	logLevel, filteredArgs, err := parseLogLevelFlag(ctx, filteredArgs)
+	if err != nil {
+		return err
+	}
+	promptsDir, filteredArgs, err := parsePromptsDirFlag(ctx, filteredArgs)
+	if err != nil {
+		return err
+	}
+	ideasDir, filteredArgs, err := parseIdeasDirFlag(ctx, filteredArgs)
+	if err != nil {
+		return err
+	}
+	logDir, filteredArgs, err := extractValueFlag(ctx, filteredArgs, "--log-dir")
+	if err != nil {
+		return err
+	}
+	workflow, filteredArgs, err := extractValueFlag(ctx, filteredArgs, "--workflow")
+	if err != nil {
+		return err
+	}
 
 	debug, command, subcommand, args, autoApprove, skipPreflight, model, skipHealthcheck := ParseArgs(
 		filteredArgs,
@@ -70,7 +97,21 @@ func run(ctx context.Context) error {
 		return nil
 	}
 
-	initLogging(debug)
+	if debug {
+		logLevel = log.LevelDebug
+	}
+	initLogging(logLevel, logFormat)
+
+	// `status --dir` reports other projects and needs no project in the working directory.
+	if command == "status" {
+		dirs, remaining, err := extractDirs(ctx, args)
+		if err != nil {
+			return err
+		}
+		if len(dirs) > 0 {
+			return runMultiStatusCommand(ctx, dirs, remaining, libtime.NewCurrentDateTime())
+		}
+	}
 
 	projectRoot, err := project.FindRoot(ctx)
 	if err != nil {
@@ -93,12 +134,29 @@ func run(ctx context.Context) error {
 	}
 	config.ApplyGlobalOverrides(&cfg, globalCfg, loadResult.Overrides)
 	sources := config.ComputeFieldSources(globalCfg, loadResult.Overrides)
+	if promptsDir != "" {
+		if err := config.ApplyPromptsDir(ctx, &cfg, promptsDir); err != nil {
+			return err
+		}
+	}
+	config.ApplyIdeasDir(&cfg, ideasDir)
+	config.ApplyLogDir(&cfg, logDir)
 	if err := config.ApplyArgOverrides(ctx, &cfg, &sources, command, model); err != nil {
 		return err
 	}
+	if err := applyWorkflowFlag(ctx, &cfg, &sources, workflow); err != nil {
+		return err
+	}
 	if err := config.ApplySetOverrides(ctx, &cfg, &sources, command, setOverrides); err != nil {
 		return err
 	}
+	if logFormat != "" {
+		cfg.LogFormat = logFormat
+	}
+	if logLevel != "" {
+		cfg.LogLevel = logLevel
+	}
+	setLogHandler(cfg.LogLevel, cfg.LogFormat)
 	if command == "run" || command == "daemon" {
 		if err := cfg.Validate(ctx); err != nil {
 			return err
@@ -120,15 +178,17 @@ func run(ctx context.Context) error {
 	)
 }
 
-func initLogging(debug bool) {
-	level := slog.LevelInfo
-	if debug {
-		level = slog.LevelDebug
-	}
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+func initLogging(level log.Level, format log.Format) {
+	setLogHandler(level, format)
 	slog.Info("dark-factory starting", "version", version.Version)
 }
 
+// setLogHandler installs the default logger writing records at level or above
+// to stderr in format. An empty level logs at info.
+func setLogHandler(level log.Level, format log.Format) {
+	slog.SetDefault(slog.New(log.NewHandler(os.Stderr, format, level.SlogLevel())))
+}
+
 func validateSkipFlags(
 	ctx context.Context,
 	command string,
@@ -161,6 +221,32 @@ func printCommandHelp(command string) {
 		printDaemonHelp()
 	case "kill":
 		printKillHelp()
+	case "stop":
+		printStopHelp()
+	case "pause":
+		printPauseHelp()
+	case "resume":
+		printResumeHelp()
+	case "bump":
+		printBumpHelp()
+	case "reorder":
+		printReorderHelp()
+	case "promote":
+		printPromoteHelp()
+	case "remove":
+		printRemoveHelp()
+	case "queue":
+		printQueueHelp()
+	case "completed":
+		printCompletedHelp()
+	case "cancel":
+		printCancelHelp()
+	case "retry":
+		printRetryHelp()
+	case "logs":
+		printLogsHelp()
+	case "validate":
+		printValidateHelp()
 	case "status":
 		printStatusHelp()
 	case "list":
@@ -173,6 +259,10 @@ func printCommandHelp(command string) {
 		printSpecHelp()
 	case "scenario":
 		printScenarioHelp()
+	case "release":
+		printReleaseHelp()
+	case "changelog":
+		printChangelogHelp()
 	case "doctor":
 		cmd.DoctorHelp()
 	case "healthcheck":
@@ -201,6 +291,10 @@ func runCommand(
 		return runSpecCommand(ctx, cfg, subcommand, args, currentDateTimeGetter)
 	case "scenario":
 		return runScenarioCommand(ctx, cfg, subcommand, args)
+	case "release":
+		return runReleaseCommand(ctx, subcommand, args)
+	case "changelog":
+		return runChangelogCommand(ctx, cfg, subcommand, args, currentDateTimeGetter)
 	case "status":
 		return runStatusCommand(ctx, cfg, args, currentDateTimeGetter)
 	case "list":
@@ -209,6 +303,12 @@ func runCommand(
 		}
 		return factory.CreateCombinedListCommand(cfg, currentDateTimeGetter).Run(ctx, args)
 	case "config":
+		if len(args) > 0 && args[0] == "show" {
+			if err := validateNoArgs(ctx, args[1:], printConfigHelp); err != nil {
+				return err
+			}
+			return printConfigSources(ctx, cfg, sources)
+		}
 		if err := validateNoArgs(ctx, args, printConfigHelp); err != nil {
 			return err
 		}
@@ -218,6 +318,53 @@ func runCommand(
 			return err
 		}
 		return factory.CreateKillCommand(cfg).Run(ctx, args)
+	case "stop":
+		if err := validateNoArgs(ctx, args, printStopHelp); err != nil {
+			return err
+		}
+		return factory.CreateStopCommand(ctx, cfg).Run(ctx, args)
+	case "pause":
+		if err := validateNoArgs(ctx, args, printPauseHelp); err != nil {
+			return err
+		}
+		return factory.CreatePauseCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "resume":
+		if err := validateNoArgs(ctx, args, printResumeHelp); err != nil {
+			return err
+		}
+		return factory.CreateResumeCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "bump":
+		return factory.CreateBumpCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "reorder":
+		if err := validateNoArgs(ctx, args, printReorderHelp); err != nil {
+			return err
+		}
+		return factory.CreateReorderCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "promote":
+		return factory.CreatePromoteCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "remove":
+		return factory.CreateRemoveCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "queue":
+		if len(args) > 0 && args[0] == "add" {
+			return factory.CreateQueueAddCommand(cfg, currentDateTimeGetter).Run(ctx, args[1:])
+		}
+		return factory.CreateQueueCommand(ctx, cfg, currentDateTimeGetter).Run(ctx, args)
+	case "completed":
+		return factory.CreateCompletedCommand(ctx, cfg, currentDateTimeGetter).Run(ctx, args)
+	case "cancel":
+		if err := validateNoArgs(ctx, args, printCancelHelp); err != nil {
+			return err
+		}
+		return factory.CreateCancelExecutingCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "retry":
+		return runRetry(ctx, cfg, args, printRetryHelp, currentDateTimeGetter)
+	case "logs":
+		return factory.CreateLogsCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "validate":
+		if err := validateNoArgs(ctx, args, printValidateHelp); err != nil {
+			return err
+		}
+		return factory.CreateValidateCommand(cfg, currentDateTimeGetter).Run(ctx, args)
 	case "doctor":
 		if err := validateDoctorArgs(ctx, args); err != nil {
 			return err
@@ -269,12 +416,25 @@ func runStatusCommand(
 	if n > 0 {
 		cfg.MaxContainers = n
 	}
-	if err := validateNoArgs(ctx, remaining, printStatusHelp); err != nil {
+	if err := validateStatusArgs(ctx, remaining, printStatusHelp); err != nil {
 		return err
 	}
 	return factory.CreateCombinedStatusCommand(ctx, cfg, currentDateTimeGetter).Run(ctx, remaining)
 }
 
+func runMultiStatusCommand(
+	ctx context.Context,
+	dirs []string,
+	args []string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) error {
+	multiStatusCommand, err := factory.CreateMultiStatusCommand(ctx, dirs, currentDateTimeGetter)
+	if err != nil {
+		return err
+	}
+	return multiStatusCommand.Run(ctx, args)
+}
+
 func runRunCommand(
 	ctx context.Context,
 	cfg config.Config,
@@ -296,6 +456,8 @@ func runRunCommand(
 		cfg.AutoApprovePrompts = true
 		sources.AutoApprovePrompts = "arg"
 	}
+	cfg.DryRun, remaining = extractDryRun(remaining)
+	cfg.ForceLock, remaining = extractForceLock(remaining)
 	if err := validateNoArgs(ctx, remaining, printRunHelp); err != nil {
 		return err
 	}
@@ -333,6 +495,7 @@ func runDaemonCommand(
 		cfg.AutoApprovePrompts = true
 		sources.AutoApprovePrompts = "arg"
 	}
+	cfg.ForceLock, remaining = extractForceLock(remaining)
 	if err := validateNoArgs(ctx, remaining, printDaemonHelp); err != nil {
 		return err
 	}
@@ -363,7 +526,7 @@ func runPromptCommand(
 		printPromptHelp()
 		return nil
 	case "status":
-		if err := validateNoArgs(ctx, args, printPromptHelp); err != nil {
+		if err := validateStatusArgs(ctx, args, printPromptHelp); err != nil {
 			return err
 		}
 		return factory.CreateStatusCommand(ctx, cfg, currentDateTimeGetter).Run(ctx, args)
@@ -388,11 +551,7 @@ func runPromptCommand(
 		}
 		return factory.CreateCancelCommand(cfg, currentDateTimeGetter).Run(ctx, args)
 	case "retry":
-		if err := validateNoArgs(ctx, args, printPromptHelp); err != nil {
-			return err
-		}
-		return factory.CreateRequeueCommand(cfg, currentDateTimeGetter).
-			Run(ctx, []string{"--failed"})
+		return runRetry(ctx, cfg, args, printPromptHelp, currentDateTimeGetter)
 	case "complete":
 		forceRelease, remaining := extractForceRelease(args)
 		if err := validateOneArg(ctx, remaining, printPromptHelp); err != nil {
@@ -416,6 +575,11 @@ func runPromptCommand(
 			return err
 		}
 		return factory.CreatePromptShowCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	case "reconcile":
+		if err := validateNoArgs(ctx, args, printPromptHelp); err != nil {
+			return err
+		}
+		return factory.CreateReconcileCommand(cfg, currentDateTimeGetter).Run(ctx, args)
 	default:
 		return errors.Errorf(ctx, "unknown prompt subcommand: %s", subcommand)
 	}
@@ -504,6 +668,48 @@ func runScenarioCommand(
 	}
 }
 
+func runReleaseCommand(
+	ctx context.Context,
+	subcommand string,
+	args []string,
+) error {
+	switch subcommand {
+	case "", "--help", "-h", "help":
+		printReleaseHelp()
+		return nil
+	case "plan":
+		return factory.CreateReleasePlanCommand().Run(ctx, args)
+	case "preview":
+		return factory.CreateReleasePreviewCommand().Run(ctx, args)
+	case "next":
+		return factory.CreateReleaseNextCommand().Run(ctx, args)
+	default:
+		return errors.Errorf(ctx, "unknown release subcommand: %s", subcommand)
+	}
+}
+
+func runChangelogCommand(
+	ctx context.Context,
+	cfg config.Config,
+	subcommand string,
+	args []string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) error {
+	switch subcommand {
+	case "", "--help", "-h", "help":
+		printChangelogHelp()
+		return nil
+	case "rebuild":
+		if containsHelpFlag(args) {
+			printChangelogHelp()
+			return nil
+		}
+		return factory.CreateChangelogRebuildCommand(cfg, currentDateTimeGetter).Run(ctx, args)
+	default:
+		return errors.Errorf(ctx, "unknown changelog subcommand: %s", subcommand)
+	}
+}
+
 // containsHelpFlag reports whether args contains --help, -help, or -h.
 func containsHelpFlag(args []string) bool {
 	for _, arg := range args {
@@ -532,6 +738,22 @@ func validateListArgs(ctx context.Context, args []string, helpFn func()) error {
 	return nil
 }
 
+// validateStatusArgs returns an error for any argument other than --json, --watch
+// and --interval <duration>.
+func validateStatusArgs(ctx context.Context, args []string, helpFn func()) error {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json", "--watch":
+			continue
+		case "--interval":
+			i++
+			continue
+		}
+		return validateNoArgs(ctx, args[i:], helpFn)
+	}
+	return nil
+}
+
 // validateNoArgs returns an error if args is non-empty.
 // Unknown flags (starting with -) are reported as "unknown flag", others as "unknown argument".
 func validateNoArgs(ctx context.Context, args []string, helpFn func()) error {
@@ -571,8 +793,25 @@ func validateOneArg(ctx context.Context, args []string, helpFn func()) error {
 	return nil
 }
 
+// runRetry requeues all failed prompts, or only the named one when an id is given.
+func runRetry(
+	ctx context.Context,
+	cfg config.Config,
+	args []string,
+	helpFn func(),
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) error {
+	if len(args) > 0 {
+		if err := validateOneArg(ctx, args, helpFn); err != nil {
+			return err
+		}
+	}
+	return factory.CreateRequeueCommand(cfg, currentDateTimeGetter).
+		Run(ctx, append([]string{"--failed"}, args...))
+}
+
 // validateRequeueArgs validates args for the requeue subcommand:
-// exactly one positional arg (slug) or the --failed flag, but not both.
+// one positional arg (slug), the --failed flag, or both (requeue the slug only if failed).
 func validateRequeueArgs(ctx context.Context, args []string, helpFn func()) error {
 	for _, arg := range args {
 		if strings.HasPrefix(arg, "-") && arg != "--failed" {
@@ -629,6 +868,24 @@ func extractMaxContainers(ctx context.Context, args []string) (int, []string, er
 	return 0, args, nil
 }
 
+// extractDirs removes every "--dir <path>" pair from args and returns the paths in order.
+func extractDirs(ctx context.Context, args []string) ([]string, []string, error) {
+	var dirs []string
+	remaining := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--dir" {
+			remaining = append(remaining, args[i])
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, nil, errors.Errorf(ctx, "--dir requires a value")
+		}
+		dirs = append(dirs, args[i+1])
+		i++
+	}
+	return dirs, remaining, nil
+}
+
 // extractAutoApprovePrompts removes --auto-approve-prompts from args and reports whether it was set.
 // The flag is a presence flag: its appearance means true. No value argument is consumed.
 func extractAutoApprovePrompts(args []string) (bool, []string) {
@@ -644,6 +901,34 @@ func extractAutoApprovePrompts(args []string) (bool, []string) {
 	return false, args
 }
 
+// extractDryRun removes --dry-run from args and reports whether it was set.
+func extractDryRun(args []string) (bool, []string) {
+	for i, arg := range args {
+		if arg != "--dry-run" {
+			continue
+		}
+		remaining := make([]string, 0, len(args)-1)
+		remaining = append(remaining, args[:i]...)
+		remaining = append(remaining, args[i+1:]...)
+		return true, remaining
+	}
+	return false, args
+}
+
+// extractForceLock removes --force from args and reports whether it was set.
+func extractForceLock(args []string) (bool, []string) {
+	for i, arg := range args {
+		if arg != "--force" {
+			continue
+		}
+		remaining := make([]string, 0, len(args)-1)
+		remaining = append(remaining, args[:i]...)
+		remaining = append(remaining, args[i+1:]...)
+		return true, remaining
+	}
+	return false, args
+}
+
 // extractForceRelease removes --release from args and reports whether it was set.
 // The flag is a presence flag: its appearance means true. No value argument is consumed.
 func extractForceRelease(args []string) (bool, []string) {
@@ -741,6 +1026,144 @@ func parseSetFlags(ctx context.Context, rawArgs []string) (map[string]string, []
 	return overrides, filtered, nil
 }
 
+// applyWorkflowFlag selects the workflow given with --workflow. An empty value
+// keeps the configured workflow.
+func applyWorkflowFlag(
+	ctx context.Context,
+	cfg *config.Config,
+	sources *config.FieldSources,
+	workflow string,
+) error {
+	if workflow == "" {
+		return nil
+	}
+	if err := config.ApplyWorkflow(ctx, cfg, workflow); err != nil {
+		return errors.Wrap(ctx, err, "invalid --workflow")
+	}
+	sources.Workflow = "arg"
+	sources.PR = "arg"
+	return nil
+}
+
+// parseLogFormatFlag removes --log-format=<fmt> or --log-format <fmt> from rawArgs.
+// Returns "" when the flag is absent, so logFormat from the config applies.
+func parseLogFormatFlag(ctx context.Context, rawArgs []string) (log.Format, []string, error) {
+	value, filtered, err := extractValueFlag(ctx, rawArgs, "--log-format")
+	if err != nil {
+		return "", nil, err
+	}
+	format := log.Format(value)
+	if err := format.Validate(ctx); err != nil {
+		return "", nil, err
+	}
+	return format, filtered, nil
+}
+
+// parseLogLevelFlag removes --log-level=<level> or --log-level <level> from rawArgs.
+// Returns "" when the flag is absent, so logLevel from the config applies.
+func parseLogLevelFlag(ctx context.Context, rawArgs []string) (log.Level, []string, error) {
+	value, filtered, err := extractValueFlag(ctx, rawArgs, "--log-level")
+	if err != nil {
+		return "", nil, err
+	}
+	level := log.Level(value)
+	if err := level.Validate(ctx); err != nil {
+		return "", nil, err
+	}
+	return level, filtered, nil
+}
+
+// parsePromptsDirFlag removes every --prompts-dir=<dir> or --prompts-dir <dir> from rawArgs.
+// The flag may be repeated and each value may be a comma-separated list; the directories
+// are returned comma-joined. A relative dir is resolved against the working directory of
+// the invocation. Returns "" when the flag is absent.
+func parsePromptsDirFlag(ctx context.Context, rawArgs []string) (string, []string, error) {
+	values, filtered, err := extractValueFlags(ctx, rawArgs, "--prompts-dir")
+	if err != nil || len(values) == 0 {
+		return "", filtered, err
+	}
+	var dirs []string
+	for _, value := range values {
+		for _, dir := range strings.Split(value, ",") {
+			if dir == "" {
+				continue
+			}
+			absDir, err := filepath.Abs(dir)
+			if err != nil {
+				return "", nil, errors.Wrapf(ctx, err, "resolve --prompts-dir %s", dir)
+			}
+			dirs = append(dirs, absDir)
+		}
+	}
+	if len(dirs) == 0 {
+		return "", nil, errors.Errorf(ctx, "--prompts-dir requires a value")
+	}
+	return strings.Join(dirs, ","), filtered, nil
+}
+
+// parseIdeasDirFlag removes --ideas-dir=<dir> or --ideas-dir <dir> from rawArgs.
+// A relative dir is resolved against the working directory of the invocation.
+// Returns "" when the flag is absent.
+func parseIdeasDirFlag(ctx context.Context, rawArgs []string) (string, []string, error) {
+	dir, filtered, err := extractValueFlag(ctx, rawArgs, "--ideas-dir")
+	if err != nil || dir == "" {
+		return "", filtered, err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, errors.Wrapf(ctx, err, "resolve --ideas-dir %s", dir)
+	}
+	return absDir, filtered, nil
+}
+
+// extractValueFlag removes name=<value> or name <value> from rawArgs.
+// Returns "" when the flag is absent; an empty value is an error.
+// When the flag is repeated, the last value wins.
+func extractValueFlag(
+	ctx context.Context,
+	rawArgs []string,
+	name string,
+) (string, []string, error) {
+	values, filtered, err := extractValueFlags(ctx, rawArgs, name)
+	if err != nil || len(values) == 0 {
+		return "", filtered, err
+	}
+	return values[len(values)-1], filtered, nil
+}
+
+// extractValueFlags removes every name=<value> or name <value> from rawArgs
+// and returns the values in order. An empty value is an error.
+func extractValueFlags(
+	ctx context.Context,
+	rawArgs []string,
+	name string,
+) ([]string, []string, error) {
+	var values []string
+	filtered := make([]string, 0, len(rawArgs))
+	for i := 0; i < len(rawArgs); i++ {
+		arg := rawArgs[i]
+		var value string
+		switch {
+		case strings.HasPrefix(arg, name+"="):
+			value = strings.TrimPrefix(arg, name+"=")
+		case arg == name:
+			if i+1 >= len(rawArgs) {
+				return nil, nil, errors.Errorf(ctx, "%s requires a value", name)
+			}
+			value = rawArgs[i+1]
+			i++ // consume the value
+		default:
+			filtered = append(filtered, arg)
+			continue
+		}
+		if value == "" {
+			return nil, nil, errors.Errorf(ctx, "%s requires a value", name)
+		}
+		values = append(values, value)
+	}
+	return values, filtered, nil
+}
+
 func printConfig(ctx context.Context, cfg config.Config) error {
 	globalCfg, err := globalconfig.NewLoader().Load(ctx)
 	if err != nil {
@@ -762,6 +1185,18 @@ func printConfig(ctx context.Context, cfg config.Config) error {
 	return enc.Encode(out)
 }
 
+// printConfigSources prints every top-level key of the effective project config
+// as JSON together with the layer it came from.
+func printConfigSources(ctx context.Context, cfg config.Config, sources config.FieldSources) error {
+	values, err := config.EffectiveValues(ctx, cfg, sources)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(values)
+}
+
 func printHelp(w io.Writer) {
 	fmt.Fprintf(
 		w,
@@ -769,17 +1204,34 @@ func printHelp(w io.Writer) {
 			"  run [--max-containers N] [--skip-preflight] [--model NAME] [--set key=value ...]    Process all queued prompts and exit\n"+
 			"  daemon [--max-containers N] [--skip-preflight] [--model NAME] [--set key=value ...] Watch for queued prompts and execute them (long-running)\n"+
 			"  kill                   Stop the running daemon\n"+
+			"  stop                   Finish the running prompt, then stop the daemon (needs serverPort)\n"+
+			"  pause                  Finish the running prompt, then start no new prompts\n"+
+			"  resume                 Resume processing queued prompts after pause\n"+
+			"  bump <id>              Move a queued prompt to the front without renumbering\n"+
+			"  reorder                Renumber the queued prompts without gaps, keeping their order\n"+
+			"  promote <idea.md>      Move an idea from the ideas dir into the queue\n"+
+			"  remove <id>            Delete a queued or failed prompt\n"+
+			"  queue [--tag <name>]   List queued prompts in the order they will run\n"+
+			"  queue add \"<title>\"    Create a queued prompt (body from stdin when piped)\n"+
+			"  queue add --from-json <file>\n"+
+			"                         Enqueue every prompt of a JSON array in one batch\n"+
+			"  completed [--limit N]  List the most recently completed prompts\n"+
+			"  cancel                 Stop the executing prompt's container and re-queue it\n"+
+			"  retry [<id>]           Re-queue all failed prompts, or only the given failed prompt\n"+
+			"  logs [-f] [<file>]     Print the executing prompt's log, or the log of <file>\n"+
+			"  validate               Lint the queued prompt files and exit non-zero if any is invalid\n"+
 			"  doctor [--fix] [--yes] [--verifying-stale-hours=N]  Detect state anomalies (and optionally fix them)\n"+
 			"  healthcheck [--no-claude]        Probe the full pipeline-execution stack\n"+
 			"  status                 Show combined status of prompts and specs\n"+
 			"  list                   List all prompts and specs with their status\n"+
-			"  config                 Show effective configuration (defaults + .dark-factory.yaml)\n\n"+
+			"  config                 Show effective configuration (defaults + .dark-factory.yaml)\n"+
+			"  config show            Show effective configuration as JSON with the source of each value\n\n"+
 			"  prompt list            List prompts with their status\n"+
 			"  prompt status          Show prompt status\n"+
 			"  prompt approve <id>    Approve a prompt (move from inbox to queue)\n"+
 			"  prompt requeue <id>    Reset a prompt's status to queued\n"+
 			"  prompt cancel <id>     Cancel an approved or executing prompt\n"+
-			"  prompt retry           Shorthand for prompt requeue --failed\n"+
+			"  prompt retry [<id>]    Shorthand for prompt requeue --failed [<id>]\n"+
 			"  prompt complete <id>   Complete a prompt (triggers commit/push)\n"+
 			"  prompt unapprove <id>  Unapprove a prompt (move back to inbox, reset to draft)\n"+
 			"  prompt reject <id> --reason <text>  Reject a prompt (move to rejected/, terminal state)\n"+
@@ -795,11 +1247,25 @@ func printHelp(w io.Writer) {
 			"  scenario list          List scenarios\n"+
 			"  scenario show <id>     Show full contents of a scenario\n"+
 			"  scenario status        Show scenario status counts\n\n"+
+			"  release plan           Show next version, bump type and changelog entry\n"+
+			"  release preview        Show the changelog entry of the next release\n"+
+			"  release next           Show the next version\n\n"+
+			"  changelog rebuild [--force]  Rebuild the changelog from completed prompts\n\n"+
 			"Configuration:\n"+
 			"  Global config:  ~/.config/dark-factory/config.yaml (XDG)\n"+
 			"                  ~/.dark-factory/config.yaml (legacy)\n"+
 			"  Per-project:    .dark-factory.yaml (current directory)\n\n"+
-			"Options:\n  -debug  Enable debug logging\n\n"+
+			"Options:\n  -debug, --verbose       Enable debug logging (-v after the command)\n"+
+			"  --log-format=text|json  Log line format (default: text)\n"+
+			"  --log-level=error|info|debug  Minimum log level (default: info)\n"+
+			"  --prompts-dir=<dir>     Prompts directory; repeat or comma-separate to watch several\n"+
+			"                          (env: DARK_FACTORY_PROMPTS_DIR)\n"+
+			"  --ideas-dir=<dir>       Ideas directory for promote and status (default: <prompts>/ideas)\n"+
+			"                          (env: DARK_FACTORY_IDEAS_DIR)\n"+
+			"  --log-dir=<dir>         Prompt log directory, relative to the prompts directory\n"+
+			"                          (default: log, env: DARK_FACTORY_LOG_DIR)\n"+
+			"  --workflow=<workflow>   direct, branch, worktree, clone or pr (clone with pr: true)\n"+
+			"                          for this run (env: DARK_FACTORY_WORKFLOW)\n\n"+
 			"Flags:\n  --help, -h       Show this help\n  --version, -v    Show version\n",
 	)
 }
@@ -807,10 +1273,13 @@ func printHelp(w io.Writer) {
 func printRunHelp() {
 	fmt.Fprintf(
 		os.Stdout,
-		"Usage: dark-factory run [--max-containers N] [--auto-approve] [--skip-preflight] [--model NAME] [--set key=value ...]\n\n"+
+		"Usage: dark-factory run [--max-containers N] [--auto-approve] [--skip-preflight] [--dry-run] [--force] [--model NAME] [--set key=value ...]\n\n"+
 			"Process all queued prompts and exit.\n\n"+
 			"Flags:\n"+
 			"  --max-containers N      Override the container limit for this run\n"+
+			"  --dry-run               Log title, container name and version bump of each queued\n"+
+			"                          prompt without executing, moving or committing anything\n"+
+			"  --force                 Take over the lock of a running instance\n"+
 			"  --auto-approve          Automatically approve new prompts found during run\n"+
 			"  --skip-preflight        Skip preflight baseline check for this invocation.\n"+
 			"                          Prompts may run on a broken baseline — use with caution.\n"+
@@ -830,13 +1299,14 @@ func printRunHelp() {
 func printDaemonHelp() {
 	fmt.Fprintf(
 		os.Stdout,
-		"Usage: dark-factory daemon [--max-containers N] [--skip-preflight] [--skip-healthcheck] [--model NAME] [--set key=value ...]\n\n"+
+		"Usage: dark-factory daemon [--max-containers N] [--skip-preflight] [--skip-healthcheck] [--force] [--model NAME] [--set key=value ...]\n\n"+
 			"Watch for queued prompts and execute them (long-running).\n\n"+
 			"Flags:\n"+
 			"  --max-containers N      Override the container limit for this run\n"+
 			"  --skip-preflight        Skip preflight baseline check for this invocation.\n"+
 			"                          Prompts may run on a broken baseline — use with caution.\n"+
 			"  --skip-healthcheck      Skip the healthcheck startup gate for this invocation (daemon only).\n"+
+			"  --force                 Take over the lock of a running instance\n"+
 			"  --model NAME            Override model for this invocation (overrides yaml)\n"+
 			"  --set key=value         Override a config field for this invocation; may repeat\n"+
 			"                          Supported keys: hideGit, autoRelease, dirtyFileThreshold, model, maxContainers, workflow, pr, autoMerge, autoGeneratePrompts\n"+
@@ -860,16 +1330,186 @@ func printKillHelp() {
 	)
 }
 
-func printStatusHelp() {
+func printStopHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory stop\n\n"+
+			"Ask the running daemon to finish the current prompt and exit.\n"+
+			"Calls POST /shutdown on the daemon REST API, so serverPort must be set.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printPauseHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory pause\n\n"+
+			"Pause the queue without stopping the daemon.\n"+
+			"Writes the .paused sentinel into the prompts inbox; the running prompt\n"+
+			"finishes but no new prompt starts until 'dark-factory resume'.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printValidateHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory validate\n\n"+
+			"Lint every prompt in the queue (prompts/in-progress) before starting the daemon.\n"+
+			"Reports unparseable frontmatter YAML, an invalid status, a missing NNN- prefix\n"+
+			"and an empty body, one line per problem, and exits non-zero if any prompt is invalid.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printResumeHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory resume\n\n"+
+			"Remove the .paused sentinel so the daemon processes queued prompts again.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printBumpHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory bump <id>\n\n"+
+			"Move a queued prompt to the front of the queue without renumbering it.\n"+
+			"With queueOrder priority (the default) the prompt's priority is raised above all other\n"+
+			"queued prompts; with queueOrder mtime its modification time is set before\n"+
+			"the oldest queued prompt. queueOrder number cannot be bumped.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printReorderHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory reorder\n\n"+
+			"Renumber the queued prompts (prompts/in-progress) to a contiguous sequence,\n"+
+			"keeping their current number order. Duplicate numbers are ordered by name.\n"+
+			"The sequence starts after the highest completed prompt number. Completed\n"+
+			"prompts are not touched and depends_on entries are not rewritten. Fails\n"+
+			"while a prompt is executing.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printPromoteHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory promote <idea.md>\n\n"+
+			"Move a file from the ideas directory (prompts/ideas by default) into the\n"+
+			"queue as an approved prompt. An existing number prefix is dropped and the\n"+
+			"next free NNN- prefix is assigned.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printRemoveHelp() {
 	fmt.Fprintf(
 		os.Stdout,
-		"Usage: dark-factory status\n\n"+
-			"Show combined status of prompts and specs.\n\n"+
+		"Usage: dark-factory remove <id>\n\n"+
+			"Delete a prompt from the queue. Only approved (queued) and failed prompts\n"+
+			"can be removed; executing and completed prompts are refused.\n\n"+
 			"Flags:\n"+
 			"  --help, -h  Show this help\n",
 	)
 }
 
+func printQueueHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory queue [--tag <name>] [--json]\n"+
+			"       dark-factory queue add \"<title>\" [< body.md]\n"+
+			"       dark-factory queue add --from-json <file>\n\n"+
+			"List queued prompts in the order the daemon picks them (see queueOrder).\n"+
+			"With add, create an approved NNN-<slug>.md prompt titled <title> in the queue\n"+
+			"and print its path. A body piped on stdin is written below the heading.\n"+
+			"With add --from-json, enqueue every prompt of the JSON array in <file>\n"+
+			"(title, body, tags, priority) and print their paths. The batch is\n"+
+			"all-or-nothing: an invalid entry writes no prompt.\n\n"+
+			"Flags:\n"+
+			"  --tag <name>  Only list prompts whose tags frontmatter includes <name>\n"+
+			"  --json        Print a JSON array of name, title and size per prompt\n"+
+			"  --help, -h    Show this help\n",
+	)
+}
+
+func printCompletedHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory completed [--limit <n>] [--json]\n\n"+
+			"List the most recently completed prompts with their completion time,\n"+
+			"most recent first.\n\n"+
+			"Flags:\n"+
+			"  --limit <n>  Number of prompts to list (default 10)\n"+
+			"  --json       Print a JSON array of name and completed_at per prompt\n"+
+			"  --help, -h   Show this help\n",
+	)
+}
+
+func printCancelHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory cancel\n\n"+
+			"Stop the container of the currently executing prompt and put the prompt\n"+
+			"back into the queue as approved. The daemon picks it up again on a later\n"+
+			"scan. To drop a prompt instead, use: dark-factory prompt cancel <id>\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printRetryHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory retry [<id>]\n\n"+
+			"Re-queue failed prompts as approved and reset their retry count.\n"+
+			"Without <id>, every failed prompt in the queue is re-queued.\n"+
+			"With <id>, only that prompt is re-queued; it must have status failed.\n\n"+
+			"Flags:\n"+
+			"  --help, -h  Show this help\n",
+	)
+}
+
+func printLogsHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory logs [-f] [<file>]\n\n"+
+			"Print the log of the currently executing prompt from the log directory\n"+
+			"(prompts.logDir, --log-dir; default prompts/log/).\n"+
+			"With <file>, print the log of that prompt instead.\n\n"+
+			"Flags:\n"+
+			"  -f, --follow  Keep printing new output until the prompt is no longer executing\n"+
+			"  --help, -h    Show this help\n",
+	)
+}
+
+func printStatusHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory status [--dir <path>]... [--json]"+
+			" [--watch [--interval <duration>]]\n\n"+
+			"Show combined status of prompts and specs.\n"+
+			"With one or more --dir, report the prompt status of each project directory instead.\n\n"+
+			"Flags:\n"+
+			"  --dir <path>           Report the project in <path> (repeatable)\n"+
+			"  --json                 Print JSON (an array with --dir)\n"+
+			"  --watch                Clear the screen and refresh until interrupted\n"+
+			"  --interval <duration>  Refresh interval for --watch (default 2s)\n"+
+			"  --help, -h             Show this help\n",
+	)
+}
+
 func printListHelp() {
 	fmt.Fprintf(
 		os.Stdout,
@@ -883,8 +1523,10 @@ func printListHelp() {
 func printConfigHelp() {
 	fmt.Fprintf(
 		os.Stdout,
-		"Usage: dark-factory config\n\n"+
-			"Show effective configuration (defaults + .dark-factory.yaml).\n\n"+
+		"Usage: dark-factory config [show]\n\n"+
+			"Show effective configuration (defaults + .dark-factory.yaml).\n"+
+			"With show, print the project configuration as JSON: every top-level key with\n"+
+			"its value and source (default, global, project, env or arg for a CLI flag).\n\n"+
 			"Flags:\n"+
 			"  --help, -h  Show this help\n",
 	)
@@ -895,17 +1537,19 @@ func printPromptHelp() {
 		os.Stdout,
 		"Usage: dark-factory prompt <subcommand>\n\nSubcommands:\n"+
 			"  list            List prompts with their status\n"+
-			"  status          Show prompt status\n"+
+			"  status [--watch [--interval <duration>]]\n"+
+			"                  Show prompt status (--watch refreshes until interrupted)\n"+
 			"  approve <id>    Approve a prompt (move from inbox to queue)\n"+
 			"  requeue <id>    Reset a prompt's status to queued\n"+
 			"  cancel <id>     Cancel an approved or executing prompt\n"+
-			"  retry           Shorthand for prompt requeue --failed\n"+
+			"  retry [<id>]    Shorthand for prompt requeue --failed [<id>]\n"+
 			"  complete <id> [--release]\n"+
 			"                  Complete a prompt (commits locally; on master+autoRelease: tag+push;\n"+
 			"                  --release forces release on any branch)\n"+
 			"  unapprove <id>  Unapprove a prompt (move back to inbox, reset to draft)\n"+
 			"  reject <id> --reason <text>  Reject a prompt (move to rejected/, terminal state)\n"+
 			"  show <id>       Show details for a single prompt\n"+
+			"  reconcile       Fix prompts whose status does not match their directory\n"+
 			"  <id> formats: padded number (063), unpadded number (63), full basename (063-foo-bar), or basename with .md extension\n",
 	)
 }
@@ -936,9 +1580,32 @@ func printScenarioHelp() {
 	)
 }
 
+func printReleaseHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory release <subcommand> [--format text|json]\n\nSubcommands:\n"+
+			"  plan          Show next version, bump type and changelog entry\n"+
+			"  preview       Show the changelog entry the next release would write\n"+
+			"  next          Show the next version only\n\n"+
+			"Flags:\n"+
+			"  --format text|json  Output format (default text; json emits version, bump, changelog)\n",
+	)
+}
+
+func printChangelogHelp() {
+	fmt.Fprintf(
+		os.Stdout,
+		"Usage: dark-factory changelog rebuild [--force]\n\n"+
+			"Rebuilds the changelog from completed prompts, grouped by dark-factory-version.\n"+
+			"Writes %s unless --force is given, which overwrites CHANGELOG.md.\n",
+		cmd.RebuiltChangelogFile,
+	)
+}
+
 // ParseArgs parses command line arguments (without program name) and returns
 // (debug, command, subcommand, args, autoApprove, skipPreflight, model, skipHealthcheck).
-// The -debug flag can appear anywhere and is extracted before parsing.
+// The -debug and --verbose flags can appear anywhere and are extracted before parsing.
+// -v means --verbose after the command and --version in its place.
 // The --auto-approve flag is extracted for the "run" command.
 // The --skip-preflight flag is extracted for the "run" and "daemon" commands.
 // The --skip-healthcheck flag is extracted for the "daemon" command.
@@ -959,7 +1626,13 @@ func ParseArgs(rawArgs []string) (bool, string, string, []string, bool, bool, st
 	filtered := make([]string, 0, len(rawArgs))
 	for _, arg := range rawArgs {
 		switch arg {
-		case "-debug":
+		case "-debug", "--verbose":
+			debug = true
+		case "-v":
+			if len(filtered) == 0 {
+				filtered = append(filtered, arg)
+				continue
+			}
 			debug = true
 		case "--auto-approve":
 			autoApprove = true
@@ -1001,9 +1674,9 @@ func ParseArgs(rawArgs []string) (bool, string, string, []string, bool, bool, st
 		return debug, "help", "", []string{}, autoApprove, skipPreflight, model, skipHealthcheck
 	case "--version", "-version", "-v":
 		return debug, "version", "", []string{}, autoApprove, skipPreflight, model, skipHealthcheck
-	case "run", "daemon", "kill", "status", "list", "config", "doctor", "healthcheck":
+	case "run", "daemon", "kill", "stop", "pause", "resume", "bump", "reorder", "promote", "remove", "queue", "completed", "cancel", "retry", "logs", "validate", "status", "list", "config", "doctor", "healthcheck":
 		return debug, command, "", rest, autoApprove, skipPreflight, model, skipHealthcheck
-	case "prompt", "spec", "scenario":
+	case "prompt", "spec", "scenario", "release", "changelog":
 		if len(rest) == 0 {
 			return debug, command, "", []string{}, autoApprove, skipPreflight, model, skipHealthcheck
 		}
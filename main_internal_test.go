@@ -159,6 +159,51 @@ var _ = Describe("validateListArgs", func() {
 	})
 })
 
+var _ = Describe("validateStatusArgs", func() {
+	ctx := context.Background()
+	noop := func() {}
+
+	It("accepts --json, --watch and --interval with a value", func() {
+		Expect(validateStatusArgs(
+			ctx,
+			[]string{"--json", "--watch", "--interval", "5s"},
+			noop,
+		)).To(Succeed())
+	})
+
+	It("returns error for unknown flag", func() {
+		Expect(validateStatusArgs(ctx, []string{"--watch", "--foo"}, noop)).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("applyWorkflowFlag", func() {
+	ctx := context.Background()
+
+	It("keeps the configured workflow without the flag", func() {
+		cfg := config.Defaults()
+		sources := config.FieldSources{Workflow: "project"}
+		Expect(applyWorkflowFlag(ctx, &cfg, &sources, "")).To(Succeed())
+		Expect(cfg.Workflow).To(Equal(config.Defaults().Workflow))
+		Expect(sources.Workflow).To(Equal("project"))
+	})
+
+	It("selects the workflow and records the arg source", func() {
+		cfg := config.Defaults()
+		var sources config.FieldSources
+		Expect(applyWorkflowFlag(ctx, &cfg, &sources, "pr")).To(Succeed())
+		Expect(cfg.Workflow).To(Equal(config.WorkflowClone))
+		Expect(cfg.PR).To(BeTrue())
+		Expect(sources.Workflow).To(Equal("arg"))
+	})
+
+	It("returns an error for an unknown workflow", func() {
+		cfg := config.Defaults()
+		var sources config.FieldSources
+		err := applyWorkflowFlag(ctx, &cfg, &sources, "yolo")
+		Expect(err).To(MatchError(ContainSubstring("invalid --workflow")))
+	})
+})
+
 var _ = Describe("validateOneArg", func() {
 	ctx := context.Background()
 	noop := func() {}
@@ -61,6 +61,18 @@ type Brancher struct {
 		result1 string
 		result2 error
 	}
+	DeleteBranchStub        func(context.Context, string) error
+	deleteBranchMutex       sync.RWMutex
+	deleteBranchArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteBranchReturns struct {
+		result1 error
+	}
+	deleteBranchReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DiscardUncommittedInPathsStub        func(context.Context, []string) error
 	discardUncommittedInPathsMutex       sync.RWMutex
 	discardUncommittedInPathsArgsForCall []struct {
@@ -452,6 +464,68 @@ func (fake *Brancher) DefaultBranchReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
+func (fake *Brancher) DeleteBranch(arg1 context.Context, arg2 string) error {
+	fake.deleteBranchMutex.Lock()
+	ret, specificReturn := fake.deleteBranchReturnsOnCall[len(fake.deleteBranchArgsForCall)]
+	fake.deleteBranchArgsForCall = append(fake.deleteBranchArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteBranchStub
+	fakeReturns := fake.deleteBranchReturns
+	fake.recordInvocation("DeleteBranch", []interface{}{arg1, arg2})
+	fake.deleteBranchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Brancher) DeleteBranchCallCount() int {
+	fake.deleteBranchMutex.RLock()
+	defer fake.deleteBranchMutex.RUnlock()
+	return len(fake.deleteBranchArgsForCall)
+}
+
+func (fake *Brancher) DeleteBranchCalls(stub func(context.Context, string) error) {
+	fake.deleteBranchMutex.Lock()
+	defer fake.deleteBranchMutex.Unlock()
+	fake.DeleteBranchStub = stub
+}
+
+func (fake *Brancher) DeleteBranchArgsForCall(i int) (context.Context, string) {
+	fake.deleteBranchMutex.RLock()
+	defer fake.deleteBranchMutex.RUnlock()
+	argsForCall := fake.deleteBranchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Brancher) DeleteBranchReturns(result1 error) {
+	fake.deleteBranchMutex.Lock()
+	defer fake.deleteBranchMutex.Unlock()
+	fake.DeleteBranchStub = nil
+	fake.deleteBranchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Brancher) DeleteBranchReturnsOnCall(i int, result1 error) {
+	fake.deleteBranchMutex.Lock()
+	defer fake.deleteBranchMutex.Unlock()
+	fake.DeleteBranchStub = nil
+	if fake.deleteBranchReturnsOnCall == nil {
+		fake.deleteBranchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteBranchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Brancher) DiscardUncommittedInPaths(arg1 context.Context, arg2 []string) error {
 	var arg2Copy []string
 	if arg2 != nil {
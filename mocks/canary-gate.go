@@ -0,0 +1,79 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/dark-factory/pkg/canary"
+)
+
+type CanaryGate struct {
+	ObserveStub        func(context.Context, string, error)
+	observeMutex       sync.RWMutex
+	observeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CanaryGate) Observe(arg1 context.Context, arg2 string, arg3 error) {
+	fake.observeMutex.Lock()
+	fake.observeArgsForCall = append(fake.observeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 error
+	}{arg1, arg2, arg3})
+	stub := fake.ObserveStub
+	fake.recordInvocation("Observe", []interface{}{arg1, arg2, arg3})
+	fake.observeMutex.Unlock()
+	if stub != nil {
+		fake.ObserveStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *CanaryGate) ObserveCallCount() int {
+	fake.observeMutex.RLock()
+	defer fake.observeMutex.RUnlock()
+	return len(fake.observeArgsForCall)
+}
+
+func (fake *CanaryGate) ObserveCalls(stub func(context.Context, string, error)) {
+	fake.observeMutex.Lock()
+	defer fake.observeMutex.Unlock()
+	fake.ObserveStub = stub
+}
+
+func (fake *CanaryGate) ObserveArgsForCall(i int) (context.Context, string, error) {
+	fake.observeMutex.RLock()
+	defer fake.observeMutex.RUnlock()
+	argsForCall := fake.observeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CanaryGate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CanaryGate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ canary.Gate = new(CanaryGate)
@@ -10,6 +10,49 @@ import (
 )
 
 type CmdPromptManager struct {
+	EnqueueStub        func(context.Context, string, string) (string, error)
+	enqueueMutex       sync.RWMutex
+	enqueueArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	enqueueReturns struct {
+		result1 string
+		result2 error
+	}
+	enqueueReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	EnqueueBatchStub        func(context.Context, []prompt.BatchEntry) ([]string, error)
+	enqueueBatchMutex       sync.RWMutex
+	enqueueBatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 []prompt.BatchEntry
+	}
+	enqueueBatchReturns struct {
+		result1 []string
+		result2 error
+	}
+	enqueueBatchReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ListQueuedByTagStub        func(context.Context, string) ([]prompt.Prompt, error)
+	listQueuedByTagMutex       sync.RWMutex
+	listQueuedByTagArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	listQueuedByTagReturns struct {
+		result1 []prompt.Prompt
+		result2 error
+	}
+	listQueuedByTagReturnsOnCall map[int]struct {
+		result1 []prompt.Prompt
+		result2 error
+	}
 	LoadStub        func(context.Context, string) (*prompt.PromptFile, error)
 	loadMutex       sync.RWMutex
 	loadArgsForCall []struct {
@@ -62,10 +105,261 @@ type CmdPromptManager struct {
 		result1 []prompt.Rename
 		result2 error
 	}
+	ReconcileStub        func(context.Context) ([]prompt.ReconcileChange, error)
+	reconcileMutex       sync.RWMutex
+	reconcileArgsForCall []struct {
+		arg1 context.Context
+	}
+	reconcileReturns struct {
+		result1 []prompt.ReconcileChange
+		result2 error
+	}
+	reconcileReturnsOnCall map[int]struct {
+		result1 []prompt.ReconcileChange
+		result2 error
+	}
+	RemoveStub        func(context.Context, string) error
+	removeMutex       sync.RWMutex
+	removeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	removeReturns struct {
+		result1 error
+	}
+	removeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReorderStub        func(context.Context) ([]prompt.Rename, error)
+	reorderMutex       sync.RWMutex
+	reorderArgsForCall []struct {
+		arg1 context.Context
+	}
+	reorderReturns struct {
+		result1 []prompt.Rename
+		result2 error
+	}
+	reorderReturnsOnCall map[int]struct {
+		result1 []prompt.Rename
+		result2 error
+	}
+	TouchStub        func(context.Context, string) error
+	touchMutex       sync.RWMutex
+	touchArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	touchReturns struct {
+		result1 error
+	}
+	touchReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CmdPromptManager) Enqueue(arg1 context.Context, arg2 string, arg3 string) (string, error) {
+	fake.enqueueMutex.Lock()
+	ret, specificReturn := fake.enqueueReturnsOnCall[len(fake.enqueueArgsForCall)]
+	fake.enqueueArgsForCall = append(fake.enqueueArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.EnqueueStub
+	fakeReturns := fake.enqueueReturns
+	fake.recordInvocation("Enqueue", []interface{}{arg1, arg2, arg3})
+	fake.enqueueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CmdPromptManager) EnqueueCallCount() int {
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	return len(fake.enqueueArgsForCall)
+}
+
+func (fake *CmdPromptManager) EnqueueCalls(stub func(context.Context, string, string) (string, error)) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = stub
+}
+
+func (fake *CmdPromptManager) EnqueueArgsForCall(i int) (context.Context, string, string) {
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	argsForCall := fake.enqueueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CmdPromptManager) EnqueueReturns(result1 string, result2 error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = nil
+	fake.enqueueReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) EnqueueReturnsOnCall(i int, result1 string, result2 error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = nil
+	if fake.enqueueReturnsOnCall == nil {
+		fake.enqueueReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.enqueueReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) EnqueueBatch(arg1 context.Context, arg2 []prompt.BatchEntry) ([]string, error) {
+	var arg2Copy []prompt.BatchEntry
+	if arg2 != nil {
+		arg2Copy = make([]prompt.BatchEntry, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.enqueueBatchMutex.Lock()
+	ret, specificReturn := fake.enqueueBatchReturnsOnCall[len(fake.enqueueBatchArgsForCall)]
+	fake.enqueueBatchArgsForCall = append(fake.enqueueBatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 []prompt.BatchEntry
+	}{arg1, arg2Copy})
+	stub := fake.EnqueueBatchStub
+	fakeReturns := fake.enqueueBatchReturns
+	fake.recordInvocation("EnqueueBatch", []interface{}{arg1, arg2Copy})
+	fake.enqueueBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CmdPromptManager) EnqueueBatchCallCount() int {
+	fake.enqueueBatchMutex.RLock()
+	defer fake.enqueueBatchMutex.RUnlock()
+	return len(fake.enqueueBatchArgsForCall)
+}
+
+func (fake *CmdPromptManager) EnqueueBatchCalls(stub func(context.Context, []prompt.BatchEntry) ([]string, error)) {
+	fake.enqueueBatchMutex.Lock()
+	defer fake.enqueueBatchMutex.Unlock()
+	fake.EnqueueBatchStub = stub
+}
+
+func (fake *CmdPromptManager) EnqueueBatchArgsForCall(i int) (context.Context, []prompt.BatchEntry) {
+	fake.enqueueBatchMutex.RLock()
+	defer fake.enqueueBatchMutex.RUnlock()
+	argsForCall := fake.enqueueBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CmdPromptManager) EnqueueBatchReturns(result1 []string, result2 error) {
+	fake.enqueueBatchMutex.Lock()
+	defer fake.enqueueBatchMutex.Unlock()
+	fake.EnqueueBatchStub = nil
+	fake.enqueueBatchReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) EnqueueBatchReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.enqueueBatchMutex.Lock()
+	defer fake.enqueueBatchMutex.Unlock()
+	fake.EnqueueBatchStub = nil
+	if fake.enqueueBatchReturnsOnCall == nil {
+		fake.enqueueBatchReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.enqueueBatchReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) ListQueuedByTag(arg1 context.Context, arg2 string) ([]prompt.Prompt, error) {
+	fake.listQueuedByTagMutex.Lock()
+	ret, specificReturn := fake.listQueuedByTagReturnsOnCall[len(fake.listQueuedByTagArgsForCall)]
+	fake.listQueuedByTagArgsForCall = append(fake.listQueuedByTagArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListQueuedByTagStub
+	fakeReturns := fake.listQueuedByTagReturns
+	fake.recordInvocation("ListQueuedByTag", []interface{}{arg1, arg2})
+	fake.listQueuedByTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CmdPromptManager) ListQueuedByTagCallCount() int {
+	fake.listQueuedByTagMutex.RLock()
+	defer fake.listQueuedByTagMutex.RUnlock()
+	return len(fake.listQueuedByTagArgsForCall)
+}
+
+func (fake *CmdPromptManager) ListQueuedByTagCalls(stub func(context.Context, string) ([]prompt.Prompt, error)) {
+	fake.listQueuedByTagMutex.Lock()
+	defer fake.listQueuedByTagMutex.Unlock()
+	fake.ListQueuedByTagStub = stub
+}
+
+func (fake *CmdPromptManager) ListQueuedByTagArgsForCall(i int) (context.Context, string) {
+	fake.listQueuedByTagMutex.RLock()
+	defer fake.listQueuedByTagMutex.RUnlock()
+	argsForCall := fake.listQueuedByTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CmdPromptManager) ListQueuedByTagReturns(result1 []prompt.Prompt, result2 error) {
+	fake.listQueuedByTagMutex.Lock()
+	defer fake.listQueuedByTagMutex.Unlock()
+	fake.ListQueuedByTagStub = nil
+	fake.listQueuedByTagReturns = struct {
+		result1 []prompt.Prompt
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) ListQueuedByTagReturnsOnCall(i int, result1 []prompt.Prompt, result2 error) {
+	fake.listQueuedByTagMutex.Lock()
+	defer fake.listQueuedByTagMutex.Unlock()
+	fake.ListQueuedByTagStub = nil
+	if fake.listQueuedByTagReturnsOnCall == nil {
+		fake.listQueuedByTagReturnsOnCall = make(map[int]struct {
+			result1 []prompt.Prompt
+			result2 error
+		})
+	}
+	fake.listQueuedByTagReturnsOnCall[i] = struct {
+		result1 []prompt.Prompt
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CmdPromptManager) Load(arg1 context.Context, arg2 string) (*prompt.PromptFile, error) {
 	fake.loadMutex.Lock()
 	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
@@ -320,6 +614,258 @@ func (fake *CmdPromptManager) NormalizeFilenamesReturnsOnCall(i int, result1 []p
 	}{result1, result2}
 }
 
+func (fake *CmdPromptManager) Reconcile(arg1 context.Context) ([]prompt.ReconcileChange, error) {
+	fake.reconcileMutex.Lock()
+	ret, specificReturn := fake.reconcileReturnsOnCall[len(fake.reconcileArgsForCall)]
+	fake.reconcileArgsForCall = append(fake.reconcileArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ReconcileStub
+	fakeReturns := fake.reconcileReturns
+	fake.recordInvocation("Reconcile", []interface{}{arg1})
+	fake.reconcileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CmdPromptManager) ReconcileCallCount() int {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return len(fake.reconcileArgsForCall)
+}
+
+func (fake *CmdPromptManager) ReconcileCalls(stub func(context.Context) ([]prompt.ReconcileChange, error)) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = stub
+}
+
+func (fake *CmdPromptManager) ReconcileArgsForCall(i int) context.Context {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	argsForCall := fake.reconcileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *CmdPromptManager) ReconcileReturns(result1 []prompt.ReconcileChange, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	fake.reconcileReturns = struct {
+		result1 []prompt.ReconcileChange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) ReconcileReturnsOnCall(i int, result1 []prompt.ReconcileChange, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	if fake.reconcileReturnsOnCall == nil {
+		fake.reconcileReturnsOnCall = make(map[int]struct {
+			result1 []prompt.ReconcileChange
+			result2 error
+		})
+	}
+	fake.reconcileReturnsOnCall[i] = struct {
+		result1 []prompt.ReconcileChange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) Remove(arg1 context.Context, arg2 string) error {
+	fake.removeMutex.Lock()
+	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
+	fake.removeArgsForCall = append(fake.removeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RemoveStub
+	fakeReturns := fake.removeReturns
+	fake.recordInvocation("Remove", []interface{}{arg1, arg2})
+	fake.removeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CmdPromptManager) RemoveCallCount() int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return len(fake.removeArgsForCall)
+}
+
+func (fake *CmdPromptManager) RemoveCalls(stub func(context.Context, string) error) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = stub
+}
+
+func (fake *CmdPromptManager) RemoveArgsForCall(i int) (context.Context, string) {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	argsForCall := fake.removeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CmdPromptManager) RemoveReturns(result1 error) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = nil
+	fake.removeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CmdPromptManager) RemoveReturnsOnCall(i int, result1 error) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = nil
+	if fake.removeReturnsOnCall == nil {
+		fake.removeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CmdPromptManager) Reorder(arg1 context.Context) ([]prompt.Rename, error) {
+	fake.reorderMutex.Lock()
+	ret, specificReturn := fake.reorderReturnsOnCall[len(fake.reorderArgsForCall)]
+	fake.reorderArgsForCall = append(fake.reorderArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ReorderStub
+	fakeReturns := fake.reorderReturns
+	fake.recordInvocation("Reorder", []interface{}{arg1})
+	fake.reorderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CmdPromptManager) ReorderCallCount() int {
+	fake.reorderMutex.RLock()
+	defer fake.reorderMutex.RUnlock()
+	return len(fake.reorderArgsForCall)
+}
+
+func (fake *CmdPromptManager) ReorderCalls(stub func(context.Context) ([]prompt.Rename, error)) {
+	fake.reorderMutex.Lock()
+	defer fake.reorderMutex.Unlock()
+	fake.ReorderStub = stub
+}
+
+func (fake *CmdPromptManager) ReorderArgsForCall(i int) context.Context {
+	fake.reorderMutex.RLock()
+	defer fake.reorderMutex.RUnlock()
+	argsForCall := fake.reorderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *CmdPromptManager) ReorderReturns(result1 []prompt.Rename, result2 error) {
+	fake.reorderMutex.Lock()
+	defer fake.reorderMutex.Unlock()
+	fake.ReorderStub = nil
+	fake.reorderReturns = struct {
+		result1 []prompt.Rename
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) ReorderReturnsOnCall(i int, result1 []prompt.Rename, result2 error) {
+	fake.reorderMutex.Lock()
+	defer fake.reorderMutex.Unlock()
+	fake.ReorderStub = nil
+	if fake.reorderReturnsOnCall == nil {
+		fake.reorderReturnsOnCall = make(map[int]struct {
+			result1 []prompt.Rename
+			result2 error
+		})
+	}
+	fake.reorderReturnsOnCall[i] = struct {
+		result1 []prompt.Rename
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CmdPromptManager) Touch(arg1 context.Context, arg2 string) error {
+	fake.touchMutex.Lock()
+	ret, specificReturn := fake.touchReturnsOnCall[len(fake.touchArgsForCall)]
+	fake.touchArgsForCall = append(fake.touchArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.TouchStub
+	fakeReturns := fake.touchReturns
+	fake.recordInvocation("Touch", []interface{}{arg1, arg2})
+	fake.touchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CmdPromptManager) TouchCallCount() int {
+	fake.touchMutex.RLock()
+	defer fake.touchMutex.RUnlock()
+	return len(fake.touchArgsForCall)
+}
+
+func (fake *CmdPromptManager) TouchCalls(stub func(context.Context, string) error) {
+	fake.touchMutex.Lock()
+	defer fake.touchMutex.Unlock()
+	fake.TouchStub = stub
+}
+
+func (fake *CmdPromptManager) TouchArgsForCall(i int) (context.Context, string) {
+	fake.touchMutex.RLock()
+	defer fake.touchMutex.RUnlock()
+	argsForCall := fake.touchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CmdPromptManager) TouchReturns(result1 error) {
+	fake.touchMutex.Lock()
+	defer fake.touchMutex.Unlock()
+	fake.TouchStub = nil
+	fake.touchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CmdPromptManager) TouchReturnsOnCall(i int, result1 error) {
+	fake.touchMutex.Lock()
+	defer fake.touchMutex.Unlock()
+	fake.TouchStub = nil
+	if fake.touchReturnsOnCall == nil {
+		fake.touchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.touchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CmdPromptManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
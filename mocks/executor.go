@@ -44,6 +44,17 @@ type Executor struct {
 		arg1 context.Context
 		arg2 string
 	}
+	TailLinesStub        func(int) []string
+	tailLinesMutex       sync.RWMutex
+	tailLinesArgsForCall []struct {
+		arg1 int
+	}
+	tailLinesReturns struct {
+		result1 []string
+	}
+	tailLinesReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -209,6 +220,67 @@ func (fake *Executor) StopAndRemoveContainerArgsForCall(i int) (context.Context,
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *Executor) TailLines(arg1 int) []string {
+	fake.tailLinesMutex.Lock()
+	ret, specificReturn := fake.tailLinesReturnsOnCall[len(fake.tailLinesArgsForCall)]
+	fake.tailLinesArgsForCall = append(fake.tailLinesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.TailLinesStub
+	fakeReturns := fake.tailLinesReturns
+	fake.recordInvocation("TailLines", []interface{}{arg1})
+	fake.tailLinesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Executor) TailLinesCallCount() int {
+	fake.tailLinesMutex.RLock()
+	defer fake.tailLinesMutex.RUnlock()
+	return len(fake.tailLinesArgsForCall)
+}
+
+func (fake *Executor) TailLinesCalls(stub func(int) []string) {
+	fake.tailLinesMutex.Lock()
+	defer fake.tailLinesMutex.Unlock()
+	fake.TailLinesStub = stub
+}
+
+func (fake *Executor) TailLinesArgsForCall(i int) int {
+	fake.tailLinesMutex.RLock()
+	defer fake.tailLinesMutex.RUnlock()
+	argsForCall := fake.tailLinesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Executor) TailLinesReturns(result1 []string) {
+	fake.tailLinesMutex.Lock()
+	defer fake.tailLinesMutex.Unlock()
+	fake.TailLinesStub = nil
+	fake.tailLinesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *Executor) TailLinesReturnsOnCall(i int, result1 []string) {
+	fake.tailLinesMutex.Lock()
+	defer fake.tailLinesMutex.Unlock()
+	fake.TailLinesStub = nil
+	if fake.tailLinesReturnsOnCall == nil {
+		fake.tailLinesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.tailLinesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *Executor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+type IdempotencyKeyFinder struct {
+	FindByIdempotencyKeyStub        func(context.Context, string) (string, error)
+	findByIdempotencyKeyMutex       sync.RWMutex
+	findByIdempotencyKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	findByIdempotencyKeyReturns struct {
+		result1 string
+		result2 error
+	}
+	findByIdempotencyKeyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *IdempotencyKeyFinder) FindByIdempotencyKey(arg1 context.Context, arg2 string) (string, error) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	ret, specificReturn := fake.findByIdempotencyKeyReturnsOnCall[len(fake.findByIdempotencyKeyArgsForCall)]
+	fake.findByIdempotencyKeyArgsForCall = append(fake.findByIdempotencyKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FindByIdempotencyKeyStub
+	fakeReturns := fake.findByIdempotencyKeyReturns
+	fake.recordInvocation("FindByIdempotencyKey", []interface{}{arg1, arg2})
+	fake.findByIdempotencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IdempotencyKeyFinder) FindByIdempotencyKeyCallCount() int {
+	fake.findByIdempotencyKeyMutex.RLock()
+	defer fake.findByIdempotencyKeyMutex.RUnlock()
+	return len(fake.findByIdempotencyKeyArgsForCall)
+}
+
+func (fake *IdempotencyKeyFinder) FindByIdempotencyKeyCalls(stub func(context.Context, string) (string, error)) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	defer fake.findByIdempotencyKeyMutex.Unlock()
+	fake.FindByIdempotencyKeyStub = stub
+}
+
+func (fake *IdempotencyKeyFinder) FindByIdempotencyKeyArgsForCall(i int) (context.Context, string) {
+	fake.findByIdempotencyKeyMutex.RLock()
+	defer fake.findByIdempotencyKeyMutex.RUnlock()
+	argsForCall := fake.findByIdempotencyKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *IdempotencyKeyFinder) FindByIdempotencyKeyReturns(result1 string, result2 error) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	defer fake.findByIdempotencyKeyMutex.Unlock()
+	fake.FindByIdempotencyKeyStub = nil
+	fake.findByIdempotencyKeyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdempotencyKeyFinder) FindByIdempotencyKeyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	defer fake.findByIdempotencyKeyMutex.Unlock()
+	fake.FindByIdempotencyKeyStub = nil
+	if fake.findByIdempotencyKeyReturnsOnCall == nil {
+		fake.findByIdempotencyKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.findByIdempotencyKeyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IdempotencyKeyFinder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *IdempotencyKeyFinder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ prompt.IdempotencyKeyFinder = new(IdempotencyKeyFinder)
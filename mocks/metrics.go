@@ -0,0 +1,103 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bborbe/dark-factory/pkg/metrics"
+)
+
+type Metrics struct {
+	PromptCompletedStub        func(time.Duration)
+	promptCompletedMutex       sync.RWMutex
+	promptCompletedArgsForCall []struct {
+		arg1 time.Duration
+	}
+	PromptFailedStub        func()
+	promptFailedMutex       sync.RWMutex
+	promptFailedArgsForCall []struct {
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Metrics) PromptCompleted(arg1 time.Duration) {
+	fake.promptCompletedMutex.Lock()
+	fake.promptCompletedArgsForCall = append(fake.promptCompletedArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.PromptCompletedStub
+	fake.recordInvocation("PromptCompleted", []interface{}{arg1})
+	fake.promptCompletedMutex.Unlock()
+	if stub != nil {
+		fake.PromptCompletedStub(arg1)
+	}
+}
+
+func (fake *Metrics) PromptCompletedCallCount() int {
+	fake.promptCompletedMutex.RLock()
+	defer fake.promptCompletedMutex.RUnlock()
+	return len(fake.promptCompletedArgsForCall)
+}
+
+func (fake *Metrics) PromptCompletedCalls(stub func(time.Duration)) {
+	fake.promptCompletedMutex.Lock()
+	defer fake.promptCompletedMutex.Unlock()
+	fake.PromptCompletedStub = stub
+}
+
+func (fake *Metrics) PromptCompletedArgsForCall(i int) time.Duration {
+	fake.promptCompletedMutex.RLock()
+	defer fake.promptCompletedMutex.RUnlock()
+	argsForCall := fake.promptCompletedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Metrics) PromptFailed() {
+	fake.promptFailedMutex.Lock()
+	fake.promptFailedArgsForCall = append(fake.promptFailedArgsForCall, struct {
+	}{})
+	stub := fake.PromptFailedStub
+	fake.recordInvocation("PromptFailed", []interface{}{})
+	fake.promptFailedMutex.Unlock()
+	if stub != nil {
+		fake.PromptFailedStub()
+	}
+}
+
+func (fake *Metrics) PromptFailedCallCount() int {
+	fake.promptFailedMutex.RLock()
+	defer fake.promptFailedMutex.RUnlock()
+	return len(fake.promptFailedArgsForCall)
+}
+
+func (fake *Metrics) PromptFailedCalls(stub func()) {
+	fake.promptFailedMutex.Lock()
+	defer fake.promptFailedMutex.Unlock()
+	fake.PromptFailedStub = stub
+}
+
+func (fake *Metrics) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Metrics) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ metrics.Metrics = new(Metrics)
@@ -127,6 +127,18 @@ type ProcessorPromptManager struct {
 		result1 *prompt.PromptFile
 		result2 error
 	}
+	MissingDependenciesStub        func(context.Context, []string) []string
+	missingDependenciesMutex       sync.RWMutex
+	missingDependenciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	missingDependenciesReturns struct {
+		result1 []string
+	}
+	missingDependenciesReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	MoveToCancelledStub        func(context.Context, string) error
 	moveToCancelledMutex       sync.RWMutex
 	moveToCancelledArgsForCall []struct {
@@ -151,6 +163,19 @@ type ProcessorPromptManager struct {
 	moveToCompletedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PruneCompletedStub        func(context.Context) ([]string, error)
+	pruneCompletedMutex       sync.RWMutex
+	pruneCompletedArgsForCall []struct {
+		arg1 context.Context
+	}
+	pruneCompletedReturns struct {
+		result1 []string
+		result2 error
+	}
+	pruneCompletedReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	RollbackMoveToCompletedStub        func(context.Context, string, prompt.FileMover) error
 	rollbackMoveToCompletedMutex       sync.RWMutex
 	rollbackMoveToCompletedArgsForCall []struct {
@@ -765,6 +790,73 @@ func (fake *ProcessorPromptManager) LoadReturnsOnCall(i int, result1 *prompt.Pro
 	}{result1, result2}
 }
 
+func (fake *ProcessorPromptManager) MissingDependencies(arg1 context.Context, arg2 []string) []string {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.missingDependenciesMutex.Lock()
+	ret, specificReturn := fake.missingDependenciesReturnsOnCall[len(fake.missingDependenciesArgsForCall)]
+	fake.missingDependenciesArgsForCall = append(fake.missingDependenciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.MissingDependenciesStub
+	fakeReturns := fake.missingDependenciesReturns
+	fake.recordInvocation("MissingDependencies", []interface{}{arg1, arg2Copy})
+	fake.missingDependenciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ProcessorPromptManager) MissingDependenciesCallCount() int {
+	fake.missingDependenciesMutex.RLock()
+	defer fake.missingDependenciesMutex.RUnlock()
+	return len(fake.missingDependenciesArgsForCall)
+}
+
+func (fake *ProcessorPromptManager) MissingDependenciesCalls(stub func(context.Context, []string) []string) {
+	fake.missingDependenciesMutex.Lock()
+	defer fake.missingDependenciesMutex.Unlock()
+	fake.MissingDependenciesStub = stub
+}
+
+func (fake *ProcessorPromptManager) MissingDependenciesArgsForCall(i int) (context.Context, []string) {
+	fake.missingDependenciesMutex.RLock()
+	defer fake.missingDependenciesMutex.RUnlock()
+	argsForCall := fake.missingDependenciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ProcessorPromptManager) MissingDependenciesReturns(result1 []string) {
+	fake.missingDependenciesMutex.Lock()
+	defer fake.missingDependenciesMutex.Unlock()
+	fake.MissingDependenciesStub = nil
+	fake.missingDependenciesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *ProcessorPromptManager) MissingDependenciesReturnsOnCall(i int, result1 []string) {
+	fake.missingDependenciesMutex.Lock()
+	defer fake.missingDependenciesMutex.Unlock()
+	fake.MissingDependenciesStub = nil
+	if fake.missingDependenciesReturnsOnCall == nil {
+		fake.missingDependenciesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.missingDependenciesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *ProcessorPromptManager) MoveToCancelled(arg1 context.Context, arg2 string) error {
 	fake.moveToCancelledMutex.Lock()
 	ret, specificReturn := fake.moveToCancelledReturnsOnCall[len(fake.moveToCancelledArgsForCall)]
@@ -889,6 +981,70 @@ func (fake *ProcessorPromptManager) MoveToCompletedReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *ProcessorPromptManager) PruneCompleted(arg1 context.Context) ([]string, error) {
+	fake.pruneCompletedMutex.Lock()
+	ret, specificReturn := fake.pruneCompletedReturnsOnCall[len(fake.pruneCompletedArgsForCall)]
+	fake.pruneCompletedArgsForCall = append(fake.pruneCompletedArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.PruneCompletedStub
+	fakeReturns := fake.pruneCompletedReturns
+	fake.recordInvocation("PruneCompleted", []interface{}{arg1})
+	fake.pruneCompletedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ProcessorPromptManager) PruneCompletedCallCount() int {
+	fake.pruneCompletedMutex.RLock()
+	defer fake.pruneCompletedMutex.RUnlock()
+	return len(fake.pruneCompletedArgsForCall)
+}
+
+func (fake *ProcessorPromptManager) PruneCompletedCalls(stub func(context.Context) ([]string, error)) {
+	fake.pruneCompletedMutex.Lock()
+	defer fake.pruneCompletedMutex.Unlock()
+	fake.PruneCompletedStub = stub
+}
+
+func (fake *ProcessorPromptManager) PruneCompletedArgsForCall(i int) context.Context {
+	fake.pruneCompletedMutex.RLock()
+	defer fake.pruneCompletedMutex.RUnlock()
+	argsForCall := fake.pruneCompletedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ProcessorPromptManager) PruneCompletedReturns(result1 []string, result2 error) {
+	fake.pruneCompletedMutex.Lock()
+	defer fake.pruneCompletedMutex.Unlock()
+	fake.PruneCompletedStub = nil
+	fake.pruneCompletedReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProcessorPromptManager) PruneCompletedReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.pruneCompletedMutex.Lock()
+	defer fake.pruneCompletedMutex.Unlock()
+	fake.PruneCompletedStub = nil
+	if fake.pruneCompletedReturnsOnCall == nil {
+		fake.pruneCompletedReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.pruneCompletedReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ProcessorPromptManager) RollbackMoveToCompleted(arg1 context.Context, arg2 string, arg3 prompt.FileMover) error {
 	fake.rollbackMoveToCompletedMutex.Lock()
 	ret, specificReturn := fake.rollbackMoveToCompletedReturnsOnCall[len(fake.rollbackMoveToCompletedArgsForCall)]
@@ -99,6 +99,18 @@ type QueueScannerPromptManager struct {
 		result1 *prompt.PromptFile
 		result2 error
 	}
+	MissingDependenciesStub        func(context.Context, []string) []string
+	missingDependenciesMutex       sync.RWMutex
+	missingDependenciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	missingDependenciesReturns struct {
+		result1 []string
+	}
+	missingDependenciesReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	SetStatusStub        func(context.Context, string, string) error
 	setStatusMutex       sync.RWMutex
 	setStatusArgsForCall []struct {
@@ -557,6 +569,73 @@ func (fake *QueueScannerPromptManager) LoadReturnsOnCall(i int, result1 *prompt.
 	}{result1, result2}
 }
 
+func (fake *QueueScannerPromptManager) MissingDependencies(arg1 context.Context, arg2 []string) []string {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.missingDependenciesMutex.Lock()
+	ret, specificReturn := fake.missingDependenciesReturnsOnCall[len(fake.missingDependenciesArgsForCall)]
+	fake.missingDependenciesArgsForCall = append(fake.missingDependenciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.MissingDependenciesStub
+	fakeReturns := fake.missingDependenciesReturns
+	fake.recordInvocation("MissingDependencies", []interface{}{arg1, arg2Copy})
+	fake.missingDependenciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QueueScannerPromptManager) MissingDependenciesCallCount() int {
+	fake.missingDependenciesMutex.RLock()
+	defer fake.missingDependenciesMutex.RUnlock()
+	return len(fake.missingDependenciesArgsForCall)
+}
+
+func (fake *QueueScannerPromptManager) MissingDependenciesCalls(stub func(context.Context, []string) []string) {
+	fake.missingDependenciesMutex.Lock()
+	defer fake.missingDependenciesMutex.Unlock()
+	fake.MissingDependenciesStub = stub
+}
+
+func (fake *QueueScannerPromptManager) MissingDependenciesArgsForCall(i int) (context.Context, []string) {
+	fake.missingDependenciesMutex.RLock()
+	defer fake.missingDependenciesMutex.RUnlock()
+	argsForCall := fake.missingDependenciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *QueueScannerPromptManager) MissingDependenciesReturns(result1 []string) {
+	fake.missingDependenciesMutex.Lock()
+	defer fake.missingDependenciesMutex.Unlock()
+	fake.MissingDependenciesStub = nil
+	fake.missingDependenciesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *QueueScannerPromptManager) MissingDependenciesReturnsOnCall(i int, result1 []string) {
+	fake.missingDependenciesMutex.Lock()
+	defer fake.missingDependenciesMutex.Unlock()
+	fake.MissingDependenciesStub = nil
+	if fake.missingDependenciesReturnsOnCall == nil {
+		fake.missingDependenciesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.missingDependenciesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *QueueScannerPromptManager) SetStatus(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.setStatusMutex.Lock()
 	ret, specificReturn := fake.setStatusReturnsOnCall[len(fake.setStatusArgsForCall)]
@@ -21,11 +21,12 @@ type Releaser struct {
 	commitAndReleaseReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CommitCompletedFileStub        func(context.Context, string) error
+	CommitCompletedFileStub        func(context.Context, string, string) error
 	commitCompletedFileMutex       sync.RWMutex
 	commitCompletedFileArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	commitCompletedFileReturns struct {
 		result1 error
@@ -45,6 +46,19 @@ type Releaser struct {
 	commitOnlyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CommitPathsStub        func(context.Context, string, ...string) error
+	commitPathsMutex       sync.RWMutex
+	commitPathsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}
+	commitPathsReturns struct {
+		result1 error
+	}
+	commitPathsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CommitWithRetryStub        func(context.Context, func(context.Context) error) error
 	commitWithRetryMutex       sync.RWMutex
 	commitWithRetryArgsForCall []struct {
@@ -93,6 +107,19 @@ type Releaser struct {
 	hasChangelogReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	HeadCommitStub        func(context.Context) (string, error)
+	headCommitMutex       sync.RWMutex
+	headCommitArgsForCall []struct {
+		arg1 context.Context
+	}
+	headCommitReturns struct {
+		result1 string
+		result2 error
+	}
+	headCommitReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	MoveFileStub        func(context.Context, string, string) error
 	moveFileMutex       sync.RWMutex
 	moveFileArgsForCall []struct {
@@ -183,19 +210,20 @@ func (fake *Releaser) CommitAndReleaseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *Releaser) CommitCompletedFile(arg1 context.Context, arg2 string) error {
+func (fake *Releaser) CommitCompletedFile(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.commitCompletedFileMutex.Lock()
 	ret, specificReturn := fake.commitCompletedFileReturnsOnCall[len(fake.commitCompletedFileArgsForCall)]
 	fake.commitCompletedFileArgsForCall = append(fake.commitCompletedFileArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.CommitCompletedFileStub
 	fakeReturns := fake.commitCompletedFileReturns
-	fake.recordInvocation("CommitCompletedFile", []interface{}{arg1, arg2})
+	fake.recordInvocation("CommitCompletedFile", []interface{}{arg1, arg2, arg3})
 	fake.commitCompletedFileMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -209,17 +237,17 @@ func (fake *Releaser) CommitCompletedFileCallCount() int {
 	return len(fake.commitCompletedFileArgsForCall)
 }
 
-func (fake *Releaser) CommitCompletedFileCalls(stub func(context.Context, string) error) {
+func (fake *Releaser) CommitCompletedFileCalls(stub func(context.Context, string, string) error) {
 	fake.commitCompletedFileMutex.Lock()
 	defer fake.commitCompletedFileMutex.Unlock()
 	fake.CommitCompletedFileStub = stub
 }
 
-func (fake *Releaser) CommitCompletedFileArgsForCall(i int) (context.Context, string) {
+func (fake *Releaser) CommitCompletedFileArgsForCall(i int) (context.Context, string, string) {
 	fake.commitCompletedFileMutex.RLock()
 	defer fake.commitCompletedFileMutex.RUnlock()
 	argsForCall := fake.commitCompletedFileArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *Releaser) CommitCompletedFileReturns(result1 error) {
@@ -307,6 +335,69 @@ func (fake *Releaser) CommitOnlyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Releaser) CommitPaths(arg1 context.Context, arg2 string, arg3 ...string) error {
+	fake.commitPathsMutex.Lock()
+	ret, specificReturn := fake.commitPathsReturnsOnCall[len(fake.commitPathsArgsForCall)]
+	fake.commitPathsArgsForCall = append(fake.commitPathsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3})
+	stub := fake.CommitPathsStub
+	fakeReturns := fake.commitPathsReturns
+	fake.recordInvocation("CommitPaths", []interface{}{arg1, arg2, arg3})
+	fake.commitPathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Releaser) CommitPathsCallCount() int {
+	fake.commitPathsMutex.RLock()
+	defer fake.commitPathsMutex.RUnlock()
+	return len(fake.commitPathsArgsForCall)
+}
+
+func (fake *Releaser) CommitPathsCalls(stub func(context.Context, string, ...string) error) {
+	fake.commitPathsMutex.Lock()
+	defer fake.commitPathsMutex.Unlock()
+	fake.CommitPathsStub = stub
+}
+
+func (fake *Releaser) CommitPathsArgsForCall(i int) (context.Context, string, []string) {
+	fake.commitPathsMutex.RLock()
+	defer fake.commitPathsMutex.RUnlock()
+	argsForCall := fake.commitPathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Releaser) CommitPathsReturns(result1 error) {
+	fake.commitPathsMutex.Lock()
+	defer fake.commitPathsMutex.Unlock()
+	fake.CommitPathsStub = nil
+	fake.commitPathsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Releaser) CommitPathsReturnsOnCall(i int, result1 error) {
+	fake.commitPathsMutex.Lock()
+	defer fake.commitPathsMutex.Unlock()
+	fake.CommitPathsStub = nil
+	if fake.commitPathsReturnsOnCall == nil {
+		fake.commitPathsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.commitPathsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Releaser) CommitWithRetry(arg1 context.Context, arg2 func(context.Context) error) error {
 	fake.commitWithRetryMutex.Lock()
 	ret, specificReturn := fake.commitWithRetryReturnsOnCall[len(fake.commitWithRetryArgsForCall)]
@@ -556,6 +647,70 @@ func (fake *Releaser) HasChangelogReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *Releaser) HeadCommit(arg1 context.Context) (string, error) {
+	fake.headCommitMutex.Lock()
+	ret, specificReturn := fake.headCommitReturnsOnCall[len(fake.headCommitArgsForCall)]
+	fake.headCommitArgsForCall = append(fake.headCommitArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.HeadCommitStub
+	fakeReturns := fake.headCommitReturns
+	fake.recordInvocation("HeadCommit", []interface{}{arg1})
+	fake.headCommitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Releaser) HeadCommitCallCount() int {
+	fake.headCommitMutex.RLock()
+	defer fake.headCommitMutex.RUnlock()
+	return len(fake.headCommitArgsForCall)
+}
+
+func (fake *Releaser) HeadCommitCalls(stub func(context.Context) (string, error)) {
+	fake.headCommitMutex.Lock()
+	defer fake.headCommitMutex.Unlock()
+	fake.HeadCommitStub = stub
+}
+
+func (fake *Releaser) HeadCommitArgsForCall(i int) context.Context {
+	fake.headCommitMutex.RLock()
+	defer fake.headCommitMutex.RUnlock()
+	argsForCall := fake.headCommitArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Releaser) HeadCommitReturns(result1 string, result2 error) {
+	fake.headCommitMutex.Lock()
+	defer fake.headCommitMutex.Unlock()
+	fake.HeadCommitStub = nil
+	fake.headCommitReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Releaser) HeadCommitReturnsOnCall(i int, result1 string, result2 error) {
+	fake.headCommitMutex.Lock()
+	defer fake.headCommitMutex.Unlock()
+	fake.HeadCommitStub = nil
+	if fake.headCommitReturnsOnCall == nil {
+		fake.headCommitReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.headCommitReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Releaser) MoveFile(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.moveFileMutex.Lock()
 	ret, specificReturn := fake.moveFileReturnsOnCall[len(fake.moveFileArgsForCall)]
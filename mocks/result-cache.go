@@ -0,0 +1,167 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/resultcache"
+)
+
+type ResultCache struct {
+	LookupStub        func(context.Context, string, string, executor.LaunchOverrides) (*resultcache.Entry, bool)
+	lookupMutex       sync.RWMutex
+	lookupArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 executor.LaunchOverrides
+	}
+	lookupReturns struct {
+		result1 *resultcache.Entry
+		result2 bool
+	}
+	lookupReturnsOnCall map[int]struct {
+		result1 *resultcache.Entry
+		result2 bool
+	}
+	RecordStub        func(context.Context, string, string, executor.LaunchOverrides, resultcache.Entry)
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 executor.LaunchOverrides
+		arg5 resultcache.Entry
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ResultCache) Lookup(arg1 context.Context, arg2 string, arg3 string, arg4 executor.LaunchOverrides) (*resultcache.Entry, bool) {
+	fake.lookupMutex.Lock()
+	ret, specificReturn := fake.lookupReturnsOnCall[len(fake.lookupArgsForCall)]
+	fake.lookupArgsForCall = append(fake.lookupArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 executor.LaunchOverrides
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.LookupStub
+	fakeReturns := fake.lookupReturns
+	fake.recordInvocation("Lookup", []interface{}{arg1, arg2, arg3, arg4})
+	fake.lookupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ResultCache) LookupCallCount() int {
+	fake.lookupMutex.RLock()
+	defer fake.lookupMutex.RUnlock()
+	return len(fake.lookupArgsForCall)
+}
+
+func (fake *ResultCache) LookupCalls(stub func(context.Context, string, string, executor.LaunchOverrides) (*resultcache.Entry, bool)) {
+	fake.lookupMutex.Lock()
+	defer fake.lookupMutex.Unlock()
+	fake.LookupStub = stub
+}
+
+func (fake *ResultCache) LookupArgsForCall(i int) (context.Context, string, string, executor.LaunchOverrides) {
+	fake.lookupMutex.RLock()
+	defer fake.lookupMutex.RUnlock()
+	argsForCall := fake.lookupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ResultCache) LookupReturns(result1 *resultcache.Entry, result2 bool) {
+	fake.lookupMutex.Lock()
+	defer fake.lookupMutex.Unlock()
+	fake.LookupStub = nil
+	fake.lookupReturns = struct {
+		result1 *resultcache.Entry
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *ResultCache) LookupReturnsOnCall(i int, result1 *resultcache.Entry, result2 bool) {
+	fake.lookupMutex.Lock()
+	defer fake.lookupMutex.Unlock()
+	fake.LookupStub = nil
+	if fake.lookupReturnsOnCall == nil {
+		fake.lookupReturnsOnCall = make(map[int]struct {
+			result1 *resultcache.Entry
+			result2 bool
+		})
+	}
+	fake.lookupReturnsOnCall[i] = struct {
+		result1 *resultcache.Entry
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *ResultCache) Record(arg1 context.Context, arg2 string, arg3 string, arg4 executor.LaunchOverrides, arg5 resultcache.Entry) {
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 executor.LaunchOverrides
+		arg5 resultcache.Entry
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.RecordStub
+	fake.recordInvocation("Record", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordMutex.Unlock()
+	if stub != nil {
+		fake.RecordStub(arg1, arg2, arg3, arg4, arg5)
+	}
+}
+
+func (fake *ResultCache) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *ResultCache) RecordCalls(stub func(context.Context, string, string, executor.LaunchOverrides, resultcache.Entry)) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = stub
+}
+
+func (fake *ResultCache) RecordArgsForCall(i int) (context.Context, string, string, executor.LaunchOverrides, resultcache.Entry) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	argsForCall := fake.recordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ResultCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ResultCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ resultcache.Cache = new(ResultCache)
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/dark-factory/pkg/resultfile"
+)
+
+type ResultFileReader struct {
+	ConsumeStub        func(context.Context, string) (*resultfile.Result, error)
+	consumeMutex       sync.RWMutex
+	consumeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	consumeReturns struct {
+		result1 *resultfile.Result
+		result2 error
+	}
+	consumeReturnsOnCall map[int]struct {
+		result1 *resultfile.Result
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ResultFileReader) Consume(arg1 context.Context, arg2 string) (*resultfile.Result, error) {
+	fake.consumeMutex.Lock()
+	ret, specificReturn := fake.consumeReturnsOnCall[len(fake.consumeArgsForCall)]
+	fake.consumeArgsForCall = append(fake.consumeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ConsumeStub
+	fakeReturns := fake.consumeReturns
+	fake.recordInvocation("Consume", []interface{}{arg1, arg2})
+	fake.consumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ResultFileReader) ConsumeCallCount() int {
+	fake.consumeMutex.RLock()
+	defer fake.consumeMutex.RUnlock()
+	return len(fake.consumeArgsForCall)
+}
+
+func (fake *ResultFileReader) ConsumeCalls(stub func(context.Context, string) (*resultfile.Result, error)) {
+	fake.consumeMutex.Lock()
+	defer fake.consumeMutex.Unlock()
+	fake.ConsumeStub = stub
+}
+
+func (fake *ResultFileReader) ConsumeArgsForCall(i int) (context.Context, string) {
+	fake.consumeMutex.RLock()
+	defer fake.consumeMutex.RUnlock()
+	argsForCall := fake.consumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ResultFileReader) ConsumeReturns(result1 *resultfile.Result, result2 error) {
+	fake.consumeMutex.Lock()
+	defer fake.consumeMutex.Unlock()
+	fake.ConsumeStub = nil
+	fake.consumeReturns = struct {
+		result1 *resultfile.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResultFileReader) ConsumeReturnsOnCall(i int, result1 *resultfile.Result, result2 error) {
+	fake.consumeMutex.Lock()
+	defer fake.consumeMutex.Unlock()
+	fake.ConsumeStub = nil
+	if fake.consumeReturnsOnCall == nil {
+		fake.consumeReturnsOnCall = make(map[int]struct {
+			result1 *resultfile.Result
+			result2 error
+		})
+	}
+	fake.consumeReturnsOnCall[i] = struct {
+		result1 *resultfile.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResultFileReader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ResultFileReader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ resultfile.Reader = new(ResultFileReader)
@@ -10,6 +10,20 @@ import (
 )
 
 type ServerPromptManager struct {
+	FindByIdempotencyKeyStub        func(context.Context, string) (string, error)
+	findByIdempotencyKeyMutex       sync.RWMutex
+	findByIdempotencyKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	findByIdempotencyKeyReturns struct {
+		result1 string
+		result2 error
+	}
+	findByIdempotencyKeyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	LoadStub        func(context.Context, string) (*prompt.PromptFile, error)
 	loadMutex       sync.RWMutex
 	loadArgsForCall []struct {
@@ -42,6 +56,71 @@ type ServerPromptManager struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ServerPromptManager) FindByIdempotencyKey(arg1 context.Context, arg2 string) (string, error) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	ret, specificReturn := fake.findByIdempotencyKeyReturnsOnCall[len(fake.findByIdempotencyKeyArgsForCall)]
+	fake.findByIdempotencyKeyArgsForCall = append(fake.findByIdempotencyKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FindByIdempotencyKeyStub
+	fakeReturns := fake.findByIdempotencyKeyReturns
+	fake.recordInvocation("FindByIdempotencyKey", []interface{}{arg1, arg2})
+	fake.findByIdempotencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ServerPromptManager) FindByIdempotencyKeyCallCount() int {
+	fake.findByIdempotencyKeyMutex.RLock()
+	defer fake.findByIdempotencyKeyMutex.RUnlock()
+	return len(fake.findByIdempotencyKeyArgsForCall)
+}
+
+func (fake *ServerPromptManager) FindByIdempotencyKeyCalls(stub func(context.Context, string) (string, error)) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	defer fake.findByIdempotencyKeyMutex.Unlock()
+	fake.FindByIdempotencyKeyStub = stub
+}
+
+func (fake *ServerPromptManager) FindByIdempotencyKeyArgsForCall(i int) (context.Context, string) {
+	fake.findByIdempotencyKeyMutex.RLock()
+	defer fake.findByIdempotencyKeyMutex.RUnlock()
+	argsForCall := fake.findByIdempotencyKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ServerPromptManager) FindByIdempotencyKeyReturns(result1 string, result2 error) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	defer fake.findByIdempotencyKeyMutex.Unlock()
+	fake.FindByIdempotencyKeyStub = nil
+	fake.findByIdempotencyKeyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ServerPromptManager) FindByIdempotencyKeyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.findByIdempotencyKeyMutex.Lock()
+	defer fake.findByIdempotencyKeyMutex.Unlock()
+	fake.FindByIdempotencyKeyStub = nil
+	if fake.findByIdempotencyKeyReturnsOnCall == nil {
+		fake.findByIdempotencyKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.findByIdempotencyKeyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ServerPromptManager) Load(arg1 context.Context, arg2 string) (*prompt.PromptFile, error) {
 	fake.loadMutex.Lock()
 	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
@@ -22,6 +22,32 @@ type Worktreer struct {
 	addReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AddDetachedStub        func(context.Context, string) (string, error)
+	addDetachedMutex       sync.RWMutex
+	addDetachedArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	addDetachedReturns struct {
+		result1 string
+		result2 error
+	}
+	addDetachedReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ApplyChangesStub        func(context.Context, string) error
+	applyChangesMutex       sync.RWMutex
+	applyChangesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	applyChangesReturns struct {
+		result1 error
+	}
+	applyChangesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoveStub        func(context.Context, string) error
 	removeMutex       sync.RWMutex
 	removeArgsForCall []struct {
@@ -101,6 +127,133 @@ func (fake *Worktreer) AddReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Worktreer) AddDetached(arg1 context.Context, arg2 string) (string, error) {
+	fake.addDetachedMutex.Lock()
+	ret, specificReturn := fake.addDetachedReturnsOnCall[len(fake.addDetachedArgsForCall)]
+	fake.addDetachedArgsForCall = append(fake.addDetachedArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AddDetachedStub
+	fakeReturns := fake.addDetachedReturns
+	fake.recordInvocation("AddDetached", []interface{}{arg1, arg2})
+	fake.addDetachedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Worktreer) AddDetachedCallCount() int {
+	fake.addDetachedMutex.RLock()
+	defer fake.addDetachedMutex.RUnlock()
+	return len(fake.addDetachedArgsForCall)
+}
+
+func (fake *Worktreer) AddDetachedCalls(stub func(context.Context, string) (string, error)) {
+	fake.addDetachedMutex.Lock()
+	defer fake.addDetachedMutex.Unlock()
+	fake.AddDetachedStub = stub
+}
+
+func (fake *Worktreer) AddDetachedArgsForCall(i int) (context.Context, string) {
+	fake.addDetachedMutex.RLock()
+	defer fake.addDetachedMutex.RUnlock()
+	argsForCall := fake.addDetachedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Worktreer) AddDetachedReturns(result1 string, result2 error) {
+	fake.addDetachedMutex.Lock()
+	defer fake.addDetachedMutex.Unlock()
+	fake.AddDetachedStub = nil
+	fake.addDetachedReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Worktreer) AddDetachedReturnsOnCall(i int, result1 string, result2 error) {
+	fake.addDetachedMutex.Lock()
+	defer fake.addDetachedMutex.Unlock()
+	fake.AddDetachedStub = nil
+	if fake.addDetachedReturnsOnCall == nil {
+		fake.addDetachedReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.addDetachedReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Worktreer) ApplyChanges(arg1 context.Context, arg2 string) error {
+	fake.applyChangesMutex.Lock()
+	ret, specificReturn := fake.applyChangesReturnsOnCall[len(fake.applyChangesArgsForCall)]
+	fake.applyChangesArgsForCall = append(fake.applyChangesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ApplyChangesStub
+	fakeReturns := fake.applyChangesReturns
+	fake.recordInvocation("ApplyChanges", []interface{}{arg1, arg2})
+	fake.applyChangesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Worktreer) ApplyChangesCallCount() int {
+	fake.applyChangesMutex.RLock()
+	defer fake.applyChangesMutex.RUnlock()
+	return len(fake.applyChangesArgsForCall)
+}
+
+func (fake *Worktreer) ApplyChangesCalls(stub func(context.Context, string) error) {
+	fake.applyChangesMutex.Lock()
+	defer fake.applyChangesMutex.Unlock()
+	fake.ApplyChangesStub = stub
+}
+
+func (fake *Worktreer) ApplyChangesArgsForCall(i int) (context.Context, string) {
+	fake.applyChangesMutex.RLock()
+	defer fake.applyChangesMutex.RUnlock()
+	argsForCall := fake.applyChangesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Worktreer) ApplyChangesReturns(result1 error) {
+	fake.applyChangesMutex.Lock()
+	defer fake.applyChangesMutex.Unlock()
+	fake.ApplyChangesStub = nil
+	fake.applyChangesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Worktreer) ApplyChangesReturnsOnCall(i int, result1 error) {
+	fake.applyChangesMutex.Lock()
+	defer fake.applyChangesMutex.Unlock()
+	fake.ApplyChangesStub = nil
+	if fake.applyChangesReturnsOnCall == nil {
+		fake.applyChangesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.applyChangesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Worktreer) Remove(arg1 context.Context, arg2 string) error {
 	fake.removeMutex.Lock()
 	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
@@ -5,7 +5,12 @@
 package main
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/bborbe/dark-factory/pkg/log"
 )
 
 type parseArgsResult struct {
@@ -123,6 +128,29 @@ func TestParseArgsSpec(t *testing.T) {
 	)
 }
 
+func TestParseArgsRelease(t *testing.T) {
+	t.Parallel()
+	assertParseArgs(
+		t,
+		[]string{"release", "plan", "--format", "json"},
+		parseArgsResult{command: "release", subcommand: "plan", args: []string{"--format", "json"}},
+	)
+	assertParseArgs(
+		t,
+		[]string{"release"},
+		parseArgsResult{command: "release", subcommand: "", args: []string{}},
+	)
+}
+
+func TestParseArgsChangelog(t *testing.T) {
+	t.Parallel()
+	assertParseArgs(
+		t,
+		[]string{"changelog", "rebuild", "--force"},
+		parseArgsResult{command: "changelog", subcommand: "rebuild", args: []string{"--force"}},
+	)
+}
+
 func TestParseArgsPromptHelp(t *testing.T) {
 	t.Parallel()
 	assertParseArgs(
@@ -199,6 +227,26 @@ func TestParseArgsDebug(t *testing.T) {
 	)
 }
 
+func TestParseArgsVerbose(t *testing.T) {
+	t.Parallel()
+	assertParseArgs(
+		t,
+		[]string{"daemon", "--verbose"},
+		parseArgsResult{debug: true, command: "daemon", args: []string{}},
+	)
+	assertParseArgs(
+		t,
+		[]string{"daemon", "-v"},
+		parseArgsResult{debug: true, command: "daemon", args: []string{}},
+	)
+	// -v in place of the command still shows the version
+	assertParseArgs(
+		t,
+		[]string{"-v"},
+		parseArgsResult{command: "version", args: []string{}},
+	)
+}
+
 func TestParseArgsAutoApprove(t *testing.T) {
 	t.Parallel()
 	// --auto-approve present sets autoApprove=true
@@ -268,6 +316,204 @@ func TestParseArgsSkipPreflight(t *testing.T) {
 	)
 }
 
+func TestExtractDryRun(t *testing.T) {
+	t.Parallel()
+	set, remaining := extractDryRun([]string{"--max-containers", "2", "--dry-run"})
+	if !set {
+		t.Error("expected set=true")
+	}
+	if len(remaining) != 2 || remaining[0] != "--max-containers" || remaining[1] != "2" {
+		t.Errorf("expected [--max-containers 2], got %v", remaining)
+	}
+}
+
+func TestExtractDryRunNoFlag(t *testing.T) {
+	t.Parallel()
+	set, remaining := extractDryRun([]string{"other"})
+	if set {
+		t.Error("expected set=false")
+	}
+	if len(remaining) != 1 || remaining[0] != "other" {
+		t.Errorf("expected [other], got %v", remaining)
+	}
+}
+
+func TestParseLogFormatFlag(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][]string{
+		{"run", "--log-format=json"},
+		{"run", "--log-format", "json"},
+	} {
+		format, remaining, err := parseLogFormatFlag(context.Background(), args)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", args, err)
+		}
+		if format != log.FormatJSON {
+			t.Errorf("expected json for %v, got %q", args, format)
+		}
+		if len(remaining) != 1 || remaining[0] != "run" {
+			t.Errorf("expected [run] for %v, got %v", args, remaining)
+		}
+	}
+}
+
+func TestParseLogFormatFlagAbsent(t *testing.T) {
+	t.Parallel()
+	format, remaining, err := parseLogFormatFlag(context.Background(), []string{"run"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if format != "" {
+		t.Errorf("expected empty format, got %q", format)
+	}
+	if len(remaining) != 1 {
+		t.Errorf("expected [run], got %v", remaining)
+	}
+}
+
+func TestParseLogFormatFlagInvalid(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][]string{
+		{"--log-format=yaml"},
+		{"--log-format"},
+		{"--log-format="},
+	} {
+		if _, _, err := parseLogFormatFlag(context.Background(), args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseLogLevelFlag(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][]string{
+		{"daemon", "--log-level=error"},
+		{"daemon", "--log-level", "error"},
+	} {
+		level, remaining, err := parseLogLevelFlag(context.Background(), args)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", args, err)
+		}
+		if level != log.LevelError {
+			t.Errorf("expected error for %v, got %q", args, level)
+		}
+		if len(remaining) != 1 || remaining[0] != "daemon" {
+			t.Errorf("expected [daemon] for %v, got %v", args, remaining)
+		}
+	}
+}
+
+func TestParseLogLevelFlagInvalid(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][]string{
+		{"--log-level=trace"},
+		{"--log-level"},
+	} {
+		if _, _, err := parseLogLevelFlag(context.Background(), args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseIdeasDirFlag(t *testing.T) {
+	t.Parallel()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, remaining, err := parseIdeasDirFlag(
+		context.Background(),
+		[]string{"promote", "--ideas-dir", "backlog/ideas", "001-idea.md"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(wd, "backlog/ideas"); dir != want {
+		t.Errorf("expected %q, got %q", want, dir)
+	}
+	if len(remaining) != 2 || remaining[0] != "promote" || remaining[1] != "001-idea.md" {
+		t.Errorf("expected [promote 001-idea.md], got %v", remaining)
+	}
+}
+
+func TestParseIdeasDirFlagAbsent(t *testing.T) {
+	t.Parallel()
+	dir, remaining, err := parseIdeasDirFlag(context.Background(), []string{"status"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "" || len(remaining) != 1 {
+		t.Errorf("expected no dir and [status], got %q %v", dir, remaining)
+	}
+}
+
+func TestParsePromptsDirFlagAbsolute(t *testing.T) {
+	t.Parallel()
+	dir, remaining, err := parsePromptsDirFlag(
+		context.Background(),
+		[]string{"--prompts-dir", "/srv/prompts", "status"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "/srv/prompts" {
+		t.Errorf("expected /srv/prompts, got %q", dir)
+	}
+	if len(remaining) != 1 || remaining[0] != "status" {
+		t.Errorf("expected [status], got %v", remaining)
+	}
+}
+
+func TestParsePromptsDirFlagRelative(t *testing.T) {
+	t.Parallel()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, _, err := parsePromptsDirFlag(context.Background(), []string{"--prompts-dir=work/prompts"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(wd, "work/prompts"); dir != want {
+		t.Errorf("expected %q, got %q", want, dir)
+	}
+}
+
+func TestParsePromptsDirFlagMultiple(t *testing.T) {
+	t.Parallel()
+	dir, remaining, err := parsePromptsDirFlag(
+		context.Background(),
+		[]string{"--prompts-dir=/srv/a,/srv/b", "daemon", "--prompts-dir", "/srv/c"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "/srv/a,/srv/b,/srv/c" {
+		t.Errorf("expected /srv/a,/srv/b,/srv/c, got %q", dir)
+	}
+	if len(remaining) != 1 || remaining[0] != "daemon" {
+		t.Errorf("expected [daemon], got %v", remaining)
+	}
+}
+
+func TestParsePromptsDirFlagAbsent(t *testing.T) {
+	t.Parallel()
+	dir, remaining, err := parsePromptsDirFlag(context.Background(), []string{"run"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "" || len(remaining) != 1 {
+		t.Errorf("expected no dir and [run], got %q %v", dir, remaining)
+	}
+}
+
+func TestParsePromptsDirFlagMissingValue(t *testing.T) {
+	t.Parallel()
+	if _, _, err := parsePromptsDirFlag(context.Background(), []string{"--prompts-dir"}); err == nil {
+		t.Error("expected error")
+	}
+}
+
 func TestExtractAutoApprovePromptsFlagOnly(t *testing.T) {
 	t.Parallel()
 	set, remaining := extractAutoApprovePrompts([]string{"--auto-approve-prompts"})
@@ -394,3 +640,38 @@ func TestParseArgsSkipHealthcheck(t *testing.T) {
 		parseArgsResult{command: "daemon", args: []string{}, skipHealthcheck: true},
 	)
 }
+
+func TestExtractDirsRepeated(t *testing.T) {
+	t.Parallel()
+	dirs, remaining, err := extractDirs(
+		context.Background(),
+		[]string{"--dir", "repoA", "--json", "--dir", "repoB"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dirs) != 2 || dirs[0] != "repoA" || dirs[1] != "repoB" {
+		t.Errorf("expected [repoA repoB], got %v", dirs)
+	}
+	if len(remaining) != 1 || remaining[0] != "--json" {
+		t.Errorf("expected [--json], got %v", remaining)
+	}
+}
+
+func TestExtractDirsMissingValue(t *testing.T) {
+	t.Parallel()
+	if _, _, err := extractDirs(context.Background(), []string{"--dir"}); err == nil {
+		t.Error("expected error for --dir without value")
+	}
+}
+
+func TestExtractForceLock(t *testing.T) {
+	t.Parallel()
+	set, remaining := extractForceLock([]string{"--force", "--max-containers", "2"})
+	if !set {
+		t.Error("expected set=true")
+	}
+	if len(remaining) != 2 || remaining[0] != "--max-containers" || remaining[1] != "2" {
+		t.Errorf("expected [--max-containers 2], got %v", remaining)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package canary gates a queue run on its first prompt: when the canary
+// prompt fails, the queue is paused so the rest of the batch does not run
+// against a broken configuration.
+package canary
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package canary
+
+import (
+	"context"
+	"path/filepath"
+
+	log "github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/pause"
+	"github.com/bborbe/dark-factory/pkg/project"
+)
+
+//counterfeiter:generate -o ../../mocks/canary-gate.go --fake-name CanaryGate . Gate
+
+// Gate observes prompt outcomes until the first prompt of a run succeeds.
+type Gate interface {
+	// Observe records the outcome of a processed prompt. While the gate is
+	// armed, a failure pauses the queue and notifies; the first success
+	// disarms the gate so later failures follow the normal failure path.
+	Observe(ctx context.Context, promptPath string, err error)
+}
+
+// NewGate creates an armed Gate that pauses via sentinel on canary failure.
+func NewGate(
+	sentinel pause.Sentinel,
+	n notifier.Notifier,
+	projectName project.Name,
+) Gate {
+	return &gate{
+		sentinel:    sentinel,
+		notifier:    n,
+		projectName: projectName,
+		armed:       true,
+	}
+}
+
+// gate implements Gate.
+type gate struct {
+	sentinel    pause.Sentinel
+	notifier    notifier.Notifier
+	projectName project.Name
+	armed       bool
+}
+
+// Observe pauses the queue on an armed failure and disarms on the first success.
+// The gate stays armed after a failure, so the first prompt after `resume` is
+// again treated as the canary.
+func (g *gate) Observe(ctx context.Context, promptPath string, err error) {
+	if !g.armed {
+		return
+	}
+	name := filepath.Base(promptPath)
+	if err == nil {
+		g.armed = false
+		log.From(ctx).Info("canary prompt succeeded, processing remaining queue", "prompt_id", name)
+		return
+	}
+	log.From(ctx).Warn("canary prompt failed, pausing queue", "prompt_id", name, "error", err.Error())
+	if pauseErr := g.sentinel.Pause(ctx); pauseErr != nil {
+		log.From(ctx).Error("canary: pause queue failed", "error", pauseErr.Error())
+	}
+	_ = g.notifier.Notify(ctx, notifier.Event{
+		ProjectName: g.projectName.String(),
+		EventType:   "canary_failed",
+		PromptName:  name,
+	})
+}
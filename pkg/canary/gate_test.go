@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package canary_test
+
+import (
+	"context"
+	stderrors "errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/canary"
+	"github.com/bborbe/dark-factory/pkg/project"
+)
+
+var _ = Describe("Gate", func() {
+	var (
+		ctx      context.Context
+		sentinel *mocks.PauseSentinel
+		n        *mocks.Notifier
+		gate     canary.Gate
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		sentinel = &mocks.PauseSentinel{}
+		n = &mocks.Notifier{}
+		gate = canary.NewGate(sentinel, n, project.Name("my-project"))
+	})
+
+	Context("canary passes", func() {
+		It("does not pause or notify", func() {
+			gate.Observe(ctx, "/queue/001-first.md", nil)
+
+			Expect(sentinel.PauseCallCount()).To(Equal(0))
+			Expect(n.NotifyCallCount()).To(Equal(0))
+		})
+
+		It("ignores later failures", func() {
+			gate.Observe(ctx, "/queue/001-first.md", nil)
+			gate.Observe(ctx, "/queue/002-second.md", stderrors.New("boom"))
+
+			Expect(sentinel.PauseCallCount()).To(Equal(0))
+			Expect(n.NotifyCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("canary fails", func() {
+		It("pauses the queue and notifies", func() {
+			gate.Observe(ctx, "/queue/001-first.md", stderrors.New("boom"))
+
+			Expect(sentinel.PauseCallCount()).To(Equal(1))
+			Expect(n.NotifyCallCount()).To(Equal(1))
+			_, event := n.NotifyArgsForCall(0)
+			Expect(event.EventType).To(Equal("canary_failed"))
+			Expect(event.ProjectName).To(Equal("my-project"))
+			Expect(event.PromptName).To(Equal("001-first.md"))
+		})
+
+		It("stays armed so the next prompt after resume is the canary", func() {
+			gate.Observe(ctx, "/queue/001-first.md", stderrors.New("boom"))
+			gate.Observe(ctx, "/queue/001-first.md", stderrors.New("boom again"))
+
+			Expect(sentinel.PauseCallCount()).To(Equal(2))
+			Expect(n.NotifyCallCount()).To(Equal(2))
+		})
+
+		It("still notifies when pausing fails", func() {
+			sentinel.PauseReturns(stderrors.New("disk full"))
+
+			gate.Observe(ctx, "/queue/001-first.md", stderrors.New("boom"))
+
+			Expect(n.NotifyCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package changelog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+// unreleasedSection collects entries without a parseable vX.Y.Z version.
+const unreleasedSection = "Unreleased"
+
+const header = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+Please choose versions by [Semantic Versioning](http://semver.org/).
+
+* MAJOR version when you make incompatible API changes,
+* MINOR version when you add functionality in a backwards-compatible manner, and
+* PATCH version when you make backwards-compatible bug fixes.
+`
+
+//counterfeiter:generate -o ../../mocks/changelog-builder.go --fake-name ChangelogBuilder . Builder
+
+// Builder reconstructs a changelog from completed prompts.
+type Builder interface {
+	// Build returns the full CHANGELOG.md content.
+	Build(ctx context.Context) (string, error)
+}
+
+// Entry is one completed prompt contributing a changelog line.
+type Entry struct {
+	Version   string
+	Title     string
+	Completed string
+}
+
+// NewBuilder creates a Builder reading completed prompts from completedDir.
+func NewBuilder(
+	completedDir string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) Builder {
+	return &builder{
+		completedDir: completedDir,
+		loader:       prompt.NewPromptFileLoader(currentDateTimeGetter),
+	}
+}
+
+// builder implements Builder.
+type builder struct {
+	completedDir string
+	loader       prompt.PromptFileLoader
+}
+
+// Build reads every completed prompt and renders the changelog.
+func (b *builder) Build(ctx context.Context) (string, error) {
+	entries, err := b.readEntries(ctx)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "read completed prompts")
+	}
+	return Render(ctx, entries), nil
+}
+
+// readEntries loads the version, title and completed date of each completed prompt,
+// in the flat completed dir and in its YYYY-MM subdirectories.
+func (b *builder) readEntries(ctx context.Context) ([]Entry, error) {
+	if _, err := os.Stat(b.completedDir); err != nil {
+		return nil, errors.Wrapf(ctx, err, "read dir %s", b.completedDir)
+	}
+	paths, err := prompt.ListCompletedFiles(ctx, b.completedDir)
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read dir %s", b.completedDir)
+	}
+	entries := make([]Entry, 0, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		pf, err := b.loader.Load(ctx, path)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "load %s", name)
+		}
+		title := pf.Title()
+		if title == "" {
+			title = strings.TrimSuffix(name, ".md")
+		}
+		entries = append(entries, Entry{
+			Version:   pf.Frontmatter.DarkFactoryVersion,
+			Title:     title,
+			Completed: pf.Frontmatter.Completed,
+		})
+	}
+	return entries, nil
+}
+
+// Render groups entries by version and returns a changelog with the newest
+// version first. Entries without a vX.Y.Z version land in "## Unreleased" at
+// the top. Within a section entries are ordered by completed date, then title.
+func Render(ctx context.Context, entries []Entry) string {
+	sections := make(map[string][]Entry)
+	versions := make(map[string]git.SemanticVersionNumber)
+	for _, entry := range entries {
+		name := unreleasedSection
+		if version, err := git.ParseSemanticVersionNumber(ctx, entry.Version); err == nil {
+			name = version.String()
+			versions[name] = version
+		}
+		sections[name] = append(sections[name], entry)
+	}
+
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return versions[names[j]].Less(versions[names[i]])
+	})
+	if _, ok := sections[unreleasedSection]; ok {
+		names = append([]string{unreleasedSection}, names...)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header)
+	for _, name := range names {
+		sectionEntries := sections[name]
+		sort.SliceStable(sectionEntries, func(i, j int) bool {
+			if sectionEntries[i].Completed != sectionEntries[j].Completed {
+				return sectionEntries[i].Completed < sectionEntries[j].Completed
+			}
+			return sectionEntries[i].Title < sectionEntries[j].Title
+		})
+		sb.WriteString("\n## " + name + "\n\n")
+		for _, entry := range sectionEntries {
+			sb.WriteString("- " + entry.Title + "\n")
+		}
+	}
+	return sb.String()
+}
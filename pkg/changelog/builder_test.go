@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package changelog_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/changelog"
+)
+
+var _ = Describe("Builder", func() {
+	var (
+		ctx          context.Context
+		completedDir string
+	)
+
+	writePrompt := func(name, version, completed, body string) {
+		content := "---\nstatus: completed\n"
+		if version != "" {
+			content += "dark-factory-version: " + version + "\n"
+		}
+		content += "completed: \"" + completed + "\"\n---\n" + body
+		Expect(os.WriteFile(filepath.Join(completedDir, name), []byte(content), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		completedDir = GinkgoT().TempDir()
+	})
+
+	It("groups completed prompts by version, newest first", func() {
+		writePrompt("001-first.md", "v0.9.0", "2026-01-01T10:00:00Z", "# Add first\n")
+		writePrompt("003-third.md", "v0.10.0", "2026-01-03T10:00:00Z", "# Add third\n")
+		writePrompt("002-second.md", "v0.9.0", "2026-01-02T10:00:00Z", "# Fix second\n")
+		writePrompt("004-fourth.md", "v0.10.0", "2026-01-04T10:00:00Z", "No heading\n")
+		writePrompt("005-fifth.md", "", "2026-01-05T10:00:00Z", "# Unversioned\n")
+		Expect(os.Mkdir(filepath.Join(completedDir, "log"), 0750)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(completedDir, ".pruned-prompts"), []byte("7\n"), 0600)).
+			To(Succeed())
+
+		content, err := changelog.NewBuilder(completedDir, libtime.NewCurrentDateTime()).Build(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(content).To(HavePrefix("# Changelog\n"))
+		Expect(content).To(HaveSuffix(`
+## Unreleased
+
+- Unversioned
+
+## v0.10.0
+
+- Add third
+- 004-fourth
+
+## v0.9.0
+
+- Add first
+- Fix second
+`))
+	})
+
+	It("includes prompts in the month directories of the monthly layout", func() {
+		writePrompt("001-first.md", "v0.9.0", "2026-01-01T10:00:00Z", "# Add first\n")
+		Expect(os.Mkdir(filepath.Join(completedDir, "2026-02"), 0750)).To(Succeed())
+		writePrompt("2026-02/002-second.md", "v0.9.0", "2026-02-02T10:00:00Z", "# Fix second\n")
+		writePrompt("2026-02/003-third.md", "v0.10.0", "2026-02-03T10:00:00Z", "# Add third\n")
+
+		content, err := changelog.NewBuilder(completedDir, libtime.NewCurrentDateTime()).Build(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(content).To(HaveSuffix(`
+## v0.10.0
+
+- Add third
+
+## v0.9.0
+
+- Add first
+- Fix second
+`))
+	})
+
+	It("returns an error when the completed dir does not exist", func() {
+		_, err := changelog.NewBuilder(
+			filepath.Join(completedDir, "missing"),
+			libtime.NewCurrentDateTime(),
+		).Build(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Render", func() {
+	It("renders only the header without entries", func() {
+		content := changelog.Render(context.Background(), nil)
+		Expect(content).To(HavePrefix("# Changelog\n"))
+		Expect(content).NotTo(ContainSubstring("## "))
+	})
+
+	It("orders entries with equal completed dates by title", func() {
+		content := changelog.Render(context.Background(), []changelog.Entry{
+			{Version: "v1.0.0", Title: "b", Completed: "2026-01-01T00:00:00Z"},
+			{Version: "v1.0.0", Title: "a", Completed: "2026-01-01T00:00:00Z"},
+		})
+		Expect(content).To(HaveSuffix("## v1.0.0\n\n- a\n- b\n"))
+	})
+})
@@ -0,0 +1,7 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package changelog reconstructs a Keep-a-Changelog style CHANGELOG.md from
+// the frontmatter of completed prompts.
+package changelog
@@ -23,9 +23,10 @@ type ApproveCommand interface {
 
 // approveCommand implements ApproveCommand.
 type approveCommand struct {
-	inboxDir      string
-	queueDir      string
-	promptManager PromptManager
+	inboxDir        string
+	queueDir        string
+	promptManager   PromptManager
+	idempotencyKeys prompt.IdempotencyKeyFinder
 }
 
 // NewApproveCommand creates a new ApproveCommand.
@@ -33,11 +34,13 @@ func NewApproveCommand(
 	inboxDir string,
 	queueDir string,
 	promptManager PromptManager,
+	idempotencyKeys prompt.IdempotencyKeyFinder,
 ) ApproveCommand {
 	return &approveCommand{
-		inboxDir:      inboxDir,
-		queueDir:      queueDir,
-		promptManager: promptManager,
+		inboxDir:        inboxDir,
+		queueDir:        queueDir,
+		promptManager:   promptManager,
+		idempotencyKeys: idempotencyKeys,
 	}
 }
 
@@ -70,7 +73,9 @@ func (a *approveCommand) approveByID(ctx context.Context, id string) error {
 // generator paths share one canonical implementation (Phase-2 cleanup of
 // [[Harden Dark Factory Architecture]], 2026-06-27).
 func (a *approveCommand) approveFromInbox(ctx context.Context, oldPath string) error {
-	newPath, err := prompt.ApproveFromInbox(ctx, oldPath, a.queueDir, a.promptManager)
+	newPath, err := prompt.ApproveFromInbox(
+		ctx, oldPath, a.queueDir, a.promptManager, a.idempotencyKeys,
+	)
 	if err != nil {
 		return err
 	}
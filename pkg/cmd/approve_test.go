@@ -52,6 +52,7 @@ var _ = Describe("ApproveCommand", func() {
 			inboxDir,
 			queueDir,
 			promptManager,
+			&mocks.IdempotencyKeyFinder{},
 		)
 		ctx = context.Background()
 	})
@@ -84,6 +85,26 @@ var _ = Describe("ApproveCommand", func() {
 			Expect(promptManager.NormalizeFilenamesCallCount()).To(Equal(1))
 		})
 
+		It("refuses a prompt whose idempotency key is already enqueued", func() {
+			idempotencyKeys := &mocks.IdempotencyKeyFinder{}
+			idempotencyKeys.FindByIdempotencyKeyReturns(filepath.Join(queueDir, "001-fix.md"), nil)
+			approveCmd = cmd.NewApproveCommand(inboxDir, queueDir, promptManager, idempotencyKeys)
+			testFile := filepath.Join(inboxDir, "fix-again.md")
+			Expect(os.WriteFile(
+				testFile,
+				[]byte("---\nidempotency_key: job-1\n---\n# Fix"),
+				0600,
+			)).To(Succeed())
+
+			err := approveCmd.Run(ctx, []string{"fix-again.md"})
+			Expect(err).To(MatchError(ContainSubstring("already enqueued prompt 001-fix.md")))
+
+			_, key := idempotencyKeys.FindByIdempotencyKeyArgsForCall(0)
+			Expect(key).To(Equal("job-1"))
+			_, err = os.Stat(filepath.Join(queueDir, "fix-again.md"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
 		It("moves unnumbered file from inbox to queue unchanged", func() {
 			testFile := filepath.Join(inboxDir, "fix-something.md")
 			err := os.WriteFile(testFile, []byte("# Fix"), 0600)
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o ../../mocks/bump-command.go --fake-name BumpCommand . BumpCommand
+
+// BumpCommand executes the bump subcommand.
+type BumpCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// bumpCommand implements BumpCommand.
+type bumpCommand struct {
+	queueDir      string
+	promptManager PromptManager
+}
+
+// NewBumpCommand creates a new BumpCommand.
+func NewBumpCommand(
+	queueDir string,
+	promptManager PromptManager,
+) BumpCommand {
+	return &bumpCommand{
+		queueDir:      queueDir,
+		promptManager: promptManager,
+	}
+}
+
+// Run moves the given queued prompt to the front of the queue without renumbering it.
+func (b *bumpCommand) Run(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.Errorf(ctx, "usage: dark-factory bump <id>")
+	}
+	path, err := FindPromptFile(ctx, b.queueDir, args[0])
+	if err != nil {
+		return errors.Errorf(ctx, "file not found: %s", args[0])
+	}
+	if err := b.promptManager.Touch(ctx, path); err != nil {
+		return errors.Wrap(ctx, err, "bump prompt")
+	}
+	fmt.Printf("bumped: %s\n", filepath.Base(path))
+	return nil
+}
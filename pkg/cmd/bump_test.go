@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("BumpCommand", func() {
+	var (
+		tempDir       string
+		queueDir      string
+		promptManager *prompt.Manager
+		bumpCmd       cmd.BumpCommand
+		ctx           context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "bump-test-*")
+		Expect(err).NotTo(HaveOccurred())
+
+		queueDir = filepath.Join(tempDir, "queue")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		for _, name := range []string{"001-first.md", "002-second.md"} {
+			Expect(os.WriteFile(
+				filepath.Join(queueDir, name),
+				[]byte("---\nstatus: approved\n---\n# Prompt\n"),
+				0600,
+			)).To(Succeed())
+		}
+
+		promptManager = prompt.NewManager(
+			"",
+			queueDir,
+			"",
+			"",
+			nil,
+			libtime.NewCurrentDateTime(),
+			prompt.WithQueueOrder(prompt.QueueOrderPriority),
+		)
+		bumpCmd = cmd.NewBumpCommand(queueDir, promptManager)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("moves the prompt to the front of the queue", func() {
+		Expect(bumpCmd.Run(ctx, []string{"002"})).To(Succeed())
+
+		prompts, err := promptManager.ListQueued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prompts).To(HaveLen(2))
+		Expect(filepath.Base(prompts[0].Path)).To(Equal("002-second.md"))
+	})
+
+	It("returns an error for an unknown prompt", func() {
+		err := bumpCmd.Run(ctx, []string{"999"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("file not found"))
+	})
+
+	It("returns an error without an id", func() {
+		err := bumpCmd.Run(ctx, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("usage"))
+	})
+})
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+//counterfeiter:generate -o ../../mocks/cancel-executing-command.go --fake-name CancelExecutingCommand . CancelExecutingCommand
+
+// CancelExecutingCommand stops the container of the executing prompt and puts
+// the prompt back into the queue.
+type CancelExecutingCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// cancelExecutingCommand implements CancelExecutingCommand.
+type cancelExecutingCommand struct {
+	queueDir      string
+	promptManager PromptManager
+	stopper       executor.ExecutionStopper
+	out           io.Writer
+}
+
+// NewCancelExecutingCommand creates a new CancelExecutingCommand.
+func NewCancelExecutingCommand(
+	queueDir string,
+	promptManager PromptManager,
+	stopper executor.ExecutionStopper,
+	out io.Writer,
+) CancelExecutingCommand {
+	return &cancelExecutingCommand{
+		queueDir:      queueDir,
+		promptManager: promptManager,
+		stopper:       stopper,
+		out:           out,
+	}
+}
+
+// Run resets the executing prompt to approved before stopping its container, so
+// the daemon sees a re-queued prompt (not a failure) when the container exits.
+func (c *cancelExecutingCommand) Run(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf(ctx, "usage: dark-factory cancel")
+	}
+	pf, err := findExecutingPrompt(ctx, c.queueDir, c.promptManager)
+	if err != nil {
+		return err
+	}
+	if pf == nil {
+		fmt.Fprintln(c.out, "no prompt is currently executing")
+		return nil
+	}
+
+	name := filepath.Base(pf.Path)
+	container := pf.Frontmatter.Container
+	pf.MarkApproved()
+	if err := pf.Save(ctx); err != nil {
+		return errors.Wrap(ctx, err, "save prompt")
+	}
+	if container == "" {
+		fmt.Fprintf(c.out, "re-queued: %s (no container recorded)\n", name)
+		return nil
+	}
+	if err := c.stopper.StopContainer(ctx, container); err != nil {
+		return errors.Wrapf(ctx, err, "re-queued %s but failed to stop %s", name, container)
+	}
+	fmt.Fprintf(c.out, "cancelled: %s (stopped %s, prompt re-queued)\n", name, container)
+	return nil
+}
+
+// findExecutingPrompt returns the first prompt in queueDir with status executing, or nil.
+func findExecutingPrompt(
+	ctx context.Context,
+	queueDir string,
+	promptManager PromptManager,
+) (*prompt.PromptFile, error) {
+	entries, err := os.ReadDir(queueDir)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "read queue directory")
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		pf, err := promptManager.Load(ctx, filepath.Join(queueDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		if pf.Frontmatter.Status == string(prompt.ExecutingPromptStatus) {
+			return pf, nil
+		}
+	}
+	return nil, nil
+}
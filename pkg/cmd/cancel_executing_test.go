@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("CancelExecutingCommand", func() {
+	var (
+		ctx       context.Context
+		queueDir  string
+		stopper   *mocks.ExecutionStopper
+		out       *bytes.Buffer
+		cancelCmd cmd.CancelExecutingCommand
+		manager   *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		queueDir = GinkgoT().TempDir()
+		stopper = &mocks.ExecutionStopper{}
+		out = &bytes.Buffer{}
+		manager = prompt.NewManager("", queueDir, "", "", nil, libtime.NewCurrentDateTime())
+		cancelCmd = cmd.NewCancelExecutingCommand(queueDir, manager, stopper, out)
+	})
+
+	write := func(name, frontmatter string) string {
+		path := filepath.Join(queueDir, name)
+		Expect(os.WriteFile(
+			path,
+			[]byte("---\n"+frontmatter+"---\n# Prompt\n"),
+			0600,
+		)).To(Succeed())
+		return path
+	}
+
+	It("reports when no prompt is executing", func() {
+		write("001-queued.md", "status: approved\n")
+
+		Expect(cancelCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(out.String()).To(Equal("no prompt is currently executing\n"))
+		Expect(stopper.StopContainerCallCount()).To(Equal(0))
+	})
+
+	It("stops the container and re-queues the executing prompt", func() {
+		write("001-queued.md", "status: approved\n")
+		path := write("002-running.md", "status: executing\ncontainer: proj-002-running\n")
+
+		Expect(cancelCmd.Run(ctx, []string{})).To(Succeed())
+
+		Expect(stopper.StopContainerCallCount()).To(Equal(1))
+		_, container := stopper.StopContainerArgsForCall(0)
+		Expect(container).To(Equal("proj-002-running"))
+		pf, err := manager.Load(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Frontmatter.Status).To(Equal(string(prompt.ApprovedPromptStatus)))
+		Expect(out.String()).To(ContainSubstring("cancelled: 002-running.md"))
+	})
+
+	It("re-queues the prompt before stopping the container", func() {
+		path := write("002-running.md", "status: executing\ncontainer: proj-002-running\n")
+		stopper.StopContainerStub = func(ctx context.Context, _ string) error {
+			pf, err := manager.Load(ctx, path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pf.Frontmatter.Status).To(Equal(string(prompt.ApprovedPromptStatus)))
+			return nil
+		}
+
+		Expect(cancelCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(stopper.StopContainerCallCount()).To(Equal(1))
+	})
+
+	It("returns an error when docker stop fails", func() {
+		write("002-running.md", "status: executing\ncontainer: proj-002-running\n")
+		stopper.StopContainerReturns(errors.New("no such container"))
+
+		err := cancelCmd.Run(ctx, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to stop proj-002-running"))
+	})
+
+	It("rejects arguments", func() {
+		Expect(cancelCmd.Run(ctx, []string{"002"})).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/changelog"
+)
+
+// RebuiltChangelogFile is where changelog rebuild writes unless --force is given.
+const RebuiltChangelogFile = "CHANGELOG.rebuilt.md"
+
+//counterfeiter:generate -o ../../mocks/changelog-rebuild-command.go --fake-name ChangelogRebuildCommand . ChangelogRebuildCommand
+
+// ChangelogRebuildCommand reconstructs the changelog from completed prompts.
+type ChangelogRebuildCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// NewChangelogRebuildCommand creates a ChangelogRebuildCommand. The result is
+// written to rebuiltPath, or to changelogPath when --force is passed.
+func NewChangelogRebuildCommand(
+	builder changelog.Builder,
+	changelogPath string,
+	rebuiltPath string,
+	out io.Writer,
+) ChangelogRebuildCommand {
+	return &changelogRebuildCommand{
+		builder:       builder,
+		changelogPath: changelogPath,
+		rebuiltPath:   rebuiltPath,
+		out:           out,
+	}
+}
+
+// changelogRebuildCommand implements ChangelogRebuildCommand.
+type changelogRebuildCommand struct {
+	builder       changelog.Builder
+	changelogPath string
+	rebuiltPath   string
+	out           io.Writer
+}
+
+// Run builds the changelog and writes it to the target file.
+func (c *changelogRebuildCommand) Run(ctx context.Context, args []string) error {
+	force := false
+	for _, arg := range args {
+		if arg != "--force" {
+			return errors.Errorf(ctx, "unexpected argument: %s", arg)
+		}
+		force = true
+	}
+
+	content, err := c.builder.Build(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, "build changelog")
+	}
+
+	target := c.rebuiltPath
+	if force {
+		target = c.changelogPath
+	}
+	if err := os.WriteFile(target, []byte(content), 0600); err != nil {
+		return errors.Wrapf(ctx, err, "write %s", target)
+	}
+	fmt.Fprintf(c.out, "wrote %s\n", target)
+	return nil
+}
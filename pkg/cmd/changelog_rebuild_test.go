@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+)
+
+var _ = Describe("ChangelogRebuildCommand", func() {
+	var (
+		ctx           context.Context
+		builder       *mocks.ChangelogBuilder
+		out           *bytes.Buffer
+		changelogPath string
+		rebuiltPath   string
+		command       cmd.ChangelogRebuildCommand
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir := GinkgoT().TempDir()
+		changelogPath = filepath.Join(dir, "CHANGELOG.md")
+		rebuiltPath = filepath.Join(dir, cmd.RebuiltChangelogFile)
+		Expect(os.WriteFile(changelogPath, []byte("original\n"), 0600)).To(Succeed())
+		builder = &mocks.ChangelogBuilder{}
+		builder.BuildReturns("# Changelog\n\n## v1.0.0\n\n- Add thing\n", nil)
+		out = &bytes.Buffer{}
+		command = cmd.NewChangelogRebuildCommand(builder, changelogPath, rebuiltPath, out)
+	})
+
+	It("writes a new file and leaves CHANGELOG.md untouched by default", func() {
+		Expect(command.Run(ctx, []string{})).To(Succeed())
+
+		rebuilt, err := os.ReadFile(rebuiltPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rebuilt)).To(ContainSubstring("- Add thing"))
+		original, err := os.ReadFile(changelogPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(original)).To(Equal("original\n"))
+		Expect(out.String()).To(ContainSubstring(rebuiltPath))
+	})
+
+	It("overwrites CHANGELOG.md with --force", func() {
+		Expect(command.Run(ctx, []string{"--force"})).To(Succeed())
+
+		content, err := os.ReadFile(changelogPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("- Add thing"))
+		Expect(rebuiltPath).NotTo(BeAnExistingFile())
+	})
+
+	It("rejects unknown arguments", func() {
+		Expect(command.Run(ctx, []string{"--bogus"})).To(HaveOccurred())
+		Expect(builder.BuildCallCount()).To(Equal(0))
+	})
+
+	It("returns the builder error without writing", func() {
+		builder.BuildReturns("", stderrors.New("boom"))
+
+		Expect(command.Run(ctx, []string{})).To(MatchError(ContainSubstring("boom")))
+		Expect(rebuiltPath).NotTo(BeAnExistingFile())
+	})
+})
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/bborbe/errors"
 
@@ -113,19 +112,12 @@ func (c *combinedListCommand) collectPromptEntriesFromDir(
 	dir string,
 	showAll bool,
 ) ([]PromptEntry, error) {
-	dirEntries, err := os.ReadDir(dir)
+	paths, err := readPromptFiles(ctx, dir, c.completedDir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, errors.Wrap(ctx, err, "read directory")
+		return nil, errors.Wrap(ctx, err, "list prompt files")
 	}
-	entries := make([]PromptEntry, 0, len(dirEntries))
-	for _, entry := range dirEntries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-		path := filepath.Join(dir, entry.Name())
+	entries := make([]PromptEntry, 0, len(paths))
+	for _, path := range paths {
 		pf, err := c.promptManager.Load(ctx, path)
 		if err != nil {
 			continue
@@ -138,7 +130,11 @@ func (c *combinedListCommand) collectPromptEntriesFromDir(
 			(st == string(prompt.CompletedPromptStatus) || st == string(prompt.RejectedPromptStatus)) {
 			continue
 		}
-		entries = append(entries, PromptEntry{Status: st, File: entry.Name()})
+		entries = append(entries, PromptEntry{
+			Status: st,
+			File:   filepath.Base(path),
+			Owner:  pf.Frontmatter.Owner,
+		})
 	}
 	return entries, nil
 }
@@ -185,7 +181,7 @@ func (c *combinedListCommand) outputHuman(prompts []PromptEntry, specs []SpecEnt
 	fmt.Println("PROMPTS:")
 	fmt.Printf("%-12s %s\n", "STATUS", "FILE")
 	for _, e := range prompts {
-		fmt.Printf("%-12s %s\n", e.Status, e.File)
+		fmt.Printf("%-12s %s\n", e.Status, e.displayFile())
 	}
 	fmt.Println()
 	fmt.Println("SPECS:")
@@ -5,8 +5,12 @@
 package cmd_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
+	"os"
+	"path/filepath"
 
 	libtime "github.com/bborbe/time"
 	. "github.com/onsi/ginkgo/v2"
@@ -111,5 +115,40 @@ var _ = Describe("CombinedListCommand", func() {
 			err := combinedListCmd.Run(ctx, []string{})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("lists completed prompts in month directories with --all flag", func() {
+			completedDir := filepath.Join(GinkgoT().TempDir(), "completed")
+			monthDir := filepath.Join(completedDir, "2026-02")
+			Expect(os.MkdirAll(monthDir, 0750)).To(Succeed())
+			Expect(os.WriteFile(
+				filepath.Join(monthDir, "076-archived.md"),
+				[]byte("---\nstatus: completed\n---\n# Archived"),
+				0600,
+			)).To(Succeed())
+			combinedListCmd = cmd.NewCombinedListCommand(
+				"/nonexistent/inbox",
+				"/nonexistent/queue",
+				completedDir,
+				"",
+				lister,
+				counter,
+				prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()),
+			)
+			lister.ListReturns([]*spec.SpecFile{}, nil)
+
+			orig := os.Stdout
+			r, w, pipeErr := os.Pipe()
+			Expect(pipeErr).NotTo(HaveOccurred())
+			os.Stdout = w
+			defer func() { os.Stdout = orig }()
+
+			err := combinedListCmd.Run(ctx, []string{"--all"})
+
+			Expect(w.Close()).To(Succeed())
+			var buf bytes.Buffer
+			_, _ = io.Copy(&buf, r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring("076-archived.md"))
+		})
 	})
 })
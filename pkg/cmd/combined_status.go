@@ -53,7 +53,8 @@ func NewCombinedStatusCommand(
 	}
 }
 
-// Run executes the combined status command.
+// Run executes the combined status command. With --watch it re-renders the status
+// every --interval (default 2s) until ctx is cancelled.
 func (c *combinedStatusCommand) Run(ctx context.Context, args []string) error {
 	jsonOutput := false
 	for _, arg := range args {
@@ -62,6 +63,20 @@ func (c *combinedStatusCommand) Run(ctx context.Context, args []string) error {
 		}
 	}
 
+	watchOpts, err := parseWatchArgs(ctx, args)
+	if err != nil {
+		return err
+	}
+	if watchOpts.enabled {
+		return watch(ctx, os.Stdout, watchOpts.interval, func(ctx context.Context) error {
+			return c.render(ctx, jsonOutput)
+		})
+	}
+	return c.render(ctx, jsonOutput)
+}
+
+// render prints the combined status once.
+func (c *combinedStatusCommand) render(ctx context.Context, jsonOutput bool) error {
 	st, err := c.checker.GetStatus(ctx)
 	if err != nil {
 		return errors.Wrap(ctx, err, "get prompt status")
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/status"
+)
+
+// DefaultCompletedLimit is the number of completed prompts listed without --limit.
+const DefaultCompletedLimit = 10
+
+//counterfeiter:generate -o ../../mocks/completed-command.go --fake-name CompletedCommand . CompletedCommand
+
+// CompletedCommand lists the most recently completed prompts.
+type CompletedCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// completedCommand implements CompletedCommand.
+type completedCommand struct {
+	checker status.Checker
+	out     io.Writer
+}
+
+// NewCompletedCommand creates a new CompletedCommand.
+func NewCompletedCommand(
+	checker status.Checker,
+	out io.Writer,
+) CompletedCommand {
+	return &completedCommand{
+		checker: checker,
+		out:     out,
+	}
+}
+
+// Run prints the last --limit (DefaultCompletedLimit) completed prompts with their
+// completion time, most recent first. With --json they are printed as a JSON array
+// of status.CompletedPrompt.
+func (c *completedCommand) Run(ctx context.Context, args []string) error {
+	limit, jsonOutput, err := parseCompletedFlags(ctx, args)
+	if err != nil {
+		return err
+	}
+	completed, err := c.checker.GetCompletedPrompts(ctx, limit)
+	if err != nil {
+		return errors.Wrap(ctx, err, "get completed prompts")
+	}
+	if jsonOutput {
+		result := make([]status.CompletedPrompt, 0, len(completed))
+		result = append(result, completed...)
+		encoder := json.NewEncoder(c.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	if len(completed) == 0 {
+		fmt.Fprintln(c.out, "no completed prompts")
+		return nil
+	}
+	fmt.Fprintf(c.out, "%-19s %s\n", "COMPLETED", "FILE")
+	for _, p := range completed {
+		fmt.Fprintf(c.out, "%-19s %s\n", time.Time(p.CompletedAt).Format(time.DateTime), p.Name)
+	}
+	return nil
+}
+
+// parseCompletedFlags extracts --limit <n> and --json from args.
+// No other arguments are accepted.
+func parseCompletedFlags(ctx context.Context, args []string) (int, bool, error) {
+	limit := DefaultCompletedLimit
+	var jsonOutput bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			jsonOutput = true
+		case "--limit":
+			if i+1 >= len(args) {
+				return 0, false, errors.Errorf(ctx, "--limit requires a value")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return 0, false, errors.Errorf(
+					ctx,
+					"--limit value must be a positive integer, got %q",
+					args[i+1],
+				)
+			}
+			limit = n
+			i++
+		default:
+			return 0, false, errors.Errorf(ctx, "unexpected argument: %s", args[i])
+		}
+	}
+	return limit, jsonOutput, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/status"
+)
+
+var _ = Describe("CompletedCommand", func() {
+	var (
+		ctx          context.Context
+		checker      *mocks.Checker
+		out          *bytes.Buffer
+		completedCmd cmd.CompletedCommand
+		completed    []status.CompletedPrompt
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		checker = &mocks.Checker{}
+		out = &bytes.Buffer{}
+		completedCmd = cmd.NewCompletedCommand(checker, out)
+		completed = []status.CompletedPrompt{
+			{
+				Name:        "002-second.md",
+				CompletedAt: libtime.DateTime(time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC)),
+			},
+			{
+				Name:        "001-first.md",
+				CompletedAt: libtime.DateTime(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)),
+			},
+		}
+		checker.GetCompletedPromptsReturns(completed, nil)
+	})
+
+	It("lists completed prompts with the default limit", func() {
+		Expect(completedCmd.Run(ctx, []string{})).To(Succeed())
+
+		Expect(checker.GetCompletedPromptsCallCount()).To(Equal(1))
+		_, limit := checker.GetCompletedPromptsArgsForCall(0)
+		Expect(limit).To(Equal(cmd.DefaultCompletedLimit))
+		Expect(out.String()).To(Equal(
+			"COMPLETED           FILE\n" +
+				"2026-03-02 10:30:00 002-second.md\n" +
+				"2026-03-01 09:00:00 001-first.md\n",
+		))
+	})
+
+	It("applies --limit", func() {
+		Expect(completedCmd.Run(ctx, []string{"--limit", "3"})).To(Succeed())
+
+		_, limit := checker.GetCompletedPromptsArgsForCall(0)
+		Expect(limit).To(Equal(3))
+	})
+
+	It("prints a JSON array of name and completed_at", func() {
+		Expect(completedCmd.Run(ctx, []string{"--json"})).To(Succeed())
+
+		var result []map[string]interface{}
+		Expect(json.Unmarshal(out.Bytes(), &result)).To(Succeed())
+		Expect(result).To(HaveLen(2))
+		Expect(result[0]).To(HaveKeyWithValue("name", "002-second.md"))
+		Expect(result[0]).To(HaveKey("completed_at"))
+		Expect(result[0]).To(HaveLen(2))
+	})
+
+	It("prints an empty JSON array when nothing is completed", func() {
+		checker.GetCompletedPromptsReturns(nil, nil)
+
+		Expect(completedCmd.Run(ctx, []string{"--json"})).To(Succeed())
+		Expect(out.String()).To(Equal("[]\n"))
+	})
+
+	It("reports when nothing is completed", func() {
+		checker.GetCompletedPromptsReturns(nil, nil)
+
+		Expect(completedCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(out.String()).To(Equal("no completed prompts\n"))
+	})
+
+	DescribeTable("rejects invalid arguments",
+		func(args []string, msg string) {
+			Expect(completedCmd.Run(ctx, args)).To(MatchError(ContainSubstring(msg)))
+			Expect(checker.GetCompletedPromptsCallCount()).To(Equal(0))
+		},
+		Entry("missing limit value", []string{"--limit"}, "--limit requires a value"),
+		Entry("non-numeric limit", []string{"--limit", "ten"}, "positive integer"),
+		Entry("zero limit", []string{"--limit", "0"}, "positive integer"),
+		Entry("unknown argument", []string{"--tag"}, "unexpected argument"),
+	)
+})
@@ -68,13 +68,13 @@ func (k *killCommand) Run(ctx context.Context, _ []string) error {
 		return errors.Wrap(ctx, err, "read lock file")
 	}
 
-	pidStr := strings.TrimSpace(string(data))
+	pidStr, _, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
 	if pidStr == "" {
 		fmt.Println("no daemon running")
 		return nil
 	}
 
-	pid, err := strconv.Atoi(pidStr)
+	pid, err := strconv.Atoi(strings.TrimSpace(pidStr))
 	if err != nil {
 		fmt.Println("no daemon running")
 		return nil
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/bborbe/errors"
 
@@ -28,6 +27,15 @@ type ListCommand interface {
 type PromptEntry struct {
 	Status string `json:"status"`
 	File   string `json:"file"`
+	Owner  string `json:"owner,omitempty"`
+}
+
+// displayFile returns the file name, suffixed with the owner when one is set.
+func (e PromptEntry) displayFile() string {
+	if e.Owner == "" {
+		return e.File
+	}
+	return fmt.Sprintf("%s (owner: %s)", e.File, e.Owner)
 }
 
 // listCommand implements ListCommand.
@@ -130,21 +138,13 @@ func (l *listCommand) scanDir(
 	ctx context.Context,
 	dir string,
 ) ([]PromptEntry, error) {
-	dirEntries, err := os.ReadDir(dir)
+	paths, err := readPromptFiles(ctx, dir, l.completedDir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, errors.Wrap(ctx, err, "read directory")
+		return nil, errors.Wrap(ctx, err, "list prompt files")
 	}
 
-	entries := make([]PromptEntry, 0, len(dirEntries))
-	for _, entry := range dirEntries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-
-		path := filepath.Join(dir, entry.Name())
+	entries := make([]PromptEntry, 0, len(paths))
+	for _, path := range paths {
 		pf, err := l.promptManager.Load(ctx, path)
 		if err != nil {
 			continue
@@ -157,7 +157,8 @@ func (l *listCommand) scanDir(
 
 		entries = append(entries, PromptEntry{
 			Status: st,
-			File:   entry.Name(),
+			File:   filepath.Base(path),
+			Owner:  pf.Frontmatter.Owner,
 		})
 	}
 	return entries, nil
@@ -167,7 +168,7 @@ func (l *listCommand) scanDir(
 func (l *listCommand) outputTable(entries []PromptEntry) error {
 	fmt.Printf("%-12s %s\n", "STATUS", "FILE")
 	for _, e := range entries {
-		fmt.Printf("%-12s %s\n", e.Status, e.File)
+		fmt.Printf("%-12s %s\n", e.Status, e.displayFile())
 	}
 	return nil
 }
@@ -5,7 +5,9 @@
 package cmd_test
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -92,6 +94,30 @@ var _ = Describe("ListCommand", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("shows completed prompts in month directories with --all flag", func() {
+			monthDir := filepath.Join(completedDir, "2026-02")
+			Expect(os.MkdirAll(monthDir, 0750)).To(Succeed())
+			Expect(os.WriteFile(
+				filepath.Join(monthDir, "076-archived.md"),
+				[]byte("---\nstatus: completed\n---\n# Archived"),
+				0600,
+			)).To(Succeed())
+
+			orig := os.Stdout
+			r, w, pipeErr := os.Pipe()
+			Expect(pipeErr).NotTo(HaveOccurred())
+			os.Stdout = w
+			defer func() { os.Stdout = orig }()
+
+			err := listCmd.Run(ctx, []string{"--all"})
+
+			Expect(w.Close()).To(Succeed())
+			var buf bytes.Buffer
+			_, _ = io.Copy(&buf, r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring("076-archived.md"))
+		})
+
 		It("handles empty directories", func() {
 			err := listCmd.Run(ctx, []string{})
 			Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o ../../mocks/logs-command.go --fake-name LogsCommand . LogsCommand
+
+// LogsCommand prints the log of the executing prompt or of a named prompt.
+type LogsCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// logsCommand implements LogsCommand.
+type logsCommand struct {
+	queueDir      string
+	logDir        string
+	promptManager PromptManager
+	out           io.Writer
+	pollInterval  time.Duration
+}
+
+// NewLogsCommand creates a new LogsCommand.
+// pollInterval is how often -f checks the log file for new output.
+func NewLogsCommand(
+	queueDir string,
+	logDir string,
+	promptManager PromptManager,
+	out io.Writer,
+	pollInterval time.Duration,
+) LogsCommand {
+	return &logsCommand{
+		queueDir:      queueDir,
+		logDir:        logDir,
+		promptManager: promptManager,
+		out:           out,
+		pollInterval:  pollInterval,
+	}
+}
+
+// Run prints the log of the executing prompt, or of the prompt named by the
+// optional filename argument. With -f it keeps printing new output until the
+// prompt is no longer executing or ctx is cancelled.
+func (l *logsCommand) Run(ctx context.Context, args []string) error {
+	name, follow, err := parseLogsArgs(ctx, args)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		pf, err := findExecutingPrompt(ctx, l.queueDir, l.promptManager)
+		if err != nil {
+			return err
+		}
+		if pf == nil {
+			fmt.Fprintln(l.out, "no prompt is currently executing")
+			return nil
+		}
+		name = filepath.Base(pf.Path)
+	}
+	base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(name), ".log"), ".md")
+	logPath := filepath.Join(l.logDir, base+".log")
+
+	if !follow {
+		return l.print(ctx, base, logPath)
+	}
+	return l.follow(ctx, base, logPath)
+}
+
+// print writes the whole log file to out.
+func (l *logsCommand) print(ctx context.Context, base string, logPath string) error {
+	// #nosec G304 -- logPath is built from the configured log directory
+	file, err := os.Open(logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(l.out, "no log yet for %s (expected at %s)\n", base, logPath)
+			return nil
+		}
+		return errors.Wrap(ctx, err, "open log file")
+	}
+	defer file.Close()
+	if _, err := io.Copy(l.out, file); err != nil {
+		return errors.Wrap(ctx, err, "read log file")
+	}
+	return nil
+}
+
+// follow waits for the log file to appear, then writes it to out as it grows
+// until the prompt base is no longer executing or ctx is cancelled.
+func (l *logsCommand) follow(ctx context.Context, base string, logPath string) error {
+	var file *os.File
+	defer func() {
+		if file != nil {
+			_ = file.Close()
+		}
+	}()
+	waiting := false
+	for {
+		if file == nil {
+			// #nosec G304 -- logPath is built from the configured log directory
+			f, err := os.Open(logPath)
+			switch {
+			case err == nil:
+				file = f
+			case os.IsNotExist(err):
+				if !waiting {
+					fmt.Fprintf(l.out, "waiting for log of %s at %s\n", base, logPath)
+					waiting = true
+				}
+			default:
+				return errors.Wrap(ctx, err, "open log file")
+			}
+		}
+		executing, err := l.isExecuting(ctx, base)
+		if err != nil {
+			return err
+		}
+		if file != nil {
+			// Reading continues at the previous offset, so only new output is copied.
+			if _, err := io.Copy(l.out, file); err != nil {
+				return errors.Wrap(ctx, err, "read log file")
+			}
+		}
+		if !executing {
+			if file == nil {
+				fmt.Fprintf(l.out, "%s is not executing and has no log\n", base)
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(l.pollInterval):
+		}
+	}
+}
+
+// isExecuting reports whether the executing prompt is base.
+func (l *logsCommand) isExecuting(ctx context.Context, base string) (bool, error) {
+	pf, err := findExecutingPrompt(ctx, l.queueDir, l.promptManager)
+	if err != nil {
+		return false, err
+	}
+	return pf != nil && strings.TrimSuffix(filepath.Base(pf.Path), ".md") == base, nil
+}
+
+// parseLogsArgs extracts -f and the optional prompt filename from args.
+func parseLogsArgs(ctx context.Context, args []string) (string, bool, error) {
+	var name string
+	var follow bool
+	for _, arg := range args {
+		switch {
+		case arg == "-f" || arg == "--follow":
+			follow = true
+		case strings.HasPrefix(arg, "-"):
+			return "", false, errors.Errorf(ctx, "unknown flag: %s", arg)
+		case name != "":
+			return "", false, errors.Errorf(ctx, "usage: dark-factory logs [-f] [<file>]")
+		default:
+			name = arg
+		}
+	}
+	return name, follow, nil
+}
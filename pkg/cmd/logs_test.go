@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+// syncBuffer is a bytes.Buffer safe for a writer and a concurrent reader.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+var _ = Describe("LogsCommand", func() {
+	var (
+		ctx      context.Context
+		queueDir string
+		logDir   string
+		out      *syncBuffer
+		logsCmd  cmd.LogsCommand
+	)
+
+	writePrompt := func(name, status string) string {
+		path := filepath.Join(queueDir, name)
+		Expect(os.WriteFile(
+			path,
+			[]byte("---\nstatus: "+status+"\n---\n# Prompt\n"),
+			0600,
+		)).To(Succeed())
+		return path
+	}
+	writeLog := func(name, content string) string {
+		path := filepath.Join(logDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+	appendLog := func(path, content string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.WriteString(content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		queueDir = GinkgoT().TempDir()
+		logDir = GinkgoT().TempDir()
+		out = &syncBuffer{}
+		logsCmd = cmd.NewLogsCommand(
+			queueDir,
+			logDir,
+			prompt.NewManager("", queueDir, "", "", nil, libtime.NewCurrentDateTime()),
+			out,
+			10*time.Millisecond,
+		)
+	})
+
+	It("prints the log of the executing prompt", func() {
+		writePrompt("001-done.md", "approved")
+		writePrompt("002-running.md", "executing")
+		writeLog("001-done.md.log", "wrong\n")
+		writeLog("002-running.log", "line 1\nline 2\n")
+
+		Expect(logsCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(out.String()).To(Equal("line 1\nline 2\n"))
+	})
+
+	It("prints the log of the named prompt", func() {
+		writeLog("007-old.log", "old output\n")
+
+		Expect(logsCmd.Run(ctx, []string{"007-old.md"})).To(Succeed())
+		Expect(out.String()).To(Equal("old output\n"))
+	})
+
+	It("reports when no prompt is executing", func() {
+		writePrompt("001-queued.md", "approved")
+
+		Expect(logsCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(out.String()).To(Equal("no prompt is currently executing\n"))
+	})
+
+	It("reports a prompt without a log yet", func() {
+		Expect(logsCmd.Run(ctx, []string{"008-new"})).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("no log yet for 008-new"))
+	})
+
+	It("follows the log until the prompt is no longer executing", func() {
+		promptPath := writePrompt("003-running.md", "executing")
+		logPath := writeLog("003-running.log", "start\n")
+
+		done := make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			done <- logsCmd.Run(ctx, []string{"-f"})
+		}()
+
+		Eventually(out.String).Should(Equal("start\n"))
+		appendLog(logPath, "more\n")
+		Eventually(out.String).Should(Equal("start\nmore\n"))
+
+		appendLog(logPath, "last\n")
+		writePrompt(filepath.Base(promptPath), "completed")
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(out.String()).To(Equal("start\nmore\nlast\n"))
+	})
+
+	It("waits for the log to appear when following", func() {
+		writePrompt("004-starting.md", "executing")
+
+		done := make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			done <- logsCmd.Run(ctx, []string{"-f"})
+		}()
+
+		Eventually(out.String).Should(ContainSubstring("waiting for log of 004-starting"))
+		appendLog(filepath.Join(logDir, "004-starting.log"), "hello\n")
+		Eventually(out.String).Should(HaveSuffix("hello\n"))
+
+		writePrompt("004-starting.md", "completed")
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("stops following when the context is cancelled", func() {
+		writePrompt("005-running.md", "executing")
+		writeLog("005-running.log", "x\n")
+		cancelCtx, cancel := context.WithCancel(ctx)
+
+		done := make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			done <- logsCmd.Run(cancelCtx, []string{"--follow"})
+		}()
+
+		Eventually(out.String).Should(Equal("x\n"))
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("rejects unknown flags", func() {
+		err := logsCmd.Run(ctx, []string{"--tail"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown flag"))
+	})
+})
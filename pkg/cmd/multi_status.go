@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/status"
+)
+
+//counterfeiter:generate -o ../../mocks/multi-status-command.go --fake-name MultiStatusCommand . MultiStatusCommand
+
+// MultiStatusCommand reports the prompt status of several projects at once.
+type MultiStatusCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// NewMultiStatusCommand creates a MultiStatusCommand with one checker per project.
+func NewMultiStatusCommand(
+	checkers []status.Checker,
+	formatter status.Formatter,
+	out io.Writer,
+) MultiStatusCommand {
+	return &multiStatusCommand{
+		checkers:  checkers,
+		formatter: formatter,
+		out:       out,
+	}
+}
+
+// multiStatusCommand implements MultiStatusCommand.
+type multiStatusCommand struct {
+	checkers  []status.Checker
+	formatter status.Formatter
+	out       io.Writer
+}
+
+// Run collects every project's status and prints them as one JSON array
+// (--json) or as consecutive human-readable blocks.
+func (m *multiStatusCommand) Run(ctx context.Context, args []string) error {
+	jsonOutput := false
+	for _, arg := range args {
+		if arg != "--json" {
+			return errors.Errorf(ctx, "unexpected argument: %s", arg)
+		}
+		jsonOutput = true
+	}
+
+	statuses := make([]*status.Status, 0, len(m.checkers))
+	for _, checker := range m.checkers {
+		st, err := checker.GetStatus(ctx)
+		if err != nil {
+			return errors.Wrap(ctx, err, "get prompt status")
+		}
+		statuses = append(statuses, st)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(m.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	}
+	for i, st := range statuses {
+		if i > 0 {
+			fmt.Fprintln(m.out)
+		}
+		fmt.Fprintf(m.out, "== %s ==\n", st.ProjectDir)
+		fmt.Fprint(m.out, m.formatter.Format(st))
+	}
+	return nil
+}
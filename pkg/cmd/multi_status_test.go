@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/status"
+)
+
+var _ = Describe("MultiStatusCommand", func() {
+	var (
+		ctx       context.Context
+		checkerA  *mocks.Checker
+		checkerB  *mocks.Checker
+		formatter *mocks.Formatter
+		out       *bytes.Buffer
+		multiCmd  cmd.MultiStatusCommand
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		checkerA = &mocks.Checker{}
+		checkerA.GetStatusReturns(&status.Status{ProjectDir: "/work/repoA", QueueCount: 2}, nil)
+		checkerB = &mocks.Checker{}
+		checkerB.GetStatusReturns(&status.Status{ProjectDir: "/work/repoB", QueueCount: 0}, nil)
+		formatter = &mocks.Formatter{}
+		formatter.FormatStub = func(st *status.Status) string {
+			return "Daemon: not running\n"
+		}
+		out = &bytes.Buffer{}
+		multiCmd = cmd.NewMultiStatusCommand(
+			[]status.Checker{checkerA, checkerB},
+			formatter,
+			out,
+		)
+	})
+
+	It("prints one JSON array with a status per project", func() {
+		Expect(multiCmd.Run(ctx, []string{"--json"})).To(Succeed())
+
+		var statuses []status.Status
+		Expect(json.Unmarshal(out.Bytes(), &statuses)).To(Succeed())
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[0].ProjectDir).To(Equal("/work/repoA"))
+		Expect(statuses[0].QueueCount).To(Equal(2))
+		Expect(statuses[1].ProjectDir).To(Equal("/work/repoB"))
+	})
+
+	It("prints a human-readable block per project", func() {
+		Expect(multiCmd.Run(ctx, []string{})).To(Succeed())
+
+		Expect(out.String()).To(Equal(
+			"== /work/repoA ==\nDaemon: not running\n\n== /work/repoB ==\nDaemon: not running\n",
+		))
+	})
+
+	It("fails when one project's status cannot be read", func() {
+		checkerB.GetStatusReturns(nil, errors.New("boom"))
+
+		err := multiCmd.Run(ctx, []string{"--json"})
+		Expect(err).To(HaveOccurred())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("rejects unknown arguments", func() {
+		Expect(multiCmd.Run(ctx, []string{"--verbose"})).NotTo(Succeed())
+	})
+})
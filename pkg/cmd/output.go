@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// OutputFormat selects how a command renders its result.
+type OutputFormat string
+
+const (
+	// OutputFormatText renders the human-readable form (default).
+	OutputFormatText OutputFormat = "text"
+	// OutputFormatJSON renders the result as indented JSON for scripts and CI.
+	OutputFormatJSON OutputFormat = "json"
+)
+
+// TextRenderer is implemented by command results that have a human-readable form.
+type TextRenderer interface {
+	RenderText(w io.Writer) error
+}
+
+// OutputWriter renders command results in the selected OutputFormat.
+type OutputWriter interface {
+	Write(ctx context.Context, result TextRenderer) error
+}
+
+// NewOutputWriter creates an OutputWriter writing to out in the given format.
+func NewOutputWriter(out io.Writer, format OutputFormat) OutputWriter {
+	return &outputWriter{
+		out:    out,
+		format: format,
+	}
+}
+
+// outputWriter implements OutputWriter.
+type outputWriter struct {
+	out    io.Writer
+	format OutputFormat
+}
+
+// Write renders result as JSON or via its RenderText method.
+func (o *outputWriter) Write(ctx context.Context, result TextRenderer) error {
+	if o.format == OutputFormatJSON {
+		encoder := json.NewEncoder(o.out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return errors.Wrap(ctx, err, "encode json output")
+		}
+		return nil
+	}
+	if err := result.RenderText(o.out); err != nil {
+		return errors.Wrap(ctx, err, "write text output")
+	}
+	return nil
+}
+
+// ParseFormatFlag extracts --format <text|json> or --format=<text|json> from args.
+// Returns OutputFormatText when the flag is absent, plus the remaining args.
+func ParseFormatFlag(ctx context.Context, args []string) (OutputFormat, []string, error) {
+	format := OutputFormatText
+	remaining := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		var value string
+		switch {
+		case args[i] == "--format":
+			if i+1 >= len(args) {
+				return "", nil, errors.Errorf(ctx, "--format requires a value (text or json)")
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--format="):
+			value = strings.TrimPrefix(args[i], "--format=")
+		default:
+			remaining = append(remaining, args[i])
+			continue
+		}
+		switch OutputFormat(value) {
+		case OutputFormatText, OutputFormatJSON:
+			format = OutputFormat(value)
+		default:
+			return "", nil, errors.Errorf(ctx, "unknown --format %q (want text or json)", value)
+		}
+	}
+	return format, remaining, nil
+}
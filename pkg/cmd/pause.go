@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/pause"
+)
+
+//counterfeiter:generate -o ../../mocks/pause-command.go --fake-name PauseCommand . PauseCommand
+
+// PauseCommand executes the pause subcommand.
+type PauseCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// pauseCommand implements PauseCommand.
+type pauseCommand struct {
+	sentinel pause.Sentinel
+}
+
+// NewPauseCommand creates a new PauseCommand.
+func NewPauseCommand(
+	sentinel pause.Sentinel,
+) PauseCommand {
+	return &pauseCommand{
+		sentinel: sentinel,
+	}
+}
+
+// Run writes the pause sentinel. A running daemon finishes the current prompt
+// and starts no new one until resumed.
+func (p *pauseCommand) Run(ctx context.Context, args []string) error {
+	if p.sentinel.IsPaused() {
+		fmt.Printf("already paused\n")
+		return nil
+	}
+	if err := p.sentinel.Pause(ctx); err != nil {
+		return errors.Wrap(ctx, err, "pause queue")
+	}
+	fmt.Printf("paused\n")
+	return nil
+}
+
+//counterfeiter:generate -o ../../mocks/resume-command.go --fake-name ResumeCommand . ResumeCommand
+
+// ResumeCommand executes the resume subcommand.
+type ResumeCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// resumeCommand implements ResumeCommand.
+type resumeCommand struct {
+	sentinel pause.Sentinel
+}
+
+// NewResumeCommand creates a new ResumeCommand.
+func NewResumeCommand(
+	sentinel pause.Sentinel,
+) ResumeCommand {
+	return &resumeCommand{
+		sentinel: sentinel,
+	}
+}
+
+// Run removes the pause sentinel so the daemon picks up queued prompts again.
+func (r *resumeCommand) Run(ctx context.Context, args []string) error {
+	if !r.sentinel.IsPaused() {
+		fmt.Printf("not paused\n")
+		return nil
+	}
+	if err := r.sentinel.Resume(ctx); err != nil {
+		return errors.Wrap(ctx, err, "resume queue")
+	}
+	fmt.Printf("resumed\n")
+	return nil
+}
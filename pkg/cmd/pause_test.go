@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+)
+
+var _ = Describe("PauseCommand", func() {
+	var (
+		ctx      context.Context
+		sentinel *mocks.PauseSentinel
+		pauseCmd cmd.PauseCommand
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		sentinel = &mocks.PauseSentinel{}
+		pauseCmd = cmd.NewPauseCommand(sentinel)
+	})
+
+	It("writes the sentinel when not paused", func() {
+		sentinel.IsPausedReturns(false)
+
+		Expect(pauseCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(sentinel.PauseCallCount()).To(Equal(1))
+	})
+
+	It("does nothing when already paused", func() {
+		sentinel.IsPausedReturns(true)
+
+		Expect(pauseCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(sentinel.PauseCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when pausing fails", func() {
+		sentinel.PauseReturns(errors.New("boom"))
+
+		err := pauseCmd.Run(ctx, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("pause queue"))
+	})
+})
+
+var _ = Describe("ResumeCommand", func() {
+	var (
+		ctx       context.Context
+		sentinel  *mocks.PauseSentinel
+		resumeCmd cmd.ResumeCommand
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		sentinel = &mocks.PauseSentinel{}
+		resumeCmd = cmd.NewResumeCommand(sentinel)
+	})
+
+	It("removes the sentinel when paused", func() {
+		sentinel.IsPausedReturns(true)
+
+		Expect(resumeCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(sentinel.ResumeCallCount()).To(Equal(1))
+	})
+
+	It("does nothing when not paused", func() {
+		sentinel.IsPausedReturns(false)
+
+		Expect(resumeCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(sentinel.ResumeCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when resuming fails", func() {
+		sentinel.IsPausedReturns(true)
+		sentinel.ResumeReturns(errors.New("boom"))
+
+		err := resumeCmd.Run(ctx, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("resume queue"))
+	})
+})
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+//counterfeiter:generate -o ../../mocks/promote-command.go --fake-name PromoteCommand . PromoteCommand
+
+// PromoteCommand executes the promote subcommand.
+type PromoteCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// promoteCommand implements PromoteCommand.
+type promoteCommand struct {
+	ideasDir        string
+	queueDir        string
+	promptManager   PromptManager
+	idempotencyKeys prompt.IdempotencyKeyFinder
+}
+
+// NewPromoteCommand creates a new PromoteCommand.
+func NewPromoteCommand(
+	ideasDir string,
+	queueDir string,
+	promptManager PromptManager,
+	idempotencyKeys prompt.IdempotencyKeyFinder,
+) PromoteCommand {
+	return &promoteCommand{
+		ideasDir:        ideasDir,
+		queueDir:        queueDir,
+		promptManager:   promptManager,
+		idempotencyKeys: idempotencyKeys,
+	}
+}
+
+// Run moves an idea into the queue as an approved prompt.
+// Any numeric prefix of the idea is stripped so NormalizeFilenames assigns
+// the next free number in the queue.
+func (p *promoteCommand) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf(ctx, "usage: dark-factory promote <idea.md>")
+	}
+	ideaPath, err := FindPromptFile(ctx, p.ideasDir, filepath.Base(args[0]))
+	if err != nil {
+		return errors.Errorf(ctx, "idea not found in %s: %s", p.ideasDir, args[0])
+	}
+
+	newPath, err := prompt.ApproveFromInbox(
+		ctx, ideaPath, p.queueDir, p.promptManager, p.idempotencyKeys,
+	)
+	if err != nil {
+		return err
+	}
+	renames, err := p.promptManager.NormalizeFilenames(ctx, p.queueDir)
+	if err != nil {
+		return errors.Wrap(ctx, err, "normalize filenames")
+	}
+	for _, rename := range renames {
+		if rename.OldPath == newPath {
+			newPath = rename.NewPath
+		}
+	}
+	fmt.Printf("promoted: %s\n", filepath.Base(newPath))
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("PromoteCommand", func() {
+	var (
+		tempDir       string
+		ideasDir      string
+		queueDir      string
+		promptManager *mocks.CmdPromptManager
+		promoteCmd    cmd.PromoteCommand
+		ctx           context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "promote-test-*")
+		Expect(err).NotTo(HaveOccurred())
+
+		ideasDir = filepath.Join(tempDir, "ideas")
+		queueDir = filepath.Join(tempDir, "queue")
+		Expect(os.MkdirAll(ideasDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+
+		mover := &mocks.FileMover{}
+		mover.MoveFileStub = func(_ context.Context, oldPath, newPath string) error {
+			return os.Rename(oldPath, newPath)
+		}
+		realPM := prompt.NewManager("", queueDir, "", "", mover, libtime.NewCurrentDateTime())
+		promptManager = &mocks.CmdPromptManager{}
+		promptManager.LoadStub = realPM.Load
+		promptManager.NormalizeFilenamesStub = realPM.NormalizeFilenames
+
+		promoteCmd = cmd.NewPromoteCommand(
+			ideasDir, queueDir, promptManager, &mocks.IdempotencyKeyFinder{},
+		)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("moves an idea without prefix into the queue with the next number", func() {
+		Expect(os.WriteFile(
+			filepath.Join(queueDir, "001-existing.md"),
+			[]byte("---\nstatus: approved\n---\n# Existing\n"),
+			0600,
+		)).To(Succeed())
+		idea := filepath.Join(ideasDir, "rough-idea.md")
+		Expect(os.WriteFile(idea, []byte("# Rough idea\n"), 0600)).To(Succeed())
+
+		Expect(promoteCmd.Run(ctx, []string{"rough-idea.md"})).To(Succeed())
+
+		_, err := os.Stat(idea)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		content, err := os.ReadFile(filepath.Join(queueDir, "002-rough-idea.md"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("status: approved"))
+	})
+
+	It("replaces an existing number prefix of the idea", func() {
+		idea := filepath.Join(ideasDir, "042-numbered-idea.md")
+		Expect(os.WriteFile(idea, []byte("# Numbered idea\n"), 0600)).To(Succeed())
+
+		Expect(promoteCmd.Run(ctx, []string{"042-numbered-idea.md"})).To(Succeed())
+
+		_, err := os.Stat(idea)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, err = os.Stat(filepath.Join(queueDir, "001-numbered-idea.md"))
+		Expect(err).NotTo(HaveOccurred())
+		entries, err := os.ReadDir(ideasDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("accepts the idea as a path", func() {
+		idea := filepath.Join(ideasDir, "path-idea.md")
+		Expect(os.WriteFile(idea, []byte("# Path idea\n"), 0600)).To(Succeed())
+
+		Expect(promoteCmd.Run(ctx, []string{idea})).To(Succeed())
+
+		_, err := os.Stat(filepath.Join(queueDir, "001-path-idea.md"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns an error when the idea does not exist", func() {
+		err := promoteCmd.Run(ctx, []string{"missing.md"})
+		Expect(err).To(MatchError(ContainSubstring("idea not found")))
+	})
+
+	It("returns a usage error without arguments", func() {
+		err := promoteCmd.Run(ctx, []string{})
+		Expect(err).To(MatchError(ContainSubstring("usage")))
+	})
+})
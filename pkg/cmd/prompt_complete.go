@@ -97,15 +97,14 @@ func (c *promptCompleteCommand) Run(ctx context.Context, args []string) error {
 		title = strings.TrimSuffix(filepath.Base(path), ".md")
 	}
 
-	completedPath := filepath.Join(c.completedDir, filepath.Base(path))
-
 	gitCtx := context.WithoutCancel(ctx)
 
 	if err := c.promptManager.MoveToCompleted(ctx, path); err != nil {
 		return errors.Wrap(ctx, err, "move to completed")
 	}
+	completedPath := prompt.CompletedFilePath(c.completedDir, filepath.Base(path))
 
-	if err := c.releaser.CommitCompletedFile(gitCtx, completedPath); err != nil {
+	if err := c.releaser.CommitCompletedFile(gitCtx, completedPath, title); err != nil {
 		return errors.Wrap(ctx, err, "commit completed file")
 	}
 
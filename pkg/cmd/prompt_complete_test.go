@@ -122,6 +122,8 @@ var _ = Describe("PromptCompleteCommand", func() {
 
 			Expect(promptManager.MoveToCompletedCallCount()).To(Equal(1))
 			Expect(releaser.CommitCompletedFileCallCount()).To(Equal(1))
+			_, _, title := releaser.CommitCompletedFileArgsForCall(0)
+			Expect(title).To(Equal("Test"))
 			Expect(releaser.CommitOnlyCallCount()).To(Equal(1))
 		})
 	})
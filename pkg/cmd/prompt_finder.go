@@ -6,6 +6,14 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+	"github.com/bborbe/dark-factory/pkg/specnum"
 )
 
 // FindPromptFileInDirs resolves an <id> argument against one or more prompt directories.
@@ -22,3 +30,67 @@ func FindPromptFileInDirs(ctx context.Context, id string, dirs ...string) (strin
 func FindPromptFile(ctx context.Context, dir, id string) (string, error) {
 	return findFilesInDirs(ctx, id, "prompt", "read directory", []string{dir})
 }
+
+// FindPromptFileInDirsOrCompleted is FindPromptFileInDirs that also searches
+// completedDir, including the YYYY-MM subdirectories of the monthly completed layout.
+// dirs are searched before completedDir.
+func FindPromptFileInDirsOrCompleted(
+	ctx context.Context,
+	id string,
+	completedDir string,
+	dirs ...string,
+) (string, error) {
+	path, err := FindPromptFileInDirs(ctx, id, dirs...)
+	if err == nil {
+		return path, nil
+	}
+	cleanID := strings.TrimSuffix(id, ".md")
+	if path := prompt.FindCompletedFile(completedDir, cleanID+".md"); path != "" {
+		return path, nil
+	}
+	idNum := specnum.Parse(cleanID)
+	if idNum < 0 {
+		return "", err
+	}
+	var matches []string
+	for _, dir := range dirs {
+		dirMatches, err := collectNumericMatches(ctx, dir, idNum, "read directory")
+		if err != nil {
+			return "", err
+		}
+		matches = append(matches, dirMatches...)
+	}
+	completed, err := prompt.ListCompletedFiles(ctx, completedDir)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "read completed directory")
+	}
+	for _, path := range completed {
+		if specnum.Parse(filepath.Base(path)) == idNum {
+			matches = append(matches, path)
+		}
+	}
+	return pickMatch(ctx, id, "prompt", matches)
+}
+
+// readPromptFiles returns the paths of the .md files in dir. When dir is completedDir the
+// YYYY-MM subdirectories of the monthly completed layout are included. A missing dir yields nil.
+func readPromptFiles(ctx context.Context, dir, completedDir string) ([]string, error) {
+	if dir == completedDir {
+		return prompt.ListCompletedFiles(ctx, dir)
+	}
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, "read directory")
+	}
+	paths := make([]string, 0, len(dirEntries))
+	for _, entry := range dirEntries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	return paths, nil
+}
@@ -18,4 +18,11 @@ type PromptManager interface {
 	NormalizeFilenames(ctx context.Context, dir string) ([]prompt.Rename, error)
 	MoveToCompleted(ctx context.Context, path string) error
 	MoveToCancelled(ctx context.Context, path string) error
+	Reconcile(ctx context.Context) ([]prompt.ReconcileChange, error)
+	EnqueueBatch(ctx context.Context, entries []prompt.BatchEntry) ([]string, error)
+	Enqueue(ctx context.Context, title string, body string) (string, error)
+	Touch(ctx context.Context, path string) error
+	Reorder(ctx context.Context) ([]prompt.Rename, error)
+	Remove(ctx context.Context, path string) error
+	ListQueuedByTag(ctx context.Context, tag string) ([]prompt.Prompt, error)
 }
@@ -108,12 +108,15 @@ func (p *promptShowCommand) Run(ctx context.Context, args []string) error {
 
 	var path string
 	var findErr error
-	for _, dir := range []string{p.inboxDir, p.inProgressDir, p.completedDir} {
+	for _, dir := range []string{p.inboxDir, p.inProgressDir} {
 		path, findErr = FindPromptFile(ctx, dir, id)
 		if findErr == nil {
 			break
 		}
 	}
+	if findErr != nil {
+		path, findErr = FindPromptFileInDirsOrCompleted(ctx, id, p.completedDir)
+	}
 	if findErr != nil {
 		return errors.Errorf(ctx, "prompt not found: %s", id)
 	}
@@ -105,6 +105,21 @@ var _ = Describe("PromptShowCommand", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("shows prompt from a month directory of completedDir", func() {
+			monthDir := filepath.Join(completedDir, "2026-02")
+			Expect(os.MkdirAll(monthDir, 0750)).To(Succeed())
+			Expect(
+				os.WriteFile(
+					filepath.Join(monthDir, "004-archived-prompt.md"),
+					[]byte("---\nstatus: completed\ncompleted: 2026-02-01T00:00:00Z\n---\n# Done"),
+					0600,
+				),
+			).To(Succeed())
+
+			Expect(promptShowCmd.Run(ctx, []string{"4"})).To(Succeed())
+			Expect(promptShowCmd.Run(ctx, []string{"004-archived-prompt"})).To(Succeed())
+		})
+
 		It("includes log path when log file exists", func() {
 			promptFile := filepath.Join(inProgressDir, "004-logged-prompt.md")
 			Expect(
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/status"
+)
+
+//counterfeiter:generate -o ../../mocks/queue-command.go --fake-name QueueCommand . QueueCommand
+
+// QueueCommand lists the queued prompts in the order the daemon picks them.
+type QueueCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// queueCommand implements QueueCommand.
+type queueCommand struct {
+	promptManager PromptManager
+	checker       status.Checker
+	out           io.Writer
+}
+
+// NewQueueCommand creates a new QueueCommand.
+func NewQueueCommand(
+	promptManager PromptManager,
+	checker status.Checker,
+	out io.Writer,
+) QueueCommand {
+	return &queueCommand{
+		promptManager: promptManager,
+		checker:       checker,
+		out:           out,
+	}
+}
+
+// Run prints the queue, optionally restricted to prompts tagged with --tag <name>.
+// With --json the queue is printed as a JSON array of status.QueuedPrompt.
+func (q *queueCommand) Run(ctx context.Context, args []string) error {
+	tag, jsonOutput, err := parseQueueFlags(ctx, args)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		return q.outputJSON(ctx, tag)
+	}
+	prompts, err := q.promptManager.ListQueuedByTag(ctx, tag)
+	if err != nil {
+		return errors.Wrap(ctx, err, "list queued prompts")
+	}
+	if len(prompts) == 0 {
+		fmt.Fprintln(q.out, "no queued prompts")
+		return nil
+	}
+	fmt.Fprintf(q.out, "%-4s %s\n", "POS", "FILE")
+	for i, p := range prompts {
+		line := filepath.Base(p.Path)
+		pf, err := q.promptManager.Load(ctx, p.Path)
+		if err == nil && len(pf.Frontmatter.Tags) > 0 {
+			line += " [" + strings.Join(pf.Frontmatter.Tags, ", ") + "]"
+		}
+		fmt.Fprintf(q.out, "%-4d %s\n", i+1, line)
+	}
+	return nil
+}
+
+// outputJSON prints the queued prompts as a JSON array, [] when the queue is empty.
+func (q *queueCommand) outputJSON(ctx context.Context, tag string) error {
+	queued, err := q.checker.GetQueuedPrompts(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, "get queued prompts")
+	}
+	result := make([]status.QueuedPrompt, 0, len(queued))
+	if tag == "" {
+		result = append(result, queued...)
+	} else {
+		tagged, err := q.promptManager.ListQueuedByTag(ctx, tag)
+		if err != nil {
+			return errors.Wrap(ctx, err, "list queued prompts")
+		}
+		names := make(map[string]bool, len(tagged))
+		for _, p := range tagged {
+			names[filepath.Base(p.Path)] = true
+		}
+		for _, qp := range queued {
+			if names[qp.Name] {
+				result = append(result, qp)
+			}
+		}
+	}
+	encoder := json.NewEncoder(q.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// parseQueueFlags extracts --tag <name> and --json from args.
+// No other arguments are accepted.
+func parseQueueFlags(ctx context.Context, args []string) (string, bool, error) {
+	var tag string
+	var jsonOutput bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			jsonOutput = true
+		case "--tag":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return "", false, errors.Errorf(ctx, "--tag requires a value")
+			}
+			tag = args[i+1]
+			i++
+		default:
+			return "", false, errors.Errorf(ctx, "unexpected argument: %s", args[i])
+		}
+	}
+	return tag, jsonOutput, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+//counterfeiter:generate -o ../../mocks/queue-add-command.go --fake-name QueueAddCommand . QueueAddCommand
+
+// QueueAddCommand creates a new queued prompt file from the CLI.
+type QueueAddCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// queueAddCommand implements QueueAddCommand.
+type queueAddCommand struct {
+	promptManager PromptManager
+	stdin         io.Reader
+	out           io.Writer
+}
+
+// NewQueueAddCommand creates a new QueueAddCommand. stdin supplies the prompt body;
+// nil means no body is read.
+func NewQueueAddCommand(
+	promptManager PromptManager,
+	stdin io.Reader,
+	out io.Writer,
+) QueueAddCommand {
+	return &queueAddCommand{
+		promptManager: promptManager,
+		stdin:         stdin,
+		out:           out,
+	}
+}
+
+// Run enqueues a prompt titled by the single argument, with the body read from
+// stdin when set, and prints the created path. With --from-json it enqueues every
+// prompt of the JSON array in the named file instead.
+func (q *queueAddCommand) Run(ctx context.Context, args []string) error {
+	if len(args) > 0 && strings.HasPrefix(args[0], "--from-json") {
+		return q.runBatch(ctx, args)
+	}
+	if len(args) != 1 || strings.HasPrefix(args[0], "-") {
+		return errors.Errorf(ctx, "usage: dark-factory queue add \"<title>\"")
+	}
+	var body string
+	if q.stdin != nil {
+		content, err := io.ReadAll(q.stdin)
+		if err != nil {
+			return errors.Wrap(ctx, err, "read prompt body from stdin")
+		}
+		body = string(content)
+	}
+	path, err := q.promptManager.Enqueue(ctx, args[0], body)
+	if err != nil {
+		return errors.Wrap(ctx, err, "enqueue prompt")
+	}
+	fmt.Fprintln(q.out, path)
+	return nil
+}
+
+// runBatch enqueues every prompt of the JSON array named by --from-json and prints
+// the created paths. The batch is all-or-nothing: a malformed file or invalid entry
+// writes no prompt.
+func (q *queueAddCommand) runBatch(ctx context.Context, args []string) error {
+	jsonPath, err := parseFromJSONFlag(ctx, args)
+	if err != nil {
+		return err
+	}
+	entries, err := readBatchEntries(ctx, jsonPath)
+	if err != nil {
+		return err
+	}
+	paths, err := q.promptManager.EnqueueBatch(ctx, entries)
+	if err != nil {
+		return errors.Wrap(ctx, err, "enqueue batch")
+	}
+	for _, path := range paths {
+		fmt.Fprintln(q.out, path)
+	}
+	return nil
+}
+
+// parseFromJSONFlag extracts the file of --from-json <file> or --from-json=<file>.
+func parseFromJSONFlag(ctx context.Context, args []string) (string, error) {
+	var path string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--from-json":
+			if i+1 >= len(args) {
+				return "", errors.Errorf(ctx, "--from-json requires a file argument")
+			}
+			path = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--from-json="):
+			path = strings.TrimPrefix(args[i], "--from-json=")
+		default:
+			return "", errors.Errorf(ctx, "unknown argument: %s", args[i])
+		}
+	}
+	if path == "" {
+		return "", errors.Errorf(ctx, "usage: dark-factory queue add --from-json <file>")
+	}
+	return path, nil
+}
+
+// readBatchEntries decodes the JSON array of prompts in path. Unknown fields are rejected
+// so a typo such as "prioirty" fails loudly instead of being silently dropped.
+func readBatchEntries(ctx context.Context, path string) ([]prompt.BatchEntry, error) {
+	// #nosec G304 -- path is an operator-supplied CLI argument
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read %s", path)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var entries []prompt.BatchEntry
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, errors.Wrapf(ctx, err, "parse %s", path)
+	}
+	return entries, nil
+}
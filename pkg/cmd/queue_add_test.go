@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("QueueAddCommand", func() {
+	var (
+		ctx      context.Context
+		queueDir string
+		mgr      *prompt.Manager
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "prompts")
+		mgr = prompt.NewManager(
+			"", queueDir, filepath.Join(tempDir, "completed"), "",
+			nil,
+			libtime.NewCurrentDateTime(),
+		)
+		out = &bytes.Buffer{}
+	})
+
+	It("creates a prompt file named after the title and prints its path", func() {
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		Expect(os.WriteFile(
+			filepath.Join(queueDir, "001-existing.md"),
+			[]byte("---\nstatus: approved\n---\n\n# Existing\n"),
+			0600,
+		)).To(Succeed())
+		addCmd := cmd.NewQueueAddCommand(mgr, nil, out)
+
+		Expect(addCmd.Run(ctx, []string{"Title here"})).To(Succeed())
+
+		path := filepath.Join(queueDir, "002-title-here.md")
+		Expect(out.String()).To(Equal(path + "\n"))
+		content, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(HavePrefix("---\nstatus: approved\n"))
+		Expect(string(content)).To(HaveSuffix("---\n\n# Title here\n"))
+	})
+
+	It("writes the body read from stdin", func() {
+		addCmd := cmd.NewQueueAddCommand(mgr, strings.NewReader("Do the thing.\n"), out)
+
+		Expect(addCmd.Run(ctx, []string{"Piped prompt"})).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(queueDir, "001-piped-prompt.md"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(HaveSuffix("# Piped prompt\n\nDo the thing.\n"))
+	})
+
+	DescribeTable("rejects invalid arguments",
+		func(args []string) {
+			addCmd := cmd.NewQueueAddCommand(mgr, nil, out)
+
+			err := addCmd.Run(ctx, args)
+			Expect(err).To(MatchError(ContainSubstring("usage: dark-factory queue add")))
+		},
+		Entry("no title", []string{}),
+		Entry("two titles", []string{"One", "Two"}),
+		Entry("flag", []string{"--help"}),
+	)
+})
+
+var _ = Describe("QueueAddCommand --from-json", func() {
+	var (
+		ctx           context.Context
+		tempDir       string
+		promptManager *mocks.CmdPromptManager
+		addCmd        cmd.QueueAddCommand
+		out           *bytes.Buffer
+	)
+
+	writeJSON := func(content string) string {
+		path := filepath.Join(tempDir, "prompts.json")
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		promptManager = &mocks.CmdPromptManager{}
+		out = &bytes.Buffer{}
+		addCmd = cmd.NewQueueAddCommand(promptManager, nil, out)
+	})
+
+	It("enqueues all entries of a multi-entry batch", func() {
+		path := writeJSON(`[
+			{"title": "Fix bug", "body": "Fix it.", "tags": ["bugfix"], "priority": 5},
+			{"title": "Write docs", "body": "Document it."}
+		]`)
+		promptManager.EnqueueBatchReturns([]string{"/q/001-fix-bug.md", "/q/002-write-docs.md"}, nil)
+
+		Expect(addCmd.Run(ctx, []string{"--from-json", path})).To(Succeed())
+
+		Expect(out.String()).To(Equal("/q/001-fix-bug.md\n/q/002-write-docs.md\n"))
+		Expect(promptManager.EnqueueBatchCallCount()).To(Equal(1))
+		_, entries := promptManager.EnqueueBatchArgsForCall(0)
+		Expect(entries).To(Equal([]prompt.BatchEntry{
+			{Title: "Fix bug", Body: "Fix it.", Tags: []string{"bugfix"}, Priority: 5},
+			{Title: "Write docs", Body: "Document it."},
+		}))
+	})
+
+	It("accepts --from-json=<file>", func() {
+		path := writeJSON(`[{"title": "One", "body": "x"}]`)
+
+		Expect(addCmd.Run(ctx, []string{"--from-json=" + path})).To(Succeed())
+		Expect(promptManager.EnqueueBatchCallCount()).To(Equal(1))
+	})
+
+	It("rejects unknown fields without enqueueing", func() {
+		path := writeJSON(`[{"title": "One", "body": "x", "prioirty": 3}]`)
+
+		Expect(addCmd.Run(ctx, []string{"--from-json", path})).NotTo(Succeed())
+		Expect(promptManager.EnqueueBatchCallCount()).To(Equal(0))
+	})
+
+	It("rejects malformed JSON without enqueueing", func() {
+		path := writeJSON(`[{"title": "One"`)
+
+		Expect(addCmd.Run(ctx, []string{"--from-json", path})).NotTo(Succeed())
+		Expect(promptManager.EnqueueBatchCallCount()).To(Equal(0))
+	})
+
+	It("requires a file for --from-json", func() {
+		Expect(addCmd.Run(ctx, []string{"--from-json"})).NotTo(Succeed())
+		Expect(addCmd.Run(ctx, []string{"--from-json="})).NotTo(Succeed())
+		Expect(promptManager.EnqueueBatchCallCount()).To(Equal(0))
+	})
+
+	It("returns the enqueue error", func() {
+		path := writeJSON(`[{"title": "One", "body": "x"}]`)
+		promptManager.EnqueueBatchReturns(nil, errors.New("entry 1: body is required"))
+
+		err := addCmd.Run(ctx, []string{"--from-json", path})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("enqueue batch"))
+	})
+})
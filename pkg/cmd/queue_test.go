@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/project"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+	"github.com/bborbe/dark-factory/pkg/status"
+	"github.com/bborbe/dark-factory/pkg/subproc"
+)
+
+var _ = Describe("QueueCommand", func() {
+	var (
+		ctx      context.Context
+		queueDir string
+		out      *bytes.Buffer
+		queueCmd cmd.QueueCommand
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		queueDir = GinkgoT().TempDir()
+		write := func(name, frontmatter string) {
+			Expect(os.WriteFile(
+				filepath.Join(queueDir, name),
+				[]byte("---\n"+frontmatter+"---\n# Prompt "+name+"\n"),
+				0600,
+			)).To(Succeed())
+		}
+		write("001-plain.md", "status: approved\n")
+		write("002-bugfix.md", "status: approved\ntags:\n  - bugfix\n")
+		write("003-docs.md", "status: approved\ntags:\n  - docs\n  - bugfix\n")
+		write("004-failed.md", "status: failed\ntags:\n  - bugfix\n")
+
+		out = &bytes.Buffer{}
+		mgr := prompt.NewManager("", queueDir, "", "", nil, libtime.NewCurrentDateTime())
+		queueCmd = cmd.NewQueueCommand(
+			mgr,
+			status.NewChecker(
+				project.Name("test"),
+				"",
+				queueDir,
+				"",
+				"",
+				"",
+				"",
+				0,
+				mgr,
+				nil,
+				0,
+				0,
+				libtime.NewCurrentDateTime(),
+				subproc.NewRunner(),
+				nil,
+			),
+			out,
+		)
+	})
+
+	It("lists every queued prompt in pick order", func() {
+		Expect(queueCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(out.String()).To(Equal("POS  FILE\n" +
+			"1    001-plain.md\n" +
+			"2    002-bugfix.md [bugfix]\n" +
+			"3    003-docs.md [docs, bugfix]\n"))
+	})
+
+	It("lists only prompts with the given tag", func() {
+		Expect(queueCmd.Run(ctx, []string{"--tag", "docs"})).To(Succeed())
+		Expect(out.String()).To(Equal("POS  FILE\n" +
+			"1    003-docs.md [docs, bugfix]\n"))
+	})
+
+	It("reports an empty result", func() {
+		Expect(queueCmd.Run(ctx, []string{"--tag", "refactor"})).To(Succeed())
+		Expect(out.String()).To(Equal("no queued prompts\n"))
+	})
+
+	It("requires a value for --tag", func() {
+		err := queueCmd.Run(ctx, []string{"--tag"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--tag requires a value"))
+	})
+
+	It("prints the queue as a JSON array with titles from the prompt heading", func() {
+		Expect(queueCmd.Run(ctx, []string{"--json"})).To(Succeed())
+
+		var queued []status.QueuedPrompt
+		Expect(json.Unmarshal(out.Bytes(), &queued)).To(Succeed())
+		Expect(queued).To(HaveLen(3))
+		Expect(queued[0].Name).To(Equal("001-plain.md"))
+		Expect(queued[0].Title).To(Equal("Prompt 001-plain.md"))
+		Expect(queued[0].Size).To(BeNumerically(">", 0))
+		Expect(queued[2].Name).To(Equal("003-docs.md"))
+		Expect(queued[2].Title).To(Equal("Prompt 003-docs.md"))
+
+		var raw []map[string]any
+		Expect(json.Unmarshal(out.Bytes(), &raw)).To(Succeed())
+		Expect(raw[0]).To(HaveKey("name"))
+		Expect(raw[0]).To(HaveKey("title"))
+		Expect(raw[0]).To(HaveKey("size"))
+	})
+
+	It("restricts the JSON array to prompts with the given tag", func() {
+		Expect(queueCmd.Run(ctx, []string{"--json", "--tag", "docs"})).To(Succeed())
+
+		var queued []status.QueuedPrompt
+		Expect(json.Unmarshal(out.Bytes(), &queued)).To(Succeed())
+		Expect(queued).To(HaveLen(1))
+		Expect(queued[0].Name).To(Equal("003-docs.md"))
+	})
+
+	It("prints an empty JSON array for an empty result", func() {
+		Expect(queueCmd.Run(ctx, []string{"--tag", "refactor", "--json"})).To(Succeed())
+		Expect(out.String()).To(Equal("[]\n"))
+	})
+
+	It("rejects unknown arguments", func() {
+		err := queueCmd.Run(ctx, []string{"--all"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unexpected argument"))
+	})
+})
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o ../../mocks/reconcile-command.go --fake-name ReconcileCommand . ReconcileCommand
+
+// ReconcileCommand executes the reconcile subcommand.
+type ReconcileCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// reconcileCommand implements ReconcileCommand.
+type reconcileCommand struct {
+	promptManager PromptManager
+}
+
+// NewReconcileCommand creates a new ReconcileCommand.
+func NewReconcileCommand(
+	promptManager PromptManager,
+) ReconcileCommand {
+	return &reconcileCommand{
+		promptManager: promptManager,
+	}
+}
+
+// Run fixes prompt files whose status does not match their directory and reports each change.
+func (r *reconcileCommand) Run(ctx context.Context, args []string) error {
+	changes, err := r.promptManager.Reconcile(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, "reconcile prompts")
+	}
+	if len(changes) == 0 {
+		fmt.Printf("nothing to reconcile\n")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Printf(
+			"%s: %s (was %q)\n",
+			change.Action,
+			filepath.Base(change.Path),
+			change.FromStatus,
+		)
+	}
+	fmt.Printf("reconciled %d prompt(s)\n", len(changes))
+	return nil
+}
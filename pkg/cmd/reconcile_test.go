@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ReconcileCommand", func() {
+	var (
+		ctx           context.Context
+		promptManager *mocks.CmdPromptManager
+		reconcileCmd  cmd.ReconcileCommand
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		promptManager = &mocks.CmdPromptManager{}
+		reconcileCmd = cmd.NewReconcileCommand(promptManager)
+	})
+
+	It("succeeds when there is nothing to reconcile", func() {
+		promptManager.ReconcileReturns(nil, nil)
+
+		Expect(reconcileCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(promptManager.ReconcileCallCount()).To(Equal(1))
+	})
+
+	It("reports every change", func() {
+		promptManager.ReconcileReturns([]prompt.ReconcileChange{
+			{
+				Path:       "/tmp/completed/001-a.md",
+				FromStatus: "approved",
+				Action:     prompt.ReconcileActionSetCompleted,
+			},
+			{
+				Path:       "/tmp/completed/002-b.md",
+				FromStatus: "completed",
+				Action:     prompt.ReconcileActionMoveToCompleted,
+			},
+		}, nil)
+
+		Expect(reconcileCmd.Run(ctx, []string{})).To(Succeed())
+	})
+
+	It("returns an error when reconcile fails", func() {
+		promptManager.ReconcileReturns(nil, errors.New("boom"))
+
+		err := reconcileCmd.Run(ctx, []string{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("reconcile prompts"))
+	})
+})
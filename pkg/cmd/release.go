@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+)
+
+//counterfeiter:generate -o ../../mocks/release-command.go --fake-name ReleaseCommand . ReleaseCommand
+
+// ReleaseCommand reports what the next release would look like without changing anything.
+type ReleaseCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// ReleasePlan is the structured result of release plan, preview and next.
+// The JSON form is identical for all three so CI can share one decoder.
+type ReleasePlan struct {
+	Version   string   `json:"version"`
+	Bump      string   `json:"bump"`
+	Changelog []string `json:"changelog"`
+}
+
+// releaseView selects the text rendering of a ReleasePlan.
+type releaseView int
+
+const (
+	releaseViewPlan releaseView = iota
+	releaseViewPreview
+	releaseViewNext
+)
+
+// renderedReleasePlan pairs a ReleasePlan with the text view of the running subcommand.
+type renderedReleasePlan struct {
+	ReleasePlan
+	view releaseView
+}
+
+// RenderText writes the human-readable form for the selected view.
+func (r renderedReleasePlan) RenderText(w io.Writer) error {
+	var err error
+	switch r.view {
+	case releaseViewNext:
+		_, err = fmt.Fprintln(w, r.Version)
+	case releaseViewPreview:
+		err = writeChangelogEntry(w, r.ReleasePlan)
+	default:
+		if _, err = fmt.Fprintf(w, "Next version: %s (%s bump)\n\n", r.Version, r.Bump); err == nil {
+			err = writeChangelogEntry(w, r.ReleasePlan)
+		}
+	}
+	return err
+}
+
+// writeChangelogEntry writes the CHANGELOG section the release would create.
+func writeChangelogEntry(w io.Writer, plan ReleasePlan) error {
+	if _, err := fmt.Fprintf(w, "## %s\n\n", plan.Version); err != nil {
+		return err
+	}
+	if len(plan.Changelog) == 0 {
+		_, err := fmt.Fprintln(w, "(no unreleased changelog entries)")
+		return err
+	}
+	for _, entry := range plan.Changelog {
+		if _, err := fmt.Fprintln(w, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewReleasePlanCommand creates a command printing version, bump and changelog entry.
+func NewReleasePlanCommand(releaser git.Releaser, dir string, out io.Writer) ReleaseCommand {
+	return &releaseCommand{releaser: releaser, dir: dir, out: out, view: releaseViewPlan}
+}
+
+// NewReleasePreviewCommand creates a command printing the changelog entry of the next release.
+func NewReleasePreviewCommand(releaser git.Releaser, dir string, out io.Writer) ReleaseCommand {
+	return &releaseCommand{releaser: releaser, dir: dir, out: out, view: releaseViewPreview}
+}
+
+// NewReleaseNextCommand creates a command printing only the next version.
+func NewReleaseNextCommand(releaser git.Releaser, dir string, out io.Writer) ReleaseCommand {
+	return &releaseCommand{releaser: releaser, dir: dir, out: out, view: releaseViewNext}
+}
+
+// releaseCommand implements ReleaseCommand.
+type releaseCommand struct {
+	releaser git.Releaser
+	dir      string
+	out      io.Writer
+	view     releaseView
+}
+
+// Run computes the release plan and writes it in the format selected by --format.
+func (r *releaseCommand) Run(ctx context.Context, args []string) error {
+	format, remaining, err := ParseFormatFlag(ctx, args)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return errors.Errorf(ctx, "unexpected arguments: %v", remaining)
+	}
+
+	bump := r.releaser.DetermineBump(ctx)
+	version, err := r.releaser.GetNextVersion(ctx, bump)
+	if err != nil {
+		return errors.Wrap(ctx, err, "get next version")
+	}
+	changelog := git.UnreleasedEntries(ctx, r.dir)
+	if changelog == nil {
+		changelog = []string{}
+	}
+
+	return NewOutputWriter(r.out, format).Write(ctx, renderedReleasePlan{
+		ReleasePlan: ReleasePlan{
+			Version:   version,
+			Bump:      bump.String(),
+			Changelog: changelog,
+		},
+		view: r.view,
+	})
+}
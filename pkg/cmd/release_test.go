@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/git"
+)
+
+var _ = Describe("ReleaseCommand", func() {
+	var (
+		ctx      context.Context
+		dir      string
+		out      *bytes.Buffer
+		releaser *mocks.Releaser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = GinkgoT().TempDir()
+		out = &bytes.Buffer{}
+		releaser = &mocks.Releaser{}
+		releaser.DetermineBumpReturns(git.MinorBump)
+		releaser.GetNextVersionReturns("v1.3.0", nil)
+		Expect(os.WriteFile(
+			filepath.Join(dir, "CHANGELOG.md"),
+			[]byte("# Changelog\n\n## Unreleased\n\n- feat: add thing\n- fix: repair other\n\n## v1.2.0\n\n- feat: old\n"),
+			0600,
+		)).To(Succeed())
+	})
+
+	decode := func() cmd.ReleasePlan {
+		var plan cmd.ReleasePlan
+		decoder := json.NewDecoder(out)
+		decoder.DisallowUnknownFields()
+		Expect(decoder.Decode(&plan)).To(Succeed())
+		return plan
+	}
+
+	expected := cmd.ReleasePlan{
+		Version:   "v1.3.0",
+		Bump:      "minor",
+		Changelog: []string{"- feat: add thing", "- fix: repair other"},
+	}
+
+	It("emits the plan as json", func() {
+		err := cmd.NewReleasePlanCommand(releaser, dir, out).Run(ctx, []string{"--format", "json"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decode()).To(Equal(expected))
+
+		_, bump := releaser.GetNextVersionArgsForCall(0)
+		Expect(bump).To(Equal(git.MinorBump))
+	})
+
+	It("emits the same structure for preview and next", func() {
+		Expect(cmd.NewReleasePreviewCommand(releaser, dir, out).Run(ctx, []string{"--format=json"})).To(Succeed())
+		Expect(decode()).To(Equal(expected))
+
+		out.Reset()
+		Expect(cmd.NewReleaseNextCommand(releaser, dir, out).Run(ctx, []string{"--format=json"})).To(Succeed())
+		Expect(decode()).To(Equal(expected))
+	})
+
+	It("emits an empty changelog list when there are no unreleased entries", func() {
+		Expect(os.Remove(filepath.Join(dir, "CHANGELOG.md"))).To(Succeed())
+		releaser.DetermineBumpReturns(git.PatchBump)
+
+		Expect(cmd.NewReleasePlanCommand(releaser, dir, out).Run(ctx, []string{"--format", "json"})).To(Succeed())
+		Expect(out.String()).To(ContainSubstring(`"changelog": []`))
+		Expect(decode().Bump).To(Equal("patch"))
+	})
+
+	It("prints text by default", func() {
+		Expect(cmd.NewReleasePlanCommand(releaser, dir, out).Run(ctx, nil)).To(Succeed())
+		Expect(out.String()).To(Equal(
+			"Next version: v1.3.0 (minor bump)\n\n## v1.3.0\n\n- feat: add thing\n- fix: repair other\n",
+		))
+	})
+
+	It("prints only the version for next", func() {
+		Expect(cmd.NewReleaseNextCommand(releaser, dir, out).Run(ctx, []string{"--format", "text"})).To(Succeed())
+		Expect(out.String()).To(Equal("v1.3.0\n"))
+	})
+
+	It("prints the changelog entry for preview", func() {
+		Expect(cmd.NewReleasePreviewCommand(releaser, dir, out).Run(ctx, nil)).To(Succeed())
+		Expect(out.String()).To(Equal("## v1.3.0\n\n- feat: add thing\n- fix: repair other\n"))
+	})
+
+	It("rejects an unknown format", func() {
+		err := cmd.NewReleasePlanCommand(releaser, dir, out).Run(ctx, []string{"--format", "yaml"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown --format "yaml"`))
+		Expect(releaser.GetNextVersionCallCount()).To(Equal(0))
+	})
+
+	It("rejects --format without a value", func() {
+		err := cmd.NewReleasePlanCommand(releaser, dir, out).Run(ctx, []string{"--format"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns error when the next version cannot be determined", func() {
+		releaser.GetNextVersionReturns("", errors.New("no tags"))
+
+		err := cmd.NewReleasePlanCommand(releaser, dir, out).Run(ctx, []string{"--format", "json"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("get next version"))
+		Expect(out.Len()).To(Equal(0))
+	})
+})
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o ../../mocks/remove-command.go --fake-name RemoveCommand . RemoveCommand
+
+// RemoveCommand executes the remove subcommand.
+type RemoveCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// removeCommand implements RemoveCommand.
+type removeCommand struct {
+	queueDir      string
+	completedDir  string
+	promptManager PromptManager
+}
+
+// NewRemoveCommand creates a new RemoveCommand.
+// completedDir is searched too, so removing a completed prompt is refused
+// with its status instead of reporting the file as missing.
+func NewRemoveCommand(
+	queueDir string,
+	completedDir string,
+	promptManager PromptManager,
+) RemoveCommand {
+	return &removeCommand{
+		queueDir:      queueDir,
+		completedDir:  completedDir,
+		promptManager: promptManager,
+	}
+}
+
+// Run deletes a queued or failed prompt.
+func (r *removeCommand) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf(ctx, "usage: dark-factory remove <file>")
+	}
+	path, err := FindPromptFileInDirsOrCompleted(ctx, args[0], r.completedDir, r.queueDir)
+	if err != nil {
+		return errors.Errorf(ctx, "file not found: %s", args[0])
+	}
+	if err := r.promptManager.Remove(ctx, path); err != nil {
+		return errors.Wrap(ctx, err, "remove prompt")
+	}
+	fmt.Printf("removed: %s\n", filepath.Base(path))
+	return nil
+}
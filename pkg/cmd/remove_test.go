@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+)
+
+var _ = Describe("RemoveCommand", func() {
+	var (
+		tempDir       string
+		queueDir      string
+		completedDir  string
+		promptManager *mocks.CmdPromptManager
+		removeCmd     cmd.RemoveCommand
+		ctx           context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "remove-cmd-test-*")
+		Expect(err).NotTo(HaveOccurred())
+
+		queueDir = filepath.Join(tempDir, "queue")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(completedDir, 0750)).To(Succeed())
+
+		promptManager = &mocks.CmdPromptManager{}
+		removeCmd = cmd.NewRemoveCommand(queueDir, completedDir, promptManager)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("removes a queued prompt found by number", func() {
+		path := filepath.Join(queueDir, "007-abandon.md")
+		Expect(os.WriteFile(path, []byte("---\nstatus: approved\n---\n# Abandon\n"), 0600)).
+			To(Succeed())
+
+		Expect(removeCmd.Run(ctx, []string{"7"})).To(Succeed())
+
+		Expect(promptManager.RemoveCallCount()).To(Equal(1))
+		_, removed := promptManager.RemoveArgsForCall(0)
+		Expect(removed).To(Equal(path))
+	})
+
+	It("passes prompts of the completed dir to the status guard", func() {
+		path := filepath.Join(completedDir, "003-done.md")
+		Expect(os.WriteFile(path, []byte("---\nstatus: completed\n---\n# Done\n"), 0600)).
+			To(Succeed())
+		promptManager.RemoveReturns(stderrors.New("cannot remove 003-done.md"))
+
+		err := removeCmd.Run(ctx, []string{"003-done.md"})
+		Expect(err).To(MatchError(ContainSubstring("cannot remove 003-done.md")))
+		_, removed := promptManager.RemoveArgsForCall(0)
+		Expect(removed).To(Equal(path))
+	})
+
+	It("passes prompts of a completed month directory to the status guard", func() {
+		monthDir := filepath.Join(completedDir, "2026-02")
+		Expect(os.MkdirAll(monthDir, 0750)).To(Succeed())
+		path := filepath.Join(monthDir, "004-archived.md")
+		Expect(os.WriteFile(path, []byte("---\nstatus: completed\n---\n# Done\n"), 0600)).
+			To(Succeed())
+		promptManager.RemoveReturns(stderrors.New("cannot remove 004-archived.md"))
+
+		err := removeCmd.Run(ctx, []string{"4"})
+		Expect(err).To(MatchError(ContainSubstring("cannot remove 004-archived.md")))
+		_, removed := promptManager.RemoveArgsForCall(0)
+		Expect(removed).To(Equal(path))
+	})
+
+	It("returns an error when the file does not exist", func() {
+		err := removeCmd.Run(ctx, []string{"missing.md"})
+		Expect(err).To(MatchError(ContainSubstring("file not found")))
+		Expect(promptManager.RemoveCallCount()).To(Equal(0))
+	})
+
+	It("returns a usage error without arguments", func() {
+		err := removeCmd.Run(ctx, []string{})
+		Expect(err).To(MatchError(ContainSubstring("usage")))
+	})
+})
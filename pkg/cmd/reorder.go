@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o ../../mocks/reorder-command.go --fake-name ReorderCommand . ReorderCommand
+
+// ReorderCommand executes the reorder subcommand.
+type ReorderCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// reorderCommand implements ReorderCommand.
+type reorderCommand struct {
+	promptManager PromptManager
+}
+
+// NewReorderCommand creates a new ReorderCommand.
+func NewReorderCommand(
+	promptManager PromptManager,
+) ReorderCommand {
+	return &reorderCommand{
+		promptManager: promptManager,
+	}
+}
+
+// Run renumbers the queued prompts to a contiguous sequence and reports each rename.
+func (r *reorderCommand) Run(ctx context.Context, args []string) error {
+	renames, err := r.promptManager.Reorder(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, "reorder prompts")
+	}
+	if len(renames) == 0 {
+		fmt.Printf("queue already contiguous\n")
+		return nil
+	}
+	for _, rename := range renames {
+		fmt.Printf("renamed: %s -> %s\n", filepath.Base(rename.OldPath), filepath.Base(rename.NewPath))
+	}
+	fmt.Printf("reordered %d prompt(s)\n", len(renames))
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	stderrors "errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ReorderCommand", func() {
+	var (
+		ctx           context.Context
+		promptManager *mocks.CmdPromptManager
+		reorderCmd    cmd.ReorderCommand
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		promptManager = &mocks.CmdPromptManager{}
+		reorderCmd = cmd.NewReorderCommand(promptManager)
+	})
+
+	It("reorders the queue", func() {
+		promptManager.ReorderReturns([]prompt.Rename{
+			{OldPath: "/q/005-b.md", NewPath: "/q/002-b.md"},
+		}, nil)
+
+		Expect(reorderCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(promptManager.ReorderCallCount()).To(Equal(1))
+	})
+
+	It("succeeds when the queue is already contiguous", func() {
+		Expect(reorderCmd.Run(ctx, []string{})).To(Succeed())
+	})
+
+	It("returns the reorder error", func() {
+		promptManager.ReorderReturns(nil, stderrors.New("executing"))
+
+		err := reorderCmd.Run(ctx, []string{})
+		Expect(err).To(MatchError(ContainSubstring("reorder prompts")))
+	})
+})
@@ -41,6 +41,7 @@ func NewRequeueCommand(
 }
 
 // Run executes the requeue command.
+// With --failed and a file, only that prompt is requeued, and only if it failed.
 func (r *requeueCommand) Run(ctx context.Context, args []string) error {
 	failedOnly := false
 	var filename string
@@ -53,6 +54,10 @@ func (r *requeueCommand) Run(ctx context.Context, args []string) error {
 		}
 	}
 
+	if failedOnly && filename != "" {
+		return r.requeueFailedFile(ctx, filename)
+	}
+
 	if failedOnly {
 		return r.requeueFailed(ctx)
 	}
@@ -86,6 +91,37 @@ func (r *requeueCommand) requeueFile(ctx context.Context, id string) error {
 	return nil
 }
 
+// requeueFailedFile requeues a specific file in the queue directory if it failed.
+func (r *requeueCommand) requeueFailedFile(ctx context.Context, id string) error {
+	path, err := FindPromptFile(ctx, r.queueDir, id)
+	if err != nil {
+		return errors.Errorf(ctx, "file not found: %s", id)
+	}
+
+	pf, err := r.promptManager.Load(ctx, path)
+	if err != nil {
+		return errors.Wrap(ctx, err, "load prompt")
+	}
+	if pf.Frontmatter.Status != string(prompt.FailedPromptStatus) {
+		return errors.Errorf(
+			ctx,
+			"prompt %s is not failed (status: %s)",
+			filepath.Base(path),
+			pf.Frontmatter.Status,
+		)
+	}
+
+	pf.MarkApproved()
+	pf.Frontmatter.RetryCount = 0 // reset auto-retry budget on manual re-queue
+	if err := pf.Save(ctx); err != nil {
+		return errors.Wrap(ctx, err, "save prompt")
+	}
+
+	fmt.Printf("requeued: %s\n", filepath.Base(path))
+	fmt.Println("requeued 1 failed prompt")
+	return nil
+}
+
 // requeueFailed requeues all failed prompts in the queue directory.
 func (r *requeueCommand) requeueFailed(ctx context.Context) error {
 	entries, err := os.ReadDir(r.queueDir)
@@ -118,6 +154,8 @@ func (r *requeueCommand) requeueFailed(ctx context.Context) error {
 
 	if requeued == 0 {
 		fmt.Println("no failed prompts found")
+		return nil
 	}
+	fmt.Printf("requeued %d failed prompt(s)\n", requeued)
 	return nil
 }
@@ -166,6 +166,58 @@ var _ = Describe("RequeueCommand", func() {
 		})
 	})
 
+	Describe("Retry a single prompt (requeue --failed <id>)", func() {
+		BeforeEach(func() {
+			err := os.WriteFile(
+				filepath.Join(queueDir, "080-failed.md"),
+				[]byte("---\nstatus: failed\n---\n# Failed 1"),
+				0600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = os.WriteFile(
+				filepath.Join(queueDir, "081-failed.md"),
+				[]byte("---\nstatus: failed\n---\n# Failed 2"),
+				0600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = os.WriteFile(
+				filepath.Join(queueDir, "082-executing.md"),
+				[]byte("---\nstatus: executing\n---\n# Executing"),
+				0600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("requeues only the named failed prompt", func() {
+			err := requeueCmd.Run(ctx, []string{"--failed", "080"})
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := os.ReadFile(filepath.Join(queueDir, "080-failed.md"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("status: approved"))
+
+			content, err = os.ReadFile(filepath.Join(queueDir, "081-failed.md"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("status: failed"))
+		})
+
+		It("rejects a named prompt that is not failed and leaves it unchanged", func() {
+			err := requeueCmd.Run(ctx, []string{"--failed", "082"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not failed (status: executing)"))
+
+			content, err := os.ReadFile(filepath.Join(queueDir, "082-executing.md"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("status: executing"))
+		})
+
+		It("returns error for a nonexistent prompt", func() {
+			err := requeueCmd.Run(ctx, []string{"--failed", "999"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("file not found"))
+		})
+	})
+
 	Describe("retryCount reset on re-queue", func() {
 		It("requeueFile resets retryCount to 0", func() {
 			testFile := filepath.Join(queueDir, "080-retry.md")
@@ -68,6 +68,11 @@ func findFilesInDirs(
 		}
 		matches = append(matches, dirMatches...)
 	}
+	return pickMatch(ctx, id, kind, matches)
+}
+
+// pickMatch returns the single numeric match, or a not-found or ambiguity error.
+func pickMatch(ctx context.Context, id, kind string, matches []string) (string, error) {
 	switch len(matches) {
 	case 0:
 		return "", errors.Errorf(ctx, "%s not found: %s", kind, id)
@@ -36,7 +36,8 @@ func NewStatusCommand(checker status.Checker, formatter status.Formatter) Status
 	}
 }
 
-// Run executes the status command.
+// Run executes the status command. With --watch it re-renders the status every
+// --interval (default 2s) until ctx is cancelled.
 func (s *statusCommand) Run(ctx context.Context, args []string) error {
 	// Check for --json flag
 	jsonOutput := false
@@ -47,6 +48,20 @@ func (s *statusCommand) Run(ctx context.Context, args []string) error {
 		}
 	}
 
+	watchOpts, err := parseWatchArgs(ctx, args)
+	if err != nil {
+		return err
+	}
+	if watchOpts.enabled {
+		return watch(ctx, os.Stdout, watchOpts.interval, func(ctx context.Context) error {
+			return s.render(ctx, jsonOutput)
+		})
+	}
+	return s.render(ctx, jsonOutput)
+}
+
+// render prints the current status once.
+func (s *statusCommand) render(ctx context.Context, jsonOutput bool) error {
 	// Get status
 	st, err := s.checker.GetStatus(ctx)
 	if err != nil {
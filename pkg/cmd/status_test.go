@@ -65,5 +65,35 @@ var _ = Describe("StatusCommand", func() {
 			err := statusCommand.Run(ctx, []string{})
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("re-renders the status until the context is cancelled with --watch", func() {
+			watchCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			checker.GetStatusStub = func(context.Context) (*status.Status, error) {
+				if checker.GetStatusCallCount() == 3 {
+					cancel()
+				}
+				return testStatus, nil
+			}
+
+			err := statusCommand.Run(watchCtx, []string{"--watch", "--interval", "10ms"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checker.GetStatusCallCount()).To(Equal(3))
+			Expect(formatter.FormatCallCount()).To(Equal(3))
+		})
+
+		It("stops watching when the checker fails", func() {
+			checker.GetStatusReturns(nil, fmt.Errorf("checker error"))
+
+			err := statusCommand.Run(ctx, []string{"--watch", "--interval", "10ms"})
+			Expect(err).To(HaveOccurred())
+			Expect(checker.GetStatusCallCount()).To(Equal(1))
+		})
+
+		It("returns error for an invalid --interval", func() {
+			err := statusCommand.Run(ctx, []string{"--watch", "--interval", "soon"})
+			Expect(err).To(MatchError(ContainSubstring("--interval")))
+			Expect(checker.GetStatusCallCount()).To(Equal(0))
+		})
 	})
 })
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"syscall"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/server"
+)
+
+//counterfeiter:generate -o ../../mocks/stop-command.go --fake-name StopCommand . StopCommand
+
+// StopCommand executes the stop subcommand.
+type StopCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// stopCommand implements StopCommand.
+type stopCommand struct {
+	baseURL     string
+	credentials *server.Credentials
+	client      *http.Client
+}
+
+// NewStopCommand creates a new StopCommand that posts to baseURL + "/shutdown".
+// An empty baseURL means the daemon runs without REST API (serverPort unset).
+// credentials may be nil when the API has no auth.
+func NewStopCommand(
+	baseURL string,
+	credentials *server.Credentials,
+	client *http.Client,
+) StopCommand {
+	return &stopCommand{
+		baseURL:     baseURL,
+		credentials: credentials,
+		client:      client,
+	}
+}
+
+// Run asks the running daemon to finish the current prompt and exit.
+func (s *stopCommand) Run(ctx context.Context, _ []string) error {
+	if s.baseURL == "" {
+		fmt.Println("serverPort is not set, no daemon API to stop; use dark-factory kill")
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/shutdown", nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, "create shutdown request")
+	}
+	if s.credentials != nil {
+		switch {
+		case s.credentials.Token != "":
+			req.Header.Set("Authorization", "Bearer "+s.credentials.Token)
+		case s.credentials.Username != "":
+			req.SetBasicAuth(s.credentials.Username, s.credentials.Password)
+		}
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if isNoDaemon(err) {
+			fmt.Println("no daemon running")
+			return nil
+		}
+		return errors.Wrap(ctx, err, "send shutdown request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.Errorf(ctx, "shutdown request failed with status %d", resp.StatusCode)
+	}
+	fmt.Println("daemon stopping after the current prompt")
+	return nil
+}
+
+// isNoDaemon reports whether err means nothing listens on the daemon address: the
+// connection was refused or the socket does not exist.
+func isNoDaemon(err error) bool {
+	return stderrors.Is(err, syscall.ECONNREFUSED) || stderrors.Is(err, syscall.ENOENT)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	libhttp "github.com/bborbe/http"
+	"github.com/bborbe/run"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/cmd"
+	"github.com/bborbe/dark-factory/pkg/server"
+)
+
+var _ = Describe("StopCommand", func() {
+	var (
+		ctx     context.Context
+		trigger run.Trigger
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		trigger = run.NewTrigger()
+		handler = libhttp.NewErrorHandler(server.NewShutdownHandler(trigger))
+	})
+
+	It("asks the daemon to shut down", func() {
+		daemon := httptest.NewServer(handler)
+		defer daemon.Close()
+
+		err := cmd.NewStopCommand(daemon.URL, nil, daemon.Client()).Run(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trigger.Done()).To(BeClosed())
+	})
+
+	It("sends the bearer token when the API requires auth", func() {
+		credentials := server.Credentials{Token: "secret"}
+		daemon := httptest.NewServer(server.NewAuthHandler(handler, credentials))
+		defer daemon.Close()
+
+		err := cmd.NewStopCommand(daemon.URL, &credentials, daemon.Client()).Run(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trigger.Done()).To(BeClosed())
+	})
+
+	It("returns an error when the daemon rejects the request", func() {
+		daemon := httptest.NewServer(
+			server.NewAuthHandler(handler, server.Credentials{Token: "secret"}),
+		)
+		defer daemon.Close()
+
+		err := cmd.NewStopCommand(daemon.URL, nil, daemon.Client()).Run(ctx, nil)
+		Expect(err).To(MatchError(ContainSubstring("status 401")))
+		Expect(trigger.Done()).NotTo(BeClosed())
+	})
+
+	It("returns nil when no daemon is running", func() {
+		daemon := httptest.NewServer(handler)
+		url := daemon.URL
+		daemon.Close()
+
+		err := cmd.NewStopCommand(url, nil, http.DefaultClient).Run(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns an error when the request fails for another reason", func() {
+		daemon := httptest.NewTLSServer(handler)
+		defer daemon.Close()
+
+		err := cmd.NewStopCommand(daemon.URL, nil, http.DefaultClient).Run(ctx, nil)
+		Expect(err).To(MatchError(ContainSubstring("send shutdown request")))
+		Expect(trigger.Done()).NotTo(BeClosed())
+	})
+
+	It("returns nil when the daemon has no REST API", func() {
+		err := cmd.NewStopCommand("", nil, http.DefaultClient).Run(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
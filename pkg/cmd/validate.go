@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+//counterfeiter:generate -o ../../mocks/validate-command.go --fake-name ValidateCommand . ValidateCommand
+
+// ValidateCommand executes the validate subcommand.
+type ValidateCommand interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// validateCommand implements ValidateCommand.
+type validateCommand struct {
+	queueDirs             []string
+	out                   io.Writer
+	currentDateTimeGetter libtime.CurrentDateTimeGetter
+}
+
+// NewValidateCommand creates a new ValidateCommand linting the prompts in queueDirs.
+func NewValidateCommand(
+	queueDirs []string,
+	out io.Writer,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ValidateCommand {
+	return &validateCommand{
+		queueDirs:             queueDirs,
+		out:                   out,
+		currentDateTimeGetter: currentDateTimeGetter,
+	}
+}
+
+// Run lints every prompt file in the queue directories and prints one line per
+// problem. It returns an error when at least one prompt is invalid.
+func (v *validateCommand) Run(ctx context.Context, args []string) error {
+	checked := 0
+	invalid := 0
+	for _, dir := range v.queueDirs {
+		paths, err := listPromptFiles(dir)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "list %s", dir)
+		}
+		for _, path := range paths {
+			problems, err := prompt.Lint(ctx, path, v.currentDateTimeGetter)
+			if err != nil {
+				return errors.Wrapf(ctx, err, "lint %s", filepath.Base(path))
+			}
+			checked++
+			if len(problems) == 0 {
+				continue
+			}
+			invalid++
+			for _, problem := range problems {
+				fmt.Fprintf(v.out, "%s: %s\n", filepath.Base(path), problem)
+			}
+		}
+	}
+	if invalid > 0 {
+		return errors.Errorf(ctx, "%d of %d prompt(s) invalid", invalid, checked)
+	}
+	fmt.Fprintf(v.out, "%d prompt(s) valid\n", checked)
+	return nil
+}
+
+// listPromptFiles returns the .md files in dir sorted by name. A missing dir yields none.
+func listPromptFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
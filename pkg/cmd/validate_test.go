@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/cmd"
+)
+
+var _ = Describe("ValidateCommand", func() {
+	var (
+		ctx         context.Context
+		queueDir    string
+		out         *bytes.Buffer
+		validateCmd cmd.ValidateCommand
+	)
+
+	writeFile := func(dir, name, content string) {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		queueDir = GinkgoT().TempDir()
+		out = &bytes.Buffer{}
+		validateCmd = cmd.NewValidateCommand(
+			[]string{queueDir},
+			out,
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("succeeds when every prompt is valid", func() {
+		writeFile(queueDir, "001-first.md", "---\nstatus: approved\n---\n\n# First\n\nDo it.\n")
+		writeFile(queueDir, "002-second.md", "---\nstatus: draft\n---\n\n# Second\n\nDo more.\n")
+
+		Expect(validateCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(out.String()).To(Equal("2 prompt(s) valid\n"))
+	})
+
+	It("succeeds when the queue directory does not exist", func() {
+		validateCmd = cmd.NewValidateCommand(
+			[]string{filepath.Join(queueDir, "missing")},
+			out,
+			libtime.NewCurrentDateTime(),
+		)
+
+		Expect(validateCmd.Run(ctx, []string{})).To(Succeed())
+		Expect(out.String()).To(Equal("0 prompt(s) valid\n"))
+	})
+
+	It("reports every problem and fails when prompts are invalid", func() {
+		writeFile(queueDir, "001-valid.md", "---\nstatus: approved\n---\n\n# Valid\n\nBody.\n")
+		writeFile(queueDir, "no-prefix.md", "---\nstatus: approved\n---\n\n# No prefix\n")
+		writeFile(queueDir, "002-bad-status.md", "---\nstatus: bogus\n---\n\n# Bad status\n")
+		writeFile(queueDir, "003-empty.md", "---\nstatus: approved\n---\n\n   \n")
+		writeFile(queueDir, "004-broken.md", "---\nstatus: [approved\n---\n\n# Broken\n")
+		writeFile(queueDir, "notes.txt", "ignored")
+
+		err := validateCmd.Run(ctx, []string{})
+		Expect(err).To(MatchError(ContainSubstring("4 of 5 prompt(s) invalid")))
+
+		output := out.String()
+		Expect(output).NotTo(ContainSubstring("001-valid.md"))
+		Expect(output).To(ContainSubstring("no-prefix.md: "))
+		Expect(output).To(ContainSubstring("missing NNN- prefix"))
+		Expect(output).To(ContainSubstring("002-bad-status.md: "))
+		Expect(output).To(ContainSubstring("status(bogus) is invalid"))
+		Expect(output).To(ContainSubstring("003-empty.md: empty body"))
+		Expect(output).To(ContainSubstring("004-broken.md: unparseable frontmatter YAML"))
+		Expect(output).NotTo(ContainSubstring("notes.txt"))
+	})
+
+	It("lints every queue directory", func() {
+		otherDir := GinkgoT().TempDir()
+		writeFile(queueDir, "001-valid.md", "---\nstatus: approved\n---\n\n# Valid\n\nBody.\n")
+		writeFile(otherDir, "001-empty.md", "---\nstatus: approved\n---\n")
+		validateCmd = cmd.NewValidateCommand(
+			[]string{queueDir, otherDir},
+			out,
+			libtime.NewCurrentDateTime(),
+		)
+
+		err := validateCmd.Run(ctx, []string{})
+		Expect(err).To(MatchError(ContainSubstring("1 of 2 prompt(s) invalid")))
+		Expect(out.String()).To(Equal("001-empty.md: empty body\n"))
+	})
+})
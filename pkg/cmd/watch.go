@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// DefaultWatchInterval is the refresh interval of --watch without --interval.
+const DefaultWatchInterval = 2 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watchOptions holds the --watch and --interval flags of a status command.
+type watchOptions struct {
+	enabled  bool
+	interval time.Duration
+}
+
+// parseWatchArgs extracts --watch and --interval <duration> from args.
+// Other arguments are ignored.
+func parseWatchArgs(ctx context.Context, args []string) (watchOptions, error) {
+	opts := watchOptions{interval: DefaultWatchInterval}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--watch":
+			opts.enabled = true
+		case "--interval":
+			if i+1 >= len(args) {
+				return watchOptions{}, errors.Errorf(ctx, "--interval requires a value")
+			}
+			interval, err := time.ParseDuration(args[i+1])
+			if err != nil || interval <= 0 {
+				return watchOptions{}, errors.Errorf(
+					ctx,
+					"--interval value must be a positive duration (e.g. 5s), got %q",
+					args[i+1],
+				)
+			}
+			opts.interval = interval
+			i++
+		}
+	}
+	return opts, nil
+}
+
+// watch clears out and calls render every interval until ctx is cancelled.
+// Cancellation is a clean exit; a render error stops the loop.
+func watch(
+	ctx context.Context,
+	out io.Writer,
+	interval time.Duration,
+	render func(ctx context.Context) error,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fmt.Fprint(out, clearScreen)
+		if err := render(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
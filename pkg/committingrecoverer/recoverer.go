@@ -94,7 +94,6 @@ func (r *recoverer) RecoverAll(ctx context.Context) {
 // Skip MoveToCompleted; transition status in place.
 func (r *recoverer) Recover(ctx context.Context, promptPath string) error {
 	gitCtx := context.WithoutCancel(ctx)
-	completedPath := filepath.Join(r.completedDir, filepath.Base(promptPath))
 
 	pf, err := r.promptManager.Load(ctx, promptPath)
 	if err != nil {
@@ -130,7 +129,7 @@ func (r *recoverer) Recover(ctx context.Context, promptPath string) error {
 	}
 
 	location := promptstate.LocationInProgress
-	if filepath.Clean(filepath.Dir(promptPath)) == filepath.Clean(r.completedDir) {
+	if prompt.IsInCompletedDir(r.completedDir, promptPath) {
 		location = promptstate.LocationCompleted
 	}
 	state := promptstate.InterpretRawTuple(
@@ -154,9 +153,10 @@ func (r *recoverer) Recover(ctx context.Context, promptPath string) error {
 			return errors.Wrap(ctx, err, "move to completed during recovery")
 		}
 	}
+	completedPath := prompt.CompletedFilePath(r.completedDir, filepath.Base(promptPath))
 
 	if err := git.CommitWithRetry(gitCtx, git.DefaultCommitBackoff, func(retryCtx context.Context) error {
-		return r.releaser.CommitCompletedFile(retryCtx, completedPath)
+		return r.releaser.CommitCompletedFile(retryCtx, completedPath, title)
 	}); err != nil {
 		return errors.Wrap(ctx, err, "commit completed file during recovery")
 	}
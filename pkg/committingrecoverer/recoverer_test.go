@@ -60,7 +60,7 @@ type stubReleaser struct {
 	pushBranchCalled          int
 }
 
-func (s *stubReleaser) CommitCompletedFile(_ context.Context, _ string) error {
+func (s *stubReleaser) CommitCompletedFile(_ context.Context, _, _ string) error {
 	s.commitCompletedFileCalled++
 	return s.commitCompletedFileErr
 }
@@ -75,6 +75,8 @@ func (s *stubReleaser) CommitAndRelease(_ context.Context, _ git.VersionBump) er
 
 func (s *stubReleaser) CommitOnly(_ context.Context, _ string) error { return nil }
 
+func (s *stubReleaser) CommitPaths(_ context.Context, _ string, _ ...string) error { return nil }
+
 func (s *stubReleaser) HasChangelog(_ context.Context) bool { return false }
 
 func (s *stubReleaser) MoveFile(_ context.Context, _, _ string) error { return nil }
@@ -95,6 +97,10 @@ func (s *stubReleaser) CommitWithRetry(
 	return fn(ctx)
 }
 
+func (s *stubReleaser) HeadCommit(_ context.Context) (string, error) {
+	return "", nil
+}
+
 type stubAutoCompleter struct {
 	checkAndCompleteErr    error
 	checkAndCompleteCalled int
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bborbe/collection"
+	"github.com/bborbe/errors"
+	"github.com/bborbe/validation"
+)
+
+const (
+	// CommitBodyNone commits with the prompt title only.
+	CommitBodyNone CommitBody = "none"
+	// CommitBodySummary appends the completion report summary after the title.
+	CommitBodySummary CommitBody = "summary"
+	// CommitBodyFull appends the full prompt body after the title.
+	CommitBodyFull CommitBody = "full"
+)
+
+// AvailableCommitBodies contains the three valid commitBody values.
+var AvailableCommitBodies = CommitBodies{CommitBodyNone, CommitBodySummary, CommitBodyFull}
+
+// CommitBody selects what is written below the title line of a prompt's commit message.
+type CommitBody string
+
+// String returns the string representation of the CommitBody.
+func (c CommitBody) String() string {
+	return string(c)
+}
+
+// Validate checks that the CommitBody is a known value.
+func (c CommitBody) Validate(ctx context.Context) error {
+	// Empty string is valid — means the field was not set in yaml (CommitBody has omitempty).
+	if c == "" {
+		return nil
+	}
+	if !AvailableCommitBodies.Contains(c) {
+		validValues := make([]string, len(AvailableCommitBodies))
+		for i, v := range AvailableCommitBodies {
+			validValues[i] = string(v)
+		}
+		return errors.Wrapf(
+			ctx,
+			validation.Error,
+			"unknown commitBody %q, valid values: %s",
+			c,
+			strings.Join(validValues, ", "),
+		)
+	}
+	return nil
+}
+
+// Ptr returns a pointer to the CommitBody value.
+func (c CommitBody) Ptr() *CommitBody {
+	return &c
+}
+
+// CommitBodies is a collection of CommitBody values.
+type CommitBodies []CommitBody
+
+func (c CommitBodies) Contains(commitBody CommitBody) bool {
+	return collection.Contains(c, commitBody)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/config"
+)
+
+var _ = Describe("CommitBody", func() {
+	ctx := context.Background()
+
+	DescribeTable("Validate accepts",
+		func(commitBody config.CommitBody) {
+			Expect(commitBody.Validate(ctx)).To(Succeed())
+		},
+		Entry("none", config.CommitBodyNone),
+		Entry("summary", config.CommitBodySummary),
+		Entry("full", config.CommitBodyFull),
+		Entry("empty", config.CommitBody("")),
+	)
+
+	It("Validate fails for an unknown value", func() {
+		err := config.CommitBody("bogus").Validate(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("none, summary, full"))
+	})
+
+	It("Defaults to none", func() {
+		Expect(config.Defaults().CommitBody).To(Equal(config.CommitBodyNone))
+	})
+})
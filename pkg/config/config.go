@@ -10,7 +10,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/bborbe/errors"
@@ -18,6 +20,8 @@ import (
 
 	"github.com/bborbe/dark-factory/pkg"
 	"github.com/bborbe/dark-factory/pkg/claudeargv"
+	"github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
 // GitHubConfig holds GitHub-specific configuration.
@@ -36,10 +40,16 @@ type DiscordConfig struct {
 	WebhookEnv string `yaml:"webhookEnv"`
 }
 
+// SlackConfig holds Slack notification configuration.
+type SlackConfig struct {
+	WebhookEnv string `yaml:"webhookEnv"`
+}
+
 // NotificationsConfig holds notification channel configuration.
 type NotificationsConfig struct {
 	Telegram TelegramConfig `yaml:"telegram"`
 	Discord  DiscordConfig  `yaml:"discord"`
+	Slack    SlackConfig    `yaml:"slack"`
 }
 
 // BitbucketConfig holds Bitbucket Server-specific configuration.
@@ -48,6 +58,25 @@ type BitbucketConfig struct {
 	TokenEnv string `yaml:"tokenEnv"`
 }
 
+// ServerTLSConfig holds the certificate and key that switch the REST API to HTTPS.
+type ServerTLSConfig struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+}
+
+// ServerAuthConfig holds the credentials required by the REST API (except /health).
+// TokenEnv names the env var with a bearer token; Username plus PasswordEnv enable basic auth.
+type ServerAuthConfig struct {
+	TokenEnv    string `yaml:"tokenEnv"`
+	Username    string `yaml:"username"`
+	PasswordEnv string `yaml:"passwordEnv"`
+}
+
+// Enabled reports whether any REST API auth scheme is configured.
+func (a ServerAuthConfig) Enabled() bool {
+	return a.TokenEnv != "" || a.Username != ""
+}
+
 // PromptsConfig holds directories for the prompt lifecycle.
 type PromptsConfig struct {
 	InboxDir      string `yaml:"inboxDir"`
@@ -56,6 +85,88 @@ type PromptsConfig struct {
 	RejectedDir   string `yaml:"rejectedDir"`
 	CancelledDir  string `yaml:"cancelledDir"`
 	LogDir        string `yaml:"logDir"`
+	IdeasDir      string `yaml:"ideasDir"`
+}
+
+// PromptsDirEnvVar names the environment variable overriding the prompts directory.
+const PromptsDirEnvVar = "DARK_FACTORY_PROMPTS_DIR"
+
+// IdeasDirEnvVar names the environment variable overriding prompts.ideasDir.
+const IdeasDirEnvVar = "DARK_FACTORY_IDEAS_DIR"
+
+// LogDirEnvVar names the environment variable overriding prompts.logDir.
+const LogDirEnvVar = "DARK_FACTORY_LOG_DIR"
+
+// LogRetentionEnvVar names the environment variable overriding logRetention.
+const LogRetentionEnvVar = "DARK_FACTORY_LOG_RETENTION"
+
+// PRBodyTemplateEnvVar names the environment variable overriding prBodyTemplate.
+const PRBodyTemplateEnvVar = "DARK_FACTORY_PR_BODY_TEMPLATE"
+
+// CompletedCommitMessageEnvVar names the environment variable overriding completedCommitMessage.
+const CompletedCommitMessageEnvVar = "DARK_FACTORY_COMPLETED_COMMIT_MSG"
+
+// DeleteBranchEnvVar names the environment variable overriding deleteBranch.
+const DeleteBranchEnvVar = "DARK_FACTORY_DELETE_BRANCH"
+
+// PRDraftEnvVar names the environment variable overriding prDraft.
+const PRDraftEnvVar = "DARK_FACTORY_PR_DRAFT"
+
+// BranchTemplateEnvVar names the environment variable overriding branchTemplate.
+const BranchTemplateEnvVar = "DARK_FACTORY_BRANCH_TEMPLATE"
+
+// StrictOrderingEnvVar names the environment variable overriding strictOrdering.
+const StrictOrderingEnvVar = "DARK_FACTORY_STRICT_ORDERING"
+
+// ConcurrencyEnvVar names the environment variable overriding concurrency.
+const ConcurrencyEnvVar = "DARK_FACTORY_CONCURRENCY"
+
+// DebounceEnvVar names the environment variable overriding debounceMs with a duration.
+const DebounceEnvVar = "DARK_FACTORY_DEBOUNCE"
+
+// GitAuthorNameEnvVar names the environment variable overriding gitAuthorName.
+const GitAuthorNameEnvVar = "DARK_FACTORY_GIT_AUTHOR_NAME"
+
+// GitAuthorEmailEnvVar names the environment variable overriding gitAuthorEmail.
+const GitAuthorEmailEnvVar = "DARK_FACTORY_GIT_AUTHOR_EMAIL"
+
+// GitRemoteEnvVar names the environment variable overriding gitRemote.
+const GitRemoteEnvVar = "DARK_FACTORY_GIT_REMOTE"
+
+// WebhookURLEnvVar names the environment variable overriding webhookURL.
+const WebhookURLEnvVar = "DARK_FACTORY_WEBHOOK_URL"
+
+// WorkflowEnvVar names the environment variable selecting the workflow (see ApplyWorkflow).
+const WorkflowEnvVar = "DARK_FACTORY_WORKFLOW"
+
+// MemoryLimitEnvVar names the environment variable overriding memoryLimit.
+const MemoryLimitEnvVar = "DARK_FACTORY_MEM_LIMIT"
+
+// CPULimitEnvVar names the environment variable overriding cpuLimit.
+const CPULimitEnvVar = "DARK_FACTORY_CPU_LIMIT"
+
+// SlackWebhookEnvVar names the environment variable read for the Slack incoming webhook
+// URL when notifications.slack.webhookEnv is not set.
+const SlackWebhookEnvVar = "DARK_FACTORY_SLACK_WEBHOOK"
+
+// CompletedLayoutEnvVar names the environment variable overriding completedLayout.
+const CompletedLayoutEnvVar = "DARK_FACTORY_COMPLETED_LAYOUT"
+
+// DefaultDebounceMs is the watcher debounce used when none or a non-positive one is set.
+const DefaultDebounceMs = 500
+
+// NewPromptsConfig returns the prompt lifecycle directories rooted at dir:
+// dir is the inbox and the other directories are subdirectories of it.
+func NewPromptsConfig(dir string) PromptsConfig {
+	return PromptsConfig{
+		InboxDir:      dir,
+		InProgressDir: filepath.Join(dir, "in-progress"),
+		CompletedDir:  filepath.Join(dir, "completed"),
+		RejectedDir:   filepath.Join(dir, "rejected"),
+		CancelledDir:  filepath.Join(dir, "cancelled"),
+		LogDir:        filepath.Join(dir, "log"),
+		IdeasDir:      filepath.Join(dir, "ideas"),
+	}
 }
 
 // SpecsConfig holds directories for the spec lifecycle.
@@ -84,50 +195,89 @@ func (m ExtraMount) IsReadonly() bool {
 
 // Config holds the dark-factory configuration.
 type Config struct {
-	ProjectName            string              `yaml:"projectName"`
-	Project                *string             `yaml:"project,omitempty"`
-	Workflow               Workflow            `yaml:"workflow"`
-	PR                     bool                `yaml:"pr,omitempty"`
-	Worktree               bool                `yaml:"worktree,omitempty"`
-	HideGit                bool                `yaml:"hideGit,omitempty"`
-	DefaultBranch          string              `yaml:"defaultBranch"`
-	Prompts                PromptsConfig       `yaml:"prompts"`
-	Specs                  SpecsConfig         `yaml:"specs"`
-	ContainerImage         string              `yaml:"containerImage"`
-	NetrcFile              string              `yaml:"netrcFile"`
-	GitconfigFile          string              `yaml:"gitconfigFile"`
-	Model                  string              `yaml:"model"`
-	ValidationCommand      string              `yaml:"validationCommand"`
-	ValidationPrompt       string              `yaml:"validationPrompt"`
-	TestCommand            string              `yaml:"testCommand"`
-	DebounceMs             int                 `yaml:"debounceMs"`
-	ServerPort             int                 `yaml:"serverPort"`
-	AutoMerge              bool                `yaml:"autoMerge"`
-	AutoRelease            bool                `yaml:"autoRelease"`
-	VerificationGate       bool                `yaml:"verificationGate"`
-	GitHub                 GitHubConfig        `yaml:"github"`
-	Provider               Provider            `yaml:"provider"`
-	Bitbucket              BitbucketConfig     `yaml:"bitbucket"`
-	Notifications          NotificationsConfig `yaml:"notifications"`
-	Env                    map[string]string   `yaml:"env,omitempty"`
-	ExtraMounts            []ExtraMount        `yaml:"extraMounts,omitempty"`
-	ClaudeDir              string              `yaml:"claudeDir"`
-	GenerateCommand        string              `yaml:"generateCommand"`
-	AdditionalInstructions string              `yaml:"additionalInstructions,omitempty"`
-	MaxContainers          int                 `yaml:"maxContainers,omitempty"`
-	DirtyFileThreshold     int                 `yaml:"dirtyFileThreshold,omitempty"`
-	AutoApprovePrompts     bool                `yaml:"autoApprovePrompts,omitempty"`
-	AutoGeneratePrompts    bool                `yaml:"autoGeneratePrompts,omitempty"`
-	MaxPromptDuration      string              `yaml:"maxPromptDuration"`
-	AutoRetryLimit         int                 `yaml:"autoRetryLimit"`
-	PreflightCommand       string              `yaml:"preflightCommand"`
-	PreflightInterval      string              `yaml:"preflightInterval"`
-	HealthcheckEnabled     *bool               `yaml:"healthcheckEnabled,omitempty"`
-	HealthcheckInterval    string              `yaml:"healthcheckInterval"`
-	QueueInterval          string              `yaml:"queueInterval"`
-	SweepInterval          string              `yaml:"sweepInterval"`
-	IdleLogInterval        string              `yaml:"idleLogInterval"`
-	Backend                Backend             `yaml:"backend,omitempty"`
+	ProjectName            string                 `yaml:"projectName"`
+	Project                *string                `yaml:"project,omitempty"`
+	Workflow               Workflow               `yaml:"workflow"`
+	PR                     bool                   `yaml:"pr,omitempty"`
+	Worktree               bool                   `yaml:"worktree,omitempty"`
+	HideGit                bool                   `yaml:"hideGit,omitempty"`
+	DefaultBranch          string                 `yaml:"defaultBranch"`
+	Prompts                PromptsConfig          `yaml:"prompts"`
+	AdditionalPrompts      []PromptsConfig        `yaml:"-"` // further --prompts-dir entries
+	Specs                  SpecsConfig            `yaml:"specs"`
+	ContainerImage         string                 `yaml:"containerImage"`
+	NetrcFile              string                 `yaml:"netrcFile"`
+	GitconfigFile          string                 `yaml:"gitconfigFile"`
+	Model                  string                 `yaml:"model"`
+	ValidationCommand      string                 `yaml:"validationCommand"`
+	ValidationPrompt       string                 `yaml:"validationPrompt"`
+	TestCommand            string                 `yaml:"testCommand"`
+	DebounceMs             int                    `yaml:"debounceMs"`
+	ServerPort             int                    `yaml:"serverPort"`
+	AutoMerge              bool                   `yaml:"autoMerge"`
+	AutoRelease            bool                   `yaml:"autoRelease"`
+	DeleteBranch           bool                   `yaml:"deleteBranch,omitempty"`
+	PRDraft                bool                   `yaml:"prDraft,omitempty"`
+	PRReviewers            []string               `yaml:"prReviewers,omitempty"`
+	PRLabels               []string               `yaml:"prLabels,omitempty"`
+	VerificationGate       bool                   `yaml:"verificationGate"`
+	ResultCache            bool                   `yaml:"resultCache,omitempty"`
+	Canary                 bool                   `yaml:"canary,omitempty"`
+	CommitBody             CommitBody             `yaml:"commitBody,omitempty"`
+	ChangelogSections      bool                   `yaml:"changelogSections,omitempty"`
+	PRBodyTemplate         string                 `yaml:"prBodyTemplate,omitempty"`
+	BranchTemplate         string                 `yaml:"branchTemplate,omitempty"`
+	CompletedCommitMessage string                 `yaml:"completedCommitMessage,omitempty"`
+	GitAuthorName          string                 `yaml:"gitAuthorName,omitempty"`
+	GitAuthorEmail         string                 `yaml:"gitAuthorEmail,omitempty"`
+	GitRemote              string                 `yaml:"gitRemote,omitempty"`
+	PushRetries            int                    `yaml:"pushRetries"`
+	ServerTLS              ServerTLSConfig        `yaml:"serverTLS,omitempty"`
+	ServerAuth             ServerAuthConfig       `yaml:"serverAuth,omitempty"`
+	GitHub                 GitHubConfig           `yaml:"github"`
+	Provider               Provider               `yaml:"provider"`
+	Bitbucket              BitbucketConfig        `yaml:"bitbucket"`
+	Notifications          NotificationsConfig    `yaml:"notifications"`
+	WebhookURL             string                 `yaml:"webhookURL,omitempty"`
+	Env                    map[string]string      `yaml:"env,omitempty"`
+	ExtraMounts            []ExtraMount           `yaml:"extraMounts,omitempty"`
+	MemoryLimit            string                 `yaml:"memoryLimit,omitempty"`
+	CPULimit               string                 `yaml:"cpuLimit,omitempty"`
+	ClaudeDir              string                 `yaml:"claudeDir"`
+	GenerateCommand        string                 `yaml:"generateCommand"`
+	AdditionalInstructions string                 `yaml:"additionalInstructions,omitempty"`
+	MaxContainers          int                    `yaml:"maxContainers,omitempty"`
+	Concurrency            int                    `yaml:"concurrency,omitempty"`
+	StrictOrdering         bool                   `yaml:"strictOrdering"`
+	DirtyFileThreshold     int                    `yaml:"dirtyFileThreshold,omitempty"`
+	AutoApprovePrompts     bool                   `yaml:"autoApprovePrompts,omitempty"`
+	AutoGeneratePrompts    bool                   `yaml:"autoGeneratePrompts,omitempty"`
+	MaxPromptDuration      string                 `yaml:"maxPromptDuration"`
+	AutoRetryLimit         int                    `yaml:"autoRetryLimit"`
+	MinPromptBodyLength    int                    `yaml:"minPromptBodyLength,omitempty"`
+	RetryBackoff           string                 `yaml:"retryBackoff,omitempty"`
+	PreflightCommand       string                 `yaml:"preflightCommand"`
+	PreflightInterval      string                 `yaml:"preflightInterval"`
+	HealthcheckEnabled     *bool                  `yaml:"healthcheckEnabled,omitempty"`
+	HealthcheckInterval    string                 `yaml:"healthcheckInterval"`
+	QueueInterval          string                 `yaml:"queueInterval"`
+	QueueMaxInterval       string                 `yaml:"queueMaxInterval"`
+	QueueOrder             prompt.QueueOrder      `yaml:"queueOrder,omitempty"`
+	CompletedLayout        prompt.CompletedLayout `yaml:"completedLayout,omitempty"`
+	MirrorCompletedTo      string                 `yaml:"mirrorCompletedTo,omitempty"`
+	CompletedRetention     string                 `yaml:"completedRetention,omitempty"`
+	LogRetention           string                 `yaml:"logRetention,omitempty"`
+	SweepInterval          string                 `yaml:"sweepInterval"`
+	ReadyDebounce          string                 `yaml:"readyDebounce,omitempty"`
+	IdleLogInterval        string                 `yaml:"idleLogInterval"`
+	LogFormat              log.Format             `yaml:"logFormat,omitempty"`
+	LogLevel               log.Level              `yaml:"logLevel,omitempty"`
+	Backend                Backend                `yaml:"backend,omitempty"`
+	// DryRun is set by `run --dry-run` only, never from .dark-factory.yaml.
+	DryRun bool `yaml:"-"`
+	// ForceLock is set by `run --force` / `daemon --force` only, never from
+	// .dark-factory.yaml. It takes over the lock of a live instance.
+	ForceLock bool `yaml:"-"`
 }
 
 // Defaults returns a Config with all default values.
@@ -136,14 +286,7 @@ func Defaults() Config {
 		Workflow: WorkflowDirect,
 		PR:       false,
 		Worktree: false,
-		Prompts: PromptsConfig{
-			InboxDir:      "prompts",
-			InProgressDir: "prompts/in-progress",
-			CompletedDir:  "prompts/completed",
-			RejectedDir:   "prompts/rejected",
-			CancelledDir:  "prompts/cancelled",
-			LogDir:        "prompts/log",
-		},
+		Prompts:  NewPromptsConfig("prompts"),
 		Specs: SpecsConfig{
 			InboxDir:      "specs",
 			InProgressDir: "specs/in-progress",
@@ -155,7 +298,7 @@ func Defaults() Config {
 		Model:               "claude-sonnet-4-6",
 		ValidationCommand:   "make precommit",
 		TestCommand:         "make test",
-		DebounceMs:          500,
+		DebounceMs:          DefaultDebounceMs,
 		ServerPort:          0,
 		AutoMerge:           false,
 		AutoRelease:         false,
@@ -168,9 +311,16 @@ func Defaults() Config {
 		PreflightInterval:   "8h",
 		HealthcheckInterval: "8h",
 		QueueInterval:       "5s",
+		QueueMaxInterval:    "60s",
+		QueueOrder:          prompt.QueueOrderPriority,
+		CompletedLayout:     prompt.CompletedLayoutFlat,
 		SweepInterval:       "60s",
 		IdleLogInterval:     "1m",
 		Backend:             BackendDocker,
+		CommitBody:          CommitBodyNone,
+		GitRemote:           "origin",
+		PushRetries:         3,
+		StrictOrdering:      true,
 	}
 }
 
@@ -194,23 +344,17 @@ func (c Config) Validate(ctx context.Context) error {
 			return nil
 		})),
 		validation.Name("serverPort", validation.HasValidationFunc(c.validateServerPort)),
-		validation.Name(
-			"completedDir",
-			validation.HasValidationFunc(func(ctx context.Context) error {
-				if c.Prompts.CompletedDir == c.Prompts.InProgressDir {
-					return errors.Errorf(ctx, "completedDir cannot equal inProgressDir")
-				}
-				if c.Prompts.CompletedDir == c.Prompts.InboxDir {
-					return errors.Errorf(ctx, "completedDir cannot equal inboxDir")
-				}
-				return nil
-			}),
-		),
+		validation.Name("serverTLS", validation.HasValidationFunc(c.validateServerTLS)),
+		validation.Name("serverAuth", validation.HasValidationFunc(c.validateServerAuth)),
+		validation.Name("completedDir", validation.HasValidationFunc(c.validatePromptDirLayout)),
 		validation.Name("workflow", validation.HasValidationFunc(c.validateWorkflowPR)),
 		validation.Name("autoMerge", validation.HasValidationFunc(func(ctx context.Context) error {
 			if c.AutoMerge && !c.PR {
 				return errors.Errorf(ctx, "autoMerge requires pr: true")
 			}
+			if c.AutoMerge && c.Provider == ProviderGitLab {
+				return errors.Errorf(ctx, "autoMerge is not supported with provider: gitlab")
+			}
 			return nil
 		})),
 		validation.Name(
@@ -236,6 +380,11 @@ func (c Config) Validate(ctx context.Context) error {
 			validation.HasValidationFunc(c.validateValidationPrompt),
 		),
 		validation.Name("maxContainers", validation.HasValidationFunc(c.validateMaxContainers)),
+		validation.Name("concurrency", validation.HasValidationFunc(c.validateConcurrency)),
+		validation.Name(
+			"additionalPrompts",
+			validation.HasValidationFunc(c.validateAdditionalPrompts),
+		),
 		validation.Name(
 			"dirtyFileThreshold",
 			validation.HasValidationFunc(c.validateDirtyFileThreshold),
@@ -245,6 +394,11 @@ func (c Config) Validate(ctx context.Context) error {
 			validation.HasValidationFunc(c.validateMaxPromptDuration),
 		),
 		validation.Name("autoRetryLimit", validation.HasValidationFunc(c.validateAutoRetryLimit)),
+		validation.Name(
+			"minPromptBodyLength",
+			validation.HasValidationFunc(c.validateMinPromptBodyLength),
+		),
+		validation.Name("retryBackoff", validation.HasValidationFunc(c.validateRetryBackoff)),
 		validation.Name(
 			"preflightInterval",
 			validation.HasValidationFunc(c.validatePreflightInterval),
@@ -258,9 +412,50 @@ func (c Config) Validate(ctx context.Context) error {
 			validation.HasValidationFunc(c.validateHealthcheckInterval),
 		),
 		validation.Name("queueInterval", validation.HasValidationFunc(c.validateQueueInterval)),
+		validation.Name(
+			"queueMaxInterval",
+			validation.HasValidationFunc(c.validateQueueMaxInterval),
+		),
+		validation.Name("queueOrder", c.QueueOrder),
+		validation.Name("completedLayout", c.CompletedLayout),
+		validation.Name("logFormat", c.LogFormat),
+		validation.Name("logLevel", c.LogLevel),
+		validation.Name(
+			"mirrorCompletedTo",
+			validation.HasValidationFunc(c.validateMirrorCompletedTo),
+		),
+		validation.Name(
+			"completedRetention",
+			validation.HasValidationFunc(c.validateCompletedRetention),
+		),
+		validation.Name("logRetention", validation.HasValidationFunc(c.validateLogRetention)),
 		validation.Name("sweepInterval", validation.HasValidationFunc(c.validateSweepInterval)),
+		validation.Name("readyDebounce", validation.HasValidationFunc(c.validateReadyDebounce)),
 		validation.Name("idleLogInterval", validation.HasValidationFunc(c.validateIdleLogInterval)),
 		validation.Name("backend", c.Backend),
+		validation.Name("commitBody", c.CommitBody),
+		validation.Name(
+			"prBodyTemplate",
+			validation.HasValidationFunc(c.validatePRBodyTemplate),
+		),
+		validation.Name(
+			"branchTemplate",
+			validation.HasValidationFunc(c.validateBranchTemplate),
+		),
+		validation.Name(
+			"completedCommitMessage",
+			validation.HasValidationFunc(c.validateCompletedCommitMessage),
+		),
+		validation.Name("gitRemote", validation.HasValidationFunc(c.validateGitRemote)),
+		validation.Name("pushRetries", validation.HasValidationFunc(c.validatePushRetries)),
+		validation.Name("memoryLimit", validation.HasValidationFunc(c.validateMemoryLimit)),
+		validation.Name("cpuLimit", validation.HasValidationFunc(c.validateCPULimit)),
+		validation.Name("prReviewers", validation.HasValidationFunc(func(ctx context.Context) error {
+			return validatePRNames(ctx, "prReviewers", c.PRReviewers)
+		})),
+		validation.Name("prLabels", validation.HasValidationFunc(func(ctx context.Context) error {
+			return validatePRNames(ctx, "prLabels", c.PRLabels)
+		})),
 	}.Validate(ctx)
 }
 
@@ -274,6 +469,31 @@ func (c Config) ResolvedProjectOverride() string {
 	return c.ProjectName
 }
 
+// InProjectDir returns a copy of c whose relative prompt and spec directories
+// are resolved against projectDir, for reading a project other than the
+// working directory.
+func (c Config) InProjectDir(projectDir string) Config {
+	resolve := func(dir string) string {
+		if dir == "" || filepath.IsAbs(dir) {
+			return dir
+		}
+		return filepath.Join(projectDir, dir)
+	}
+	c.Prompts.InboxDir = resolve(c.Prompts.InboxDir)
+	c.Prompts.InProgressDir = resolve(c.Prompts.InProgressDir)
+	c.Prompts.CompletedDir = resolve(c.Prompts.CompletedDir)
+	c.Prompts.RejectedDir = resolve(c.Prompts.RejectedDir)
+	c.Prompts.CancelledDir = resolve(c.Prompts.CancelledDir)
+	c.Prompts.LogDir = resolve(c.Prompts.LogDir)
+	c.Prompts.IdeasDir = resolve(c.Prompts.IdeasDir)
+	c.Specs.InboxDir = resolve(c.Specs.InboxDir)
+	c.Specs.InProgressDir = resolve(c.Specs.InProgressDir)
+	c.Specs.CompletedDir = resolve(c.Specs.CompletedDir)
+	c.Specs.RejectedDir = resolve(c.Specs.RejectedDir)
+	c.Specs.LogDir = resolve(c.Specs.LogDir)
+	return c
+}
+
 // ParsedPreflightInterval returns the parsed duration from PreflightInterval.
 // Returns 0 when PreflightInterval is empty (disables interval-based caching).
 // Safe to call at any time — returns 0 on error, never panics.
@@ -367,6 +587,22 @@ func (c Config) validateModel(ctx context.Context) error {
 	return nil
 }
 
+// validateServerTLS requires certFile and keyFile to be set together.
+func (c Config) validateServerTLS(ctx context.Context) error {
+	if (c.ServerTLS.CertFile == "") != (c.ServerTLS.KeyFile == "") {
+		return errors.Errorf(ctx, "serverTLS requires both certFile and keyFile")
+	}
+	return nil
+}
+
+// validateServerAuth requires username and passwordEnv to be set together.
+func (c Config) validateServerAuth(ctx context.Context) error {
+	if (c.ServerAuth.Username == "") != (c.ServerAuth.PasswordEnv == "") {
+		return errors.Errorf(ctx, "serverAuth requires both username and passwordEnv")
+	}
+	return nil
+}
+
 // validateServerPort rejects out-of-range server port values.
 func (c Config) validateServerPort(ctx context.Context) error {
 	if c.ServerPort < 0 || c.ServerPort > 65535 {
@@ -387,6 +623,58 @@ func (c Config) validateMaxContainers(ctx context.Context) error {
 	return nil
 }
 
+// validateConcurrency rejects negative concurrency values and parallel processing
+// with workflows that switch branches or chdir per prompt, which cannot run side by side,
+// or with the verification gate.
+func (c Config) validateConcurrency(ctx context.Context) error {
+	if c.Concurrency < 0 {
+		return errors.Errorf(ctx, "concurrency must not be negative, got %d", c.Concurrency)
+	}
+	if c.Concurrency > 1 && c.Workflow != WorkflowDirect {
+		return errors.Errorf(
+			ctx,
+			"concurrency %d requires workflow %q, got %q",
+			c.Concurrency,
+			WorkflowDirect,
+			c.Workflow,
+		)
+	}
+	if c.Concurrency > 1 && c.VerificationGate {
+		// Parked changes would sit in the project tree, where the next commit picks them up.
+		return errors.Errorf(
+			ctx,
+			"concurrency %d cannot be combined with verificationGate",
+			c.Concurrency,
+		)
+	}
+	return nil
+}
+
+// validateAdditionalPrompts requires workflow direct when more than one prompts
+// directory is watched and rejects directories listed twice.
+func (c Config) validateAdditionalPrompts(ctx context.Context) error {
+	if len(c.AdditionalPrompts) == 0 {
+		return nil
+	}
+	if c.Workflow != WorkflowDirect {
+		return errors.Errorf(
+			ctx,
+			"multiple prompts directories require workflow %q, got %q",
+			WorkflowDirect,
+			c.Workflow,
+		)
+	}
+	seen := map[string]bool{filepath.Clean(c.Prompts.InboxDir): true}
+	for _, p := range c.AdditionalPrompts {
+		dir := filepath.Clean(p.InboxDir)
+		if seen[dir] {
+			return errors.Errorf(ctx, "prompts directory %s listed more than once", p.InboxDir)
+		}
+		seen[dir] = true
+	}
+	return nil
+}
+
 // validateMaxPromptDuration rejects unparseable duration strings.
 func (c Config) validateMaxPromptDuration(ctx context.Context) error {
 	if c.MaxPromptDuration == "" {
@@ -470,6 +758,20 @@ func (c Config) ParsedQueueInterval() time.Duration {
 	return d
 }
 
+// ParsedQueueMaxInterval returns the parsed duration from QueueMaxInterval.
+// Returns 60 * time.Second when QueueMaxInterval is empty or unparseable.
+// Safe to call at any time — never panics.
+func (c Config) ParsedQueueMaxInterval() time.Duration {
+	if c.QueueMaxInterval == "" {
+		return 60 * time.Second
+	}
+	d, err := time.ParseDuration(c.QueueMaxInterval)
+	if err != nil {
+		return 60 * time.Second
+	}
+	return d
+}
+
 // ParsedSweepInterval returns the parsed duration from SweepInterval.
 // Returns 60 * time.Second when SweepInterval is empty or unparseable (preserves default behaviour).
 // Safe to call at any time — never panics.
@@ -504,6 +806,58 @@ func (c Config) validateQueueInterval(ctx context.Context) error {
 	return nil
 }
 
+// validateMirrorCompletedTo rejects mirror directories that coincide with a prompt
+// directory — mirroring into the inbox or queue would re-run completed prompts.
+func (c Config) validateMirrorCompletedTo(ctx context.Context) error {
+	if c.MirrorCompletedTo == "" {
+		return nil
+	}
+	mirror := filepath.Clean(c.MirrorCompletedTo)
+	for _, dir := range []string{
+		c.Prompts.InboxDir,
+		c.Prompts.InProgressDir,
+		c.Prompts.CompletedDir,
+	} {
+		if dir != "" && filepath.Clean(dir) == mirror {
+			return errors.Errorf(
+				ctx,
+				"mirrorCompletedTo %q must not be a prompt directory",
+				c.MirrorCompletedTo,
+			)
+		}
+	}
+	return nil
+}
+
+// validateQueueMaxInterval rejects unparseable or non-positive duration strings for
+// queueMaxInterval and values below queueInterval.
+func (c Config) validateQueueMaxInterval(ctx context.Context) error {
+	if c.QueueMaxInterval == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(c.QueueMaxInterval)
+	if err != nil {
+		return errors.Errorf(
+			ctx,
+			"queueMaxInterval %q is not a valid duration: %v",
+			c.QueueMaxInterval,
+			err,
+		)
+	}
+	if d <= 0 {
+		return errors.Errorf(ctx, "queueMaxInterval must be positive, got %s", c.QueueMaxInterval)
+	}
+	if d < c.ParsedQueueInterval() {
+		return errors.Errorf(
+			ctx,
+			"queueMaxInterval %s must not be below queueInterval %s",
+			c.QueueMaxInterval,
+			c.ParsedQueueInterval(),
+		)
+	}
+	return nil
+}
+
 // validateSweepInterval rejects unparseable or non-positive duration strings for sweepInterval.
 func (c Config) validateSweepInterval(ctx context.Context) error {
 	if c.SweepInterval == "" {
@@ -524,6 +878,246 @@ func (c Config) validateSweepInterval(ctx context.Context) error {
 	return nil
 }
 
+// ParsedCompletedRetention returns the parsed duration from CompletedRetention.
+// Returns 0 (keep completed prompts forever) when CompletedRetention is empty or unparseable.
+// Safe to call at any time — never panics.
+func (c Config) ParsedCompletedRetention() time.Duration {
+	if c.CompletedRetention == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.CompletedRetention)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// validateCompletedRetention rejects unparseable or negative duration strings for completedRetention.
+func (c Config) validateCompletedRetention(ctx context.Context) error {
+	if c.CompletedRetention == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(c.CompletedRetention)
+	if err != nil {
+		return errors.Errorf(
+			ctx,
+			"completedRetention %q is not a valid duration: %v",
+			c.CompletedRetention,
+			err,
+		)
+	}
+	if d < 0 {
+		return errors.Errorf(
+			ctx,
+			"completedRetention must not be negative, got %s",
+			c.CompletedRetention,
+		)
+	}
+	return nil
+}
+
+// ParsedLogRetention returns the number of prompt logs to keep and the maximum
+// log age from LogRetention: a plain integer is a count ("50"), anything else a
+// duration ("168h"). Returns 0, 0 (keep every log) when LogRetention is empty or invalid.
+func (c Config) ParsedLogRetention() (int, time.Duration) {
+	count, maxAge, err := parseLogRetention(context.Background(), c.LogRetention)
+	if err != nil {
+		return 0, 0
+	}
+	return count, maxAge
+}
+
+// validateLogRetention rejects a logRetention that is neither a positive count
+// nor a positive duration.
+func (c Config) validateLogRetention(ctx context.Context) error {
+	_, _, err := parseLogRetention(ctx, c.LogRetention)
+	return err
+}
+
+// parseLogRetention parses value as a log count or a maximum log age.
+func parseLogRetention(ctx context.Context, value string) (int, time.Duration, error) {
+	if value == "" {
+		return 0, 0, nil
+	}
+	if count, err := strconv.Atoi(value); err == nil {
+		if count <= 0 {
+			return 0, 0, errors.Errorf(ctx, "logRetention count must be positive, got %d", count)
+		}
+		return count, 0, nil
+	}
+	maxAge, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, 0, errors.Errorf(
+			ctx,
+			"logRetention %q is neither a log count nor a duration",
+			value,
+		)
+	}
+	if maxAge <= 0 {
+		return 0, 0, errors.Errorf(ctx, "logRetention duration must be positive, got %s", value)
+	}
+	return 0, maxAge, nil
+}
+
+// ParsedReadyDebounce returns the parsed duration from ReadyDebounce.
+// Returns 0 (no debounce) when ReadyDebounce is empty or unparseable.
+// Safe to call at any time — never panics.
+func (c Config) ParsedReadyDebounce() time.Duration {
+	if c.ReadyDebounce == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.ReadyDebounce)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// validateReadyDebounce rejects unparseable or negative duration strings for readyDebounce.
+func (c Config) validateReadyDebounce(ctx context.Context) error {
+	if c.ReadyDebounce == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(c.ReadyDebounce)
+	if err != nil {
+		return errors.Errorf(
+			ctx,
+			"readyDebounce %q is not a valid duration: %v",
+			c.ReadyDebounce,
+			err,
+		)
+	}
+	if d < 0 {
+		return errors.Errorf(ctx, "readyDebounce must not be negative, got %s", c.ReadyDebounce)
+	}
+	return nil
+}
+
+func (c Config) validatePRBodyTemplate(ctx context.Context) error {
+	if c.PRBodyTemplate == "" {
+		return nil
+	}
+	if _, err := template.New("prBodyTemplate").Parse(c.PRBodyTemplate); err != nil {
+		return errors.Errorf(ctx, "prBodyTemplate is not a valid template: %v", err)
+	}
+	return nil
+}
+
+func (c Config) validateBranchTemplate(ctx context.Context) error {
+	if c.BranchTemplate == "" {
+		return nil
+	}
+	if _, err := template.New("branchTemplate").Parse(c.BranchTemplate); err != nil {
+		return errors.Errorf(ctx, "branchTemplate is not a valid template: %v", err)
+	}
+	return nil
+}
+
+func (c Config) validateCompletedCommitMessage(ctx context.Context) error {
+	if c.CompletedCommitMessage == "" {
+		return nil
+	}
+	tmpl := template.New("completedCommitMessage")
+	if _, err := tmpl.Parse(c.CompletedCommitMessage); err != nil {
+		return errors.Errorf(ctx, "completedCommitMessage is not a valid template: %v", err)
+	}
+	return nil
+}
+
+// gitRemoteRegexp matches a git remote name that cannot be mistaken for a flag.
+var gitRemoteRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// validateGitRemote ensures gitRemote is a plain remote name. Empty means origin.
+func (c Config) validateGitRemote(ctx context.Context) error {
+	if c.GitRemote != "" && !gitRemoteRegexp.MatchString(c.GitRemote) {
+		return errors.Errorf(ctx, "gitRemote %q is not a valid remote name", c.GitRemote)
+	}
+	return nil
+}
+
+// memoryLimitRegexp matches a docker --memory value such as 512m or 8g.
+var memoryLimitRegexp = regexp.MustCompile(`^[1-9][0-9]*[bkmgBKMG]?$`)
+
+// validateMemoryLimit ensures memoryLimit, when set, is a docker memory size.
+func (c Config) validateMemoryLimit(ctx context.Context) error {
+	if c.MemoryLimit != "" && !memoryLimitRegexp.MatchString(c.MemoryLimit) {
+		return errors.Errorf(
+			ctx,
+			"memoryLimit %q is not a memory size like 512m or 8g",
+			c.MemoryLimit,
+		)
+	}
+	return nil
+}
+
+// validateCPULimit ensures cpuLimit, when set, is a positive number of CPUs.
+func (c Config) validateCPULimit(ctx context.Context) error {
+	if c.CPULimit == "" {
+		return nil
+	}
+	cpus, err := strconv.ParseFloat(c.CPULimit, 64)
+	if err != nil || cpus <= 0 {
+		return errors.Errorf(ctx, "cpuLimit %q is not a positive number of CPUs", c.CPULimit)
+	}
+	return nil
+}
+
+// validatePRNames ensures every reviewer or label is non-empty and cannot be
+// mistaken for a flag by the gh CLI.
+func validatePRNames(ctx context.Context, field string, names []string) error {
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return errors.Errorf(ctx, "%s must not contain empty entries", field)
+		}
+		if strings.HasPrefix(name, "-") {
+			return errors.Errorf(ctx, "%s entry %q must not start with a dash", field, name)
+		}
+	}
+	return nil
+}
+
+// validatePushRetries ensures pushRetries is not negative.
+func (c Config) validatePushRetries(ctx context.Context) error {
+	if c.PushRetries < 0 {
+		return errors.Errorf(ctx, "pushRetries must be >= 0, got %d", c.PushRetries)
+	}
+	return nil
+}
+
+// ParsedRetryBackoff returns the parsed duration from RetryBackoff.
+// Returns 0 (retry immediately) when RetryBackoff is empty or unparseable.
+// Safe to call at any time — never panics.
+func (c Config) ParsedRetryBackoff() time.Duration {
+	if c.RetryBackoff == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.RetryBackoff)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// validateRetryBackoff rejects unparseable or negative duration strings for retryBackoff.
+func (c Config) validateRetryBackoff(ctx context.Context) error {
+	if c.RetryBackoff == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(c.RetryBackoff)
+	if err != nil {
+		return errors.Errorf(
+			ctx,
+			"retryBackoff %q is not a valid duration: %v",
+			c.RetryBackoff,
+			err,
+		)
+	}
+	if d < 0 {
+		return errors.Errorf(ctx, "retryBackoff must not be negative, got %s", c.RetryBackoff)
+	}
+	return nil
+}
+
 // ParsedIdleLogInterval returns the parsed duration from IdleLogInterval.
 // Returns time.Minute when IdleLogInterval is empty or unparseable (preserves default behaviour).
 // Returns 0 when IdleLogInterval is "0" (heartbeat disabled).
@@ -560,6 +1154,18 @@ func (c Config) validateIdleLogInterval(ctx context.Context) error {
 	return nil
 }
 
+// validateMinPromptBodyLength rejects negative minPromptBodyLength values.
+func (c Config) validateMinPromptBodyLength(ctx context.Context) error {
+	if c.MinPromptBodyLength < 0 {
+		return errors.Errorf(
+			ctx,
+			"minPromptBodyLength must not be negative, got %d",
+			c.MinPromptBodyLength,
+		)
+	}
+	return nil
+}
+
 // validateAutoRetryLimit rejects negative autoRetryLimit values.
 func (c Config) validateAutoRetryLimit(ctx context.Context) error {
 	if c.AutoRetryLimit < 0 {
@@ -684,6 +1290,52 @@ func (c Config) validateEnv(ctx context.Context) error {
 	return nil
 }
 
+// validatePromptDirLayout ensures completedDir and logDir are never watched as
+// prompt sources: neither may equal inboxDir or inProgressDir, and neither
+// watched directory may live inside them. Otherwise the watcher picks up files
+// the daemon just moved or wrote and loops. completed/ and log/ nested below
+// the watched directory are fine — the watcher and ListQueued are not recursive.
+func (c Config) validatePromptDirLayout(ctx context.Context) error {
+	excluded := []struct {
+		name string
+		dir  string
+	}{
+		{name: "completedDir", dir: c.Prompts.CompletedDir},
+		{name: "logDir", dir: c.Prompts.LogDir},
+	}
+	watched := []struct {
+		name string
+		dir  string
+	}{
+		{name: "inProgressDir", dir: c.Prompts.InProgressDir},
+		{name: "inboxDir", dir: c.Prompts.InboxDir},
+	}
+	for _, e := range excluded {
+		for _, w := range watched {
+			if e.dir != "" && w.dir != "" && filepath.Clean(e.dir) == filepath.Clean(w.dir) {
+				return errors.Errorf(ctx, "%s cannot equal %s", e.name, w.name)
+			}
+		}
+	}
+	for _, e := range excluded {
+		for _, w := range watched {
+			if e.dir != "" && w.dir != "" && isSubdir(e.dir, w.dir) {
+				return errors.Errorf(ctx, "%s %q cannot be inside %s %q", w.name, w.dir, e.name, e.dir)
+			}
+		}
+	}
+	return nil
+}
+
+// isSubdir reports whether child is strictly below parent after cleaning both paths.
+func isSubdir(parent, child string) bool {
+	rel, err := filepath.Rel(filepath.Clean(parent), filepath.Clean(child))
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // validateExtraMounts validates each extra mount entry.
 func (c Config) validateExtraMounts(ctx context.Context) error {
 	for i, m := range c.ExtraMounts {
@@ -781,6 +1433,24 @@ func (c Config) ResolvedBitbucketToken() string {
 	return token
 }
 
+// ResolvedServerToken reads the REST API bearer token from the env var named in ServerAuth.TokenEnv.
+// Returns empty string when not configured or env var is empty.
+func (c Config) ResolvedServerToken() string {
+	if c.ServerAuth.TokenEnv == "" {
+		return ""
+	}
+	return os.Getenv(c.ServerAuth.TokenEnv)
+}
+
+// ResolvedServerPassword reads the REST API basic-auth password from the env var named in ServerAuth.PasswordEnv.
+// Returns empty string when not configured or env var is empty.
+func (c Config) ResolvedServerPassword() string {
+	if c.ServerAuth.PasswordEnv == "" {
+		return ""
+	}
+	return os.Getenv(c.ServerAuth.PasswordEnv)
+}
+
 // ResolvedTelegramBotToken reads the Telegram bot token from the env var named in BotTokenEnv.
 // Returns empty string when not configured or env var is empty.
 func (c Config) ResolvedTelegramBotToken() string {
@@ -808,6 +1478,15 @@ func (c Config) ResolvedDiscordWebhook() string {
 	return os.Getenv(c.Notifications.Discord.WebhookEnv)
 }
 
+// ResolvedSlackWebhook reads the Slack incoming webhook URL from the env var named in
+// WebhookEnv, falling back to $DARK_FACTORY_SLACK_WEBHOOK. Returns empty string when unset.
+func (c Config) ResolvedSlackWebhook() string {
+	if c.Notifications.Slack.WebhookEnv == "" {
+		return os.Getenv(SlackWebhookEnvVar)
+	}
+	return os.Getenv(c.Notifications.Slack.WebhookEnv)
+}
+
 // validateValidationPrompt rejects absolute paths and paths that traverse outside the project root.
 func (c Config) validateValidationPrompt(ctx context.Context) error {
 	v := c.ValidationPrompt
@@ -839,5 +1518,14 @@ func (c Config) validateNotifications(ctx context.Context) error {
 	if webhook != "" && !strings.HasPrefix(webhook, "https://") {
 		return errors.Errorf(ctx, "discord webhook URL must use HTTPS")
 	}
+	slackWebhook := c.ResolvedSlackWebhook()
+	if slackWebhook != "" && !strings.HasPrefix(slackWebhook, "https://") {
+		return errors.Errorf(ctx, "slack webhook URL must use HTTPS")
+	}
+	if c.WebhookURL != "" &&
+		!strings.HasPrefix(c.WebhookURL, "https://") &&
+		!strings.HasPrefix(c.WebhookURL, "http://") {
+		return errors.Errorf(ctx, "webhookURL must be an http or https URL")
+	}
 	return nil
 }
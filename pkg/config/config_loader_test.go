@@ -15,6 +15,7 @@ import (
 
 	"github.com/bborbe/dark-factory/pkg"
 	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
 var _ = Describe("Config", func() {
@@ -88,6 +89,29 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	DescribeTable("ApplyWorkflow",
+		func(value string, startPR bool, expectedWorkflow config.Workflow, expectedPR bool) {
+			cfg := config.Defaults()
+			cfg.PR = startPR
+			Expect(config.ApplyWorkflow(context.Background(), &cfg, value)).To(Succeed())
+			Expect(cfg.Workflow).To(Equal(expectedWorkflow))
+			Expect(cfg.PR).To(Equal(expectedPR))
+		},
+		Entry("direct clears pr", "direct", true, config.WorkflowDirect, false),
+		Entry("pr selects clone with pr", "pr", false, config.WorkflowClone, true),
+		Entry("branch keeps pr", "branch", true, config.WorkflowBranch, true),
+		Entry("worktree keeps pr", "worktree", false, config.WorkflowWorktree, false),
+		Entry("clone keeps pr", "clone", true, config.WorkflowClone, true),
+	)
+
+	It("ApplyWorkflow rejects an unknown workflow and lists the valid values", func() {
+		cfg := config.Defaults()
+		err := config.ApplyWorkflow(context.Background(), &cfg, "yolo")
+		Expect(err).To(MatchError(ContainSubstring(`unknown workflow "yolo"`)))
+		Expect(err.Error()).To(ContainSubstring("clone, pr"))
+		Expect(cfg.Workflow).To(Equal(config.Defaults().Workflow))
+	})
+
 	Describe("Workflows", func() {
 		Describe("Contains", func() {
 			It("returns true for all four valid workflows", func() {
@@ -133,6 +157,713 @@ var _ = Describe("Config", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		Describe("ideas dir", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "")
+				GinkgoT().Setenv(config.IdeasDirEnvVar, "")
+			})
+
+			It("defaults to prompts/ideas", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.IdeasDir).To(Equal("prompts/ideas"))
+			})
+
+			It("is set by the env var, also when the prompts dir is moved", func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "/srv/prompts")
+				GinkgoT().Setenv(config.IdeasDirEnvVar, "backlog/ideas")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.InboxDir).To(Equal("/srv/prompts"))
+				Expect(cfg.Prompts.IdeasDir).To(Equal("backlog/ideas"))
+			})
+
+			It("applies the flag dir over the env dir", func() {
+				GinkgoT().Setenv(config.IdeasDirEnvVar, "/srv/from-env")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				config.ApplyIdeasDir(&cfg, "/srv/from-flag")
+				Expect(cfg.Prompts.IdeasDir).To(Equal("/srv/from-flag"))
+			})
+		})
+
+		Describe("log retention env", func() {
+			It("sets logRetention", func() {
+				GinkgoT().Setenv(config.LogRetentionEnvVar, "20")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.LogRetention).To(Equal("20"))
+			})
+
+			It("rejects an invalid value", func() {
+				GinkgoT().Setenv(config.LogRetentionEnvVar, "forever")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.LogRetentionEnvVar)))
+			})
+		})
+
+		Describe("log dir", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "")
+				GinkgoT().Setenv(config.LogDirEnvVar, "")
+			})
+
+			It("defaults to prompts/log", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.LogDir).To(Equal("prompts/log"))
+			})
+
+			It("resolves a relative env dir against the prompts dir", func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "/srv/a,/srv/b")
+				GinkgoT().Setenv(config.LogDirEnvVar, "agent-logs")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.LogDir).To(Equal("/srv/a/agent-logs"))
+				Expect(cfg.AdditionalPrompts[0].LogDir).To(Equal("/srv/b/agent-logs"))
+			})
+
+			It("keeps an absolute env dir", func() {
+				GinkgoT().Setenv(config.LogDirEnvVar, "/var/log/dark-factory")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.LogDir).To(Equal("/var/log/dark-factory"))
+			})
+
+			It("applies the flag dir over the env dir", func() {
+				GinkgoT().Setenv(config.LogDirEnvVar, "from-env")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				config.ApplyLogDir(&cfg, "from-flag")
+				Expect(cfg.Prompts.LogDir).To(Equal("prompts/from-flag"))
+			})
+		})
+
+		Describe("prompts dir", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "")
+			})
+
+			It("defaults to prompts with derived subdirectories", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts).To(Equal(config.PromptsConfig{
+					InboxDir:      "prompts",
+					InProgressDir: "prompts/in-progress",
+					CompletedDir:  "prompts/completed",
+					RejectedDir:   "prompts/rejected",
+					CancelledDir:  "prompts/cancelled",
+					LogDir:        "prompts/log",
+					IdeasDir:      "prompts/ideas",
+				}))
+			})
+
+			It("roots all prompt directories at an absolute env dir", func() {
+				dir := filepath.Join(tmpDir, "elsewhere")
+				GinkgoT().Setenv(config.PromptsDirEnvVar, dir)
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.InboxDir).To(Equal(dir))
+				Expect(cfg.Prompts.InProgressDir).To(Equal(filepath.Join(dir, "in-progress")))
+				Expect(cfg.Prompts.CompletedDir).To(Equal(filepath.Join(dir, "completed")))
+				Expect(cfg.Prompts.LogDir).To(Equal(filepath.Join(dir, "log")))
+			})
+
+			It("resolves a relative env dir against the working directory", func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "work/prompts")
+				wd, err := os.Getwd()
+				Expect(err).NotTo(HaveOccurred())
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.InboxDir).To(Equal(filepath.Join(wd, "work/prompts")))
+				Expect(cfg.Prompts.CompletedDir).
+					To(Equal(filepath.Join(wd, "work/prompts/completed")))
+			})
+
+			It("wins over the prompts section of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("prompts:\n  inboxDir: from-file\n  completedDir: from-file/done\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "/srv/prompts")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.InboxDir).To(Equal("/srv/prompts"))
+				Expect(cfg.Prompts.CompletedDir).To(Equal("/srv/prompts/completed"))
+			})
+
+			It("applies the flag dir over the env dir", func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "/srv/from-env")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.ApplyPromptsDir(ctx, &cfg, "/srv/from-flag")).To(Succeed())
+				Expect(cfg.Prompts.InboxDir).To(Equal("/srv/from-flag"))
+				Expect(cfg.Prompts.LogDir).To(Equal("/srv/from-flag/log"))
+			})
+
+			It("splits a comma-separated env dir into additional prompts dirs", func() {
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "/srv/a,/srv/b")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Prompts.InboxDir).To(Equal("/srv/a"))
+				Expect(cfg.AdditionalPrompts).To(HaveLen(1))
+				Expect(cfg.AdditionalPrompts[0].InboxDir).To(Equal("/srv/b"))
+				Expect(cfg.AdditionalPrompts[0].CompletedDir).To(Equal("/srv/b/completed"))
+				Expect(cfg.AdditionalPrompts[0].LogDir).To(Equal("/srv/b/log"))
+			})
+
+			It("expands a glob pattern to the matching directories", func() {
+				for _, sub := range []string{"b", "a"} {
+					Expect(os.MkdirAll(filepath.Join(tmpDir, "projects", sub, "prompts"), 0750)).
+						To(Succeed())
+				}
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.ApplyPromptsDir(
+					ctx,
+					&cfg,
+					filepath.Join(tmpDir, "projects", "*", "prompts"),
+				)).To(Succeed())
+				Expect(cfg.Prompts.InboxDir).To(Equal(filepath.Join(tmpDir, "projects/a/prompts")))
+				Expect(cfg.AdditionalPrompts).To(HaveLen(1))
+				Expect(cfg.AdditionalPrompts[0].InboxDir).
+					To(Equal(filepath.Join(tmpDir, "projects/b/prompts")))
+			})
+
+			It("rejects a glob pattern matching no directory", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.ApplyPromptsDir(ctx, &cfg, filepath.Join(tmpDir, "none-*"))).
+					NotTo(Succeed())
+			})
+
+			It("rejects a prompts dir listed twice", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("workflow: direct\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.PromptsDirEnvVar, "/srv/a,/srv/a/")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("listed more than once"))
+			})
+		})
+
+		Describe("prBodyTemplate", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.PRBodyTemplateEnvVar, "")
+			})
+
+			It("reads prBodyTemplate from the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("prBodyTemplate: \"{{.Title}}\"\n"),
+					0600,
+				)).To(Succeed())
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.PRBodyTemplate).To(Equal("{{.Title}}"))
+			})
+
+			It("lets the env var win over the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("prBodyTemplate: \"{{.Title}}\"\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.PRBodyTemplateEnvVar, "{{.Prompt}}")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.PRBodyTemplate).To(Equal("{{.Prompt}}"))
+			})
+		})
+
+		Describe("branchTemplate", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.BranchTemplateEnvVar, "")
+			})
+
+			It("reads branchTemplate from the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("branchTemplate: \"df/{{.Slug}}\"\n"),
+					0600,
+				)).To(Succeed())
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.BranchTemplate).To(Equal("df/{{.Slug}}"))
+			})
+
+			It("lets the env var win over the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("branchTemplate: \"df/{{.Slug}}\"\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.BranchTemplateEnvVar, "feat/{{.Number}}")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.BranchTemplate).To(Equal("feat/{{.Number}}"))
+			})
+		})
+
+		Describe("completedCommitMessage", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.CompletedCommitMessageEnvVar, "")
+			})
+
+			It("reads completedCommitMessage from the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("completedCommitMessage: \"done {{.Prompt}}\"\n"),
+					0600,
+				)).To(Succeed())
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.CompletedCommitMessage).To(Equal("done {{.Prompt}}"))
+			})
+
+			It("lets the env var win over the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("completedCommitMessage: \"done {{.Prompt}}\"\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.CompletedCommitMessageEnvVar, "complete: {{.Title}}")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.CompletedCommitMessage).To(Equal("complete: {{.Title}}"))
+			})
+		})
+
+		Describe("deleteBranch", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.DeleteBranchEnvVar, "")
+			})
+
+			It("defaults to false", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.DeleteBranch).To(BeFalse())
+			})
+
+			It("lets the env var win over the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("deleteBranch: true\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.DeleteBranchEnvVar, "false")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.DeleteBranch).To(BeFalse())
+			})
+
+			It("rejects a value other than true or false", func() {
+				GinkgoT().Setenv(config.DeleteBranchEnvVar, "yes")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring("expected true or false")))
+			})
+		})
+
+		Describe("prDraft", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(config.PRDraftEnvVar, "")
+			})
+
+			It("reads prDraft from the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("prDraft: true\n"),
+					0600,
+				)).To(Succeed())
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.PRDraft).To(BeTrue())
+			})
+
+			It("reads prDraft from the env var", func() {
+				GinkgoT().Setenv(config.PRDraftEnvVar, "true")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.PRDraft).To(BeTrue())
+			})
+		})
+
+		Describe("provider env", func() {
+			It("defaults to github", func() {
+				GinkgoT().Setenv(config.ProviderEnvVar, "")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Provider).To(Equal(config.ProviderGitHub))
+			})
+
+			It("wins over the provider of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("provider: github\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.ProviderEnvVar, "gitlab")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Provider).To(Equal(config.ProviderGitLab))
+			})
+
+			It("rejects an unknown provider", func() {
+				GinkgoT().Setenv(config.ProviderEnvVar, "svn")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.ProviderEnvVar)))
+			})
+		})
+
+		Describe("concurrency env", func() {
+			It("wins over the concurrency of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("concurrency: 2\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.ConcurrencyEnvVar, "4")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Concurrency).To(Equal(4))
+			})
+
+			It("rejects a value that is not a number", func() {
+				GinkgoT().Setenv(config.ConcurrencyEnvVar, "many")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.ConcurrencyEnvVar)))
+			})
+
+			It("rejects concurrency with a workflow other than direct", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("workflow: clone\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.ConcurrencyEnvVar, "2")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring("concurrency")))
+			})
+
+			It("rejects concurrency with the verification gate", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("verificationGate: true\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.ConcurrencyEnvVar, "2")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring("verificationGate")))
+			})
+		})
+
+		Describe("strictOrdering", func() {
+			It("defaults to true", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.StrictOrdering).To(BeTrue())
+			})
+
+			It("is disabled by the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("strictOrdering: false\n"),
+					0600,
+				)).To(Succeed())
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.StrictOrdering).To(BeFalse())
+			})
+
+			It("env wins over the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("strictOrdering: false\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.StrictOrderingEnvVar, "true")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.StrictOrdering).To(BeTrue())
+			})
+
+			It("rejects an env value that is not a boolean", func() {
+				GinkgoT().Setenv(config.StrictOrderingEnvVar, "sometimes")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.StrictOrderingEnvVar)))
+			})
+		})
+
+		Describe("debounce env", func() {
+			It("wins over debounceMs of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("debounceMs: 800\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.DebounceEnvVar, "2s")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.DebounceMs).To(Equal(2000))
+			})
+
+			DescribeTable("converts the duration to milliseconds",
+				func(value string, expected int) {
+					GinkgoT().Setenv(config.DebounceEnvVar, value)
+
+					cfg, err := loader.Load(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cfg.DebounceMs).To(Equal(expected))
+				},
+				Entry("tiny", "5ms", 5),
+				Entry("large", "1m", 60000),
+				Entry("below one millisecond", "100us", 1),
+				Entry("zero falls back to the default", "0s", config.DefaultDebounceMs),
+				Entry("negative falls back to the default", "-1s", config.DefaultDebounceMs),
+			)
+
+			It("rejects a value that is not a duration", func() {
+				GinkgoT().Setenv(config.DebounceEnvVar, "soon")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.DebounceEnvVar)))
+			})
+		})
+
+		Describe("completed layout env", func() {
+			It("wins over completedLayout of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("completedLayout: flat\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.CompletedLayoutEnvVar, "monthly")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.CompletedLayout).To(Equal(prompt.CompletedLayoutMonthly))
+			})
+
+			It("defaults to the flat layout", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.CompletedLayout).To(Equal(prompt.CompletedLayoutFlat))
+			})
+
+			It("rejects an unknown layout", func() {
+				GinkgoT().Setenv(config.CompletedLayoutEnvVar, "yearly")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.CompletedLayoutEnvVar)))
+			})
+		})
+
+		Describe("webhook URL env", func() {
+			It("wins over webhookURL of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("webhookURL: https://example.com/from-file\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.WebhookURLEnvVar, "https://example.com/from-env")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.WebhookURL).To(Equal("https://example.com/from-env"))
+			})
+
+			It("rejects a URL that is not http or https", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("workflow: direct\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.WebhookURLEnvVar, "ftp://example.com/hook")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring("webhookURL")))
+			})
+		})
+
+		Describe("git author env", func() {
+			It("wins over gitAuthorName and gitAuthorEmail of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("gitAuthorName: File User\ngitAuthorEmail: file@example.com\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.GitAuthorNameEnvVar, "Env User")
+				GinkgoT().Setenv(config.GitAuthorEmailEnvVar, "env@example.com")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GitAuthorName).To(Equal("Env User"))
+				Expect(cfg.GitAuthorEmail).To(Equal("env@example.com"))
+			})
+
+			It("applies without a config file", func() {
+				GinkgoT().Setenv(config.GitAuthorNameEnvVar, "Env User")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GitAuthorName).To(Equal("Env User"))
+				Expect(cfg.GitAuthorEmail).To(BeEmpty())
+			})
+		})
+
+		Describe("git remote env", func() {
+			It("defaults to origin", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GitRemote).To(Equal("origin"))
+			})
+
+			It("wins over gitRemote of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("gitRemote: fork\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.GitRemoteEnvVar, "upstream")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GitRemote).To(Equal("upstream"))
+			})
+
+			It("rejects a name that looks like a flag", func() {
+				GinkgoT().Setenv(config.GitRemoteEnvVar, "--mirror")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.GitRemoteEnvVar)))
+			})
+		})
+
+		Describe("resource limits", func() {
+			It("leaves the limits unset by default", func() {
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.MemoryLimit).To(BeEmpty())
+				Expect(cfg.CPULimit).To(BeEmpty())
+			})
+
+			It("reads memoryLimit and cpuLimit from the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("memoryLimit: 8g\ncpuLimit: \"2\"\n"),
+					0600,
+				)).To(Succeed())
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.MemoryLimit).To(Equal("8g"))
+				Expect(cfg.CPULimit).To(Equal("2"))
+			})
+
+			It("lets the env vars win over the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("memoryLimit: 8g\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.MemoryLimitEnvVar, "512m")
+				GinkgoT().Setenv(config.CPULimitEnvVar, "0.5")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.MemoryLimit).To(Equal("512m"))
+				Expect(cfg.CPULimit).To(Equal("0.5"))
+			})
+
+			It("rejects an invalid memory limit", func() {
+				GinkgoT().Setenv(config.MemoryLimitEnvVar, "lots")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.MemoryLimitEnvVar)))
+			})
+
+			It("rejects a non-positive CPU limit", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("cpuLimit: \"0\"\n"),
+					0600,
+				)).To(Succeed())
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring("cpuLimit")))
+			})
+		})
+
+		Describe("workflow env", func() {
+			It("wins over the workflow of the config file", func() {
+				Expect(os.WriteFile(
+					filepath.Join(tmpDir, ".dark-factory.yaml"),
+					[]byte("workflow: direct\n"),
+					0600,
+				)).To(Succeed())
+				GinkgoT().Setenv(config.WorkflowEnvVar, "pr")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Workflow).To(Equal(config.WorkflowClone))
+				Expect(cfg.PR).To(BeTrue())
+			})
+
+			It("applies without a config file", func() {
+				GinkgoT().Setenv(config.WorkflowEnvVar, "branch")
+
+				cfg, err := loader.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Workflow).To(Equal(config.WorkflowBranch))
+			})
+
+			It("rejects an unknown workflow", func() {
+				GinkgoT().Setenv(config.WorkflowEnvVar, "yolo")
+
+				_, err := loader.Load(ctx)
+				Expect(err).To(MatchError(ContainSubstring(config.WorkflowEnvVar)))
+			})
+		})
+
 		Describe("LoadWithOverrides", func() {
 			// The outer Loader BeforeEach already chdir's to a fresh tmpDir for each test.
 			// We write .dark-factory.yaml directly into the current directory.
@@ -972,4 +1703,31 @@ autoRetryLimit: 3
 		})
 	})
 
+	Describe("NewLoaderForDir", func() {
+		It("reads the config of another directory", func() {
+			dir := GinkgoT().TempDir()
+			Expect(os.WriteFile(
+				filepath.Join(dir, ".dark-factory.yaml"),
+				[]byte("prompts:\n  inboxDir: tasks\n"),
+				0600,
+			)).To(Succeed())
+
+			cfg, err := config.NewLoaderForDir(dir).Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Prompts.InboxDir).To(Equal("tasks"))
+		})
+	})
+
+	Describe("InProjectDir", func() {
+		It("resolves relative prompt and spec dirs against the project dir", func() {
+			cfg := config.Defaults()
+			cfg.Prompts.LogDir = "/var/log/prompts"
+
+			resolved := cfg.InProjectDir("/work/repoA")
+			Expect(resolved.Prompts.InProgressDir).To(Equal("/work/repoA/prompts/in-progress"))
+			Expect(resolved.Specs.CompletedDir).To(Equal("/work/repoA/specs/completed"))
+			Expect(resolved.Prompts.LogDir).To(Equal("/var/log/prompts"))
+			Expect(cfg.Prompts.InProgressDir).To(Equal("prompts/in-progress"))
+		})
+	})
 })
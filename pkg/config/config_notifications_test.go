@@ -533,6 +533,34 @@ var _ = Describe("Config", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("autoRetryLimit"))
 		})
+
+		It("fails when minPromptBodyLength is -1", func() {
+			cfg := validBase()
+			cfg.MinPromptBodyLength = -1
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("minPromptBodyLength"))
+		})
+	})
+
+	Describe("PushRetries", func() {
+		It("defaults to 3", func() {
+			Expect(config.Defaults().PushRetries).To(Equal(3))
+		})
+
+		It("succeeds when pushRetries is 0 (disabled)", func() {
+			cfg := config.Defaults()
+			cfg.PushRetries = 0
+			Expect(cfg.Validate(ctx)).NotTo(HaveOccurred())
+		})
+
+		It("fails when pushRetries is -1", func() {
+			cfg := config.Defaults()
+			cfg.PushRetries = -1
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("pushRetries"))
+		})
 	})
 
 	Describe("legacy worktree: bool mapping", func() {
@@ -848,6 +876,47 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("validateQueueMaxInterval", func() {
+		It("rejects invalid duration string", func() {
+			cfg := config.Defaults()
+			cfg.QueueMaxInterval = "bad"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("queueMaxInterval"))
+		})
+
+		It("rejects zero duration", func() {
+			cfg := config.Defaults()
+			cfg.QueueMaxInterval = "0s"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("queueMaxInterval"))
+		})
+
+		It("rejects a value below queueInterval", func() {
+			cfg := config.Defaults()
+			cfg.QueueInterval = "10s"
+			cfg.QueueMaxInterval = "5s"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must not be below queueInterval"))
+		})
+
+		It("allows a value equal to queueInterval (fixed polling)", func() {
+			cfg := config.Defaults()
+			cfg.QueueInterval = "10s"
+			cfg.QueueMaxInterval = "10s"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
+		It("defaults to 60s", func() {
+			cfg := config.Defaults()
+			Expect(cfg.ParsedQueueMaxInterval()).To(Equal(60 * time.Second))
+			cfg.QueueMaxInterval = ""
+			Expect(cfg.ParsedQueueMaxInterval()).To(Equal(60 * time.Second))
+		})
+	})
+
 	Describe("validateSweepInterval", func() {
 		It("rejects invalid duration string", func() {
 			cfg := config.Defaults()
@@ -927,4 +996,141 @@ var _ = Describe("Config", func() {
 			Expect(cfg.ParsedSweepInterval()).To(Equal(60 * time.Second))
 		})
 	})
+
+	Describe("completedRetention", func() {
+		It("rejects invalid duration string", func() {
+			cfg := config.Defaults()
+			cfg.CompletedRetention = "a month"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("completedRetention"))
+		})
+
+		It("rejects negative duration", func() {
+			cfg := config.Defaults()
+			cfg.CompletedRetention = "-1h"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("completedRetention"))
+		})
+
+		It("parses valid duration", func() {
+			cfg := config.Defaults()
+			cfg.CompletedRetention = "720h"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+			Expect(cfg.ParsedCompletedRetention()).To(Equal(720 * time.Hour))
+		})
+
+		It("returns 0 for empty", func() {
+			cfg := config.Defaults()
+			Expect(cfg.ParsedCompletedRetention()).To(Equal(time.Duration(0)))
+		})
+	})
+
+	Describe("logRetention", func() {
+		DescribeTable("rejects invalid values",
+			func(value string) {
+				cfg := config.Defaults()
+				cfg.LogRetention = value
+				err := cfg.Validate(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("logRetention"))
+			},
+			Entry("word", "a week"),
+			Entry("zero count", "0"),
+			Entry("negative count", "-5"),
+			Entry("negative duration", "-1h"),
+		)
+
+		It("parses a count", func() {
+			cfg := config.Defaults()
+			cfg.LogRetention = "50"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+			count, maxAge := cfg.ParsedLogRetention()
+			Expect(count).To(Equal(50))
+			Expect(maxAge).To(Equal(time.Duration(0)))
+		})
+
+		It("parses a duration", func() {
+			cfg := config.Defaults()
+			cfg.LogRetention = "168h"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+			count, maxAge := cfg.ParsedLogRetention()
+			Expect(count).To(Equal(0))
+			Expect(maxAge).To(Equal(168 * time.Hour))
+		})
+
+		It("keeps every log when empty", func() {
+			count, maxAge := config.Defaults().ParsedLogRetention()
+			Expect(count).To(Equal(0))
+			Expect(maxAge).To(Equal(time.Duration(0)))
+		})
+	})
+
+	Describe("readyDebounce", func() {
+		It("rejects invalid duration string", func() {
+			cfg := config.Defaults()
+			cfg.ReadyDebounce = "bad"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("readyDebounce"))
+		})
+
+		It("rejects negative duration", func() {
+			cfg := config.Defaults()
+			cfg.ReadyDebounce = "-1s"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("readyDebounce"))
+		})
+
+		It("allows zero (disabled)", func() {
+			cfg := config.Defaults()
+			cfg.ReadyDebounce = "0s"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+			Expect(cfg.ParsedReadyDebounce()).To(Equal(time.Duration(0)))
+		})
+
+		It("parses valid duration", func() {
+			cfg := config.Defaults()
+			cfg.ReadyDebounce = "250ms"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+			Expect(cfg.ParsedReadyDebounce()).To(Equal(250 * time.Millisecond))
+		})
+
+		It("returns 0 for empty", func() {
+			cfg := config.Defaults()
+			Expect(cfg.ParsedReadyDebounce()).To(Equal(time.Duration(0)))
+		})
+	})
+
+	Describe("retryBackoff", func() {
+		It("rejects invalid duration string", func() {
+			cfg := config.Defaults()
+			cfg.RetryBackoff = "bad"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("retryBackoff"))
+		})
+
+		It("rejects negative duration", func() {
+			cfg := config.Defaults()
+			cfg.RetryBackoff = "-1m"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("retryBackoff"))
+		})
+
+		It("parses valid duration", func() {
+			cfg := config.Defaults()
+			cfg.RetryBackoff = "2m"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+			Expect(cfg.ParsedRetryBackoff()).To(Equal(2 * time.Minute))
+		})
+
+		It("returns 0 for empty", func() {
+			cfg := config.Defaults()
+			Expect(cfg.ParsedRetryBackoff()).To(Equal(time.Duration(0)))
+		})
+	})
 })
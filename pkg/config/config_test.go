@@ -14,6 +14,8 @@ import (
 
 	"github.com/bborbe/dark-factory/pkg"
 	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
 var _ = Describe("Config", func() {
@@ -27,6 +29,7 @@ var _ = Describe("Config", func() {
 		It("returns config with default values", func() {
 			cfg := config.Defaults()
 			Expect(cfg.Workflow).To(Equal(config.WorkflowDirect))
+			Expect(cfg.StrictOrdering).To(BeTrue())
 			Expect(cfg.Prompts.InboxDir).To(Equal("prompts"))
 			Expect(cfg.Prompts.InProgressDir).To(Equal("prompts/in-progress"))
 			Expect(cfg.Prompts.CompletedDir).To(Equal("prompts/completed"))
@@ -48,6 +51,7 @@ var _ = Describe("Config", func() {
 			Expect(cfg.PreflightCommand).To(Equal("make precommit"))
 			Expect(cfg.PreflightInterval).To(Equal("8h"))
 			Expect(cfg.QueueInterval).To(Equal("5s"))
+			Expect(cfg.QueueOrder).To(Equal(prompt.QueueOrderPriority))
 			Expect(cfg.SweepInterval).To(Equal("60s"))
 		})
 	})
@@ -276,6 +280,63 @@ var _ = Describe("Config", func() {
 			Expect(err.Error()).To(ContainSubstring("completedDir cannot equal inboxDir"))
 		})
 
+		DescribeTable("rejects watched dirs that are, or live inside, completedDir or logDir",
+			func(prompts config.PromptsConfig, expected string) {
+				cfg := config.Config{
+					Workflow:       config.WorkflowDirect,
+					Prompts:        prompts,
+					ContainerImage: pkg.DefaultContainerImage,
+					Model:          "claude-sonnet-4-6",
+					DebounceMs:     500,
+					ServerPort:     8080,
+				}
+				err := cfg.Validate(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(expected))
+			},
+			Entry("logDir equals inProgressDir", config.PromptsConfig{
+				InboxDir:      "prompts",
+				InProgressDir: "prompts/in-progress",
+				CompletedDir:  "prompts/completed",
+				LogDir:        "prompts/in-progress",
+			}, "logDir cannot equal inProgressDir"),
+			Entry("inProgressDir inside completedDir", config.PromptsConfig{
+				InboxDir:      "prompts",
+				InProgressDir: "prompts/completed/queue",
+				CompletedDir:  "prompts/completed",
+				LogDir:        "prompts/log",
+			}, `inProgressDir "prompts/completed/queue" cannot be inside completedDir`),
+			Entry("inboxDir inside logDir", config.PromptsConfig{
+				InboxDir:      "prompts/log/inbox",
+				InProgressDir: "prompts/in-progress",
+				CompletedDir:  "prompts/completed",
+				LogDir:        "prompts/log",
+			}, "inboxDir \"prompts/log/inbox\" cannot be inside logDir"),
+			Entry("completedDir equals inProgressDir after cleaning", config.PromptsConfig{
+				InboxDir:      "prompts",
+				InProgressDir: "prompts/in-progress",
+				CompletedDir:  "prompts/in-progress/",
+				LogDir:        "prompts/log",
+			}, "completedDir cannot equal inProgressDir"),
+		)
+
+		It("accepts completedDir and logDir nested below the watched dir", func() {
+			cfg := config.Config{
+				Workflow: config.WorkflowDirect,
+				Prompts: config.PromptsConfig{
+					InboxDir:      "prompts",
+					InProgressDir: "prompts",
+					CompletedDir:  "prompts/completed",
+					LogDir:        "prompts/log",
+				},
+				ContainerImage: pkg.DefaultContainerImage,
+				Model:          "claude-sonnet-4-6",
+				DebounceMs:     500,
+				ServerPort:     8080,
+			}
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
 		It("fails for empty containerImage", func() {
 			cfg := config.Config{
 				Workflow: config.WorkflowDirect,
@@ -407,6 +468,32 @@ var _ = Describe("Config", func() {
 			Expect(err.Error()).To(ContainSubstring("serverPort"))
 		})
 
+		DescribeTable("serverTLS and serverAuth pairing",
+			func(mutate func(cfg *config.Config), expectedErr string) {
+				cfg := config.Defaults()
+				mutate(&cfg)
+				err := cfg.Validate(ctx)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(expectedErr))
+			},
+			Entry("tls cert and key", func(cfg *config.Config) {
+				cfg.ServerTLS = config.ServerTLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"}
+			}, ""),
+			Entry("tls cert without key", func(cfg *config.Config) {
+				cfg.ServerTLS = config.ServerTLSConfig{CertFile: "cert.pem"}
+			}, "serverTLS requires both certFile and keyFile"),
+			Entry("bearer token only", func(cfg *config.Config) {
+				cfg.ServerAuth = config.ServerAuthConfig{TokenEnv: "DF_TOKEN"}
+			}, ""),
+			Entry("username without passwordEnv", func(cfg *config.Config) {
+				cfg.ServerAuth = config.ServerAuthConfig{Username: "admin"}
+			}, "serverAuth requires both username and passwordEnv"),
+		)
+
 		It("succeeds for autoMerge true with workflow clone and pr: true", func() {
 			cfg := config.Config{
 				Workflow: config.WorkflowClone,
@@ -519,6 +606,78 @@ var _ = Describe("Config", func() {
 			Expect(err.Error()).To(ContainSubstring("autoMerge requires pr: true"))
 		})
 
+		It("succeeds for a valid prBodyTemplate", func() {
+			cfg := config.Defaults()
+			cfg.PRBodyTemplate = "{{.Title}}\n\n{{.Prompt}}"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
+		It("fails for an unparsable prBodyTemplate", func() {
+			cfg := config.Defaults()
+			cfg.PRBodyTemplate = "{{.Title"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("prBodyTemplate is not a valid template"))
+		})
+
+		It("succeeds for valid prReviewers and prLabels", func() {
+			cfg := config.Defaults()
+			cfg.PRReviewers = []string{"alice", "org/team"}
+			cfg.PRLabels = []string{"automated"}
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
+		It("fails for a prLabels entry starting with a dash", func() {
+			cfg := config.Defaults()
+			cfg.PRLabels = []string{"--web"}
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must not start with a dash"))
+		})
+
+		It("succeeds for a valid branchTemplate", func() {
+			cfg := config.Defaults()
+			cfg.BranchTemplate = "feature/{{.Number}}-{{.Slug}}"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
+		It("fails for an unparsable branchTemplate", func() {
+			cfg := config.Defaults()
+			cfg.BranchTemplate = "feature/{{.Slug"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("branchTemplate is not a valid template"))
+		})
+
+		It("fails for an unparsable completedCommitMessage", func() {
+			cfg := config.Defaults()
+			cfg.CompletedCommitMessage = "done {{.Prompt"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(
+				ContainSubstring("completedCommitMessage is not a valid template"),
+			)
+		})
+
+		It("fails for autoMerge true with provider gitlab", func() {
+			cfg := config.Defaults()
+			cfg.Workflow = config.WorkflowClone
+			cfg.PR = true
+			cfg.AutoMerge = true
+			cfg.Provider = config.ProviderGitLab
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("autoMerge is not supported with provider: gitlab"))
+		})
+
+		It("succeeds for pr true with provider gitlab", func() {
+			cfg := config.Defaults()
+			cfg.Workflow = config.WorkflowClone
+			cfg.PR = true
+			cfg.Provider = config.ProviderGitLab
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
 		It("succeeds for autoRelease true with autoMerge true", func() {
 			cfg := config.Config{
 				Workflow: config.WorkflowClone,
@@ -1290,4 +1449,120 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("queueOrder via Validate", func() {
+		validBase := config.Config{
+			Workflow: config.WorkflowDirect,
+			Prompts: config.PromptsConfig{
+				InboxDir:      "prompts",
+				InProgressDir: "prompts/in-progress",
+				CompletedDir:  "prompts/completed",
+				LogDir:        "prompts/log",
+			},
+			ContainerImage: "ghcr.io/bborbe/claude-code-yolo:latest",
+			Model:          "claude-sonnet-4-6",
+			DebounceMs:     500,
+		}
+
+		DescribeTable("accepts known values",
+			func(order prompt.QueueOrder) {
+				cfg := validBase
+				cfg.QueueOrder = order
+				Expect(cfg.Validate(ctx)).To(Succeed())
+			},
+			Entry("empty", prompt.QueueOrder("")),
+			Entry("number", prompt.QueueOrderNumber),
+			Entry("mtime", prompt.QueueOrderMtime),
+			Entry("priority", prompt.QueueOrderPriority),
+		)
+
+		It("rejects an unknown queueOrder", func() {
+			cfg := validBase
+			cfg.QueueOrder = "random"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("queueOrder"))
+		})
+	})
+
+	Describe("logFormat via Validate", func() {
+		validBase := config.Config{
+			Workflow: config.WorkflowDirect,
+			Prompts: config.PromptsConfig{
+				InboxDir:      "prompts",
+				InProgressDir: "prompts/in-progress",
+				CompletedDir:  "prompts/completed",
+				LogDir:        "prompts/log",
+			},
+			ContainerImage: "ghcr.io/bborbe/claude-code-yolo:latest",
+			Model:          "claude-sonnet-4-6",
+			DebounceMs:     500,
+		}
+
+		DescribeTable("accepts known values",
+			func(format log.Format) {
+				cfg := validBase
+				cfg.LogFormat = format
+				Expect(cfg.Validate(ctx)).To(Succeed())
+			},
+			Entry("empty", log.Format("")),
+			Entry("text", log.FormatText),
+			Entry("json", log.FormatJSON),
+		)
+
+		It("rejects an unknown logFormat", func() {
+			cfg := validBase
+			cfg.LogFormat = "xml"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("logFormat"))
+		})
+
+		It("rejects an unknown logLevel", func() {
+			cfg := validBase
+			cfg.LogLevel = "trace"
+			err := cfg.Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("logLevel"))
+		})
+	})
+
+	Describe("mirrorCompletedTo via Validate", func() {
+		validBase := config.Config{
+			Workflow: config.WorkflowDirect,
+			Prompts: config.PromptsConfig{
+				InboxDir:      "prompts",
+				InProgressDir: "prompts/in-progress",
+				CompletedDir:  "prompts/completed",
+				LogDir:        "prompts/log",
+			},
+			ContainerImage: "ghcr.io/bborbe/claude-code-yolo:latest",
+			Model:          "claude-sonnet-4-6",
+			DebounceMs:     500,
+		}
+
+		It("accepts an empty mirrorCompletedTo", func() {
+			cfg := validBase
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
+		It("accepts a separate directory", func() {
+			cfg := validBase
+			cfg.MirrorCompletedTo = "reports/completed"
+			Expect(cfg.Validate(ctx)).To(Succeed())
+		})
+
+		DescribeTable("rejects prompt directories",
+			func(dir string) {
+				cfg := validBase
+				cfg.MirrorCompletedTo = dir
+				err := cfg.Validate(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("mirrorCompletedTo"))
+			},
+			Entry("inbox", "prompts"),
+			Entry("in-progress", "prompts/in-progress/"),
+			Entry("completed", "./prompts/completed"),
+		)
+	})
+
 })
@@ -6,6 +6,8 @@ package config
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -189,6 +191,92 @@ func ComputeFieldSources(
 	return s
 }
 
+// ApplyPromptsDir roots all prompt directories at dir, replacing the configured ones.
+// dir may be a comma-separated list, and an entry may be a glob pattern expanding to
+// the matching directories in lexical order: the first directory becomes Prompts and
+// every further one an AdditionalPrompts directory watched alongside it.
+// A relative dir is resolved against the current working directory.
+func ApplyPromptsDir(ctx context.Context, cfg *Config, dir string) error {
+	var dirs []PromptsConfig
+	for _, d := range strings.Split(dir, ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		absDir, err := filepath.Abs(d)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "resolve prompts dir %s", d)
+		}
+		if !strings.ContainsAny(absDir, "*?[") {
+			dirs = append(dirs, NewPromptsConfig(absDir))
+			continue
+		}
+		matches, err := globDirs(absDir)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "expand prompts dir pattern %s", d)
+		}
+		if len(matches) == 0 {
+			return errors.Errorf(ctx, "prompts dir pattern %s matches no directory", d)
+		}
+		for _, match := range matches {
+			dirs = append(dirs, NewPromptsConfig(match))
+		}
+	}
+	if len(dirs) == 0 {
+		return errors.Errorf(ctx, "prompts dir %q contains no directory", dir)
+	}
+	cfg.Prompts = dirs[0]
+	cfg.AdditionalPrompts = dirs[1:]
+	return nil
+}
+
+// ApplyIdeasDir sets prompts.ideasDir to dir, or to $DARK_FACTORY_IDEAS_DIR when
+// dir is empty. Apply it after ApplyPromptsDir, which resets the ideas directory.
+func ApplyIdeasDir(cfg *Config, dir string) {
+	if dir == "" {
+		dir = os.Getenv(IdeasDirEnvVar)
+	}
+	if dir != "" {
+		cfg.Prompts.IdeasDir = dir
+	}
+}
+
+// ApplyLogDir sets prompts.logDir to dir, or to $DARK_FACTORY_LOG_DIR when dir is
+// empty. A relative dir is resolved against the prompts directory, for the
+// additional prompts directories too; an absolute dir applies to the primary one.
+// Apply it after ApplyPromptsDir, which resets the log directory.
+func ApplyLogDir(cfg *Config, dir string) {
+	if dir == "" {
+		dir = os.Getenv(LogDirEnvVar)
+	}
+	if dir == "" {
+		return
+	}
+	if filepath.IsAbs(dir) {
+		cfg.Prompts.LogDir = dir
+		return
+	}
+	cfg.Prompts.LogDir = filepath.Join(cfg.Prompts.InboxDir, dir)
+	for i := range cfg.AdditionalPrompts {
+		cfg.AdditionalPrompts[i].LogDir = filepath.Join(cfg.AdditionalPrompts[i].InboxDir, dir)
+	}
+}
+
+// globDirs returns the directories matching pattern in lexical order.
+func globDirs(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.IsDir() {
+			dirs = append(dirs, match)
+		}
+	}
+	return dirs, nil
+}
+
 // ApplyArgOverrides validates command-gate rules and applies --model CLI flag
 // override to cfg and sources. model is the extracted flag value from
 // ParseArgs (empty = not set).
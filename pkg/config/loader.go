@@ -8,9 +8,15 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/bborbe/errors"
 	"gopkg.in/yaml.v3"
+
+	"github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
 //counterfeiter:generate -o ../../mocks/config-loader.go --fake-name Loader . Loader
@@ -27,6 +33,13 @@ func NewLoader() Loader {
 	}
 }
 
+// NewLoaderForDir creates a Loader that reads .dark-factory.yaml in dir.
+func NewLoaderForDir(dir string) Loader {
+	return &fileLoader{
+		configPath: filepath.Join(dir, ".dark-factory.yaml"),
+	}
+}
+
 // LayeredProjectOverrides reports which of the 4 layered user-pref fields were
 // explicitly set in .dark-factory.yaml. nil means the field was absent from the file
 // (so the default or global value applies). Non-nil means project explicitly set it.
@@ -61,6 +74,204 @@ func LoadWithOverrides(ctx context.Context) (LoadResult, error) {
 	return (&fileLoader{configPath: ".dark-factory.yaml"}).loadWithOverrides(ctx)
 }
 
+// applyPromptsDirEnv roots the prompt directories at $DARK_FACTORY_PROMPTS_DIR when set.
+func applyPromptsDirEnv(ctx context.Context, cfg *Config) error {
+	dir := os.Getenv(PromptsDirEnvVar)
+	if dir == "" {
+		return nil
+	}
+	return ApplyPromptsDir(ctx, cfg, dir)
+}
+
+// applyProviderEnv sets the git provider from $DARK_FACTORY_PR_PROVIDER when set.
+func applyProviderEnv(ctx context.Context, cfg *Config) error {
+	provider := Provider(os.Getenv(ProviderEnvVar))
+	if provider == "" {
+		return nil
+	}
+	if err := provider.Validate(ctx); err != nil {
+		return errors.Wrapf(ctx, err, "invalid %s", ProviderEnvVar)
+	}
+	cfg.Provider = provider
+	return nil
+}
+
+// applyPRBodyTemplateEnv sets prBodyTemplate from $DARK_FACTORY_PR_BODY_TEMPLATE when set.
+func applyPRBodyTemplateEnv(cfg *Config) {
+	if tmpl := os.Getenv(PRBodyTemplateEnvVar); tmpl != "" {
+		cfg.PRBodyTemplate = tmpl
+	}
+}
+
+// applyBranchTemplateEnv sets branchTemplate from $DARK_FACTORY_BRANCH_TEMPLATE when set.
+func applyBranchTemplateEnv(cfg *Config) {
+	if tmpl := os.Getenv(BranchTemplateEnvVar); tmpl != "" {
+		cfg.BranchTemplate = tmpl
+	}
+}
+
+// applyCompletedCommitMessageEnv sets completedCommitMessage from
+// $DARK_FACTORY_COMPLETED_COMMIT_MSG when set.
+func applyCompletedCommitMessageEnv(cfg *Config) {
+	if tmpl := os.Getenv(CompletedCommitMessageEnvVar); tmpl != "" {
+		cfg.CompletedCommitMessage = tmpl
+	}
+}
+
+// applyBoolEnv sets *target from the env var name when set. Only "true" and
+// "false" are accepted, matching yaml semantics.
+func applyBoolEnv(ctx context.Context, name string, target *bool) error {
+	switch value := os.Getenv(name); value {
+	case "":
+		return nil
+	case "true":
+		*target = true
+	case "false":
+		*target = false
+	default:
+		return errors.Errorf(ctx, "invalid %s %q, expected true or false", name, value)
+	}
+	return nil
+}
+
+// applyStrictOrderingEnv sets strictOrdering from $DARK_FACTORY_STRICT_ORDERING when set.
+func applyStrictOrderingEnv(ctx context.Context, cfg *Config) error {
+	return applyBoolEnv(ctx, StrictOrderingEnvVar, &cfg.StrictOrdering)
+}
+
+// applyDeleteBranchEnv sets deleteBranch from $DARK_FACTORY_DELETE_BRANCH when set.
+func applyDeleteBranchEnv(ctx context.Context, cfg *Config) error {
+	return applyBoolEnv(ctx, DeleteBranchEnvVar, &cfg.DeleteBranch)
+}
+
+// applyPRDraftEnv sets prDraft from $DARK_FACTORY_PR_DRAFT when set.
+func applyPRDraftEnv(ctx context.Context, cfg *Config) error {
+	return applyBoolEnv(ctx, PRDraftEnvVar, &cfg.PRDraft)
+}
+
+// applyConcurrencyEnv sets concurrency from $DARK_FACTORY_CONCURRENCY when set.
+func applyConcurrencyEnv(ctx context.Context, cfg *Config) error {
+	value := os.Getenv(ConcurrencyEnvVar)
+	if value == "" {
+		return nil
+	}
+	concurrency, err := strconv.Atoi(value)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "invalid %s", ConcurrencyEnvVar)
+	}
+	cfg.Concurrency = concurrency
+	return nil
+}
+
+// applyDebounceEnv sets debounceMs from the duration in $DARK_FACTORY_DEBOUNCE when set.
+// Zero or negative durations fall back to DefaultDebounceMs; positive durations below
+// one millisecond round up to 1ms.
+func applyDebounceEnv(ctx context.Context, cfg *Config) error {
+	value := os.Getenv(DebounceEnvVar)
+	if value == "" {
+		return nil
+	}
+	debounce, err := time.ParseDuration(value)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "invalid %s", DebounceEnvVar)
+	}
+	if debounce <= 0 {
+		cfg.DebounceMs = DefaultDebounceMs
+		return nil
+	}
+	cfg.DebounceMs = max(int(debounce.Milliseconds()), 1)
+	return nil
+}
+
+// applyLogRetentionEnv sets logRetention from $DARK_FACTORY_LOG_RETENTION when set.
+func applyLogRetentionEnv(ctx context.Context, cfg *Config) error {
+	value := os.Getenv(LogRetentionEnvVar)
+	if value == "" {
+		return nil
+	}
+	if _, _, err := parseLogRetention(ctx, value); err != nil {
+		return errors.Wrapf(ctx, err, "invalid %s", LogRetentionEnvVar)
+	}
+	cfg.LogRetention = value
+	return nil
+}
+
+// applyCompletedLayoutEnv sets completedLayout from $DARK_FACTORY_COMPLETED_LAYOUT when set.
+func applyCompletedLayoutEnv(ctx context.Context, cfg *Config) error {
+	layout := prompt.CompletedLayout(os.Getenv(CompletedLayoutEnvVar))
+	if layout == "" {
+		return nil
+	}
+	if err := layout.Validate(ctx); err != nil {
+		return errors.Wrapf(ctx, err, "invalid %s", CompletedLayoutEnvVar)
+	}
+	cfg.CompletedLayout = layout
+	return nil
+}
+
+// applyWebhookURLEnv sets webhookURL from $DARK_FACTORY_WEBHOOK_URL when set.
+func applyWebhookURLEnv(cfg *Config) {
+	if url := os.Getenv(WebhookURLEnvVar); url != "" {
+		cfg.WebhookURL = url
+	}
+}
+
+// applyGitAuthorEnv sets gitAuthorName and gitAuthorEmail from
+// $DARK_FACTORY_GIT_AUTHOR_NAME and $DARK_FACTORY_GIT_AUTHOR_EMAIL when set.
+func applyGitAuthorEnv(cfg *Config) {
+	if name := os.Getenv(GitAuthorNameEnvVar); name != "" {
+		cfg.GitAuthorName = name
+	}
+	if email := os.Getenv(GitAuthorEmailEnvVar); email != "" {
+		cfg.GitAuthorEmail = email
+	}
+}
+
+// applyGitRemoteEnv sets gitRemote from $DARK_FACTORY_GIT_REMOTE when set.
+// The name is validated here because the no-config-file path skips Validate.
+func applyGitRemoteEnv(ctx context.Context, cfg *Config) error {
+	remote := os.Getenv(GitRemoteEnvVar)
+	if remote == "" {
+		return nil
+	}
+	cfg.GitRemote = remote
+	if err := cfg.validateGitRemote(ctx); err != nil {
+		return errors.Wrapf(ctx, err, "invalid %s", GitRemoteEnvVar)
+	}
+	return nil
+}
+
+// applyWorkflowEnv selects the workflow from $DARK_FACTORY_WORKFLOW when set.
+func applyWorkflowEnv(ctx context.Context, cfg *Config) error {
+	value := os.Getenv(WorkflowEnvVar)
+	if value == "" {
+		return nil
+	}
+	if err := ApplyWorkflow(ctx, cfg, value); err != nil {
+		return errors.Wrapf(ctx, err, "invalid %s", WorkflowEnvVar)
+	}
+	return nil
+}
+
+// applyResourceLimitsEnv sets memoryLimit and cpuLimit from $DARK_FACTORY_MEM_LIMIT
+// and $DARK_FACTORY_CPU_LIMIT when set. The values are validated here because the
+// no-config-file path skips Validate.
+func applyResourceLimitsEnv(ctx context.Context, cfg *Config) error {
+	if memory := os.Getenv(MemoryLimitEnvVar); memory != "" {
+		cfg.MemoryLimit = memory
+		if err := cfg.validateMemoryLimit(ctx); err != nil {
+			return errors.Wrapf(ctx, err, "invalid %s", MemoryLimitEnvVar)
+		}
+	}
+	if cpus := os.Getenv(CPULimitEnvVar); cpus != "" {
+		cfg.CPULimit = cpus
+		if err := cfg.validateCPULimit(ctx); err != nil {
+			return errors.Wrapf(ctx, err, "invalid %s", CPULimitEnvVar)
+		}
+	}
+	return nil
+}
+
 // fileLoader implements Loader by reading from a file.
 type fileLoader struct {
 	configPath string
@@ -72,6 +283,7 @@ type partialPromptsConfig struct {
 	InProgressDir *string `yaml:"inProgressDir"`
 	CompletedDir  *string `yaml:"completedDir"`
 	LogDir        *string `yaml:"logDir"`
+	IdeasDir      *string `yaml:"ideasDir"`
 }
 
 // partialSpecsConfig is used for YAML unmarshaling of the specs section.
@@ -85,55 +297,88 @@ type partialSpecsConfig struct {
 // partialConfig is used for YAML unmarshaling to distinguish between
 // explicitly set zero values and missing fields.
 type partialConfig struct {
-	Workflow          *Workflow             `yaml:"workflow"`
-	PR                *bool                 `yaml:"pr"`
-	Worktree          *bool                 `yaml:"worktree"`
-	ProjectName       *string               `yaml:"projectName"`
-	Project           *string               `yaml:"project,omitempty"`
-	DefaultBranch     *string               `yaml:"defaultBranch"`
-	Prompts           *partialPromptsConfig `yaml:"prompts"`
-	Specs             *partialSpecsConfig   `yaml:"specs"`
-	ContainerImage    *string               `yaml:"containerImage"`
-	NetrcFile         *string               `yaml:"netrcFile"`
-	GitconfigFile     *string               `yaml:"gitconfigFile"`
-	Model             *string               `yaml:"model"`
-	ValidationCommand *string               `yaml:"validationCommand"`
-	ValidationPrompt  *string               `yaml:"validationPrompt"`
-	TestCommand       *string               `yaml:"testCommand"`
-	DebounceMs        *int                  `yaml:"debounceMs"`
-	ServerPort        *int                  `yaml:"serverPort"`
-	AutoMerge         *bool                 `yaml:"autoMerge"`
-	AutoRelease       *bool                 `yaml:"autoRelease"`
-	VerificationGate  *bool                 `yaml:"verificationGate"`
+	Workflow               *Workflow             `yaml:"workflow"`
+	PR                     *bool                 `yaml:"pr"`
+	Worktree               *bool                 `yaml:"worktree"`
+	ProjectName            *string               `yaml:"projectName"`
+	Project                *string               `yaml:"project,omitempty"`
+	DefaultBranch          *string               `yaml:"defaultBranch"`
+	Prompts                *partialPromptsConfig `yaml:"prompts"`
+	Specs                  *partialSpecsConfig   `yaml:"specs"`
+	ContainerImage         *string               `yaml:"containerImage"`
+	NetrcFile              *string               `yaml:"netrcFile"`
+	GitconfigFile          *string               `yaml:"gitconfigFile"`
+	Model                  *string               `yaml:"model"`
+	ValidationCommand      *string               `yaml:"validationCommand"`
+	ValidationPrompt       *string               `yaml:"validationPrompt"`
+	TestCommand            *string               `yaml:"testCommand"`
+	DebounceMs             *int                  `yaml:"debounceMs"`
+	ServerPort             *int                  `yaml:"serverPort"`
+	AutoMerge              *bool                 `yaml:"autoMerge"`
+	AutoRelease            *bool                 `yaml:"autoRelease"`
+	DeleteBranch           *bool                 `yaml:"deleteBranch"`
+	StrictOrdering         *bool                 `yaml:"strictOrdering"`
+	PRDraft                *bool                 `yaml:"prDraft"`
+	PRReviewers            []string              `yaml:"prReviewers"`
+	PRLabels               []string              `yaml:"prLabels"`
+	VerificationGate       *bool                 `yaml:"verificationGate"`
+	ResultCache            *bool                 `yaml:"resultCache"`
+	Canary                 *bool                 `yaml:"canary"`
+	CommitBody             *CommitBody           `yaml:"commitBody"`
+	ChangelogSections      *bool                 `yaml:"changelogSections"`
+	PRBodyTemplate         *string               `yaml:"prBodyTemplate"`
+	BranchTemplate         *string               `yaml:"branchTemplate"`
+	CompletedCommitMessage *string               `yaml:"completedCommitMessage"`
+	GitAuthorName          *string               `yaml:"gitAuthorName"`
+	GitAuthorEmail         *string               `yaml:"gitAuthorEmail"`
+	GitRemote              *string               `yaml:"gitRemote"`
+	MemoryLimit            *string               `yaml:"memoryLimit"`
+	CPULimit               *string               `yaml:"cpuLimit"`
+	PushRetries            *int                  `yaml:"pushRetries"`
 	// Removed fields kept as sentinels to detect legacy configs.
 	// loadWithOverrides returns a friendly error if any of these is set.
-	AutoReview             *bool                `yaml:"autoReview"`
-	AllowedReviewers       []string             `yaml:"allowedReviewers,omitempty"`
-	UseCollaborators       *bool                `yaml:"useCollaborators"`
-	GitHub                 *GitHubConfig        `yaml:"github"`
-	Provider               *Provider            `yaml:"provider"`
-	Bitbucket              *BitbucketConfig     `yaml:"bitbucket"`
-	Notifications          *NotificationsConfig `yaml:"notifications"`
-	Env                    map[string]string    `yaml:"env,omitempty"`
-	ExtraMounts            []ExtraMount         `yaml:"extraMounts,omitempty"`
-	ClaudeDir              *string              `yaml:"claudeDir"`
-	GenerateCommand        *string              `yaml:"generateCommand"`
-	AdditionalInstructions *string              `yaml:"additionalInstructions,omitempty"`
-	MaxContainers          *int                 `yaml:"maxContainers,omitempty"`
-	DirtyFileThreshold     *int                 `yaml:"dirtyFileThreshold,omitempty"`
-	AutoApprovePrompts     *bool                `yaml:"autoApprovePrompts"`
-	AutoGeneratePrompts    *bool                `yaml:"autoGeneratePrompts"`
-	Backend                *Backend             `yaml:"backend"`
-	MaxPromptDuration      *string              `yaml:"maxPromptDuration"`
-	AutoRetryLimit         *int                 `yaml:"autoRetryLimit"`
-	HideGit                *bool                `yaml:"hideGit"`
-	PreflightCommand       *string              `yaml:"preflightCommand"`
-	PreflightInterval      *string              `yaml:"preflightInterval"`
-	HealthcheckEnabled     *bool                `yaml:"healthcheckEnabled"`
-	HealthcheckInterval    *string              `yaml:"healthcheckInterval"`
-	QueueInterval          *string              `yaml:"queueInterval"`
-	SweepInterval          *string              `yaml:"sweepInterval"`
-	IdleLogInterval        *string              `yaml:"idleLogInterval"`
+	AutoReview             *bool                   `yaml:"autoReview"`
+	AllowedReviewers       []string                `yaml:"allowedReviewers,omitempty"`
+	UseCollaborators       *bool                   `yaml:"useCollaborators"`
+	GitHub                 *GitHubConfig           `yaml:"github"`
+	Provider               *Provider               `yaml:"provider"`
+	Bitbucket              *BitbucketConfig        `yaml:"bitbucket"`
+	Notifications          *NotificationsConfig    `yaml:"notifications"`
+	ServerTLS              *ServerTLSConfig        `yaml:"serverTLS"`
+	ServerAuth             *ServerAuthConfig       `yaml:"serverAuth"`
+	Env                    map[string]string       `yaml:"env,omitempty"`
+	ExtraMounts            []ExtraMount            `yaml:"extraMounts,omitempty"`
+	ClaudeDir              *string                 `yaml:"claudeDir"`
+	GenerateCommand        *string                 `yaml:"generateCommand"`
+	AdditionalInstructions *string                 `yaml:"additionalInstructions,omitempty"`
+	MaxContainers          *int                    `yaml:"maxContainers,omitempty"`
+	Concurrency            *int                    `yaml:"concurrency,omitempty"`
+	DirtyFileThreshold     *int                    `yaml:"dirtyFileThreshold,omitempty"`
+	AutoApprovePrompts     *bool                   `yaml:"autoApprovePrompts"`
+	AutoGeneratePrompts    *bool                   `yaml:"autoGeneratePrompts"`
+	Backend                *Backend                `yaml:"backend"`
+	MaxPromptDuration      *string                 `yaml:"maxPromptDuration"`
+	AutoRetryLimit         *int                    `yaml:"autoRetryLimit"`
+	MinPromptBodyLength    *int                    `yaml:"minPromptBodyLength"`
+	RetryBackoff           *string                 `yaml:"retryBackoff"`
+	HideGit                *bool                   `yaml:"hideGit"`
+	PreflightCommand       *string                 `yaml:"preflightCommand"`
+	PreflightInterval      *string                 `yaml:"preflightInterval"`
+	HealthcheckEnabled     *bool                   `yaml:"healthcheckEnabled"`
+	HealthcheckInterval    *string                 `yaml:"healthcheckInterval"`
+	QueueInterval          *string                 `yaml:"queueInterval"`
+	QueueMaxInterval       *string                 `yaml:"queueMaxInterval"`
+	QueueOrder             *prompt.QueueOrder      `yaml:"queueOrder"`
+	CompletedLayout        *prompt.CompletedLayout `yaml:"completedLayout"`
+	WebhookURL             *string                 `yaml:"webhookURL"`
+	MirrorCompletedTo      *string                 `yaml:"mirrorCompletedTo"`
+	CompletedRetention     *string                 `yaml:"completedRetention"`
+	LogRetention           *string                 `yaml:"logRetention"`
+	SweepInterval          *string                 `yaml:"sweepInterval"`
+	ReadyDebounce          *string                 `yaml:"readyDebounce"`
+	IdleLogInterval        *string                 `yaml:"idleLogInterval"`
+	LogFormat              *log.Format             `yaml:"logFormat"`
+	LogLevel               *log.Level              `yaml:"logLevel"`
 }
 
 // Load reads the config file, merges with defaults, validates, and returns the config.
@@ -159,6 +404,49 @@ func (l *fileLoader) loadWithOverrides(ctx context.Context) (LoadResult, error)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// File doesn't exist - return defaults, no overrides
+			if err := applyPromptsDirEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			ApplyIdeasDir(&cfg, "")
+			ApplyLogDir(&cfg, "")
+			if err := applyProviderEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			applyPRBodyTemplateEnv(&cfg)
+			applyBranchTemplateEnv(&cfg)
+			applyCompletedCommitMessageEnv(&cfg)
+			if err := applyDeleteBranchEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyPRDraftEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyConcurrencyEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyStrictOrderingEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyDebounceEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyCompletedLayoutEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyLogRetentionEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			applyWebhookURLEnv(&cfg)
+			applyGitAuthorEnv(&cfg)
+			if err := applyGitRemoteEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyResourceLimitsEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
+			if err := applyWorkflowEnv(ctx, &cfg); err != nil {
+				return LoadResult{}, err
+			}
 			return LoadResult{Config: cfg}, nil
 		}
 		return LoadResult{}, errors.Wrap(ctx, err, "read config file")
@@ -274,6 +562,50 @@ func (l *fileLoader) loadWithOverrides(ctx context.Context) (LoadResult, error)
 	// Step D — zero out cfg.Worktree unconditionally
 	cfg.Worktree = false
 
+	if err := applyPromptsDirEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	ApplyIdeasDir(&cfg, "")
+	ApplyLogDir(&cfg, "")
+	if err := applyProviderEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	applyPRBodyTemplateEnv(&cfg)
+	applyBranchTemplateEnv(&cfg)
+	applyCompletedCommitMessageEnv(&cfg)
+	if err := applyDeleteBranchEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyPRDraftEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyConcurrencyEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyStrictOrderingEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyDebounceEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyCompletedLayoutEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyLogRetentionEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	applyWebhookURLEnv(&cfg)
+	applyGitAuthorEnv(&cfg)
+	if err := applyGitRemoteEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyResourceLimitsEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+	if err := applyWorkflowEnv(ctx, &cfg); err != nil {
+		return LoadResult{}, err
+	}
+
 	// Validate merged config
 	if err := cfg.Validate(ctx); err != nil {
 		return LoadResult{}, errors.Wrap(ctx, err, "validate config")
@@ -360,9 +692,63 @@ func mergePartialRuntime(cfg *Config, partial *partialConfig) {
 	if partial.AutoRelease != nil {
 		cfg.AutoRelease = *partial.AutoRelease
 	}
+	if partial.DeleteBranch != nil {
+		cfg.DeleteBranch = *partial.DeleteBranch
+	}
+	if partial.StrictOrdering != nil {
+		cfg.StrictOrdering = *partial.StrictOrdering
+	}
+	if partial.PRDraft != nil {
+		cfg.PRDraft = *partial.PRDraft
+	}
+	if partial.PRReviewers != nil {
+		cfg.PRReviewers = partial.PRReviewers
+	}
+	if partial.PRLabels != nil {
+		cfg.PRLabels = partial.PRLabels
+	}
 	if partial.VerificationGate != nil {
 		cfg.VerificationGate = *partial.VerificationGate
 	}
+	if partial.ResultCache != nil {
+		cfg.ResultCache = *partial.ResultCache
+	}
+	if partial.Canary != nil {
+		cfg.Canary = *partial.Canary
+	}
+	if partial.CommitBody != nil {
+		cfg.CommitBody = *partial.CommitBody
+	}
+	if partial.ChangelogSections != nil {
+		cfg.ChangelogSections = *partial.ChangelogSections
+	}
+	if partial.GitAuthorName != nil {
+		cfg.GitAuthorName = *partial.GitAuthorName
+	}
+	if partial.GitAuthorEmail != nil {
+		cfg.GitAuthorEmail = *partial.GitAuthorEmail
+	}
+	if partial.GitRemote != nil {
+		cfg.GitRemote = *partial.GitRemote
+	}
+	if partial.MemoryLimit != nil {
+		cfg.MemoryLimit = *partial.MemoryLimit
+	}
+	if partial.CPULimit != nil {
+		cfg.CPULimit = *partial.CPULimit
+	}
+	if partial.PushRetries != nil {
+		cfg.PushRetries = *partial.PushRetries
+	}
+	if partial.PRBodyTemplate != nil {
+		cfg.PRBodyTemplate = *partial.PRBodyTemplate
+	}
+	if partial.BranchTemplate != nil {
+		cfg.BranchTemplate = *partial.BranchTemplate
+	}
+	if partial.CompletedCommitMessage != nil {
+		cfg.CompletedCommitMessage = *partial.CompletedCommitMessage
+	}
 	if partial.ClaudeDir != nil {
 		cfg.ClaudeDir = *partial.ClaudeDir
 	}
@@ -421,6 +807,12 @@ func mergePartialProviders(cfg *Config, partial *partialConfig) {
 	if partial.Notifications != nil {
 		cfg.Notifications = *partial.Notifications
 	}
+	if partial.ServerTLS != nil {
+		cfg.ServerTLS = *partial.ServerTLS
+	}
+	if partial.ServerAuth != nil {
+		cfg.ServerAuth = *partial.ServerAuth
+	}
 }
 
 // mergePartialLimits merges resource limit and duration settings.
@@ -431,6 +823,9 @@ func mergePartialLimits(cfg *Config, partial *partialConfig) {
 	if partial.MaxContainers != nil {
 		cfg.MaxContainers = *partial.MaxContainers
 	}
+	if partial.Concurrency != nil {
+		cfg.Concurrency = *partial.Concurrency
+	}
 	if partial.DirtyFileThreshold != nil {
 		cfg.DirtyFileThreshold = *partial.DirtyFileThreshold
 	}
@@ -443,15 +838,51 @@ func mergePartialLimits(cfg *Config, partial *partialConfig) {
 	if partial.AutoRetryLimit != nil {
 		cfg.AutoRetryLimit = *partial.AutoRetryLimit
 	}
+	if partial.MinPromptBodyLength != nil {
+		cfg.MinPromptBodyLength = *partial.MinPromptBodyLength
+	}
+	if partial.RetryBackoff != nil {
+		cfg.RetryBackoff = *partial.RetryBackoff
+	}
 	if partial.QueueInterval != nil {
 		cfg.QueueInterval = *partial.QueueInterval
 	}
+	if partial.QueueMaxInterval != nil {
+		cfg.QueueMaxInterval = *partial.QueueMaxInterval
+	}
+	if partial.QueueOrder != nil {
+		cfg.QueueOrder = *partial.QueueOrder
+	}
+	if partial.CompletedLayout != nil {
+		cfg.CompletedLayout = *partial.CompletedLayout
+	}
+	if partial.WebhookURL != nil {
+		cfg.WebhookURL = *partial.WebhookURL
+	}
+	if partial.MirrorCompletedTo != nil {
+		cfg.MirrorCompletedTo = *partial.MirrorCompletedTo
+	}
+	if partial.CompletedRetention != nil {
+		cfg.CompletedRetention = *partial.CompletedRetention
+	}
+	if partial.LogRetention != nil {
+		cfg.LogRetention = *partial.LogRetention
+	}
 	if partial.SweepInterval != nil {
 		cfg.SweepInterval = *partial.SweepInterval
 	}
+	if partial.ReadyDebounce != nil {
+		cfg.ReadyDebounce = *partial.ReadyDebounce
+	}
 	if partial.IdleLogInterval != nil {
 		cfg.IdleLogInterval = *partial.IdleLogInterval
 	}
+	if partial.LogFormat != nil {
+		cfg.LogFormat = *partial.LogFormat
+	}
+	if partial.LogLevel != nil {
+		cfg.LogLevel = *partial.LogLevel
+	}
 }
 
 // mergePartialPrompts applies non-nil fields from src onto dst.
@@ -471,6 +902,9 @@ func mergePartialPrompts(dst *PromptsConfig, src *partialPromptsConfig) {
 	if src.LogDir != nil {
 		dst.LogDir = *src.LogDir
 	}
+	if src.IdeasDir != nil {
+		dst.IdeasDir = *src.IdeasDir
+	}
 }
 
 // mergePartialSpecs applies non-nil fields from src onto dst.
@@ -16,10 +16,14 @@ import (
 const (
 	ProviderGitHub          Provider = "github"
 	ProviderBitbucketServer Provider = "bitbucket-server"
+	ProviderGitLab          Provider = "gitlab"
 )
 
+// ProviderEnvVar names the environment variable overriding the configured provider.
+const ProviderEnvVar = "DARK_FACTORY_PR_PROVIDER"
+
 // AvailableProviders contains all valid provider values.
-var AvailableProviders = Providers{ProviderGitHub, ProviderBitbucketServer}
+var AvailableProviders = Providers{ProviderGitHub, ProviderBitbucketServer, ProviderGitLab}
 
 // Provider is a string-based enum for git provider types.
 type Provider string
@@ -35,7 +39,7 @@ func (p Provider) Validate(ctx context.Context) error {
 		return errors.Wrapf(
 			ctx,
 			validation.Error,
-			"unknown provider %q, valid values: github, bitbucket-server",
+			"unknown provider %q, valid values: github, bitbucket-server, gitlab",
 			p,
 		)
 	}
@@ -32,6 +32,6 @@ var _ = Describe("Provider", func() {
 		Entry("bitbucket-server is valid", config.ProviderBitbucketServer, false),
 		Entry("empty string is invalid", config.Provider(""), true),
 		Entry("invalid value is rejected", config.Provider("invalid"), true),
-		Entry("gitlab is not supported", config.Provider("gitlab"), true),
+		Entry("gitlab is valid", config.ProviderGitLab, false),
 	)
 })
@@ -15,6 +15,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
 // exclusions lists Config fields that are intentionally absent from partialConfig,
@@ -36,6 +39,10 @@ var exclusions = map[string]string{
 	// Validation-coupled fields — round-trip covered by paired-yaml tests below
 	"PR":        "validation-coupled: workflow: direct + pr: true is invalid; covered by paired-yaml test",
 	"AutoMerge": "validation-coupled: requires pr: true; covered by paired-yaml test",
+	// Runtime-only fields — set from CLI flags, never read from yaml
+	"DryRun":            "runtime-only: yaml:\"-\", set by run --dry-run",
+	"ForceLock":         "runtime-only: yaml:\"-\", set by run/daemon --force",
+	"AdditionalPrompts": "runtime-only: yaml:\"-\", set by --prompts-dir / DARK_FACTORY_PROMPTS_DIR",
 }
 
 var _ = Describe("Config/partialConfig parity", func() {
@@ -159,8 +166,18 @@ var _ = Describe("Config/partialConfig parity", func() {
 				func(cfg Config) { Expect(cfg.HealthcheckInterval).To(Equal("3h")) }),
 			Entry("queueInterval", "queueInterval", "10s",
 				func(cfg Config) { Expect(cfg.QueueInterval).To(Equal("10s")) }),
+			Entry("queueMaxInterval", "queueMaxInterval", "2m",
+				func(cfg Config) { Expect(cfg.QueueMaxInterval).To(Equal("2m")) }),
 			Entry("sweepInterval", "sweepInterval", "2m",
 				func(cfg Config) { Expect(cfg.SweepInterval).To(Equal("2m")) }),
+			Entry("completedRetention", "completedRetention", "720h",
+				func(cfg Config) { Expect(cfg.CompletedRetention).To(Equal("720h")) }),
+			Entry("logRetention", "logRetention", "50",
+				func(cfg Config) { Expect(cfg.LogRetention).To(Equal("50")) }),
+			Entry("readyDebounce", "readyDebounce", "250ms",
+				func(cfg Config) { Expect(cfg.ReadyDebounce).To(Equal("250ms")) }),
+			Entry("retryBackoff", "retryBackoff", "30s",
+				func(cfg Config) { Expect(cfg.RetryBackoff).To(Equal("30s")) }),
 			// Int fields
 			Entry("debounceMs", "debounceMs", "42",
 				func(cfg Config) { Expect(cfg.DebounceMs).To(Equal(42)) }),
@@ -172,15 +189,31 @@ var _ = Describe("Config/partialConfig parity", func() {
 				func(cfg Config) { Expect(cfg.DirtyFileThreshold).To(Equal(7)) }),
 			Entry("autoRetryLimit", "autoRetryLimit", "4",
 				func(cfg Config) { Expect(cfg.AutoRetryLimit).To(Equal(4)) }),
+			Entry("minPromptBodyLength", "minPromptBodyLength", "20",
+				func(cfg Config) { Expect(cfg.MinPromptBodyLength).To(Equal(20)) }),
 			// Bool fields (flipped from defaults)
 			Entry("autoRelease", "autoRelease", "true",
 				func(cfg Config) { Expect(cfg.AutoRelease).To(BeTrue()) }),
 			Entry("verificationGate", "verificationGate", "true",
 				func(cfg Config) { Expect(cfg.VerificationGate).To(BeTrue()) }),
+			Entry("resultCache", "resultCache", "true",
+				func(cfg Config) { Expect(cfg.ResultCache).To(BeTrue()) }),
+			Entry("canary", "canary", "true",
+				func(cfg Config) { Expect(cfg.Canary).To(BeTrue()) }),
+			Entry("commitBody", "commitBody", "full",
+				func(cfg Config) { Expect(cfg.CommitBody).To(Equal(CommitBodyFull)) }),
 			Entry("hideGit", "hideGit", "true",
 				func(cfg Config) { Expect(cfg.HideGit).To(BeTrue()) }),
 			Entry("backend", "backend", "local",
 				func(cfg Config) { Expect(cfg.Backend).To(Equal(BackendLocal)) }),
+			Entry("queueOrder", "queueOrder", "mtime",
+				func(cfg Config) { Expect(cfg.QueueOrder).To(Equal(prompt.QueueOrderMtime)) }),
+			Entry("logFormat", "logFormat", "json",
+				func(cfg Config) { Expect(cfg.LogFormat).To(Equal(log.FormatJSON)) }),
+			Entry("logLevel", "logLevel", "debug",
+				func(cfg Config) { Expect(cfg.LogLevel).To(Equal(log.LevelDebug)) }),
+			Entry("mirrorCompletedTo", "mirrorCompletedTo", "reports/completed",
+				func(cfg Config) { Expect(cfg.MirrorCompletedTo).To(Equal("reports/completed")) }),
 			Entry("healthcheckEnabled false", "healthcheckEnabled", "false",
 				func(cfg Config) {
 					Expect(cfg.HealthcheckEnabled).NotTo(BeNil())
@@ -241,5 +274,21 @@ var _ = Describe("Config/partialConfig parity", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(cfg.Provider).To(Equal(ProviderBitbucketServer))
 		})
+
+		It("serverTLS and serverAuth round-trip", func() {
+			yaml := "serverTLS:\n  certFile: /tls/cert.pem\n  keyFile: /tls/key.pem\n" +
+				"serverAuth:\n  tokenEnv: DF_TOKEN\n  username: admin\n  passwordEnv: DF_PASSWORD\n"
+			cfg, err := writeAndLoad(yaml)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.ServerTLS).To(Equal(ServerTLSConfig{
+				CertFile: "/tls/cert.pem",
+				KeyFile:  "/tls/key.pem",
+			}))
+			Expect(cfg.ServerAuth).To(Equal(ServerAuthConfig{
+				TokenEnv:    "DF_TOKEN",
+				Username:    "admin",
+				PasswordEnv: "DF_PASSWORD",
+			}))
+		})
 	})
 })
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+
+	"github.com/bborbe/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// EffectiveValue is one top-level key of the effective configuration and the
+// layer it came from: "default", "global", "project", "env" or "arg" (a CLI flag).
+type EffectiveValue struct {
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+// envVarKeys maps each environment variable read by the loader to the yaml key it overrides.
+var envVarKeys = []struct {
+	envVar string
+	key    string
+}{
+	{PromptsDirEnvVar, "prompts"},
+	{IdeasDirEnvVar, "prompts"},
+	{LogDirEnvVar, "prompts"},
+	{ProviderEnvVar, "provider"},
+	{PRBodyTemplateEnvVar, "prBodyTemplate"},
+	{BranchTemplateEnvVar, "branchTemplate"},
+	{CompletedCommitMessageEnvVar, "completedCommitMessage"},
+	{DeleteBranchEnvVar, "deleteBranch"},
+	{PRDraftEnvVar, "prDraft"},
+	{ConcurrencyEnvVar, "concurrency"},
+	{StrictOrderingEnvVar, "strictOrdering"},
+	{DebounceEnvVar, "debounceMs"},
+	{CompletedLayoutEnvVar, "completedLayout"},
+	{LogRetentionEnvVar, "logRetention"},
+	{WebhookURLEnvVar, "webhookURL"},
+	{GitAuthorNameEnvVar, "gitAuthorName"},
+	{GitAuthorEmailEnvVar, "gitAuthorEmail"},
+	{GitRemoteEnvVar, "gitRemote"},
+	{MemoryLimitEnvVar, "memoryLimit"},
+	{CPULimitEnvVar, "cpuLimit"},
+	{WorkflowEnvVar, "workflow"},
+	{WorkflowEnvVar, "pr"},
+}
+
+// EffectiveValues returns every top-level key of cfg with its source. Keys that
+// are layered (see FieldSources) take the recorded source; other keys are
+// "project" when they differ from Defaults(). A set environment variable from
+// the loader marks its key "env" unless a CLI flag overrode it afterwards.
+func EffectiveValues(
+	ctx context.Context,
+	cfg Config,
+	sources FieldSources,
+) (map[string]EffectiveValue, error) {
+	values, err := toYAMLMap(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "convert config")
+	}
+	defaults, err := toYAMLMap(ctx, Defaults())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "convert defaults")
+	}
+	layered := layeredSources(sources)
+
+	result := make(map[string]EffectiveValue, len(values))
+	for key, value := range values {
+		source := "project"
+		if reflect.DeepEqual(value, defaults[key]) {
+			source = "default"
+		}
+		if s, ok := layered[key]; ok && s != "" {
+			source = s
+		}
+		result[key] = EffectiveValue{Value: value, Source: source}
+	}
+	for _, e := range envVarKeys {
+		if os.Getenv(e.envVar) == "" {
+			continue
+		}
+		v, ok := result[e.key]
+		if !ok || v.Source == "arg" {
+			continue
+		}
+		v.Source = "env"
+		result[e.key] = v
+	}
+	return result, nil
+}
+
+// layeredSources returns the sources of FieldSources keyed by yaml key.
+func layeredSources(s FieldSources) map[string]string {
+	return map[string]string{
+		"hideGit":             s.HideGit,
+		"autoRelease":         s.AutoRelease,
+		"dirtyFileThreshold":  s.DirtyFileThreshold,
+		"model":               s.Model,
+		"maxContainers":       s.MaxContainers,
+		"workflow":            s.Workflow,
+		"pr":                  s.PR,
+		"autoMerge":           s.AutoMerge,
+		"autoApprovePrompts":  s.AutoApprovePrompts,
+		"autoGeneratePrompts": s.AutoGeneratePrompts,
+		"healthcheckEnabled":  s.HealthcheckEnabled,
+		"healthcheckInterval": s.HealthcheckInterval,
+		"backend":             s.Backend,
+	}
+}
+
+// toYAMLMap converts cfg to its top-level yaml keys.
+func toYAMLMap(ctx context.Context, cfg Config) (map[string]any, error) {
+	content, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "marshal")
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, errors.Wrap(ctx, err, "unmarshal")
+	}
+	return values, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/config"
+)
+
+var _ = Describe("EffectiveValues", func() {
+	var (
+		ctx     context.Context
+		cfg     config.Config
+		sources config.FieldSources
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = config.Defaults()
+		sources = config.FieldSources{}
+		for _, name := range []string{
+			config.ConcurrencyEnvVar,
+			config.GitRemoteEnvVar,
+			config.WorkflowEnvVar,
+		} {
+			GinkgoT().Setenv(name, "")
+		}
+	})
+
+	It("reports defaults", func() {
+		values, err := config.EffectiveValues(ctx, cfg, sources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values["debounceMs"]).To(Equal(config.EffectiveValue{
+			Value:  config.DefaultDebounceMs,
+			Source: "default",
+		}))
+		Expect(values["strictOrdering"].Source).To(Equal("default"))
+	})
+
+	It("reports values changed by the project file", func() {
+		cfg.DebounceMs = 1234
+
+		values, err := config.EffectiveValues(ctx, cfg, sources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values["debounceMs"]).To(Equal(config.EffectiveValue{
+			Value:  1234,
+			Source: "project",
+		}))
+	})
+
+	It("reports values set by environment variables", func() {
+		GinkgoT().Setenv(config.ConcurrencyEnvVar, "3")
+		GinkgoT().Setenv(config.GitRemoteEnvVar, "upstream")
+		cfg.Concurrency = 3
+		cfg.GitRemote = "upstream"
+
+		values, err := config.EffectiveValues(ctx, cfg, sources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values["concurrency"]).To(Equal(config.EffectiveValue{Value: 3, Source: "env"}))
+		Expect(values["gitRemote"]).To(Equal(config.EffectiveValue{
+			Value:  "upstream",
+			Source: "env",
+		}))
+		Expect(values["debounceMs"].Source).To(Equal("default"))
+	})
+
+	It("reports layered sources", func() {
+		cfg.Model = "claude-from-global"
+		sources.Model = "global"
+		sources.AutoRelease = "project"
+
+		values, err := config.EffectiveValues(ctx, cfg, sources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values["model"]).To(Equal(config.EffectiveValue{
+			Value:  "claude-from-global",
+			Source: "global",
+		}))
+		Expect(values["autoRelease"].Source).To(Equal("project"))
+	})
+
+	It("prefers a CLI flag over the environment variable", func() {
+		GinkgoT().Setenv(config.WorkflowEnvVar, "branch")
+		cfg.Workflow = config.WorkflowBranch
+		sources.Workflow = "arg"
+
+		values, err := config.EffectiveValues(ctx, cfg, sources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values["workflow"].Source).To(Equal("arg"))
+	})
+})
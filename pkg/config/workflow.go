@@ -56,6 +56,37 @@ func (w Workflow) Validate(ctx context.Context) error {
 	return nil
 }
 
+// ApplyWorkflow selects the workflow named by value for a single run. Besides
+// the AvailableWorkflows it accepts "pr", which selects clone with pr: true as
+// in the yaml. "direct" clears pr, since a direct commit never opens a PR.
+func ApplyWorkflow(ctx context.Context, cfg *Config, value string) error {
+	switch w := Workflow(value); w {
+	case WorkflowPR:
+		cfg.Workflow = WorkflowClone
+		cfg.PR = true
+	case WorkflowDirect:
+		cfg.Workflow = WorkflowDirect
+		cfg.PR = false
+	default:
+		if !AvailableWorkflows.Contains(w) {
+			validValues := make([]string, 0, len(AvailableWorkflows)+1)
+			for _, v := range AvailableWorkflows {
+				validValues = append(validValues, string(v))
+			}
+			validValues = append(validValues, string(WorkflowPR))
+			return errors.Wrapf(
+				ctx,
+				validation.Error,
+				"unknown workflow %q, valid values: %s",
+				w,
+				strings.Join(validValues, ", "),
+			)
+		}
+		cfg.Workflow = w
+	}
+	return nil
+}
+
 // Ptr returns a pointer to the Workflow value.
 func (w Workflow) Ptr() *Workflow {
 	return &w
@@ -52,6 +52,10 @@ func (r *defaultCommandRunner) Run(ctx context.Context, cmd *exec.Cmd) error {
 	}()
 
 	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+			err = &ExitError{Code: exitErr.ExitCode(), Err: err}
+		}
 		return errors.Wrap(ctx, err, "wait command")
 	}
 	return nil
@@ -44,6 +44,9 @@ type Executor interface {
 	// StopAndRemoveContainer stops and forcibly removes the named execution.
 	// Best-effort: any errors are logged but not returned.
 	StopAndRemoveContainer(ctx context.Context, executionID string)
+	// TailLines returns up to n of the most recent formatted log lines of the
+	// current or last execution, oldest first.
+	TailLines(n int) []string
 }
 
 // NewDockerExecutor creates a new Executor using Docker. The launch shape
@@ -65,6 +68,7 @@ func NewDockerExecutor(
 		maxPromptDuration:     maxPromptDuration,
 		currentDateTimeGetter: currentDateTimeGetter,
 		formatter:             fmtr,
+		tail:                  NewTailBuffer(tailBufferLines),
 	}
 }
 
@@ -76,6 +80,12 @@ type dockerExecutor struct {
 	maxPromptDuration     time.Duration // 0 = disabled
 	currentDateTimeGetter libtime.CurrentDateTimeGetter
 	formatter             formatter.Formatter
+	tail                  *TailBuffer
+}
+
+// TailLines returns up to n of the most recent formatted log lines.
+func (e *dockerExecutor) TailLines(n int) []string {
+	return e.tail.TailLines(n)
 }
 
 // Execute runs the claude-yolo Docker container with the given prompt content.
@@ -88,9 +98,12 @@ func (e *dockerExecutor) Execute(
 	logFile string,
 	containerName string,
 ) error {
-	projectRoot, err := os.Getwd()
-	if err != nil {
-		return errors.Wrap(ctx, err, "get working directory")
+	projectRoot := WorkspaceFrom(ctx)
+	if projectRoot == "" {
+		var err error
+		if projectRoot, err = os.Getwd(); err != nil {
+			return errors.Wrap(ctx, err, "get working directory")
+		}
 	}
 	logFileHandle, err := prepareLogFile(ctx, logFile)
 	if err != nil {
@@ -117,12 +130,12 @@ func (e *dockerExecutor) Execute(
 	}
 	cmd := e.buildDockerCommand(ctx, containerName, promptFilePath, promptBaseName)
 	log.From(ctx).Debug("docker command prepared",
-		"image", e.policy.ContainerImage(), "container", containerName,
+		"image", e.containerImage(ctx), "container", containerName,
 		"workspace_mount", projectRoot+":/workspace",
 		"config_mount", claudeConfigDir+":/home/node/.claude")
 	if runErr := e.runWithFormatterPipeline(
 		ctx, cmd, rawFileHandle, logFileHandle,
-		e.buildRunFuncs(cmd, logFile, containerName), "formatter error",
+		e.buildRunFuncs(ctx, cmd, logFile, containerName), "formatter error",
 	); runErr != nil {
 		return errors.Wrap(ctx, runErr, "docker run failed")
 	}
@@ -132,6 +145,7 @@ func (e *dockerExecutor) Execute(
 // runWithFormatterPipeline wires cmd.Stdout through the formatter pipeline, runs the provided
 // run.Funcs via run.CancelOnFirstFinish, and waits for the formatter goroutine to finish.
 // cmd.Stderr is connected to both os.Stderr and logWriter.
+// logWriter is teed into the tail buffer, which is reset first.
 // pw is closed via wrapFirstFuncWithPipeClose when the first run.Func returns, signalling EOF.
 func (e *dockerExecutor) runWithFormatterPipeline(
 	ctx context.Context,
@@ -141,6 +155,8 @@ func (e *dockerExecutor) runWithFormatterPipeline(
 	runFuncs []run.Func,
 	fmtErrMsg string,
 ) error {
+	e.tail.Reset()
+	logWriter = io.MultiWriter(logWriter, e.tail)
 	pr, pw := io.Pipe()
 	cmd.Stdout = pw
 	cmd.Stderr = io.MultiWriter(os.Stderr, logWriter)
@@ -155,13 +171,19 @@ func (e *dockerExecutor) runWithFormatterPipeline(
 	return runErr
 }
 
-// buildRunFuncs returns the set of parallel functions for Execute using the configured maxPromptDuration.
+// buildRunFuncs returns the set of parallel functions for Execute using the configured
+// maxPromptDuration, or the per-prompt override from LaunchOverridesFrom(ctx).
 func (e *dockerExecutor) buildRunFuncs(
+	ctx context.Context,
 	cmd *exec.Cmd,
 	logFile string,
 	containerName string,
 ) []run.Func {
-	return e.buildRunFuncsWithTimeout(cmd, logFile, containerName, e.maxPromptDuration)
+	timeout := e.maxPromptDuration
+	if d := LaunchOverridesFrom(ctx).MaxPromptDuration; d > 0 {
+		timeout = d
+	}
+	return e.buildRunFuncsWithTimeout(cmd, logFile, containerName, timeout)
 }
 
 // buildRunFuncsWithTimeout returns the set of parallel functions with an explicit timeout.
@@ -454,7 +476,10 @@ func createPromptTempFile(ctx context.Context, promptContent string) (string, fu
 // The launch shape is sourced from the executor's launchpolicy.Policy; only
 // the prompt-specific concerns (prompt-file mount, ANTHROPIC_MODEL,
 // YOLO_PROMPT_FILE, YOLO_OUTPUT, the dark-factory.prompt label) flow through
-// the Extras overlay.
+// the Extras overlay. A per-prompt command from LaunchOverridesFrom(ctx) is
+// appended after the image name, a per-prompt image replaces the configured
+// one, per-prompt env is added to the overlay and per-prompt volumes are
+// mounted after the default mounts.
 func (e *dockerExecutor) buildDockerCommand(
 	ctx context.Context,
 	containerName string,
@@ -463,31 +488,77 @@ func (e *dockerExecutor) buildDockerCommand(
 ) *exec.Cmd {
 	extras := launchpolicy.Extras{
 		ContainerName: containerName,
-		EnvOverlay: claudeargv.EnvOverlay(claudeargv.Options{
-			Model:      e.model,
-			Output:     claudeargv.OutputJSON,
-			PromptFile: "/tmp/prompt.md",
-		}),
+		EnvOverlay: promptEnvOverlay(
+			LaunchOverridesFrom(ctx).Env,
+			claudeargv.EnvOverlay(claudeargv.Options{
+				Model:      e.model,
+				Output:     claudeargv.OutputJSON,
+				PromptFile: "/tmp/prompt.md",
+			}),
+		),
 		ExtraLabels: map[string]string{
 			"dark-factory.prompt": promptBaseName,
 		},
+		Command: LaunchOverridesFrom(ctx).Command,
 	}
 	opts := e.policy.BuildOpts(extras)
+	opts.ContainerImage = e.containerImage(ctx)
+	if workspace := WorkspaceFrom(ctx); workspace != "" {
+		opts.ProjectRoot = workspace
+	}
 	args := BuildDockerRunArgs(opts)
-	args = insertPromptFileMount(args, promptFilePath, e.policy.ContainerImage())
+	args = insertBeforeImage(
+		args,
+		opts.ContainerImage,
+		promptVolumeArgs(LaunchOverridesFrom(ctx).Volumes, opts.ProjectRoot)...,
+	)
+	args = insertPromptFileMount(args, promptFilePath, opts.ContainerImage)
 	// #nosec G204 -- args are derived from configured policy + sanitized container name, not user input
 	return exec.CommandContext(ctx, "docker", args...)
 }
 
+// promptEnvOverlay merges the per-prompt env under the executor's own overlay,
+// so a prompt can never replace the keys the executor sets.
+func promptEnvOverlay(promptEnv map[string]string, overlay map[string]string) map[string]string {
+	if len(promptEnv) == 0 {
+		return overlay
+	}
+	merged := make(map[string]string, len(promptEnv)+len(overlay))
+	for k, v := range promptEnv {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
+
+// containerImage returns the per-prompt image override from ctx, falling back
+// to the image configured on the launch policy.
+func (e *dockerExecutor) containerImage(ctx context.Context) string {
+	if image := LaunchOverridesFrom(ctx).Image; image != "" {
+		return image
+	}
+	return e.policy.ContainerImage()
+}
+
 // insertPromptFileMount adds `-v <promptFilePath>:/tmp/prompt.md:ro` just before the
 // containerImage positional. Kept as a small adapter so BuildDockerRunArgs stays free
 // of prompt-specific concepts.
 func insertPromptFileMount(args []string, promptFilePath, containerImage string) []string {
+	return insertBeforeImage(args, containerImage, "-v", promptFilePath+":/tmp/prompt.md:ro")
+}
+
+// insertBeforeImage adds extra just before the containerImage positional.
+func insertBeforeImage(args []string, containerImage string, extra ...string) []string {
+	if len(extra) == 0 {
+		return args
+	}
 	for i, a := range args {
 		if a == containerImage {
-			out := make([]string, 0, len(args)+2)
+			out := make([]string, 0, len(args)+len(extra))
 			out = append(out, args[:i]...)
-			out = append(out, "-v", promptFilePath+":/tmp/prompt.md:ro")
+			out = append(out, extra...)
 			out = append(out, args[i:]...)
 			return out
 		}
@@ -495,6 +566,20 @@ func insertPromptFileMount(args []string, promptFilePath, containerImage string)
 	return args
 }
 
+// promptVolumeArgs returns a `-v` pair for every per-prompt volume, resolving a
+// relative host path against projectRoot.
+func promptVolumeArgs(volumes []string, projectRoot string) []string {
+	args := make([]string, 0, 2*len(volumes))
+	for _, volume := range volumes {
+		host, rest, _ := strings.Cut(volume, ":")
+		if !filepath.IsAbs(host) {
+			host = filepath.Join(projectRoot, host)
+		}
+		args = append(args, "-v", host+":"+rest)
+	}
+	return args
+}
+
 // (buildHideGitArgs moved to launch.go as buildHideGitArgsForRoot — used by both the
 // prompt executor path and the healthcheck probes via BuildDockerRunArgs.)
 
@@ -1017,6 +1017,234 @@ var _ = Describe("Internal helper functions", func() {
 		})
 	})
 
+	Describe("buildDockerCommand command override", func() {
+		build := func(ctx context.Context) *exec.Cmd {
+			return executor.BuildDockerCommandForTest(
+				ctx,
+				"my-image:latest",
+				"test-project",
+				"",
+				"",
+				"",
+				nil,
+				nil,
+				"test-container",
+				"/tmp/prompt.md",
+				"/workspace",
+				"/home/user/.claude",
+				"test-prompt",
+				"/home/user",
+				false,
+			)
+		}
+
+		It("appends the override as the final args after the image", func() {
+			overrideCtx := executor.WithLaunchOverrides(ctx, executor.LaunchOverrides{
+				Command: []string{"make", "test", "--verbose"},
+			})
+			args := build(overrideCtx).Args
+
+			n := len(args)
+			Expect(args[n-4:]).To(Equal([]string{"my-image:latest", "make", "test", "--verbose"}))
+			Expect(args).To(ContainElement("/tmp/prompt.md:/tmp/prompt.md:ro"))
+		})
+
+		It("ends with the image when no override is set", func() {
+			args := build(ctx).Args
+			Expect(args[len(args)-1]).To(Equal("my-image:latest"))
+		})
+	})
+
+	Describe("buildDockerCommand image override", func() {
+		build := func(ctx context.Context) *exec.Cmd {
+			return executor.BuildDockerCommandForTest(
+				ctx,
+				"my-image:latest",
+				"test-project",
+				"",
+				"",
+				"",
+				nil,
+				nil,
+				"test-container",
+				"/tmp/prompt.md",
+				"/workspace",
+				"/home/user/.claude",
+				"test-prompt",
+				"/home/user",
+				false,
+			)
+		}
+
+		It("uses the per-prompt image instead of the configured one", func() {
+			overrideCtx := executor.WithLaunchOverrides(ctx, executor.LaunchOverrides{
+				Image: "ghcr.io/org/agent-python:1.2",
+			})
+			args := build(overrideCtx).Args
+
+			Expect(args[len(args)-1]).To(Equal("ghcr.io/org/agent-python:1.2"))
+			Expect(args).NotTo(ContainElement("my-image:latest"))
+		})
+
+		It("mounts the prompt file before the per-prompt image", func() {
+			overrideCtx := executor.WithLaunchOverrides(ctx, executor.LaunchOverrides{
+				Image:   "node:22",
+				Command: []string{"make", "test"},
+			})
+			args := build(overrideCtx).Args
+
+			n := len(args)
+			Expect(args[n-4:]).To(Equal([]string{
+				"/tmp/prompt.md:/tmp/prompt.md:ro", "node:22", "make", "test",
+			}))
+		})
+	})
+
+	Describe("buildDockerCommand env override", func() {
+		build := func(ctx context.Context) *exec.Cmd {
+			return executor.BuildDockerCommandForTest(
+				ctx,
+				"my-image:latest",
+				"test-project",
+				"claude-sonnet-4-6",
+				"",
+				"",
+				map[string]string{"SHARED": "config", "CONFIG_ONLY": "1"},
+				nil,
+				"test-container",
+				"/tmp/prompt.md",
+				"/workspace",
+				"/home/user/.claude",
+				"test-prompt",
+				"/home/user",
+				false,
+			)
+		}
+
+		It("adds the per-prompt env as -e flags, winning over the configured env", func() {
+			overrideCtx := executor.WithLaunchOverrides(ctx, executor.LaunchOverrides{
+				Env: map[string]string{"FEATURE_X": "on", "SHARED": "prompt"},
+			})
+			args := build(overrideCtx).Args
+
+			Expect(args).To(ContainElement("FEATURE_X=on"))
+			Expect(args).To(ContainElement("SHARED=prompt"))
+			Expect(args).NotTo(ContainElement("SHARED=config"))
+			Expect(args).To(ContainElement("CONFIG_ONLY=1"))
+		})
+
+		It("never lets the per-prompt env replace executor keys", func() {
+			overrideCtx := executor.WithLaunchOverrides(ctx, executor.LaunchOverrides{
+				Env: map[string]string{
+					"YOLO_PROMPT_FILE": "/etc/passwd",
+					"ANTHROPIC_MODEL":  "other",
+				},
+			})
+			args := build(overrideCtx).Args
+
+			Expect(args).To(ContainElement("YOLO_PROMPT_FILE=/tmp/prompt.md"))
+			Expect(args).To(ContainElement("ANTHROPIC_MODEL=claude-sonnet-4-6"))
+			Expect(args).NotTo(ContainElement("YOLO_PROMPT_FILE=/etc/passwd"))
+		})
+	})
+
+	Describe("buildDockerCommand volumes override", func() {
+		build := func(ctx context.Context) *exec.Cmd {
+			return executor.BuildDockerCommandForTest(
+				ctx,
+				"my-image:latest",
+				"test-project",
+				"",
+				"",
+				"",
+				nil,
+				nil,
+				"test-container",
+				"/tmp/prompt.md",
+				"/workspace",
+				"/home/user/.claude",
+				"test-prompt",
+				"/home/user",
+				false,
+			)
+		}
+
+		It("mounts the per-prompt volumes after the default mounts", func() {
+			overrideCtx := executor.WithLaunchOverrides(ctx, executor.LaunchOverrides{
+				Volumes: []string{"testdata:/data", "fixtures/api:/fixtures:ro"},
+			})
+			args := build(overrideCtx).Args
+
+			n := len(args)
+			Expect(args[n-7:]).To(Equal([]string{
+				"-v", "/workspace/testdata:/data",
+				"-v", "/workspace/fixtures/api:/fixtures:ro",
+				"-v", "/tmp/prompt.md:/tmp/prompt.md:ro",
+				"my-image:latest",
+			}))
+			Expect(args).To(ContainElement("/workspace:/workspace"))
+		})
+	})
+
+	Describe("buildDockerCommand workspace", func() {
+		build := func(ctx context.Context) []string {
+			return executor.BuildDockerCommandForTest(
+				ctx,
+				"my-image:latest",
+				"test-project",
+				"",
+				"",
+				"",
+				nil,
+				nil,
+				"test-container",
+				"/tmp/prompt.md",
+				"/project",
+				"/home/user/.claude",
+				"test-prompt",
+				"/home/user",
+				false,
+			).Args
+		}
+
+		It("mounts the project root without a workspace", func() {
+			Expect(build(ctx)).To(ContainElement("/project:/workspace"))
+		})
+
+		It("mounts the workspace bound to the context instead of the project root", func() {
+			args := build(executor.WithWorkspace(ctx, "/tmp/trees/prompt-1"))
+
+			Expect(args).To(ContainElement("/tmp/trees/prompt-1:/workspace"))
+			Expect(args).NotTo(ContainElement("/project:/workspace"))
+		})
+	})
+
+	Describe("buildDockerCommand resource limits", func() {
+		build := func(opts launchpolicy.SecurityOpts) []string {
+			policy := launchpolicy.NewPolicy(
+				"my-image:latest", "test-project", "/workspace",
+				"/home/user/.claude", "/home/user", nil, nil, "", "", false,
+			).WithSecurity(opts)
+			return executor.BuildDockerCommandFromPolicyForTest(
+				ctx, policy, "", "test-container", "/tmp/prompt.md", "test-prompt",
+			).Args
+		}
+
+		It("passes the configured memory and CPU limits", func() {
+			args := build(launchpolicy.SecurityOpts{MemoryLimit: "4g", CPULimit: "1.5"})
+
+			Expect(strings.Join(args, " ")).To(ContainSubstring("--memory 4g"))
+			Expect(strings.Join(args, " ")).To(ContainSubstring("--cpus 1.5"))
+		})
+
+		It("omits the flags when no limit is configured", func() {
+			args := build(launchpolicy.SecurityOpts{})
+
+			Expect(args).NotTo(ContainElement("--memory"))
+			Expect(args).NotTo(ContainElement("--cpus"))
+		})
+	})
+
 	Describe("buildDockerCommand hideGit", func() {
 		buildCmd := func(projectRoot string, hideGit bool) *exec.Cmd {
 			return executor.BuildDockerCommandForTest(
@@ -1562,6 +1790,7 @@ This has frontmatter.`
 				logContent, err := os.ReadFile(logFile)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(logContent)).To(ContainSubstring("not-json-at-all"))
+				Expect(e.TailLines(10)).To(ContainElement(ContainSubstring("not-json-at-all")))
 			})
 		})
 
@@ -1767,6 +1996,27 @@ This has frontmatter.`
 		})
 	})
 
+	Describe("ExitCode", func() {
+		It("returns 0 for a nil error", func() {
+			code, ok := executor.ExitCode(nil)
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(0))
+		})
+
+		It("returns the code of a wrapped ExitError", func() {
+			exitErr := &executor.ExitError{Code: 2, Err: errors.New(ctx, "exit status 2")}
+			err := errors.Wrap(ctx, exitErr, "docker run failed")
+			code, ok := executor.ExitCode(err)
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(2))
+		})
+
+		It("reports an unknown code for other errors", func() {
+			_, ok := executor.ExitCode(errors.New(ctx, "docker not found"))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
 	Describe("defaultCommandRunner", func() {
 		It("returns nil when command exits normally", func() {
 			runner := executor.NewDefaultCommandRunnerForTest()
@@ -1782,6 +2032,16 @@ This has frontmatter.`
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("surfaces the exit code of a failed command", func() {
+			runner := executor.NewDefaultCommandRunnerForTest()
+			cmd := exec.Command("sh", "-c", "exit 3")
+			err := runner.Run(ctx, cmd)
+			Expect(err).To(HaveOccurred())
+			code, ok := executor.ExitCode(err)
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(3))
+		})
+
 		It("terminates long-running process when context is cancelled", func() {
 			runner := executor.NewDefaultCommandRunnerForTest()
 			cancelCtx, cancel := context.WithCancel(ctx)
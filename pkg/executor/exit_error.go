@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/bborbe/errors"
+)
+
+// ExitError reports that the container or subprocess of an execution exited
+// with a non-zero code. Execute returns it wrapped, so callers use ExitCode.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error returns the message of the underlying error.
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit code %d: %v", e.Code, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code of an execution error: 0 for nil, the code
+// carried by an ExitError in the chain, and false when the code is unknown
+// (e.g. docker could not be started or the execution timed out).
+func ExitCode(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code, true
+	}
+	return 0, false
+}
@@ -67,6 +67,7 @@ func NewDockerExecutorWithRunnerForTest(
 		maxPromptDuration:     maxPromptDuration,
 		currentDateTimeGetter: currentDateTimeGetter,
 		formatter:             fmtr,
+		tail:                  NewTailBuffer(tailBufferLines),
 	}
 }
 
@@ -44,6 +44,7 @@ type localSubprocessExecutor struct {
 	currentDateTimeGetter libtime.CurrentDateTimeGetter
 	formatter             formatter.Formatter
 	commandRunner         commandRunner
+	tail                  *TailBuffer
 
 	mu         sync.Mutex
 	runningCmd *exec.Cmd
@@ -62,9 +63,15 @@ func NewLocalSubprocessExecutor(
 		currentDateTimeGetter: currentDateTimeGetter,
 		formatter:             fmtr,
 		commandRunner:         &defaultCommandRunner{},
+		tail:                  NewTailBuffer(tailBufferLines),
 	}
 }
 
+// TailLines returns up to n of the most recent formatted log lines.
+func (e *localSubprocessExecutor) TailLines(n int) []string {
+	return e.tail.TailLines(n)
+}
+
 // Execute runs the claude binary as a local subprocess in the current working directory.
 // It blocks until the subprocess exits and returns an error if the exit code is non-zero.
 // Two log files are written: a raw JSONL file (stdout verbatim) and
@@ -113,7 +120,7 @@ func (e *localSubprocessExecutor) Execute(
 
 	if runErr := e.runWithFormatterPipeline(
 		ctx, cmd, rawFileHandle, logFileHandle,
-		e.buildRunFuncs(cmd),
+		e.buildRunFuncs(ctx, cmd),
 		"local claude run failed",
 	); runErr != nil {
 		return errors.Wrap(ctx, runErr, "local claude run failed")
@@ -137,7 +144,7 @@ func (e *localSubprocessExecutor) buildCommand(
 	}
 	// #nosec G204 -- claudePath is resolved from PATH via exec.LookPath; args are static flags from config, not user input
 	cmd := exec.CommandContext(ctx, claudePath, args...)
-	cmd.Dir = "" // inherit cwd (already the checked-out repo)
+	cmd.Dir = WorkspaceFrom(ctx) // empty inherits cwd (already the checked-out repo)
 
 	// Set process group so we can kill the whole group on stop.
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
@@ -159,7 +166,8 @@ func (e *localSubprocessExecutor) buildCommand(
 }
 
 // buildRunFuncs returns the run.Funcs for Execute, including an optional timeout killer.
-func (e *localSubprocessExecutor) buildRunFuncs(cmd *exec.Cmd) []run.Func {
+// A per-prompt MaxPromptDuration from LaunchOverridesFrom(ctx) replaces the configured one.
+func (e *localSubprocessExecutor) buildRunFuncs(ctx context.Context, cmd *exec.Cmd) []run.Func {
 	funcs := []run.Func{func(ctx context.Context) error {
 		e.mu.Lock()
 		e.runningCmd = cmd
@@ -171,8 +179,11 @@ func (e *localSubprocessExecutor) buildRunFuncs(cmd *exec.Cmd) []run.Func {
 		}()
 		return e.commandRunner.Run(ctx, cmd)
 	}}
-	if e.maxPromptDuration > 0 {
-		d := e.maxPromptDuration
+	d := e.maxPromptDuration
+	if override := LaunchOverridesFrom(ctx).MaxPromptDuration; override > 0 {
+		d = override
+	}
+	if d > 0 {
 		funcs = append(funcs, func(ctx context.Context) error {
 			deadline := time.Time(e.currentDateTimeGetter.Now()).Add(d)
 			if !waitUntilDeadline(ctx, e.currentDateTimeGetter, deadline, 100*time.Millisecond) {
@@ -190,6 +201,7 @@ func (e *localSubprocessExecutor) buildRunFuncs(cmd *exec.Cmd) []run.Func {
 // runWithFormatterPipeline wires cmd.Stdout through the formatter pipeline, runs the provided
 // run.Funcs via run.CancelOnFirstFinish, and waits for the formatter goroutine to finish.
 // cmd.Stderr is connected to both os.Stderr and logWriter.
+// logWriter is teed into the tail buffer, which is reset first.
 // pw is closed via wrapFirstFuncWithPipeClose when the first run.Func returns, signalling EOF.
 func (e *localSubprocessExecutor) runWithFormatterPipeline(
 	ctx context.Context,
@@ -199,6 +211,8 @@ func (e *localSubprocessExecutor) runWithFormatterPipeline(
 	runFuncs []run.Func,
 	fmtErrMsg string,
 ) error {
+	e.tail.Reset()
+	logWriter = io.MultiWriter(logWriter, e.tail)
 	pr, pw := io.Pipe()
 	cmd.Stdout = pw
 	cmd.Stderr = io.MultiWriter(os.Stderr, logWriter)
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"context"
+	"time"
+)
+
+type launchOverridesKey struct{}
+
+// LaunchOverrides are per-prompt adjustments to the container launch, taken
+// from the prompt's frontmatter. The zero value leaves the launch unchanged.
+type LaunchOverrides struct {
+	// Command replaces the image's default command. It is passed as positional
+	// args after the image name, never through a shell.
+	Command []string
+	// Image replaces the configured container image. Empty keeps the default.
+	Image string
+	// Env is added to the container env. It wins over the configured env but
+	// never over the executor's prompt-specific keys.
+	Env map[string]string
+	// Volumes are extra "host:container[:ro]" mounts added after the default
+	// mounts. A relative host path is resolved against the project root.
+	Volumes []string
+	// MaxPromptDuration replaces the configured maxPromptDuration for Execute.
+	// 0 keeps the default.
+	MaxPromptDuration time.Duration
+}
+
+// WithLaunchOverrides returns a context carrying overrides for the next Execute.
+func WithLaunchOverrides(ctx context.Context, overrides LaunchOverrides) context.Context {
+	return context.WithValue(ctx, launchOverridesKey{}, overrides)
+}
+
+// LaunchOverridesFrom returns the overrides bound to ctx, or the zero value.
+func LaunchOverridesFrom(ctx context.Context) LaunchOverrides {
+	overrides, _ := ctx.Value(launchOverridesKey{}).(LaunchOverrides)
+	return overrides
+}
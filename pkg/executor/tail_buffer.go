@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"bytes"
+	"sync"
+)
+
+// tailBufferLines is the number of log lines an executor keeps in memory.
+const tailBufferLines = 200
+
+// TailBuffer is an io.Writer keeping the last lines written to it in a ring
+// buffer. Executors tee the formatted log into one, so the tail of the running
+// prompt is available without reading the log file. It is safe for concurrent use.
+type TailBuffer struct {
+	mu      sync.Mutex
+	lines   []string
+	next    int
+	full    bool
+	partial []byte
+}
+
+// NewTailBuffer returns a TailBuffer keeping the last capacity lines.
+// A capacity below 1 keeps a single line.
+func NewTailBuffer(capacity int) *TailBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &TailBuffer{
+		lines: make([]string, capacity),
+	}
+}
+
+// Write splits p into lines and stores the complete ones. A trailing line
+// without newline is kept until the rest of it is written.
+func (b *TailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rest := p
+	for {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(b.partial) + string(bytes.TrimSuffix(rest[:i], []byte("\r")))
+		b.partial = b.partial[:0]
+		b.add(line)
+		rest = rest[i+1:]
+	}
+	b.partial = append(b.partial, rest...)
+	return len(p), nil
+}
+
+// add stores line, overwriting the oldest line when the buffer is full.
+func (b *TailBuffer) add(line string) {
+	b.lines[b.next] = line
+	b.next = (b.next + 1) % len(b.lines)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// TailLines returns up to n of the most recent lines, oldest first. A pending
+// line without newline counts as the most recent one.
+func (b *TailBuffer) TailLines(n int) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var all []string
+	if b.full {
+		all = append(all, b.lines[b.next:]...)
+	}
+	all = append(all, b.lines[:b.next]...)
+	if len(b.partial) > 0 {
+		all = append(all, string(b.partial))
+	}
+	if n <= 0 {
+		return nil
+	}
+	if n < len(all) {
+		all = all[len(all)-n:]
+	}
+	return all
+}
+
+// Reset drops all stored lines.
+func (b *TailBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clear(b.lines)
+	b.next = 0
+	b.full = false
+	b.partial = b.partial[:0]
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor_test
+
+import (
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/executor"
+)
+
+var _ = Describe("TailBuffer", func() {
+	var buffer *executor.TailBuffer
+
+	BeforeEach(func() {
+		buffer = executor.NewTailBuffer(3)
+	})
+
+	It("returns nothing before anything is written", func() {
+		Expect(buffer.TailLines(5)).To(BeEmpty())
+	})
+
+	It("returns the lines written, oldest first", func() {
+		_, err := io.WriteString(buffer, "one\ntwo\n")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buffer.TailLines(5)).To(Equal([]string{"one", "two"}))
+	})
+
+	It("keeps only the most recent lines once full", func() {
+		for i := 1; i <= 7; i++ {
+			_, err := fmt.Fprintf(buffer, "line %d\n", i)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(buffer.TailLines(5)).To(Equal([]string{"line 5", "line 6", "line 7"}))
+		Expect(buffer.TailLines(2)).To(Equal([]string{"line 6", "line 7"}))
+	})
+
+	It("joins lines split across writes", func() {
+		_, err := io.WriteString(buffer, "hel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.TailLines(1)).To(Equal([]string{"hel"}))
+
+		_, err = io.WriteString(buffer, "lo\r\nworld\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.TailLines(5)).To(Equal([]string{"hello", "world"}))
+	})
+
+	It("returns nothing for n below 1", func() {
+		_, err := io.WriteString(buffer, "one\n")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buffer.TailLines(0)).To(BeEmpty())
+	})
+
+	It("drops all lines on Reset", func() {
+		_, err := io.WriteString(buffer, "one\ntwo\nthree\nfour\npartial")
+		Expect(err).NotTo(HaveOccurred())
+
+		buffer.Reset()
+
+		Expect(buffer.TailLines(5)).To(BeEmpty())
+		_, err = io.WriteString(buffer, "five\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.TailLines(5)).To(Equal([]string{"five"}))
+	})
+})
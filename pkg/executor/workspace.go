@@ -0,0 +1,22 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import "context"
+
+type workspaceKey struct{}
+
+// WithWorkspace returns a context whose Execute runs the prompt in dir instead of
+// the project root: the docker executor mounts dir at /workspace and the local
+// executor starts claude in it.
+func WithWorkspace(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, dir)
+}
+
+// WorkspaceFrom returns the workspace bound to ctx, or "" for the project root.
+func WorkspaceFrom(ctx context.Context) string {
+	dir, _ := ctx.Value(workspaceKey{}).(string)
+	return dir
+}
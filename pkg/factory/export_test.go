@@ -35,15 +35,18 @@ var BuildIdleLoggerForTest = func(
 	return buildIdleLogger(idleLogInterval, queueInterval, emit)
 }
 
-// ProviderDepsBackendForTest reports the backend ("github" or "bitbucket")
+// BuildAdditionalProcessorConfigForTest exposes buildAdditionalProcessorConfig.
+var BuildAdditionalProcessorConfigForTest = buildAdditionalProcessorConfig
+
+// ProviderDepsBackendForTest reports the backend ("github", "bitbucket" or "gitlab")
 // the createProviderDeps dispatcher chose for the given config. Used by
 // black-box tests in factory_test.go to assert the dispatch wiring without
 // having to expose the unexported providerDeps struct.
 //
 // Implementation detail: inspects the package path of the returned
 // prCreator's underlying type (pkg/git/* → "github",
-// pkg/gitprovider/bitbucket/* → "bitbucket"). Returns "unknown" if neither
-// matches.
+// pkg/gitprovider/bitbucket/* → "bitbucket", pkg/gitprovider/gitlab/* →
+// "gitlab"). Returns "unknown" if none matches.
 var ProviderDepsBackendForTest = func(
 	ctx context.Context,
 	cfg config.Config,
@@ -54,6 +57,8 @@ var ProviderDepsBackendForTest = func(
 	switch {
 	case strings.Contains(pkgPath, "/pkg/gitprovider/bitbucket"):
 		return "bitbucket"
+	case strings.Contains(pkgPath, "/pkg/gitprovider/gitlab"):
+		return "gitlab"
 	case strings.Contains(pkgPath, "/pkg/git"):
 		return "github"
 	default:
@@ -6,6 +6,8 @@ package factory
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"log/slog"
@@ -19,9 +21,13 @@ import (
 	"github.com/bborbe/errors"
 	libhttp "github.com/bborbe/http"
 	liblog "github.com/bborbe/log"
+	"github.com/bborbe/run"
 	libtime "github.com/bborbe/time"
+	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/bborbe/dark-factory/pkg/canary"
 	"github.com/bborbe/dark-factory/pkg/cancellationwatcher"
+	"github.com/bborbe/dark-factory/pkg/changelog"
 	"github.com/bborbe/dark-factory/pkg/claudeargv"
 	"github.com/bborbe/dark-factory/pkg/cmd"
 	"github.com/bborbe/dark-factory/pkg/cmd/healthcheck"
@@ -37,11 +43,15 @@ import (
 	"github.com/bborbe/dark-factory/pkg/generator"
 	"github.com/bborbe/dark-factory/pkg/git"
 	"github.com/bborbe/dark-factory/pkg/gitprovider/bitbucket"
+	"github.com/bborbe/dark-factory/pkg/gitprovider/gitlab"
 	"github.com/bborbe/dark-factory/pkg/globalconfig"
 	"github.com/bborbe/dark-factory/pkg/healthcheckgate"
 	"github.com/bborbe/dark-factory/pkg/launchpolicy"
 	"github.com/bborbe/dark-factory/pkg/lock"
+	"github.com/bborbe/dark-factory/pkg/logretention"
+	"github.com/bborbe/dark-factory/pkg/metrics"
 	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/pause"
 	"github.com/bborbe/dark-factory/pkg/preflight"
 	"github.com/bborbe/dark-factory/pkg/preflightconditions"
 	"github.com/bborbe/dark-factory/pkg/processor"
@@ -49,7 +59,10 @@ import (
 	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/promptenricher"
 	"github.com/bborbe/dark-factory/pkg/promptresumer"
+	"github.com/bborbe/dark-factory/pkg/promptsource"
 	"github.com/bborbe/dark-factory/pkg/queuescanner"
+	"github.com/bborbe/dark-factory/pkg/resultcache"
+	"github.com/bborbe/dark-factory/pkg/resultfile"
 	"github.com/bborbe/dark-factory/pkg/runner"
 	"github.com/bborbe/dark-factory/pkg/scenario"
 	"github.com/bborbe/dark-factory/pkg/server"
@@ -148,9 +161,18 @@ func LogEffectiveConfig(
 		"autoMerge", cfg.AutoMerge,
 		"autoMergeSource", sources.AutoMerge,
 		"verificationGate", cfg.VerificationGate,
+		"resultCache", cfg.ResultCache,
+		"canary", cfg.Canary,
+		"commitBody", cfg.CommitBody,
+		"retryBackoff", cfg.RetryBackoff,
 		"validationCommand", cfg.ValidationCommand,
 		"testCommand", cfg.TestCommand,
 		"debounceMs", cfg.DebounceMs,
+		"readyDebounce", cfg.ReadyDebounce,
+		"queueOrder", cfg.QueueOrder,
+		"mirrorCompletedTo", cfg.MirrorCompletedTo,
+		"completedRetention", cfg.CompletedRetention,
+		"logRetention", cfg.LogRetention,
 		"hideGit", cfg.HideGit,
 		"hideGitSource", sources.HideGit,
 		"autoApprovePrompts", cfg.AutoApprovePrompts,
@@ -208,8 +230,30 @@ func createPromptManager(
 	completedDir string,
 	cancelledDir string,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+	opts ...prompt.ManagerOption,
+) (*prompt.Manager, git.Releaser) {
+	return createPromptManagerWithReleaser(
+		git.NewReleaser(),
+		inboxDir,
+		inProgressDir,
+		completedDir,
+		cancelledDir,
+		currentDateTimeGetter,
+		opts...,
+	)
+}
+
+// createPromptManagerWithReleaser is createPromptManager for callers that commit and
+// therefore need the releaser built from config (see CreateReleaser).
+func createPromptManagerWithReleaser(
+	releaser git.Releaser,
+	inboxDir string,
+	inProgressDir string,
+	completedDir string,
+	cancelledDir string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+	opts ...prompt.ManagerOption,
 ) (*prompt.Manager, git.Releaser) {
-	releaser := git.NewReleaser()
 	promptManager := prompt.NewManager(
 		inboxDir,
 		inProgressDir,
@@ -217,6 +261,7 @@ func createPromptManager(
 		cancelledDir,
 		releaser,
 		currentDateTimeGetter,
+		opts...,
 	)
 	return promptManager, releaser
 }
@@ -248,6 +293,8 @@ func createProviderDeps(
 	switch cfg.Provider {
 	case config.ProviderBitbucketServer:
 		return createBitbucketProviderDeps(ctx, cfg, currentDateTimeGetter)
+	case config.ProviderGitLab:
+		return createGitLabProviderDeps(cfg)
 	default:
 		return CreateGitHubProviderDeps(cfg, currentDateTimeGetter)
 	}
@@ -260,9 +307,31 @@ func CreateGitHubProviderDeps(
 ) providerDeps {
 	ghToken := cfg.ResolvedGitHubToken()
 	return providerDeps{
-		prCreator: git.NewPRCreator(ghToken),
-		prMerger:  git.NewPRMerger(ghToken, currentDateTimeGetter),
-		brancher:  git.NewBrancher(git.WithDefaultBranch(cfg.DefaultBranch)),
+		prCreator: git.NewPRCreator(
+			ghToken,
+			git.WithDraft(cfg.PRDraft),
+			git.WithReviewers(cfg.PRReviewers),
+			git.WithLabels(cfg.PRLabels),
+		),
+		prMerger: git.NewPRMerger(ghToken, currentDateTimeGetter),
+		brancher: git.NewBrancher(
+			git.WithDefaultBranch(cfg.DefaultBranch),
+			git.WithBrancherRemote(cfg.GitRemote),
+		),
+	}
+}
+
+// createGitLabProviderDeps returns glab-CLI-backed implementations.
+// Merge requests are not merged automatically; config validation rejects
+// autoMerge with provider: gitlab.
+func createGitLabProviderDeps(cfg config.Config) providerDeps {
+	return providerDeps{
+		prCreator: gitlab.NewPRCreator(cfg.DefaultBranch),
+		prMerger:  gitlab.NewPRMerger(),
+		brancher: git.NewBrancher(
+			git.WithDefaultBranch(cfg.DefaultBranch),
+			git.WithBrancherRemote(cfg.GitRemote),
+		),
 	}
 }
 
@@ -313,7 +382,10 @@ func createBitbucketProviderDeps(
 			coords.Repo,
 			currentDateTimeGetter,
 		),
-		brancher: git.NewBrancher(git.WithDefaultBranch(cfg.DefaultBranch)),
+		brancher: git.NewBrancher(
+			git.WithDefaultBranch(cfg.DefaultBranch),
+			git.WithBrancherRemote(cfg.GitRemote),
+		),
 	}
 }
 
@@ -366,12 +438,17 @@ func CreateRunner(
 	inboxDir := cfg.Prompts.InboxDir
 	inProgressDir := cfg.Prompts.InProgressDir
 	completedDir := cfg.Prompts.CompletedDir
-	promptManager, releaser := createPromptManager(
+	promptManager, releaser := createPromptManagerWithReleaser(
+		CreateReleaser(cfg),
 		inboxDir,
 		inProgressDir,
 		completedDir,
 		cfg.Prompts.CancelledDir,
 		currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+		prompt.WithMirrorCompletedTo(cfg.MirrorCompletedTo),
+		prompt.WithCompletedRetention(cfg.ParsedCompletedRetention()),
 	)
 	versionGetter := version.NewGetter(ver)
 	projectName, projectNameErr := project.Resolve(
@@ -383,6 +460,7 @@ func CreateRunner(
 		return &errRunner{err: errors.Wrap(ctx, projectNameErr, "resolve project name")}
 	}
 	wakeup := make(chan struct{}, 10)
+	shutdownTrigger := run.NewTrigger()
 	migrator := createSpecSlugMigrator(cfg, currentDateTimeGetter)
 	specGen := CreateSpecGenerator(
 		ctx,
@@ -398,6 +476,12 @@ func CreateRunner(
 		CreateTelegramNotifier(cfg.ResolvedTelegramBotToken(), cfg.ResolvedTelegramChatID()),
 		CreateDiscordNotifier(cfg.ResolvedDiscordWebhook()),
 	)
+	// queueManagers backs the queue depth gauge; additional prompts directories append below.
+	queueManagers := []*prompt.Manager{promptManager}
+	metricsRegistry := prometheus.NewRegistry()
+	promptMetrics := metrics.NewMetrics(metricsRegistry, func() int {
+		return countQueued(ctx, queueManagers)
+	})
 	var srv server.Server
 	if cfg.ServerPort > 0 {
 		srv = CreateServer(
@@ -407,10 +491,15 @@ func CreateRunner(
 			inProgressDir,
 			completedDir,
 			cfg.Prompts.LogDir,
+			cfg.Prompts.IdeasDir,
 			promptManager,
 			currentDateTimeGetter,
 			cfg.MaxContainers,
 			projectName,
+			cfg.ServerTLS,
+			createServerCredentials(ctx, cfg),
+			metricsRegistry,
+			shutdownTrigger,
 		)
 	}
 
@@ -464,11 +553,13 @@ func CreateRunner(
 		releaser,
 		versionGetter,
 		wakeup,
+		shutdownTrigger.Done(),
 		deps.brancher,
 		deps.prCreator,
 		deps.prMerger,
 		currentDateTimeGetter,
 		n,
+		promptMetrics,
 		createContainerCounter(cfg.Backend),
 		cl,
 		executionChecker,
@@ -477,13 +568,73 @@ func CreateRunner(
 		preflightChecker,
 		buildIdleLogger(
 			cfg.ParsedIdleLogInterval(),
-			cfg.ParsedQueueInterval(),
+			cfg.ParsedQueueMaxInterval(),
 			func() { slog.Info("nothing to do, waiting for changes") },
 		),
 	)
 	watcher := CreateWatcher(inProgressDir, inboxDir, promptManager, wakeup,
 		time.Duration(cfg.DebounceMs)*time.Millisecond, currentDateTimeGetter)
 	specWatcher := CreateSpecWatcher(cfg, specGen, currentDateTimeGetter)
+
+	// Additional prompts directories each get their own manager, watcher and processor.
+	promptDirs := make([]runner.PromptDir, 0, len(cfg.AdditionalPrompts))
+	for _, dirCfg := range cfg.AdditionalPrompts {
+		dirConfig := cfg
+		dirConfig.Prompts = dirCfg
+		dirManager, dirReleaser := createPromptManagerWithReleaser(
+			CreateReleaser(cfg),
+			dirCfg.InboxDir,
+			dirCfg.InProgressDir,
+			dirCfg.CompletedDir,
+			dirCfg.CancelledDir,
+			currentDateTimeGetter,
+			prompt.WithQueueOrder(cfg.QueueOrder),
+			prompt.WithCompletedLayout(cfg.CompletedLayout),
+			prompt.WithMirrorCompletedTo(cfg.MirrorCompletedTo),
+			prompt.WithCompletedRetention(cfg.ParsedCompletedRetention()),
+		)
+		queueManagers = append(queueManagers, dirManager)
+		dirWakeup := make(chan struct{}, 10)
+		promptDirs = append(promptDirs, runner.PromptDir{
+			InboxDir:      dirCfg.InboxDir,
+			InProgressDir: dirCfg.InProgressDir,
+			CompletedDir:  dirCfg.CompletedDir,
+			LogDir:        dirCfg.LogDir,
+			PromptManager: dirManager,
+			Watcher: CreateWatcher(dirCfg.InProgressDir, dirCfg.InboxDir, dirManager, dirWakeup,
+				time.Duration(cfg.DebounceMs)*time.Millisecond, currentDateTimeGetter),
+			Processor: CreateProcessor(
+				ctx,
+				buildAdditionalProcessorConfig(cfg, dirConfig, globalCfg),
+				projectName,
+				dirManager,
+				dirReleaser,
+				versionGetter,
+				dirWakeup,
+				shutdownTrigger.Done(),
+				deps.brancher,
+				deps.prCreator,
+				deps.prMerger,
+				currentDateTimeGetter,
+				n,
+				promptMetrics,
+				createContainerCounter(cfg.Backend),
+				cl,
+				executionChecker,
+				dirtyFileChecker,
+				gitLockChecker,
+				preflightChecker,
+				buildIdleLogger(
+					cfg.ParsedIdleLogInterval(),
+					cfg.ParsedQueueMaxInterval(),
+					func() {
+						slog.Info("nothing to do, waiting for changes", "dir", dirCfg.InboxDir)
+					},
+				),
+			),
+		})
+	}
+
 	var logWriter io.Writer
 	if logFile, err := os.Create(".dark-factory.log"); err != nil {
 		slog.Warn("failed to create daemon log file, continuing without", "error", err)
@@ -493,7 +644,7 @@ func CreateRunner(
 	return runner.NewRunner(
 		inboxDir, inProgressDir, completedDir, cfg.Prompts.LogDir,
 		cfg.Specs.InboxDir, cfg.Specs.InProgressDir, cfg.Specs.CompletedDir, cfg.Specs.LogDir,
-		promptManager, CreateLocker("."), watcher, proc, srv,
+		promptManager, CreateLocker(".", cfg.ForceLock), watcher, proc, srv,
 		specWatcher, projectName,
 		executionChecker, n, migrator,
 		currentDateTimeGetter,
@@ -503,8 +654,10 @@ func CreateRunner(
 		cfg.HideGit,
 		preflightChecker,
 		logWriter,
+		cfg.LogFormat,
 		healthcheckGate,
 		cfg.Backend == config.BackendLocal,
+		promptDirs,
 	)
 }
 
@@ -530,8 +683,14 @@ func CreateOneShotRunner(
 	inboxDir := cfg.Prompts.InboxDir
 	inProgressDir := cfg.Prompts.InProgressDir
 	completedDir := cfg.Prompts.CompletedDir
-	promptManager, releaser := createPromptManager(
-		inboxDir, inProgressDir, completedDir, cfg.Prompts.CancelledDir, currentDateTimeGetter)
+	promptManager, releaser := createPromptManagerWithReleaser(
+		CreateReleaser(cfg),
+		inboxDir, inProgressDir, completedDir, cfg.Prompts.CancelledDir, currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+		prompt.WithMirrorCompletedTo(cfg.MirrorCompletedTo),
+		prompt.WithCompletedRetention(cfg.ParsedCompletedRetention()),
+	)
 	versionGetter, n := version.NewGetter(ver), CreateNotifier(
 		CreateTelegramNotifier(cfg.ResolvedTelegramBotToken(), cfg.ResolvedTelegramChatID()),
 		CreateDiscordNotifier(cfg.ResolvedDiscordWebhook()),
@@ -590,7 +749,7 @@ func CreateOneShotRunner(
 		cfg.Specs.CompletedDir,
 		cfg.Specs.LogDir,
 		promptManager,
-		CreateLocker("."),
+		CreateLocker(".", cfg.ForceLock),
 		CreateProcessor(
 			ctx,
 			buildProcessorConfig(cfg, globalCfg, inProgressDir, completedDir),
@@ -599,11 +758,13 @@ func CreateOneShotRunner(
 			releaser,
 			versionGetter,
 			make(chan struct{}, 10),
+			nil,
 			deps.brancher,
 			deps.prCreator,
 			deps.prMerger,
 			currentDateTimeGetter,
 			n,
+			nil, // one-shot mode serves no metrics
 			createContainerCounter(cfg.Backend),
 			cl,
 			executionChecker,
@@ -628,6 +789,7 @@ func CreateOneShotRunner(
 		autoApprove,
 		migrator,
 		cfg.HideGit,
+		cfg.DryRun,
 		createStartupLogger(ctx, cfg, globalCfg, sources, projectEnv),
 	)
 }
@@ -668,7 +830,7 @@ func CreateSpecGenerator(
 		cfg.NetrcFile,
 		cfg.GitconfigFile,
 		cfg.EffectiveHideGit(),
-	)
+	).WithSecurity(resourceLimits(cfg.MemoryLimit, cfg.CPULimit))
 	return generator.NewSpecGenerator(
 		createExecutor(
 			cfg.Backend,
@@ -689,6 +851,7 @@ func CreateSpecGenerator(
 		cfg.AdditionalInstructions,
 		cfg.ParsedMaxPromptDuration(),
 		promptManager,
+		promptManager,
 		cfg.AutoApprovePrompts,
 		cfg.Prompts.InProgressDir,
 		specGenName,
@@ -729,6 +892,15 @@ func CreateWatcher(
 	)
 }
 
+// resourceLimits returns the container memory and CPU limits as launch security opts.
+// Unset limits stay empty, so docker runs the container without them.
+func resourceLimits(memoryLimit, cpuLimit string) launchpolicy.SecurityOpts {
+	return launchpolicy.SecurityOpts{
+		MemoryLimit: memoryLimit,
+		CPULimit:    cpuLimit,
+	}
+}
+
 // createExecutor returns the executor for the configured backend. Docker is the
 // default; backend: local returns the in-process subprocess executor, which needs
 // no launch policy (no image / mounts / hide-git).
@@ -784,7 +956,7 @@ func createContainerCounter(backend config.Backend) executor.ContainerCounter {
 // projectMax is the project-level MaxContainers value (may be 0); effective max is resolved against global config.
 func createStatusChecker(
 	ctx context.Context,
-	inProgressDir, completedDir, logDir string,
+	inboxDir, inProgressDir, completedDir, logDir, ideasDir string,
 	serverPort int,
 	promptManager *prompt.Manager,
 	projectMax int,
@@ -793,6 +965,33 @@ func createStatusChecker(
 	projectName project.Name,
 ) status.Checker {
 	projectDir, _ := os.Getwd()
+	return createStatusCheckerForDir(
+		ctx,
+		projectDir,
+		inboxDir,
+		inProgressDir, completedDir, logDir, ideasDir,
+		serverPort,
+		promptManager,
+		projectMax,
+		dirtyFileThreshold,
+		currentDateTimeGetter,
+		projectName,
+	)
+}
+
+// createStatusCheckerForDir creates a status checker for the project rooted at
+// projectDir; the daemon lock file is looked up there.
+func createStatusCheckerForDir(
+	ctx context.Context,
+	projectDir string,
+	inboxDir, inProgressDir, completedDir, logDir, ideasDir string,
+	serverPort int,
+	promptManager *prompt.Manager,
+	projectMax int,
+	dirtyFileThreshold int,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+	projectName project.Name,
+) status.Checker {
 	globalCfg, err := globalconfig.NewLoader().Load(ctx)
 	if err != nil {
 		slog.Warn("globalconfig load failed for status checker, using default", "error", err)
@@ -804,7 +1003,8 @@ func createStatusChecker(
 		inProgressDir,
 		completedDir,
 		logDir,
-		lock.FilePath("."),
+		ideasDir,
+		lock.FilePath(projectDir),
 		serverPort,
 		promptManager,
 		// backend: docker regardless of cfg — createStatusChecker backs the
@@ -816,6 +1016,7 @@ func createStatusChecker(
 		dirtyFileThreshold,
 		currentDateTimeGetter,
 		subproc.NewRunner(),
+		pause.NewSentinel(inboxDir, currentDateTimeGetter),
 	)
 }
 
@@ -857,12 +1058,17 @@ func CreateWorkflowExecutor(
 	prMerger git.PRMerger,
 	autoMerge bool,
 	autoRelease bool,
+	deleteBranch bool,
 	projectName project.Name,
 	promptManager *prompt.Manager,
 	releaser git.Releaser,
 	autoCompleter spec.AutoCompleter,
 	promptDirPrefixes []string,
 	fileMover prompt.FileMover,
+	commitBody config.CommitBody,
+	prBodyTemplate string,
+	branchTemplate string,
+	gitRemote string,
 ) processor.WorkflowExecutorProvider {
 	deps := processor.WorkflowDeps{
 		ProjectName:        projectName,
@@ -872,13 +1078,17 @@ func CreateWorkflowExecutor(
 		FileMover:          fileMover,
 		Brancher:           brancher,
 		PRCreator:          prCreator,
-		Cloner:             git.NewCloner(),
+		Cloner:             git.NewCloner(git.WithClonerRemote(gitRemote)),
 		Worktreer:          git.NewWorktreer(),
 		PRMerger:           prMerger,
 		PR:                 pr,
 		AutoMerge:          autoMerge,
 		AutoRelease:        autoRelease,
+		DeleteBranch:       deleteBranch,
 		IgnorePathPrefixes: promptDirPrefixes,
+		CommitBody:         commitBody,
+		PRBodyTemplate:     prBodyTemplate,
+		BranchTemplate:     branchTemplate,
 	}
 	return processor.NewWorkflowExecutorProviderMap(map[config.Workflow]processor.WorkflowExecutor{
 		config.WorkflowClone:    processor.NewCloneWorkflowExecutor(deps),
@@ -888,6 +1098,24 @@ func CreateWorkflowExecutor(
 	})
 }
 
+// CreatePromptWorkflowExecutor returns the WorkflowExecutor for workflow that also
+// honors per-prompt `workflow:` frontmatter. `workflow: pr` uses the clone executor
+// of prProvider, whose executors open a pull request.
+func CreatePromptWorkflowExecutor(
+	ctx context.Context,
+	workflow config.Workflow,
+	provider processor.WorkflowExecutorProvider,
+	prProvider processor.WorkflowExecutorProvider,
+) processor.WorkflowExecutor {
+	executors := map[config.Workflow]processor.WorkflowExecutor{
+		config.WorkflowPR: prProvider.Get(ctx, config.WorkflowClone),
+	}
+	for _, w := range config.AvailableWorkflows {
+		executors[w] = provider.Get(ctx, w)
+	}
+	return processor.NewPromptWorkflowExecutor(provider.Get(ctx, workflow), executors)
+}
+
 // buildProcessorConfig assembles a ProcessorConfig from the dark-factory cfg,
 // the global config, and the resolved in-progress / completed dirs. Both
 // CreateRunner and CreateOneShotRunner call this helper so the two paths
@@ -900,9 +1128,13 @@ func buildProcessorConfig(
 	globalCfg globalconfig.GlobalConfig,
 	inProgressDir, completedDir string,
 ) ProcessorConfig {
+	logRetentionCount, logRetentionMaxAge := cfg.ParsedLogRetention()
 	return ProcessorConfig{
+		InboxDir:           cfg.Prompts.InboxDir,
+		PauseDir:           cfg.Prompts.InboxDir,
 		InProgressDir:      inProgressDir,
 		CompletedDir:       completedDir,
+		CompletedLayout:    cfg.CompletedLayout,
 		LogDir:             cfg.Prompts.LogDir,
 		SpecsInboxDir:      cfg.Specs.InboxDir,
 		SpecsInProgressDir: cfg.Specs.InProgressDir,
@@ -920,6 +1152,8 @@ func buildProcessorConfig(
 		ClaudeDir:              cfg.ResolvedClaudeDir(),
 		Env:                    cfg.Env,
 		ExtraMounts:            cfg.ExtraMounts,
+		MemoryLimit:            cfg.MemoryLimit,
+		CPULimit:               cfg.CPULimit,
 		HideGit:                cfg.HideGit,
 		NetrcFile:              cfg.NetrcFile,
 		GitconfigFile:          cfg.GitconfigFile,
@@ -927,20 +1161,57 @@ func buildProcessorConfig(
 		PR:                     cfg.PR,
 		AutoMerge:              cfg.AutoMerge,
 		AutoRelease:            cfg.AutoRelease,
+		DeleteBranch:           cfg.DeleteBranch,
 		VerificationGate:       cfg.VerificationGate,
+		ResultCache:            cfg.ResultCache,
+		Canary:                 cfg.Canary,
+		CommitBody:             cfg.CommitBody,
+		PRBodyTemplate:         cfg.PRBodyTemplate,
+		BranchTemplate:         cfg.BranchTemplate,
+		GitRemote:              cfg.GitRemote,
+		Concurrency:            cfg.Concurrency,
+		StrictOrdering:         cfg.StrictOrdering,
 		ValidationCommand:      cfg.ValidationCommand,
 		ValidationPrompt:       cfg.ValidationPrompt,
 		TestCommand:            cfg.TestCommand,
 		AdditionalInstructions: cfg.AdditionalInstructions,
 		MaxContainers:          EffectiveMaxContainers(cfg.MaxContainers, globalCfg.MaxContainers),
 		MaxPromptDuration:      cfg.ParsedMaxPromptDuration(),
+		MinPromptBodyLength:    cfg.MinPromptBodyLength,
+		LogRetentionCount:      logRetentionCount,
+		LogRetentionMaxAge:     logRetentionMaxAge,
 		DirtyFileThreshold:     cfg.DirtyFileThreshold,
 		AutoRetryLimit:         cfg.AutoRetryLimit,
+		WebhookURL:             cfg.WebhookURL,
+		SlackWebhook:           cfg.ResolvedSlackWebhook(),
+		RetryBackoff:           cfg.ParsedRetryBackoff(),
 		QueueInterval:          cfg.ParsedQueueInterval(),
+		QueueMaxInterval:       cfg.ParsedQueueMaxInterval(),
 		SweepInterval:          cfg.ParsedSweepInterval(),
+		ReadyDebounce:          cfg.ParsedReadyDebounce(),
+		DryRun:                 cfg.DryRun,
 	}
 }
 
+// buildAdditionalProcessorConfig is buildProcessorConfig for the processor of an
+// additional prompts directory (dirCfg). It pauses with the primary directory's
+// sentinel, so `dark-factory pause` stops every directory, and names its containers
+// after its inbox, so equally named prompts of two directories do not collide.
+func buildAdditionalProcessorConfig(
+	cfg, dirCfg config.Config,
+	globalCfg globalconfig.GlobalConfig,
+) ProcessorConfig {
+	processorCfg := buildProcessorConfig(
+		dirCfg,
+		globalCfg,
+		dirCfg.Prompts.InProgressDir,
+		dirCfg.Prompts.CompletedDir,
+	)
+	processorCfg.PauseDir = cfg.Prompts.InboxDir
+	processorCfg.ContainerScope = dirCfg.Prompts.InboxDir
+	return processorCfg
+}
+
 // ProcessorConfig groups the config-derived inputs to CreateProcessor.
 // Previously these 25 fields were positional params of CreateProcessor,
 // duplicated across CreateRunner and CreateOneShotRunner — silent drift
@@ -950,6 +1221,7 @@ func buildProcessorConfig(
 // separate args so wiring stays visible at the call site.
 type ProcessorConfig struct {
 	// Directories
+	InboxDir           string
 	InProgressDir      string
 	CompletedDir       string
 	LogDir             string
@@ -957,7 +1229,13 @@ type ProcessorConfig struct {
 	SpecsInProgressDir string
 	SpecsCompletedDir  string
 	SpecsRejectedDir   string
+	CompletedLayout    prompt.CompletedLayout
 	PromptDirPrefixes  []string
+	// PauseDir holds the .paused sentinel; the primary inbox for every processor.
+	PauseDir string
+	// ContainerScope is added to container names of an additional prompts
+	// directory; empty for the primary.
+	ContainerScope string
 
 	// Container
 	Backend        config.Backend
@@ -966,6 +1244,8 @@ type ProcessorConfig struct {
 	ClaudeDir      string
 	Env            map[string]string
 	ExtraMounts    []config.ExtraMount
+	MemoryLimit    string
+	CPULimit       string
 	HideGit        bool
 
 	// Git / VCS
@@ -977,7 +1257,16 @@ type ProcessorConfig struct {
 	PR               bool
 	AutoMerge        bool
 	AutoRelease      bool
+	DeleteBranch     bool
 	VerificationGate bool
+	ResultCache      bool
+	Canary           bool
+	CommitBody       config.CommitBody
+	PRBodyTemplate   string
+	BranchTemplate   string
+	GitRemote        string
+	Concurrency      int
+	StrictOrdering   bool
 
 	// Validation
 	ValidationCommand      string
@@ -986,14 +1275,29 @@ type ProcessorConfig struct {
 	AdditionalInstructions string
 
 	// Resource limits
-	MaxContainers      int
-	MaxPromptDuration  time.Duration
-	DirtyFileThreshold int
-	AutoRetryLimit     int
+	MaxContainers       int
+	MaxPromptDuration   time.Duration
+	MinPromptBodyLength int
+	DirtyFileThreshold  int
+	AutoRetryLimit      int
+
+	// Log retention
+	LogRetentionCount  int
+	LogRetentionMaxAge time.Duration
+
+	// Notifications
+	WebhookURL   string
+	SlackWebhook string
 
 	// Timing
-	QueueInterval time.Duration
-	SweepInterval time.Duration
+	RetryBackoff     time.Duration
+	QueueInterval    time.Duration
+	QueueMaxInterval time.Duration
+	SweepInterval    time.Duration
+	ReadyDebounce    time.Duration
+
+	// DryRun logs the queued prompts instead of executing them.
+	DryRun bool
 }
 
 // EffectiveHideGit mirrors config.Config.EffectiveHideGit for the subset
@@ -1039,11 +1343,13 @@ func CreateProcessor(
 	releaser git.Releaser,
 	versionGetter version.Getter,
 	wakeup <-chan struct{},
+	shutdown <-chan struct{},
 	brancher git.Brancher,
 	prCreator git.PRCreator,
 	prMerger git.PRMerger,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 	n notifier.Notifier,
+	promptMetrics metrics.Metrics,
 	containerCounter executor.ContainerCounter,
 	containerLock containerlock.ContainerLock,
 	executionChecker executor.ExecutionChecker,
@@ -1053,22 +1359,33 @@ func CreateProcessor(
 	onIdle processor.NothingToDoCallback,
 ) processor.Processor {
 	dirs := processor.Dirs{
-		Queue:     cfg.InProgressDir,
-		Completed: cfg.CompletedDir,
-		Log:       cfg.LogDir,
+		Inbox:           cfg.InboxDir,
+		Queue:           cfg.InProgressDir,
+		Completed:       cfg.CompletedDir,
+		CompletedLayout: cfg.CompletedLayout,
+		Log:             cfg.LogDir,
+		Scope:           cfg.ContainerScope,
 	}
 	autoCompleter := createAutoCompleter(
 		cfg.InProgressDir, cfg.CompletedDir,
 		cfg.SpecsInboxDir, cfg.SpecsInProgressDir, cfg.SpecsCompletedDir,
 		currentDateTimeGetter, projectName, n, promptManager,
 	)
-	workflowExecutorProvider := CreateWorkflowExecutor(
-		cfg.PR, brancher, prCreator, prMerger,
-		cfg.AutoMerge, cfg.AutoRelease,
-		projectName, promptManager, releaser, autoCompleter,
-		cfg.PromptDirPrefixes, releaser,
+	createWorkflowExecutor := func(pr bool) processor.WorkflowExecutorProvider {
+		return CreateWorkflowExecutor(
+			pr, brancher, prCreator, prMerger,
+			cfg.AutoMerge, cfg.AutoRelease, cfg.DeleteBranch,
+			projectName, promptManager, releaser, autoCompleter,
+			cfg.PromptDirPrefixes, releaser,
+			cfg.CommitBody, cfg.PRBodyTemplate, cfg.BranchTemplate, cfg.GitRemote,
+		)
+	}
+	workflowExecutor := CreatePromptWorkflowExecutor(
+		ctx,
+		cfg.Workflow,
+		createWorkflowExecutor(cfg.PR),
+		createWorkflowExecutor(true),
 	)
-	workflowExecutor := workflowExecutorProvider.Get(ctx, cfg.Workflow)
 	projectRoot, _ := os.Getwd()
 	home, _ := os.UserHomeDir()
 	processorPolicy := launchpolicy.NewPolicy(
@@ -1082,7 +1399,7 @@ func CreateProcessor(
 		cfg.NetrcFile,
 		cfg.GitconfigFile,
 		cfg.EffectiveHideGit(),
-	)
+	).WithSecurity(resourceLimits(cfg.MemoryLimit, cfg.CPULimit))
 	exec := createExecutor(
 		cfg.Backend,
 		processorPolicy,
@@ -1091,12 +1408,14 @@ func CreateProcessor(
 		currentDateTimeGetter,
 		formatter.NewFormatter(currentDateTimeGetter),
 	)
+	promptNotifier := CreatePromptNotifier(cfg.WebhookURL, cfg.SlackWebhook)
 	fh := failurehandler.NewHandler(
 		promptManager,
-		n,
+		notifier.NewMultiNotifier(n, CreateWebhookNotifier(cfg.WebhookURL)),
 		dirs.Completed,
 		projectName,
 		int(cfg.AutoRetryLimit),
+		cfg.RetryBackoff,
 	)
 	resumer := promptresumer.NewResumer(
 		promptManager,
@@ -1106,13 +1425,22 @@ func CreateProcessor(
 		fh,
 		dirs.Queue,
 		dirs.Completed,
+		dirs.CompletedLayout,
 		dirs.Log,
 		projectName,
 		cfg.MaxPromptDuration,
+		// Always set: a prompt of a daemon that ran with a higher concurrency may
+		// still have its own tree.
+		git.NewWorktreer(),
 	)
 	// Two-phase wiring: scanner → proc.ProcessPrompt → scanner.
 	// The lazyPromptProcessor closes the loop inside factory where wiring belongs.
 	ppForwarder := &lazyPromptProcessor{}
+	pauseSentinel := pause.NewSentinel(cfg.PauseDir, currentDateTimeGetter)
+	var canaryGate canary.Gate
+	if cfg.Canary {
+		canaryGate = canary.NewGate(pauseSentinel, n, projectName)
+	}
 	scanner := queuescanner.NewScanner(
 		promptManager,
 		ppForwarder,
@@ -1120,6 +1448,11 @@ func CreateProcessor(
 		dirs.Queue,
 		lock.NewDirLock,
 		0,
+		pauseSentinel,
+		canaryGate,
+		shutdown,
+		cfg.Concurrency,
+		cfg.StrictOrdering,
 	)
 	proc := processor.NewProcessor(
 		exec,
@@ -1153,6 +1486,7 @@ func CreateProcessor(
 		),
 		cancellationwatcher.NewWatcher(exec, promptManager),
 		wakeup,
+		shutdown,
 		dirs,
 		projectName,
 		fh,
@@ -1177,8 +1511,26 @@ func CreateProcessor(
 			cfg.AutoRelease,
 		),
 		scanner,
+		createResultCache(cfg, projectName, currentDateTimeGetter),
+		promptsource.NewFetcher(nil, promptsource.DefaultTimeout, promptsource.DefaultMaxBytes),
+		resultfile.NewReader(),
+		promptNotifier,
+		promptMetrics,
+		cfg.MaxPromptDuration,
+		cfg.MinPromptBodyLength,
+		logretention.NewPruner(
+			cfg.LogDir,
+			cfg.InProgressDir,
+			cfg.LogRetentionCount,
+			cfg.LogRetentionMaxAge,
+			currentDateTimeGetter,
+		),
+		createPromptTrees(cfg.Concurrency),
+		cfg.DryRun,
 		cfg.QueueInterval,
+		cfg.QueueMaxInterval,
 		cfg.SweepInterval,
+		cfg.ReadyDebounce,
 		onIdle,
 	)
 	ppForwarder.inner = proc
@@ -1202,6 +1554,50 @@ func CreateDiscordNotifier(webhook string) notifier.Notifier {
 	return notifier.NewDiscordNotifier(webhook)
 }
 
+// CreateWebhookNotifier creates a notifier POSTing prompt outcomes to url.
+func CreateWebhookNotifier(url string) notifier.Notifier {
+	if url == "" {
+		return notifier.NewMultiNotifier()
+	}
+	return notifier.NewWebhookNotifier(url)
+}
+
+// CreateReleaser creates a git.Releaser configured from cfg: commit author (gitAuthorName,
+// gitAuthorEmail), push target and retries (gitRemote, pushRetries), changelogSections and
+// the completed-file commit message (completedCommitMessage).
+func CreateReleaser(cfg config.Config) git.Releaser {
+	return git.NewReleaser(
+		git.WithAuthor(git.Author{
+			Name:  cfg.GitAuthorName,
+			Email: cfg.GitAuthorEmail,
+		}),
+		git.WithReleaserRemote(cfg.GitRemote),
+		git.WithPushRetries(cfg.PushRetries),
+		git.WithChangelogSections(cfg.ChangelogSections),
+		git.WithCompletedCommitMessage(cfg.CompletedCommitMessage),
+	)
+}
+
+// CreateSlackNotifier creates a notifier posting completed prompts to a Slack webhook.
+func CreateSlackNotifier(webhook string) notifier.Notifier {
+	if webhook == "" {
+		return notifier.NewMultiNotifier()
+	}
+	return notifier.NewSlackNotifier(webhook)
+}
+
+// CreatePromptNotifier creates the notifier receiving prompt_completed from the processor,
+// or nil when neither the generic webhook nor Slack is configured.
+func CreatePromptNotifier(webhookURL, slackWebhook string) notifier.Notifier {
+	if webhookURL == "" && slackWebhook == "" {
+		return nil
+	}
+	return notifier.NewMultiNotifier(
+		CreateWebhookNotifier(webhookURL),
+		CreateSlackNotifier(slackWebhook),
+	)
+}
+
 // CreateNotifier creates a Notifier based on the provided Telegram and Discord notifiers.
 func CreateNotifier(telegram, discord notifier.Notifier) notifier.Notifier {
 	return notifier.NewMultiNotifier(telegram, discord)
@@ -1212,9 +1608,123 @@ func CreateKillCommand(_ config.Config) cmd.KillCommand {
 	return cmd.NewKillCommand(lock.FilePath("."), nil, nil)
 }
 
+// CreateStopCommand creates a StopCommand that asks the daemon's REST API to shut down.
+// The daemon listens on 127.0.0.1:serverPort, over TLS when serverTLS is configured.
+func CreateStopCommand(ctx context.Context, cfg config.Config) cmd.StopCommand {
+	client := &http.Client{Timeout: 10 * time.Second}
+	if cfg.ServerPort <= 0 {
+		return cmd.NewStopCommand("", nil, client)
+	}
+	scheme := "http"
+	if cfg.ServerTLS.CertFile != "" {
+		scheme = "https"
+		client.Transport = createServerTLSTransport(ctx, cfg.ServerTLS.CertFile)
+	}
+	return cmd.NewStopCommand(
+		fmt.Sprintf("%s://127.0.0.1:%d", scheme, cfg.ServerPort),
+		createServerCredentials(ctx, cfg),
+		client,
+	)
+}
+
+// createServerTLSTransport returns a transport trusting the daemon's certificate.
+// On a read error it logs a warning and falls back to the system roots.
+func createServerTLSTransport(ctx context.Context, certFile string) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// #nosec G304 -- certFile comes from the project config
+	pem, err := os.ReadFile(certFile)
+	if err != nil {
+		slog.WarnContext(ctx, "read serverTLS certFile failed", "file", certFile, "error", err)
+		return transport
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(pem)
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return transport
+}
+
+// createPromptTrees returns the Worktreer giving every prompt its own tree when
+// more than one prompt runs at a time, and nil otherwise.
+func createPromptTrees(concurrency int) git.Worktreer {
+	if concurrency <= 1 {
+		return nil
+	}
+	return git.NewWorktreer()
+}
+
+// createResultCache returns the per-project executor result cache under
+// ~/.dark-factory/result-cache, or nil when resultCache is disabled.
+func createResultCache(
+	cfg ProcessorConfig,
+	projectName project.Name,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) resultcache.Cache {
+	if !cfg.ResultCache {
+		return nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		slog.Warn(
+			"result cache: os.UserHomeDir failed; cache root will be CWD-relative",
+			"error",
+			err,
+		)
+	}
+	return resultcache.NewFileCache(
+		filepath.Join(home, ".dark-factory", "result-cache", projectName.String()),
+		cfg.ContainerImage,
+		currentDateTimeGetter,
+	)
+}
+
+// CreateReleasePlanCommand creates a ReleaseCommand printing the next version, bump and changelog entry.
+func CreateReleasePlanCommand() cmd.ReleaseCommand {
+	return cmd.NewReleasePlanCommand(git.NewReleaser(), ".", os.Stdout)
+}
+
+// CreateReleasePreviewCommand creates a ReleaseCommand printing the next changelog entry.
+func CreateReleasePreviewCommand() cmd.ReleaseCommand {
+	return cmd.NewReleasePreviewCommand(git.NewReleaser(), ".", os.Stdout)
+}
+
+// CreateReleaseNextCommand creates a ReleaseCommand printing the next version.
+func CreateReleaseNextCommand() cmd.ReleaseCommand {
+	return cmd.NewReleaseNextCommand(git.NewReleaser(), ".", os.Stdout)
+}
+
+// CreateChangelogRebuildCommand creates a command rebuilding CHANGELOG.md from completed prompts.
+func CreateChangelogRebuildCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.ChangelogRebuildCommand {
+	return cmd.NewChangelogRebuildCommand(
+		changelog.NewBuilder(cfg.Prompts.CompletedDir, currentDateTimeGetter),
+		"CHANGELOG.md",
+		cmd.RebuiltChangelogFile,
+		os.Stdout,
+	)
+}
+
+// CreatePauseCommand creates a PauseCommand that writes the queue pause sentinel.
+func CreatePauseCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.PauseCommand {
+	return cmd.NewPauseCommand(pause.NewSentinel(cfg.Prompts.InboxDir, currentDateTimeGetter))
+}
+
+// CreateResumeCommand creates a ResumeCommand that removes the queue pause sentinel.
+func CreateResumeCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.ResumeCommand {
+	return cmd.NewResumeCommand(pause.NewSentinel(cfg.Prompts.InboxDir, currentDateTimeGetter))
+}
+
 // CreateLocker creates a Locker for the specified directory.
-func CreateLocker(dir string) lock.Locker {
-	return lock.NewLocker(dir)
+// With force, a lock held by a live instance is taken over.
+func CreateLocker(dir string, force bool) lock.Locker {
+	return lock.NewLocker(dir, lock.WithForce(force))
 }
 
 // CreateServer creates a Server that provides HTTP endpoints for monitoring.
@@ -1225,17 +1735,25 @@ func CreateServer(
 	inProgressDir string,
 	completedDir string,
 	logDir string,
+	ideasDir string,
 	promptManager *prompt.Manager,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 	projectMaxContainers int,
 	projectName project.Name,
+	serverTLS config.ServerTLSConfig,
+	credentials *server.Credentials,
+	// metricsGatherer is served on /metrics in Prometheus text format; nil disables the route.
+	metricsGatherer prometheus.Gatherer,
+	shutdownTrigger run.Fire,
 ) server.Server {
 	addr := fmt.Sprintf("127.0.0.1:%d", port)
 	statusChecker := createStatusChecker(
 		ctx,
+		inboxDir,
 		inProgressDir,
 		completedDir,
 		logDir,
+		ideasDir,
 		port,
 		promptManager,
 		projectMaxContainers,
@@ -1244,9 +1762,8 @@ func CreateServer(
 		projectName,
 	)
 
-	// Build the mux with all routes
+	// Build the mux with all API routes; /health is mounted outside auth below.
 	mux := http.NewServeMux()
-	mux.Handle("/health", libhttp.NewErrorHandler(server.NewHealthHandler()))
 	mux.Handle("/api/v1/status", libhttp.NewErrorHandler(server.NewStatusHandler(statusChecker)))
 	mux.Handle("/api/v1/queue", libhttp.NewErrorHandler(server.NewQueueHandler(statusChecker)))
 	// Both routes share a single handler instance. The handler inspects the URL path
@@ -1261,11 +1778,69 @@ func CreateServer(
 		"/api/v1/completed",
 		libhttp.NewErrorHandler(server.NewCompletedHandler(statusChecker)),
 	)
+	mux.Handle("/shutdown", libhttp.NewErrorHandler(server.NewShutdownHandler(shutdownTrigger)))
+	pauseSentinel := pause.NewSentinel(inboxDir, currentDateTimeGetter)
+	mux.Handle("/pause", libhttp.NewErrorHandler(server.NewPauseHandler(pauseSentinel)))
+	mux.Handle("/resume", libhttp.NewErrorHandler(server.NewResumeHandler(pauseSentinel)))
+	if metricsGatherer != nil {
+		mux.Handle("/metrics", metrics.NewHandler(metricsGatherer))
+	}
+
+	var apiHandler http.Handler = mux
+	if credentials != nil {
+		apiHandler = server.NewAuthHandler(mux, *credentials)
+	}
+	root := http.NewServeMux()
+	root.Handle("/health", libhttp.NewErrorHandler(server.NewHealthHandler()))
+	root.Handle("/", apiHandler)
 
 	// Create server with libhttp (sane defaults: ReadHeaderTimeout 10s, ReadTimeout 30s,
 	// WriteTimeout 30s, IdleTimeout 60s, MaxHeaderBytes 1MB — sufficient for dark-factory threat model)
-	runFunc := libhttp.NewServer(addr, mux)
-	return server.NewServer(runFunc)
+	if serverTLS.CertFile != "" {
+		return server.NewServer(
+			libhttp.NewServerTLS(addr, root, serverTLS.CertFile, serverTLS.KeyFile),
+		)
+	}
+	return server.NewServer(libhttp.NewServer(addr, root))
+}
+
+// countQueued returns the number of queued prompts across managers. A manager
+// failing to list its queue counts as empty.
+func countQueued(ctx context.Context, managers []*prompt.Manager) int {
+	count := 0
+	for _, manager := range managers {
+		queued, err := manager.ListQueued(ctx)
+		if err != nil {
+			slog.Debug("count queued prompts failed", "error", err)
+			continue
+		}
+		count += len(queued)
+	}
+	return count
+}
+
+// createServerCredentials resolves the REST API credentials from the env vars
+// named in cfg.ServerAuth. Returns nil when no auth is configured. An unset env
+// var leaves that scheme disabled, so a misconfigured server rejects every
+// request instead of running open.
+func createServerCredentials(ctx context.Context, cfg config.Config) *server.Credentials {
+	if !cfg.ServerAuth.Enabled() {
+		return nil
+	}
+	credentials := &server.Credentials{
+		Token:    cfg.ResolvedServerToken(),
+		Username: cfg.ServerAuth.Username,
+		Password: cfg.ResolvedServerPassword(),
+	}
+	if credentials.Token == "" && credentials.Password == "" {
+		slog.WarnContext(
+			ctx,
+			"serverAuth configured but no credential env var is set; all API requests will be rejected",
+			"tokenEnv", cfg.ServerAuth.TokenEnv,
+			"passwordEnv", cfg.ServerAuth.PasswordEnv,
+		)
+	}
+	return credentials
 }
 
 // CreateStatusCommand creates a StatusCommand.
@@ -1280,6 +1855,8 @@ func CreateStatusCommand(
 		cfg.Prompts.CompletedDir,
 		cfg.Prompts.CancelledDir,
 		currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
 	)
 
 	statusProjectName, statusProjectNameErr := project.Resolve(
@@ -1298,9 +1875,11 @@ func CreateStatusCommand(
 	}
 	statusChecker := createStatusChecker(
 		ctx,
+		cfg.Prompts.InboxDir,
 		cfg.Prompts.InProgressDir,
 		cfg.Prompts.CompletedDir,
 		cfg.Prompts.LogDir,
+		cfg.Prompts.IdeasDir,
 		cfg.ServerPort,
 		promptManager,
 		cfg.MaxContainers,
@@ -1320,7 +1899,8 @@ func CreateDoctorCommand(
 	verifyingStaleHours int,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) cmd.DoctorCommand {
-	promptManager, releaser := createPromptManager(
+	promptManager, releaser := createPromptManagerWithReleaser(
+		CreateReleaser(cfg),
 		cfg.Prompts.InboxDir,
 		cfg.Prompts.InProgressDir,
 		cfg.Prompts.CompletedDir,
@@ -1553,6 +2133,267 @@ func CreateRequeueCommand(
 	return cmd.NewRequeueCommand(cfg.Prompts.InProgressDir, promptManager)
 }
 
+// CreateBumpCommand creates a BumpCommand using the configured queueOrder.
+func CreateBumpCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.BumpCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+	)
+	return cmd.NewBumpCommand(cfg.Prompts.InProgressDir, promptManager)
+}
+
+// CreatePromoteCommand creates a PromoteCommand moving ideas into the queue.
+func CreatePromoteCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.PromoteCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+	)
+	return cmd.NewPromoteCommand(
+		cfg.Prompts.IdeasDir,
+		cfg.Prompts.InProgressDir,
+		promptManager,
+		promptManager,
+	)
+}
+
+// CreateRemoveCommand creates a RemoveCommand deleting queued or failed prompts.
+func CreateRemoveCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.RemoveCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+	)
+	return cmd.NewRemoveCommand(
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		promptManager,
+	)
+}
+
+// CreateQueueCommand creates a QueueCommand listing prompts in the configured queueOrder.
+func CreateQueueCommand(
+	ctx context.Context,
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.QueueCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+	)
+	queueProjectName, err := project.Resolve(
+		ctx,
+		subproc.NewRunner(),
+		cfg.ResolvedProjectOverride(),
+	)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			"resolve project name for queue command failed, using fallback",
+			"error",
+			err,
+		)
+		queueProjectName = project.Name("dark-factory")
+	}
+	statusChecker := createStatusChecker(
+		ctx,
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.LogDir,
+		cfg.Prompts.IdeasDir,
+		cfg.ServerPort,
+		promptManager,
+		cfg.MaxContainers,
+		cfg.DirtyFileThreshold,
+		currentDateTimeGetter,
+		queueProjectName,
+	)
+	return cmd.NewQueueCommand(promptManager, statusChecker, os.Stdout)
+}
+
+// CreateCompletedCommand creates a CompletedCommand listing recently completed prompts.
+func CreateCompletedCommand(
+	ctx context.Context,
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.CompletedCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+	)
+	completedProjectName, err := project.Resolve(
+		ctx,
+		subproc.NewRunner(),
+		cfg.ResolvedProjectOverride(),
+	)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			"resolve project name for completed command failed, using fallback",
+			"error",
+			err,
+		)
+		completedProjectName = project.Name("dark-factory")
+	}
+	statusChecker := createStatusChecker(
+		ctx,
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.LogDir,
+		cfg.Prompts.IdeasDir,
+		cfg.ServerPort,
+		promptManager,
+		cfg.MaxContainers,
+		cfg.DirtyFileThreshold,
+		currentDateTimeGetter,
+		completedProjectName,
+	)
+	return cmd.NewCompletedCommand(statusChecker, os.Stdout)
+}
+
+// CreateCancelExecutingCommand creates a CancelExecutingCommand that stops the executing
+// prompt's container and re-queues the prompt.
+func CreateCancelExecutingCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.CancelExecutingCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+	)
+	return cmd.NewCancelExecutingCommand(
+		cfg.Prompts.InProgressDir,
+		promptManager,
+		createExecutionStopper(cfg.Backend),
+		os.Stdout,
+	)
+}
+
+// CreateLogsCommand creates a LogsCommand printing prompt logs from the configured log directory.
+func CreateLogsCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.LogsCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+	)
+	return cmd.NewLogsCommand(
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.LogDir,
+		promptManager,
+		os.Stdout,
+		time.Second,
+	)
+}
+
+// CreateReconcileCommand creates a ReconcileCommand.
+func CreateReconcileCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.ReconcileCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+	)
+	return cmd.NewReconcileCommand(promptManager)
+}
+
+// CreateReorderCommand creates a ReorderCommand renumbering the queue.
+func CreateReorderCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.ReorderCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+	)
+	return cmd.NewReorderCommand(promptManager)
+}
+
+// CreateValidateCommand creates a ValidateCommand linting the queue of every prompts directory.
+func CreateValidateCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.ValidateCommand {
+	queueDirs := []string{cfg.Prompts.InProgressDir}
+	for _, additional := range cfg.AdditionalPrompts {
+		queueDirs = append(queueDirs, additional.InProgressDir)
+	}
+	return cmd.NewValidateCommand(queueDirs, os.Stdout, currentDateTimeGetter)
+}
+
+// CreateQueueAddCommand creates a QueueAddCommand that reads the prompt body from
+// stdin when stdin is piped.
+func CreateQueueAddCommand(
+	cfg config.Config,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) cmd.QueueAddCommand {
+	promptManager, _ := createPromptManager(
+		cfg.Prompts.InboxDir,
+		cfg.Prompts.InProgressDir,
+		cfg.Prompts.CompletedDir,
+		cfg.Prompts.CancelledDir,
+		currentDateTimeGetter,
+	)
+	return cmd.NewQueueAddCommand(promptManager, pipedStdin(), os.Stdout)
+}
+
+// pipedStdin returns os.Stdin when it is a pipe or file, nil when it is a terminal.
+func pipedStdin() io.Reader {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return nil
+	}
+	return os.Stdin
+}
+
 // CreateCancelCommand creates a CancelCommand.
 func CreateCancelCommand(
 	cfg config.Config,
@@ -1575,12 +2416,15 @@ func CreatePromptCompleteCommand(
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 	forceRelease bool,
 ) cmd.PromptCompleteCommand {
-	promptManager, releaser := createPromptManager(
+	promptManager, releaser := createPromptManagerWithReleaser(
+		CreateReleaser(cfg),
 		cfg.Prompts.InboxDir,
 		cfg.Prompts.InProgressDir,
 		cfg.Prompts.CompletedDir,
 		cfg.Prompts.CancelledDir,
 		currentDateTimeGetter,
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
+		prompt.WithMirrorCompletedTo(cfg.MirrorCompletedTo),
 	)
 	deps := createProviderDeps(ctx, cfg, currentDateTimeGetter)
 	return cmd.NewPromptCompleteCommand(
@@ -1633,6 +2477,7 @@ func CreateApproveCommand(
 		cfg.Prompts.InboxDir,
 		cfg.Prompts.InProgressDir,
 		promptManager,
+		promptManager,
 	)
 }
 
@@ -1812,6 +2657,8 @@ func CreateCombinedStatusCommand(
 		cfg.Prompts.CompletedDir,
 		cfg.Prompts.CancelledDir,
 		currentDateTimeGetter,
+		prompt.WithQueueOrder(cfg.QueueOrder),
+		prompt.WithCompletedLayout(cfg.CompletedLayout),
 	)
 
 	combinedProjectName, combinedProjectNameErr := project.Resolve(
@@ -1830,9 +2677,11 @@ func CreateCombinedStatusCommand(
 	}
 	statusChecker := createStatusChecker(
 		ctx,
+		cfg.Prompts.InboxDir,
 		cfg.Prompts.InProgressDir,
 		cfg.Prompts.CompletedDir,
 		cfg.Prompts.LogDir,
+		cfg.Prompts.IdeasDir,
 		cfg.ServerPort,
 		promptManager,
 		cfg.MaxContainers,
@@ -1862,6 +2711,57 @@ func CreateCombinedStatusCommand(
 	)
 }
 
+// CreateMultiStatusCommand creates a MultiStatusCommand reporting the prompt
+// status of every project directory in dirs. Each directory's own
+// .dark-factory.yaml decides where its prompts live.
+func CreateMultiStatusCommand(
+	ctx context.Context,
+	dirs []string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) (cmd.MultiStatusCommand, error) {
+	checkers := make([]status.Checker, 0, len(dirs))
+	for _, dir := range dirs {
+		projectDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "resolve project dir %s", dir)
+		}
+		cfg, err := config.NewLoaderForDir(projectDir).Load(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "load config of %s", projectDir)
+		}
+		cfg = cfg.InProjectDir(projectDir)
+		promptManager, _ := createPromptManager(
+			cfg.Prompts.InboxDir,
+			cfg.Prompts.InProgressDir,
+			cfg.Prompts.CompletedDir,
+			cfg.Prompts.CancelledDir,
+			currentDateTimeGetter,
+			prompt.WithQueueOrder(cfg.QueueOrder),
+			prompt.WithCompletedLayout(cfg.CompletedLayout),
+		)
+		projectName := project.Name(cfg.ResolvedProjectOverride())
+		if projectName == "" {
+			projectName = project.Name(filepath.Base(projectDir))
+		}
+		checkers = append(checkers, createStatusCheckerForDir(
+			ctx,
+			projectDir,
+			cfg.Prompts.InboxDir,
+			cfg.Prompts.InProgressDir,
+			cfg.Prompts.CompletedDir,
+			cfg.Prompts.LogDir,
+			cfg.Prompts.IdeasDir,
+			cfg.ServerPort,
+			promptManager,
+			cfg.MaxContainers,
+			cfg.DirtyFileThreshold,
+			currentDateTimeGetter,
+			projectName,
+		))
+	}
+	return cmd.NewMultiStatusCommand(checkers, status.NewFormatter(), os.Stdout), nil
+}
+
 // CreateSpecShowCommand creates a SpecShowCommand.
 func CreateSpecShowCommand(
 	cfg config.Config,
@@ -1959,12 +2859,13 @@ func CreateCombinedListCommand(
 // Exposed for testing the burst-collapse and heartbeat behavior.
 //
 // Behavior:
-//   - First call (or first call > 2*queueInterval since the last) emits unconditionally
+//   - First call (or first call > 2*pollInterval since the last) emits unconditionally
 //   - Subsequent calls within the same idle window emit only when the heartbeat sampler fires
 //   - idleLogInterval == 0 disables the heartbeat; only first-entry emissions fire
 func buildIdleLogger(
 	idleLogInterval time.Duration,
-	queueInterval time.Duration,
+	// pollInterval is the longest delay between two queue polls.
+	pollInterval time.Duration,
 	emit func(),
 ) func(context.Context, context.CancelFunc) {
 	var (
@@ -1984,7 +2885,7 @@ func buildIdleLogger(
 		mu.Lock()
 		defer mu.Unlock()
 		now := time.Now()
-		isNewIdleEntry := lastIdleCall.IsZero() || now.Sub(lastIdleCall) > 2*queueInterval
+		isNewIdleEntry := lastIdleCall.IsZero() || now.Sub(lastIdleCall) > 2*pollInterval
 		lastIdleCall = now
 		if isNewIdleEntry {
 			emit()
@@ -7,17 +7,23 @@ package factory_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	stderrors "errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/bborbe/run"
 	libtime "github.com/bborbe/time"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/bborbe/dark-factory/mocks"
 	"github.com/bborbe/dark-factory/pkg/config"
@@ -25,11 +31,14 @@ import (
 	"github.com/bborbe/dark-factory/pkg/factory"
 	"github.com/bborbe/dark-factory/pkg/git"
 	"github.com/bborbe/dark-factory/pkg/globalconfig"
+	"github.com/bborbe/dark-factory/pkg/metrics"
 	"github.com/bborbe/dark-factory/pkg/notifier"
 	"github.com/bborbe/dark-factory/pkg/preflightconditions"
 	"github.com/bborbe/dark-factory/pkg/processor"
 	"github.com/bborbe/dark-factory/pkg/project"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/promptenricher"
+	"github.com/bborbe/dark-factory/pkg/status"
 	"github.com/bborbe/dark-factory/pkg/subproc"
 )
 
@@ -98,11 +107,13 @@ var _ = Describe("Factory", func() {
 				nil, // releaser not needed for nil check
 				nil, // versionGetter not needed for nil check
 				wakeup,
+				nil, // shutdown
 				git.NewBrancher(),
 				git.NewPRCreator(""),
 				git.NewPRMerger("", libtime.NewCurrentDateTime()),
 				libtime.NewCurrentDateTime(),
 				notifier.NewMultiNotifier(),
+				nil, // promptMetrics
 				executor.NewDockerContainerCounter(subproc.NewRunner()),
 				nil, // containerLock
 				nil, // containerChecker
@@ -117,7 +128,7 @@ var _ = Describe("Factory", func() {
 
 	Describe("CreateLocker", func() {
 		It("should return a non-nil locker", func() {
-			locker := factory.CreateLocker(".")
+			locker := factory.CreateLocker(".", false)
 			Expect(locker).NotTo(BeNil())
 		})
 	})
@@ -131,13 +142,132 @@ var _ = Describe("Factory", func() {
 				cfg.Prompts.InProgressDir,
 				cfg.Prompts.CompletedDir,
 				cfg.Prompts.LogDir,
+				cfg.Prompts.IdeasDir,
 				nil, // promptManager not needed for nil check
 				libtime.NewCurrentDateTime(),
 				0,
 				project.Name("test-project"),
+				config.ServerTLSConfig{},
+				nil,
+				nil,
+				run.NewTrigger(),
 			)
 			Expect(server).NotTo(BeNil())
 		})
+
+		It("serves status and health until the context is cancelled", func() {
+			dir := GinkgoT().TempDir()
+			inboxDir := filepath.Join(dir, "prompts")
+			inProgressDir := filepath.Join(dir, "prompts", "in-progress")
+			completedDir := filepath.Join(dir, "prompts", "completed")
+			logDir := filepath.Join(dir, "prompts", "log")
+			Expect(os.MkdirAll(inProgressDir, 0750)).To(Succeed())
+			for _, name := range []string{"001-first.md", "002-second.md"} {
+				Expect(os.WriteFile(
+					filepath.Join(inProgressDir, name),
+					[]byte("---\nstatus: approved\n---\n# Queued\n"),
+					0600,
+				)).To(Succeed())
+			}
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			port := listener.Addr().(*net.TCPAddr).Port
+			Expect(listener.Close()).To(Succeed())
+
+			currentDateTimeGetter := libtime.NewCurrentDateTime()
+			shutdownTrigger := run.NewTrigger()
+			metricsRegistry := prometheus.NewRegistry()
+			metrics.NewMetrics(metricsRegistry, func() int { return 2 }).PromptCompleted(time.Minute)
+			server := factory.CreateServer(
+				context.Background(),
+				port,
+				inboxDir,
+				inProgressDir,
+				completedDir,
+				logDir,
+				filepath.Join(dir, "prompts", "ideas"),
+				prompt.NewManager(
+					inboxDir,
+					inProgressDir,
+					completedDir,
+					filepath.Join(dir, "prompts", "cancelled"),
+					nil,
+					currentDateTimeGetter,
+				),
+				currentDateTimeGetter,
+				0,
+				project.Name("test-project"),
+				config.ServerTLSConfig{},
+				nil,
+				metricsRegistry,
+				shutdownTrigger,
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan error, 1)
+			go func() { done <- server.ListenAndServe(ctx) }()
+
+			baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+			Eventually(func() (int, error) {
+				resp, err := http.Get(baseURL + "/health")
+				if err != nil {
+					return 0, err
+				}
+				_ = resp.Body.Close()
+				return resp.StatusCode, nil
+			}, 5*time.Second, 50*time.Millisecond).Should(Equal(http.StatusOK))
+
+			resp, err := http.Get(baseURL + "/api/v1/status")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = resp.Body.Close() }()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			var st status.Status
+			Expect(json.NewDecoder(resp.Body).Decode(&st)).To(Succeed())
+			Expect(st.QueueCount).To(Equal(2))
+			Expect(st.QueuedPrompts).To(ConsistOf("001-first.md", "002-second.md"))
+
+			metricsResp, err := http.Get(baseURL + "/metrics")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = metricsResp.Body.Close() }()
+			Expect(metricsResp.StatusCode).To(Equal(http.StatusOK))
+			metricsBody, err := io.ReadAll(metricsResp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(metricsBody)).
+				To(ContainSubstring("dark_factory_prompts_completed_total 1\n"))
+			Expect(string(metricsBody)).To(ContainSubstring("dark_factory_queue_depth 2\n"))
+
+			getPaused := func() bool {
+				resp, err := http.Get(baseURL + "/api/v1/status")
+				Expect(err).NotTo(HaveOccurred())
+				defer func() { _ = resp.Body.Close() }()
+				var st status.Status
+				Expect(json.NewDecoder(resp.Body).Decode(&st)).To(Succeed())
+				return st.Paused
+			}
+			for _, toggle := range []struct {
+				path   string
+				paused bool
+			}{{"/pause", true}, {"/resume", false}} {
+				toggleResp, err := http.Post(baseURL+toggle.path, "application/json", nil)
+				Expect(err).NotTo(HaveOccurred())
+				_ = toggleResp.Body.Close()
+				Expect(toggleResp.StatusCode).To(Equal(http.StatusOK))
+				Expect(getPaused()).To(Equal(toggle.paused))
+			}
+
+			shutdownResp, err := http.Post(baseURL+"/shutdown", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			_ = shutdownResp.Body.Close()
+			Expect(shutdownResp.StatusCode).To(Equal(http.StatusAccepted))
+			Expect(shutdownTrigger.Done()).To(BeClosed())
+
+			cancel()
+			Eventually(done, 5*time.Second).Should(Receive())
+			_, err = http.Get(baseURL + "/health")
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("CreateStatusCommand", func() {
@@ -162,6 +292,54 @@ var _ = Describe("Factory", func() {
 		})
 	})
 
+	Describe("CreateMultiStatusCommand", func() {
+		It("reports one status per project dir", func() {
+			ctx := context.Background()
+			dirs := make([]string, 0, 2)
+			for _, name := range []string{"repoA", "repoB"} {
+				dir := filepath.Join(GinkgoT().TempDir(), name)
+				Expect(os.MkdirAll(filepath.Join(dir, "prompts", "in-progress"), 0750)).To(Succeed())
+				Expect(os.WriteFile(
+					filepath.Join(dir, ".dark-factory.yaml"),
+					[]byte("workflow: direct\n"),
+					0600,
+				)).To(Succeed())
+				dirs = append(dirs, dir)
+			}
+			Expect(os.WriteFile(
+				filepath.Join(dirs[0], "prompts", "in-progress", "001-queued.md"),
+				[]byte("---\nstatus: approved\n---\n# Queued\n"),
+				0600,
+			)).To(Succeed())
+
+			// Redirect stdout before creating the command, which binds os.Stdout.
+			orig := os.Stdout
+			r, w, pipeErr := os.Pipe()
+			Expect(pipeErr).NotTo(HaveOccurred())
+			os.Stdout = w
+			defer func() { os.Stdout = orig }()
+			multiStatusCommand, err := factory.CreateMultiStatusCommand(
+				ctx,
+				dirs,
+				libtime.NewCurrentDateTime(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			runErr := multiStatusCommand.Run(ctx, []string{"--json"})
+			Expect(w.Close()).To(Succeed())
+			Expect(runErr).NotTo(HaveOccurred())
+			var buf bytes.Buffer
+			_, _ = io.Copy(&buf, r)
+
+			var statuses []status.Status
+			Expect(json.Unmarshal(buf.Bytes(), &statuses)).To(Succeed())
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[0].ProjectDir).To(Equal(dirs[0]))
+			Expect(statuses[0].QueueCount).To(Equal(1))
+			Expect(statuses[1].ProjectDir).To(Equal(dirs[1]))
+			Expect(statuses[1].QueueCount).To(Equal(0))
+		})
+	})
+
 	Describe("CreateCombinedListCommand", func() {
 		It("should return a non-nil combined list command", func() {
 			cmd := factory.CreateCombinedListCommand(cfg, libtime.NewCurrentDateTime())
@@ -208,6 +386,29 @@ var _ = Describe("Factory", func() {
 		})
 	})
 
+	Describe("buildAdditionalProcessorConfig", func() {
+		It("pauses with the primary sentinel and scopes the container names", func() {
+			cfg := config.Defaults()
+			cfg.Prompts.InboxDir = "a/prompts"
+			dirCfg := cfg
+			dirCfg.Prompts = config.PromptsConfig{
+				InboxDir:      "b/prompts",
+				InProgressDir: "b/prompts/in-progress",
+				CompletedDir:  "b/prompts/completed",
+				LogDir:        "b/prompts/log",
+			}
+
+			processorCfg := factory.BuildAdditionalProcessorConfigForTest(
+				cfg, dirCfg, globalconfig.GlobalConfig{},
+			)
+
+			Expect(processorCfg.InboxDir).To(Equal("b/prompts"))
+			Expect(processorCfg.InProgressDir).To(Equal("b/prompts/in-progress"))
+			Expect(processorCfg.PauseDir).To(Equal("a/prompts"))
+			Expect(processorCfg.ContainerScope).To(Equal("b/prompts"))
+		})
+	})
+
 	Describe("LogEffectiveConfig", func() {
 		var (
 			logBuf      bytes.Buffer
@@ -445,6 +646,7 @@ var _ = Describe("Factory", func() {
 				config.ProviderBitbucketServer,
 				"bitbucket",
 			),
+			Entry("gitlab → gitlab backend", config.ProviderGitLab, "gitlab"),
 			Entry("empty provider → github (default)", config.Provider(""), "github"),
 		)
 
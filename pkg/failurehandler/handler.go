@@ -11,9 +11,11 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/bborbe/errors"
 
+	"github.com/bborbe/dark-factory/pkg/log"
 	"github.com/bborbe/dark-factory/pkg/notifier"
 	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/prompt"
@@ -41,13 +43,22 @@ type PromptManager interface {
 	Load(ctx context.Context, path string) (*prompt.PromptFile, error)
 }
 
+// maxRetryDelay caps the exponential retry backoff.
+const maxRetryDelay = 24 * time.Hour
+
 // NewHandler creates a new Handler.
+// retryBackoff delays a re-queued prompt by retryBackoff, doubled for every
+// further attempt; zero re-queues immediately. A prompt's retry_backoff
+// frontmatter overrides it.
+// autoRetryLimit is the number of automatic retries before a prompt is marked
+// failed; a prompt's max_retries frontmatter overrides it.
 func NewHandler(
 	promptManager PromptManager,
 	n notifier.Notifier,
 	completedDir string,
 	projectName project.Name,
 	autoRetryLimit int,
+	retryBackoff time.Duration,
 ) Handler {
 	return &handler{
 		promptManager:  promptManager,
@@ -55,6 +66,7 @@ func NewHandler(
 		completedDir:   completedDir,
 		projectName:    projectName,
 		autoRetryLimit: autoRetryLimit,
+		retryBackoff:   retryBackoff,
 	}
 }
 
@@ -64,6 +76,7 @@ type handler struct {
 	completedDir   string
 	projectName    project.Name
 	autoRetryLimit int
+	retryBackoff   time.Duration
 }
 
 // Handle is called when processPrompt returns an error.
@@ -92,8 +105,7 @@ func (h *handler) checkPostExecutionFailure(
 	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
 		return nil
 	}
-	completedFilePath := filepath.Join(h.completedDir, filepath.Base(path))
-	if _, cStatErr := os.Stat(completedFilePath); cStatErr != nil {
+	if prompt.FindCompletedFile(h.completedDir, filepath.Base(path)) == "" {
 		return nil
 	}
 	slog.Error(
@@ -105,9 +117,15 @@ func (h *handler) checkPostExecutionFailure(
 }
 
 // handlePromptFailure decides whether to retry or fail the prompt.
-// Re-queuing increments retryCount and calls MarkApproved; exhausted retries call MarkFailed.
+// Re-queuing increments retryCount, sets not_before per the retry backoff and
+// calls MarkApproved; exhausted retries call MarkFailed.
 func (h *handler) handlePromptFailure(ctx context.Context, path string, err error) {
-	slog.Error("prompt failed", "file", filepath.Base(path), "error", err)
+	log.From(ctx).Error(
+		"prompt failed",
+		log.Event(log.EventFailed),
+		"file", filepath.Base(path),
+		"error", err,
+	)
 
 	pf, loadErr := h.promptManager.Load(ctx, path)
 	if loadErr != nil {
@@ -118,9 +136,12 @@ func (h *handler) handlePromptFailure(ctx context.Context, path string, err erro
 	reason := err.Error()
 	pf.SetLastFailReason(reason)
 
-	if h.autoRetryLimit > 0 && pf.RetryCount() < h.autoRetryLimit {
+	retryLimit := h.retryLimitFor(pf)
+	if retryLimit > 0 && pf.RetryCount() < retryLimit {
 		// Re-queue with incremented retry count
 		pf.Frontmatter.RetryCount++
+		delay := retryDelay(h.backoffFor(pf), pf.RetryCount())
+		pf.DeferFor(delay)
 		pf.MarkApproved()
 		if saveErr := pf.Save(ctx); saveErr != nil {
 			slog.Error("failed to save prompt for retry", "error", saveErr)
@@ -129,30 +150,76 @@ func (h *handler) handlePromptFailure(ctx context.Context, path string, err erro
 			if saveErr2 := pf.Save(ctx); saveErr2 != nil {
 				slog.Error("failed to save failed prompt", "error", saveErr2)
 			}
-			h.notifyFailed(ctx, path)
+			h.notifyFailed(ctx, path, pf)
 			return
 		}
 		slog.Info("prompt re-queued for retry",
 			"file", filepath.Base(path),
 			"retryCount", pf.RetryCount(),
-			"autoRetryLimit", h.autoRetryLimit)
+			"retryLimit", retryLimit,
+			"notBefore", pf.Frontmatter.NotBefore)
 		return
 	}
 
-	// Retries exhausted or autoRetryLimit == 0 — mark failed
+	// Retries exhausted or retry limit == 0 — mark failed
 	pf.MarkFailed()
 	if saveErr := pf.Save(ctx); saveErr != nil {
 		slog.Error("failed to set failed status", "error", saveErr)
 	}
-	h.notifyFailed(ctx, path)
+	h.notifyFailed(ctx, path, pf)
+}
+
+// retryLimitFor returns the prompt's max_retries frontmatter when it is set and
+// non-negative, otherwise the configured autoRetryLimit.
+func (h *handler) retryLimitFor(pf *prompt.PromptFile) int {
+	if pf.Frontmatter.MaxRetries == nil {
+		return h.autoRetryLimit
+	}
+	if *pf.Frontmatter.MaxRetries < 0 {
+		slog.Warn("ignoring invalid max_retries frontmatter",
+			"maxRetries", *pf.Frontmatter.MaxRetries)
+		return h.autoRetryLimit
+	}
+	return *pf.Frontmatter.MaxRetries
+}
+
+// backoffFor returns the prompt's retry_backoff frontmatter when it parses as a
+// non-negative duration, otherwise the configured retry backoff.
+func (h *handler) backoffFor(pf *prompt.PromptFile) time.Duration {
+	if pf.Frontmatter.RetryBackoff == "" {
+		return h.retryBackoff
+	}
+	d, err := time.ParseDuration(pf.Frontmatter.RetryBackoff)
+	if err != nil || d < 0 {
+		slog.Warn("ignoring invalid retry_backoff frontmatter",
+			"retryBackoff", pf.Frontmatter.RetryBackoff)
+		return h.retryBackoff
+	}
+	return d
+}
+
+// retryDelay doubles backoff for every attempt after the first, so retries
+// wait backoff, 2*backoff, 4*backoff, ... up to maxRetryDelay.
+func retryDelay(backoff time.Duration, retryCount int) time.Duration {
+	delay := backoff
+	for i := 1; i < retryCount && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
 }
 
 // notifyFailed fires a notification for a failed prompt.
-func (h *handler) notifyFailed(ctx context.Context, path string) {
+func (h *handler) notifyFailed(ctx context.Context, path string, pf *prompt.PromptFile) {
 	_ = h.notifier.Notify(ctx, notifier.Event{
 		ProjectName: h.projectName.String(),
 		EventType:   "prompt_failed",
 		PromptName:  filepath.Base(path),
+		Owner:       pf.Frontmatter.Owner,
+		Title:       pf.Title(),
+		Status:      string(prompt.FailedPromptStatus),
+		Version:     pf.Frontmatter.DarkFactoryVersion,
+		Container:   pf.Frontmatter.Container,
+		Duration:    pf.Elapsed(),
 	})
 }
 
@@ -164,10 +231,15 @@ func (h *handler) NotifyFromReport(ctx context.Context, logFile string, promptPa
 		return
 	}
 	if completionReport.Status == "partial" {
+		var owner string
+		if pf, loadErr := h.promptManager.Load(ctx, promptPath); loadErr == nil && pf != nil {
+			owner = pf.Frontmatter.Owner
+		}
 		_ = h.notifier.Notify(ctx, notifier.Event{
 			ProjectName: h.projectName.String(),
 			EventType:   "prompt_partial",
 			PromptName:  filepath.Base(promptPath),
+			Owner:       owner,
 		})
 	}
 }
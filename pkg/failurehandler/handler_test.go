@@ -6,9 +6,13 @@ package failurehandler_test
 
 import (
 	"context"
+	"encoding/json"
 	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"time"
 
 	libtime "github.com/bborbe/time"
 	. "github.com/onsi/ginkgo/v2"
@@ -76,7 +80,7 @@ var _ = Describe("Handler", func() {
 	Describe("Handle", func() {
 		Context("when ctx is already cancelled", func() {
 			BeforeEach(func() {
-				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0)
+				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0, 0)
 			})
 
 			It("returns a wrapped error and does not touch the prompt", func() {
@@ -98,7 +102,7 @@ var _ = Describe("Handler", func() {
 					0600,
 				)
 				Expect(err).NotTo(HaveOccurred())
-				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0)
+				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0, 0)
 			})
 
 			It("returns a stop error", func() {
@@ -110,7 +114,7 @@ var _ = Describe("Handler", func() {
 
 		Context("with autoRetryLimit > 0 and retries available", func() {
 			BeforeEach(func() {
-				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 3)
+				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 3, 0)
 				pf := makePromptFile(0)
 				promptMgr.LoadReturns(pf, nil)
 			})
@@ -131,9 +135,86 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("with retryBackoff", func() {
+			var (
+				now             time.Time
+				currentDateTime libtime.CurrentDateTime
+			)
+
+			BeforeEach(func() {
+				h = failurehandler.NewHandler(
+					promptMgr,
+					n,
+					completedDir,
+					"test-project",
+					3,
+					time.Minute,
+				)
+				now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+				currentDateTime = libtime.NewCurrentDateTime()
+				currentDateTime.SetNow(libtime.DateTime(now))
+			})
+
+			loadSaved := func() *prompt.PromptFile {
+				saved, err := prompt.NewManager("", "", "", "", nil, currentDateTime).
+					Load(ctx, promptPath)
+				Expect(err).NotTo(HaveOccurred())
+				return saved
+			}
+
+			failWithRetryCount := func(retryCount int, retryBackoff string) *prompt.PromptFile {
+				pf := prompt.NewPromptFile(
+					promptPath,
+					prompt.Frontmatter{
+						Status:       "approved",
+						RetryCount:   retryCount,
+						RetryBackoff: retryBackoff,
+					},
+					[]byte("# My prompt\n"),
+					currentDateTime,
+				)
+				promptMgr.LoadReturns(pf, nil)
+				Expect(h.Handle(ctx, promptPath, stderrors.New("transient"))).To(Succeed())
+				return loadSaved()
+			}
+
+			It("delays the first retry by the backoff", func() {
+				saved := failWithRetryCount(0, "")
+				Expect(saved.Frontmatter.Status).To(Equal("approved"))
+				Expect(saved.Frontmatter.NotBefore).
+					To(Equal(now.Add(time.Minute).Format(time.RFC3339)))
+				Expect(saved.Deferred()).To(BeTrue())
+			})
+
+			It("doubles the backoff for the second retry", func() {
+				saved := failWithRetryCount(1, "")
+				Expect(saved.Frontmatter.RetryCount).To(Equal(2))
+				Expect(saved.Frontmatter.NotBefore).
+					To(Equal(now.Add(2 * time.Minute).Format(time.RFC3339)))
+			})
+
+			It("uses the retry_backoff frontmatter over the configured backoff", func() {
+				saved := failWithRetryCount(0, "10s")
+				Expect(saved.Frontmatter.NotBefore).
+					To(Equal(now.Add(10 * time.Second).Format(time.RFC3339)))
+			})
+
+			It("falls back to the configured backoff for invalid frontmatter", func() {
+				saved := failWithRetryCount(0, "soon")
+				Expect(saved.Frontmatter.NotBefore).
+					To(Equal(now.Add(time.Minute).Format(time.RFC3339)))
+			})
+
+			It("releases the prompt once the backoff has elapsed", func() {
+				failWithRetryCount(0, "")
+				currentDateTime.SetNow(libtime.DateTime(now.Add(time.Minute)))
+				Expect(loadSaved().Deferred()).To(BeFalse())
+			})
+		})
+
 		Context("with autoRetryLimit == 0 (disabled)", func() {
 			BeforeEach(func() {
-				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0)
+				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0, 0)
 				pf := makePromptFile(0)
 				promptMgr.LoadReturns(pf, nil)
 				n.NotifyReturns(nil)
@@ -153,12 +234,78 @@ var _ = Describe("Handler", func() {
 				Expect(evt.EventType).To(Equal("prompt_failed"))
 				Expect(evt.ProjectName).To(Equal("test-project"))
 				Expect(evt.PromptName).To(Equal(filepath.Base(promptPath)))
+				Expect(evt.Owner).To(BeEmpty())
+			})
+
+			It("passes the prompt owner to the notification", func() {
+				pf := makePromptFile(0)
+				pf.Frontmatter.Owner = "alice"
+				promptMgr.LoadReturns(pf, nil)
+
+				Expect(h.Handle(ctx, promptPath, stderrors.New("fatal error"))).To(Succeed())
+
+				_, evt := n.NotifyArgsForCall(0)
+				Expect(evt.Owner).To(Equal("alice"))
+
+				saved, readErr := prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+					Load(ctx, promptPath)
+				Expect(readErr).NotTo(HaveOccurred())
+				Expect(saved.Frontmatter.Owner).To(Equal("alice"))
+			})
+		})
+
+		Context("with a webhook notifier", func() {
+			var (
+				server   *httptest.Server
+				payloads chan notifier.WebhookPayload
+			)
+
+			BeforeEach(func() {
+				payloads = make(chan notifier.WebhookPayload, 1)
+				server = httptest.NewServer(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var payload notifier.WebhookPayload
+						Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+						payloads <- payload
+					}),
+				)
+				h = failurehandler.NewHandler(
+					promptMgr,
+					notifier.NewWebhookNotifier(server.URL),
+					completedDir,
+					"test-project",
+					0,
+					0,
+				)
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("posts the failed prompt", func() {
+				pf := makePromptFile(0)
+				pf.Frontmatter.Started = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+				pf.Frontmatter.Container = "test-project-001-my-prompt"
+				pf.Frontmatter.DarkFactoryVersion = "v1.2.3"
+				promptMgr.LoadReturns(pf, nil)
+
+				Expect(h.Handle(ctx, promptPath, stderrors.New("fatal error"))).To(Succeed())
+
+				var payload notifier.WebhookPayload
+				Eventually(payloads).Should(Receive(&payload))
+				Expect(payload.Prompt).To(Equal("001-my-prompt.md"))
+				Expect(payload.Title).To(Equal("My prompt"))
+				Expect(payload.Status).To(Equal("failed"))
+				Expect(payload.Version).To(Equal("v1.2.3"))
+				Expect(payload.Container).To(Equal("test-project-001-my-prompt"))
+				Expect(payload.Duration).To(BeNumerically(">=", 59))
 			})
 		})
 
 		Context("when retries are exhausted", func() {
 			BeforeEach(func() {
-				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 2)
+				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 2, 0)
 				// retryCount already at limit
 				pf := makePromptFile(2)
 				promptMgr.LoadReturns(pf, nil)
@@ -178,9 +325,35 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		DescribeTable("with max_retries frontmatter",
+			func(autoRetryLimit int, maxRetries int, retryCount int, expectedStatus string) {
+				h = failurehandler.NewHandler(
+					promptMgr, n, completedDir, "test-project", autoRetryLimit, 0,
+				)
+				pf := makePromptFile(retryCount)
+				pf.Frontmatter.MaxRetries = &maxRetries
+				promptMgr.LoadReturns(pf, nil)
+
+				err := h.Handle(ctx, promptPath, stderrors.New("some error"))
+				Expect(err).NotTo(HaveOccurred())
+
+				saved, readErr := prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+					Load(ctx, promptPath)
+				Expect(readErr).NotTo(HaveOccurred())
+				Expect(saved.Frontmatter.Status).To(Equal(expectedStatus))
+				Expect(saved.Frontmatter.MaxRetries).NotTo(BeNil())
+				Expect(*saved.Frontmatter.MaxRetries).To(Equal(maxRetries))
+			},
+			Entry("retries although autoRetryLimit is disabled", 0, 2, 0, "approved"),
+			Entry("retries up to its own limit", 0, 2, 1, "approved"),
+			Entry("fails once its own limit is reached", 3, 1, 1, "failed"),
+			Entry("0 disables retries despite autoRetryLimit", 3, 0, 0, "failed"),
+			Entry("negative falls back to autoRetryLimit", 3, -1, 0, "approved"),
+		)
+
 		Context("when Load fails", func() {
 			BeforeEach(func() {
-				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0)
+				h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0, 0)
 				promptMgr.LoadReturns(nil, stderrors.New("load error"))
 			})
 
@@ -195,7 +368,7 @@ var _ = Describe("Handler", func() {
 
 	Describe("NotifyFromReport", func() {
 		BeforeEach(func() {
-			h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0)
+			h = failurehandler.NewHandler(promptMgr, n, completedDir, "test-project", 0, 0)
 		})
 
 		Context("when log file does not exist", func() {
@@ -252,6 +425,7 @@ var _ = Describe("Handler", func() {
 				completedDir,
 				"proj",
 				0,
+				0,
 			)
 			Expect(h2).NotTo(BeNil())
 		})
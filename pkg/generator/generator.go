@@ -44,6 +44,7 @@ type dockerSpecGenerator struct {
 	additionalInstructions string
 	maxPromptDuration      time.Duration
 	promptManager          PromptManager
+	idempotencyKeys        prompt.IdempotencyKeyFinder
 	autoApprovePrompts     bool
 	queueDir               string // in-progress dir; prompts are approved into here
 }
@@ -63,6 +64,7 @@ func NewSpecGenerator(
 	additionalInstructions string,
 	maxPromptDuration time.Duration,
 	pm PromptManager,
+	idempotencyKeys prompt.IdempotencyKeyFinder,
 	autoApprovePrompts bool,
 	queueDir string,
 	projectName project.Name,
@@ -81,6 +83,7 @@ func NewSpecGenerator(
 		additionalInstructions: additionalInstructions,
 		maxPromptDuration:      maxPromptDuration,
 		promptManager:          pm,
+		idempotencyKeys:        idempotencyKeys,
 		autoApprovePrompts:     autoApprovePrompts,
 		queueDir:               queueDir,
 	}
@@ -351,7 +354,7 @@ func (g *dockerSpecGenerator) approvePromptFromInbox(
 	promptBasename string,
 ) error {
 	if _, err := prompt.ApproveFromInbox(
-		ctx, inboxPath, g.queueDir, g.promptManager,
+		ctx, inboxPath, g.queueDir, g.promptManager, g.idempotencyKeys,
 	); err != nil {
 		return errors.Wrapf(ctx, err, "approve prompt %s from inbox", promptBasename)
 	}
@@ -384,29 +387,23 @@ func inheritFromSpec(
 	return nil
 }
 
-// countCompletedPromptsForSpec counts prompts in completedDir that have specID in their spec field.
+// countCompletedPromptsForSpec counts prompts in completedDir and its YYYY-MM
+// subdirectories that have specID in their spec field.
 func countCompletedPromptsForSpec(
 	ctx context.Context,
 	completedDir string,
 	specID string,
 	pm PromptManager,
 ) (int, error) {
-	entries, err := os.ReadDir(completedDir)
+	paths, err := prompt.ListCompletedFiles(ctx, completedDir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return 0, nil
-		}
 		return 0, err
 	}
 	count := 0
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-		path := filepath.Join(completedDir, entry.Name())
+	for _, path := range paths {
 		pf, err := pm.Load(ctx, path)
 		if err != nil || pf == nil {
-			slog.Warn("skipping prompt during spec scan", "file", entry.Name(), "error", err)
+			slog.Warn("skipping prompt during spec scan", "file", filepath.Base(path), "error", err)
 			continue
 		}
 		if pf.Frontmatter.HasSpec(specID) {
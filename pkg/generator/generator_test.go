@@ -74,6 +74,7 @@ var _ = Describe("SpecGenerator", func() {
 			"",
 			0,
 			promptMgr,
+			&mocks.IdempotencyKeyFinder{},
 			false,
 			"",
 			project.Name("test-project"),
@@ -175,6 +176,27 @@ var _ = Describe("SpecGenerator", func() {
 			})
 		})
 
+		Context("no files produced but completed prompts exist for spec in a month dir", func() {
+			BeforeEach(func() {
+				executor.ExecuteStub = nil
+				executor.ExecuteReturns(nil)
+
+				// Monthly layout: the completed prompt lives in completedDir/YYYY-MM
+				monthDir := filepath.Join(completedDir, "2026-03")
+				Expect(os.MkdirAll(monthDir, 0750)).To(Succeed())
+				content := "---\nstatus: completed\nspec: \"020-auto-prompt-generation\"\n---\n# Done\n"
+				Expect(os.WriteFile(
+					filepath.Join(monthDir, "020-some-prompt.md"),
+					[]byte(content),
+					0600,
+				)).To(Succeed())
+			})
+
+			It("returns no error", func() {
+				Expect(sg.Generate(ctx, specPath)).To(Succeed())
+			})
+		})
+
 		Context("no files produced and no completed prompts for spec", func() {
 			BeforeEach(func() {
 				executor.ExecuteStub = nil
@@ -575,6 +597,7 @@ var _ = Describe("SpecGenerator", func() {
 					"Read /docs/guidelines.md before starting.",
 					0,
 					promptMgr,
+					&mocks.IdempotencyKeyFinder{},
 					false,
 					"",
 					project.Name("test-project"),
@@ -643,6 +666,7 @@ var _ = Describe("SpecGenerator", func() {
 				"",
 				0,
 				promptMgr,
+				&mocks.IdempotencyKeyFinder{},
 				true,
 				queueDir,
 				project.Name("test-project"),
@@ -789,6 +813,7 @@ var _ = Describe("SpecGenerator", func() {
 					"",
 					0,
 					promptMgr,
+					&mocks.IdempotencyKeyFinder{},
 					true,
 					queueDir,
 					project.Name("test-project"),
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"context"
+	"strings"
+)
+
+// Author is the identity commits are created with.
+type Author struct {
+	Name  string
+	Email string
+}
+
+// DefaultAuthor fills in the name or email when neither the configured Author nor the
+// repo's git config provides one, so commits never fail for a missing identity.
+var DefaultAuthor = Author{Name: "dark-factory", Email: "noreply@dark-factory"}
+
+// commitIdentityArgs returns the `-c user.name=… -c user.email=…` flags placed before
+// `commit`. A configured author field wins over the repo's git config; a field missing
+// from both falls back to DefaultAuthor. Fields the repo already provides yield no flag.
+func (h *Helpers) commitIdentityArgs(ctx context.Context) []string {
+	var args []string
+	if name := h.identityValue(ctx, h.author.Name, "user.name", DefaultAuthor.Name); name != "" {
+		args = append(args, "-c", "user.name="+name)
+	}
+	if email := h.identityValue(
+		ctx,
+		h.author.Email,
+		"user.email",
+		DefaultAuthor.Email,
+	); email != "" {
+		args = append(args, "-c", "user.email="+email)
+	}
+	return args
+}
+
+// identityValue returns configured if set, "" if git config has key, fallback otherwise.
+func (h *Helpers) identityValue(ctx context.Context, configured, key, fallback string) string {
+	if configured != "" {
+		return configured
+	}
+	out, err := h.runner.RunWithWarnAndTimeout(ctx, "git config", "git", "config", "--get", key)
+	if err == nil && strings.TrimSpace(string(out)) != "" {
+		return ""
+	}
+	return fallback
+}
+
+// commitArgs returns the git arguments committing with message under the resolved identity.
+func (h *Helpers) commitArgs(ctx context.Context, message string) []string {
+	return append(h.commitIdentityArgs(ctx), "commit", "-m", message)
+}
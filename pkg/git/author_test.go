@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+)
+
+var _ = Describe("Commit author", func() {
+	var (
+		ctx         context.Context
+		tempDir     string
+		originalDir string
+	)
+
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	lastAuthor := func() string {
+		return runGit("log", "-1", "--format=%an <%ae>")
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		// Hide global and system git config so the repo has no identity at all.
+		GinkgoT().Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+		GinkgoT().Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+		var err error
+		originalDir, err = os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		tempDir = GinkgoT().TempDir()
+		runGit("init")
+		Expect(os.Chdir(tempDir)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tempDir, "a.txt"), []byte("a"), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Chdir(originalDir)).To(Succeed())
+	})
+
+	It("CommitOnly falls back to the default author without a configured user", func() {
+		Expect(git.NewReleaser().CommitOnly(ctx, "add a")).To(Succeed())
+		Expect(lastAuthor()).To(Equal("dark-factory <noreply@dark-factory>"))
+	})
+
+	It("CommitCompletedFile falls back to the default author without a configured user", func() {
+		Expect(git.NewReleaser().CommitCompletedFile(ctx, "a.txt", "A")).To(Succeed())
+		Expect(lastAuthor()).To(Equal("dark-factory <noreply@dark-factory>"))
+	})
+
+	It("CommitAndRelease falls back to the default author without a configured user", func() {
+		Expect(os.WriteFile(
+			filepath.Join(tempDir, "CHANGELOG.md"),
+			[]byte("# Changelog\n\n## v0.1.0\n\n- Initial\n"),
+			0600,
+		)).To(Succeed())
+		Expect(git.NewReleaser().CommitOnly(ctx, "initial")).To(Succeed())
+		bareDir := GinkgoT().TempDir()
+		runGit("init", "--bare", bareDir)
+		runGit("remote", "add", "origin", bareDir)
+		runGit("push", "-u", "origin", "HEAD")
+		Expect(os.WriteFile(
+			filepath.Join(tempDir, "CHANGELOG.md"),
+			[]byte("# Changelog\n\n## Unreleased\n\n- Fix a\n\n## v0.1.0\n\n- Initial\n"),
+			0600,
+		)).To(Succeed())
+
+		Expect(git.NewReleaser().CommitAndRelease(ctx, git.PatchBump)).To(Succeed())
+		Expect(runGit("log", "-1", "--format=%s")).To(Equal("release v0.1.1"))
+		Expect(lastAuthor()).To(Equal("dark-factory <noreply@dark-factory>"))
+	})
+
+	It("keeps the repo's git config when no author is configured", func() {
+		runGit("config", "user.name", "Repo User")
+		runGit("config", "user.email", "repo@example.com")
+
+		Expect(git.NewReleaser().CommitOnly(ctx, "add a")).To(Succeed())
+		Expect(lastAuthor()).To(Equal("Repo User <repo@example.com>"))
+	})
+
+	It("commits as the configured author over the repo's git config", func() {
+		runGit("config", "user.name", "Repo User")
+		runGit("config", "user.email", "repo@example.com")
+		r := git.NewReleaser(git.WithAuthor(git.Author{Name: "Bot", Email: "bot@example.com"}))
+
+		Expect(r.CommitOnly(ctx, "add a")).To(Succeed())
+		Expect(lastAuthor()).To(Equal("Bot <bot@example.com>"))
+	})
+
+	It("fills a missing configured field from the repo's git config", func() {
+		runGit("config", "user.email", "repo@example.com")
+		r := git.NewReleaser(git.WithAuthor(git.Author{Name: "Bot"}))
+
+		Expect(r.CommitOnly(ctx, "add a")).To(Succeed())
+		Expect(lastAuthor()).To(Equal("Bot <repo@example.com>"))
+	})
+})
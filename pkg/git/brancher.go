@@ -22,6 +22,8 @@ type Brancher interface {
 	CreateAndSwitch(ctx context.Context, name string) error
 	Push(ctx context.Context, name string) error
 	Switch(ctx context.Context, name string) error
+	// DeleteBranch force-deletes the local branch name (git branch -D).
+	DeleteBranch(ctx context.Context, name string) error
 	CurrentBranch(ctx context.Context) (string, error)
 	Fetch(ctx context.Context) error
 	FetchAndVerifyBranch(ctx context.Context, branch string) error
@@ -59,6 +61,15 @@ func WithDefaultBranch(branch string) BrancherOption {
 	}
 }
 
+// WithBrancherRemote pushes branches to remote instead of DefaultRemote.
+func WithBrancherRemote(remote string) BrancherOption {
+	return func(b *brancher) {
+		if remote != "" {
+			b.remote = remote
+		}
+	}
+}
+
 // withBrancherRunner is an unexported option for injecting a runner (tests).
 func withBrancherRunner(r subproc.Runner) BrancherOption {
 	return func(b *brancher) {
@@ -69,12 +80,13 @@ func withBrancherRunner(r subproc.Runner) BrancherOption {
 // brancher implements Brancher.
 type brancher struct {
 	configuredDefaultBranch string
+	remote                  string
 	runner                  subproc.Runner
 }
 
 // NewBrancher creates a new Brancher.
 func NewBrancher(opts ...BrancherOption) Brancher {
-	b := &brancher{runner: subproc.NewRunner()}
+	b := &brancher{remote: DefaultRemote, runner: subproc.NewRunner()}
 	for _, opt := range opts {
 		opt(b)
 	}
@@ -114,14 +126,14 @@ func (b *brancher) Push(ctx context.Context, name string) error {
 	if err := ValidateBranchName(ctx, name); err != nil {
 		return errors.Wrap(ctx, err, "validate branch name")
 	}
-	slog.Debug("pushing branch to remote", "branch", name)
+	slog.Debug("pushing branch to remote", "branch", name, "remote", b.remote)
 	out, err := b.runner.RunWithWarnAndTimeout(
 		ctx,
-		"git push -u origin",
+		"git push -u",
 		"git",
 		"push",
 		"-u",
-		"origin",
+		b.remote,
 		name,
 	)
 	if err != nil {
@@ -154,6 +166,22 @@ func (b *brancher) Switch(ctx context.Context, name string) error {
 	return nil
 }
 
+// DeleteBranch force-deletes a local branch. The branch must not be checked out.
+func (b *brancher) DeleteBranch(ctx context.Context, name string) error {
+	if err := ValidateBranchName(ctx, name); err != nil {
+		return errors.Wrap(ctx, err, "validate branch name")
+	}
+	slog.Debug("deleting local branch", "branch", name)
+	out, err := b.runner.RunWithWarnAndTimeout(ctx, "git branch -D", "git", "branch", "-D", name)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "delete branch: %s", stderrFromErr(err))
+	}
+	if s := strings.TrimSpace(string(out)); s != "" {
+		slog.Debug("git output", "op", "delete-branch", "output", s)
+	}
+	return nil
+}
+
 // CurrentBranch returns the name of the current branch.
 func (b *brancher) CurrentBranch(ctx context.Context) (string, error) {
 	output, err := b.runner.RunWithWarnAndTimeout(
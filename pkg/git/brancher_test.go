@@ -167,6 +167,27 @@ var _ = Describe("Brancher", func() {
 		})
 	})
 
+	Describe("DeleteBranch", func() {
+		It("deletes an unmerged local branch", func() {
+			Expect(b.CreateAndSwitch(ctx, "delete-me")).To(Succeed())
+			Expect(b.CreateAndSwitch(ctx, "keep-me")).To(Succeed())
+
+			Expect(b.DeleteBranch(ctx, "delete-me")).To(Succeed())
+			Expect(b.Switch(ctx, "delete-me")).NotTo(Succeed())
+		})
+
+		It("returns error when the branch is checked out", func() {
+			Expect(b.CreateAndSwitch(ctx, "checked-out")).To(Succeed())
+
+			Expect(b.DeleteBranch(ctx, "checked-out")).NotTo(Succeed())
+		})
+
+		It("rejects an invalid branch name", func() {
+			err := b.DeleteBranch(ctx, "-D")
+			Expect(err).To(MatchError(ContainSubstring("validate branch name")))
+		})
+	})
+
 	Describe("Push", func() {
 		It("returns error when no remote is configured", func() {
 			// Create and switch to new branch
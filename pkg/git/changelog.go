@@ -12,7 +12,8 @@ import (
 )
 
 // DetermineBumpFromChangelog reads CHANGELOG.md from the given directory and returns
-// MinorBump if any ## Unreleased entry starts with "- feat:", PatchBump otherwise.
+// MajorBump if any ## Unreleased entry is breaking (see isBreakingEntry), MinorBump if
+// any entry starts with "- feat:", PatchBump otherwise.
 // Returns PatchBump when CHANGELOG.md is missing or has no ## Unreleased section.
 func DetermineBumpFromChangelog(ctx context.Context, dir string) VersionBump {
 	// #nosec G304 -- dir is a trusted application-controlled path, not user input
@@ -23,6 +24,7 @@ func DetermineBumpFromChangelog(ctx context.Context, dir string) VersionBump {
 
 	lines := strings.Split(string(content), "\n")
 	inUnreleased := false
+	bump := PatchBump
 	for _, line := range lines {
 		if strings.HasPrefix(line, "## Unreleased") {
 			inUnreleased = true
@@ -31,9 +33,212 @@ func DetermineBumpFromChangelog(ctx context.Context, dir string) VersionBump {
 		if inUnreleased && strings.HasPrefix(line, "##") {
 			break
 		}
-		if inUnreleased && strings.HasPrefix(strings.TrimSpace(line), "- feat:") {
-			return MinorBump
+		if !inUnreleased {
+			continue
+		}
+		entry := strings.TrimSpace(line)
+		if isBreakingEntry(entry) {
+			return MajorBump
+		}
+		if strings.HasPrefix(entry, "- feat:") {
+			bump = MinorBump
+		}
+	}
+	return bump
+}
+
+// isBreakingEntry reports whether a changelog bullet announces a breaking change:
+// a conventional "!" prefix ("- feat!:", "- fix!:", ...), a "- breaking:" prefix,
+// or the text "BREAKING CHANGE" anywhere in the entry.
+func isBreakingEntry(entry string) bool {
+	if !strings.HasPrefix(entry, "- ") {
+		return false
+	}
+	if strings.Contains(entry, "BREAKING CHANGE") {
+		return true
+	}
+	prefix, _, found := strings.Cut(strings.TrimPrefix(entry, "- "), ":")
+	if !found || strings.Contains(prefix, " ") {
+		return false
+	}
+	return strings.HasSuffix(prefix, "!") || strings.EqualFold(prefix, "breaking")
+}
+
+// UnreleasedEntries returns the bullet lines of the ## Unreleased section of
+// CHANGELOG.md in the given directory, in file order. Returns nil when
+// CHANGELOG.md is missing or has no ## Unreleased section.
+func UnreleasedEntries(ctx context.Context, dir string) []string {
+	// #nosec G304 -- dir is a trusted application-controlled path, not user input
+	content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+	if err != nil {
+		return nil
+	}
+
+	var entries []string
+	inUnreleased := false
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "## Unreleased") {
+			inUnreleased = true
+			continue
+		}
+		if inUnreleased && strings.HasPrefix(line, "##") {
+			break
+		}
+		if inUnreleased && strings.HasPrefix(strings.TrimSpace(line), "- ") {
+			entries = append(entries, strings.TrimSpace(line))
+		}
+	}
+	return entries
+}
+
+// changelogSectionOrder is the Keep a Changelog order used for newly created subsections.
+var changelogSectionOrder = []string{
+	"Added",
+	"Changed",
+	"Deprecated",
+	"Removed",
+	"Fixed",
+	"Security",
+}
+
+// changelogSectionFor returns the "### " subsection a changelog bullet belongs to,
+// derived from its conventional prefix: "feat" → Added, "fix" → Fixed, "deprecate" →
+// Deprecated, "remove" → Removed, "security" → Security, anything else → Changed.
+func changelogSectionFor(entry string) string {
+	prefix, _, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(entry), "- "), ":")
+	if !found || strings.Contains(prefix, " ") {
+		return "Changed"
+	}
+	prefix = strings.TrimSuffix(prefix, "!")
+	if scope := strings.Index(prefix, "("); scope >= 0 {
+		prefix = prefix[:scope]
+	}
+	switch strings.ToLower(prefix) {
+	case "feat", "feature", "add":
+		return "Added"
+	case "fix", "bugfix":
+		return "Fixed"
+	case "deprecate", "deprecated":
+		return "Deprecated"
+	case "remove", "removed":
+		return "Removed"
+	case "security":
+		return "Security"
+	default:
+		return "Changed"
+	}
+}
+
+// changelogSubsection is one "### " heading of the Unreleased section with its lines.
+type changelogSubsection struct {
+	name  string
+	lines []string
+}
+
+// sectionUnreleasedEntries moves the bullets of ## Unreleased that are not under a
+// "### " subsection into the subsection named by changelogSectionFor, creating it
+// if missing. Existing subsections keep their order and content; new ones follow
+// in Keep a Changelog order. Lines are returned unchanged when there is nothing to move.
+func sectionUnreleasedEntries(lines []string) []string {
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## Unreleased") {
+			start = i
+			break
 		}
 	}
-	return PatchBump
+	if start < 0 {
+		return lines
+	}
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "## ") {
+			end = i
+			break
+		}
+	}
+
+	var text []string
+	var loose [][]string
+	var subsections []*changelogSubsection
+	for _, line := range lines[start+1 : end] {
+		switch {
+		case strings.HasPrefix(line, "### "):
+			subsections = append(subsections, &changelogSubsection{
+				name: strings.TrimSpace(strings.TrimPrefix(line, "### ")),
+			})
+		case len(subsections) > 0:
+			current := subsections[len(subsections)-1]
+			current.lines = append(current.lines, line)
+		case strings.HasPrefix(line, "- "):
+			loose = append(loose, []string{line})
+		case len(loose) > 0 && strings.TrimSpace(line) != "" &&
+			strings.TrimLeft(line, " \t") != line:
+			// Indented continuation of the previous bullet.
+			loose[len(loose)-1] = append(loose[len(loose)-1], line)
+		case strings.TrimSpace(line) != "":
+			text = append(text, line)
+		}
+	}
+	if len(loose) == 0 {
+		return lines
+	}
+
+	// Create missing subsections in Keep a Changelog order, after the existing ones.
+	needed := make(map[string]bool, len(loose))
+	for _, bullet := range loose {
+		needed[changelogSectionFor(bullet[0])] = true
+	}
+	for _, name := range changelogSectionOrder {
+		if needed[name] && findChangelogSubsection(subsections, name) == nil {
+			subsections = append(subsections, &changelogSubsection{name: name})
+		}
+	}
+	for _, bullet := range loose {
+		subsection := findChangelogSubsection(subsections, changelogSectionFor(bullet[0]))
+		subsection.lines = append(trimTrailingBlank(subsection.lines), bullet...)
+	}
+
+	result := make([]string, 0, len(lines)+2*len(subsections))
+	result = append(result, lines[:start+1]...)
+	result = append(result, "")
+	if len(text) > 0 {
+		result = append(result, text...)
+		result = append(result, "")
+	}
+	for _, subsection := range subsections {
+		result = append(result, "### "+subsection.name)
+		result = append(result, trimLeadingBlank(trimTrailingBlank(subsection.lines))...)
+		result = append(result, "")
+	}
+	return append(result, lines[end:]...)
+}
+
+// findChangelogSubsection returns the subsection named name (case-insensitive), or nil.
+func findChangelogSubsection(
+	subsections []*changelogSubsection,
+	name string,
+) *changelogSubsection {
+	for _, subsection := range subsections {
+		if strings.EqualFold(subsection.name, name) {
+			return subsection
+		}
+	}
+	return nil
+}
+
+// trimTrailingBlank drops trailing blank lines.
+func trimTrailingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// trimLeadingBlank drops leading blank lines.
+func trimLeadingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	return lines
 }
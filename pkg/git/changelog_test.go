@@ -92,4 +92,44 @@ var _ = Describe("DetermineBumpFromChangelog", func() {
 			Expect(bump).To(Equal(git.MinorBump))
 		})
 	})
+
+	DescribeTable("breaking entries in ## Unreleased",
+		func(entries string, expected git.VersionBump) {
+			content := "# Changelog\n\n## Unreleased\n\n" + entries + "\n## v1.0.0\n\n- feat!: old\n"
+			Expect(
+				os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte(content), 0600),
+			).To(Succeed())
+			Expect(git.DetermineBumpFromChangelog(ctx, dir)).To(Equal(expected))
+		},
+		Entry("feat! prefix", "- feat: add x\n- feat!: drop y\n", git.MajorBump),
+		Entry("fix! prefix", "- fix!: rename flag\n", git.MajorBump),
+		Entry("breaking prefix", "- breaking: remove legacy config\n", git.MajorBump),
+		Entry("BREAKING CHANGE text", "- feat: new API. BREAKING CHANGE: old one removed\n", git.MajorBump),
+		Entry("lowercase breaking in text", "- fix: stop breaking the build\n", git.PatchBump),
+		Entry("feat only", "- feat: add x\n", git.MinorBump),
+	)
+})
+
+var _ = Describe("UnreleasedEntries", func() {
+	var ctx context.Context
+	var dir string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = GinkgoT().TempDir()
+	})
+
+	It("returns nil when CHANGELOG.md is missing", func() {
+		Expect(git.UnreleasedEntries(ctx, dir)).To(BeNil())
+	})
+
+	It("returns only the bullets of the Unreleased section", func() {
+		content := "# Changelog\n\n## Unreleased\n\n- feat: add thing\n- fix: repair other\n\n## v0.1.0\n\n- feat: initial\n"
+		Expect(os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte(content), 0600)).To(Succeed())
+
+		Expect(git.UnreleasedEntries(ctx, dir)).To(Equal([]string{
+			"- feat: add thing",
+			"- fix: repair other",
+		}))
+	})
 })
@@ -23,9 +23,24 @@ type Cloner interface {
 	Remove(ctx context.Context, path string) error
 }
 
+// ClonerOption is a functional option for configuring a cloner.
+type ClonerOption func(*cloner)
+
+// WithClonerRemote makes clones carry remote with the URL it has in the source repo,
+// so pushes to a configured non-origin remote also work from the clone.
+func WithClonerRemote(remote string) ClonerOption {
+	return func(c *cloner) {
+		c.remote = remote
+	}
+}
+
 // NewCloner creates a new Cloner.
-func NewCloner() Cloner {
-	return &cloner{runner: subproc.NewRunner()}
+func NewCloner(opts ...ClonerOption) Cloner {
+	c := &cloner{runner: subproc.NewRunner()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // newClonerWithRunner creates a Cloner with an injected runner (for tests).
@@ -36,6 +51,7 @@ func newClonerWithRunner(r subproc.Runner) *cloner {
 // cloner implements Cloner.
 type cloner struct {
 	runner subproc.Runner
+	remote string
 }
 
 // Clone creates a local clone of srcDir at destDir and checks out the branch.
@@ -84,35 +100,65 @@ func (c *cloner) gitClone(ctx context.Context, srcDir string, destDir string) er
 }
 
 // setRealRemote updates the clone's origin to the real remote URL from srcDir.
+// A configured remote other than origin is added to the clone with its srcDir URL.
 func (c *cloner) setRealRemote(ctx context.Context, srcDir string, destDir string) error {
-	remoteOutput, err := c.runner.RunWithWarnAndTimeoutDir(
+	url, err := c.remoteURL(ctx, srcDir, DefaultRemote)
+	if err != nil {
+		return err
+	}
+	_, err = c.runner.RunWithWarnAndTimeoutDir(
 		ctx,
-		"git remote get-url",
-		srcDir,
+		"git remote set-url",
+		destDir,
 		"git",
 		"remote",
-		"get-url",
-		"origin",
+		"set-url",
+		DefaultRemote,
+		url,
 	)
 	if err != nil {
-		return errors.Wrapf(ctx, err, "get remote url: %s", stderrFromErr(err))
+		return errors.Wrap(ctx, err, "set remote url")
+	}
+	if c.remote == "" || c.remote == DefaultRemote {
+		return nil
+	}
+	url, err = c.remoteURL(ctx, srcDir, c.remote)
+	if err != nil {
+		return err
 	}
 	_, err = c.runner.RunWithWarnAndTimeoutDir(
 		ctx,
-		"git remote set-url",
+		"git remote add",
 		destDir,
 		"git",
 		"remote",
-		"set-url",
-		"origin",
-		strings.TrimSpace(string(remoteOutput)),
+		"add",
+		c.remote,
+		url,
 	)
 	if err != nil {
-		return errors.Wrap(ctx, err, "set remote url")
+		return errors.Wrapf(ctx, err, "add remote %s: %s", c.remote, stderrFromErr(err))
 	}
 	return nil
 }
 
+// remoteURL returns the URL of remote in dir.
+func (c *cloner) remoteURL(ctx context.Context, dir string, remote string) (string, error) {
+	out, err := c.runner.RunWithWarnAndTimeoutDir(
+		ctx,
+		"git remote get-url",
+		dir,
+		"git",
+		"remote",
+		"get-url",
+		remote,
+	)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "get remote url: %s", stderrFromErr(err))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // checkoutBranch fetches from origin and checks out branch, tracking it if it exists remotely.
 func (c *cloner) checkoutBranch(ctx context.Context, destDir string, branch string) error {
 	// Fetch from real remote to detect existing branches (best-effort)
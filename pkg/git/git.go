@@ -19,6 +19,13 @@ import (
 	"github.com/bborbe/dark-factory/pkg/subproc"
 )
 
+// DefaultRemote is the remote pushed to when none is configured.
+const DefaultRemote = "origin"
+
+// DefaultCompletedCommitMessage is the commit message of a completed prompt file when
+// no completedCommitMessage template is configured.
+const DefaultCompletedCommitMessage = "move prompt to completed"
+
 // DefaultCommitBackoff defines the default retry backoff for git commit operations.
 // 3 retries with exponential backoff: ~2s, ~4s, ~8s.
 var DefaultCommitBackoff = run.Backoff{
@@ -67,26 +74,59 @@ const (
 	PatchBump VersionBump = iota
 	// MinorBump increments the minor version (vX.Y.Z -> vX.Y+1.0)
 	MinorBump
+	// MajorBump increments the major version (vX.Y.Z -> vX+1.0.0)
+	MajorBump
 )
 
+// String returns the bump name used in command output ("patch", "minor" or "major").
+func (b VersionBump) String() string {
+	switch b {
+	case MajorBump:
+		return "major"
+	case MinorBump:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+// ParseVersionBump returns the bump named by s ("patch", "minor" or "major"),
+// or false when s names no known bump.
+func ParseVersionBump(s string) (VersionBump, bool) {
+	for _, b := range []VersionBump{PatchBump, MinorBump, MajorBump} {
+		if s == b.String() {
+			return b, true
+		}
+	}
+	return PatchBump, false
+}
+
 //counterfeiter:generate -o ../../mocks/releaser.go --fake-name Releaser . Releaser
 
 // Releaser handles git commit, tag, and push operations.
 type Releaser interface {
 	GetNextVersion(ctx context.Context, bump VersionBump) (string, error)
 	CommitAndRelease(ctx context.Context, bump VersionBump) error
-	CommitCompletedFile(ctx context.Context, path string) error
+	// CommitCompletedFile commits the completed prompt file at path; title fills the
+	// {{.Title}} placeholder of the completed commit message.
+	CommitCompletedFile(ctx context.Context, path string, title string) error
 	CommitOnly(ctx context.Context, message string) error
+	// CommitPaths stages every change below paths and commits only those paths;
+	// other changes in the working tree and index are left as they are.
+	CommitPaths(ctx context.Context, message string, paths ...string) error
 	HasChangelog(ctx context.Context) bool
 	MoveFile(ctx context.Context, oldPath string, newPath string) error
 	PushBranch(ctx context.Context) error
 	// DetermineBump inspects CHANGELOG.md in the current directory and returns
-	// the appropriate version bump. PatchBump if missing or no `- feat:` entry.
+	// the appropriate version bump. MajorBump for a breaking entry, MinorBump for
+	// a `- feat:` entry, PatchBump otherwise or if missing.
 	DetermineBump(ctx context.Context) VersionBump
 	// CommitWithRetry runs fn with the default retry backoff and lock-aware
 	// logging. Application-layer code uses this seam instead of the package-
 	// level git.CommitWithRetry so processor stays mockable.
 	CommitWithRetry(ctx context.Context, fn func(context.Context) error) error
+	// HeadCommit returns the hash of the commit HEAD points to in the current directory.
+	HeadCommit(ctx context.Context) (string, error)
 }
 
 // releaser implements Releaser.
@@ -94,9 +134,56 @@ type releaser struct {
 	helpers *Helpers
 }
 
+// ReleaserOption is a functional option for configuring a releaser.
+type ReleaserOption func(*releaser)
+
+// WithAuthor commits as author instead of the repo's git config. Empty fields keep
+// the repo's value, or DefaultAuthor when the repo has none.
+func WithAuthor(author Author) ReleaserOption {
+	return func(r *releaser) {
+		r.helpers.author = author
+	}
+}
+
+// WithReleaserRemote pushes commits and tags to remote instead of DefaultRemote.
+func WithReleaserRemote(remote string) ReleaserOption {
+	return func(r *releaser) {
+		r.helpers.remote = remote
+	}
+}
+
+// WithPushRetries sets how often a push failing with a transient (network) error is
+// retried; 0 disables retries. Defaults to DefaultPushRetries.
+func WithPushRetries(retries int) ReleaserOption {
+	return func(r *releaser) {
+		r.helpers.pushRetries = retries
+	}
+}
+
+// WithChangelogSections groups the Unreleased CHANGELOG.md bullets under ### Added,
+// ### Fixed, ### Changed, ... subsections by their conventional prefix on release.
+func WithChangelogSections(enabled bool) ReleaserOption {
+	return func(r *releaser) {
+		r.helpers.changelogSections = enabled
+	}
+}
+
+// WithCompletedCommitMessage commits completed prompt files with the text/template tmpl
+// instead of DefaultCompletedCommitMessage. {{.Prompt}} is the prompt filename and
+// {{.Title}} its title. An empty tmpl keeps the default.
+func WithCompletedCommitMessage(tmpl string) ReleaserOption {
+	return func(r *releaser) {
+		r.helpers.completedCommitMessage = tmpl
+	}
+}
+
 // NewReleaser creates a new Releaser.
-func NewReleaser() Releaser {
-	return &releaser{helpers: NewHelpers()}
+func NewReleaser(opts ...ReleaserOption) Releaser {
+	r := &releaser{helpers: NewHelpers()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // newReleaserWithRunner creates a Releaser with an injected runner (for tests).
@@ -114,9 +201,14 @@ func (r *releaser) CommitAndRelease(ctx context.Context, bump VersionBump) error
 	return r.helpers.CommitAndRelease(ctx, bump)
 }
 
+// HeadCommit returns the hash of the current HEAD commit.
+func (r *releaser) HeadCommit(ctx context.Context) (string, error) {
+	return r.helpers.HeadCommit(ctx)
+}
+
 // CommitCompletedFile commits a completed prompt file to git.
-func (r *releaser) CommitCompletedFile(ctx context.Context, path string) error {
-	return r.helpers.CommitCompletedFile(ctx, path)
+func (r *releaser) CommitCompletedFile(ctx context.Context, path string, title string) error {
+	return r.helpers.CommitCompletedFile(ctx, path, title)
 }
 
 // HasChangelog checks if CHANGELOG.md exists in the current directory.
@@ -151,6 +243,11 @@ func (r *releaser) CommitOnly(ctx context.Context, message string) error {
 	return nil
 }
 
+// CommitPaths stages and commits only the changes below paths.
+func (r *releaser) CommitPaths(ctx context.Context, message string, paths ...string) error {
+	return r.helpers.commitPaths(ctx, message, paths)
+}
+
 // MoveFile moves a file using git mv to preserve history.
 func (r *releaser) MoveFile(ctx context.Context, oldPath string, newPath string) error {
 	return r.helpers.MoveFile(ctx, oldPath, newPath)
@@ -167,8 +264,8 @@ func CommitAndRelease(ctx context.Context, bump VersionBump) error {
 }
 
 // CommitCompletedFile stages and commits a completed prompt file (package-level wrapper).
-func CommitCompletedFile(ctx context.Context, path string) error {
-	return NewHelpers().CommitCompletedFile(ctx, path)
+func CommitCompletedFile(ctx context.Context, path string, title string) error {
+	return NewHelpers().CommitCompletedFile(ctx, path, title)
 }
 
 // MoveFile moves a file using git mv (package-level wrapper).
@@ -226,8 +323,10 @@ func latestVersionFromChangelog(ctx context.Context) (*SemanticVersionNumber, er
 	return maxVersion, nil
 }
 
-// updateChangelog renames ## Unreleased to version in CHANGELOG.md.
-func updateChangelog(ctx context.Context, version string) error {
+// updateChangelog renames ## Unreleased to version in CHANGELOG.md. With sectioned, the
+// loose Unreleased bullets are first grouped under ### subsections (see
+// sectionUnreleasedEntries).
+func updateChangelog(ctx context.Context, version string, sectioned bool) error {
 	changelogPath := "CHANGELOG.md"
 
 	content, err := os.ReadFile(changelogPath)
@@ -236,6 +335,9 @@ func updateChangelog(ctx context.Context, version string) error {
 	}
 
 	lines := strings.Split(string(content), "\n")
+	if sectioned {
+		lines = sectionUnreleasedEntries(lines)
+	}
 	result, unreleasedFound := processUnreleasedSection(lines, version)
 
 	if !unreleasedFound {
@@ -222,7 +222,7 @@ var _ = Describe("Internal git helpers", func() {
 			err = os.Chdir(tmpDir)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = CommitCompletedFile(ctx, filepath.Join(tmpDir, "somefile.md"))
+			err = CommitCompletedFile(ctx, filepath.Join(tmpDir, "somefile.md"), "Some file")
 			Expect(err).To(HaveOccurred())
 		})
 	})
@@ -328,3 +328,146 @@ var _ = Describe("Internal git helpers", func() {
 		})
 	})
 })
+
+var _ = Describe("sectionUnreleasedEntries", func() {
+	It("puts a fix under ### Fixed and a feature under ### Added", func() {
+		lines := []string{
+			"# Changelog",
+			"",
+			"## Unreleased",
+			"",
+			"- fix: Handle empty prompt",
+			"- feat: Add status --watch",
+			"- chore: Bump deps",
+			"",
+			"## v1.0.0",
+			"",
+			"- Old change",
+		}
+
+		Expect(sectionUnreleasedEntries(lines)).To(Equal([]string{
+			"# Changelog",
+			"",
+			"## Unreleased",
+			"",
+			"### Added",
+			"- feat: Add status --watch",
+			"",
+			"### Changed",
+			"- chore: Bump deps",
+			"",
+			"### Fixed",
+			"- fix: Handle empty prompt",
+			"",
+			"## v1.0.0",
+			"",
+			"- Old change",
+		}))
+	})
+
+	It("appends to existing subsections and keeps their order", func() {
+		lines := []string{
+			"## Unreleased",
+			"",
+			"- feat(api): Add endpoint",
+			"- fix!: Drop legacy flag",
+			"",
+			"### Fixed",
+			"- Earlier fix",
+			"",
+			"### Added",
+			"- Earlier feature",
+			"",
+		}
+
+		Expect(sectionUnreleasedEntries(lines)).To(Equal([]string{
+			"## Unreleased",
+			"",
+			"### Fixed",
+			"- Earlier fix",
+			"- fix!: Drop legacy flag",
+			"",
+			"### Added",
+			"- Earlier feature",
+			"- feat(api): Add endpoint",
+			"",
+		}))
+	})
+
+	It("keeps indented continuation lines with their bullet", func() {
+		lines := []string{
+			"## Unreleased",
+			"- fix: Long entry",
+			"  spanning two lines",
+			"",
+		}
+
+		Expect(sectionUnreleasedEntries(lines)).To(Equal([]string{
+			"## Unreleased",
+			"",
+			"### Fixed",
+			"- fix: Long entry",
+			"  spanning two lines",
+			"",
+		}))
+	})
+
+	It("returns lines unchanged when every bullet is already in a subsection", func() {
+		lines := []string{
+			"## Unreleased",
+			"",
+			"### Fixed",
+			"- Some bug fix",
+			"",
+			"## v1.0.0",
+		}
+
+		Expect(sectionUnreleasedEntries(lines)).To(Equal(lines))
+	})
+
+	It("returns lines unchanged without an Unreleased section", func() {
+		lines := []string{"# Changelog", "", "## v1.0.0", "- Old change"}
+
+		Expect(sectionUnreleasedEntries(lines)).To(Equal(lines))
+	})
+})
+
+var _ = Describe("updateChangelog with sections", func() {
+	var originalDir string
+
+	BeforeEach(func() {
+		var err error
+		originalDir, err = os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir(GinkgoT().TempDir())).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Chdir(originalDir)).To(Succeed())
+	})
+
+	It("releases the sectioned entries under the new version", func() {
+		Expect(os.WriteFile(
+			"CHANGELOG.md",
+			[]byte("# Changelog\n\n## Unreleased\n\n- feat: New thing\n- fix: Broken thing\n"),
+			0600,
+		)).To(Succeed())
+
+		Expect(updateChangelog(context.Background(), "v1.1.0", true)).To(Succeed())
+		content, err := os.ReadFile("CHANGELOG.md")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(
+			"# Changelog\n\n## v1.1.0\n\n### Added\n- feat: New thing\n\n### Fixed\n- fix: Broken thing\n",
+		))
+	})
+
+	It("leaves the entries flat when sections are disabled", func() {
+		original := "# Changelog\n\n## Unreleased\n\n- feat: New thing\n"
+		Expect(os.WriteFile("CHANGELOG.md", []byte(original), 0600)).To(Succeed())
+
+		Expect(updateChangelog(context.Background(), "v1.1.0", false)).To(Succeed())
+		content, err := os.ReadFile("CHANGELOG.md")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("# Changelog\n\n## v1.1.0\n\n- feat: New thing\n"))
+	})
+})
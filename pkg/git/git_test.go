@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/bborbe/errors"
 	"github.com/bborbe/run"
@@ -222,7 +223,7 @@ var _ = Describe("Git", func() {
 			err = os.WriteFile(completedPath, []byte("completed"), 0600)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = r.CommitCompletedFile(ctx, completedPath)
+			err = r.CommitCompletedFile(ctx, completedPath, "Completed")
 			Expect(err).To(BeNil())
 
 			// Verify commit was created
@@ -233,6 +234,33 @@ var _ = Describe("Git", func() {
 			Expect(string(output)).To(ContainSubstring("move prompt to completed"))
 		})
 
+		It("CommitCompletedFile renders the configured commit message template", func() {
+			completedPath := filepath.Join(tempDir, "001-fix-login.md")
+			Expect(os.WriteFile(completedPath, []byte("completed"), 0600)).To(Succeed())
+
+			r = git.NewReleaser(
+				git.WithCompletedCommitMessage("complete {{.Prompt}}: {{.Title}}"),
+			)
+			Expect(r.CommitCompletedFile(ctx, completedPath, "Fix login")).To(Succeed())
+
+			cmd := exec.Command("git", "log", "--format=%s", "-n", "1")
+			cmd.Dir = tempDir
+			output, err := cmd.Output()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.TrimSpace(string(output))).To(
+				Equal("complete 001-fix-login.md: Fix login"),
+			)
+		})
+
+		It("CommitCompletedFile rejects an invalid commit message template", func() {
+			completedPath := filepath.Join(tempDir, "001-fix-login.md")
+			Expect(os.WriteFile(completedPath, []byte("completed"), 0600)).To(Succeed())
+
+			r = git.NewReleaser(git.WithCompletedCommitMessage("{{.Prompt"))
+			err := r.CommitCompletedFile(ctx, completedPath, "Fix login")
+			Expect(err).To(MatchError(ContainSubstring("render completed commit message")))
+		})
+
 		It("CommitAndRelease performs full workflow", func() {
 			// Create CHANGELOG.md with entry in Unreleased
 			err := os.WriteFile(
@@ -428,6 +456,12 @@ var _ = Describe("Git", func() {
 				Expect(err).To(BeNil())
 				Expect(version).To(Equal("v1.3.0"))
 			})
+
+			It("returns v2.0.0 with MajorBump", func() {
+				version, err := git.GetNextVersion(ctx, git.MajorBump)
+				Expect(err).To(BeNil())
+				Expect(version).To(Equal("v2.0.0"))
+			})
 		})
 
 		Context("with multiple tags", func() {
@@ -1344,7 +1378,7 @@ var _ = Describe("Git", func() {
 			})
 
 			It("stages and commits the file", func() {
-				err := git.CommitCompletedFile(ctx, completedFilePath)
+				err := git.CommitCompletedFile(ctx, completedFilePath, "Test prompt")
 				Expect(err).To(BeNil())
 
 				// Verify commit was created
@@ -1397,7 +1431,7 @@ var _ = Describe("Git", func() {
 				beforeOutput, err := cmd.Output()
 				Expect(err).NotTo(HaveOccurred())
 
-				err = git.CommitCompletedFile(ctx, completedFilePath)
+				err = git.CommitCompletedFile(ctx, completedFilePath, "Test prompt")
 				Expect(err).To(BeNil())
 
 				// Get commit count after
@@ -1447,7 +1481,7 @@ var _ = Describe("Git", func() {
 			})
 
 			It("stages and commits the modification", func() {
-				err := git.CommitCompletedFile(ctx, completedFilePath)
+				err := git.CommitCompletedFile(ctx, completedFilePath, "Test prompt")
 				Expect(err).To(BeNil())
 
 				// Verify commit was created
@@ -1846,6 +1880,80 @@ var _ = Describe("Git", func() {
 			})
 		})
 	})
+
+	Describe("CommitPaths", func() {
+		var (
+			ctx         context.Context
+			tempDir     string
+			originalDir string
+			r           git.Releaser
+		)
+
+		runGit := func(args ...string) string {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = tempDir
+			out, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			var err error
+			originalDir, err = os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			tempDir, err = os.MkdirTemp("", "git-commit-paths-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			runGit("init", "-q")
+			runGit("config", "user.email", "test@example.com")
+			runGit("config", "user.name", "Test User")
+			completedDir := filepath.Join(tempDir, "prompts", "completed")
+			Expect(os.MkdirAll(completedDir, 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(completedDir, "001-old.md"), []byte("old"), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("# Test"), 0600)).
+				To(Succeed())
+			runGit("add", "-A")
+			runGit("commit", "-q", "-m", "initial commit")
+
+			Expect(os.Chdir(tempDir)).To(Succeed())
+			r = git.NewReleaser()
+		})
+
+		AfterEach(func() {
+			Expect(os.Chdir(originalDir)).To(Succeed())
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("commits only the changes below the paths", func() {
+			Expect(os.Remove(filepath.Join(tempDir, "prompts", "completed", "001-old.md"))).
+				To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("# Changed"), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "staged.txt"), []byte("staged"), 0600)).
+				To(Succeed())
+			runGit("add", "staged.txt")
+
+			Expect(r.CommitPaths(ctx, "prune 1 completed prompts", "prompts/completed")).
+				To(Succeed())
+
+			Expect(runGit("log", "-1", "--format=%s")).To(Equal("prune 1 completed prompts"))
+			Expect(runGit("show", "--name-only", "--format=", "HEAD")).
+				To(Equal("prompts/completed/001-old.md"))
+			Expect(runGit("status", "--porcelain")).To(Equal("M README.md\nA  staged.txt"))
+		})
+
+		It("does nothing without changes below the paths", func() {
+			head := runGit("rev-parse", "HEAD")
+
+			Expect(r.CommitPaths(ctx, "prune 0 completed prompts", "prompts/completed")).
+				To(Succeed())
+
+			Expect(runGit("rev-parse", "HEAD")).To(Equal(head))
+		})
+	})
 })
 
 var _ = Describe("CommitWithRetry", func() {
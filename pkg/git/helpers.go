@@ -5,9 +5,13 @@
 package git
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
+	"path/filepath"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/bborbe/errors"
 
@@ -17,14 +21,28 @@ import (
 // Helpers groups git CLI free functions onto a runner-bearing struct so
 // production wiring injects a runner once and tests inject a fake.
 type Helpers struct {
-	runner subproc.Runner
+	runner         subproc.Runner
+	author         Author
+	remote         string
+	pushRetries    int
+	pushRetryDelay time.Duration
+	// changelogSections groups Unreleased bullets into ### subsections on release.
+	changelogSections bool
+	// completedCommitMessage is the text/template of the completed-file commit message.
+	completedCommitMessage string
 }
 
 // NewHelpers wires a Helpers with the default production runner.
-func NewHelpers() *Helpers { return &Helpers{runner: subproc.NewRunner()} }
+func NewHelpers() *Helpers { return NewHelpersWithRunner(subproc.NewRunner()) }
 
 // NewHelpersWithRunner is the test seam.
-func NewHelpersWithRunner(r subproc.Runner) *Helpers { return &Helpers{runner: r} }
+func NewHelpersWithRunner(r subproc.Runner) *Helpers {
+	return &Helpers{
+		runner:         r,
+		pushRetries:    DefaultPushRetries,
+		pushRetryDelay: DefaultPushRetryDelay,
+	}
+}
 
 // HasDirtyFiles returns true if there are any uncommitted changes in the working tree.
 func (h *Helpers) HasDirtyFiles(ctx context.Context) (bool, error) {
@@ -41,8 +59,14 @@ func (h *Helpers) HasDirtyFiles(ctx context.Context) (bool, error) {
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
-// CommitCompletedFile stages and commits a completed prompt file.
-func (h *Helpers) CommitCompletedFile(ctx context.Context, path string) error {
+// CommitCompletedFile stages and commits a completed prompt file. The commit message
+// is the completedCommitMessage template rendered for path and title.
+func (h *Helpers) CommitCompletedFile(ctx context.Context, path string, title string) error {
+	message, err := h.renderCompletedCommitMessage(ctx, path, title)
+	if err != nil {
+		return errors.Wrap(ctx, err, "render completed commit message")
+	}
+
 	// Stage only the specified file
 	addOut, err := h.runner.RunWithWarnAndTimeout(ctx, "git add", "git", "add", path)
 	if err != nil {
@@ -72,9 +96,7 @@ func (h *Helpers) CommitCompletedFile(ctx context.Context, path string) error {
 		ctx,
 		"git commit",
 		"git",
-		"commit",
-		"-m",
-		"move prompt to completed",
+		h.commitArgs(ctx, message)...,
 	)
 	if err != nil {
 		return errors.Wrapf(ctx, err, "git commit: %s", stderrFromErr(err))
@@ -85,6 +107,41 @@ func (h *Helpers) CommitCompletedFile(ctx context.Context, path string) error {
 	return nil
 }
 
+// completedCommitMessageData is the data the completed commit message template is rendered with.
+type completedCommitMessageData struct {
+	Prompt string // prompt filename, e.g. 001-fix-bug.md
+	Title  string // prompt title
+}
+
+// renderCompletedCommitMessage renders completedCommitMessage, or
+// DefaultCompletedCommitMessage when it is empty, for the prompt at path.
+func (h *Helpers) renderCompletedCommitMessage(
+	ctx context.Context,
+	path string,
+	title string,
+) (string, error) {
+	text := h.completedCommitMessage
+	if text == "" {
+		text = DefaultCompletedCommitMessage
+	}
+	tmpl, err := template.New("completedCommitMessage").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "parse template")
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, completedCommitMessageData{
+		Prompt: filepath.Base(path),
+		Title:  title,
+	}); err != nil {
+		return "", errors.Wrap(ctx, err, "execute template")
+	}
+	message := strings.TrimSpace(buf.String())
+	if message == "" {
+		return "", errors.Errorf(ctx, "completed commit message is empty")
+	}
+	return message, nil
+}
+
 // MoveFile moves a file using git mv to preserve history.
 // Falls back to os.Rename if git operations fail or not in a git repo.
 func (h *Helpers) MoveFile(ctx context.Context, oldPath string, newPath string) error {
@@ -96,6 +153,32 @@ func (h *Helpers) MoveFile(ctx context.Context, oldPath string, newPath string)
 	return nil
 }
 
+// commitPaths stages every change below paths and commits only those paths.
+// Without staged changes below paths it does nothing.
+func (h *Helpers) commitPaths(ctx context.Context, message string, paths []string) error {
+	addArgs := append([]string{"add", "-A", "--"}, paths...)
+	if _, err := h.runner.RunWithWarnAndTimeout(ctx, "git add", "git", addArgs...); err != nil {
+		return errors.Wrapf(ctx, err, "git add: %s", stderrFromErr(err))
+	}
+	diffArgs := append([]string{"diff", "--cached", "--name-only", "--"}, paths...)
+	diffOut, err := h.runner.RunWithWarnAndTimeout(ctx, "git diff --cached", "git", diffArgs...)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "git diff: %s", stderrFromErr(err))
+	}
+	if len(strings.TrimSpace(string(diffOut))) == 0 {
+		return nil
+	}
+	commitArgs := append(append(h.commitArgs(ctx, message), "--"), paths...)
+	commitOut, err := h.runner.RunWithWarnAndTimeout(ctx, "git commit", "git", commitArgs...)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "git commit: %s", stderrFromErr(err))
+	}
+	if s := strings.TrimSpace(string(commitOut)); s != "" {
+		slog.Debug("git output", "op", "commit-paths", "output", s)
+	}
+	return nil
+}
+
 // gitAddAll stages all changes.
 func (h *Helpers) gitAddAll(ctx context.Context) error {
 	out, err := h.runner.RunWithWarnAndTimeout(ctx, "git add -A", "git", "add", "-A")
@@ -140,30 +223,10 @@ func (h *Helpers) getNextVersion(ctx context.Context, bump VersionBump) (string,
 		return "", errors.Wrapf(ctx, err, "list git tags: %s", stderrFromErr(err))
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
-	versions := make([]SemanticVersionNumber, 0, len(lines))
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		version, parseErr := ParseSemanticVersionNumber(ctx, line)
-		if parseErr != nil {
-			continue
-		}
-		versions = append(versions, version)
-	}
-
-	var maxTagVersion *SemanticVersionNumber
-	if len(versions) > 0 {
-		mv := versions[0]
-		for _, v := range versions[1:] {
-			if mv.Less(v) {
-				mv = v
-			}
-		}
-		maxTagVersion = &mv
-	}
+	maxTagVersion := MaxSemanticVersionNumber(
+		ctx,
+		strings.Split(strings.TrimSpace(string(out)), "\n"),
+	)
 
 	changelogVersion, _ := latestVersionFromChangelog(ctx)
 
@@ -190,6 +253,8 @@ func (h *Helpers) getNextVersion(ctx context.Context, bump VersionBump) (string,
 
 	var nextVersion SemanticVersionNumber
 	switch bump {
+	case MajorBump:
+		nextVersion = base.BumpMajor()
 	case MinorBump:
 		nextVersion = base.BumpMinor()
 	case PatchBump:
@@ -203,7 +268,12 @@ func (h *Helpers) getNextVersion(ctx context.Context, bump VersionBump) (string,
 // gitCommit creates a commit with the given message.
 func (h *Helpers) gitCommit(ctx context.Context, message string) error {
 	slog.Debug("creating commit", "message", message)
-	out, err := h.runner.RunWithWarnAndTimeout(ctx, "git commit", "git", "commit", "-m", message)
+	out, err := h.runner.RunWithWarnAndTimeout(
+		ctx,
+		"git commit",
+		"git",
+		h.commitArgs(ctx, message)...,
+	)
 	if err != nil {
 		return errors.Wrapf(ctx, err, "create commit: %s", stderrFromErr(err))
 	}
@@ -229,10 +299,30 @@ func (h *Helpers) gitTag(ctx context.Context, tag string) error {
 	return nil
 }
 
-// gitPush pushes commits to remote.
+// pushRemote returns the remote pushes go to, DefaultRemote unless configured.
+func (h *Helpers) pushRemote() string {
+	if h.remote == "" {
+		return DefaultRemote
+	}
+	return h.remote
+}
+
+// gitPush pushes the current branch to the push remote, retrying transient failures.
 func (h *Helpers) gitPush(ctx context.Context) error {
-	slog.Debug("pushing commits to remote")
-	out, err := h.runner.RunWithWarnAndTimeout(ctx, "git push", "git", "push")
+	slog.Debug("pushing commits to remote", "remote", h.pushRemote())
+	var out []byte
+	err := h.retryPush(ctx, "git push", func(ctx context.Context) error {
+		var err error
+		out, err = h.runner.RunWithWarnAndTimeout(
+			ctx,
+			"git push",
+			"git",
+			"push",
+			h.pushRemote(),
+			"HEAD",
+		)
+		return err
+	})
 	if err != nil {
 		return errors.Wrapf(ctx, err, "push to remote: %s", stderrFromErr(err))
 	}
@@ -242,13 +332,25 @@ func (h *Helpers) gitPush(ctx context.Context) error {
 	return nil
 }
 
-// gitPushTag pushes a tag to remote.
+// gitPushTag pushes a tag to remote, retrying transient failures.
 func (h *Helpers) gitPushTag(ctx context.Context, tag string) error {
 	if _, err := ParseSemanticVersionNumber(ctx, tag); err != nil {
 		return errors.Wrap(ctx, err, "invalid tag format")
 	}
-	slog.Debug("pushing tag to remote", "tag", tag)
-	out, err := h.runner.RunWithWarnAndTimeout(ctx, "git push tag", "git", "push", "origin", tag)
+	slog.Debug("pushing tag to remote", "tag", tag, "remote", h.pushRemote())
+	var out []byte
+	err := h.retryPush(ctx, "git push tag", func(ctx context.Context) error {
+		var err error
+		out, err = h.runner.RunWithWarnAndTimeout(
+			ctx,
+			"git push tag",
+			"git",
+			"push",
+			h.pushRemote(),
+			tag,
+		)
+		return err
+	})
 	if err != nil {
 		return errors.Wrapf(ctx, err, "push tag to remote: %s", stderrFromErr(err))
 	}
@@ -258,6 +360,21 @@ func (h *Helpers) gitPushTag(ctx context.Context, tag string) error {
 	return nil
 }
 
+// HeadCommit returns the hash of the commit HEAD points to.
+func (h *Helpers) HeadCommit(ctx context.Context) (string, error) {
+	out, err := h.runner.RunWithWarnAndTimeout(
+		ctx,
+		"git rev-parse HEAD",
+		"git",
+		"rev-parse",
+		"HEAD",
+	)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "resolve HEAD: %s", stderrFromErr(err))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // ResolveGitRoot returns the absolute path to the root of the current git repository.
 func (h *Helpers) ResolveGitRoot(ctx context.Context) (string, error) {
 	out, err := h.runner.RunWithWarnAndTimeout(
@@ -296,7 +413,7 @@ func (h *Helpers) CommitAndRelease(ctx context.Context, bump VersionBump) error
 		return errors.Wrap(ctx, err, "get next version")
 	}
 
-	if err := updateChangelog(ctx, nextVersion); err != nil {
+	if err := updateChangelog(ctx, nextVersion, h.changelogSections); err != nil {
 		return errors.Wrap(ctx, err, "update changelog")
 	}
 
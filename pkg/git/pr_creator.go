@@ -30,16 +30,47 @@ type CommandOutputFn func(cmd *exec.Cmd) ([]byte, error)
 
 // prCreator implements PRCreator via subproc.Runner.
 type prCreator struct {
-	ghToken string
-	runner  subproc.Runner
+	ghToken   string
+	runner    subproc.Runner
+	draft     bool
+	reviewers []string
+	labels    []string
+}
+
+// PRCreatorOption is a functional option for configuring a prCreator.
+type PRCreatorOption func(*prCreator)
+
+// WithDraft opens every pull request as a draft when draft is true.
+func WithDraft(draft bool) PRCreatorOption {
+	return func(p *prCreator) {
+		p.draft = draft
+	}
+}
+
+// WithReviewers requests reviewers on every pull request.
+func WithReviewers(reviewers []string) PRCreatorOption {
+	return func(p *prCreator) {
+		p.reviewers = reviewers
+	}
+}
+
+// WithLabels adds labels to every pull request.
+func WithLabels(labels []string) PRCreatorOption {
+	return func(p *prCreator) {
+		p.labels = labels
+	}
 }
 
 // NewPRCreator creates a new PRCreator.
-func NewPRCreator(ghToken string) PRCreator {
-	return &prCreator{
+func NewPRCreator(ghToken string, opts ...PRCreatorOption) PRCreator {
+	p := &prCreator{
 		ghToken: ghToken,
 		runner:  subproc.NewRunner(),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // NewPRCreatorWithCommandOutput creates a PRCreator. The CommandOutputFn is accepted for
@@ -52,8 +83,16 @@ func NewPRCreatorWithCommandOutput(ghToken string, _ CommandOutputFn) PRCreator
 }
 
 // NewPRCreatorWithRunner creates a PRCreator with an injected runner (for tests).
-func NewPRCreatorWithRunner(ghToken string, r subproc.Runner) PRCreator {
-	return &prCreator{ghToken: ghToken, runner: r}
+func NewPRCreatorWithRunner(
+	ghToken string,
+	r subproc.Runner,
+	opts ...PRCreatorOption,
+) PRCreator {
+	p := &prCreator{ghToken: ghToken, runner: r}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // FindOpenPR returns the URL of an open PR for the given branch, or "" if none exists.
@@ -89,22 +128,52 @@ func (p *prCreator) Create(
 	if err := ValidatePRTitle(ctx, title); err != nil {
 		return "", errors.Wrap(ctx, err, "validate PR title")
 	}
+	if err := ValidatePROptions(ctx, PROptionsFrom(ctx)); err != nil {
+		return "", errors.Wrap(ctx, err, "validate PR options")
+	}
 	var extraEnv []string
 	if p.ghToken != "" {
 		extraEnv = []string{"GH_TOKEN=" + p.ghToken}
 	}
+	args := []string{
+		"pr", "create",
+		"--head", branch,
+		"--title", title,
+		"--body", body,
+	}
+	options := PROptionsFrom(ctx)
+	if p.draft || options.Draft {
+		args = append(args, "--draft")
+	}
+	for _, reviewer := range mergePRNames(p.reviewers, options.Reviewers) {
+		args = append(args, "--reviewer", reviewer)
+	}
+	for _, label := range mergePRNames(p.labels, options.Labels) {
+		args = append(args, "--label", label)
+	}
 	output, err := p.runner.RunWithWarnAndTimeoutEnv(
 		ctx,
 		"gh pr create",
 		"",
 		extraEnv,
-		"gh", "pr", "create",
-		"--head", branch,
-		"--title", title,
-		"--body", body,
+		"gh", args...,
 	)
 	if err != nil {
 		return "", errors.Errorf(ctx, "create pull request: %v: %s", err, stderrFromErr(err))
 	}
 	return strings.TrimSpace(string(output)), nil
 }
+
+// mergePRNames returns defaults followed by the extra names not already present.
+func mergePRNames(defaults []string, extra []string) []string {
+	merged := make([]string, 0, len(defaults)+len(extra))
+	seen := make(map[string]bool, len(defaults)+len(extra))
+	for _, name := range append(append([]string{}, defaults...), extra...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		merged = append(merged, name)
+	}
+	return merged
+}
@@ -54,6 +54,90 @@ var _ = Describe("PRCreator", func() {
 			Expect(url).To(Equal("https://github.com/owner/repo/pull/1"))
 		})
 
+		It("runs gh pr create for the branch", func() {
+			fakeRunner := &mocks.SubprocRunner{}
+			fakeRunner.RunWithWarnAndTimeoutEnvReturns(
+				[]byte("https://github.com/owner/repo/pull/1\n"),
+				nil,
+			)
+			p := git.NewPRCreatorWithRunner("", fakeRunner)
+			_, err := p.Create(ctx, "Test PR", "Test body", "dark-factory/test-branch")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, name, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(name).To(Equal("gh"))
+			Expect(args).To(Equal([]string{
+				"pr", "create",
+				"--head", "dark-factory/test-branch",
+				"--title", "Test PR",
+				"--body", "Test body",
+			}))
+		})
+
+		It("passes --draft when constructed with WithDraft(true)", func() {
+			fakeRunner := &mocks.SubprocRunner{}
+			p := git.NewPRCreatorWithRunner("", fakeRunner, git.WithDraft(true))
+			_, err := p.Create(ctx, "Test PR", "Test body", "dark-factory/test-branch")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, _, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(args).To(ContainElement("--draft"))
+		})
+
+		It("passes --draft when the context requests a draft", func() {
+			fakeRunner := &mocks.SubprocRunner{}
+			p := git.NewPRCreatorWithRunner("", fakeRunner, git.WithDraft(false))
+			draftCtx := git.WithPROptions(ctx, git.PROptions{Draft: true})
+			_, err := p.Create(draftCtx, "Test PR", "Test body", "dark-factory/test-branch")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, _, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(args).To(ContainElement("--draft"))
+		})
+
+		It("omits --draft by default", func() {
+			fakeRunner := &mocks.SubprocRunner{}
+			p := git.NewPRCreatorWithRunner("", fakeRunner)
+			_, err := p.Create(ctx, "Test PR", "Test body", "dark-factory/test-branch")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, _, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(args).NotTo(ContainElement("--draft"))
+		})
+
+		It("merges reviewers and labels of the creator and the context", func() {
+			fakeRunner := &mocks.SubprocRunner{}
+			p := git.NewPRCreatorWithRunner(
+				"",
+				fakeRunner,
+				git.WithReviewers([]string{"alice", "org/team"}),
+				git.WithLabels([]string{"automated"}),
+			)
+			optionsCtx := git.WithPROptions(ctx, git.PROptions{
+				Reviewers: []string{"bob", "alice"},
+				Labels:    []string{"risky"},
+			})
+			_, err := p.Create(optionsCtx, "Test PR", "Test body", "dark-factory/test-branch")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, _, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(args).To(Equal([]string{
+				"pr", "create",
+				"--head", "dark-factory/test-branch",
+				"--title", "Test PR",
+				"--body", "Test body",
+				"--reviewer", "alice",
+				"--reviewer", "org/team",
+				"--reviewer", "bob",
+				"--label", "automated",
+				"--label", "risky",
+			}))
+		})
+
+		It("rejects a label that starts with a dash", func() {
+			fakeRunner := &mocks.SubprocRunner{}
+			p := git.NewPRCreatorWithRunner("", fakeRunner)
+			optionsCtx := git.WithPROptions(ctx, git.PROptions{Labels: []string{"--web"}})
+			_, err := p.Create(optionsCtx, "Test PR", "Test body", "dark-factory/test-branch")
+			Expect(err).To(MatchError(ContainSubstring("validate PR options")))
+			Expect(fakeRunner.RunWithWarnAndTimeoutEnvCallCount()).To(Equal(0))
+		})
+
 		It("returns error when title starts with a dash", func() {
 			p := git.NewPRCreator("")
 			_, err := p.Create(ctx, "--title-injection", "body", "dark-factory/test-branch")
@@ -0,0 +1,31 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import "context"
+
+type prOptionsKey struct{}
+
+// PROptions are per-prompt adjustments to the pull request opened by
+// PRCreator.Create. The zero value leaves the request unchanged.
+type PROptions struct {
+	// Draft opens the pull request as a draft.
+	Draft bool
+	// Reviewers are requested in addition to the PRCreator's reviewers.
+	Reviewers []string
+	// Labels are added in addition to the PRCreator's labels.
+	Labels []string
+}
+
+// WithPROptions returns a context carrying options for the next Create.
+func WithPROptions(ctx context.Context, options PROptions) context.Context {
+	return context.WithValue(ctx, prOptionsKey{}, options)
+}
+
+// PROptionsFrom returns the options bound to ctx, or the zero value.
+func PROptionsFrom(ctx context.Context) PROptions {
+	options, _ := ctx.Value(prOptionsKey{}).(PROptions)
+	return options
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// DefaultPushRetries is how often a push failing with a transient error is retried.
+const DefaultPushRetries = 3
+
+// DefaultPushRetryDelay is the wait before the first push retry; it doubles per retry.
+const DefaultPushRetryDelay = 2 * time.Second
+
+// transientPushErrors are stderr fragments of push failures caused by the network
+// rather than by the remote refusing the push.
+var transientPushErrors = []string{
+	"could not resolve host",
+	"temporary failure in name resolution",
+	"connection timed out",
+	"connection refused",
+	"connection reset",
+	"operation timed out",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"rpc failed",
+	"gnutls",
+	"ssl_",
+}
+
+// permanentPushErrors are stderr fragments of push failures that a retry cannot fix:
+// rejections and authentication failures. They win over transientPushErrors.
+var permanentPushErrors = []string{
+	"[rejected]",
+	"non-fast-forward",
+	"permission denied",
+	"authentication failed",
+	"returned error: 403",
+}
+
+// isTransientPushError reports whether a failed push is worth retrying. Subprocess
+// timeouts and network errors are; rejections such as non-fast-forward and
+// authentication failures are not.
+func isTransientPushError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	stderr := strings.ToLower(stderrFromErr(err))
+	for _, fragment := range permanentPushErrors {
+		if strings.Contains(stderr, fragment) {
+			return false
+		}
+	}
+	for _, fragment := range transientPushErrors {
+		if strings.Contains(stderr, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryPush runs push and retries it up to h.pushRetries times with exponential backoff
+// while it fails with a transient error. The last error is returned unchanged.
+func (h *Helpers) retryPush(
+	ctx context.Context,
+	op string,
+	push func(ctx context.Context) error,
+) error {
+	delay := h.pushRetryDelay
+	for retry := 1; ; retry++ {
+		err := push(ctx)
+		if err == nil || retry > h.pushRetries || !isTransientPushError(err) {
+			return err
+		}
+		slog.Warn(
+			"retrying git push after transient failure",
+			"op", op,
+			"retry", retry,
+			"delay", delay,
+			"error", err,
+		)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// scriptedPushRunner fails the first len(failures) calls with the given stderr and
+// succeeds afterwards. Only RunWithWarnAndTimeout is used by the push helpers.
+type scriptedPushRunner struct {
+	failures []string
+	calls    int
+}
+
+func (s *scriptedPushRunner) RunWithWarnAndTimeout(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ ...string,
+) ([]byte, error) {
+	s.calls++
+	if s.calls > len(s.failures) {
+		return nil, nil
+	}
+	// Run a real process so the error is an *exec.ExitError carrying stderr.
+	// #nosec G204 -- fixed test command
+	_, err := exec.Command("sh", "-c", `echo "$0" >&2; exit 128`, s.failures[s.calls-1]).Output()
+	return nil, err
+}
+
+func (s *scriptedPushRunner) RunWithWarnAndTimeoutDir(
+	ctx context.Context,
+	op string,
+	_ string,
+	name string,
+	args ...string,
+) ([]byte, error) {
+	return s.RunWithWarnAndTimeout(ctx, op, name, args...)
+}
+
+func (s *scriptedPushRunner) RunWithWarnAndTimeoutEnv(
+	ctx context.Context,
+	op string,
+	_ string,
+	_ []string,
+	name string,
+	args ...string,
+) ([]byte, error) {
+	return s.RunWithWarnAndTimeout(ctx, op, name, args...)
+}
+
+var _ = Describe("push retry", func() {
+	const networkError = "fatal: unable to access 'https://example.com/repo.git/': " +
+		"Could not resolve host: example.com"
+	const rejectedError = " ! [rejected]        master -> master (non-fast-forward)"
+
+	var (
+		ctx    context.Context
+		runner *scriptedPushRunner
+		h      *Helpers
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		runner = &scriptedPushRunner{}
+		h = NewHelpersWithRunner(runner)
+		h.pushRetryDelay = 0
+	})
+
+	It("gitPush succeeds after two transient failures", func() {
+		runner.failures = []string{networkError, networkError}
+
+		Expect(h.gitPush(ctx)).To(Succeed())
+		Expect(runner.calls).To(Equal(3))
+	})
+
+	It("gitPushTag succeeds after two transient failures", func() {
+		runner.failures = []string{networkError, "fatal: the remote end hung up unexpectedly"}
+
+		Expect(h.gitPushTag(ctx, "v1.2.3")).To(Succeed())
+		Expect(runner.calls).To(Equal(3))
+	})
+
+	It("does not retry a non-fast-forward rejection", func() {
+		runner.failures = []string{rejectedError}
+
+		err := h.gitPush(ctx)
+		Expect(err).To(MatchError(ContainSubstring("push to remote")))
+		Expect(err).To(MatchError(ContainSubstring("non-fast-forward")))
+		Expect(runner.calls).To(Equal(1))
+	})
+
+	DescribeTable("does not retry an authentication failure",
+		func(stderr string) {
+			runner.failures = []string{stderr}
+
+			Expect(h.gitPush(ctx)).NotTo(Succeed())
+			Expect(runner.calls).To(Equal(1))
+		},
+		Entry("ssh publickey", "git@example.com: Permission denied (publickey).\n"+
+			"fatal: Could not read from remote repository."),
+		Entry("https 403", "remote: Permission to owner/repo.git denied.\n"+
+			"fatal: unable to access 'https://example.com/repo.git/': "+
+			"The requested URL returned error: 403"),
+		Entry("https credentials", "fatal: Authentication failed for 'https://example.com/repo.git/'"),
+	)
+
+	It("returns the last error once the retries are used up", func() {
+		runner.failures = []string{networkError, networkError, networkError, networkError}
+
+		err := h.gitPush(ctx)
+		Expect(err).To(MatchError(ContainSubstring("push to remote")))
+		Expect(err).To(MatchError(ContainSubstring("Could not resolve host")))
+		Expect(runner.calls).To(Equal(1 + DefaultPushRetries))
+	})
+
+	It("does not retry when retries are disabled", func() {
+		h.pushRetries = 0
+		runner.failures = []string{networkError}
+
+		Expect(h.gitPush(ctx)).NotTo(Succeed())
+		Expect(runner.calls).To(Equal(1))
+	})
+
+	It("treats a subprocess timeout as transient", func() {
+		Expect(isTransientPushError(context.DeadlineExceeded)).To(BeTrue())
+	})
+})
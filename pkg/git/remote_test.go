@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+)
+
+var _ = Describe("Push remote", func() {
+	var (
+		ctx         context.Context
+		tempDir     string
+		originDir   string
+		upstreamDir string
+		originalDir string
+	)
+
+	runGit := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		originalDir, err = os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+
+		tempDir = GinkgoT().TempDir()
+		originDir = GinkgoT().TempDir()
+		upstreamDir = GinkgoT().TempDir()
+		runGit(originDir, "init", "--bare")
+		runGit(upstreamDir, "init", "--bare")
+		runGit(tempDir, "init")
+		runGit(tempDir, "config", "user.name", "Test User")
+		runGit(tempDir, "config", "user.email", "test@example.com")
+		runGit(tempDir, "remote", "add", "origin", originDir)
+		runGit(tempDir, "remote", "add", "upstream", upstreamDir)
+		Expect(os.WriteFile(
+			filepath.Join(tempDir, "CHANGELOG.md"),
+			[]byte("# Changelog\n\n## v0.1.0\n\n- Initial\n"),
+			0600,
+		)).To(Succeed())
+		runGit(tempDir, "add", "-A")
+		runGit(tempDir, "commit", "-m", "initial")
+		runGit(tempDir, "push", "origin", "HEAD")
+		runGit(tempDir, "push", "upstream", "HEAD")
+		Expect(os.Chdir(tempDir)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Chdir(originalDir)).To(Succeed())
+	})
+
+	It("CommitAndRelease pushes commit and tag to the configured remote", func() {
+		Expect(os.WriteFile(
+			filepath.Join(tempDir, "CHANGELOG.md"),
+			[]byte("# Changelog\n\n## Unreleased\n\n- Fix\n\n## v0.1.0\n\n- Initial\n"),
+			0600,
+		)).To(Succeed())
+		r := git.NewReleaser(git.WithReleaserRemote("upstream"))
+
+		Expect(r.CommitAndRelease(ctx, git.PatchBump)).To(Succeed())
+		Expect(runGit(upstreamDir, "tag", "--list")).To(Equal("v0.1.1"))
+		Expect(runGit(upstreamDir, "log", "-1", "--format=%s")).To(Equal("release v0.1.1"))
+		Expect(runGit(originDir, "tag", "--list")).To(BeEmpty())
+		Expect(runGit(originDir, "log", "-1", "--format=%s")).To(Equal("initial"))
+	})
+
+	It("Brancher.Push pushes the branch to the configured remote", func() {
+		b := git.NewBrancher(git.WithBrancherRemote("upstream"))
+		Expect(b.CreateAndSwitch(ctx, "dark-factory/feature")).To(Succeed())
+
+		Expect(b.Push(ctx, "dark-factory/feature")).To(Succeed())
+		Expect(runGit(upstreamDir, "branch", "--list", "dark-factory/feature")).
+			To(ContainSubstring("dark-factory/feature"))
+		Expect(runGit(originDir, "branch", "--list", "dark-factory/feature")).To(BeEmpty())
+	})
+
+	It("Brancher.Push pushes to origin by default", func() {
+		b := git.NewBrancher()
+		Expect(b.CreateAndSwitch(ctx, "dark-factory/feature")).To(Succeed())
+
+		Expect(b.Push(ctx, "dark-factory/feature")).To(Succeed())
+		Expect(runGit(originDir, "branch", "--list", "dark-factory/feature")).
+			To(ContainSubstring("dark-factory/feature"))
+		Expect(runGit(upstreamDir, "branch", "--list", "dark-factory/feature")).To(BeEmpty())
+	})
+
+	It("Cloner adds the configured remote to the clone", func() {
+		cloneDir := filepath.Join(GinkgoT().TempDir(), "clone")
+		c := git.NewCloner(git.WithClonerRemote("upstream"))
+
+		Expect(c.Clone(ctx, tempDir, cloneDir, "dark-factory/feature")).To(Succeed())
+		Expect(runGit(cloneDir, "remote", "get-url", "origin")).To(Equal(originDir))
+		Expect(runGit(cloneDir, "remote", "get-url", "upstream")).To(Equal(upstreamDir))
+	})
+})
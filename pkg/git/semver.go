@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/bborbe/errors"
 )
@@ -41,6 +42,23 @@ func ParseSemanticVersionNumber(ctx context.Context, tag string) (SemanticVersio
 	}, nil
 }
 
+// MaxSemanticVersionNumber returns the numerically greatest "vX.Y.Z" tag in tags,
+// comparing major, minor and patch as numbers (v0.10.0 > v0.9.0). Tags that are
+// not plain "vX.Y.Z" are skipped. Returns nil if no tag parses.
+func MaxSemanticVersionNumber(ctx context.Context, tags []string) *SemanticVersionNumber {
+	var maxVersion *SemanticVersionNumber
+	for _, tag := range tags {
+		version, err := ParseSemanticVersionNumber(ctx, strings.TrimSpace(tag))
+		if err != nil {
+			continue
+		}
+		if maxVersion == nil || maxVersion.Less(version) {
+			maxVersion = &version
+		}
+	}
+	return maxVersion
+}
+
 // String returns the "vX.Y.Z" representation.
 func (v SemanticVersionNumber) String() string {
 	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
@@ -64,6 +82,15 @@ func (v SemanticVersionNumber) BumpMinor() SemanticVersionNumber {
 	}
 }
 
+// BumpMajor returns a new version with major incremented and minor and patch reset to 0.
+func (v SemanticVersionNumber) BumpMajor() SemanticVersionNumber {
+	return SemanticVersionNumber{
+		Major: v.Major + 1,
+		Minor: 0,
+		Patch: 0,
+	}
+}
+
 // Less returns true if v is lower than other.
 func (v SemanticVersionNumber) Less(other SemanticVersionNumber) bool {
 	if v.Major != other.Major {
@@ -138,6 +138,20 @@ var _ = Describe("SemanticVersionNumber", func() {
 		})
 	})
 
+	Describe("BumpMajor", func() {
+		It("bumps v1.2.3 to v2.0.0", func() {
+			version := git.SemanticVersionNumber{Major: 1, Minor: 2, Patch: 3}
+			bumped := version.BumpMajor()
+			Expect(bumped.String()).To(Equal("v2.0.0"))
+		})
+
+		It("bumps v0.9.9 to v1.0.0", func() {
+			version := git.SemanticVersionNumber{Major: 0, Minor: 9, Patch: 9}
+			bumped := version.BumpMajor()
+			Expect(bumped.String()).To(Equal("v1.0.0"))
+		})
+	})
+
 	Describe("Less", func() {
 		Context("comparing major versions", func() {
 			It("returns true when v0.2.25 < v1.0.0", func() {
@@ -210,4 +224,21 @@ var _ = Describe("SemanticVersionNumber", func() {
 			})
 		})
 	})
+
+	Describe("MaxSemanticVersionNumber", func() {
+		It("selects v0.10.0 over lexicographically greater tags", func() {
+			tags := []string{"v0.9.0", "v0.10.0", "v0.2.25", "v0.1.9"}
+			Expect(git.MaxSemanticVersionNumber(ctx, tags).String()).To(Equal("v0.10.0"))
+		})
+
+		It("skips tags that are not vX.Y.Z", func() {
+			tags := []string{"v0.2.25", "v9.9.9-rc1", "latest", "v10", " v0.3.0 ", ""}
+			Expect(git.MaxSemanticVersionNumber(ctx, tags).String()).To(Equal("v0.3.0"))
+		})
+
+		It("returns nil without a parseable tag", func() {
+			Expect(git.MaxSemanticVersionNumber(ctx, []string{"latest", ""})).To(BeNil())
+			Expect(git.MaxSemanticVersionNumber(ctx, nil)).To(BeNil())
+		})
+	})
 })
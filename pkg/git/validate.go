@@ -40,6 +40,21 @@ func ValidatePRURL(ctx context.Context, prURL string) error {
 	return nil
 }
 
+// ValidatePROptions returns an error if a reviewer or label is empty or starts
+// with a dash, which could be interpreted as a flag by the gh CLI.
+func ValidatePROptions(ctx context.Context, options PROptions) error {
+	for _, name := range append(append([]string{}, options.Reviewers...), options.Labels...) {
+		if name == "" || name[0] == '-' {
+			return errors.Errorf(
+				ctx,
+				"invalid reviewer or label %q: must not be empty or start with a dash",
+				name,
+			)
+		}
+	}
+	return nil
+}
+
 // ValidatePRTitle returns an error if the PR title is empty or starts with a dash,
 // which could be interpreted as a flag by the gh CLI.
 func ValidatePRTitle(ctx context.Context, title string) error {
@@ -74,6 +74,28 @@ var _ = Describe("ValidatePRURL", func() {
 	)
 })
 
+var _ = Describe("ValidatePROptions", func() {
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	DescribeTable("validates reviewers and labels",
+		func(options git.PROptions, valid bool) {
+			err := git.ValidatePROptions(ctx, options)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty options", git.PROptions{}, true),
+		Entry("user and team", git.PROptions{Reviewers: []string{"alice", "org/team"}}, true),
+		Entry("label with space", git.PROptions{Labels: []string{"needs review"}}, true),
+		Entry("empty reviewer", git.PROptions{Reviewers: []string{""}}, false),
+		Entry("label starting with dash", git.PROptions{Labels: []string{"-x"}}, false),
+	)
+})
+
 var _ = Describe("ValidatePRTitle", func() {
 	var ctx context.Context
 	BeforeEach(func() {
@@ -7,6 +7,9 @@ package git
 import (
 	"context"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/bborbe/errors"
 
@@ -21,6 +24,14 @@ type Worktreer interface {
 	Add(ctx context.Context, worktreePath string, branch string) error
 	// Remove removes the linked worktree at worktreePath.
 	Remove(ctx context.Context, worktreePath string) error
+	// AddDetached creates a linked worktree at worktreePath on a detached HEAD,
+	// without creating a branch. It returns the directory of the worktree that
+	// corresponds to the current directory.
+	AddDetached(ctx context.Context, worktreePath string) (string, error)
+	// ApplyChanges stages every change in the worktree at worktreePath and applies
+	// them to the index and working tree of the current directory. Changes that
+	// do not apply cleanly leave the current directory untouched.
+	ApplyChanges(ctx context.Context, worktreePath string) error
 }
 
 // NewWorktreer creates a new Worktreer.
@@ -28,6 +39,12 @@ func NewWorktreer() Worktreer {
 	return &worktreer{runner: subproc.NewRunner()}
 }
 
+// PromptTreePath returns the path of the detached worktree a prompt runs in when
+// prompts get their own tree. name is the prompt's execution ID.
+func PromptTreePath(name string) string {
+	return filepath.Join(os.TempDir(), "dark-factory", "trees", name)
+}
+
 // newWorktreerWithRunner creates a Worktreer with an injected runner (for tests).
 func newWorktreerWithRunner(r subproc.Runner) *worktreer {
 	return &worktreer{runner: r}
@@ -117,3 +134,98 @@ func (w *worktreer) Remove(ctx context.Context, worktreePath string) error {
 	}
 	return nil
 }
+
+// AddDetached creates a linked worktree at worktreePath on a detached HEAD and
+// returns worktreePath joined with the path of the current directory inside the repo.
+func (w *worktreer) AddDetached(ctx context.Context, worktreePath string) (string, error) {
+	slog.Debug("adding detached worktree", "path", worktreePath)
+	prefix, err := w.runner.RunWithWarnAndTimeout(
+		ctx, "git rev-parse --show-prefix", "git", "rev-parse", "--show-prefix",
+	)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "git rev-parse --show-prefix: %s", stderrFromErr(err))
+	}
+	_, err = w.runner.RunWithWarnAndTimeout(
+		ctx,
+		"git worktree add --detach",
+		"git",
+		"worktree",
+		"add",
+		"--detach",
+		worktreePath,
+		"HEAD",
+	)
+	if err != nil {
+		return "", errors.Wrapf(
+			ctx,
+			err,
+			"git worktree add --detach (path=%s): %s",
+			worktreePath,
+			stderrFromErr(err),
+		)
+	}
+	return filepath.Join(worktreePath, strings.TrimSpace(string(prefix))), nil
+}
+
+// ApplyChanges stages the changes in worktreePath and applies their binary diff
+// against the worktree HEAD with git apply --index at the top level of the repo
+// of the current directory, so changes outside the current directory apply too.
+func (w *worktreer) ApplyChanges(ctx context.Context, worktreePath string) error {
+	if _, err := w.runner.RunWithWarnAndTimeoutDir(
+		ctx, "git add -A", worktreePath, "git", "add", "-A",
+	); err != nil {
+		return errors.Wrapf(ctx, err, "stage worktree changes: %s", stderrFromErr(err))
+	}
+	patch, err := w.runner.RunWithWarnAndTimeoutDir(
+		ctx,
+		"git diff --cached --binary",
+		worktreePath,
+		"git",
+		"diff",
+		"--cached",
+		"--binary",
+		"HEAD",
+	)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "diff worktree changes: %s", stderrFromErr(err))
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	patchFile, err := os.CreateTemp("", "dark-factory-tree-*.patch")
+	if err != nil {
+		return errors.Wrap(ctx, err, "create patch file")
+	}
+	defer func() { _ = os.Remove(patchFile.Name()) }()
+	if _, err := patchFile.Write(patch); err != nil {
+		_ = patchFile.Close()
+		return errors.Wrap(ctx, err, "write patch file")
+	}
+	if err := patchFile.Close(); err != nil {
+		return errors.Wrap(ctx, err, "close patch file")
+	}
+	topLevel, err := w.runner.RunWithWarnAndTimeout(
+		ctx, "git rev-parse --show-toplevel", "git", "rev-parse", "--show-toplevel",
+	)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "git rev-parse --show-toplevel: %s", stderrFromErr(err))
+	}
+	if _, err := w.runner.RunWithWarnAndTimeoutDir(
+		ctx,
+		"git apply --index",
+		strings.TrimSpace(string(topLevel)),
+		"git",
+		"apply",
+		"--index",
+		patchFile.Name(),
+	); err != nil {
+		return errors.Wrapf(
+			ctx,
+			err,
+			"apply worktree changes (path=%s): %s",
+			worktreePath,
+			stderrFromErr(err),
+		)
+	}
+	return nil
+}
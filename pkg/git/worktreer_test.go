@@ -7,6 +7,9 @@ package git_test
 import (
 	"context"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -56,4 +59,91 @@ var _ = Describe("Worktreer", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("AddDetached and ApplyChanges", func() {
+		var (
+			repoDir     string
+			treePath    string
+			originalDir string
+			worktreer   git.Worktreer
+		)
+
+		runGit := func(dir string, args ...string) string {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			out, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		writeFile := func(dir, name, content string) {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			repoDir = GinkgoT().TempDir()
+			treePath = filepath.Join(GinkgoT().TempDir(), "tree")
+			runGit(repoDir, "init")
+			runGit(repoDir, "config", "user.email", "test@example.com")
+			runGit(repoDir, "config", "user.name", "Test")
+			writeFile(repoDir, "a.txt", "a\n")
+			writeFile(repoDir, "b.txt", "b\n")
+			runGit(repoDir, "add", "-A")
+			runGit(repoDir, "commit", "-m", "initial")
+
+			var err error
+			originalDir, err = os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.Chdir(repoDir)).To(Succeed())
+			DeferCleanup(func() { _ = os.Chdir(originalDir) })
+			worktreer = git.NewWorktreer()
+		})
+
+		It("applies the worktree changes on top of a moved HEAD", func() {
+			dir, err := worktreer.AddDetached(ctx, treePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dir).To(Equal(treePath))
+			writeFile(treePath, "a.txt", "a changed\n")
+			writeFile(treePath, "c.txt", "c\n")
+
+			writeFile(repoDir, "b.txt", "b changed\n")
+			runGit(repoDir, "commit", "-am", "change b")
+
+			Expect(worktreer.ApplyChanges(ctx, treePath)).To(Succeed())
+			Expect(runGit(repoDir, "diff", "--cached", "--name-only")).To(Equal("a.txt\nc.txt"))
+			content, err := os.ReadFile(filepath.Join(repoDir, "c.txt"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("c\n"))
+		})
+
+		It("leaves the current tree untouched when the changes conflict", func() {
+			_, err := worktreer.AddDetached(ctx, treePath)
+			Expect(err).NotTo(HaveOccurred())
+			writeFile(treePath, "a.txt", "a from tree\n")
+			writeFile(treePath, "c.txt", "c\n")
+
+			writeFile(repoDir, "a.txt", "a from head\n")
+			runGit(repoDir, "commit", "-am", "change a")
+
+			err = worktreer.ApplyChanges(ctx, treePath)
+			Expect(err).To(MatchError(ContainSubstring("apply worktree changes")))
+			Expect(runGit(repoDir, "status", "--porcelain")).To(BeEmpty())
+		})
+
+		It("returns the worktree directory matching a subdirectory", func() {
+			Expect(os.Mkdir(filepath.Join(repoDir, "sub"), 0750)).To(Succeed())
+			writeFile(filepath.Join(repoDir, "sub"), "d.txt", "d\n")
+			runGit(repoDir, "add", "-A")
+			runGit(repoDir, "commit", "-m", "add sub")
+			Expect(os.Chdir(filepath.Join(repoDir, "sub"))).To(Succeed())
+
+			dir, err := worktreer.AddDetached(ctx, treePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dir).To(Equal(filepath.Join(treePath, "sub")))
+			writeFile(treePath, "a.txt", "a changed\n")
+
+			Expect(worktreer.ApplyChanges(ctx, treePath)).To(Succeed())
+			Expect(runGit(repoDir, "diff", "--cached", "--name-only")).To(Equal("a.txt"))
+		})
+	})
 })
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/subproc"
+)
+
+// prCreator implements git.PRCreator for GitLab merge requests via the glab CLI.
+type prCreator struct {
+	defaultBranch string
+	runner        subproc.Runner
+}
+
+// NewPRCreator creates a git.PRCreator that opens GitLab merge requests with glab.
+// defaultBranch is the merge request target; empty lets glab pick the project default.
+func NewPRCreator(defaultBranch string) git.PRCreator {
+	return NewPRCreatorWithRunner(defaultBranch, subproc.NewRunner())
+}
+
+// NewPRCreatorWithRunner creates a GitLab git.PRCreator with an injected runner (for tests).
+func NewPRCreatorWithRunner(defaultBranch string, r subproc.Runner) git.PRCreator {
+	return &prCreator{
+		defaultBranch: defaultBranch,
+		runner:        r,
+	}
+}
+
+// glabMR is the subset of a merge request in `glab mr list --output json`.
+type glabMR struct {
+	WebURL string `json:"web_url"`
+}
+
+// FindOpenPR returns the URL of an open merge request for branch, or "" if none exists.
+func (p *prCreator) FindOpenPR(ctx context.Context, branch string) (string, error) {
+	output, err := p.runner.RunWithWarnAndTimeoutEnv(
+		ctx,
+		"glab mr list",
+		"",
+		nil,
+		"glab", "mr", "list",
+		"--source-branch", branch,
+		"--output", "json",
+	)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "list open merge requests")
+	}
+	var mrs []glabMR
+	if err := json.Unmarshal(output, &mrs); err != nil {
+		return "", errors.Wrap(ctx, err, "parse merge request list json")
+	}
+	if len(mrs) == 0 {
+		return "", nil
+	}
+	return mrs[0].WebURL, nil
+}
+
+// Create opens a merge request from branch and returns its URL.
+func (p *prCreator) Create(
+	ctx context.Context,
+	title string,
+	body string,
+	branch string,
+) (string, error) {
+	if err := git.ValidatePRTitle(ctx, title); err != nil {
+		return "", errors.Wrap(ctx, err, "validate PR title")
+	}
+	args := []string{
+		"mr", "create",
+		"--source-branch", branch,
+		"--title", title,
+		"--description", body,
+		"--yes",
+	}
+	if p.defaultBranch != "" {
+		args = append(args, "--target-branch", p.defaultBranch)
+	}
+	output, err := p.runner.RunWithWarnAndTimeoutEnv(ctx, "glab mr create", "", nil, "glab", args...)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "create merge request")
+	}
+	url := mergeRequestURL(string(output))
+	if url == "" {
+		return "", errors.Errorf(ctx, "no merge request URL in glab output: %s", output)
+	}
+	return url, nil
+}
+
+// mergeRequestURL returns the last line of glab output that is a URL.
+// glab prints progress lines before the URL of the created merge request.
+func mergeRequestURL(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "https://") || strings.HasPrefix(line, "http://") {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab_test
+
+import (
+	"context"
+	stderrors "errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/gitprovider/gitlab"
+)
+
+var _ = Describe("PRCreator", func() {
+	var (
+		ctx        context.Context
+		fakeRunner *mocks.SubprocRunner
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeRunner = &mocks.SubprocRunner{}
+	})
+
+	Describe("Create", func() {
+		It("runs glab mr create and returns the merge request URL", func() {
+			fakeRunner.RunWithWarnAndTimeoutEnvReturns([]byte(
+				"\nCreating merge request for dark-factory/x into main in group/repo\n\n"+
+					"!12 Fix X (dark-factory/x)\n"+
+					" https://gitlab.com/group/repo/-/merge_requests/12\n",
+			), nil)
+			p := gitlab.NewPRCreatorWithRunner("main", fakeRunner)
+
+			url, err := p.Create(ctx, "Fix X", "Body", "dark-factory/x")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(url).To(Equal("https://gitlab.com/group/repo/-/merge_requests/12"))
+
+			Expect(fakeRunner.RunWithWarnAndTimeoutEnvCallCount()).To(Equal(1))
+			_, _, _, _, name, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(name).To(Equal("glab"))
+			Expect(args).To(Equal([]string{
+				"mr", "create",
+				"--source-branch", "dark-factory/x",
+				"--title", "Fix X",
+				"--description", "Body",
+				"--yes",
+				"--target-branch", "main",
+			}))
+		})
+
+		It("leaves the target branch to glab without a default branch", func() {
+			fakeRunner.RunWithWarnAndTimeoutEnvReturns(
+				[]byte("https://gitlab.com/group/repo/-/merge_requests/3\n"),
+				nil,
+			)
+			p := gitlab.NewPRCreatorWithRunner("", fakeRunner)
+
+			_, err := p.Create(ctx, "Fix X", "Body", "dark-factory/x")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, _, _, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(args).NotTo(ContainElement("--target-branch"))
+		})
+
+		It("returns an error when glab fails", func() {
+			fakeRunner.RunWithWarnAndTimeoutEnvReturns(nil, stderrors.New("exit status 1"))
+			p := gitlab.NewPRCreatorWithRunner("main", fakeRunner)
+
+			_, err := p.Create(ctx, "Fix X", "Body", "dark-factory/x")
+			Expect(err).To(MatchError(ContainSubstring("create merge request")))
+		})
+
+		It("returns an error when glab prints no URL", func() {
+			fakeRunner.RunWithWarnAndTimeoutEnvReturns([]byte("something went sideways\n"), nil)
+			p := gitlab.NewPRCreatorWithRunner("main", fakeRunner)
+
+			_, err := p.Create(ctx, "Fix X", "Body", "dark-factory/x")
+			Expect(err).To(MatchError(ContainSubstring("no merge request URL")))
+		})
+
+		It("rejects a title starting with a dash without running glab", func() {
+			p := gitlab.NewPRCreatorWithRunner("main", fakeRunner)
+
+			_, err := p.Create(ctx, "--web", "Body", "dark-factory/x")
+			Expect(err).To(MatchError(ContainSubstring("invalid PR title")))
+			Expect(fakeRunner.RunWithWarnAndTimeoutEnvCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("FindOpenPR", func() {
+		It("returns the URL of the first open merge request of the branch", func() {
+			fakeRunner.RunWithWarnAndTimeoutEnvReturns([]byte(
+				`[{"iid":12,"web_url":"https://gitlab.com/group/repo/-/merge_requests/12"}]`,
+			), nil)
+			p := gitlab.NewPRCreatorWithRunner("main", fakeRunner)
+
+			url, err := p.FindOpenPR(ctx, "dark-factory/x")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(url).To(Equal("https://gitlab.com/group/repo/-/merge_requests/12"))
+			_, _, _, _, name, args := fakeRunner.RunWithWarnAndTimeoutEnvArgsForCall(0)
+			Expect(name).To(Equal("glab"))
+			Expect(args).To(Equal([]string{
+				"mr", "list", "--source-branch", "dark-factory/x", "--output", "json",
+			}))
+		})
+
+		It("returns empty when the branch has no open merge request", func() {
+			fakeRunner.RunWithWarnAndTimeoutEnvReturns([]byte("[]\n"), nil)
+			p := gitlab.NewPRCreatorWithRunner("main", fakeRunner)
+
+			url, err := p.FindOpenPR(ctx, "dark-factory/x")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(url).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("PRMerger", func() {
+	It("refuses to merge", func() {
+		err := gitlab.NewPRMerger().WaitAndMerge(
+			context.Background(),
+			"https://gitlab.com/group/repo/-/merge_requests/12",
+		)
+		Expect(err).To(MatchError(ContainSubstring("not supported with provider: gitlab")))
+	})
+})
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+)
+
+// prMerger implements git.PRMerger for GitLab, which does not support auto-merge yet.
+type prMerger struct{}
+
+// NewPRMerger creates a git.PRMerger that refuses to merge GitLab merge requests.
+// Config validation rejects autoMerge with provider: gitlab, so it is only
+// reached when validation was bypassed.
+func NewPRMerger() git.PRMerger {
+	return &prMerger{}
+}
+
+// WaitAndMerge returns an error; merge requests must be merged in GitLab.
+func (p *prMerger) WaitAndMerge(ctx context.Context, prURL string) error {
+	return errors.Errorf(
+		ctx,
+		"autoMerge is not supported with provider: gitlab, merge %s in GitLab",
+		prURL,
+	)
+}
@@ -7,11 +7,13 @@ package lock
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/bborbe/errors"
 )
@@ -31,46 +33,96 @@ type Locker interface {
 	Release(ctx context.Context) error
 }
 
+// LockerOption configures a Locker.
+type LockerOption func(*locker)
+
+// WithForce makes Acquire take over a lock held by a live instance instead of failing.
+func WithForce(force bool) LockerOption {
+	return func(l *locker) {
+		l.force = force
+	}
+}
+
 // NewLocker creates a new Locker for the specified directory.
-func NewLocker(dir string) Locker {
-	return &locker{
+func NewLocker(dir string, opts ...LockerOption) Locker {
+	l := &locker{
 		lockPath: filepath.Join(dir, lockFileName),
 	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
 }
 
 // locker implements file-based locking using flock.
 type locker struct {
 	lockPath string
+	force    bool
 	fd       *os.File
 }
 
+// Info is the content of a lock file: the PID of the holding process and when it
+// acquired the lock.
+type Info struct {
+	PID int
+	// StartedAt is zero for lock files that only record a PID.
+	StartedAt time.Time
+}
+
+// ReadInfo reads the lock file at path. The first line holds the PID, the
+// optional second line the RFC3339 start time.
+func ReadInfo(path string) (Info, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the lock file path
+	if err != nil {
+		return Info{}, err
+	}
+	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 2)
+	pid, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil {
+		return Info{}, err
+	}
+	info := Info{PID: pid}
+	if len(lines) == 2 {
+		if startedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(lines[1])); err == nil {
+			info.StartedAt = startedAt
+		}
+	}
+	return info, nil
+}
+
 // Acquire attempts to acquire an exclusive lock on the lock file.
-// Returns an error if another instance is already running.
+// A lock whose recorded PID is no longer alive is stale and reclaimed; a lock held
+// by a live instance is only taken over with WithForce. Otherwise it returns an
+// error naming the running instance.
 func (l *locker) Acquire(ctx context.Context) error {
-	// Create the lock file (or open if exists)
-	fd, err := os.OpenFile(l.lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	fd, err := l.tryLock(ctx)
 	if err != nil {
-		return errors.Wrap(ctx, err, "open lock file")
+		return err
 	}
-
-	// Try to acquire exclusive lock (non-blocking)
-	if err := syscall.Flock( //nolint:gosec // G115: File descriptor conversion is safe
-		int(fd.Fd()),
-		syscall.LOCK_EX|syscall.LOCK_NB,
-	); err != nil {
-		// Lock failed - another instance is running
-		_ = fd.Close()
-
-		// Try to read the PID from the lock file
-		pid, readErr := l.readPID()
-		if readErr == nil && pid > 0 {
-			return errors.Errorf(ctx, "another instance is already running (pid %d)", pid)
+	if fd == nil {
+		info, readErr := ReadInfo(l.lockPath)
+		switch {
+		case l.force:
+			slog.Warn("taking over lock held by another instance (--force)", "pid", info.PID)
+		case readErr == nil && info.PID > 0 && !isProcessAlive(info.PID):
+			slog.Warn("reclaiming stale lock of dead process", "pid", info.PID)
+		case readErr == nil && info.PID > 0:
+			return errors.Errorf(
+				ctx,
+				"another instance is already running (pid %d%s), use --force to take over",
+				info.PID,
+				formatStartedAt(info.StartedAt),
+			)
+		default:
+			return errors.Errorf(ctx, "another instance is already running")
+		}
+		if fd, err = l.takeOver(ctx); err != nil {
+			return err
 		}
-		return errors.Errorf(ctx, "another instance is already running")
 	}
 
-	// Write our PID to the lock file
-	if err := l.writePID(ctx, fd); err != nil {
+	// Write our PID and start time to the lock file
+	if err := l.writeInfo(ctx, fd); err != nil {
 		_ = fd.Close()
 		return errors.Wrap(ctx, err, "write pid to lock file")
 	}
@@ -80,6 +132,39 @@ func (l *locker) Acquire(ctx context.Context) error {
 	return nil
 }
 
+// tryLock opens (or creates) the lock file and flocks it without blocking.
+// It returns a nil fd when another process holds the flock.
+func (l *locker) tryLock(ctx context.Context) (*os.File, error) {
+	fd, err := os.OpenFile(l.lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "open lock file")
+	}
+	if err := syscall.Flock( //nolint:gosec // G115: File descriptor conversion is safe
+		int(fd.Fd()),
+		syscall.LOCK_EX|syscall.LOCK_NB,
+	); err != nil {
+		_ = fd.Close()
+		return nil, nil
+	}
+	return fd, nil
+}
+
+// takeOver replaces the held lock file with a new one and locks it. The previous
+// holder keeps its flock on the unlinked file and no longer blocks anyone.
+func (l *locker) takeOver(ctx context.Context) (*os.File, error) {
+	if err := os.Remove(l.lockPath); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(ctx, err, "remove lock file")
+	}
+	fd, err := l.tryLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if fd == nil {
+		return nil, errors.Errorf(ctx, "another instance took the lock concurrently")
+	}
+	return fd, nil
+}
+
 // Release releases the lock and removes the lock file.
 func (l *locker) Release(ctx context.Context) error {
 	if l.fd == nil {
@@ -94,6 +179,9 @@ func (l *locker) Release(ctx context.Context) error {
 		return errors.Wrap(ctx, err, "unlock file")
 	}
 
+	// A lock taken over by another instance is no longer ours to remove
+	owned := l.ownsLockFile()
+
 	// Close the file descriptor
 	if err := l.fd.Close(); err != nil {
 		return errors.Wrap(ctx, err, "close lock file")
@@ -101,6 +189,9 @@ func (l *locker) Release(ctx context.Context) error {
 	l.fd = nil
 
 	// Remove the lock file
+	if !owned {
+		return nil
+	}
 	if err := os.Remove(l.lockPath); err != nil && !os.IsNotExist(err) {
 		return errors.Wrap(ctx, err, "remove lock file")
 	}
@@ -108,31 +199,43 @@ func (l *locker) Release(ctx context.Context) error {
 	return nil
 }
 
-// readPID reads the PID from the lock file.
-func (l *locker) readPID() (int, error) {
-	data, err := os.ReadFile(l.lockPath)
+// ownsLockFile reports whether the file at lockPath is still the one l.fd locks.
+func (l *locker) ownsLockFile() bool {
+	held, err := l.fd.Stat()
 	if err != nil {
-		return 0, err
+		return false
 	}
-
-	pidStr := strings.TrimSpace(string(data))
-	pid, err := strconv.Atoi(pidStr)
+	current, err := os.Stat(l.lockPath)
 	if err != nil {
-		return 0, err
+		return false
 	}
-
-	return pid, nil
+	return os.SameFile(held, current)
 }
 
-// writePID writes the current process PID to the file.
-func (l *locker) writePID(ctx context.Context, fd *os.File) error {
+// writeInfo writes the current process PID and start time to the file.
+func (l *locker) writeInfo(ctx context.Context, fd *os.File) error {
 	pid := os.Getpid()
 	// Truncate and seek to start (errors ignored as they should not fail on regular files)
 	_ = fd.Truncate(0)
 	_, _ = fd.Seek(0, 0)
-	_, err := fmt.Fprintf(fd, "%d\n", pid)
+	_, err := fmt.Fprintf(fd, "%d\n%s\n", pid, time.Now().UTC().Format(time.RFC3339))
 	if err != nil {
 		return errors.Wrap(ctx, err, "write pid")
 	}
 	return fd.Sync()
 }
+
+// isProcessAlive reports whether a process with pid exists. EPERM means it exists
+// but belongs to another user.
+func isProcessAlive(pid int) bool {
+	err := syscall.Kill(pid, syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// formatStartedAt renders the start time for the already-running error, or "" when unknown.
+func formatStartedAt(startedAt time.Time) string {
+	if startedAt.IsZero() {
+		return ""
+	}
+	return ", started " + startedAt.Format(time.RFC3339)
+}
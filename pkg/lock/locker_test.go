@@ -7,10 +7,12 @@ package lock_test
 import (
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -63,12 +65,19 @@ var _ = Describe("Locker", func() {
 			data, err := os.ReadFile(lockPath)
 			Expect(err).NotTo(HaveOccurred())
 
-			pidStr := strings.TrimSpace(string(data))
+			pidStr, _, _ := strings.Cut(string(data), "\n")
 			pid, err := strconv.Atoi(pidStr)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(pid).To(Equal(os.Getpid()))
 		})
 
+		It("writes the start time to lock file", func() {
+			info, err := lock.ReadInfo(filepath.Join(tmpDir, ".dark-factory.lock"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.PID).To(Equal(os.Getpid()))
+			Expect(info.StartedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
 		Context("when lock is already held", func() {
 			var secondLocker lock.Locker
 
@@ -91,6 +100,51 @@ var _ = Describe("Locker", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("another instance is already running"))
 				Expect(err.Error()).To(ContainSubstring("pid"))
+				Expect(err.Error()).To(ContainSubstring("--force"))
+			})
+
+			It("takes over the live lock with force", func() {
+				Expect(lockErr).NotTo(HaveOccurred())
+
+				secondLocker = lock.NewLocker(tmpDir, lock.WithForce(true))
+				Expect(secondLocker.Acquire(ctx)).To(Succeed())
+
+				// Releasing the overridden lock leaves the new lock file in place
+				Expect(locker.Release(ctx)).To(Succeed())
+				_, err := os.Stat(filepath.Join(tmpDir, ".dark-factory.lock"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when lock is held for a dead process", func() {
+			BeforeEach(func() {
+				// A finished child process yields a PID that is no longer alive
+				child := exec.Command("true")
+				Expect(child.Run()).To(Succeed())
+				deadPID := child.Process.Pid
+
+				lockPath := filepath.Join(tmpDir, ".dark-factory.lock")
+				Expect(os.WriteFile(
+					lockPath,
+					[]byte(strconv.Itoa(deadPID)+"\n2026-01-02T03:04:05Z\n"),
+					0600,
+				)).To(Succeed())
+
+				// Hold the flock so only the recorded PID tells the lock is stale
+				fd, err := os.OpenFile(lockPath, os.O_RDWR, 0600)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(syscall.Flock(int(fd.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)).To(Succeed())
+				DeferCleanup(func() {
+					_ = fd.Close()
+				})
+			})
+
+			It("reclaims the stale lock", func() {
+				Expect(lockErr).NotTo(HaveOccurred())
+
+				info, err := lock.ReadInfo(filepath.Join(tmpDir, ".dark-factory.lock"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.PID).To(Equal(os.Getpid()))
 			})
 		})
 
@@ -5,4 +5,6 @@
 // Package log provides context-aware structured logging helpers for dark-factory.
 // It allows a *slog.Logger to be bound to a context.Context (via NewContext) and
 // retrieved from it (via From), falling back to slog.Default() when no logger is bound.
+// NewHandler renders records as text or JSON lines, and Event tags prompt lifecycle
+// records with a stable "event" attribute.
 package log
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/bborbe/collection"
+	"github.com/bborbe/errors"
+	"github.com/bborbe/validation"
+)
+
+const (
+	// FormatText renders each record as key=value pairs (the default).
+	FormatText Format = "text"
+	// FormatJSON renders each record as one JSON object per line.
+	FormatJSON Format = "json"
+)
+
+// AvailableFormats contains all valid log format values.
+var AvailableFormats = Formats{FormatText, FormatJSON}
+
+// Format selects how log records are rendered.
+type Format string
+
+// String returns the string representation of the Format.
+func (f Format) String() string {
+	return string(f)
+}
+
+// Validate checks that the Format is a known value.
+func (f Format) Validate(ctx context.Context) error {
+	// Empty string is valid — means the field was not set and text applies.
+	if f == "" {
+		return nil
+	}
+	if !AvailableFormats.Contains(f) {
+		validValues := make([]string, len(AvailableFormats))
+		for i, v := range AvailableFormats {
+			validValues[i] = string(v)
+		}
+		return errors.Wrapf(
+			ctx,
+			validation.Error,
+			"unknown log format %q, valid values: %s",
+			f,
+			strings.Join(validValues, ", "),
+		)
+	}
+	return nil
+}
+
+// Formats is a collection of Format values.
+type Formats []Format
+
+// Contains reports whether format is in the collection.
+func (f Formats) Contains(format Format) bool {
+	return collection.Contains(f, format)
+}
+
+// NewHandler returns a slog.Handler writing records at level or above to w,
+// as JSON lines for FormatJSON and as text otherwise.
+func NewHandler(w io.Writer, format Format, level slog.Leveler) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// EventKey is the attribute key naming the prompt lifecycle event of a record.
+const EventKey = "event"
+
+// Prompt lifecycle events, logged under EventKey so log aggregators can
+// filter on them without matching message text.
+const (
+	EventQueued    = "queued"
+	EventExecuting = "executing"
+	EventCompleted = "completed"
+	EventFailed    = "failed"
+	EventCommitted = "committed"
+	EventTagged    = "tagged"
+)
+
+// Event returns the attribute marking a record as lifecycle event name.
+func Event(name string) slog.Attr {
+	return slog.String(EventKey, name)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	log "github.com/bborbe/dark-factory/pkg/log"
+)
+
+var _ = Describe("Format", func() {
+	DescribeTable("Validate",
+		func(format log.Format, valid bool) {
+			err := format.Validate(context.Background())
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring("unknown log format")))
+			}
+		},
+		Entry("empty", log.Format(""), true),
+		Entry("text", log.FormatText, true),
+		Entry("json", log.FormatJSON, true),
+		Entry("unknown", log.Format("yaml"), false),
+	)
+})
+
+var _ = Describe("NewHandler", func() {
+	var buf *bytes.Buffer
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+	})
+
+	It("writes one JSON object per line carrying the event attribute", func() {
+		logger := slog.New(log.NewHandler(buf, log.FormatJSON, slog.LevelInfo))
+		logger.Info("executing prompt", log.Event(log.EventExecuting), "file", "001-x.md")
+		logger.Info("prompt failed", log.Event(log.EventFailed))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		var record map[string]any
+		Expect(json.Unmarshal([]byte(lines[0]), &record)).To(Succeed())
+		Expect(record).To(HaveKeyWithValue("msg", "executing prompt"))
+		Expect(record).To(HaveKeyWithValue(log.EventKey, log.EventExecuting))
+		Expect(record).To(HaveKeyWithValue("file", "001-x.md"))
+		Expect(json.Unmarshal([]byte(lines[1]), &record)).To(Succeed())
+		Expect(record).To(HaveKeyWithValue(log.EventKey, log.EventFailed))
+	})
+
+	It("writes key=value text when the format is empty", func() {
+		logger := slog.New(log.NewHandler(buf, "", slog.LevelInfo))
+		logger.Info("executing prompt", log.Event(log.EventExecuting))
+
+		Expect(buf.String()).To(ContainSubstring(`msg="executing prompt" event=executing`))
+	})
+
+	It("drops records below level", func() {
+		logger := slog.New(log.NewHandler(buf, log.FormatJSON, slog.LevelInfo))
+		logger.Debug("hidden")
+
+		Expect(buf.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/bborbe/collection"
+	"github.com/bborbe/errors"
+	"github.com/bborbe/validation"
+)
+
+const (
+	// LevelError logs warnings and errors only.
+	LevelError Level = "error"
+	// LevelInfo additionally logs the daemon's progress (the default).
+	LevelInfo Level = "info"
+	// LevelDebug additionally logs which prompts were scanned and why they were skipped.
+	LevelDebug Level = "debug"
+)
+
+// AvailableLevels contains all valid log level values.
+var AvailableLevels = Levels{LevelError, LevelInfo, LevelDebug}
+
+// Level selects the minimum severity of logged records.
+type Level string
+
+// String returns the string representation of the Level.
+func (l Level) String() string {
+	return string(l)
+}
+
+// Validate checks that the Level is a known value.
+func (l Level) Validate(ctx context.Context) error {
+	// Empty string is valid — means the field was not set and info applies.
+	if l == "" {
+		return nil
+	}
+	if !AvailableLevels.Contains(l) {
+		validValues := make([]string, len(AvailableLevels))
+		for i, v := range AvailableLevels {
+			validValues[i] = string(v)
+		}
+		return errors.Wrapf(
+			ctx,
+			validation.Error,
+			"unknown log level %q, valid values: %s",
+			l,
+			strings.Join(validValues, ", "),
+		)
+	}
+	return nil
+}
+
+// SlogLevel returns the slog level for l. Unknown and empty levels map to slog.LevelInfo.
+// LevelError keeps warnings, so problems stay visible while progress lines are dropped.
+func (l Level) SlogLevel() slog.Level {
+	switch l {
+	case LevelError:
+		return slog.LevelWarn
+	case LevelDebug:
+		return slog.LevelDebug
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Levels is a collection of Level values.
+type Levels []Level
+
+// Contains reports whether level is in the collection.
+func (l Levels) Contains(level Level) bool {
+	return collection.Contains(l, level)
+}
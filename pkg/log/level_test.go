@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	log "github.com/bborbe/dark-factory/pkg/log"
+)
+
+var _ = Describe("Level", func() {
+	DescribeTable("Validate",
+		func(level log.Level, valid bool) {
+			err := level.Validate(context.Background())
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring("unknown log level")))
+			}
+		},
+		Entry("empty", log.Level(""), true),
+		Entry("error", log.LevelError, true),
+		Entry("info", log.LevelInfo, true),
+		Entry("debug", log.LevelDebug, true),
+		Entry("unknown", log.Level("trace"), false),
+	)
+
+	DescribeTable("SlogLevel",
+		func(level log.Level, expected slog.Level) {
+			Expect(level.SlogLevel()).To(Equal(expected))
+		},
+		Entry("empty defaults to info", log.Level(""), slog.LevelInfo),
+		Entry("error keeps warnings", log.LevelError, slog.LevelWarn),
+		Entry("info", log.LevelInfo, slog.LevelInfo),
+		Entry("debug", log.LevelDebug, slog.LevelDebug),
+	)
+
+	It("suppresses informational lines at error level", func() {
+		buf := &bytes.Buffer{}
+		logger := slog.New(log.NewHandler(buf, log.FormatText, log.LevelError.SlogLevel()))
+
+		logger.Info("watching for queued prompts")
+		logger.Warn("prompt failed; queue blocked until manual retry")
+
+		Expect(buf.String()).NotTo(ContainSubstring("watching"))
+		Expect(buf.String()).To(ContainSubstring("queue blocked"))
+	})
+})
@@ -0,0 +1,7 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package logretention deletes old per-prompt logs from the log directory,
+// keeping the newest ones or the ones younger than a maximum age.
+package logretention
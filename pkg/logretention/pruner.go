@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logretention
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+
+	"github.com/bborbe/dark-factory/pkg/log"
+)
+
+//counterfeiter:generate -o ../../mocks/log-pruner.go --fake-name LogPruner . Pruner
+
+// Pruner deletes per-prompt logs beyond the retention limit.
+type Pruner interface {
+	// Prune deletes the logs beyond the limit and returns their former paths.
+	Prune(ctx context.Context) ([]string, error)
+}
+
+// NewPruner returns a Pruner for the <prompt>.log files in logDir. It keeps the
+// newest keepCount logs when keepCount is positive and deletes logs older than
+// maxAge when maxAge is positive; with both zero nothing is deleted. Logs of
+// prompts still in queueDir, including the executing one, are never deleted.
+// The companion <prompt>.jsonl file is deleted together with its log.
+func NewPruner(
+	logDir string,
+	queueDir string,
+	keepCount int,
+	maxAge time.Duration,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) Pruner {
+	return &pruner{
+		logDir:                logDir,
+		queueDir:              queueDir,
+		keepCount:             keepCount,
+		maxAge:                maxAge,
+		currentDateTimeGetter: currentDateTimeGetter,
+	}
+}
+
+// pruner implements Pruner.
+type pruner struct {
+	logDir                string
+	queueDir              string
+	keepCount             int
+	maxAge                time.Duration
+	currentDateTimeGetter libtime.CurrentDateTimeGetter
+}
+
+// logFile is a prompt log with its modification time.
+type logFile struct {
+	path    string
+	modTime time.Time
+}
+
+// Prune deletes the logs beyond the limit, oldest first.
+func (p *pruner) Prune(ctx context.Context) ([]string, error) {
+	if p.keepCount <= 0 && p.maxAge <= 0 {
+		return nil, nil
+	}
+	logs, err := p.listLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].modTime.After(logs[j].modTime)
+	})
+	cutoff := time.Time(p.currentDateTimeGetter.Now()).Add(-p.maxAge)
+	queued := p.queuedNames()
+	var pruned []string
+	for i, l := range logs {
+		beyondCount := p.keepCount > 0 && i >= p.keepCount
+		tooOld := p.maxAge > 0 && l.modTime.Before(cutoff)
+		if !beyondCount && !tooOld {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(l.path), ".log")
+		if queued[name] {
+			continue
+		}
+		if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+			return pruned, errors.Wrapf(ctx, err, "delete %s", l.path)
+		}
+		jsonl := strings.TrimSuffix(l.path, ".log") + ".jsonl"
+		if err := os.Remove(jsonl); err != nil && !os.IsNotExist(err) {
+			return pruned, errors.Wrapf(ctx, err, "delete %s", jsonl)
+		}
+		pruned = append(pruned, l.path)
+	}
+	if len(pruned) > 0 {
+		log.From(ctx).Info("pruned prompt logs", "count", len(pruned), "log_dir", p.logDir)
+	}
+	return pruned, nil
+}
+
+// listLogs returns the .log files directly in logDir; a missing directory has none.
+func (p *pruner) listLogs(ctx context.Context) ([]logFile, error) {
+	entries, err := os.ReadDir(p.logDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, "read log directory")
+	}
+	var logs []logFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".log") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		logs = append(logs, logFile{
+			path:    filepath.Join(p.logDir, entry.Name()),
+			modTime: info.ModTime(),
+		})
+	}
+	return logs, nil
+}
+
+// queuedNames returns the base names, without .md, of the prompts in queueDir.
+func (p *pruner) queuedNames() map[string]bool {
+	names := make(map[string]bool)
+	entries, err := os.ReadDir(p.queueDir)
+	if err != nil {
+		return names
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			names[strings.TrimSuffix(entry.Name(), ".md")] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logretention_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/logretention"
+)
+
+var _ = Describe("Pruner", func() {
+	var (
+		ctx      context.Context
+		logDir   string
+		queueDir string
+		now      time.Time
+		getter   libtime.CurrentDateTimeGetter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		queueDir = filepath.Join(tempDir, "in-progress")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		currentDateTime := libtime.NewCurrentDateTime()
+		currentDateTime.SetNow(libtime.DateTime(now))
+		getter = currentDateTime
+	})
+
+	// writeLogs creates 001.log (oldest) .. NNN.log (newest), one hour apart.
+	writeLogs := func(count int) {
+		for i := 1; i <= count; i++ {
+			path := filepath.Join(logDir, fmt.Sprintf("%03d-prompt.log", i))
+			Expect(os.WriteFile(path, []byte("log"), 0600)).To(Succeed())
+			modTime := now.Add(-time.Duration(count-i) * time.Hour)
+			Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+		}
+	}
+
+	listLogs := func() []string {
+		entries, err := os.ReadDir(logDir)
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	It("keeps only the newest logs when a count is set", func() {
+		writeLogs(20)
+
+		pruned, err := logretention.NewPruner(logDir, queueDir, 3, 0, getter).Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(HaveLen(17))
+		Expect(listLogs()).To(Equal([]string{
+			"018-prompt.log",
+			"019-prompt.log",
+			"020-prompt.log",
+		}))
+	})
+
+	It("deletes logs older than the max age", func() {
+		writeLogs(10)
+
+		_, err := logretention.NewPruner(logDir, queueDir, 0, 150*time.Minute, getter).Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listLogs()).To(Equal([]string{
+			"008-prompt.log",
+			"009-prompt.log",
+			"010-prompt.log",
+		}))
+	})
+
+	It("never deletes the log of a prompt still in the queue", func() {
+		writeLogs(5)
+		Expect(os.WriteFile(filepath.Join(queueDir, "001-prompt.md"), []byte("---\n"), 0600)).
+			To(Succeed())
+
+		_, err := logretention.NewPruner(logDir, queueDir, 2, 0, getter).Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listLogs()).To(Equal([]string{
+			"001-prompt.log",
+			"004-prompt.log",
+			"005-prompt.log",
+		}))
+	})
+
+	It("deletes the jsonl file together with its log", func() {
+		writeLogs(2)
+		jsonl := filepath.Join(logDir, "001-prompt.jsonl")
+		Expect(os.WriteFile(jsonl, []byte("{}\n"), 0600)).To(Succeed())
+
+		_, err := logretention.NewPruner(logDir, queueDir, 1, 0, getter).Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listLogs()).To(Equal([]string{"002-prompt.log"}))
+	})
+
+	It("keeps every log without a limit", func() {
+		writeLogs(5)
+
+		pruned, err := logretention.NewPruner(logDir, queueDir, 0, 0, getter).Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(BeEmpty())
+		Expect(listLogs()).To(HaveLen(5))
+	})
+})
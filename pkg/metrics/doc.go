@@ -0,0 +1,6 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package metrics records prompt lifecycle counters and serves them in Prometheus text format.
+package metrics
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+//counterfeiter:generate -o ../../mocks/metrics.go --fake-name Metrics . Metrics
+
+// Metrics records the outcome of prompt executions.
+type Metrics interface {
+	// PromptCompleted counts a completed prompt and observes how long it executed.
+	PromptCompleted(duration time.Duration)
+	// PromptFailed counts a failed prompt execution, including ones re-queued for retry.
+	PromptFailed()
+}
+
+// QueueDepthFunc returns the number of queued prompts at scrape time.
+type QueueDepthFunc func() int
+
+// NewMetrics registers the dark-factory collectors with registerer and returns
+// the Metrics updating them. queueDepth backs the dark_factory_queue_depth gauge;
+// pass nil to leave the gauge out.
+func NewMetrics(registerer prometheus.Registerer, queueDepth QueueDepthFunc) Metrics {
+	m := &metrics{
+		completed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dark_factory_prompts_completed_total",
+			Help: "Number of prompts completed.",
+		}),
+		failed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dark_factory_prompts_failed_total",
+			Help: "Number of prompt executions that failed.",
+		}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "dark_factory_prompt_duration_seconds",
+			Help:    "Execution duration of completed prompts.",
+			Buckets: []float64{30, 60, 120, 300, 600, 900, 1800, 3600, 7200},
+		}),
+	}
+	registerer.MustRegister(m.completed, m.failed, m.duration)
+	if queueDepth != nil {
+		registerer.MustRegister(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name: "dark_factory_queue_depth",
+				Help: "Number of prompts waiting in the queue.",
+			},
+			func() float64 { return float64(queueDepth()) },
+		))
+	}
+	return m
+}
+
+// NewHandler returns the /metrics handler serving gatherer in Prometheus text format.
+func NewHandler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+}
+
+// NewNoopMetrics returns Metrics that records nothing.
+func NewNoopMetrics() Metrics {
+	return noopMetrics{}
+}
+
+type metrics struct {
+	completed prometheus.Counter
+	failed    prometheus.Counter
+	duration  prometheus.Histogram
+}
+
+func (m *metrics) PromptCompleted(duration time.Duration) {
+	m.completed.Inc()
+	m.duration.Observe(duration.Seconds())
+}
+
+func (m *metrics) PromptFailed() {
+	m.failed.Inc()
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) PromptCompleted(_ time.Duration) {}
+
+func (noopMetrics) PromptFailed() {}
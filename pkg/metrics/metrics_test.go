@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bborbe/dark-factory/pkg/metrics"
+)
+
+var _ = Describe("Metrics", func() {
+	var (
+		registry   *prometheus.Registry
+		queueDepth int
+		m          metrics.Metrics
+	)
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		metrics.NewHandler(registry).ServeHTTP(
+			recorder,
+			httptest.NewRequest(http.MethodGet, "/metrics", nil),
+		)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		return recorder.Body.String()
+	}
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
+		queueDepth = 0
+		m = metrics.NewMetrics(registry, func() int { return queueDepth })
+	})
+
+	It("starts every counter at zero", func() {
+		body := scrape()
+		Expect(body).To(ContainSubstring("dark_factory_prompts_completed_total 0\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompts_failed_total 0\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompt_duration_seconds_count 0\n"))
+		Expect(body).To(ContainSubstring("dark_factory_queue_depth 0\n"))
+	})
+
+	It("advances the counters and observes the duration", func() {
+		m.PromptCompleted(90 * time.Second)
+		m.PromptCompleted(10 * time.Second)
+		m.PromptFailed()
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("dark_factory_prompts_completed_total 2\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompts_failed_total 1\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompt_duration_seconds_count 2\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompt_duration_seconds_sum 100\n"))
+		Expect(body).To(ContainSubstring(`dark_factory_prompt_duration_seconds_bucket{le="60"} 1`))
+	})
+
+	It("reports the queue depth at scrape time", func() {
+		queueDepth = 3
+		Expect(scrape()).To(ContainSubstring("dark_factory_queue_depth 3\n"))
+	})
+
+	It("leaves the queue depth gauge out without a queue depth func", func() {
+		registry = prometheus.NewRegistry()
+		metrics.NewMetrics(registry, nil)
+		Expect(scrape()).NotTo(ContainSubstring("dark_factory_queue_depth"))
+	})
+})
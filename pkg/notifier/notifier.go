@@ -4,14 +4,25 @@
 
 package notifier
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Event holds the data for a single notification.
 type Event struct {
 	ProjectName string
-	EventType   string // "prompt_failed", "prompt_partial", "spec_verifying", "review_limit", "stuck_container", "preflight_failed"
+	EventType   string // "prompt_failed", "prompt_partial", "spec_verifying", "review_limit", "stuck_container", "preflight_failed", "prompt_completed"
 	PromptName  string // filename without path, empty if not applicable
 	PRURL       string // empty if not applicable
+	Owner       string // prompt owner from frontmatter, empty if not set
+	// The fields below are set on prompt_completed and prompt_failed events only.
+	Title     string        // first # heading of the prompt
+	Status    string        // prompt status after the event ("completed" or "failed")
+	Version   string        // dark-factory version that executed the prompt
+	Tag       string        // release tag created for the prompt, empty if none
+	Container string        // execution identifier of the container
+	Duration  time.Duration // time since the prompt started executing
 }
 
 //counterfeiter:generate -o ../../mocks/notifier.go --fake-name Notifier . Notifier
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -231,6 +232,36 @@ var _ = Describe("discordNotifier", func() {
 		It("does not include empty PR line", func() {
 			Expect(body["content"]).NotTo(ContainSubstring("PR:"))
 		})
+
+		It("does not include empty Owner line", func() {
+			Expect(body["content"]).NotTo(ContainSubstring("Owner:"))
+		})
+	})
+
+	Context("event with owner", func() {
+		BeforeEach(func() {
+			server = httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					data, _ := io.ReadAll(r.Body)
+					_ = json.Unmarshal(data, &body)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			)
+		})
+
+		It("mentions the owner once, with a single @", func() {
+			for _, owner := range []string{"alice", "@alice"} {
+				err = notifier.NewDiscordNotifier(server.URL).Notify(ctx, notifier.Event{
+					ProjectName: "myproject",
+					EventType:   "prompt_failed",
+					PromptName:  "001-fix.md",
+					Owner:       owner,
+				})
+				Expect(err).To(BeNil())
+				Expect(body["content"]).To(ContainSubstring("\nOwner: @alice"))
+				Expect(body["content"]).NotTo(ContainSubstring("@@"))
+			}
+		})
 	})
 
 	Context("non-2xx response", func() {
@@ -340,3 +371,156 @@ var _ = Describe("NewTelegramNotifier HTTP test", func() {
 		})
 	})
 })
+
+var _ = Describe("webhookNotifier", func() {
+	var (
+		ctx      context.Context
+		server   *httptest.Server
+		status   int
+		requests int
+		method   string
+		payload  notifier.WebhookPayload
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		status = http.StatusOK
+		requests = 0
+		server = httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				method = r.Method
+				data, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(data, &payload)
+				w.WriteHeader(status)
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	DescribeTable("posts the prompt outcome",
+		func(eventType, promptStatus string) {
+			err := notifier.NewWebhookNotifier(server.URL).Notify(ctx, notifier.Event{
+				ProjectName: "myproject",
+				EventType:   eventType,
+				PromptName:  "001-fix.md",
+				Title:       "Fix the thing",
+				Status:      promptStatus,
+				Version:     "v1.2.3",
+				Container:   "myproject-001-fix",
+				Duration:    90 * time.Second,
+			})
+			Expect(err).To(BeNil())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(payload).To(Equal(notifier.WebhookPayload{
+				Prompt:    "001-fix.md",
+				Title:     "Fix the thing",
+				Status:    promptStatus,
+				Version:   "v1.2.3",
+				Container: "myproject-001-fix",
+				Duration:  90,
+			}))
+		},
+		Entry("completed", "prompt_completed", "completed"),
+		Entry("failed", "prompt_failed", "failed"),
+	)
+
+	It("ignores other event types", func() {
+		err := notifier.NewWebhookNotifier(server.URL).Notify(ctx, notifier.Event{
+			ProjectName: "myproject",
+			EventType:   "spec_verifying",
+		})
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(0))
+	})
+
+	It("returns an error on a non-2xx response", func() {
+		status = http.StatusInternalServerError
+
+		err := notifier.NewWebhookNotifier(server.URL).Notify(ctx, notifier.Event{
+			EventType: "prompt_completed",
+		})
+		Expect(err).To(MatchError(ContainSubstring("status 500")))
+	})
+})
+
+var _ = Describe("slackNotifier", func() {
+	var (
+		ctx      context.Context
+		server   *httptest.Server
+		status   int
+		requests int
+		text     string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		status = http.StatusOK
+		requests = 0
+		text = ""
+		server = httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				var payload map[string]string
+				data, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(data, &payload)
+				text = payload["text"]
+				w.WriteHeader(status)
+			}),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts the title, version and PR link of a completed prompt", func() {
+		err := notifier.NewSlackNotifier(server.URL).Notify(ctx, notifier.Event{
+			ProjectName: "myproject",
+			EventType:   "prompt_completed",
+			PromptName:  "001-fix.md",
+			Title:       "Fix the thing",
+			Tag:         "v1.2.3",
+			PRURL:       "https://github.com/org/repo/pull/7",
+		})
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(1))
+		Expect(text).To(ContainSubstring("myproject"))
+		Expect(text).To(ContainSubstring("Fix the thing"))
+		Expect(text).To(ContainSubstring("v1.2.3"))
+		Expect(text).To(ContainSubstring("https://github.com/org/repo/pull/7"))
+	})
+
+	It("omits version and PR when not set", func() {
+		err := notifier.NewSlackNotifier(server.URL).Notify(ctx, notifier.Event{
+			ProjectName: "myproject",
+			EventType:   "prompt_completed",
+			PromptName:  "001-fix.md",
+		})
+		Expect(err).To(BeNil())
+		Expect(text).To(ContainSubstring("001-fix.md"))
+		Expect(text).NotTo(ContainSubstring("Version:"))
+		Expect(text).NotTo(ContainSubstring("PR:"))
+	})
+
+	It("ignores other event types", func() {
+		err := notifier.NewSlackNotifier(server.URL).Notify(ctx, notifier.Event{
+			ProjectName: "myproject",
+			EventType:   "prompt_failed",
+		})
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal(0))
+	})
+
+	It("returns an error on a non-2xx response", func() {
+		status = http.StatusBadRequest
+
+		err := notifier.NewSlackNotifier(server.URL).Notify(ctx, notifier.Event{
+			EventType: "prompt_completed",
+		})
+		Expect(err).To(MatchError(ContainSubstring("status 400")))
+	})
+})
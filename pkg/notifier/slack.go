@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// NewSlackNotifier returns a Notifier that posts prompt_completed events to a Slack
+// incoming webhook. Other event types are ignored.
+func NewSlackNotifier(webhookURL string) Notifier {
+	return &slackNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: WebhookTimeout},
+	}
+}
+
+type slackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, event Event) error {
+	if event.EventType != "prompt_completed" {
+		return nil
+	}
+	body, err := json.Marshal(map[string]string{
+		"text": formatSlackMessage(event),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, "marshal slack payload")
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		s.webhookURL,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, "create slack request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(ctx, err, "send slack request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf(ctx, "slack request failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// formatSlackMessage formats a completed prompt as Slack mrkdwn text.
+func formatSlackMessage(event Event) string {
+	title := event.Title
+	if title == "" {
+		title = event.PromptName
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, ":white_check_mark: [%s] *%s* completed", event.ProjectName, title)
+	if event.Tag != "" {
+		fmt.Fprintf(&sb, "\nVersion: %s", event.Tag)
+	}
+	if event.PRURL != "" {
+		fmt.Fprintf(&sb, "\nPR: <%s>", event.PRURL)
+	}
+	return sb.String()
+}
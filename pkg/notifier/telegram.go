@@ -80,5 +80,8 @@ func formatMessage(event Event) string {
 	if event.PRURL != "" {
 		fmt.Fprintf(&sb, "\nPR: %s", event.PRURL)
 	}
+	if event.Owner != "" {
+		fmt.Fprintf(&sb, "\nOwner: @%s", strings.TrimPrefix(event.Owner, "@"))
+	}
 	return sb.String()
 }
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// WebhookTimeout bounds a single webhook POST so a slow endpoint never holds up processing.
+const WebhookTimeout = 5 * time.Second
+
+// WebhookPayload is the JSON body POSTed by the webhook notifier.
+type WebhookPayload struct {
+	Prompt    string  `json:"prompt"`
+	Title     string  `json:"title"`
+	Status    string  `json:"status"`
+	Version   string  `json:"version"`
+	Container string  `json:"container"`
+	Duration  float64 `json:"duration"` // seconds
+}
+
+// NewWebhookNotifier returns a Notifier that POSTs prompt_completed and prompt_failed
+// events as a WebhookPayload to url. Other event types are ignored.
+func NewWebhookNotifier(url string) Notifier {
+	return &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: WebhookTimeout},
+	}
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, event Event) error {
+	if event.EventType != "prompt_completed" && event.EventType != "prompt_failed" {
+		return nil
+	}
+	body, err := json.Marshal(WebhookPayload{
+		Prompt:    event.PromptName,
+		Title:     event.Title,
+		Status:    event.Status,
+		Version:   event.Version,
+		Container: event.Container,
+		Duration:  event.Duration.Seconds(),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, "marshal webhook payload")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(ctx, err, "create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Wrap(ctx, err, "send webhook request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf(ctx, "webhook request failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
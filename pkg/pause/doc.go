@@ -0,0 +1,7 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pause manages the prompts/.paused sentinel that stops the daemon
+// from starting new prompts while it keeps watching.
+package pause
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pause
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+)
+
+// FileName is the name of the sentinel file inside the prompts inbox directory.
+const FileName = ".paused"
+
+//counterfeiter:generate -o ../../mocks/pause-sentinel.go --fake-name PauseSentinel . Sentinel
+
+// Sentinel reports and toggles the paused state of the queue.
+type Sentinel interface {
+	// IsPaused returns true when the sentinel file exists.
+	IsPaused() bool
+	// Pause creates the sentinel file. Pausing an already paused queue is a no-op.
+	Pause(ctx context.Context) error
+	// Resume removes the sentinel file. Resuming a queue that is not paused is a no-op.
+	Resume(ctx context.Context) error
+}
+
+// NewSentinel creates a Sentinel backed by the .paused file in dir.
+func NewSentinel(
+	dir string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) Sentinel {
+	return &sentinel{
+		path:                  filepath.Join(dir, FileName),
+		currentDateTimeGetter: currentDateTimeGetter,
+	}
+}
+
+// sentinel implements Sentinel.
+type sentinel struct {
+	path                  string
+	currentDateTimeGetter libtime.CurrentDateTimeGetter
+}
+
+func (s *sentinel) IsPaused() bool {
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+func (s *sentinel) Pause(ctx context.Context) error {
+	if s.IsPaused() {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return errors.Wrap(ctx, err, "create sentinel directory")
+	}
+	// The file content is informational only; presence alone means paused.
+	content := "paused: " + time.Time(s.currentDateTimeGetter.Now()).UTC().Format(time.RFC3339) + "\n"
+	if err := os.WriteFile(s.path, []byte(content), 0600); err != nil {
+		return errors.Wrap(ctx, err, "write pause sentinel")
+	}
+	return nil
+}
+
+func (s *sentinel) Resume(ctx context.Context) error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(ctx, err, "remove pause sentinel")
+	}
+	return nil
+}
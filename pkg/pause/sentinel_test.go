@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pause_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/pause"
+)
+
+var _ = Describe("Sentinel", func() {
+	var (
+		ctx      context.Context
+		dir      string
+		sentinel pause.Sentinel
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = filepath.Join(GinkgoT().TempDir(), "prompts")
+		sentinel = pause.NewSentinel(dir, libtime.NewCurrentDateTime())
+	})
+
+	It("is not paused without the sentinel file", func() {
+		Expect(sentinel.IsPaused()).To(BeFalse())
+	})
+
+	It("creates the sentinel file on Pause", func() {
+		Expect(sentinel.Pause(ctx)).To(Succeed())
+		Expect(sentinel.IsPaused()).To(BeTrue())
+		_, err := os.Stat(filepath.Join(dir, pause.FileName))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("is idempotent on Pause", func() {
+		Expect(sentinel.Pause(ctx)).To(Succeed())
+		Expect(sentinel.Pause(ctx)).To(Succeed())
+		Expect(sentinel.IsPaused()).To(BeTrue())
+	})
+
+	It("removes the sentinel file on Resume", func() {
+		Expect(sentinel.Pause(ctx)).To(Succeed())
+		Expect(sentinel.Resume(ctx)).To(Succeed())
+		Expect(sentinel.IsPaused()).To(BeFalse())
+	})
+
+	It("is idempotent on Resume", func() {
+		Expect(sentinel.Resume(ctx)).To(Succeed())
+		Expect(sentinel.IsPaused()).To(BeFalse())
+	})
+})
@@ -7,6 +7,8 @@ package processor
 import (
 	"context"
 
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/subproc"
 )
 
@@ -19,7 +21,49 @@ func SetupInPlaceBranchForTest(deps WorkflowDeps, ctx context.Context, branch st
 	return e.setupInPlaceBranch(ctx, branch)
 }
 
+// HandleBranchPRCompletionForTest exposes branchWorkflowExecutor.handleBranchPRCompletion
+// for external tests. createdBranch reports whether Setup created the feature branch.
+func HandleBranchPRCompletionForTest(
+	deps WorkflowDeps,
+	ctx context.Context,
+	pf *prompt.PromptFile,
+	featureBranch string,
+	createdBranch bool,
+) error {
+	e := &branchWorkflowExecutor{deps: deps, createdBranch: createdBranch}
+	return e.handleBranchPRCompletion(ctx, ctx, pf, featureBranch, "Test", "/tmp/completed.md")
+}
+
 // NewDirtyFileCheckerWithRunner exposes newDirtyFileCheckerWithRunner for external tests.
 func NewDirtyFileCheckerWithRunner(repoDir string, runner subproc.Runner) DirtyFileChecker {
 	return newDirtyFileCheckerWithRunner(repoDir, runner)
 }
+
+// BumpOverrideFromForTest exposes bumpOverrideFrom for external tests.
+func BumpOverrideFromForTest(ctx context.Context) (git.VersionBump, bool) {
+	return bumpOverrideFrom(ctx)
+}
+
+// RecordReleaseForTest exposes recordRelease for external tests.
+func RecordReleaseForTest(ctx context.Context, version string) {
+	recordRelease(ctx, version)
+}
+
+func SkipReleaseFromForTest(ctx context.Context) bool {
+	return skipReleaseFrom(ctx)
+}
+
+// PruneCompletedForTest exposes processor.pruneCompleted for external tests.
+func PruneCompletedForTest(
+	ctx context.Context,
+	promptManager PromptManager,
+	releaser git.Releaser,
+	completedDir string,
+) {
+	p := &processor{
+		promptManager: promptManager,
+		releaser:      releaser,
+		dirs:          Dirs{Completed: completedDir},
+	}
+	p.pruneCompleted(ctx)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor
+
+import "time"
+
+// pollInterval is the adaptive delay between periodic queue scans. It starts at
+// base, doubles after every scan without progress up to limit, and drops back to
+// base after a scan with progress or a watcher ready signal.
+type pollInterval struct {
+	base    time.Duration
+	limit   time.Duration
+	current time.Duration
+}
+
+// newPollInterval returns a pollInterval starting at base. A limit below base
+// disables the backoff: every scan then waits base.
+func newPollInterval(base time.Duration, limit time.Duration) *pollInterval {
+	if limit < base {
+		limit = base
+	}
+	return &pollInterval{
+		base:    base,
+		limit:   limit,
+		current: base,
+	}
+}
+
+// Current returns the delay before the next periodic scan.
+func (p *pollInterval) Current() time.Duration {
+	return p.current
+}
+
+// Idle doubles the delay after a scan that made no progress, capped at limit.
+func (p *pollInterval) Idle() {
+	if p.current > p.limit/2 {
+		p.current = p.limit
+		return
+	}
+	p.current *= 2
+}
+
+// Reset returns the delay to base after activity.
+func (p *pollInterval) Reset() {
+	p.current = p.base
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pollInterval", func() {
+	// Each Idle call stands for one elapsed poll without progress, so the tests
+	// step the clock explicitly instead of waiting on real timers.
+	It("starts at the base interval", func() {
+		poll := newPollInterval(5*time.Second, time.Minute)
+		Expect(poll.Current()).To(Equal(5 * time.Second))
+	})
+
+	It("doubles the interval while the queue stays idle, capped at the limit", func() {
+		poll := newPollInterval(5*time.Second, time.Minute)
+
+		var intervals []time.Duration
+		for i := 0; i < 6; i++ {
+			poll.Idle()
+			intervals = append(intervals, poll.Current())
+		}
+
+		Expect(intervals).To(Equal([]time.Duration{
+			10 * time.Second,
+			20 * time.Second,
+			40 * time.Second,
+			time.Minute,
+			time.Minute,
+			time.Minute,
+		}))
+	})
+
+	It("resets to the base interval on activity", func() {
+		poll := newPollInterval(5*time.Second, time.Minute)
+		poll.Idle()
+		poll.Idle()
+		Expect(poll.Current()).To(Equal(20 * time.Second))
+
+		poll.Reset()
+
+		Expect(poll.Current()).To(Equal(5 * time.Second))
+		poll.Idle()
+		Expect(poll.Current()).To(Equal(10 * time.Second))
+	})
+
+	It("keeps a fixed interval when the limit is below the base", func() {
+		poll := newPollInterval(5*time.Second, time.Second)
+		poll.Idle()
+		Expect(poll.Current()).To(Equal(5 * time.Second))
+	})
+})
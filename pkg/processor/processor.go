@@ -7,9 +7,13 @@ package processor
 import (
 	"context"
 	stderrors "errors"
+	"fmt"
 	"log/slog"
+	"os"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -24,13 +28,19 @@ import (
 	"github.com/bborbe/dark-factory/pkg/failurehandler"
 	"github.com/bborbe/dark-factory/pkg/git"
 	log "github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/logretention"
+	"github.com/bborbe/dark-factory/pkg/metrics"
+	"github.com/bborbe/dark-factory/pkg/notifier"
 	"github.com/bborbe/dark-factory/pkg/preflightconditions"
 	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/promptenricher"
 	"github.com/bborbe/dark-factory/pkg/promptresumer"
+	"github.com/bborbe/dark-factory/pkg/promptsource"
 	promptstate "github.com/bborbe/dark-factory/pkg/promptstate"
 	"github.com/bborbe/dark-factory/pkg/queuescanner"
+	"github.com/bborbe/dark-factory/pkg/resultcache"
+	"github.com/bborbe/dark-factory/pkg/resultfile"
 	"github.com/bborbe/dark-factory/pkg/spec"
 	"github.com/bborbe/dark-factory/pkg/specsweeper"
 	"github.com/bborbe/dark-factory/pkg/version"
@@ -40,6 +50,10 @@ import (
 // can use stderrors.Is(err, processor.ErrPreflightFailed) without importing preflightconditions.
 var ErrPreflightFailed = preflightconditions.ErrPreflightFailed
 
+// ErrShutdownRequested is returned by Process when the shutdown channel fired.
+// Process only returns it between prompts, so no prompt is left executing.
+var ErrShutdownRequested = stderrors.New("shutdown requested")
+
 //counterfeiter:generate -o ../../mocks/processor.go --fake-name Processor . Processor
 
 // Processor processes queued prompts.
@@ -57,6 +71,10 @@ type Processor interface {
 	ResumeCommitting(ctx context.Context) error
 }
 
+// errPromptRequeued reports that the container was stopped because the prompt
+// was put back into the queue while it ran.
+var errPromptRequeued = stderrors.New("prompt re-queued during execution")
+
 // NothingToDoCallback fires when a Process tick ends with no progress made.
 // Daemon mode passes a log-only callback. One-shot mode passes one that calls cancel().
 type NothingToDoCallback func(ctx context.Context, cancel context.CancelFunc)
@@ -84,6 +102,9 @@ func NewProcessor(
 	executionSlotManager executionslot.Manager,
 	cancellationWatcher cancellationwatcher.Watcher,
 	wakeup <-chan struct{},
+	// shutdown makes Process return ErrShutdownRequested once the prompt in flight
+	// is completed and committed. Pass nil to disable.
+	shutdown <-chan struct{},
 	dirs Dirs,
 	projectName project.Name,
 	failureHandler failurehandler.Handler,
@@ -95,12 +116,52 @@ func NewProcessor(
 	promptEnricher promptenricher.Enricher,
 	committingRecoverer committingrecoverer.Recoverer,
 	queueScanner queuescanner.Scanner,
-	// queueInterval controls how often the daemon polls for queued prompts.
+	// resultCache skips the container for prompts whose content, image and version
+	// match a recorded successful execution. Pass nil to disable caching.
+	resultCache resultcache.Cache,
+	// sourceFetcher downloads the body of prompts with source_url frontmatter.
+	// Pass nil to use a plain HTTP fetcher with the promptsource defaults.
+	sourceFetcher promptsource.Fetcher,
+	// resultReader consumes the result file the container may leave in the workspace.
+	// Pass nil to use resultfile.NewReader.
+	resultReader resultfile.Reader,
+	// promptNotifier receives prompt_completed once a prompt is committed.
+	// Pass nil to disable.
+	promptNotifier notifier.Notifier,
+	// promptMetrics counts completed and failed prompt executions.
+	// Pass nil to disable.
+	promptMetrics metrics.Metrics,
+	// maxPromptDuration bounds a container execution; a prompt's timeout frontmatter
+	// overrides it. Pass 0 to disable the timeout.
+	maxPromptDuration time.Duration,
+	// minBodyLength is the minimum number of characters below the heading; shorter
+	// prompts are moved to completed without execution. Pass 0 to disable.
+	minBodyLength int,
+	// logPruner deletes old prompt logs on startup and after each completed prompt.
+	// Pass nil to keep every log.
+	logPruner logretention.Pruner,
+	// promptTrees gives every prompt its own detached worktree of HEAD. Its changes
+	// are applied to the project tree right before the commit, so prompts running
+	// side by side never see or commit each other's changes.
+	// Pass nil to run prompts in the project tree.
+	promptTrees git.Worktreer,
+	// dryRun makes Process log what each queued prompt would do and return,
+	// without executing, moving or committing anything.
+	dryRun bool,
+	// queueInterval is the delay between queue polls right after activity.
 	// Pass 0 to use the default of 5s.
 	queueInterval time.Duration,
+	// queueMaxInterval caps the delay between queue polls: every poll without
+	// progress doubles the delay up to it, a ready signal resets it to queueInterval.
+	// Pass 0 to use the default of 60s.
+	queueMaxInterval time.Duration,
 	// sweepInterval controls the auto-complete sweep cadence.
 	// Pass 0 to use the default of 60s.
 	sweepInterval time.Duration,
+	// readyDebounce coalesces watcher ready signals: the first signal arms a timer
+	// and all signals until it fires trigger a single scan.
+	// Pass 0 to scan on every signal.
+	readyDebounce time.Duration,
 	// onIdle is invoked at the end of any tick that made no progress.
 	// Pass a log-only callback for daemon mode, or one that calls cancel() for one-shot mode.
 	// If nil, a no-op callback is used (safe for tests that do not need idle detection).
@@ -109,12 +170,28 @@ func NewProcessor(
 	if queueInterval <= 0 {
 		queueInterval = 5 * time.Second
 	}
+	if queueMaxInterval <= 0 {
+		queueMaxInterval = 60 * time.Second
+	}
 	if sweepInterval <= 0 {
 		sweepInterval = 60 * time.Second
 	}
 	if onIdle == nil {
 		onIdle = func(_ context.Context, _ context.CancelFunc) {}
 	}
+	if sourceFetcher == nil {
+		sourceFetcher = promptsource.NewFetcher(
+			nil,
+			promptsource.DefaultTimeout,
+			promptsource.DefaultMaxBytes,
+		)
+	}
+	if resultReader == nil {
+		resultReader = resultfile.NewReader()
+	}
+	if promptMetrics == nil {
+		promptMetrics = metrics.NewNoopMetrics()
+	}
 	return &processor{
 		executor:                  exec,
 		promptManager:             promptManager,
@@ -128,18 +205,31 @@ func NewProcessor(
 		executionSlotManager:      executionSlotManager,
 		cancellationWatcher:       cancellationWatcher,
 		wakeup:                    wakeup,
+		shutdown:                  shutdown,
 		dirs:                      dirs,
 		projectName:               projectName,
 		resumer:                   resumer,
 		workflowType:              workflowType,
 		verificationGate:          verificationGate,
 		queueInterval:             queueInterval,
+		queueMaxInterval:          queueMaxInterval,
 		sweepInterval:             sweepInterval,
+		readyDebounce:             readyDebounce,
 		onIdle:                    onIdle,
 		completionReportValidator: completionReportValidator,
 		promptEnricher:            promptEnricher,
 		committingRecoverer:       committingRecoverer,
 		queueScanner:              queueScanner,
+		resultCache:               resultCache,
+		sourceFetcher:             sourceFetcher,
+		resultReader:              resultReader,
+		promptNotifier:            promptNotifier,
+		promptMetrics:             promptMetrics,
+		maxPromptDuration:         maxPromptDuration,
+		minBodyLength:             minBodyLength,
+		logPruner:                 logPruner,
+		promptTrees:               promptTrees,
+		dryRun:                    dryRun,
 	}
 }
 
@@ -157,28 +247,51 @@ type processor struct {
 	executionSlotManager      executionslot.Manager
 	cancellationWatcher       cancellationwatcher.Watcher
 	wakeup                    <-chan struct{}
+	shutdown                  <-chan struct{}
 	dirs                      Dirs
 	projectName               project.Name
 	resumer                   promptresumer.Resumer
 	workflowType              config.Workflow
 	verificationGate          bool
 	queueInterval             time.Duration
+	queueMaxInterval          time.Duration
 	sweepInterval             time.Duration
+	readyDebounce             time.Duration
 	onIdle                    NothingToDoCallback
 	completionReportValidator completionreport.Validator
 	promptEnricher            promptenricher.Enricher
 	committingRecoverer       committingrecoverer.Recoverer
 	queueScanner              queuescanner.Scanner
+	resultCache               resultcache.Cache
+	sourceFetcher             promptsource.Fetcher
+	resultReader              resultfile.Reader
+	promptNotifier            notifier.Notifier
+	promptMetrics             metrics.Metrics
+	maxPromptDuration         time.Duration
+	minBodyLength             int
+	logPruner                 logretention.Pruner
+	promptTrees               git.Worktreer
+	dryRun                    bool
 }
 
+// gitMu serializes the workflows' git operations (remote sync and commit) across
+// every processor in the process, so prompts run concurrently — by the scanner or
+// by the processors of several prompts directories — never race on the git index.
+var gitMu sync.Mutex
+
 // Process starts processing queued prompts.
 // It processes existing queued prompts on startup, then listens for signals from the watcher.
 // When a tick ends with no progress, onIdle is called. Daemon mode logs; one-shot mode cancels.
 func (p *processor) Process(ctx context.Context) error {
+	if p.dryRun {
+		return p.dryRunQueue(ctx)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	log.From(ctx).Info("processor started")
+	p.pruneLogs(ctx)
 
 	// Startup scans — do NOT fire onIdle here; that would cancel one-shot before work starts.
 	if _, err := p.specSweeper.Sweep(ctx); err != nil {
@@ -198,31 +311,76 @@ func (p *processor) Process(ctx context.Context) error {
 	// After startup scan, also retry any committing prompts.
 	p.committingRecoverer.RecoverAll(ctx)
 
-	// Listen for ready signals from watcher
-	ticker := time.NewTicker(p.queueInterval)
-	defer ticker.Stop()
+	// Periodic queue poll backing off while the queue stays idle; the watcher's
+	// ready signals still trigger an immediate scan.
+	poll := newPollInterval(p.queueInterval, p.queueMaxInterval)
+	queueTimer := time.NewTimer(poll.Current())
+	defer queueTimer.Stop()
 
 	// Slow self-healing sweep: catches specs stuck in `prompted` if the per-prompt
 	// CheckAndComplete missed (daemon crash mid-completion, race, future regression).
-	// Cadence kept slower than the queue ticker because the sweep is more expensive.
+	// Cadence kept slower than the queue poll because the sweep is more expensive.
 	sweepTicker := time.NewTicker(p.sweepInterval)
 	defer sweepTicker.Stop()
 
+	// readyC is non-nil while a debounced ready scan is pending.
+	var readyTimer *time.Timer
+	var readyC <-chan time.Time
+	defer func() {
+		if readyTimer != nil {
+			readyTimer.Stop()
+		}
+	}()
+
 	for {
+		// Checked before the other cases so a pending tick cannot start another prompt.
+		select {
+		case <-p.shutdown:
+			log.From(ctx).Info("shutdown requested, processor stopping")
+			return ErrShutdownRequested
+		default:
+		}
+
 		select {
 		case <-ctx.Done():
 			log.From(ctx).Info("processor shutting down")
 			return nil
 
+		case <-p.shutdown:
+			log.From(ctx).Info("shutdown requested, processor stopping")
+			return ErrShutdownRequested
+
 		case <-p.wakeup:
+			poll.Reset()
+			queueTimer.Reset(poll.Current())
+			if p.readyDebounce <= 0 {
+				if err := p.runReadyTick(ctx, cancel); err != nil {
+					return err
+				}
+				continue
+			}
+			if readyC == nil {
+				readyTimer = time.NewTimer(p.readyDebounce)
+				readyC = readyTimer.C
+			}
+
+		case <-readyC:
+			readyC = nil
 			if err := p.runReadyTick(ctx, cancel); err != nil {
 				return err
 			}
 
-		case <-ticker.C:
-			if err := p.runQueueTick(ctx, cancel); err != nil {
+		case <-queueTimer.C:
+			progress, err := p.runQueueTick(ctx, cancel)
+			if err != nil {
 				return err
 			}
+			if progress {
+				poll.Reset()
+			} else {
+				poll.Idle()
+			}
+			queueTimer.Reset(poll.Current())
 
 		case <-sweepTicker.C:
 			if !p.runSweepTick(ctx) {
@@ -232,6 +390,36 @@ func (p *processor) Process(ctx context.Context) error {
 	}
 }
 
+// dryRunQueue logs title, container name and version bump of every queued prompt.
+// Prompt files stay untouched and no executor or git operation runs. The bump is
+// the prompt's bump frontmatter or the one derived from the changelog.
+func (p *processor) dryRunQueue(ctx context.Context) error {
+	queued, err := p.promptManager.ListQueued(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, "list queued prompts")
+	}
+	for _, pr := range queued {
+		pf, err := p.promptManager.Load(ctx, pr.Path)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "load prompt %s", filepath.Base(pr.Path))
+		}
+		_, containerName := computePromptMetadata(pr.Path, p.projectName, p.dirs.Scope)
+		bump, ok := pf.Frontmatter.VersionBump()
+		if !ok {
+			bump = git.DetermineBumpFromChangelog(ctx, ".")
+		}
+		log.From(ctx).Info(
+			"dry-run: would execute prompt",
+			"file", filepath.Base(pr.Path),
+			"title", pf.Title(),
+			"container", containerName,
+			"bump", bump.String(),
+		)
+	}
+	log.From(ctx).Info("dry-run: queue scanned", "prompts", len(queued))
+	return nil
+}
+
 // runReadyTick handles a watcher-ready event.
 // Returns ErrPreflightFailed if the baseline is broken; fires onIdle if no progress; otherwise nil.
 func (p *processor) runReadyTick(ctx context.Context, cancel context.CancelFunc) error {
@@ -251,32 +439,55 @@ func (p *processor) runReadyTick(ctx context.Context, cancel context.CancelFunc)
 	return nil
 }
 
-// runQueueTick handles a periodic queue poll.
+// runQueueTick handles a periodic queue poll and reports whether it made progress.
 // Returns ErrPreflightFailed if the baseline is broken; fires onIdle if no progress; otherwise nil.
-func (p *processor) runQueueTick(ctx context.Context, cancel context.CancelFunc) error {
+func (p *processor) runQueueTick(ctx context.Context, cancel context.CancelFunc) (bool, error) {
 	p.committingRecoverer.RecoverAll(ctx)
 	completed, err := p.queueScanner.ScanAndProcess(ctx)
 	if err != nil {
 		if stderrors.Is(err, ErrPreflightFailed) {
-			return err
+			return false, err
 		}
 		log.From(ctx).Warn("prompt failed; queue blocked until manual retry", "error", err)
 	}
-	if !(tickResult{completedPrompts: completed}).madeProgress() {
+	progress := (tickResult{completedPrompts: completed}).madeProgress()
+	if !progress {
 		p.onIdle(ctx, cancel)
 	}
-	return nil
+	return progress, nil
 }
 
-// runSweepTick handles a periodic spec sweep. Returns true if the tick made progress.
+// runSweepTick handles a periodic spec sweep and completed-prompt retention.
+// Returns true if the tick made progress.
 func (p *processor) runSweepTick(ctx context.Context) bool {
 	transitioned, err := p.specSweeper.Sweep(ctx)
 	if err != nil {
 		log.From(ctx).Warn("periodic spec sweep failed", "error", err)
 	}
+	// Retention deletes are housekeeping, not progress.
+	p.pruneCompleted(ctx)
 	return (tickResult{transitionedSpecs: transitioned}).madeProgress()
 }
 
+// pruneCompleted applies the completed-prompt retention and commits the deletions on
+// their own, so they neither end up in the next prompt's commit nor count towards the
+// dirty-file threshold.
+func (p *processor) pruneCompleted(ctx context.Context) {
+	gitMu.Lock()
+	defer gitMu.Unlock()
+	pruned, err := p.promptManager.PruneCompleted(ctx)
+	if err != nil {
+		log.From(ctx).Warn("prune completed prompts failed", "error", err)
+	}
+	if len(pruned) == 0 || p.releaser == nil {
+		return
+	}
+	message := fmt.Sprintf("prune %d completed prompts", len(pruned))
+	if err := p.releaser.CommitPaths(ctx, message, p.dirs.Completed); err != nil {
+		log.From(ctx).Warn("commit pruned completed prompts failed", "error", err)
+	}
+}
+
 // ResumeExecuting resumes any prompts still in "executing" state on startup.
 // Called once by the runner before the normal event loop begins.
 func (p *processor) ResumeExecuting(ctx context.Context) error {
@@ -290,7 +501,32 @@ func (p *processor) ResumeCommitting(ctx context.Context) error {
 }
 
 // ProcessPrompt executes a single prompt and commits the result.
-func (p *processor) ProcessPrompt(ctx context.Context, pr prompt.Prompt) error {
+// A panic anywhere in the per-prompt flow is recovered and returned as an error,
+// so the failure handler marks the prompt failed (or re-queues it) and the daemon
+// keeps running. Deferred cleanups of the flow still run during the unwind.
+// Every failed execution is counted in the prompt metrics.
+func (p *processor) ProcessPrompt(ctx context.Context, pr prompt.Prompt) (err error) {
+	defer func() {
+		if err != nil && ctx.Err() == nil && !stderrors.Is(err, ErrPreflightFailed) {
+			p.promptMetrics.PromptFailed()
+		}
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			log.From(ctx).Error(
+				"prompt processing panicked",
+				"file", filepath.Base(pr.Path),
+				"panic", r,
+				"stack", string(debug.Stack()),
+			)
+			err = errors.Errorf(ctx, "panic while processing prompt: %v", r)
+		}
+	}()
+	return p.processPrompt(ctx, pr)
+}
+
+// processPrompt holds the per-prompt flow guarded by ProcessPrompt.
+func (p *processor) processPrompt(ctx context.Context, pr prompt.Prompt) error {
 	if skip, err := p.preflightConditions.ShouldSkip(ctx); err != nil {
 		if stderrors.Is(err, ErrPreflightFailed) {
 			return err // propagate sentinel unwrapped so caller can recognize it
@@ -304,12 +540,41 @@ func (p *processor) ProcessPrompt(ctx context.Context, pr prompt.Prompt) error {
 	if err != nil {
 		return errors.Wrap(ctx, err, "load prompt")
 	}
-	content, err := pf.Content()
+	if err := p.fetchSourceBody(ctx, pf); err != nil {
+		return err
+	}
+	content, err := pf.ExpandedContent(ctx, p.dirs.Inbox)
 	if err != nil {
 		return p.handleEmptyPrompt(ctx, pr.Path, err)
 	}
+	if length := pf.InstructionLength(); length < p.minBodyLength {
+		return p.handleShortPrompt(ctx, pr.Path, length)
+	}
+	if err := pf.Frontmatter.ValidateCommand(ctx); err != nil {
+		return errors.Wrap(ctx, err, "validate command override")
+	}
+	if err := pf.Frontmatter.ValidateImage(ctx); err != nil {
+		return errors.Wrap(ctx, err, "validate image override")
+	}
+	if err := pf.Frontmatter.ValidateTimeout(ctx); err != nil {
+		return errors.Wrap(ctx, err, "validate timeout override")
+	}
+	if err := pf.Frontmatter.ValidateEnv(ctx); err != nil {
+		return errors.Wrap(ctx, err, "validate env override")
+	}
+	if err := pf.Frontmatter.ValidateVolumes(ctx); err != nil {
+		return errors.Wrap(ctx, err, "validate volumes override")
+	}
+	timeout := pf.Frontmatter.EffectiveTimeout(p.maxPromptDuration)
+	ctx = executor.WithLaunchOverrides(ctx, executor.LaunchOverrides{
+		Command:           pf.Frontmatter.Command,
+		Image:             pf.Frontmatter.Image,
+		Env:               pf.Frontmatter.Env,
+		Volumes:           pf.Frontmatter.Volumes,
+		MaxPromptDuration: timeout,
+	})
 
-	baseName, executionID := computePromptMetadata(pr.Path, p.projectName)
+	baseName, executionID := computePromptMetadata(pr.Path, p.projectName, p.dirs.Scope)
 	title := pf.Title()
 	if title == "" {
 		title = strings.TrimSuffix(filepath.Base(pr.Path), ".md")
@@ -322,7 +587,11 @@ func (p *processor) ProcessPrompt(ctx context.Context, pr prompt.Prompt) error {
 	}
 	ctx = bindPromptLogger(ctx, baseName.String(), specID, "", p.workflowType.String())
 
-	log.From(ctx).Info("executing prompt", "title", title)
+	if entry, ok := p.lookupResultCache(ctx, content); ok {
+		return p.completeFromResultCache(ctx, pf, baseName, pr.Path, title, entry)
+	}
+
+	log.From(ctx).Info("executing prompt", log.Event(log.EventExecuting), "title", title)
 
 	// Derive log file path before Setup, which may os.Chdir to clone/worktree dir.
 	logFile, err := filepath.Abs(filepath.Join(p.dirs.Log, string(baseName)+".log"))
@@ -330,14 +599,25 @@ func (p *processor) ProcessPrompt(ctx context.Context, pr prompt.Prompt) error {
 		return errors.Wrap(ctx, err, "resolve log file path")
 	}
 
+	releaseProjectTree, err := p.acquireProjectTree(ctx)
+	if err != nil {
+		return err
+	}
+	defer releaseProjectTree()
+
 	// Setup workflow (sync, branch or clone) before execution.
 	// This is intentionally done BEFORE persisting the container name (pf.Save) so that
 	// if sync fails, the prompt file is not modified and checkPostExecutionFailure can
 	// correctly detect pre-execution failures vs post-execution failures.
-	if err := p.workflowExecutor.Setup(ctx, baseName, pf); err != nil {
+	if err := p.setupWorkflow(ctx, baseName, pf); err != nil {
 		return errors.Wrap(ctx, err, "setup workflow")
 	}
 	defer p.workflowExecutor.CleanupOnError(ctx)
+	ctx, removeTree, err := p.addPromptTree(ctx, executionID)
+	if err != nil {
+		return err
+	}
+	defer removeTree()
 
 	// Persist container name and version AFTER sync succeeds (so resume can find the container).
 	pf.PrepareForExecution(executionID.String(), p.versionGetter.Get())
@@ -362,16 +642,194 @@ func (p *processor) ProcessPrompt(ctx context.Context, pr prompt.Prompt) error {
 	// Release the container lock once the container has started (not after it exits).
 	p.executionSlotManager.ReleaseAfterStart(ctx, executionID.String(), releaseLock)
 
-	cancelled, execErr := p.runContainer(ctx, content, logFile, executionID, pr.Path)
+	cancelled, execErr := p.runContainer(ctx, content, logFile, executionID, pr.Path, timeout)
 	if cancelled {
 		p.moveCancelledPrompt(ctx, pr.Path)
 		return nil // proceed to next prompt
 	}
+	if stderrors.Is(execErr, errPromptRequeued) {
+		return nil // prompt stays queued for the next scan
+	}
+	if err := p.recordExitCode(ctx, pf, execErr); err != nil {
+		return err
+	}
 	if execErr != nil {
 		return execErr
 	}
 
-	return p.completeAfterExecution(ctx, pf, logFile, pr.Path, title)
+	ctx, releasedVersion := withReleaseRecorder(ctx)
+	ctx, committed := withCommitRecorder(ctx)
+	if err := p.completeAfterExecution(ctx, pf, logFile, pr.Path, title); err != nil {
+		return err
+	}
+	p.recordResultCache(ctx, pf, content, pr.Path, committed())
+	if !p.verificationGate {
+		p.promptMetrics.PromptCompleted(pf.Elapsed())
+	}
+	p.notifyCompleted(ctx, pr.Path, title, releasedVersion())
+	p.pruneLogs(ctx)
+	return nil
+}
+
+// recordExitCode saves the exit code of the execution to the prompt frontmatter,
+// before the prompt is completed or the failure handler marks it failed. Nothing
+// is saved when the code is unknown, e.g. after a timeout, or during shutdown.
+func (p *processor) recordExitCode(
+	ctx context.Context,
+	pf *prompt.PromptFile,
+	execErr error,
+) error {
+	code, ok := executor.ExitCode(execErr)
+	if !ok || ctx.Err() != nil {
+		return nil
+	}
+	pf.SetExitCode(code)
+	if err := pf.Save(ctx); err != nil {
+		return errors.Wrap(ctx, err, "save exit code")
+	}
+	return nil
+}
+
+// pruneLogs applies the log retention. Failures are logged and never fail a prompt.
+func (p *processor) pruneLogs(ctx context.Context) {
+	if p.logPruner == nil {
+		return
+	}
+	if _, err := p.logPruner.Prune(ctx); err != nil {
+		log.From(ctx).Warn("prune prompt logs failed", "error", err)
+	}
+}
+
+// notifyCompleted fires prompt_completed for a committed prompt. Prompts parked in
+// pending_verification are not committed yet and are skipped, as are prompts whose
+// commit failed and were rolled back out of the completed directory. The completed
+// file is reloaded so the event carries the PR URL saved by the workflow. tag is the
+// version released for the prompt, empty when none was. Delivery failures are
+// logged and never fail the prompt.
+func (p *processor) notifyCompleted(ctx context.Context, promptPath, title, tag string) {
+	if p.promptNotifier == nil || p.verificationGate {
+		return
+	}
+	completedPath := prompt.CompletedFilePath(p.dirs.Completed, filepath.Base(promptPath))
+	pf, err := p.promptManager.Load(ctx, completedPath)
+	if err != nil {
+		log.From(ctx).Debug("skip prompt notification, prompt not completed", "error", err)
+		return
+	}
+	if err := p.promptNotifier.Notify(ctx, notifier.Event{
+		ProjectName: p.projectName.String(),
+		EventType:   "prompt_completed",
+		PromptName:  filepath.Base(promptPath),
+		PRURL:       pf.PRURL(),
+		Owner:       pf.Frontmatter.Owner,
+		Title:       title,
+		Status:      string(prompt.CompletedPromptStatus),
+		Version:     pf.Frontmatter.DarkFactoryVersion,
+		Tag:         tag,
+		Container:   pf.Frontmatter.Container,
+		Duration:    pf.Elapsed(),
+	}); err != nil {
+		log.From(ctx).Warn("prompt notification failed", "error", err)
+	}
+}
+
+// fetchSourceBody replaces the body of a prompt with source_url frontmatter by
+// the fetched content. The body is persisted with the execution metadata, so the
+// completed file keeps the text that actually ran.
+func (p *processor) fetchSourceBody(ctx context.Context, pf *prompt.PromptFile) error {
+	if pf.Frontmatter.SourceURL == "" {
+		return nil
+	}
+	body, err := p.sourceFetcher.Fetch(ctx, pf.Frontmatter.SourceURL)
+	if err != nil {
+		return errors.Wrap(ctx, err, "fetch source_url")
+	}
+	log.From(ctx).Info("fetched prompt body", "source_url", pf.Frontmatter.SourceURL)
+	pf.Body = []byte(body)
+	return nil
+}
+
+// lookupResultCache returns the cached result for content, if caching is enabled and a
+// prior identical execution was recorded. With the verification gate every prompt runs,
+// as a cached result would be committed without verification.
+func (p *processor) lookupResultCache(
+	ctx context.Context,
+	content string,
+) (*resultcache.Entry, bool) {
+	if p.resultCache == nil || p.verificationGate {
+		return nil, false
+	}
+	return p.resultCache.Lookup(
+		ctx, content, p.versionGetter.Get(), executor.LaunchOverridesFrom(ctx),
+	)
+}
+
+// completeFromResultCache completes the prompt without running a container, noting
+// which earlier prompt and commit produced the reused result. The prompt takes the
+// workflow Setup and Complete of a normal run, so the move to completed is committed,
+// and is counted and notified like any completed prompt.
+func (p *processor) completeFromResultCache(
+	ctx context.Context,
+	pf *prompt.PromptFile,
+	baseName prompt.BaseName,
+	promptPath, title string,
+	entry *resultcache.Entry,
+) error {
+	log.From(ctx).Info("result cache hit, skipping execution",
+		"cached_prompt", entry.PromptFile,
+		"cached_commit", entry.Commit,
+		"recorded_at", entry.RecordedAt,
+	)
+	note := fmt.Sprintf("reused cached result of %s", entry.PromptFile)
+	if entry.Commit != "" {
+		note += fmt.Sprintf(" (commit %s)", entry.Commit)
+	}
+	if entry.Summary != "" {
+		note += ": " + entry.Summary
+	}
+	pf.SetSummary(note)
+
+	releaseProjectTree, err := p.acquireProjectTree(ctx)
+	if err != nil {
+		return err
+	}
+	defer releaseProjectTree()
+	if err := p.setupWorkflow(ctx, baseName, pf); err != nil {
+		return errors.Wrap(ctx, err, "setup workflow")
+	}
+	defer p.workflowExecutor.CleanupOnError(ctx)
+	if err := pf.Save(ctx); err != nil {
+		return errors.Wrap(ctx, err, "save cached summary")
+	}
+
+	ctx, releasedVersion := withReleaseRecorder(ctx)
+	gitCtx := releaseContext(ctx, context.WithoutCancel(ctx), pf, title)
+	completedPath := pf.CompletedPath(p.dirs.Completed, p.dirs.CompletedLayout, promptPath)
+	if err := p.completeWorkflow(gitCtx, ctx, pf, title, promptPath, completedPath); err != nil {
+		return err
+	}
+	p.promptMetrics.PromptCompleted(pf.Elapsed())
+	p.notifyCompleted(ctx, promptPath, title, releasedVersion())
+	return nil
+}
+
+// recordResultCache stores a successful execution with the commit holding its changes.
+// Prompts parked in pending_verification are not recorded — they are not known-good
+// until verified.
+func (p *processor) recordResultCache(
+	ctx context.Context,
+	pf *prompt.PromptFile,
+	content, promptPath, commit string,
+) {
+	if p.resultCache == nil || p.verificationGate {
+		return
+	}
+	overrides := executor.LaunchOverridesFrom(ctx)
+	p.resultCache.Record(ctx, content, p.versionGetter.Get(), overrides, resultcache.Entry{
+		PromptFile: filepath.Base(promptPath),
+		Summary:    pf.Frontmatter.Summary,
+		Commit:     commit,
+	})
 }
 
 // completeAfterExecution runs the post-container phase: report validation, then workflow Complete.
@@ -381,7 +839,13 @@ func (p *processor) completeAfterExecution(
 	logFile, promptPath, title string,
 ) error {
 	gitCtx := context.WithoutCancel(ctx)
-	completedPath := filepath.Join(p.dirs.Completed, filepath.Base(promptPath))
+	completedPath := pf.CompletedPath(p.dirs.Completed, p.dirs.CompletedLayout, promptPath)
+
+	// The result file is consumed first so it never reaches a commit.
+	result, err := p.consumeResultFile(ctx)
+	if err != nil {
+		return err
+	}
 
 	// Verification gate: pause before git operations if enabled
 	if p.verificationGate {
@@ -393,26 +857,120 @@ func (p *processor) completeAfterExecution(
 		p.failureHandler.NotifyFromReport(ctx, logFile, promptPath)
 		return errors.Wrap(ctx, err, "validate completion report")
 	}
-	if completionReport != nil && completionReport.Summary != "" {
-		pf.SetSummary(completionReport.Summary)
+	summary := ""
+	if completionReport != nil {
+		summary = completionReport.Summary
+	}
+	if result != nil && result.Message != "" {
+		summary = result.Message
+	}
+	if summary != "" {
+		pf.SetSummary(summary)
 		if err := pf.Save(ctx); err != nil {
 			return errors.Wrap(ctx, err, "save summary")
 		}
 	}
+	if result != nil {
+		if bump, ok := result.VersionBump(); ok {
+			gitCtx = withBumpOverride(gitCtx, bump)
+		}
+	}
+	gitCtx = releaseContext(ctx, gitCtx, pf, title)
+	return p.completeWorkflow(gitCtx, ctx, pf, title, promptPath, completedPath)
+}
 
+// releaseContext applies the bump and skip-release frontmatter of pf to gitCtx.
+// The frontmatter bump overrides one already set on gitCtx.
+func releaseContext(
+	ctx, gitCtx context.Context,
+	pf *prompt.PromptFile,
+	title string,
+) context.Context {
+	if bump, ok := pf.Frontmatter.VersionBump(); ok {
+		gitCtx = withBumpOverride(gitCtx, bump)
+	} else if pf.Frontmatter.Bump != "" {
+		log.From(ctx).Warn(
+			"ignoring unknown bump frontmatter, using changelog bump",
+			"bump", pf.Frontmatter.Bump,
+		)
+	}
+	if skipsRelease(pf, title) {
+		gitCtx = withSkipRelease(gitCtx)
+	}
+	return gitCtx
+}
+
+// completeWorkflow applies the prompt's tree and runs the workflow Complete under gitMu.
+func (p *processor) completeWorkflow(
+	gitCtx, ctx context.Context,
+	pf *prompt.PromptFile,
+	title, promptPath, completedPath string,
+) error {
+	gitMu.Lock()
+	defer gitMu.Unlock()
+	if err := p.applyPromptTree(ctx); err != nil {
+		return err
+	}
 	return p.workflowExecutor.Complete(gitCtx, ctx, pf, title, promptPath, completedPath)
 }
 
+// setupWorkflow runs the workflow Setup under gitMu.
+func (p *processor) setupWorkflow(
+	ctx context.Context,
+	baseName prompt.BaseName,
+	pf *prompt.PromptFile,
+) error {
+	gitMu.Lock()
+	defer gitMu.Unlock()
+	return p.workflowExecutor.Setup(ctx, baseName, pf)
+}
+
+// consumeResultFile reads the result file from the workspace (the prompt's own tree,
+// or else the current directory, which is what the container mounts). A result other
+// than success fails the prompt even though the container exited zero.
+func (p *processor) consumeResultFile(ctx context.Context) (*resultfile.Result, error) {
+	workspace := executor.WorkspaceFrom(ctx)
+	if workspace == "" {
+		var err error
+		if workspace, err = os.Getwd(); err != nil {
+			return nil, errors.Wrap(ctx, err, "get workspace dir")
+		}
+	}
+	result, err := p.resultReader.Consume(ctx, workspace)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "consume result file")
+	}
+	if result == nil {
+		return nil, nil
+	}
+	log.From(ctx).Info("result file found", "status", result.Status, "bump", result.Bump)
+	if !result.Succeeded() {
+		return nil, errors.Errorf(
+			ctx,
+			"result file reports %s: %s",
+			result.Status,
+			result.Message,
+		)
+	}
+	return result, nil
+}
+
 // runContainer starts the YOLO container with a cancellation watcher and returns whether
 // the prompt was cancelled by the user and any execution error.
+// A timeout > 0 bounds the execution; when it expires the container is stopped.
 func (p *processor) runContainer(
 	ctx context.Context,
 	content, logFile string,
 	executionID prompt.ContainerName,
 	promptPath string,
+	timeout time.Duration,
 ) (cancelled bool, err error) {
 	execCtx, execCancel := context.WithCancel(ctx)
 	defer execCancel()
+	if timeout > 0 {
+		execCtx, execCancel = context.WithTimeout(execCtx, timeout)
+		defer execCancel()
+	}
 
 	cancelledCh := p.cancellationWatcher.Watch(execCtx, promptPath, executionID.String())
 
@@ -435,19 +993,37 @@ func (p *processor) runContainer(
 		log.From(ctx).Info("prompt cancelled", "workflow_step", "cancel")
 		return true, nil
 	}
+	if ctx.Err() == nil && stderrors.Is(execCtx.Err(), context.DeadlineExceeded) {
+		log.From(ctx).Warn("prompt exceeded timeout, stopping container",
+			"timeout", timeout,
+			"workflow_step", "run_claude",
+		)
+		p.executor.StopAndRemoveContainer(ctx, executionID.String())
+		return false, errors.Errorf(ctx, "prompt timed out after %s", timeout)
+	}
 	if execErr != nil {
 		// Deterministic fallback: goroutine may not have been scheduled before Execute
 		// returned. Re-read the prompt file — the CLI writes status=cancelled before
 		// stopping the container, so this is the ground truth.
-		if pf, loadErr := p.promptManager.Load(ctx, promptPath); loadErr == nil &&
-			promptstate.InterpretRawTuple(
+		if pf, loadErr := p.promptManager.Load(ctx, promptPath); loadErr == nil {
+			switch promptstate.InterpretRawTuple(
 				promptstate.LocationInProgress,
 				pf.Frontmatter.Status,
 				pf.Frontmatter.Container,
 				promptstate.DockerStateUnavailable,
-			) == promptstate.StateCancelled {
-			log.From(ctx).Info("prompt cancelled", "workflow_step", "cancel")
-			return true, nil
+			) {
+			case promptstate.StateCancelled:
+				log.From(ctx).Info("prompt cancelled", "workflow_step", "cancel")
+				return true, nil
+			case promptstate.StateApproved:
+				// `dark-factory cancel` re-queues the prompt before stopping its container;
+				// the container name it keeps identifies this execution.
+				if pf.Frontmatter.Container == executionID.String() {
+					log.From(ctx).
+						Info("prompt re-queued, container stopped", "workflow_step", "cancel")
+					return false, errPromptRequeued
+				}
+			}
 		}
 		if ctx.Err() != nil {
 			log.From(ctx).Info("daemon shutting down, leaving container running")
@@ -512,6 +1088,20 @@ func (p *processor) handleEmptyPrompt(
 	return errors.Wrap(ctx, contentErr, "get prompt content")
 }
 
+// handleShortPrompt moves a prompt whose body below the heading is shorter than
+// minBodyLength to completed without execution, like an empty prompt.
+func (p *processor) handleShortPrompt(ctx context.Context, promptPath string, length int) error {
+	log.From(ctx).Info(
+		"skipping prompt without instructions",
+		"body_length", length,
+		"min_body_length", p.minBodyLength,
+	)
+	if err := p.promptManager.MoveToCompleted(ctx, promptPath); err != nil {
+		return errors.Wrap(ctx, err, "move short prompt to completed")
+	}
+	return nil
+}
+
 // moveCancelledPrompt moves a cancelled prompt out of in-progress/ into cancelled/.
 // Non-fatal: if the move fails, we log a warning and continue — the cancelled status
 // already prevents the daemon from re-executing the prompt.
@@ -539,12 +1129,17 @@ func bindPromptLogger(
 }
 
 // computePromptMetadata derives the baseName and executionID from the prompt path and project name.
+// A non-empty scope (see Dirs.Scope) is part of the executionID.
 // It does NOT save to disk — call pf.PrepareForExecution + pf.Save separately after sync succeeds.
 func computePromptMetadata(
 	promptPath string,
 	projectName project.Name,
+	scope string,
 ) (prompt.BaseName, prompt.ContainerName) {
 	base := prompt.BaseName(strings.TrimSuffix(filepath.Base(promptPath), ".md"))
-	name := prompt.ContainerName(string(projectName) + "-exec-" + string(base)).Sanitize()
-	return base, name
+	raw := string(projectName) + "-exec-" + string(base)
+	if scope != "" {
+		raw = string(projectName) + "-exec-" + scope + "/" + string(base)
+	}
+	return base, prompt.ContainerName(raw).SanitizeUnique()
 }
@@ -251,6 +251,49 @@ var _ = Describe("Processor", func() {
 			},
 		)
 
+		It(
+			"pr=true, worktree=false, execution fails: switches back to the original branch",
+			func() {
+				promptPath := filepath.Join(promptsDir, "001-branch-fails.md")
+				queued := []prompt.Prompt{
+					{Path: promptPath, Status: prompt.ApprovedPromptStatus},
+				}
+
+				manager.LoadStub = func(_ context.Context, path string) (*prompt.PromptFile, error) {
+					return createBranchPromptFile(path, "dark-factory/test"), nil
+				}
+				manager.ListQueuedReturnsOnCall(0, queued, nil)
+				manager.ListQueuedReturnsOnCall(1, []prompt.Prompt{}, nil)
+				manager.AllPreviousCompletedReturns(true)
+				manager.AllPreviousInSpecCompletedReturns(true)
+				executor.ExecuteReturns(stderrors.New("container crashed"))
+
+				brancher.IsCleanIgnoringReturns(nil, nil)
+				brancher.DefaultBranchReturns("main", nil)
+				brancher.CurrentBranchReturns("develop", nil)
+				brancher.FetchAndVerifyBranchReturns(stderrors.New("not found"))
+				brancher.CreateAndSwitchReturns(nil)
+				brancher.SwitchReturns(nil)
+
+				p := newProcWithWorkflow(true, config.WorkflowBranch)
+				go func() {
+					_ = p.Process(ctx)
+				}()
+
+				Eventually(func() int {
+					return brancher.SwitchCallCount()
+				}, 2*time.Second, 50*time.Millisecond).Should(Equal(1))
+
+				Expect(executor.ExecuteCallCount()).To(Equal(1))
+				Expect(brancher.CreateAndSwitchCallCount()).To(Equal(1))
+				_, switchArg := brancher.SwitchArgsForCall(0)
+				Expect(switchArg).To(Equal("develop"))
+				Expect(manager.MoveToCompletedCallCount()).To(Equal(0))
+
+				cancel()
+			},
+		)
+
 		It(
 			"pr=true, worktree=false, branch set, clean, branch not on remote: CreateAndSwitch called",
 			func() {
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+	"github.com/bborbe/dark-factory/pkg/resultfile"
+)
+
+var _ = Describe("ProcessPrompt — bump frontmatter", func() {
+	var (
+		ctx          context.Context
+		promptPath   string
+		workflowExec *mocks.WorkflowExecutor
+		reader       *mocks.ResultFileReader
+		pp           processorPromptProcesser
+		writePrompt  func(frontmatter string)
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir := filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "007-fix-x.md")
+		writePrompt = func(frontmatter string) {
+			Expect(os.WriteFile(
+				promptPath,
+				[]byte("---\nstatus: approved\n"+frontmatter+"---\n# Fix X\n\nDo the thing"),
+				0600,
+			)).To(Succeed())
+		}
+
+		mgr := &mocks.ProcessorPromptManager{}
+		mgr.LoadStub = func(ctx context.Context, path string) (*prompt.PromptFile, error) {
+			return prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+				Load(ctx, path)
+		}
+		workflowExec = &mocks.WorkflowExecutor{}
+		reader = &mocks.ResultFileReader{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp = newProcessorWithResultCache(
+			logDir,
+			&mocks.Executor{},
+			mgr,
+			vg,
+			workflowExec,
+			nil,
+			nil,
+			reader,
+		)
+	})
+
+	DescribeTable("forces the version bump",
+		func(value string, expected git.VersionBump) {
+			writePrompt("bump: " + value + "\n")
+
+			Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+			Expect(workflowExec.CompleteCallCount()).To(Equal(1))
+			gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+			bump, ok := processor.BumpOverrideFromForTest(gitCtx)
+			Expect(ok).To(BeTrue())
+			Expect(bump).To(Equal(expected))
+		},
+		Entry("patch", "patch", git.PatchBump),
+		Entry("minor", "minor", git.MinorBump),
+		Entry("major", "major", git.MajorBump),
+	)
+
+	It("falls back to the changelog bump without bump frontmatter", func() {
+		writePrompt("")
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		_, ok := processor.BumpOverrideFromForTest(gitCtx)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("ignores an unknown bump value and falls back to the changelog bump", func() {
+		writePrompt("bump: huge\n")
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		_, ok := processor.BumpOverrideFromForTest(gitCtx)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("wins over the bump of the result file", func() {
+		writePrompt("bump: minor\n")
+		reader.ConsumeReturns(&resultfile.Result{
+			Status: resultfile.StatusSuccess,
+			Bump:   "patch",
+		}, nil)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		bump, ok := processor.BumpOverrideFromForTest(gitCtx)
+		Expect(ok).To(BeTrue())
+		Expect(bump).To(Equal(git.MinorBump))
+	})
+})
@@ -54,7 +54,7 @@ func newProcessorWithMockWatcher(
 	enricherReleaser.CommitWithRetryStub = func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }
 	enricherReleaser.HasChangelogReturns(false)
 
-	fh := failurehandler.NewHandler(mgr, notifier.NewMultiNotifier(), "", project.Name("test"), 0)
+	fh := failurehandler.NewHandler(mgr, notifier.NewMultiNotifier(), "", project.Name("test"), 0, 0)
 	resumer := promptresumer.NewResumer(
 		mgr,
 		exec,
@@ -63,12 +63,14 @@ func newProcessorWithMockWatcher(
 		fh,
 		"",
 		"",
+		prompt.CompletedLayoutFlat,
 		logDir,
 		project.Name("test"),
 		0,
+		nil,
 	)
 	ppForwarder := &lazyProcessorForwarder{}
-	scanner := queuescanner.NewScanner(mgr, ppForwarder, fh, "", nil, 0)
+	scanner := queuescanner.NewScanner(mgr, ppForwarder, fh, "", nil, 0, nil, nil, nil, 0, true)
 
 	proc := processor.NewProcessor(
 		exec,
@@ -82,6 +84,7 @@ func newProcessorWithMockWatcher(
 		executionslot.NewManager(nil, nil, nil, 0, 0),
 		cancellationWatcher,
 		make(chan struct{}),
+		nil,
 		processor.Dirs{Log: logDir},
 		project.Name("test"),
 		fh,
@@ -100,6 +103,18 @@ func newProcessorWithMockWatcher(
 		),
 		committingrecoverer.NewRecoverer(mgr, nil, nil, "", false),
 		scanner,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		nil,
+		false,
+		0,
+		0,
 		0,
 		0,
 		nil,
@@ -262,4 +277,72 @@ var _ = Describe("ProcessPrompt — cancellation", func() {
 		_, cancelledPath := mgr.MoveToCancelledArgsForCall(0)
 		Expect(cancelledPath).To(Equal(promptPath))
 	})
+
+	It("leaves a re-queued prompt in the queue when Execute returns after a cancel", func() {
+		tempDir, err := os.MkdirTemp("", "processor-requeue-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = os.RemoveAll(tempDir) }()
+
+		logDir := filepath.Join(tempDir, "log")
+		err = os.MkdirAll(logDir, 0750)
+		Expect(err).NotTo(HaveOccurred())
+
+		promptPath := filepath.Join(tempDir, "003-requeue-test.md")
+		err = os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Requeue test\n\nTest content"),
+			0600,
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx := context.Background()
+
+		// Execute returns with an error, as it does when the container is stopped
+		exec := &mocks.Executor{}
+		exec.ExecuteReturns(fmt.Errorf("exit status 143"))
+
+		mgr := &mocks.ProcessorPromptManager{}
+		mgr.LoadStub = func(_ context.Context, path string) (*prompt.PromptFile, error) {
+			frontmatter := prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)}
+			if exec.ExecuteCallCount() > 0 {
+				// `dark-factory cancel` keeps the container name when it re-queues
+				_, _, _, containerName := exec.ExecuteArgsForCall(0)
+				frontmatter.Container = containerName
+			}
+			return prompt.NewPromptFile(
+				path,
+				frontmatter,
+				[]byte("# Requeue test\n\nTest content"),
+				libtime.NewCurrentDateTime(),
+			), nil
+		}
+
+		fakeCancellationWatcher := &mocks.CancellationWatcher{}
+		fakeCancellationWatcher.WatchReturns(make(chan struct{}))
+
+		workflowExec := &mocks.WorkflowExecutor{}
+		workflowExec.SetupReturns(nil)
+
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+
+		pp := newProcessorWithMockWatcher(
+			logDir,
+			exec,
+			mgr,
+			vg,
+			fakeCancellationWatcher,
+			workflowExec,
+		)
+
+		pr := prompt.Prompt{Path: promptPath, Status: prompt.ApprovedPromptStatus}
+
+		testCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		err = pp.ProcessPrompt(testCtx, pr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.MoveToCancelledCallCount()).To(Equal(0))
+		Expect(mgr.MoveToCompletedCallCount()).To(Equal(0))
+	})
 })
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — command override", func() {
+	var (
+		ctx        context.Context
+		logDir     string
+		promptPath string
+		exec       *mocks.Executor
+		mgr        *mocks.ProcessorPromptManager
+		pp         processorPromptProcesser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "003-command.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Command\n\nRun tests"),
+			0600,
+		)).To(Succeed())
+
+		mgr = &mocks.ProcessorPromptManager{}
+		exec = &mocks.Executor{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp = newProcessorWithResultCache(
+			logDir,
+			exec,
+			mgr,
+			vg,
+			&mocks.WorkflowExecutor{},
+			nil,
+			nil,
+			nil,
+		)
+	})
+
+	loadWithCommand := func(command []string) {
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{
+					Status:  string(prompt.ApprovedPromptStatus),
+					Command: command,
+				},
+				[]byte("# Command\n\nRun tests"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+	}
+
+	It("passes the frontmatter command to the executor", func() {
+		loadWithCommand([]string{"make", "test"})
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+		execCtx, _, _, _ := exec.ExecuteArgsForCall(0)
+		Expect(executor.LaunchOverridesFrom(execCtx).Command).To(Equal([]string{"make", "test"}))
+	})
+
+	It("leaves the image command untouched without an override", func() {
+		loadWithCommand(nil)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		execCtx, _, _, _ := exec.ExecuteArgsForCall(0)
+		Expect(executor.LaunchOverridesFrom(execCtx).Command).To(BeEmpty())
+	})
+
+	It("rejects a shell-string command before launching", func() {
+		loadWithCommand([]string{"make test && rm -rf /"})
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("validate command override"))
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+	})
+})
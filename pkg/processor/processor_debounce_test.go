@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/completionreport"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/executionslot"
+	"github.com/bborbe/dark-factory/pkg/preflightconditions"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/project"
+)
+
+var _ = Describe("Process — ready debounce", func() {
+	var (
+		ctx      context.Context
+		cancel   context.CancelFunc
+		wakeup   chan struct{}
+		scanner  *mocks.QueueScanner
+		errCh    chan error
+		debounce time.Duration
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		wakeup = make(chan struct{}, 10)
+		scanner = &mocks.QueueScanner{}
+		errCh = make(chan error, 1)
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(errCh).Should(Receive(BeNil()))
+	})
+
+	start := func() {
+		fakeCancellationWatcher := &mocks.CancellationWatcher{}
+		fakeCancellationWatcher.WatchReturns(make(chan struct{}))
+		proc := processor.NewProcessor(
+			&mocks.Executor{},
+			&mocks.ProcessorPromptManager{},
+			nil,
+			&mocks.VersionGetter{},
+			&mocks.WorkflowExecutor{},
+			nil,
+			&mocks.Sweeper{},
+			preflightconditions.NewConditions(nil, nil, nil, 0),
+			executionslot.NewManager(nil, nil, nil, 0, 0),
+			fakeCancellationWatcher,
+			wakeup,
+			nil,
+			processor.Dirs{},
+			project.Name("test"),
+			nil,
+			nil,
+			config.WorkflowDirect,
+			false,
+			completionreport.NewValidator(),
+			nil,
+			&mocks.CommittingRecoverer{},
+			scanner,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			0,
+			0,
+			nil,
+			nil,
+			false,
+			time.Hour,
+			time.Hour,
+			time.Hour,
+			debounce,
+			nil,
+		)
+		go func() { errCh <- proc.Process(ctx) }()
+		// Startup scan has run once the loop is listening.
+		Eventually(scanner.ScanAndProcessCallCount).Should(Equal(1))
+	}
+
+	sendBurst := func(n int) {
+		for i := 0; i < n; i++ {
+			wakeup <- struct{}{}
+		}
+	}
+
+	It("coalesces a burst of ready signals into one scan", func() {
+		debounce = 100 * time.Millisecond
+		start()
+
+		sendBurst(5)
+
+		Eventually(scanner.ClearSkippedCacheCallCount).Should(Equal(1))
+		Consistently(scanner.ClearSkippedCacheCallCount, 300*time.Millisecond).Should(Equal(1))
+		Expect(scanner.ScanAndProcessCallCount()).To(Equal(2))
+	})
+
+	It("scans again for signals arriving after the debounce fired", func() {
+		debounce = 50 * time.Millisecond
+		start()
+
+		sendBurst(3)
+		Eventually(scanner.ClearSkippedCacheCallCount).Should(Equal(1))
+
+		sendBurst(3)
+		Eventually(scanner.ClearSkippedCacheCallCount).Should(Equal(2))
+		Consistently(scanner.ClearSkippedCacheCallCount, 200*time.Millisecond).Should(Equal(2))
+	})
+
+	It("scans on every signal when debounce is disabled", func() {
+		debounce = 0
+		start()
+
+		sendBurst(5)
+
+		Eventually(scanner.ClearSkippedCacheCallCount).Should(Equal(5))
+	})
+})
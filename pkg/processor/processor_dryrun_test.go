@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/completionreport"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/executionslot"
+	"github.com/bborbe/dark-factory/pkg/preflightconditions"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/project"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Process — dry-run", func() {
+	var (
+		ctx          context.Context
+		queueDir     string
+		exec         *mocks.Executor
+		releaser     *mocks.Releaser
+		workflowExec *mocks.WorkflowExecutor
+		scanner      *mocks.QueueScanner
+		sweeper      *mocks.Sweeper
+		mgr          *mocks.ProcessorPromptManager
+		proc         processor.Processor
+	)
+
+	writePrompt := func(name string, content string) string {
+		path := filepath.Join(queueDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		queueDir = GinkgoT().TempDir()
+		exec = &mocks.Executor{}
+		releaser = &mocks.Releaser{}
+		workflowExec = &mocks.WorkflowExecutor{}
+		scanner = &mocks.QueueScanner{}
+		sweeper = &mocks.Sweeper{}
+
+		realManager := prompt.NewManager("", queueDir, "", "", nil, libtime.NewCurrentDateTime())
+		mgr = &mocks.ProcessorPromptManager{}
+		mgr.ListQueuedStub = realManager.ListQueued
+		mgr.LoadStub = realManager.Load
+
+		proc = processor.NewProcessor(
+			exec,
+			mgr,
+			releaser,
+			&mocks.VersionGetter{},
+			workflowExec,
+			nil,
+			sweeper,
+			preflightconditions.NewConditions(nil, nil, nil, 0),
+			executionslot.NewManager(nil, nil, nil, 0, 0),
+			&mocks.CancellationWatcher{},
+			make(chan struct{}),
+			nil,
+			processor.Dirs{},
+			project.Name("test"),
+			nil,
+			nil,
+			config.WorkflowDirect,
+			false,
+			completionreport.NewValidator(),
+			nil,
+			&mocks.CommittingRecoverer{},
+			scanner,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			0,
+			0,
+			nil,
+			nil,
+			true,
+			0,
+			0,
+			0,
+			0,
+			nil,
+		)
+	})
+
+	It("reports every queued prompt without executing, moving or committing", func() {
+		first := writePrompt("001-fix-x.md", "---\nstatus: approved\nbump: minor\n---\n# Fix X\n")
+		second := writePrompt("002-add-y.md", "---\nstatus: approved\n---\n# Add Y\n")
+		writePrompt("003-done.md", "---\nstatus: completed\n---\n# Done\n")
+
+		Expect(proc.Process(ctx)).To(Succeed())
+
+		Expect(mgr.ListQueuedCallCount()).To(Equal(1))
+		Expect(mgr.LoadCallCount()).To(Equal(2))
+		_, loaded := mgr.LoadArgsForCall(0)
+		Expect(loaded).To(Equal(first))
+		_, loaded = mgr.LoadArgsForCall(1)
+		Expect(loaded).To(Equal(second))
+
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+		Expect(releaser.Invocations()).To(BeEmpty())
+		Expect(workflowExec.Invocations()).To(BeEmpty())
+		Expect(scanner.ScanAndProcessCallCount()).To(Equal(0))
+		Expect(sweeper.SweepCallCount()).To(Equal(0))
+		Expect(mgr.MoveToCompletedCallCount()).To(Equal(0))
+	})
+
+	It("leaves the prompt files unchanged on disk", func() {
+		content := "---\nstatus: approved\n---\n# Fix X\n"
+		path := writePrompt("001-fix-x.md", content)
+
+		Expect(proc.Process(ctx)).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+	})
+
+	It("returns without blocking on an empty queue", func() {
+		Expect(proc.Process(ctx)).To(Succeed())
+		Expect(mgr.LoadCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Processor — exit code", func() {
+	var (
+		ctx        context.Context
+		promptPath string
+		logDir     string
+		exec       *mocks.Executor
+		mgr        *mocks.ProcessorPromptManager
+		pf         *prompt.PromptFile
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "008-exit-code.md")
+		Expect(os.WriteFile(promptPath, []byte("---\nstatus: approved\n---\n"), 0600)).
+			To(Succeed())
+
+		pf = prompt.NewPromptFile(
+			promptPath,
+			prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+			[]byte("# Exit code\n\nRecord how the container exited.\n"),
+			libtime.NewCurrentDateTime(),
+		)
+		mgr = &mocks.ProcessorPromptManager{}
+		mgr.LoadReturns(pf, nil)
+		exec = &mocks.Executor{}
+	})
+
+	process := func() error {
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp := newProcessorWithLogPruner(
+			logDir, exec, mgr, vg, &mocks.WorkflowExecutor{},
+			nil, nil, nil, nil, nil, 0, nil,
+		)
+		return pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+	}
+
+	savedFrontmatter := func() prompt.Frontmatter {
+		saved := prompt.NewManager("", filepath.Dir(promptPath), "", "", nil,
+			libtime.NewCurrentDateTime())
+		fm, err := saved.ReadFrontmatter(ctx, promptPath)
+		Expect(err).NotTo(HaveOccurred())
+		return *fm
+	}
+
+	It("stores exit code 0 after a successful execution", func() {
+		Expect(process()).To(Succeed())
+		Expect(pf.Frontmatter.ExitCode).NotTo(BeNil())
+		Expect(*pf.Frontmatter.ExitCode).To(Equal(0))
+	})
+
+	It("stores the exit code of a failed execution before the failure handler runs", func() {
+		exec.ExecuteReturns(&executor.ExitError{Code: 137, Err: errors.New("exit status 137")})
+
+		Expect(process()).NotTo(Succeed())
+		fm := savedFrontmatter()
+		Expect(fm.ExitCode).NotTo(BeNil())
+		Expect(*fm.ExitCode).To(Equal(137))
+	})
+
+	It("stores no exit code when it is unknown", func() {
+		exec.ExecuteReturns(errors.New("docker not found"))
+
+		Expect(process()).NotTo(Succeed())
+		Expect(savedFrontmatter().ExitCode).To(BeNil())
+	})
+})
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — image override", func() {
+	var (
+		ctx        context.Context
+		logDir     string
+		promptPath string
+		exec       *mocks.Executor
+		mgr        *mocks.ProcessorPromptManager
+		pp         processorPromptProcesser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "004-image.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Image\n\nRun tests"),
+			0600,
+		)).To(Succeed())
+
+		mgr = &mocks.ProcessorPromptManager{}
+		exec = &mocks.Executor{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp = newProcessorWithResultCache(
+			logDir,
+			exec,
+			mgr,
+			vg,
+			&mocks.WorkflowExecutor{},
+			nil,
+			nil,
+			nil,
+		)
+	})
+
+	loadWithImage := func(image string) {
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{
+					Status: string(prompt.ApprovedPromptStatus),
+					Image:  image,
+				},
+				[]byte("# Image\n\nRun tests"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+	}
+
+	It("passes the frontmatter image to the executor", func() {
+		loadWithImage("ghcr.io/org/agent-python:1.2")
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+		execCtx, _, _, _ := exec.ExecuteArgsForCall(0)
+		Expect(executor.LaunchOverridesFrom(execCtx).Image).To(Equal("ghcr.io/org/agent-python:1.2"))
+	})
+
+	It("falls back to the configured image without an override", func() {
+		loadWithImage("")
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		execCtx, _, _, _ := exec.ExecuteArgsForCall(0)
+		Expect(executor.LaunchOverridesFrom(execCtx).Image).To(BeEmpty())
+	})
+
+	It("rejects an invalid image reference before launching", func() {
+		loadWithImage("node 22; rm -rf /")
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("validate image override"))
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — includes", func() {
+	var (
+		ctx          context.Context
+		tempDir      string
+		promptPath   string
+		logDir       string
+		exec         *mocks.Executor
+		mgr          *mocks.ProcessorPromptManager
+		workflowExec *mocks.WorkflowExecutor
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "005-with-includes.md")
+		Expect(os.WriteFile(promptPath, []byte("---\nstatus: approved\n---\n"), 0600)).
+			To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tempDir, "api.md"), []byte("GET /users\n"), 0600)).
+			To(Succeed())
+
+		mgr = &mocks.ProcessorPromptManager{}
+		exec = &mocks.Executor{}
+		workflowExec = &mocks.WorkflowExecutor{}
+	})
+
+	process := func(includes ...string) error {
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{
+					Status:   string(prompt.ApprovedPromptStatus),
+					Includes: includes,
+				},
+				[]byte("# Add the client\n\nImplement the API below.\n"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp := newProcessorWithMinBodyLength(
+			logDir, exec, mgr, vg, workflowExec, nil, nil, nil, nil, nil, 0,
+		)
+		return pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+	}
+
+	It("passes the included files to the executor", func() {
+		Expect(process("api.md")).To(Succeed())
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+		_, content, _, _ := exec.ExecuteArgsForCall(0)
+		Expect(content).To(ContainSubstring("Implement the API below."))
+		Expect(content).To(ContainSubstring("--- include: api.md ---\n\nGET /users\n"))
+	})
+
+	It("does not execute a prompt whose include leaves the prompts directory", func() {
+		Expect(process("../outside.md")).To(MatchError(ContainSubstring("must stay inside")))
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+	})
+})
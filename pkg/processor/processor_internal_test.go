@@ -16,6 +16,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
@@ -214,6 +215,24 @@ var _ = Describe("DetermineBumpFromChangelog", func() {
 	})
 })
 
+var _ = Describe("computePromptMetadata", func() {
+	It("names the container after the project and the prompt", func() {
+		base, name := computePromptMetadata("prompts/001-setup.md", project.Name("proj"), "")
+		Expect(base).To(Equal(prompt.BaseName("001-setup")))
+		Expect(name).To(Equal(prompt.ContainerName("proj-exec-001-setup")))
+	})
+
+	It("tells equally named prompts of two scopes apart", func() {
+		_, primary := computePromptMetadata("a/001-setup.md", project.Name("proj"), "")
+		_, first := computePromptMetadata("b/001-setup.md", project.Name("proj"), "b/prompts")
+		_, second := computePromptMetadata("c/001-setup.md", project.Name("proj"), "c/prompts")
+		Expect(first).To(HavePrefix("proj-exec-b-prompts-001-setup-"))
+		Expect(second).To(HavePrefix("proj-exec-c-prompts-001-setup-"))
+		Expect(first).NotTo(Equal(primary))
+		Expect(first).NotTo(Equal(second))
+	})
+})
+
 var _ = Describe("ResumeExecuting delegates to Resumer", func() {
 	It("calls resumer.ResumeAll and returns its result", func() {
 		ctx := context.Background()
@@ -255,6 +274,10 @@ func (s *stubReleaser) CommitOnly(_ context.Context, _ string) error {
 	return nil
 }
 
+func (s *stubReleaser) CommitPaths(_ context.Context, _ string, _ ...string) error {
+	return nil
+}
+
 func (s *stubReleaser) CommitAndRelease(_ context.Context, _ git.VersionBump) error {
 	s.commitAndRelCalled++
 	return nil
@@ -264,7 +287,9 @@ func (s *stubReleaser) GetNextVersion(_ context.Context, _ git.VersionBump) (str
 	return s.nextVersion, nil
 }
 
-func (s *stubReleaser) CommitCompletedFile(_ context.Context, _ string) error { return nil }
+func (s *stubReleaser) CommitCompletedFile(_ context.Context, _, _ string) error {
+	return nil
+}
 
 func (s *stubReleaser) MoveFile(_ context.Context, _, _ string) error { return nil }
 
@@ -284,6 +309,10 @@ func (s *stubReleaser) CommitWithRetry(
 	return fn(ctx)
 }
 
+func (s *stubReleaser) HeadCommit(_ context.Context) (string, error) {
+	return "", nil
+}
+
 var _ = Describe("handleDirectWorkflow", func() {
 	var (
 		ctx    context.Context
@@ -374,6 +403,14 @@ func (s *stubWorktreer) Remove(_ context.Context, _ string) error {
 	return nil
 }
 
+func (s *stubWorktreer) AddDetached(_ context.Context, path string) (string, error) {
+	return path, nil
+}
+
+func (s *stubWorktreer) ApplyChanges(_ context.Context, _ string) error {
+	return nil
+}
+
 // callRecorder tracks the order of named operations across multiple stubs.
 type callRecorder struct {
 	mu  sync.Mutex
@@ -454,6 +491,10 @@ func (s *stubBrancher) Switch(_ context.Context, _ string) error {
 	return s.switchErr
 }
 
+func (s *stubBrancher) DeleteBranch(_ context.Context, _ string) error {
+	return nil
+}
+
 func (s *stubBrancher) IsClean(_ context.Context) (bool, error) {
 	return s.isClean, s.isCleanErr
 }
@@ -544,22 +585,30 @@ type stubWorkflowReleaser struct {
 	hasChangelog          bool
 	commitAndReleaseCount int
 	pushBranchCount       int
+	commitOnlyMessages    []string
+	releasedBumps         []git.VersionBump
 }
 
-func (s *stubWorkflowReleaser) CommitOnly(_ context.Context, _ string) error {
+func (s *stubWorkflowReleaser) CommitOnly(_ context.Context, message string) error {
 	s.commitOnlyCount++
+	s.commitOnlyMessages = append(s.commitOnlyMessages, message)
 	return s.commitOnlyErr
 }
 
-func (s *stubWorkflowReleaser) CommitCompletedFile(_ context.Context, _ string) error {
+func (s *stubWorkflowReleaser) CommitPaths(_ context.Context, _ string, _ ...string) error {
+	return nil
+}
+
+func (s *stubWorkflowReleaser) CommitCompletedFile(_ context.Context, _, _ string) error {
 	s.commitFileCount++
 	return s.commitFileErr
 }
 
 func (s *stubWorkflowReleaser) HasChangelog(_ context.Context) bool { return s.hasChangelog }
 
-func (s *stubWorkflowReleaser) CommitAndRelease(_ context.Context, _ git.VersionBump) error {
+func (s *stubWorkflowReleaser) CommitAndRelease(_ context.Context, bump git.VersionBump) error {
 	s.commitAndReleaseCount++
+	s.releasedBumps = append(s.releasedBumps, bump)
 	return nil
 }
 
@@ -588,6 +637,10 @@ func (s *stubWorkflowReleaser) CommitWithRetry(
 	return fn(ctx)
 }
 
+func (s *stubWorkflowReleaser) HeadCommit(_ context.Context) (string, error) {
+	return "", nil
+}
+
 // stubWorkflowManager tracks MoveToCompleted and HasQueuedPromptsOnBranch.
 type stubWorkflowManager struct {
 	moveToCompletedCount         int
@@ -704,6 +757,10 @@ func (s *stubWorkflowManager) FindMissingInSpecCompleted(
 	return -1
 }
 
+func (s *stubWorkflowManager) MissingDependencies(_ context.Context, _ []string) []string {
+	return nil
+}
+
 func (s *stubWorkflowManager) FindPromptStatusInProgress(_ context.Context, _ int) string {
 	return ""
 }
@@ -714,6 +771,10 @@ func (s *stubWorkflowManager) FindCommitting(
 	return nil, nil
 }
 
+func (s *stubWorkflowManager) PruneCompleted(_ context.Context) ([]string, error) {
+	return nil, nil
+}
+
 var _ = Describe("processor workflow routing", func() {
 	var (
 		ctx           context.Context
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — configured log dir", func() {
+	It("passes a log path inside the configured log dir to the executor", func() {
+		ctx := context.Background()
+		promptsDir := filepath.Join(GinkgoT().TempDir(), "prompts")
+		cfg := config.Defaults()
+		cfg.Prompts = config.NewPromptsConfig(promptsDir)
+		config.ApplyLogDir(&cfg, "agent-logs")
+		Expect(os.MkdirAll(cfg.Prompts.LogDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(cfg.Prompts.InProgressDir, 0750)).To(Succeed())
+		promptPath := filepath.Join(cfg.Prompts.InProgressDir, "006-log-dir.md")
+		Expect(os.WriteFile(promptPath, []byte("---\nstatus: approved\n---\n"), 0600)).
+			To(Succeed())
+
+		mgr := &mocks.ProcessorPromptManager{}
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+				[]byte("# Log dir\n\nWrite the log elsewhere.\n"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		exec := &mocks.Executor{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp := newProcessorWithMinBodyLength(
+			cfg.Prompts.LogDir, exec, mgr, vg, &mocks.WorkflowExecutor{},
+			nil, nil, nil, nil, nil, 0,
+		)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+		_, _, logFile, _ := exec.ExecuteArgsForCall(0)
+		Expect(logFile).To(Equal(filepath.Join(promptsDir, "agent-logs", "006-log-dir.log")))
+	})
+})
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/completionreport"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/executionslot"
+	"github.com/bborbe/dark-factory/pkg/preflightconditions"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/project"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Processor — log retention", func() {
+	var logPruner *mocks.LogPruner
+
+	BeforeEach(func() {
+		logPruner = &mocks.LogPruner{}
+	})
+
+	It("prunes logs on startup", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		scanner := &mocks.QueueScanner{}
+		fakeCancellationWatcher := &mocks.CancellationWatcher{}
+		fakeCancellationWatcher.WatchReturns(make(chan struct{}))
+		proc := processor.NewProcessor(
+			&mocks.Executor{},
+			&mocks.ProcessorPromptManager{},
+			nil,
+			&mocks.VersionGetter{},
+			&mocks.WorkflowExecutor{},
+			nil,
+			&mocks.Sweeper{},
+			preflightconditions.NewConditions(nil, nil, nil, 0),
+			executionslot.NewManager(nil, nil, nil, 0, 0),
+			fakeCancellationWatcher,
+			make(chan struct{}),
+			nil,
+			processor.Dirs{},
+			project.Name("test"),
+			nil,
+			nil,
+			config.WorkflowDirect,
+			false,
+			completionreport.NewValidator(),
+			nil,
+			&mocks.CommittingRecoverer{},
+			scanner,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			0,
+			0,
+			logPruner,
+			nil,
+			false,
+			time.Hour,
+			time.Hour,
+			time.Hour,
+			0,
+			nil,
+		)
+		errCh := make(chan error, 1)
+		go func() { errCh <- proc.Process(ctx) }()
+
+		Eventually(scanner.ScanAndProcessCallCount).Should(Equal(1))
+		Expect(logPruner.PruneCallCount()).To(Equal(1))
+		cancel()
+		Eventually(errCh).Should(Receive(BeNil()))
+	})
+
+	Describe("ProcessPrompt", func() {
+		var (
+			ctx        context.Context
+			promptPath string
+			logDir     string
+			exec       *mocks.Executor
+			mgr        *mocks.ProcessorPromptManager
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			tempDir := GinkgoT().TempDir()
+			logDir = filepath.Join(tempDir, "log")
+			Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+			promptPath = filepath.Join(tempDir, "007-retention.md")
+			Expect(os.WriteFile(promptPath, []byte("---\nstatus: approved\n---\n"), 0600)).
+				To(Succeed())
+
+			mgr = &mocks.ProcessorPromptManager{}
+			mgr.LoadReturns(
+				prompt.NewPromptFile(
+					promptPath,
+					prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+					[]byte("# Retention\n\nKeep the log directory small.\n"),
+					libtime.NewCurrentDateTime(),
+				),
+				nil,
+			)
+			exec = &mocks.Executor{}
+		})
+
+		process := func() error {
+			vg := &mocks.VersionGetter{}
+			vg.GetReturns("v0.0.1-test")
+			pp := newProcessorWithLogPruner(
+				logDir, exec, mgr, vg, &mocks.WorkflowExecutor{},
+				nil, nil, nil, nil, nil, 0, logPruner,
+			)
+			return pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		}
+
+		It("prunes logs after a completed prompt", func() {
+			Expect(process()).To(Succeed())
+			Expect(exec.ExecuteCallCount()).To(Equal(1))
+			Expect(logPruner.PruneCallCount()).To(Equal(1))
+		})
+
+		It("does not fail the prompt when pruning fails", func() {
+			logPruner.PruneReturns(nil, errors.New("permission denied"))
+
+			Expect(process()).To(Succeed())
+			Expect(logPruner.PruneCallCount()).To(Equal(1))
+		})
+
+		It("does not prune when the execution fails", func() {
+			exec.ExecuteReturns(errors.New("container failed"))
+
+			Expect(process()).NotTo(Succeed())
+			Expect(logPruner.PruneCallCount()).To(Equal(0))
+		})
+	})
+})
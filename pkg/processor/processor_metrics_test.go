@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/metrics"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — metrics", func() {
+	var (
+		ctx          context.Context
+		promptPath   string
+		server       *httptest.Server
+		workflowExec *mocks.WorkflowExecutor
+		pp           processorPromptProcesser
+	)
+
+	scrape := func() string {
+		resp, err := http.Get(server.URL + "/metrics")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir := filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+
+		promptPath = filepath.Join(tempDir, "004-measure.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Measure it\n\nDo it"),
+			0600,
+		)).To(Succeed())
+
+		registry := prometheus.NewRegistry()
+		server = httptest.NewServer(metrics.NewHandler(registry))
+
+		mgr := &mocks.ProcessorPromptManager{}
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+				[]byte("# Measure it\n\nDo it"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		workflowExec = &mocks.WorkflowExecutor{}
+		pp = newProcessorWithPromptMetrics(
+			logDir, &mocks.Executor{}, mgr, &mocks.VersionGetter{}, workflowExec,
+			nil, nil, nil, nil, metrics.NewMetrics(registry, nil),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("counts a completed prompt and its duration", func() {
+		Expect(scrape()).To(ContainSubstring("dark_factory_prompts_completed_total 0\n"))
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("dark_factory_prompts_completed_total 1\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompts_failed_total 0\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompt_duration_seconds_count 1\n"))
+	})
+
+	It("counts a failed prompt", func() {
+		workflowExec.CompleteReturns(stderrors.New("commit failed"))
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).NotTo(Succeed())
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("dark_factory_prompts_failed_total 1\n"))
+		Expect(body).To(ContainSubstring("dark_factory_prompts_completed_total 0\n"))
+	})
+})
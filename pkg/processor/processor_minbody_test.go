@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — minimum body length", func() {
+	var (
+		ctx          context.Context
+		promptPath   string
+		logDir       string
+		exec         *mocks.Executor
+		mgr          *mocks.ProcessorPromptManager
+		workflowExec *mocks.WorkflowExecutor
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "004-heading-only.md")
+		Expect(os.WriteFile(promptPath, []byte("---\nstatus: approved\n---\n"), 0600)).
+			To(Succeed())
+
+		mgr = &mocks.ProcessorPromptManager{}
+		exec = &mocks.Executor{}
+		workflowExec = &mocks.WorkflowExecutor{}
+	})
+
+	loadBody := func(body string) {
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+				[]byte(body),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+	}
+
+	processWith := func(minBodyLength int) error {
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp := newProcessorWithMinBodyLength(
+			logDir, exec, mgr, vg, workflowExec, nil, nil, nil, nil, nil, minBodyLength,
+		)
+		return pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+	}
+
+	It("completes a heading-only prompt below the threshold without executing it", func() {
+		loadBody("# Add the feature\n\n")
+
+		Expect(processWith(10)).To(Succeed())
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+		Expect(mgr.MoveToCompletedCallCount()).To(Equal(1))
+		_, movedPath := mgr.MoveToCompletedArgsForCall(0)
+		Expect(movedPath).To(Equal(promptPath))
+	})
+
+	It("executes a prompt whose instructions reach the threshold", func() {
+		loadBody("# Add the feature\n\nAdd the flag.\n")
+
+		Expect(processWith(10)).To(Succeed())
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+	})
+
+	It("executes a heading-only prompt with the default threshold of 0", func() {
+		loadBody("# Add the feature\n")
+
+		Expect(processWith(0)).To(Succeed())
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+	})
+})
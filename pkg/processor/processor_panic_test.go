@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/committingrecoverer"
+	"github.com/bborbe/dark-factory/pkg/completionreport"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/executionslot"
+	"github.com/bborbe/dark-factory/pkg/failurehandler"
+	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/preflightconditions"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/project"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+	"github.com/bborbe/dark-factory/pkg/promptenricher"
+	"github.com/bborbe/dark-factory/pkg/promptresumer"
+	"github.com/bborbe/dark-factory/pkg/queuescanner"
+	"github.com/bborbe/dark-factory/pkg/specsweeper"
+	"github.com/bborbe/dark-factory/pkg/validationprompt"
+)
+
+var _ = Describe("ProcessPrompt — panic recovery", func() {
+	var (
+		ctx          context.Context
+		queueDir     string
+		logDir       string
+		promptPath   string
+		exec         *mocks.Executor
+		mgr          *mocks.ProcessorPromptManager
+		workflowExec *mocks.WorkflowExecutor
+		proc         processorPromptProcesser
+		scanner      queuescanner.Scanner
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "in-progress")
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+
+		promptPath = filepath.Join(queueDir, "001-boom.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Boom\n\nTriggers a panic"),
+			0600,
+		)).To(Succeed())
+
+		// Load reads the real file so the failure handler's save is observable.
+		loader := prompt.NewPromptFileLoader(libtime.NewCurrentDateTime())
+		mgr = &mocks.ProcessorPromptManager{}
+		mgr.LoadStub = loader.Load
+		mgr.AllPreviousCompletedReturns(true)
+		mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{
+			{Path: promptPath, Status: prompt.ApprovedPromptStatus},
+		}, nil)
+
+		exec = &mocks.Executor{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		workflowExec = &mocks.WorkflowExecutor{}
+		workflowExec.SetupStub = func(context.Context, prompt.BaseName, *prompt.PromptFile) error {
+			panic("boom in setup")
+		}
+
+		enricherReleaser := &mocks.Releaser{}
+		enricherReleaser.HasChangelogReturns(false)
+		fakeCancellationWatcher := &mocks.CancellationWatcher{}
+		fakeCancellationWatcher.WatchReturns(make(chan struct{}))
+
+		fh := failurehandler.NewHandler(mgr, notifier.NewMultiNotifier(), "", project.Name("test"), 0, 0)
+		resumer := promptresumer.NewResumer(
+			mgr,
+			exec,
+			&noOpWorkflowExecutorAdapter{},
+			completionreport.NewValidator(),
+			fh,
+			queueDir,
+			"",
+			prompt.CompletedLayoutFlat,
+			logDir,
+			project.Name("test"),
+			0,
+			nil,
+		)
+		ppForwarder := &lazyProcessorForwarder{}
+		scanner = queuescanner.NewScanner(
+			mgr, ppForwarder, fh, queueDir, nil, 0, nil, nil, nil, 0,
+			true,
+		)
+		p := processor.NewProcessor(
+			exec,
+			mgr,
+			nil,
+			vg,
+			workflowExec,
+			nil,
+			specsweeper.NewSweeper(nil, nil),
+			preflightconditions.NewConditions(nil, nil, nil, 0),
+			executionslot.NewManager(nil, nil, nil, 0, 0),
+			fakeCancellationWatcher,
+			make(chan struct{}),
+			nil,
+			processor.Dirs{Queue: queueDir, Log: logDir},
+			project.Name("test"),
+			fh,
+			resumer,
+			config.WorkflowDirect,
+			false,
+			completionreport.NewValidator(),
+			promptenricher.NewEnricher(
+				enricherReleaser,
+				"",
+				"",
+				"",
+				"",
+				validationprompt.NewResolver(),
+				false,
+			),
+			committingrecoverer.NewRecoverer(mgr, nil, nil, "", false),
+			scanner,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			0,
+			0,
+			nil,
+			nil,
+			false,
+			0,
+			0,
+			0,
+			0,
+			nil,
+		)
+		ppForwarder.inner = p
+		proc = p
+	})
+
+	It("returns the panic as an error instead of crashing", func() {
+		err := proc.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("panic while processing prompt: boom in setup"))
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+	})
+
+	It("marks the prompt failed and keeps the scan loop running", func() {
+		var completed int
+		var err error
+		Expect(func() {
+			completed, err = scanner.ScanAndProcess(ctx)
+		}).NotTo(Panic())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(completed).To(Equal(0))
+		// The scan continued past the failed prompt and asked for the next one.
+		Expect(mgr.ListQueuedCallCount()).To(Equal(2))
+
+		content, readErr := os.ReadFile(promptPath)
+		Expect(readErr).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("status: failed"))
+		Expect(string(content)).To(ContainSubstring("boom in setup"))
+	})
+})
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/completionreport"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/executionslot"
+	"github.com/bborbe/dark-factory/pkg/preflightconditions"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/project"
+)
+
+var _ = Describe("Process — queue poll backoff", func() {
+	var (
+		ctx     context.Context
+		cancel  context.CancelFunc
+		wakeup  chan struct{}
+		scanner *mocks.QueueScanner
+		errCh   chan error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		wakeup = make(chan struct{}, 10)
+		scanner = &mocks.QueueScanner{}
+		errCh = make(chan error, 1)
+
+		fakeCancellationWatcher := &mocks.CancellationWatcher{}
+		fakeCancellationWatcher.WatchReturns(make(chan struct{}))
+		proc := processor.NewProcessor(
+			&mocks.Executor{},
+			&mocks.ProcessorPromptManager{},
+			nil,
+			&mocks.VersionGetter{},
+			&mocks.WorkflowExecutor{},
+			nil,
+			&mocks.Sweeper{},
+			preflightconditions.NewConditions(nil, nil, nil, 0),
+			executionslot.NewManager(nil, nil, nil, 0, 0),
+			fakeCancellationWatcher,
+			wakeup,
+			nil,
+			processor.Dirs{},
+			project.Name("test"),
+			nil,
+			nil,
+			config.WorkflowDirect,
+			false,
+			completionreport.NewValidator(),
+			nil,
+			&mocks.CommittingRecoverer{},
+			scanner,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			0,
+			0,
+			nil,
+			nil,
+			false,
+			50*time.Millisecond,
+			time.Hour,
+			time.Hour,
+			0,
+			nil,
+		)
+		go func() { errCh <- proc.Process(ctx) }()
+		Eventually(scanner.ScanAndProcessCallCount).Should(Equal(1))
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(errCh).Should(Receive(BeNil()))
+	})
+
+	It("polls less often while the queue stays idle", func() {
+		// Polls after 50ms, 150ms, 350ms and 750ms; a fixed 50ms ticker would poll 16 times.
+		Consistently(scanner.ScanAndProcessCallCount, 800*time.Millisecond).
+			Should(BeNumerically("<=", 6))
+		Expect(scanner.ScanAndProcessCallCount()).To(BeNumerically(">=", 4))
+	})
+
+	It("resets the poll interval on a ready signal", func() {
+		// Let the interval back off past 400ms.
+		Eventually(scanner.ScanAndProcessCallCount, 2*time.Second).Should(Equal(5))
+
+		wakeup <- struct{}{}
+
+		// The ready scan plus the first poll 50ms after it.
+		Eventually(scanner.ScanAndProcessCallCount, 300*time.Millisecond).Should(Equal(7))
+	})
+})
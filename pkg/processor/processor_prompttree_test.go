@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — prompt trees", func() {
+	var (
+		ctx          context.Context
+		repoDir      string
+		promptDir    string
+		fakeExec     *mocks.Executor
+		mgr          *mocks.ProcessorPromptManager
+		workflowExec *mocks.WorkflowExecutor
+		pp           processorPromptProcesser
+	)
+
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		repoDir, err = filepath.EvalSymlinks(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		promptDir = GinkgoT().TempDir()
+		logDir := filepath.Join(promptDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+
+		runGit("init", "-q")
+		runGit("config", "user.email", "test@example.com")
+		runGit("config", "user.name", "Test")
+		Expect(os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Repo\n"), 0600)).
+			To(Succeed())
+		runGit("add", "-A")
+		runGit("commit", "-q", "-m", "initial")
+
+		originalDir, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir(repoDir)).To(Succeed())
+		DeferCleanup(func() { _ = os.Chdir(originalDir) })
+
+		mgr = &mocks.ProcessorPromptManager{}
+		mgr.LoadStub = func(_ context.Context, path string) (*prompt.PromptFile, error) {
+			return prompt.NewPromptFile(
+				path,
+				prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+				[]byte("# "+filepath.Base(path)+"\n\nDo the work"),
+				libtime.NewCurrentDateTime(),
+			), nil
+		}
+		fakeExec = &mocks.Executor{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		workflowExec = &mocks.WorkflowExecutor{}
+		workflowExec.CompleteStub = func(
+			_ context.Context,
+			_ context.Context,
+			_ *prompt.PromptFile,
+			_, promptPath, _ string,
+		) error {
+			runGit("add", "-A")
+			runGit("commit", "-q", "-m", filepath.Base(promptPath))
+			return nil
+		}
+
+		pp = newProcessorWithPromptTrees(
+			logDir, fakeExec, mgr, vg, workflowExec, nil, nil, nil, nil, nil, 0, nil,
+			git.NewWorktreer(),
+		)
+	})
+
+	It("commits only each prompt's own changes when two prompts overlap", func() {
+		alphaPath := filepath.Join(promptDir, "001-alpha.md")
+		betaPath := filepath.Join(promptDir, "002-beta.md")
+
+		// Both prompts write their file before either commits; beta then waits until
+		// alpha's commit is made, so the project tree is committed while beta's
+		// change exists.
+		var arrived sync.WaitGroup
+		arrived.Add(2)
+		alphaCommitted := make(chan struct{})
+		complete := workflowExec.CompleteStub
+		workflowExec.CompleteStub = func(
+			gitCtx context.Context,
+			ctx context.Context,
+			pf *prompt.PromptFile,
+			title, promptPath, completedPath string,
+		) error {
+			err := complete(gitCtx, ctx, pf, title, promptPath, completedPath)
+			if promptPath == alphaPath {
+				close(alphaCommitted)
+			}
+			return err
+		}
+		fakeExec.ExecuteStub = func(ctx context.Context, _, _, executionID string) error {
+			name := "beta.txt"
+			if strings.Contains(executionID, "alpha") {
+				name = "alpha.txt"
+			}
+			workspace := executor.WorkspaceFrom(ctx)
+			Expect(workspace).NotTo(BeEmpty())
+			Expect(workspace).NotTo(Equal(repoDir))
+			if err := os.WriteFile(filepath.Join(workspace, name), []byte(name), 0600); err != nil {
+				return err
+			}
+			arrived.Done()
+			arrived.Wait()
+			if name == "beta.txt" {
+				select {
+				case <-alphaCommitted:
+				case <-time.After(10 * time.Second):
+					Fail("alpha was not committed")
+				}
+			}
+			return nil
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, path := range []string{alphaPath, betaPath} {
+			wg.Add(1)
+			go func(i int, path string) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				errs[i] = pp.ProcessPrompt(ctx, prompt.Prompt{Path: path})
+			}(i, path)
+		}
+		wg.Wait()
+		Expect(errs).To(HaveEach(Succeed()))
+
+		Expect(runGit("log", "--format=%s", "-2")).To(Equal("002-beta.md\n001-alpha.md"))
+		Expect(runGit("show", "--name-only", "--format=", "HEAD~1")).To(Equal("alpha.txt"))
+		Expect(runGit("show", "--name-only", "--format=", "HEAD")).To(Equal("beta.txt"))
+		Expect(runGit("status", "--porcelain")).To(BeEmpty())
+	})
+
+	It("removes the prompt's tree after the prompt completes", func() {
+		var workspace string
+		fakeExec.ExecuteStub = func(ctx context.Context, _, _, _ string) error {
+			workspace = executor.WorkspaceFrom(ctx)
+			return os.WriteFile(filepath.Join(workspace, "gamma.txt"), []byte("gamma"), 0600)
+		}
+
+		gammaPath := filepath.Join(promptDir, "003-gamma.md")
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: gammaPath})).To(Succeed())
+
+		Expect(workspace).NotTo(BeEmpty())
+		Expect(workspace).NotTo(BeADirectory())
+		Expect(runGit("show", "--name-only", "--format=", "HEAD")).To(Equal("gamma.txt"))
+		Expect(runGit("worktree", "list", "--porcelain")).NotTo(ContainSubstring(workspace))
+	})
+})
+
+var _ = Describe("ProcessPrompt — shared project tree", func() {
+	newDirProcessor := func(
+		exec *mocks.Executor,
+		workflowExec *mocks.WorkflowExecutor,
+	) processorPromptProcesser {
+		logDir := GinkgoT().TempDir()
+		mgr := &mocks.ProcessorPromptManager{}
+		mgr.LoadStub = func(_ context.Context, path string) (*prompt.PromptFile, error) {
+			return prompt.NewPromptFile(
+				path,
+				prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+				[]byte("# "+filepath.Base(path)+"\n\nDo the work"),
+				libtime.NewCurrentDateTime(),
+			), nil
+		}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		return newProcessorWithPromptTrees(
+			logDir, exec, mgr, vg, workflowExec, nil, nil, nil, nil, nil, 0, nil, nil,
+		)
+	}
+
+	It("runs the prompts of two directories one after the other", func() {
+		ctx := context.Background()
+		promptDir := GinkgoT().TempDir()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		firstExec := &mocks.Executor{}
+		firstExec.ExecuteStub = func(context.Context, string, string, string) error {
+			close(started)
+			<-release
+			return nil
+		}
+		firstWorkflow := &mocks.WorkflowExecutor{}
+		secondWorkflow := &mocks.WorkflowExecutor{}
+
+		first := newDirProcessor(firstExec, firstWorkflow)
+		second := newDirProcessor(&mocks.Executor{}, secondWorkflow)
+
+		firstDone := make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			firstDone <- first.ProcessPrompt(
+				ctx, prompt.Prompt{Path: filepath.Join(promptDir, "001-setup.md")},
+			)
+		}()
+		Eventually(started).Should(BeClosed())
+
+		secondDone := make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			secondDone <- second.ProcessPrompt(
+				ctx, prompt.Prompt{Path: filepath.Join(promptDir, "002-setup.md")},
+			)
+		}()
+		Consistently(secondWorkflow.SetupCallCount, 200*time.Millisecond).Should(Equal(0))
+
+		close(release)
+		Eventually(firstDone).Should(Receive(Succeed()))
+		Eventually(secondDone).Should(Receive(Succeed()))
+		Expect(firstWorkflow.CompleteCallCount()).To(Equal(1))
+		Expect(secondWorkflow.SetupCallCount()).To(Equal(1))
+		Expect(secondWorkflow.CompleteCallCount()).To(Equal(1))
+	})
+
+	It("stops waiting when the context is cancelled", func() {
+		promptDir := GinkgoT().TempDir()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		firstExec := &mocks.Executor{}
+		firstExec.ExecuteStub = func(context.Context, string, string, string) error {
+			close(started)
+			<-release
+			return nil
+		}
+		first := newDirProcessor(firstExec, &mocks.WorkflowExecutor{})
+		secondWorkflow := &mocks.WorkflowExecutor{}
+		second := newDirProcessor(&mocks.Executor{}, secondWorkflow)
+
+		firstDone := make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			firstDone <- first.ProcessPrompt(
+				context.Background(),
+				prompt.Prompt{Path: filepath.Join(promptDir, "001-setup.md")},
+			)
+		}()
+		Eventually(started).Should(BeClosed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := second.ProcessPrompt(
+			ctx, prompt.Prompt{Path: filepath.Join(promptDir, "002-setup.md")},
+		)
+		Expect(err).To(MatchError(ContainSubstring("wait for project tree cancelled")))
+		Expect(secondWorkflow.SetupCallCount()).To(Equal(0))
+
+		close(release)
+		Eventually(firstDone).Should(Receive(Succeed()))
+	})
+})
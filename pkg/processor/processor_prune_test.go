@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/processor"
+)
+
+var _ = Describe("pruneCompleted", func() {
+	var (
+		ctx      context.Context
+		mgr      *mocks.ProcessorPromptManager
+		releaser *mocks.Releaser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mgr = &mocks.ProcessorPromptManager{}
+		releaser = &mocks.Releaser{}
+	})
+
+	It("commits the pruned prompts on their own", func() {
+		mgr.PruneCompletedReturns(
+			[]string{"prompts/completed/001-a.md", "prompts/completed/002-b.md"},
+			nil,
+		)
+
+		processor.PruneCompletedForTest(ctx, mgr, releaser, "prompts/completed")
+
+		Expect(releaser.CommitPathsCallCount()).To(Equal(1))
+		_, message, paths := releaser.CommitPathsArgsForCall(0)
+		Expect(message).To(Equal("prune 2 completed prompts"))
+		Expect(paths).To(Equal([]string{"prompts/completed"}))
+		Expect(releaser.CommitOnlyCallCount()).To(Equal(0))
+	})
+
+	It("commits the prompts pruned before an error", func() {
+		mgr.PruneCompletedReturns([]string{"prompts/completed/001-a.md"}, errors.New("boom"))
+
+		processor.PruneCompletedForTest(ctx, mgr, releaser, "prompts/completed")
+
+		Expect(releaser.CommitPathsCallCount()).To(Equal(1))
+	})
+
+	It("does not commit when nothing was pruned", func() {
+		processor.PruneCompletedForTest(ctx, mgr, releaser, "prompts/completed")
+
+		Expect(releaser.CommitPathsCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,390 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/committingrecoverer"
+	"github.com/bborbe/dark-factory/pkg/completionreport"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/executionslot"
+	"github.com/bborbe/dark-factory/pkg/failurehandler"
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/logretention"
+	"github.com/bborbe/dark-factory/pkg/metrics"
+	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/preflightconditions"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/project"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+	"github.com/bborbe/dark-factory/pkg/promptenricher"
+	"github.com/bborbe/dark-factory/pkg/promptresumer"
+	"github.com/bborbe/dark-factory/pkg/promptsource"
+	"github.com/bborbe/dark-factory/pkg/queuescanner"
+	"github.com/bborbe/dark-factory/pkg/resultcache"
+	"github.com/bborbe/dark-factory/pkg/resultfile"
+	"github.com/bborbe/dark-factory/pkg/specsweeper"
+	"github.com/bborbe/dark-factory/pkg/validationprompt"
+)
+
+// newProcessorWithResultCache creates a processor wired with the given result cache,
+// source fetcher and result file reader.
+func newProcessorWithResultCache(
+	logDir string,
+	exec *mocks.Executor,
+	mgr *mocks.ProcessorPromptManager,
+	vg *mocks.VersionGetter,
+	workflowExec *mocks.WorkflowExecutor,
+	cache resultcache.Cache,
+	sourceFetcher promptsource.Fetcher,
+	resultReader resultfile.Reader,
+) processorPromptProcesser {
+	return newProcessorWithPromptNotifier(
+		logDir, exec, mgr, vg, workflowExec, cache, sourceFetcher, resultReader, nil,
+	)
+}
+
+// newProcessorWithPromptNotifier is newProcessorWithResultCache plus the prompt notifier
+// that receives prompt_completed.
+func newProcessorWithPromptNotifier(
+	logDir string,
+	exec *mocks.Executor,
+	mgr *mocks.ProcessorPromptManager,
+	vg *mocks.VersionGetter,
+	workflowExec *mocks.WorkflowExecutor,
+	cache resultcache.Cache,
+	sourceFetcher promptsource.Fetcher,
+	resultReader resultfile.Reader,
+	promptNotifier notifier.Notifier,
+) processorPromptProcesser {
+	return newProcessorWithPromptMetrics(
+		logDir, exec, mgr, vg, workflowExec, cache, sourceFetcher, resultReader,
+		promptNotifier, nil,
+	)
+}
+
+// newProcessorWithPromptMetrics is newProcessorWithPromptNotifier plus the metrics
+// counting completed and failed prompts.
+func newProcessorWithPromptMetrics(
+	logDir string,
+	exec *mocks.Executor,
+	mgr *mocks.ProcessorPromptManager,
+	vg *mocks.VersionGetter,
+	workflowExec *mocks.WorkflowExecutor,
+	cache resultcache.Cache,
+	sourceFetcher promptsource.Fetcher,
+	resultReader resultfile.Reader,
+	promptNotifier notifier.Notifier,
+	promptMetrics metrics.Metrics,
+) processorPromptProcesser {
+	return newProcessorWithMinBodyLength(
+		logDir, exec, mgr, vg, workflowExec, cache, sourceFetcher, resultReader,
+		promptNotifier, promptMetrics, 0,
+	)
+}
+
+// newProcessorWithMinBodyLength is newProcessorWithPromptMetrics plus the minimum
+// body length below which prompts are completed without execution.
+func newProcessorWithMinBodyLength(
+	logDir string,
+	exec *mocks.Executor,
+	mgr *mocks.ProcessorPromptManager,
+	vg *mocks.VersionGetter,
+	workflowExec *mocks.WorkflowExecutor,
+	cache resultcache.Cache,
+	sourceFetcher promptsource.Fetcher,
+	resultReader resultfile.Reader,
+	promptNotifier notifier.Notifier,
+	promptMetrics metrics.Metrics,
+	minBodyLength int,
+) processorPromptProcesser {
+	return newProcessorWithLogPruner(
+		logDir, exec, mgr, vg, workflowExec, cache, sourceFetcher, resultReader,
+		promptNotifier, promptMetrics, minBodyLength, nil,
+	)
+}
+
+// newProcessorWithLogPruner is newProcessorWithMinBodyLength plus the log retention.
+func newProcessorWithLogPruner(
+	logDir string,
+	exec *mocks.Executor,
+	mgr *mocks.ProcessorPromptManager,
+	vg *mocks.VersionGetter,
+	workflowExec *mocks.WorkflowExecutor,
+	cache resultcache.Cache,
+	sourceFetcher promptsource.Fetcher,
+	resultReader resultfile.Reader,
+	promptNotifier notifier.Notifier,
+	promptMetrics metrics.Metrics,
+	minBodyLength int,
+	logPruner logretention.Pruner,
+) processorPromptProcesser {
+	return newProcessorWithPromptTrees(
+		logDir, exec, mgr, vg, workflowExec, cache, sourceFetcher, resultReader,
+		promptNotifier, promptMetrics, minBodyLength, logPruner, nil,
+	)
+}
+
+// newProcessorWithPromptTrees is newProcessorWithLogPruner plus the worktreer that
+// gives every prompt its own tree.
+func newProcessorWithPromptTrees(
+	logDir string,
+	exec *mocks.Executor,
+	mgr *mocks.ProcessorPromptManager,
+	vg *mocks.VersionGetter,
+	workflowExec *mocks.WorkflowExecutor,
+	cache resultcache.Cache,
+	sourceFetcher promptsource.Fetcher,
+	resultReader resultfile.Reader,
+	promptNotifier notifier.Notifier,
+	promptMetrics metrics.Metrics,
+	minBodyLength int,
+	logPruner logretention.Pruner,
+	promptTrees git.Worktreer,
+) processorPromptProcesser {
+	enricherReleaser := &mocks.Releaser{}
+	enricherReleaser.HasChangelogReturns(false)
+
+	fakeCancellationWatcher := &mocks.CancellationWatcher{}
+	fakeCancellationWatcher.WatchReturns(make(chan struct{}))
+
+	fh := failurehandler.NewHandler(mgr, notifier.NewMultiNotifier(), "", project.Name("test"), 0, 0)
+	resumer := promptresumer.NewResumer(
+		mgr,
+		exec,
+		&noOpWorkflowExecutorAdapter{},
+		completionreport.NewValidator(),
+		fh,
+		"",
+		"",
+		prompt.CompletedLayoutFlat,
+		logDir,
+		project.Name("test"),
+		0,
+		nil,
+	)
+	ppForwarder := &lazyProcessorForwarder{}
+	scanner := queuescanner.NewScanner(mgr, ppForwarder, fh, "", nil, 0, nil, nil, nil, 0, true)
+
+	proc := processor.NewProcessor(
+		exec,
+		mgr,
+		nil,
+		vg,
+		workflowExec,
+		nil,
+		specsweeper.NewSweeper(nil, nil),
+		preflightconditions.NewConditions(nil, nil, nil, 0),
+		executionslot.NewManager(nil, nil, nil, 0, 0),
+		fakeCancellationWatcher,
+		make(chan struct{}),
+		nil,
+		processor.Dirs{Log: logDir},
+		project.Name("test"),
+		fh,
+		resumer,
+		config.WorkflowDirect,
+		false,
+		completionreport.NewValidator(),
+		promptenricher.NewEnricher(
+			enricherReleaser,
+			"",
+			"",
+			"",
+			"",
+			validationprompt.NewResolver(),
+			false,
+		),
+		committingrecoverer.NewRecoverer(mgr, nil, nil, "", false),
+		scanner,
+		cache,
+		sourceFetcher,
+		resultReader,
+		promptNotifier,
+		promptMetrics,
+		0,
+		minBodyLength,
+		logPruner,
+		promptTrees,
+		false,
+		0,
+		0,
+		0,
+		0,
+		nil,
+	)
+	ppForwarder.inner = proc
+	return proc
+}
+
+var _ = Describe("ProcessPrompt — result cache", func() {
+	var (
+		ctx          context.Context
+		logDir       string
+		promptPath   string
+		exec         *mocks.Executor
+		mgr          *mocks.ProcessorPromptManager
+		vg           *mocks.VersionGetter
+		workflowExec *mocks.WorkflowExecutor
+		cache        *mocks.ResultCache
+		pp           processorPromptProcesser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+
+		promptPath = filepath.Join(tempDir, "002-cached.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Cached\n\nSame content"),
+			0600,
+		)).To(Succeed())
+
+		mgr = &mocks.ProcessorPromptManager{}
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+				[]byte("# Cached\n\nSame content"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		exec = &mocks.Executor{}
+		vg = &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		workflowExec = &mocks.WorkflowExecutor{}
+		cache = &mocks.ResultCache{}
+
+		pp = newProcessorWithResultCache(logDir, exec, mgr, vg, workflowExec, cache, nil, nil)
+	})
+
+	It("skips the executor on a cache hit and completes the prompt", func() {
+		cache.LookupReturns(&resultcache.Entry{
+			PromptFile: "001-original.md",
+			Summary:    "did the thing",
+		}, true)
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+		Expect(workflowExec.SetupCallCount()).To(Equal(1))
+		Expect(workflowExec.CompleteCallCount()).To(Equal(1))
+		_, _, _, title, completedPromptPath, _ := workflowExec.CompleteArgsForCall(0)
+		Expect(title).To(Equal("Cached"))
+		Expect(completedPromptPath).To(Equal(promptPath))
+		Expect(cache.RecordCallCount()).To(Equal(0))
+
+		_, _, version, _ := cache.LookupArgsForCall(0)
+		Expect(version).To(Equal("v0.0.1-test"))
+
+		content, err := os.ReadFile(promptPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("reused cached result of 001-original.md: did the thing"))
+	})
+
+	It("names the reused commit on a cache hit", func() {
+		cache.LookupReturns(&resultcache.Entry{
+			PromptFile: "001-original.md",
+			Summary:    "did the thing",
+			Commit:     "4f2c9e1",
+		}, true)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		content, err := os.ReadFile(promptPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring(
+			"reused cached result of 001-original.md (commit 4f2c9e1): did the thing",
+		))
+	})
+
+	It("counts and notifies a cache hit like a completed prompt", func() {
+		cache.LookupReturns(&resultcache.Entry{PromptFile: "001-original.md"}, true)
+		promptNotifier := &mocks.Notifier{}
+		promptMetrics := &mocks.Metrics{}
+		pp = newProcessorWithPromptMetrics(
+			logDir, exec, mgr, vg, workflowExec, cache, nil, nil,
+			promptNotifier, promptMetrics,
+		)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		Expect(promptMetrics.PromptCompletedCallCount()).To(Equal(1))
+		Expect(promptNotifier.NotifyCallCount()).To(Equal(1))
+		_, event := promptNotifier.NotifyArgsForCall(0)
+		Expect(event.EventType).To(Equal("prompt_completed"))
+		Expect(event.PromptName).To(Equal("002-cached.md"))
+	})
+
+	It("does not complete a cache hit when the workflow setup fails", func() {
+		cache.LookupReturns(&resultcache.Entry{PromptFile: "001-original.md"}, true)
+		workflowExec.SetupReturns(context.Canceled)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).NotTo(Succeed())
+		Expect(workflowExec.CompleteCallCount()).To(Equal(0))
+	})
+
+	It("executes and records the result on a cache miss", func() {
+		cache.LookupReturns(nil, false)
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+		Expect(workflowExec.CompleteCallCount()).To(Equal(1))
+		Expect(cache.RecordCallCount()).To(Equal(1))
+		_, content, version, overrides, entry := cache.RecordArgsForCall(0)
+		_, lookupContent, _, lookupOverrides := cache.LookupArgsForCall(0)
+		Expect(content).To(Equal(lookupContent))
+		Expect(overrides).To(Equal(lookupOverrides))
+		Expect(version).To(Equal("v0.0.1-test"))
+		Expect(entry.PromptFile).To(Equal("002-cached.md"))
+	})
+
+	It("keys the lookup on the prompt's launch overrides", func() {
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{
+					Status: string(prompt.ApprovedPromptStatus),
+					Image:  "custom:v2",
+					Env:    map[string]string{"MODE": "fast"},
+				},
+				[]byte("# Cached\n\nSame content"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		cache.LookupReturns(nil, false)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		_, _, _, overrides := cache.LookupArgsForCall(0)
+		Expect(overrides.Image).To(Equal("custom:v2"))
+		Expect(overrides.Env).To(Equal(map[string]string{"MODE": "fast"}))
+	})
+
+	It("does not record when execution fails", func() {
+		cache.LookupReturns(nil, false)
+		exec.ExecuteReturns(context.DeadlineExceeded)
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).To(HaveOccurred())
+		Expect(cache.RecordCallCount()).To(Equal(0))
+	})
+})
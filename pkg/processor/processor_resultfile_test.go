@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+	"github.com/bborbe/dark-factory/pkg/resultfile"
+)
+
+var _ = Describe("ProcessPrompt — result file", func() {
+	var (
+		ctx          context.Context
+		promptPath   string
+		exec         *mocks.Executor
+		workflowExec *mocks.WorkflowExecutor
+		reader       *mocks.ResultFileReader
+		pp           processorPromptProcesser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir := filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "006-result.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Result\n\nDo the thing"),
+			0600,
+		)).To(Succeed())
+
+		mgr := &mocks.ProcessorPromptManager{}
+		mgr.LoadStub = func(ctx context.Context, path string) (*prompt.PromptFile, error) {
+			return prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+				Load(ctx, path)
+		}
+		exec = &mocks.Executor{}
+		workflowExec = &mocks.WorkflowExecutor{}
+		reader = &mocks.ResultFileReader{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp = newProcessorWithResultCache(logDir, exec, mgr, vg, workflowExec, nil, nil, reader)
+	})
+
+	It("completes normally without a result file", func() {
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		Expect(reader.ConsumeCallCount()).To(Equal(1))
+		Expect(workflowExec.CompleteCallCount()).To(Equal(1))
+		gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		_, ok := processor.BumpOverrideFromForTest(gitCtx)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("fails the prompt despite a zero exit code when the result file reports failure", func() {
+		reader.ConsumeReturns(&resultfile.Result{
+			Status:  resultfile.StatusFailed,
+			Message: "tests still red",
+		}, nil)
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("result file reports failed: tests still red"))
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+		Expect(workflowExec.CompleteCallCount()).To(Equal(0))
+	})
+
+	It("overrides the version bump and summary", func() {
+		reader.ConsumeReturns(&resultfile.Result{
+			Status:  resultfile.StatusSuccess,
+			Message: "added the widget",
+			Bump:    "minor",
+		}, nil)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		Expect(workflowExec.CompleteCallCount()).To(Equal(1))
+		gitCtx, _, pf, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		bump, ok := processor.BumpOverrideFromForTest(gitCtx)
+		Expect(ok).To(BeTrue())
+		Expect(bump).To(Equal(git.MinorBump))
+		Expect(pf.Frontmatter.Summary).To(Equal("added the widget"))
+	})
+
+	It("fails the prompt when the result file is invalid", func() {
+		reader.ConsumeReturns(nil, os.ErrInvalid)
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("consume result file"))
+		Expect(workflowExec.CompleteCallCount()).To(Equal(0))
+	})
+})
@@ -280,6 +280,69 @@ var _ = Describe("Processor", func() {
 			cancel()
 		})
 
+		Context("with max_retries frontmatter", func() {
+			var promptPath string
+
+			BeforeEach(func() {
+				promptPath = filepath.Join(promptsDir, "001-max-retries.md")
+				Expect(os.WriteFile(
+					promptPath,
+					[]byte("---\nstatus: approved\nmax_retries: 2\n---\n# Test\n\nContent"),
+					0600,
+				)).To(Succeed())
+
+				manager.LoadStub = func(_ context.Context, path string) (*prompt.PromptFile, error) {
+					return prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+						Load(ctx, path)
+				}
+				// The prompt stays queued for as long as the file on disk is approved.
+				manager.ListQueuedStub = func(_ context.Context) ([]prompt.Prompt, error) {
+					pf, err := prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+						Load(ctx, promptPath)
+					if err != nil || pf.Frontmatter.Status != string(prompt.ApprovedPromptStatus) {
+						return []prompt.Prompt{}, nil
+					}
+					return []prompt.Prompt{
+						{Path: promptPath, Status: prompt.ApprovedPromptStatus},
+					}, nil
+				}
+				manager.AllPreviousCompletedReturns(true)
+				manager.AllPreviousInSpecCompletedReturns(true)
+			})
+
+			It("completes a prompt that fails twice and then succeeds", func() {
+				executor.ExecuteReturnsOnCall(0, stderrors.New("execution failed"))
+				executor.ExecuteReturnsOnCall(1, stderrors.New("execution failed"))
+				executor.ExecuteReturnsOnCall(2, nil)
+
+				// autoRetryLimit=0: only the frontmatter allows the retries
+				p := newProcWithNotifierAndRetryLimit(notifier.NewMultiNotifier(), 0)
+				go func() { _ = p.Process(ctx) }()
+
+				Eventually(manager.MoveToCompletedCallCount, 15*time.Second, 50*time.Millisecond).
+					Should(Equal(1))
+				Expect(executor.ExecuteCallCount()).To(Equal(3))
+				content, readErr := os.ReadFile(promptPath)
+				Expect(readErr).NotTo(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring("retryCount: 2"))
+				cancel()
+			})
+
+			It("marks failed once the retries are exhausted", func() {
+				executor.ExecuteReturns(stderrors.New("execution failed"))
+
+				p := newProcWithNotifierAndRetryLimit(notifier.NewMultiNotifier(), 5)
+				go func() { _ = p.Process(ctx) }()
+
+				Eventually(func() string {
+					content, _ := os.ReadFile(promptPath)
+					return string(content)
+				}, 15*time.Second, 50*time.Millisecond).Should(ContainSubstring("status: failed"))
+				Expect(executor.ExecuteCallCount()).To(Equal(3))
+				Expect(manager.MoveToCompletedCallCount()).To(Equal(0))
+				cancel()
+			})
+		})
 	})
 
 	Describe("processExistingQueued post-execution failure detection", func() {
@@ -651,6 +714,7 @@ var _ = Describe("Processor", func() {
 				sweepCompletedDir,
 				project.Name("sweep-test"),
 				0,
+				0,
 			)
 			sweepResumer := promptresumer.NewResumer(
 				manager,
@@ -660,9 +724,11 @@ var _ = Describe("Processor", func() {
 				sweepFH,
 				sweepQueueDir,
 				sweepCompletedDir,
+				prompt.CompletedLayoutFlat,
 				filepath.Join(sweepTempDir, "log"),
 				project.Name("sweep-test"),
 				0,
+				nil,
 			)
 			sweepPPForwarder := &lazyProcessorForwarder{}
 			sweepScanner := queuescanner.NewScanner(
@@ -672,6 +738,11 @@ var _ = Describe("Processor", func() {
 				sweepQueueDir,
 				nil,
 				0,
+				nil,
+				nil,
+				nil,
+				0,
+				true,
 			)
 			sweepProc := processor.NewProcessor(
 				executor,
@@ -685,6 +756,7 @@ var _ = Describe("Processor", func() {
 				executionslot.NewManager(nil, nil, nil, 0, 10*time.Second),
 				cancellationwatcher.NewWatcher(executor, manager),
 				wakeup,
+				nil,
 				processor.Dirs{
 					Queue:     sweepQueueDir,
 					Completed: sweepCompletedDir,
@@ -713,8 +785,20 @@ var _ = Describe("Processor", func() {
 					false,
 				),
 				sweepScanner,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				0,
+				0,
+				nil,
+				nil,
+				false,
+				0,
 				0,
 				20*time.Millisecond, // sweepInterval 20ms for test speed
+				0,                   // readyDebounce: disabled
 				nil,                 // onIdle: no-op for tests
 			)
 			sweepPPForwarder.inner = sweepProc
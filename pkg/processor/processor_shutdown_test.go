@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/completionreport"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/executionslot"
+	"github.com/bborbe/dark-factory/pkg/preflightconditions"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/project"
+)
+
+var _ = Describe("Process — shutdown", func() {
+	var (
+		ctx      context.Context
+		cancel   context.CancelFunc
+		wakeup   chan struct{}
+		shutdown chan struct{}
+		scanner  *mocks.QueueScanner
+		proc     processor.Processor
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		wakeup = make(chan struct{}, 10)
+		shutdown = make(chan struct{})
+		scanner = &mocks.QueueScanner{}
+		fakeCancellationWatcher := &mocks.CancellationWatcher{}
+		fakeCancellationWatcher.WatchReturns(make(chan struct{}))
+		proc = processor.NewProcessor(
+			&mocks.Executor{},
+			&mocks.ProcessorPromptManager{},
+			nil,
+			&mocks.VersionGetter{},
+			&mocks.WorkflowExecutor{},
+			nil,
+			&mocks.Sweeper{},
+			preflightconditions.NewConditions(nil, nil, nil, 0),
+			executionslot.NewManager(nil, nil, nil, 0, 0),
+			fakeCancellationWatcher,
+			wakeup,
+			shutdown,
+			processor.Dirs{},
+			project.Name("test"),
+			nil,
+			nil,
+			config.WorkflowDirect,
+			false,
+			completionreport.NewValidator(),
+			nil,
+			&mocks.CommittingRecoverer{},
+			scanner,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			0,
+			0,
+			nil,
+			nil,
+			false,
+			time.Hour,
+			time.Hour,
+			time.Hour,
+			0,
+			nil,
+		)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("finishes the prompt in flight and then returns ErrShutdownRequested", func() {
+		promptFinished := false
+		scanner.ScanAndProcessStub = func(_ context.Context) (int, error) {
+			// The shutdown request arrives while the prompt executes.
+			close(shutdown)
+			wakeup <- struct{}{}
+			promptFinished = true
+			return 1, nil
+		}
+
+		err := proc.Process(ctx)
+		Expect(err).To(MatchError(processor.ErrShutdownRequested))
+		Expect(promptFinished).To(BeTrue())
+		Expect(scanner.ScanAndProcessCallCount()).To(Equal(1))
+	})
+
+	It("keeps running while no shutdown is requested", func() {
+		errCh := make(chan error, 1)
+		go func() { errCh <- proc.Process(ctx) }()
+
+		Eventually(scanner.ScanAndProcessCallCount).Should(Equal(1))
+		Consistently(errCh, 200*time.Millisecond).ShouldNot(Receive())
+
+		close(shutdown)
+		Eventually(errCh).Should(Receive(MatchError(processor.ErrShutdownRequested)))
+	})
+})
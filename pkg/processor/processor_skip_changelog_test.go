@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — skip changelog", func() {
+	var (
+		ctx          context.Context
+		promptPath   string
+		workflowExec *mocks.WorkflowExecutor
+		pp           processorPromptProcesser
+		writePrompt  func(frontmatter, title string)
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir := filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "008-docs.md")
+		writePrompt = func(frontmatter, title string) {
+			Expect(os.WriteFile(
+				promptPath,
+				[]byte("---\nstatus: approved\n"+frontmatter+"---\n# "+title+"\n\nDo the thing"),
+				0600,
+			)).To(Succeed())
+		}
+
+		mgr := &mocks.ProcessorPromptManager{}
+		mgr.LoadStub = func(ctx context.Context, path string) (*prompt.PromptFile, error) {
+			return prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+				Load(ctx, path)
+		}
+		workflowExec = &mocks.WorkflowExecutor{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp = newProcessorWithResultCache(
+			logDir,
+			&mocks.Executor{},
+			mgr,
+			vg,
+			workflowExec,
+			nil,
+			nil,
+			&mocks.ResultFileReader{},
+		)
+	})
+
+	It("skips the release for skip_changelog frontmatter", func() {
+		writePrompt("skip_changelog: true\n", "Fix typo in docs")
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		Expect(workflowExec.CompleteCallCount()).To(Equal(1))
+		gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		Expect(processor.SkipReleaseFromForTest(gitCtx)).To(BeTrue())
+	})
+
+	It("skips the release for a [skip changelog] title marker", func() {
+		writePrompt("", "Fix typo in docs [Skip Changelog]")
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		Expect(processor.SkipReleaseFromForTest(gitCtx)).To(BeTrue())
+	})
+
+	It("releases without a marker", func() {
+		writePrompt("", "Fix typo in docs")
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		gitCtx, _, _, _, _, _ := workflowExec.CompleteArgsForCall(0)
+		Expect(processor.SkipReleaseFromForTest(gitCtx)).To(BeFalse())
+	})
+})
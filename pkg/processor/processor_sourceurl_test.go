@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/failurehandler"
+	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — source_url", func() {
+	var (
+		ctx        context.Context
+		tempDir    string
+		promptPath string
+		exec       *mocks.Executor
+		mgr        *mocks.ProcessorPromptManager
+		fetcher    *mocks.PromptSourceFetcher
+		pp         processorPromptProcesser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		logDir := filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+		promptPath = filepath.Join(tempDir, "005-remote.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\nsource_url: https://example.com/prompt.md\n---\n"),
+			0600,
+		)).To(Succeed())
+
+		mgr = &mocks.ProcessorPromptManager{}
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{
+					Status:    string(prompt.ApprovedPromptStatus),
+					SourceURL: "https://example.com/prompt.md",
+				},
+				nil,
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		exec = &mocks.Executor{}
+		fetcher = &mocks.PromptSourceFetcher{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		pp = newProcessorWithResultCache(
+			logDir,
+			exec,
+			mgr,
+			vg,
+			&mocks.WorkflowExecutor{},
+			nil,
+			fetcher,
+			nil,
+		)
+	})
+
+	It("executes the fetched body and keeps it in the prompt file", func() {
+		fetcher.FetchReturns("# Remote\n\nDo the remote thing", nil)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		Expect(fetcher.FetchCallCount()).To(Equal(1))
+		_, sourceURL := fetcher.FetchArgsForCall(0)
+		Expect(sourceURL).To(Equal("https://example.com/prompt.md"))
+
+		Expect(exec.ExecuteCallCount()).To(Equal(1))
+		_, content, _, _ := exec.ExecuteArgsForCall(0)
+		Expect(content).To(ContainSubstring("Do the remote thing"))
+
+		saved, err := os.ReadFile(promptPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(saved)).To(ContainSubstring("source_url: https://example.com/prompt.md"))
+		Expect(string(saved)).To(ContainSubstring("# Remote\n\nDo the remote thing"))
+	})
+
+	It("fails the prompt without launching when the fetch fails", func() {
+		fetcher.FetchReturns("", stderrors.New("connection refused"))
+
+		err := pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fetch source_url"))
+		Expect(exec.ExecuteCallCount()).To(Equal(0))
+
+		completedDir := filepath.Join(tempDir, "completed")
+		fh := failurehandler.NewHandler(
+			mgr,
+			notifier.NewMultiNotifier(),
+			completedDir,
+			"test",
+			0,
+			0,
+		)
+		Expect(fh.Handle(ctx, promptPath, err)).To(Succeed())
+
+		saved, loadErr := prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+			Load(ctx, promptPath)
+		Expect(loadErr).NotTo(HaveOccurred())
+		Expect(saved.Frontmatter.Status).To(Equal(string(prompt.FailedPromptStatus)))
+		Expect(saved.Frontmatter.LastFailReason).To(ContainSubstring("connection refused"))
+	})
+})
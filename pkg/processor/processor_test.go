@@ -89,7 +89,7 @@ func newTestProcessor(
 		workflow, pr, autoMerge, autoRelease,
 		projectName, mgr, rel, autoCompleter, brancher, prCreator, cloner, worktreer, prMerger,
 	)
-	fh := failurehandler.NewHandler(mgr, n, completedDir, project.Name(projectName), autoRetryLimit)
+	fh := failurehandler.NewHandler(mgr, n, completedDir, project.Name(projectName), autoRetryLimit, 0)
 	// Build a real resumer using a no-op workflow adapter so existing tests
 	// that don't exercise ResumeExecuting are not affected.
 	resumer := promptresumer.NewResumer(
@@ -100,12 +100,14 @@ func newTestProcessor(
 		fh,
 		queueDir,
 		completedDir,
+		prompt.CompletedLayoutFlat,
 		logDir,
 		project.Name(projectName),
 		maxPromptDuration,
+		nil,
 	)
 	ppForwarder := &lazyProcessorForwarder{}
-	scanner := queuescanner.NewScanner(mgr, ppForwarder, fh, queueDir, nil, 0)
+	scanner := queuescanner.NewScanner(mgr, ppForwarder, fh, queueDir, nil, 0, nil, nil, nil, 0, true)
 	proc := processor.NewProcessor(
 		exec,
 		mgr,
@@ -129,6 +131,7 @@ func newTestProcessor(
 		),
 		cancellationwatcher.NewWatcher(exec, mgr),
 		wakeup,
+		nil,
 		processor.Dirs{Queue: queueDir, Completed: completedDir, Log: logDir},
 		project.Name(projectName),
 		fh,
@@ -147,8 +150,20 @@ func newTestProcessor(
 		),
 		committingrecoverer.NewRecoverer(mgr, rel, autoCompleter, completedDir, autoRelease),
 		scanner,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		maxPromptDuration,
+		0,
+		nil,
+		nil,
+		false,
+		0,
 		0,
-		0,   // queueInterval and sweepInterval: 0 → use defaults (5s, 60s)
+		0,   // queueInterval, queueMaxInterval and sweepInterval: 0 → use defaults
+		0,   // readyDebounce: 0 → scan on every ready signal
 		nil, // onIdle: no-op for tests
 	)
 	ppForwarder.inner = proc
@@ -1096,7 +1111,7 @@ var _ = Describe("Processor", func() {
 
 		// Verify container name was sanitized
 		_, _, _, containerName := executor.ExecuteArgsForCall(0)
-		Expect(containerName).To(Equal("test-project-exec-001-test-file-name"))
+		Expect(containerName).To(MatchRegexp(`^test-project-exec-001-test-file-name-[0-9a-f]{8}$`))
 
 		cancel()
 	})
@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Processor prompt timeout", func() {
+	var (
+		tempDir    string
+		promptsDir string
+		wakeup     chan struct{}
+		ctx        context.Context
+		cancel     context.CancelFunc
+		executor   *mocks.Executor
+		manager    *mocks.ProcessorPromptManager
+		versionGet *mocks.VersionGetter
+		specLister *mocks.Lister
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "processor-timeout-test-*")
+		Expect(err).NotTo(HaveOccurred())
+
+		promptsDir = filepath.Join(tempDir, "prompts")
+		err = os.MkdirAll(promptsDir, 0750)
+		Expect(err).NotTo(HaveOccurred())
+
+		wakeup = make(chan struct{}, 10)
+		ctx, cancel = context.WithCancel(context.Background())
+
+		// Execute blocks until its context ends, like a container that never finishes.
+		executor = &mocks.Executor{}
+		executor.ExecuteStub = func(execCtx context.Context, _, _, _ string) error {
+			<-execCtx.Done()
+			return execCtx.Err()
+		}
+
+		manager = &mocks.ProcessorPromptManager{}
+		manager.LoadStub = func(_ context.Context, path string) (*prompt.PromptFile, error) {
+			return prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+				Load(ctx, path)
+		}
+		manager.AllPreviousCompletedReturns(true)
+		manager.AllPreviousInSpecCompletedReturns(true)
+
+		versionGet = &mocks.VersionGetter{}
+		versionGet.GetReturns("v0.0.1-test")
+		specLister = &mocks.Lister{}
+		specLister.ListReturns(nil, nil)
+	})
+
+	AfterEach(func() {
+		cancel()
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
+	})
+
+	newProcWithMaxPromptDuration := func(maxPromptDuration time.Duration) processor.Processor {
+		releaser := &mocks.Releaser{}
+		releaser.CommitWithRetryStub = func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }
+		return newTestProcessor(
+			promptsDir,
+			filepath.Join(promptsDir, "completed"),
+			filepath.Join(promptsDir, "log"),
+			"test-project",
+			executor,
+			manager,
+			releaser,
+			versionGet,
+			wakeup,
+			false,
+			config.WorkflowDirect,
+			&mocks.Brancher{},
+			&mocks.PRCreator{},
+			&mocks.Cloner{},
+			&mocks.Worktreer{},
+			&mocks.PRMerger{},
+			false,
+			false,
+			&mocks.AutoCompleter{},
+			specLister,
+			"",
+			"",
+			"",
+			false,
+			notifier.NewMultiNotifier(),
+			nil,
+			0,
+			"",
+			nil,
+			nil,
+			0,
+			nil,
+			nil,
+			0,
+			maxPromptDuration,
+			nil,
+		)
+	}
+
+	queuePrompt := func(name string, frontmatter string) string {
+		promptPath := filepath.Join(promptsDir, name)
+		content := fmt.Sprintf("---\nstatus: approved\n%s---\n# Test\n\nContent", frontmatter)
+		Expect(os.WriteFile(promptPath, []byte(content), 0600)).To(Succeed())
+		manager.ListQueuedReturnsOnCall(0, []prompt.Prompt{
+			{Path: promptPath, Status: prompt.ApprovedPromptStatus},
+		}, nil)
+		manager.ListQueuedReturnsOnCall(1, []prompt.Prompt{}, nil)
+		return promptPath
+	}
+
+	readPrompt := func(promptPath string) func() string {
+		return func() string {
+			content, _ := os.ReadFile(promptPath)
+			return string(content)
+		}
+	}
+
+	It("marks the prompt failed when its timeout frontmatter expires", func() {
+		promptPath := queuePrompt("001-timeout.md", "timeout: 100ms\n")
+
+		p := newProcWithMaxPromptDuration(0)
+		go func() { _ = p.Process(ctx) }()
+
+		Eventually(readPrompt(promptPath), 2*time.Second, 50*time.Millisecond).
+			Should(ContainSubstring("status: failed"))
+		Expect(readPrompt(promptPath)()).To(ContainSubstring("prompt timed out after 100ms"))
+		Expect(executor.StopAndRemoveContainerCallCount()).To(Equal(1))
+	})
+
+	It("falls back to maxPromptDuration without timeout frontmatter", func() {
+		promptPath := queuePrompt("001-global-timeout.md", "")
+
+		p := newProcWithMaxPromptDuration(150 * time.Millisecond)
+		go func() { _ = p.Process(ctx) }()
+
+		Eventually(readPrompt(promptPath), 2*time.Second, 50*time.Millisecond).
+			Should(ContainSubstring("status: failed"))
+		Expect(readPrompt(promptPath)()).To(ContainSubstring("prompt timed out after 150ms"))
+	})
+
+	It("prefers timeout frontmatter over maxPromptDuration", func() {
+		promptPath := queuePrompt("001-override-timeout.md", "timeout: 100ms\n")
+
+		p := newProcWithMaxPromptDuration(time.Hour)
+		go func() { _ = p.Process(ctx) }()
+
+		Eventually(readPrompt(promptPath), 2*time.Second, 50*time.Millisecond).
+			Should(ContainSubstring("status: failed"))
+		Expect(readPrompt(promptPath)()).To(ContainSubstring("prompt timed out after 100ms"))
+		_, _, _, containerName := executor.ExecuteArgsForCall(0)
+		Expect(executor.StopAndRemoveContainerCallCount()).To(Equal(1))
+		_, stopped := executor.StopAndRemoveContainerArgsForCall(0)
+		Expect(stopped).To(Equal(containerName))
+	})
+
+	It("marks the prompt failed on an invalid timeout without executing", func() {
+		promptPath := queuePrompt("001-invalid-timeout.md", "timeout: soon\n")
+
+		p := newProcWithMaxPromptDuration(0)
+		go func() { _ = p.Process(ctx) }()
+
+		Eventually(readPrompt(promptPath), 2*time.Second, 50*time.Millisecond).
+			Should(ContainSubstring("status: failed"))
+		Expect(readPrompt(promptPath)()).To(ContainSubstring("not a positive duration"))
+		Expect(executor.ExecuteCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("ProcessPrompt — webhook", func() {
+	var (
+		ctx          context.Context
+		promptPath   string
+		logDir       string
+		server       *httptest.Server
+		payloads     chan notifier.WebhookPayload
+		exec         *mocks.Executor
+		mgr          *mocks.ProcessorPromptManager
+		workflowExec *mocks.WorkflowExecutor
+		pp           processorPromptProcesser
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		logDir = filepath.Join(tempDir, "log")
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+
+		promptPath = filepath.Join(tempDir, "003-hook.md")
+		Expect(os.WriteFile(
+			promptPath,
+			[]byte("---\nstatus: approved\n---\n# Hook the webhook\n\nDo it"),
+			0600,
+		)).To(Succeed())
+
+		payloads = make(chan notifier.WebhookPayload, 1)
+		server = httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload notifier.WebhookPayload
+				Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+				payloads <- payload
+			}),
+		)
+
+		mgr = &mocks.ProcessorPromptManager{}
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus)},
+				[]byte("# Hook the webhook\n\nDo it"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		exec = &mocks.Executor{}
+		vg := &mocks.VersionGetter{}
+		vg.GetReturns("v0.0.1-test")
+		workflowExec = &mocks.WorkflowExecutor{}
+
+		pp = newProcessorWithPromptNotifier(
+			logDir, exec, mgr, vg, workflowExec, nil, nil, nil,
+			notifier.NewWebhookNotifier(server.URL),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts the completed prompt after it is committed", func() {
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		var payload notifier.WebhookPayload
+		Eventually(payloads).Should(Receive(&payload))
+		Expect(payload.Prompt).To(Equal("003-hook.md"))
+		Expect(payload.Title).To(Equal("Hook the webhook"))
+		Expect(payload.Status).To(Equal("completed"))
+		Expect(payload.Version).To(Equal("v0.0.1-test"))
+		Expect(payload.Container).To(Equal("test-exec-003-hook"))
+		Expect(payload.Duration).To(BeNumerically(">=", 0))
+	})
+
+	It("does not post when the commit fails", func() {
+		workflowExec.CompleteReturns(stderrors.New("commit failed"))
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).NotTo(Succeed())
+		Consistently(payloads).ShouldNot(Receive())
+	})
+
+	It("posts the released version and PR link to Slack", func() {
+		texts := make(chan string, 1)
+		slack := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload map[string]string
+				Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+				texts <- payload["text"]
+			}),
+		)
+		defer slack.Close()
+		mgr.LoadReturns(
+			prompt.NewPromptFile(
+				promptPath,
+				prompt.Frontmatter{
+					Status: string(prompt.ApprovedPromptStatus),
+					PRURL:  "https://github.com/org/repo/pull/7",
+				},
+				[]byte("# Hook the webhook\n\nDo it"),
+				libtime.NewCurrentDateTime(),
+			),
+			nil,
+		)
+		workflowExec.CompleteStub = func(
+			_ context.Context,
+			ctx context.Context,
+			_ *prompt.PromptFile,
+			_, _, _ string,
+		) error {
+			processor.RecordReleaseForTest(ctx, "v1.4.0")
+			return nil
+		}
+		pp = newProcessorWithPromptNotifier(
+			logDir, exec, mgr, &mocks.VersionGetter{}, workflowExec, nil, nil, nil,
+			notifier.NewSlackNotifier(slack.URL),
+		)
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+
+		var text string
+		Eventually(texts).Should(Receive(&text))
+		Expect(text).To(ContainSubstring("Hook the webhook"))
+		Expect(text).To(ContainSubstring("v1.4.0"))
+		Expect(text).To(ContainSubstring("https://github.com/org/repo/pull/7"))
+	})
+
+	It("still completes the prompt when the webhook is unreachable", func() {
+		server.Close()
+
+		Expect(pp.ProcessPrompt(ctx, prompt.Prompt{Path: promptPath})).To(Succeed())
+		Expect(workflowExec.CompleteCallCount()).To(Equal(1))
+	})
+})
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor
+
+import (
+	"context"
+	"os"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/git"
+	log "github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+// projectTree is held by a prompt running in the project tree from its setup to its
+// commit, so the processors of several prompts directories, which share the project
+// tree, never edit it at the same time. Prompts with their own tree do not take it.
+var projectTree = make(chan struct{}, 1)
+
+// acquireProjectTree waits until no other prompt runs in the project tree. The
+// returned func releases it; it is a no-op when prompts get their own tree.
+func (p *processor) acquireProjectTree(ctx context.Context) (func(), error) {
+	if p.promptTrees != nil {
+		return func() {}, nil
+	}
+	select {
+	case projectTree <- struct{}{}:
+		return func() { <-projectTree }, nil
+	default:
+	}
+	log.From(ctx).Info("waiting for the prompt of another prompts directory")
+	select {
+	case projectTree <- struct{}{}:
+		return func() { <-projectTree }, nil
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx, ctx.Err(), "wait for project tree cancelled")
+	}
+}
+
+// addPromptTree adds the prompt's own worktree when promptTrees is set and binds it
+// as the container workspace. The returned func removes the worktree again; it is a
+// no-op without promptTrees.
+func (p *processor) addPromptTree(
+	ctx context.Context,
+	executionID prompt.ContainerName,
+) (context.Context, func(), error) {
+	if p.promptTrees == nil {
+		return ctx, func() {}, nil
+	}
+	path := git.PromptTreePath(executionID.String())
+	gitMu.Lock()
+	defer gitMu.Unlock()
+	// A tree left behind by a crashed daemon would make the add fail.
+	_ = p.promptTrees.Remove(ctx, path)
+	if err := os.RemoveAll(path); err != nil {
+		return ctx, nil, errors.Wrapf(ctx, err, "remove stale prompt tree %s", path)
+	}
+	workspace, err := p.promptTrees.AddDetached(ctx, path)
+	if err != nil {
+		return ctx, nil, errors.Wrap(ctx, err, "add prompt tree")
+	}
+	log.From(ctx).Info("prompt runs in its own tree", "tree", path)
+	removeCtx := context.WithoutCancel(ctx)
+	return executor.WithWorkspace(ctx, workspace), func() {
+		gitMu.Lock()
+		defer gitMu.Unlock()
+		_ = p.promptTrees.Remove(removeCtx, path)
+	}, nil
+}
+
+// applyPromptTree applies the changes made in the prompt's own worktree to the
+// project tree, where the workflow commits them. Callers hold gitMu.
+func (p *processor) applyPromptTree(ctx context.Context) error {
+	workspace := executor.WorkspaceFrom(ctx)
+	if p.promptTrees == nil || workspace == "" {
+		return nil
+	}
+	if err := p.promptTrees.ApplyChanges(ctx, workspace); err != nil {
+		return errors.Wrap(ctx, err, "apply prompt tree changes")
+	}
+	return nil
+}
@@ -4,7 +4,15 @@
 
 package processor
 
-// Dirs groups the three prompt directory paths used by the processor.
+import "github.com/bborbe/dark-factory/pkg/prompt"
+
+// Dirs groups the prompt directory paths used by the processor,
+// plus the layout of the completed directory. Inbox is the root that
+// includes resolve against. Scope is added to the container names of an
+// additional prompts directory so they never collide with another
+// directory's; it is empty for the primary directory.
 type Dirs struct {
-	Queue, Completed, Log string
+	Inbox, Queue, Completed, Log string
+	CompletedLayout              prompt.CompletedLayout
+	Scope                        string
 }
@@ -63,8 +63,8 @@ type WorkflowExecutor interface {
 
 	// CleanupOnError undoes any environment setup performed by Setup when
 	// execution or post-execution fails. Idempotent — safe to call if Setup was
-	// not called or has already been cleaned up. No-op for direct and branch
-	// executors where no isolated directory exists.
+	// not called or has already been cleaned up. For branch: switches back to
+	// the branch checked out before Setup. For direct: no-op.
 	CleanupOnError(ctx context.Context)
 
 	// Complete performs all post-execution git operations after the YOLO container
@@ -118,4 +118,16 @@ type WorkflowDeps struct {
 	// Typically set to the four prompts.* config directories.
 	// Nil or empty means no filtering (identical to the previous IsClean behavior).
 	IgnorePathPrefixes []string
+	// CommitBody selects what is appended below the title line of the prompt
+	// commit message. Empty means config.CommitBodyNone.
+	CommitBody config.CommitBody
+	// PRBodyTemplate is the text/template rendered as pull request body when
+	// the prompt sets no pr_body. Empty means the default body.
+	PRBodyTemplate string
+	// BranchTemplate is the text/template rendered as feature branch name when
+	// the prompt sets no branch. Empty means dark-factory/<baseName>.
+	BranchTemplate string
+	// DeleteBranch deletes the local feature branch created by the branch
+	// workflow once its pull request exists.
+	DeleteBranch bool
 }
@@ -20,6 +20,11 @@ type branchWorkflowExecutor struct {
 	// state set during Setup
 	inPlaceBranch        string
 	inPlaceDefaultBranch string
+	// originalBranch is the branch checked out before Setup switched to the
+	// feature branch. Cleared once a branch has been restored.
+	originalBranch string
+	// createdBranch is true when Setup created the feature branch locally.
+	createdBranch bool
 }
 
 // NewBranchWorkflowExecutor creates a WorkflowExecutor for the branch workflow.
@@ -28,8 +33,8 @@ func NewBranchWorkflowExecutor(deps WorkflowDeps) WorkflowExecutor {
 }
 
 // Setup syncs with remote, then switches to the feature branch.
-// When the prompt frontmatter has no branch field, a branch name is derived
-// from baseName using the same convention as the clone and worktree executors.
+// When the prompt frontmatter has no branch field, the branch name is rendered
+// from the branch template, the same way as in the clone and worktree executors.
 func (e *branchWorkflowExecutor) Setup(
 	ctx context.Context,
 	baseName prompt.BaseName,
@@ -38,13 +43,13 @@ func (e *branchWorkflowExecutor) Setup(
 	if err := syncWithRemoteViaDeps(ctx, e.deps); err != nil {
 		return errors.Wrap(ctx, err, "sync with remote")
 	}
-	branch := pf.Branch()
-	if branch == "" {
-		branch = "dark-factory/" + string(baseName)
-		// Persist the generated branch into the in-memory PromptFile so the
-		// caller's subsequent pf.Save() writes it to disk for resume support.
-		pf.SetBranchIfEmpty(branch)
+	branch, err := featureBranchName(ctx, e.deps.BranchTemplate, baseName, pf)
+	if err != nil {
+		return errors.Wrap(ctx, err, "feature branch name")
 	}
+	// Persist a generated branch into the in-memory PromptFile so the
+	// caller's subsequent pf.Save() writes it to disk for resume support.
+	pf.SetBranchIfEmpty(branch)
 	return e.setupInPlaceBranch(ctx, branch)
 }
 
@@ -79,6 +84,14 @@ func (e *branchWorkflowExecutor) setupInPlaceBranch(ctx context.Context, branch
 	}
 	e.inPlaceDefaultBranch = defaultBranch
 	e.inPlaceBranch = branch
+	e.createdBranch = false
+
+	// Capture the original branch before switching so CleanupOnError can return to it.
+	originalBranch, err := e.deps.Brancher.CurrentBranch(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, "get current branch")
+	}
+	e.originalBranch = originalBranch
 
 	if err := e.deps.Brancher.FetchAndVerifyBranch(ctx, branch); err == nil {
 		if err := e.deps.Brancher.Switch(ctx, branch); err != nil {
@@ -88,13 +101,31 @@ func (e *branchWorkflowExecutor) setupInPlaceBranch(ctx context.Context, branch
 		if err := e.deps.Brancher.CreateAndSwitch(ctx, branch); err != nil {
 			return errors.Wrap(ctx, err, "create and switch to branch")
 		}
+		e.createdBranch = true
 	}
 	log.From(ctx).Info("switched to branch for in-place execution", "branch", branch)
 	return nil
 }
 
-// CleanupOnError is a no-op for the branch workflow — no isolated directory to remove.
-func (e *branchWorkflowExecutor) CleanupOnError(_ context.Context) {}
+// CleanupOnError switches back to the branch checked out before Setup, so a failed
+// execution or commit never leaves the working tree on the feature branch.
+// Idempotent — a no-op once Complete or an earlier call restored a branch.
+func (e *branchWorkflowExecutor) CleanupOnError(ctx context.Context) {
+	originalBranch := e.originalBranch
+	if originalBranch == "" {
+		return
+	}
+	e.originalBranch = ""
+	if err := e.deps.Brancher.Switch(ctx, originalBranch); err != nil {
+		log.From(ctx).Warn(
+			"failed to restore original branch on error",
+			"branch", originalBranch,
+			"error", err,
+		)
+		return
+	}
+	log.From(ctx).Info("restored original branch on error", "branch", originalBranch)
+}
 
 // Complete moves prompt to completed, creates a combined commit on the feature branch,
 // restores the default branch, and handles PR/merge.
@@ -110,11 +141,12 @@ func (e *branchWorkflowExecutor) Complete(
 		e.restoreDefaultBranch(ctx)
 		return errors.Wrap(ctx, err, "move to completed")
 	}
-	log.From(ctx).Info("moved to completed")
+	log.From(ctx).Info("moved to completed", log.Event(log.EventCompleted))
 
 	// Create a combined commit (work changes + prompt move) on the feature branch.
 	// Roll back the move BEFORE restoring the default branch if the commit fails.
-	if err := handleDirectWorkflow(gitCtx, ctx, e.deps, title, featureBranch); err != nil {
+	message := buildCommitMessage(e.deps.CommitBody, title, pf)
+	if err := handleDirectWorkflow(gitCtx, ctx, e.deps, message, featureBranch); err != nil {
 		if rollbackErr := e.deps.PromptManager.RollbackMoveToCompleted(ctx, completedPath, e.deps.FileMover); rollbackErr != nil {
 			log.From(ctx).Error("rollback after commit failure failed", "error", rollbackErr)
 		}
@@ -158,12 +190,14 @@ func (e *branchWorkflowExecutor) ReconstructState(
 			return false, errors.Wrap(ctx, err, "get default branch for resume")
 		}
 		e.inPlaceDefaultBranch = defaultBranch
+		e.originalBranch = defaultBranch
 	}
 	return true, nil
 }
 
 // restoreDefaultBranch switches back to the default branch after in-place execution.
 func (e *branchWorkflowExecutor) restoreDefaultBranch(ctx context.Context) {
+	e.originalBranch = ""
 	if e.inPlaceDefaultBranch == "" {
 		return
 	}
@@ -228,6 +262,7 @@ func (e *branchWorkflowExecutor) handleBranchPRCompletion(
 	if err != nil {
 		return errors.Wrap(ctx, err, "find or create PR")
 	}
+	e.deleteCreatedBranch(gitCtx, ctx, featureBranch)
 	if e.deps.AutoMerge {
 		hasMore, err := e.deps.PromptManager.HasQueuedPromptsOnBranch(
 			ctx,
@@ -257,3 +292,22 @@ func (e *branchWorkflowExecutor) handleBranchPRCompletion(
 	savePRURLToFrontmatter(gitCtx, e.deps, completedPath, prURL)
 	return nil
 }
+
+// deleteCreatedBranch deletes the local feature branch after its PR was created,
+// when deleteBranch is enabled and Setup created the branch. Best-effort: the
+// branch is already pushed, so a failure is only logged.
+func (e *branchWorkflowExecutor) deleteCreatedBranch(
+	gitCtx context.Context,
+	ctx context.Context,
+	featureBranch string,
+) {
+	if !e.deps.DeleteBranch || !e.createdBranch {
+		return
+	}
+	e.createdBranch = false
+	if err := e.deps.Brancher.DeleteBranch(gitCtx, featureBranch); err != nil {
+		log.From(ctx).Warn("failed to delete feature branch", "branch", featureBranch, "error", err)
+		return
+	}
+	log.From(ctx).Info("deleted feature branch", "branch", featureBranch)
+}
@@ -16,6 +16,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/git"
 	"github.com/bborbe/dark-factory/pkg/processor"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 )
@@ -237,6 +238,145 @@ func writeFileBranch(path, content string) {
 	Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
 }
 
+var _ = Describe("branchWorkflowExecutor CleanupOnError", func() {
+	var (
+		ctx          context.Context
+		fakeBrancher *mocks.Brancher
+		workflowExec processor.WorkflowExecutor
+		pf           *prompt.PromptFile
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeBrancher = &mocks.Brancher{}
+		fakeBrancher.IsCleanIgnoringReturns([]string{}, nil)
+		fakeBrancher.DefaultBranchReturns("master", nil)
+		fakeBrancher.CurrentBranchReturns("master", nil)
+		fakeBrancher.FetchAndVerifyBranchReturns(stderrors.New("not found"))
+		workflowExec = processor.NewBranchWorkflowExecutor(processor.WorkflowDeps{
+			Brancher: fakeBrancher,
+			PR:       true,
+		})
+		pf = prompt.NewPromptFile(
+			"/tmp/001-test.md",
+			prompt.Frontmatter{Status: "executing"},
+			[]byte("# Test\n"),
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("does nothing before Setup", func() {
+		workflowExec.CleanupOnError(ctx)
+
+		Expect(fakeBrancher.SwitchCallCount()).To(Equal(0))
+	})
+
+	It("switches back to the original branch once", func() {
+		Expect(workflowExec.Setup(ctx, "001-test", pf)).To(Succeed())
+		Expect(fakeBrancher.CreateAndSwitchCallCount()).To(Equal(1))
+
+		workflowExec.CleanupOnError(ctx)
+		workflowExec.CleanupOnError(ctx)
+
+		Expect(fakeBrancher.SwitchCallCount()).To(Equal(1))
+		_, branch := fakeBrancher.SwitchArgsForCall(0)
+		Expect(branch).To(Equal("master"))
+	})
+
+	It("does not set up anything when the current branch is unknown", func() {
+		fakeBrancher.CurrentBranchReturns("", stderrors.New("detached HEAD"))
+
+		err := workflowExec.Setup(ctx, "001-test", pf)
+		Expect(err).To(MatchError(ContainSubstring("get current branch")))
+		Expect(fakeBrancher.CreateAndSwitchCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("branchWorkflowExecutor deleteBranch", func() {
+	var (
+		ctx          context.Context
+		fakeBrancher *mocks.Brancher
+		prCreator    *mocks.PRCreator
+		deps         processor.WorkflowDeps
+		pf           *prompt.PromptFile
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeBrancher = &mocks.Brancher{}
+		prCreator = &mocks.PRCreator{}
+		prCreator.CreateReturns("https://github.com/org/repo/pull/1", nil)
+		deps = processor.WorkflowDeps{
+			Brancher:      fakeBrancher,
+			PRCreator:     prCreator,
+			PromptManager: &mocks.ProcessorPromptManager{},
+			PR:            true,
+			DeleteBranch:  true,
+		}
+		pf = prompt.NewPromptFile(
+			"/tmp/001-test.md",
+			prompt.Frontmatter{Status: "completed"},
+			[]byte("# Test\n"),
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("deletes the created branch after the PR is created", func() {
+		err := processor.HandleBranchPRCompletionForTest(deps, ctx, pf, "dark-factory/001-test", true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeBrancher.DeleteBranchCallCount()).To(Equal(1))
+		_, branch := fakeBrancher.DeleteBranchArgsForCall(0)
+		Expect(branch).To(Equal("dark-factory/001-test"))
+	})
+
+	It("retains the branch when the push fails", func() {
+		fakeBrancher.PushReturns(stderrors.New("rejected"))
+
+		err := processor.HandleBranchPRCompletionForTest(deps, ctx, pf, "dark-factory/001-test", true)
+		Expect(err).To(MatchError(ContainSubstring("push feature branch")))
+		Expect(fakeBrancher.DeleteBranchCallCount()).To(Equal(0))
+	})
+
+	It("retains the branch when the PR cannot be created", func() {
+		prCreator.CreateReturns("", stderrors.New("gh failed"))
+
+		err := processor.HandleBranchPRCompletionForTest(deps, ctx, pf, "dark-factory/001-test", true)
+		Expect(err).To(MatchError(ContainSubstring("find or create PR")))
+		Expect(fakeBrancher.DeleteBranchCallCount()).To(Equal(0))
+	})
+
+	It("retains a branch that existed before Setup", func() {
+		err := processor.HandleBranchPRCompletionForTest(deps, ctx, pf, "dark-factory/001-test", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeBrancher.DeleteBranchCallCount()).To(Equal(0))
+	})
+
+	It("passes draft, reviewers and labels of the prompt to the PR creator", func() {
+		pf.Frontmatter.Draft = true
+		pf.Frontmatter.Reviewers = []string{"alice"}
+		pf.Frontmatter.Labels = []string{"risky"}
+
+		err := processor.HandleBranchPRCompletionForTest(deps, ctx, pf, "dark-factory/001-test", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prCreator.CreateCallCount()).To(Equal(1))
+		createCtx, _, _, _ := prCreator.CreateArgsForCall(0)
+		Expect(git.PROptionsFrom(createCtx)).To(Equal(git.PROptions{
+			Draft:     true,
+			Reviewers: []string{"alice"},
+			Labels:    []string{"risky"},
+		}))
+	})
+
+	It("retains the branch when deleteBranch is disabled", func() {
+		deps.DeleteBranch = false
+
+		err := processor.HandleBranchPRCompletionForTest(deps, ctx, pf, "dark-factory/001-test", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeBrancher.DeleteBranchCallCount()).To(Equal(0))
+	})
+})
+
 var _ = Describe("branchWorkflowExecutor moves prompt before commit", func() {
 	It(
 		"produces a single commit on the feature branch containing both code change and prompt rename (move before commit)",
@@ -40,11 +40,11 @@ func (e *cloneWorkflowExecutor) Setup(
 		return errors.Wrap(ctx, err, "sync with remote")
 	}
 
-	if branch := pf.Branch(); branch != "" {
-		e.branchName = branch
-	} else {
-		e.branchName = "dark-factory/" + string(baseName)
+	branch, err := featureBranchName(ctx, e.deps.BranchTemplate, baseName, pf)
+	if err != nil {
+		return errors.Wrap(ctx, err, "feature branch name")
 	}
+	e.branchName = branch
 	e.clonePath = filepath.Join(
 		os.TempDir(),
 		"dark-factory",
@@ -105,12 +105,15 @@ func (e *cloneWorkflowExecutor) Complete(
 	if err := e.deps.PromptManager.MoveToCompleted(ctx, promptPath); err != nil {
 		return errors.Wrap(ctx, err, "move to completed")
 	}
-	log.From(ctx).Info("moved to completed")
+	log.From(ctx).Info("moved to completed", log.Event(log.EventCompleted))
 
 	// Single combined commit: work changes + prompt move.
-	if err := e.deps.Releaser.CommitOnly(gitCtx, title); err != nil {
+	message := buildCommitMessage(e.deps.CommitBody, title, pf)
+	before := headCommit(gitCtx, e.deps.Releaser)
+	if err := e.deps.Releaser.CommitOnly(gitCtx, message); err != nil {
 		return errors.Wrap(ctx, err, "commit changes")
 	}
+	recordCommit(gitCtx, ctx, e.deps.Releaser, before)
 
 	// Push from inside the clone while the feature branch is still locally visible.
 	// The parent repo has never seen this branch; pushing here ensures it exists on
@@ -172,11 +175,11 @@ func (e *cloneWorkflowExecutor) ReconstructState(
 	if _, err := os.Stat(clonePath); err != nil {
 		return false, nil // clone missing — signal reset-to-approved
 	}
-	if branch := pf.Branch(); branch != "" {
-		e.branchName = branch
-	} else {
-		e.branchName = "dark-factory/" + string(baseName)
+	branch, err := featureBranchName(ctx, e.deps.BranchTemplate, baseName, pf)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, "feature branch name for resume")
 	}
+	e.branchName = branch
 	e.clonePath = clonePath
 	originalDir, err := os.Getwd()
 	if err != nil {
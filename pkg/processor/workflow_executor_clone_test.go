@@ -292,7 +292,7 @@ func (r *realGitReleaser) CommitAndRelease(_ context.Context, _ git.VersionBump)
 	return runGit(r.workDir, "commit", "-m", "release")
 }
 
-func (r *realGitReleaser) CommitCompletedFile(_ context.Context, _ string) error {
+func (r *realGitReleaser) CommitCompletedFile(_ context.Context, _, _ string) error {
 	return nil
 }
 
@@ -317,6 +317,10 @@ func (r *realGitReleaser) CommitOnly(_ context.Context, title string) error {
 	return runGit(r.workDir, "commit", "-m", title)
 }
 
+func (r *realGitReleaser) CommitPaths(_ context.Context, _ string, _ ...string) error {
+	return nil
+}
+
 func (r *realGitReleaser) HasChangelog(_ context.Context) bool {
 	return r.hasChangelog
 }
@@ -343,6 +347,13 @@ func (r *realGitReleaser) CommitWithRetry(
 	return fn(ctx)
 }
 
+func (r *realGitReleaser) HeadCommit(_ context.Context) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = r.workDir
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
 func (r *realGitReleaser) Push(_ context.Context, branch string) error {
 	if r.pushErr != nil {
 		return r.pushErr
@@ -374,6 +385,10 @@ func (r *realBrancher) Switch(_ context.Context, name string) error {
 	return runGit(r.workDir, "checkout", name)
 }
 
+func (r *realBrancher) DeleteBranch(_ context.Context, name string) error {
+	return runGit(r.workDir, "branch", "-D", name)
+}
+
 func (r *realBrancher) CurrentBranch(_ context.Context) (string, error) {
 	output, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").CombinedOutput()
 	if err != nil {
@@ -69,11 +69,17 @@ func (e *directWorkflowExecutor) completeCommit(
 	if err := e.deps.PromptManager.MoveToCompleted(ctx, promptPath); err != nil {
 		return errors.Wrap(ctx, err, "move to completed")
 	}
-	log.From(ctx).Info("moved to completed")
+	log.From(ctx).Info("moved to completed", log.Event(log.EventCompleted))
 
 	// Commit all code changes with retry. If the commit fails, roll the prompt file back to in-progress/ first.
 	if err := e.deps.Releaser.CommitWithRetry(gitCtx, func(retryCtx context.Context) error {
-		return handleDirectWorkflow(retryCtx, ctx, e.deps, title, "")
+		return handleDirectWorkflow(
+			retryCtx,
+			ctx,
+			e.deps,
+			buildCommitMessage(e.deps.CommitBody, title, pf),
+			"",
+		)
 	}); err != nil {
 		if rollbackErr := e.deps.PromptManager.RollbackMoveToCompleted(ctx, completedPath, e.deps.FileMover); rollbackErr != nil {
 			log.From(ctx).Error("rollback after commit failure failed", "error", rollbackErr)
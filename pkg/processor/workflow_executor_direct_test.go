@@ -51,7 +51,7 @@ func (r *realGitReleaser) CommitAndRelease(_ context.Context, _ git.VersionBump)
 	return runGitDirect(r.workDir, "commit", "-m", "release")
 }
 
-func (r *realGitReleaser) CommitCompletedFile(_ context.Context, _ string) error {
+func (r *realGitReleaser) CommitCompletedFile(_ context.Context, _, _ string) error {
 	return nil
 }
 
@@ -65,6 +65,10 @@ func (r *realGitReleaser) CommitOnly(_ context.Context, title string) error {
 	return runGitDirect(r.workDir, "commit", "-m", title)
 }
 
+func (r *realGitReleaser) CommitPaths(_ context.Context, _ string, _ ...string) error {
+	return nil
+}
+
 func (r *realGitReleaser) HasChangelog(_ context.Context) bool {
 	return r.hasChangelog
 }
@@ -98,6 +102,13 @@ func (r *realGitReleaser) CommitWithRetry(
 	return fn(ctx)
 }
 
+func (r *realGitReleaser) HeadCommit(_ context.Context) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = r.workDir
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
 func runGitDirect(dir string, args ...string) error {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
@@ -325,6 +336,58 @@ var _ = Describe("directWorkflowExecutor no-diff success (regression)", func() {
 		Expect(rel.commitOnlyCount).To(Equal(1))
 		Expect(rel.commitAndReleaseCount).To(Equal(0))
 	})
+
+	It("completes with the real releaser when the working tree stays clean", func() {
+		ctx := context.Background()
+		repoDir := setupRealGitRepo(GinkgoT())
+		writeFile(filepath.Join(repoDir, ".gitignore"), "prompts/\n")
+		Expect(runGitDirect(repoDir, "add", ".gitignore")).To(Succeed())
+		Expect(runGitDirect(repoDir, "commit", "-m", "ignore prompts")).To(Succeed())
+		headBefore, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+		Expect(err).NotTo(HaveOccurred())
+
+		originalDir, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir(repoDir)).To(Succeed())
+		DeferCleanup(func() { Expect(os.Chdir(originalDir)).To(Succeed()) })
+
+		queueDir := filepath.Join(repoDir, "prompts", "in-progress")
+		completedDirPath := filepath.Join(repoDir, "prompts", "completed")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(completedDirPath, 0750)).To(Succeed())
+		promptPath := filepath.Join(queueDir, "001-noop.md")
+		writePromptFile(promptPath, "committing")
+		completedPath := filepath.Join(completedDirPath, "001-noop.md")
+
+		executor := NewDirectWorkflowExecutor(WorkflowDeps{
+			PromptManager: prompt.NewManager(
+				filepath.Join(repoDir, "prompts", "inbox"),
+				queueDir,
+				completedDirPath,
+				"",
+				&osFileMover{},
+				libtime.NewCurrentDateTime(),
+			),
+			AutoCompleter: &stubAutoCompleter{},
+			Releaser:      git.NewReleaser(),
+			FileMover:     &osFileMover{},
+		})
+		pf := prompt.NewPromptFile(
+			promptPath,
+			prompt.Frontmatter{Status: "committing"},
+			[]byte("# Noop\n"),
+			libtime.NewCurrentDateTime(),
+		)
+
+		recCtx, committed := withCommitRecorder(ctx)
+		Expect(executor.Complete(recCtx, recCtx, pf, "noop title", promptPath, completedPath)).
+			To(Succeed())
+		Expect(completedPath).To(BeAnExistingFile())
+		headAfter, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(headAfter)).To(Equal(string(headBefore)))
+		Expect(committed()).To(BeEmpty())
+	})
 })
 
 var _ = Describe("directWorkflowExecutor moves prompt before commit", func() {
@@ -386,8 +449,13 @@ var _ = Describe("directWorkflowExecutor moves prompt before commit", func() {
 			// Modify code file before complete
 			writeFile(codeFile, "package main // modified\n")
 
-			err := executor.Complete(ctx, ctx, pf, "test commit", promptPath, completedPath)
+			recCtx, committed := withCommitRecorder(ctx)
+			err := executor.Complete(recCtx, recCtx, pf, "test commit", promptPath, completedPath)
+			Expect(err).NotTo(HaveOccurred())
+			head, err := exec.CommandContext(ctx, "git", "-C", repoDir, "rev-parse", "HEAD").
+				Output()
 			Expect(err).NotTo(HaveOccurred())
+			Expect(committed()).To(Equal(strings.TrimSpace(string(head))))
 
 			// Verify: prompt is at completed path, not at in-progress path.
 			// `git ls-tree HEAD -- <path>` exits 0 with empty stdout when path doesn't match.
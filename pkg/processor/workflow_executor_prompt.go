@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/dark-factory/pkg/config"
+	log "github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+// promptWorkflowExecutor runs each prompt through the executor named by its
+// workflow frontmatter, falling back to the configured default executor.
+type promptWorkflowExecutor struct {
+	defaultExecutor WorkflowExecutor
+	executors       map[config.Workflow]WorkflowExecutor
+
+	mu      sync.Mutex
+	current WorkflowExecutor
+}
+
+// NewPromptWorkflowExecutor creates a WorkflowExecutor that lets a prompt override
+// the workflow with `workflow:` frontmatter. executors maps every selectable
+// workflow (including config.WorkflowPR for clone with pr: true) to its executor.
+// Prompts without the field, or with a value missing from executors, use
+// defaultExecutor; an unknown value is logged as a warning.
+func NewPromptWorkflowExecutor(
+	defaultExecutor WorkflowExecutor,
+	executors map[config.Workflow]WorkflowExecutor,
+) WorkflowExecutor {
+	return &promptWorkflowExecutor{
+		defaultExecutor: defaultExecutor,
+		executors:       executors,
+	}
+}
+
+// Setup selects the executor for pf and delegates to it.
+func (e *promptWorkflowExecutor) Setup(
+	ctx context.Context,
+	baseName prompt.BaseName,
+	pf *prompt.PromptFile,
+) error {
+	return e.use(e.selectFor(ctx, pf, true)).Setup(ctx, baseName, pf)
+}
+
+// CleanupOnError delegates to the executor selected by the last Setup or ReconstructState.
+func (e *promptWorkflowExecutor) CleanupOnError(ctx context.Context) {
+	e.mu.Lock()
+	current := e.current
+	e.mu.Unlock()
+	if current == nil {
+		current = e.defaultExecutor
+	}
+	current.CleanupOnError(ctx)
+}
+
+// Complete delegates to the executor selected for pf.
+func (e *promptWorkflowExecutor) Complete(
+	gitCtx context.Context,
+	ctx context.Context,
+	pf *prompt.PromptFile,
+	title, promptPath, completedPath string,
+) error {
+	return e.selectFor(ctx, pf, false).
+		Complete(gitCtx, ctx, pf, title, promptPath, completedPath)
+}
+
+// ReconstructState selects the executor for pf and delegates to it.
+func (e *promptWorkflowExecutor) ReconstructState(
+	ctx context.Context,
+	baseName prompt.BaseName,
+	pf *prompt.PromptFile,
+) (bool, error) {
+	return e.use(e.selectFor(ctx, pf, false)).ReconstructState(ctx, baseName, pf)
+}
+
+// use records executor as the one CleanupOnError delegates to.
+func (e *promptWorkflowExecutor) use(executor WorkflowExecutor) WorkflowExecutor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.current = executor
+	return executor
+}
+
+// selectFor returns the executor named by the workflow frontmatter of pf, or the
+// default executor. warn logs unknown values, so each prompt warns once.
+func (e *promptWorkflowExecutor) selectFor(
+	ctx context.Context,
+	pf *prompt.PromptFile,
+	warn bool,
+) WorkflowExecutor {
+	if pf == nil || pf.Frontmatter.Workflow == "" {
+		return e.defaultExecutor
+	}
+	executor, ok := e.executors[config.Workflow(pf.Frontmatter.Workflow)]
+	if !ok {
+		if warn {
+			log.From(ctx).Warn(
+				"unknown workflow override, using the configured workflow",
+				"workflow", pf.Frontmatter.Workflow,
+			)
+		}
+		return e.defaultExecutor
+	}
+	return executor
+}
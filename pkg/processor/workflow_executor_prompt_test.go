@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package processor_test
+
+import (
+	"context"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/processor"
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("PromptWorkflowExecutor", func() {
+	var (
+		ctx             context.Context
+		directExecutor  *mocks.WorkflowExecutor
+		prExecutor      *mocks.WorkflowExecutor
+		workflowExec    processor.WorkflowExecutor
+		newPromptFile   func(workflow string) *prompt.PromptFile
+		baseName        prompt.BaseName
+		completeWithPF  func(pf *prompt.PromptFile) error
+		setupAndCleanup func(pf *prompt.PromptFile)
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		directExecutor = &mocks.WorkflowExecutor{}
+		prExecutor = &mocks.WorkflowExecutor{}
+		workflowExec = processor.NewPromptWorkflowExecutor(
+			directExecutor,
+			map[config.Workflow]processor.WorkflowExecutor{
+				config.WorkflowDirect: directExecutor,
+				config.WorkflowPR:     prExecutor,
+			},
+		)
+		baseName = prompt.BaseName("001-test")
+		newPromptFile = func(workflow string) *prompt.PromptFile {
+			return prompt.NewPromptFile(
+				"/tmp/001-test.md",
+				prompt.Frontmatter{Workflow: workflow},
+				[]byte("# Test\n"),
+				libtime.NewCurrentDateTime(),
+			)
+		}
+		completeWithPF = func(pf *prompt.PromptFile) error {
+			return workflowExec.Complete(ctx, ctx, pf, "Test", "/tmp/a.md", "/tmp/b.md")
+		}
+		setupAndCleanup = func(pf *prompt.PromptFile) {
+			Expect(workflowExec.Setup(ctx, baseName, pf)).To(Succeed())
+			workflowExec.CleanupOnError(ctx)
+		}
+	})
+
+	It("uses the default executor without workflow frontmatter", func() {
+		pf := newPromptFile("")
+		setupAndCleanup(pf)
+		Expect(completeWithPF(pf)).To(Succeed())
+
+		Expect(directExecutor.SetupCallCount()).To(Equal(1))
+		Expect(directExecutor.CleanupOnErrorCallCount()).To(Equal(1))
+		Expect(directExecutor.CompleteCallCount()).To(Equal(1))
+		Expect(prExecutor.SetupCallCount()).To(Equal(0))
+	})
+
+	It("uses the executor named by the workflow frontmatter", func() {
+		pf := newPromptFile("pr")
+		setupAndCleanup(pf)
+		Expect(completeWithPF(pf)).To(Succeed())
+
+		Expect(prExecutor.SetupCallCount()).To(Equal(1))
+		Expect(prExecutor.CleanupOnErrorCallCount()).To(Equal(1))
+		Expect(prExecutor.CompleteCallCount()).To(Equal(1))
+		Expect(directExecutor.SetupCallCount()).To(Equal(0))
+		Expect(directExecutor.CompleteCallCount()).To(Equal(0))
+	})
+
+	It("falls back to the default executor for an unknown workflow", func() {
+		pf := newPromptFile("bogus")
+		setupAndCleanup(pf)
+		Expect(completeWithPF(pf)).To(Succeed())
+
+		Expect(directExecutor.SetupCallCount()).To(Equal(1))
+		Expect(directExecutor.CompleteCallCount()).To(Equal(1))
+		Expect(prExecutor.SetupCallCount()).To(Equal(0))
+	})
+
+	It("reconstructs state with the executor named by the workflow frontmatter", func() {
+		prExecutor.ReconstructStateReturns(true, nil)
+
+		ok, err := workflowExec.ReconstructState(ctx, baseName, newPromptFile("pr"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(prExecutor.ReconstructStateCallCount()).To(Equal(1))
+
+		workflowExec.CleanupOnError(ctx)
+		Expect(prExecutor.CleanupOnErrorCallCount()).To(Equal(1))
+	})
+
+	It("cleans up with the default executor before any setup", func() {
+		workflowExec.CleanupOnError(ctx)
+
+		Expect(directExecutor.CleanupOnErrorCallCount()).To(Equal(1))
+		Expect(prExecutor.CleanupOnErrorCallCount()).To(Equal(0))
+	})
+})
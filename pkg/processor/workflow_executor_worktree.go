@@ -40,9 +40,9 @@ func (e *worktreeWorkflowExecutor) Setup(
 		return errors.Wrap(ctx, err, "sync with remote")
 	}
 
-	branch := pf.Branch()
-	if branch == "" {
-		branch = "dark-factory/" + string(baseName)
+	branch, err := featureBranchName(ctx, e.deps.BranchTemplate, baseName, pf)
+	if err != nil {
+		return errors.Wrap(ctx, err, "feature branch name")
 	}
 	e.branchName = branch
 	e.worktreePath = filepath.Join(
@@ -106,12 +106,15 @@ func (e *worktreeWorkflowExecutor) Complete(
 	if err := e.deps.PromptManager.MoveToCompleted(ctx, promptPath); err != nil {
 		return errors.Wrap(ctx, err, "move to completed")
 	}
-	log.From(ctx).Info("moved to completed")
+	log.From(ctx).Info("moved to completed", log.Event(log.EventCompleted))
 
 	// Single combined commit: work changes + prompt move.
-	if err := e.deps.Releaser.CommitOnly(gitCtx, title); err != nil {
+	message := buildCommitMessage(e.deps.CommitBody, title, pf)
+	before := headCommit(gitCtx, e.deps.Releaser)
+	if err := e.deps.Releaser.CommitOnly(gitCtx, message); err != nil {
 		return errors.Wrap(ctx, err, "commit changes")
 	}
+	recordCommit(gitCtx, ctx, e.deps.Releaser, before)
 
 	if err := os.Chdir(e.originalDir); err != nil {
 		return errors.Wrap(ctx, err, "chdir back to original directory")
@@ -166,9 +169,9 @@ func (e *worktreeWorkflowExecutor) ReconstructState(
 	if _, err := os.Stat(worktreePath); err != nil {
 		return false, nil // worktree missing — signal reset-to-approved
 	}
-	branch := pf.Branch()
-	if branch == "" {
-		branch = "dark-factory/" + string(baseName)
+	branch, err := featureBranchName(ctx, e.deps.BranchTemplate, baseName, pf)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, "feature branch name for resume")
 	}
 	e.branchName = branch
 	e.worktreePath = worktreePath
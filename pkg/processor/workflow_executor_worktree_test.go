@@ -16,6 +16,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/dark-factory/pkg/git"
 	"github.com/bborbe/dark-factory/pkg/processor"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 )
@@ -227,6 +228,14 @@ func (w *realWorktreer) Remove(ctx context.Context, path string) error {
 	return cmd.Run()
 }
 
+func (w *realWorktreer) AddDetached(ctx context.Context, path string) (string, error) {
+	return git.NewWorktreer().AddDetached(ctx, path)
+}
+
+func (w *realWorktreer) ApplyChanges(ctx context.Context, path string) error {
+	return git.NewWorktreer().ApplyChanges(ctx, path)
+}
+
 // NOTE: integration-level "sync failure" injection was removed for the same
 // reason as in workflow_executor_clone_test.go — see the comment there.
 // Failure-path coverage lives in workflow_helpers_internal_test.go.
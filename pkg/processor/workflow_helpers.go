@@ -5,17 +5,51 @@
 package processor
 
 import (
+	"bytes"
 	"context"
 	"os"
+	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/bborbe/errors"
 
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/git"
 	log "github.com/bborbe/dark-factory/pkg/log"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
+// buildCommitMessage returns the commit message for a completed prompt: the title
+// line, followed by a blank line and the body selected by commitBody. Falls back
+// to the title alone when the selected body is empty.
+func buildCommitMessage(commitBody config.CommitBody, title string, pf *prompt.PromptFile) string {
+	var body string
+	switch commitBody {
+	case config.CommitBodySummary:
+		body = pf.Frontmatter.Summary
+	case config.CommitBodyFull:
+		body = promptBodyWithoutTitle(pf, title)
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return title
+	}
+	return title + "\n\n" + body
+}
+
+// promptBodyWithoutTitle returns the prompt body with a leading "# <title>" heading
+// removed, so the title is not repeated in the commit body.
+func promptBodyWithoutTitle(pf *prompt.PromptFile, title string) string {
+	body := strings.TrimSpace(string(pf.Body))
+	firstLine, rest, _ := strings.Cut(body, "\n")
+	if strings.TrimSpace(firstLine) == "# "+title {
+		return rest
+	}
+	return body
+}
+
 // syncWithRemoteViaDeps fetches and merges from remote using deps.Brancher.
 func syncWithRemoteViaDeps(ctx context.Context, deps WorkflowDeps) error {
 	log.From(ctx).Info("syncing with remote default branch")
@@ -30,6 +64,122 @@ func syncWithRemoteViaDeps(ctx context.Context, deps WorkflowDeps) error {
 	return nil
 }
 
+// prBodyData holds the placeholders available to a PR body template.
+type prBodyData struct {
+	Title   string
+	Prompt  string
+	Summary string
+	Issue   string
+	Specs   []string
+	Version string
+}
+
+// renderPRBody returns the PR body for pf. The prompt's pr_body template wins
+// over prBodyTemplate; without either the default body of buildPRBody is used.
+func renderPRBody(
+	ctx context.Context,
+	prBodyTemplate string,
+	title string,
+	pf *prompt.PromptFile,
+) (string, error) {
+	text := pf.PRBody()
+	if text == "" {
+		text = prBodyTemplate
+	}
+	if text == "" {
+		return buildPRBody(pf), nil
+	}
+	tmpl, err := template.New("pr_body").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "parse PR body template")
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, prBodyData{
+		Title:   title,
+		Prompt:  strings.TrimSpace(promptBodyWithoutTitle(pf, title)),
+		Summary: pf.Summary(),
+		Issue:   pf.Issue(),
+		Specs:   pf.Specs(),
+		Version: pf.Frontmatter.DarkFactoryVersion,
+	}); err != nil {
+		return "", errors.Wrap(ctx, err, "render PR body template")
+	}
+	return buf.String(), nil
+}
+
+// defaultBranchTemplate renders the feature branch name when branchTemplate is empty.
+const defaultBranchTemplate = "dark-factory/{{.BaseName}}"
+
+// branchNameData is the data available to the branchTemplate.
+type branchNameData struct {
+	BaseName string
+	Number   string
+	Slug     string
+	Title    string
+}
+
+var (
+	invalidBranchCharRegexp = regexp.MustCompile(`[^a-zA-Z0-9/_.-]+`)
+	repeatedDashRegexp      = regexp.MustCompile(`-{2,}`)
+	repeatedDotRegexp       = regexp.MustCompile(`\.{2,}`)
+)
+
+// featureBranchName returns the feature branch for pf. The branch frontmatter wins;
+// otherwise branchTemplate (or defaultBranchTemplate) is rendered with the number,
+// slug and title of the prompt and sanitized to a valid git ref.
+func featureBranchName(
+	ctx context.Context,
+	branchTemplate string,
+	baseName prompt.BaseName,
+	pf *prompt.PromptFile,
+) (string, error) {
+	if branch := pf.Branch(); branch != "" {
+		return branch, nil
+	}
+	if branchTemplate == "" {
+		branchTemplate = defaultBranchTemplate
+	}
+	tmpl, err := template.New("branch").Parse(branchTemplate)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "parse branch template")
+	}
+	slug := prompt.StripNumberPrefix(string(baseName))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, branchNameData{
+		BaseName: string(baseName),
+		Number:   strings.TrimSuffix(strings.TrimSuffix(string(baseName), slug), "-"),
+		Slug:     slug,
+		Title:    pf.Title(),
+	}); err != nil {
+		return "", errors.Wrap(ctx, err, "render branch template")
+	}
+	branch := sanitizeBranchName(buf.String())
+	if err := git.ValidateBranchName(ctx, branch); err != nil {
+		return "", errors.Wrapf(ctx, err, "branch template rendered %q", buf.String())
+	}
+	return branch, nil
+}
+
+// sanitizeBranchName turns name into a valid git ref: runs of characters outside
+// [a-zA-Z0-9/_.-] become a single dash, and every path segment is stripped of
+// leading and trailing dashes and dots, "..", and a ".lock" suffix. Empty segments
+// are dropped.
+func sanitizeBranchName(name string) string {
+	name = invalidBranchCharRegexp.ReplaceAllString(name, "-")
+	name = repeatedDashRegexp.ReplaceAllString(name, "-")
+	name = repeatedDotRegexp.ReplaceAllString(name, ".")
+	var segments []string
+	for _, segment := range strings.Split(name, "/") {
+		segment = strings.Trim(segment, "-._")
+		segment = strings.TrimSuffix(segment, ".lock")
+		segment = strings.Trim(segment, "-._")
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
 // buildPRBody constructs the PR body from the prompt file's summary, spec links, and issue reference.
 func buildPRBody(pf *prompt.PromptFile) string {
 	var parts []string
@@ -71,7 +221,16 @@ func findOrCreatePR(
 		)
 		return prURL, nil
 	}
-	prURL, err = deps.PRCreator.Create(gitCtx, title, buildPRBody(pf), branchName)
+	body, err := renderPRBody(ctx, deps.PRBodyTemplate, title, pf)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "build pull request body")
+	}
+	gitCtx = git.WithPROptions(gitCtx, git.PROptions{
+		Draft:     pf.Frontmatter.Draft,
+		Reviewers: pf.Frontmatter.Reviewers,
+		Labels:    pf.Frontmatter.Labels,
+	})
+	prURL, err = deps.PRCreator.Create(gitCtx, title, body, branchName)
 	if err != nil {
 		return "", errors.Wrap(ctx, err, "create pull request")
 	}
@@ -96,40 +255,167 @@ func savePRURLToFrontmatter(
 	}
 }
 
+type bumpOverrideKey struct{}
+
+// withBumpOverride returns a context whose release uses bump instead of the
+// bump derived from the changelog.
+func withBumpOverride(ctx context.Context, bump git.VersionBump) context.Context {
+	return context.WithValue(ctx, bumpOverrideKey{}, bump)
+}
+
+type skipReleaseKey struct{}
+
+// skipChangelogMarker in a prompt title has the same effect as skip_changelog: true.
+const skipChangelogMarker = "[skip changelog]"
+
+// withSkipRelease returns a context whose direct workflow commits without a release.
+func withSkipRelease(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipReleaseKey{}, true)
+}
+
+// skipReleaseFrom reports whether withSkipRelease was bound.
+func skipReleaseFrom(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipReleaseKey{}).(bool)
+	return skip
+}
+
+// skipsRelease reports whether the prompt opts out of a release, either via the
+// skip_changelog frontmatter field or a [skip changelog] marker in its title.
+func skipsRelease(pf *prompt.PromptFile, title string) bool {
+	return pf.Frontmatter.SkipChangelog ||
+		strings.Contains(strings.ToLower(title), skipChangelogMarker)
+}
+
+type releaseRecorderKey struct{}
+
+// withReleaseRecorder returns a context in which handleDirectWorkflow records the
+// version it tags, and a func returning that version ("" while nothing was released).
+func withReleaseRecorder(ctx context.Context) (context.Context, func() string) {
+	var version string
+	return context.WithValue(ctx, releaseRecorderKey{}, &version), func() string { return version }
+}
+
+// recordRelease stores version in the recorder bound by withReleaseRecorder, if any.
+func recordRelease(ctx context.Context, version string) {
+	if recorded, ok := ctx.Value(releaseRecorderKey{}).(*string); ok {
+		*recorded = version
+	}
+}
+
+type commitRecorderKey struct{}
+
+// withCommitRecorder returns a context in which the workflows record the commit
+// holding the prompt's changes, and a func returning its hash ("" while nothing
+// was committed).
+func withCommitRecorder(ctx context.Context) (context.Context, func() string) {
+	var commit string
+	return context.WithValue(ctx, commitRecorderKey{}, &commit), func() string { return commit }
+}
+
+// headCommit returns the current HEAD hash, or "" when it cannot be resolved.
+func headCommit(ctx context.Context, releaser git.Releaser) string {
+	commit, err := releaser.HeadCommit(ctx)
+	if err != nil {
+		log.From(ctx).Debug("resolve HEAD commit failed", "error", err)
+		return ""
+	}
+	return commit
+}
+
+// recordCommit stores the HEAD hash in the recorder bound by withCommitRecorder
+// when HEAD moved away from before, i.e. a commit was actually created.
+func recordCommit(
+	gitCtx context.Context,
+	ctx context.Context,
+	releaser git.Releaser,
+	before string,
+) {
+	recorded, ok := ctx.Value(commitRecorderKey{}).(*string)
+	if !ok {
+		return
+	}
+	if after := headCommit(gitCtx, releaser); after != "" && after != before {
+		*recorded = after
+	}
+}
+
+// bumpOverrideFrom returns the bump bound by withBumpOverride, if any.
+func bumpOverrideFrom(ctx context.Context) (git.VersionBump, bool) {
+	bump, ok := ctx.Value(bumpOverrideKey{}).(git.VersionBump)
+	return bump, ok
+}
+
 // handleDirectWorkflow handles the direct commit workflow: commit, tag, push.
+// The created commit is recorded for withCommitRecorder.
 func handleDirectWorkflow(
 	gitCtx context.Context,
 	ctx context.Context,
 	deps WorkflowDeps,
-	title string,
+	message string,
+	featureBranch string,
+) error {
+	before := headCommit(gitCtx, deps.Releaser)
+	if err := commitDirectWorkflow(gitCtx, ctx, deps, message, featureBranch); err != nil {
+		return err
+	}
+	recordCommit(gitCtx, ctx, deps.Releaser, before)
+	return nil
+}
+
+// commitDirectWorkflow commits the prompt's changes, on featureBranch without a
+// release, otherwise with a release when the project has a changelog.
+func commitDirectWorkflow(
+	gitCtx context.Context,
+	ctx context.Context,
+	deps WorkflowDeps,
+	message string,
 	featureBranch string,
 ) error {
 	if featureBranch != "" {
-		if err := deps.Releaser.CommitOnly(gitCtx, title); err != nil {
+		if err := deps.Releaser.CommitOnly(gitCtx, message); err != nil {
 			return errors.Wrap(ctx, err, "commit on feature branch")
 		}
 		log.From(ctx).Info("committed changes on feature branch (no release)",
+			log.Event(log.EventCommitted),
 			"branch", featureBranch,
 			"workflow_step", "commit",
 		)
 		return nil
 	}
 	if !deps.Releaser.HasChangelog(gitCtx) {
-		if err := deps.Releaser.CommitOnly(gitCtx, title); err != nil {
+		if err := deps.Releaser.CommitOnly(gitCtx, message); err != nil {
 			return errors.Wrap(ctx, err, "commit")
 		}
-		log.From(ctx).Info("committed changes", "workflow_step", "commit")
+		log.From(ctx).
+			Info("committed changes", log.Event(log.EventCommitted), "workflow_step", "commit")
+		return nil
+	}
+	if skipReleaseFrom(gitCtx) {
+		if err := deps.Releaser.CommitOnly(gitCtx, message); err != nil {
+			return errors.Wrap(ctx, err, "commit without release")
+		}
+		log.From(ctx).Info(
+			"committed changes (skip_changelog, skipping release)",
+			log.Event(log.EventCommitted),
+			"workflow_step", "commit",
+		)
 		return nil
 	}
 	if !deps.AutoRelease {
-		if err := deps.Releaser.CommitOnly(gitCtx, title); err != nil {
+		if err := deps.Releaser.CommitOnly(gitCtx, message); err != nil {
 			return errors.Wrap(ctx, err, "commit without release")
 		}
-		log.From(ctx).
-			Info("committed changes (autoRelease disabled, skipping tag)", "workflow_step", "commit")
+		log.From(ctx).Info(
+			"committed changes (autoRelease disabled, skipping tag)",
+			log.Event(log.EventCommitted),
+			"workflow_step", "commit",
+		)
 		return nil
 	}
-	bump := deps.Releaser.DetermineBump(ctx)
+	bump, ok := bumpOverrideFrom(ctx)
+	if !ok {
+		bump = deps.Releaser.DetermineBump(ctx)
+	}
 	nextVersion, err := deps.Releaser.GetNextVersion(gitCtx, bump)
 	if err != nil {
 		return errors.Wrap(ctx, err, "get next version")
@@ -137,7 +423,13 @@ func handleDirectWorkflow(
 	if err := deps.Releaser.CommitAndRelease(gitCtx, bump); err != nil {
 		return errors.Wrap(ctx, err, "commit and release")
 	}
-	log.From(ctx).Info("committed and tagged", "version", nextVersion, "workflow_step", "commit")
+	recordRelease(ctx, nextVersion)
+	log.From(ctx).Info(
+		"committed and tagged",
+		log.Event(log.EventTagged),
+		"version", nextVersion,
+		"workflow_step", "commit",
+	)
 	return nil
 }
 
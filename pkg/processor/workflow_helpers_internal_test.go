@@ -13,6 +13,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/git"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
@@ -107,3 +109,256 @@ var _ = Describe("syncPromptFileToOriginalRepo", func() {
 		Expect(err.Error()).To(ContainSubstring(completedPath))
 	})
 })
+
+var _ = Describe("buildCommitMessage", func() {
+	var pf *prompt.PromptFile
+
+	BeforeEach(func() {
+		pf = prompt.NewPromptFile(
+			"/tmp/001-test.md",
+			prompt.Frontmatter{Status: "committing", Summary: "Added the widget"},
+			[]byte("# Add widget\n\nImplement the widget.\n\n- step one\n"),
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("returns the title only for none", func() {
+		Expect(buildCommitMessage(config.CommitBodyNone, "Add widget", pf)).To(Equal("Add widget"))
+	})
+
+	It("returns the title only when unset", func() {
+		Expect(buildCommitMessage("", "Add widget", pf)).To(Equal("Add widget"))
+	})
+
+	It("appends the summary for summary", func() {
+		Expect(buildCommitMessage(config.CommitBodySummary, "Add widget", pf)).To(
+			Equal("Add widget\n\nAdded the widget"),
+		)
+	})
+
+	It("returns the title only for summary without a summary", func() {
+		pf.Frontmatter.Summary = ""
+		Expect(buildCommitMessage(config.CommitBodySummary, "Add widget", pf)).To(Equal("Add widget"))
+	})
+
+	It("appends the prompt body without the title heading for full", func() {
+		Expect(buildCommitMessage(config.CommitBodyFull, "Add widget", pf)).To(
+			Equal("Add widget\n\nImplement the widget.\n\n- step one"),
+		)
+	})
+
+	It("keeps the whole body for full when it does not start with the title", func() {
+		pf.Body = []byte("Implement the widget.\n")
+		Expect(buildCommitMessage(config.CommitBodyFull, "001-test", pf)).To(
+			Equal("001-test\n\nImplement the widget."),
+		)
+	})
+})
+
+var _ = Describe("renderPRBody", func() {
+	var (
+		ctx context.Context
+		pf  *prompt.PromptFile
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		pf = prompt.NewPromptFile(
+			"/tmp/001-test.md",
+			prompt.Frontmatter{Status: "committing", DarkFactoryVersion: "v0.193.0"},
+			[]byte("# Add widget\n\nImplement the widget.\n"),
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("falls back to the default body without any template", func() {
+		body, err := renderPRBody(ctx, "", "Add widget", pf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(Equal("Automated by dark-factory"))
+	})
+
+	It("renders the configured template", func() {
+		body, err := renderPRBody(
+			ctx,
+			"{{.Title}}\n\n{{.Prompt}}\n\ndark-factory {{.Version}}",
+			"Add widget",
+			pf,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(Equal("Add widget\n\nImplement the widget.\n\ndark-factory v0.193.0"))
+	})
+
+	It("prefers the pr_body of the prompt over the configured template", func() {
+		pf.Frontmatter.PRBody = "Closes {{.Title}}"
+		body, err := renderPRBody(ctx, "{{.Prompt}}", "Add widget", pf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(Equal("Closes Add widget"))
+	})
+
+	It("returns an error for an unknown placeholder", func() {
+		_, err := renderPRBody(ctx, "{{.Unknown}}", "Add widget", pf)
+		Expect(err).To(MatchError(ContainSubstring("render PR body template")))
+	})
+
+	It("returns an error for an unparsable pr_body", func() {
+		pf.Frontmatter.PRBody = "{{.Title"
+		_, err := renderPRBody(ctx, "", "Add widget", pf)
+		Expect(err).To(MatchError(ContainSubstring("parse PR body template")))
+	})
+})
+
+var _ = Describe("featureBranchName", func() {
+	var (
+		ctx context.Context
+		pf  *prompt.PromptFile
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		pf = prompt.NewPromptFile(
+			"/tmp/042-add-widget.md",
+			prompt.Frontmatter{Status: "executing"},
+			[]byte("# Add the Widget: v2!\n\nImplement the widget.\n"),
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("keeps the default dark-factory/<baseName> without a template", func() {
+		branch, err := featureBranchName(ctx, "", "042-add-widget", pf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(branch).To(Equal("dark-factory/042-add-widget"))
+	})
+
+	It("prefers the branch of the prompt over the template", func() {
+		pf.Frontmatter.Branch = "feature/widget"
+		branch, err := featureBranchName(ctx, "df/{{.Slug}}", "042-add-widget", pf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(branch).To(Equal("feature/widget"))
+	})
+
+	DescribeTable("renders the template",
+		func(branchTemplate string, expected string) {
+			branch, err := featureBranchName(ctx, branchTemplate, "042-add-widget", pf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branch).To(Equal(expected))
+		},
+		Entry("number and slug", "feat/{{.Number}}/{{.Slug}}", "feat/042/add-widget"),
+		Entry("title with spaces and punctuation", "df/{{.Title}}", "df/Add-the-Widget-v2"),
+		Entry("empty segments", "df//{{.Slug}}/", "df/add-widget"),
+		Entry("double dots", "df/{{.Number}}..{{.Slug}}", "df/042.add-widget"),
+		Entry("lock suffix", "df/{{.Slug}}.lock", "df/add-widget"),
+		Entry("leading dash and dot", "-.df/.{{.Slug}}", "df/add-widget"),
+	)
+
+	It("returns an error when the template renders an empty name", func() {
+		_, err := featureBranchName(ctx, "{{.Title}}", "042-add-widget", prompt.NewPromptFile(
+			"/tmp/042-add-widget.md",
+			prompt.Frontmatter{Status: "executing"},
+			[]byte("no title\n"),
+			libtime.NewCurrentDateTime(),
+		))
+		Expect(err).To(MatchError(ContainSubstring("invalid branch name")))
+	})
+
+	It("returns an error for an unknown placeholder", func() {
+		_, err := featureBranchName(ctx, "{{.Unknown}}", "042-add-widget", pf)
+		Expect(err).To(MatchError(ContainSubstring("render branch template")))
+	})
+})
+
+var _ = Describe("directWorkflowExecutor commitBody", func() {
+	It("commits with the prompt content as body when full", func() {
+		ctx := context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir := filepath.Join(tempDir, "in-progress")
+		completedDirPath := filepath.Join(tempDir, "completed")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(completedDirPath, 0750)).To(Succeed())
+
+		body := "# Add widget\n\nImplement the widget.\n"
+		promptPath := filepath.Join(queueDir, "001-widget.md")
+		Expect(
+			os.WriteFile(promptPath, []byte("---\nstatus: committing\n---\n"+body), 0600),
+		).To(Succeed())
+		completedPath := filepath.Join(completedDirPath, "001-widget.md")
+
+		promptMgr := prompt.NewManager(
+			filepath.Join(tempDir, "inbox"),
+			queueDir,
+			completedDirPath,
+			"",
+			&osFileMover{},
+			libtime.NewCurrentDateTime(),
+		)
+		rel := &stubWorkflowReleaser{}
+		executor := NewDirectWorkflowExecutor(WorkflowDeps{
+			PromptManager: promptMgr,
+			AutoCompleter: &stubAutoCompleter{},
+			Releaser:      rel,
+			CommitBody:    config.CommitBodyFull,
+		})
+
+		pf := prompt.NewPromptFile(
+			promptPath,
+			prompt.Frontmatter{Status: "committing"},
+			[]byte(body),
+			libtime.NewCurrentDateTime(),
+		)
+
+		err := executor.Complete(ctx, ctx, pf, "Add widget", promptPath, completedPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rel.commitOnlyMessages).To(HaveLen(1))
+		Expect(rel.commitOnlyMessages[0]).To(Equal("Add widget\n\nImplement the widget."))
+	})
+})
+
+var _ = Describe("handleDirectWorkflow bump override", func() {
+	It("releases with the changelog bump by default", func() {
+		ctx := context.Background()
+		rel := &stubWorkflowReleaser{hasChangelog: true}
+		deps := WorkflowDeps{Releaser: rel, AutoRelease: true}
+
+		Expect(handleDirectWorkflow(ctx, ctx, deps, "Add widget", "")).To(Succeed())
+		Expect(rel.releasedBumps).To(Equal([]git.VersionBump{git.PatchBump}))
+	})
+
+	It("releases with the bump bound to the context", func() {
+		ctx := withBumpOverride(context.Background(), git.MinorBump)
+		rel := &stubWorkflowReleaser{hasChangelog: true}
+		deps := WorkflowDeps{Releaser: rel, AutoRelease: true}
+
+		Expect(handleDirectWorkflow(ctx, ctx, deps, "Add widget", "")).To(Succeed())
+		Expect(rel.releasedBumps).To(Equal([]git.VersionBump{git.MinorBump}))
+	})
+})
+
+var _ = Describe("handleDirectWorkflow release recorder", func() {
+	It("records the released version", func() {
+		ctx, released := withReleaseRecorder(context.Background())
+		deps := WorkflowDeps{Releaser: &stubWorkflowReleaser{hasChangelog: true}, AutoRelease: true}
+
+		Expect(handleDirectWorkflow(ctx, ctx, deps, "Add widget", "")).To(Succeed())
+		Expect(released()).To(Equal("v1.0.0"))
+	})
+
+	It("records nothing without a release", func() {
+		ctx, released := withReleaseRecorder(context.Background())
+		deps := WorkflowDeps{Releaser: &stubWorkflowReleaser{}, AutoRelease: true}
+
+		Expect(handleDirectWorkflow(ctx, ctx, deps, "Add widget", "")).To(Succeed())
+		Expect(released()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("handleDirectWorkflow skip release", func() {
+	It("commits without a release when the context skips it", func() {
+		ctx := withSkipRelease(context.Background())
+		rel := &stubWorkflowReleaser{hasChangelog: true}
+		deps := WorkflowDeps{Releaser: rel, AutoRelease: true}
+
+		Expect(handleDirectWorkflow(ctx, ctx, deps, "Fix typo", "")).To(Succeed())
+		Expect(rel.commitOnlyMessages).To(Equal([]string{"Fix typo"}))
+		Expect(rel.commitAndReleaseCount).To(Equal(0))
+		Expect(rel.releasedBumps).To(BeEmpty())
+	})
+})
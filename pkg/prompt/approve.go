@@ -13,9 +13,9 @@ import (
 )
 
 // ApproveManager is the minimum subset of Manager required by
-// ApproveFromInbox — just Load. Each consumer package's own PromptManager
-// interface (pkg/cmd, pkg/generator, ...) already includes Load, so the
-// existing managers satisfy this implicitly with no new method needed.
+// ApproveFromInbox. Each consumer package's own PromptManager interface
+// (pkg/cmd, pkg/generator, ...) includes Load, so the existing managers
+// satisfy this implicitly.
 type ApproveManager interface {
 	Load(ctx context.Context, path string) (*PromptFile, error)
 }
@@ -25,6 +25,11 @@ type ApproveManager interface {
 // saves it. Returns the new on-disk path so callers can log it or chain
 // a post-approve step (typically NormalizeFilenames).
 //
+// When the inbox prompt carries an idempotency_key that an already queued or
+// completed prompt also carries, nothing is moved and an error naming the
+// existing prompt is returned, so the duplicate does not sit in the inbox
+// unnoticed.
+//
 // Used by:
 //   - pkg/cmd.approveCommand — CLI `dark-factory approve` after fuzzy match
 //   - pkg/generator.dockerSpecGenerator — spec-generator auto-approve path
@@ -46,7 +51,21 @@ func ApproveFromInbox(
 	inboxPath string,
 	queueDir string,
 	pm ApproveManager,
+	keys IdempotencyKeyFinder,
 ) (string, error) {
+	existing, err := FindDuplicateByIdempotencyKey(ctx, inboxPath, pm, keys)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "check idempotency key")
+	}
+	if existing != "" {
+		return "", errors.Errorf(
+			ctx,
+			"%s has the idempotency key of already enqueued prompt %s",
+			filepath.Base(inboxPath),
+			filepath.Base(existing),
+		)
+	}
+
 	filename := StripNumberPrefix(filepath.Base(inboxPath))
 	newPath := filepath.Join(queueDir, filename)
 
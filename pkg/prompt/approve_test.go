@@ -50,7 +50,7 @@ var _ = Describe("ApproveFromInbox", func() {
 	It("moves prompt from inbox to queue, strips numeric prefix, and marks approved", func() {
 		inboxPath := createPromptFile(inboxDir, "017-do-thing.md", "draft")
 
-		newPath, err := prompt.ApproveFromInbox(ctx, inboxPath, queueDir, mgr)
+		newPath, err := prompt.ApproveFromInbox(ctx, inboxPath, queueDir, mgr, mgr)
 		Expect(err).NotTo(HaveOccurred())
 
 		// File moved: gone from inbox, present in queue WITHOUT the numeric prefix
@@ -67,12 +67,49 @@ var _ = Describe("ApproveFromInbox", func() {
 		Expect(pf.Frontmatter.Status).To(Equal("approved"))
 	})
 
+	It("refuses a prompt whose idempotency key is already queued", func() {
+		content := "---\nstatus: approved\nidempotency_key: job-42\n---\n\n# Original\n"
+		existingPath := filepath.Join(queueDir, "001-original.md")
+		Expect(os.WriteFile(existingPath, []byte(content), 0600)).To(Succeed())
+
+		retry := "---\nstatus: draft\nidempotency_key: job-42\n---\n\n# Retry\n"
+		inboxPath := filepath.Join(inboxDir, "retry.md")
+		Expect(os.WriteFile(inboxPath, []byte(retry), 0600)).To(Succeed())
+
+		_, err := prompt.ApproveFromInbox(ctx, inboxPath, queueDir, mgr, mgr)
+		Expect(err).To(MatchError(ContainSubstring(
+			"retry.md has the idempotency key of already enqueued prompt 001-original.md",
+		)))
+
+		// Nothing was moved: the queue holds only the original.
+		_, statErr := os.Stat(filepath.Join(queueDir, "retry.md"))
+		Expect(os.IsNotExist(statErr)).To(BeTrue())
+		entries, err := os.ReadDir(queueDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("approves normally when the idempotency key is new", func() {
+		content := "---\nstatus: draft\nidempotency_key: job-43\n---\n\n# New\n"
+		inboxPath := filepath.Join(inboxDir, "new.md")
+		Expect(os.WriteFile(inboxPath, []byte(content), 0600)).To(Succeed())
+
+		newPath, err := prompt.ApproveFromInbox(ctx, inboxPath, queueDir, mgr, mgr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newPath).To(Equal(filepath.Join(queueDir, "new.md")))
+
+		pf, err := mgr.Load(ctx, newPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Frontmatter.IdempotencyKey).To(Equal("job-43"))
+	})
+
 	It("returns wrapped error when the source file doesn't exist", func() {
 		_, err := prompt.ApproveFromInbox(
 			ctx,
 			filepath.Join(inboxDir, "does-not-exist.md"),
 			queueDir,
 			mgr,
+			mgr,
 		)
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("move file to queue"))
@@ -86,7 +123,7 @@ var _ = Describe("ApproveFromInbox", func() {
 		inboxPath := createPromptFile(inboxDir, "005-new.md", "draft")
 		_ = createPromptFile(queueDir, "002-existing.md", "approved")
 
-		newPath, err := prompt.ApproveFromInbox(ctx, inboxPath, queueDir, mgr)
+		newPath, err := prompt.ApproveFromInbox(ctx, inboxPath, queueDir, mgr, mgr)
 		Expect(err).NotTo(HaveOccurred())
 
 		// The new file landed at "new.md" (prefix stripped) — NOT
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+var nonSlugCharsRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// BatchEntry is one prompt of a batch enqueue.
+type BatchEntry struct {
+	Title    string   `json:"title"`
+	Body     string   `json:"body"`
+	Tags     []string `json:"tags,omitempty"`
+	Priority int      `json:"priority,omitempty"`
+}
+
+// EnqueueBatch writes one approved NNN-slug.md file per entry into the queue directory
+// and returns the created paths in entry order.
+// Numbers are allocated sequentially from those unused in the queue and completed directories.
+// All entries are validated before anything is written; if a write fails, files already
+// written by this call are removed, so the batch is all-or-nothing.
+func (pm *Manager) EnqueueBatch(ctx context.Context, entries []BatchEntry) ([]string, error) {
+	if len(entries) == 0 {
+		return nil, errors.Errorf(ctx, "batch contains no prompts")
+	}
+	slugs := make([]string, len(entries))
+	for i, entry := range entries {
+		slug, err := validateBatchEntry(ctx, entry)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "entry %d", i+1)
+		}
+		slugs[i] = slug
+	}
+
+	usedNumbers, err := pm.usedPromptNumbers(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "collect used prompt numbers")
+	}
+	if err := os.MkdirAll(pm.inProgressDir, 0750); err != nil {
+		return nil, errors.Wrap(ctx, err, "create queue directory")
+	}
+
+	paths := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		number := findNextAvailableNumber(usedNumbers)
+		usedNumbers[number] = true
+		path := filepath.Join(pm.inProgressDir, fmt.Sprintf("%03d-%s.md", number, slugs[i]))
+		if err := pm.writeBatchEntry(ctx, path, entry); err != nil {
+			removeBatchFiles(paths)
+			return nil, errors.Wrapf(ctx, err, "write entry %d", i+1)
+		}
+		paths = append(paths, path)
+	}
+	slog.Info("enqueued prompt batch", "count", len(paths), "dir", pm.inProgressDir)
+	return paths, nil
+}
+
+// Enqueue writes one approved NNN-slug.md file titled title into the queue directory
+// and returns its path. The number is the first one unused in the queue and completed
+// directories. body may be empty, leaving only the "# Title" heading.
+func (pm *Manager) Enqueue(ctx context.Context, title string, body string) (string, error) {
+	slug, err := validateTitle(ctx, title)
+	if err != nil {
+		return "", err
+	}
+	usedNumbers, err := pm.usedPromptNumbers(ctx)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "collect used prompt numbers")
+	}
+	if err := os.MkdirAll(pm.inProgressDir, 0750); err != nil {
+		return "", errors.Wrap(ctx, err, "create queue directory")
+	}
+	number := findNextAvailableNumber(usedNumbers)
+	path := filepath.Join(pm.inProgressDir, fmt.Sprintf("%03d-%s.md", number, slug))
+	if err := pm.writeBatchEntry(ctx, path, BatchEntry{Title: title, Body: body}); err != nil {
+		return "", errors.Wrap(ctx, err, "write prompt")
+	}
+	slog.Info("enqueued prompt", "path", path)
+	return path, nil
+}
+
+// validateBatchEntry checks that entry can be written and returns its filename slug.
+func validateBatchEntry(ctx context.Context, entry BatchEntry) (string, error) {
+	if strings.TrimSpace(entry.Body) == "" {
+		return "", errors.Errorf(ctx, "body is required")
+	}
+	return validateTitle(ctx, entry.Title)
+}
+
+// validateTitle checks that title is usable as prompt heading and returns its filename slug.
+func validateTitle(ctx context.Context, title string) (string, error) {
+	if strings.TrimSpace(title) == "" {
+		return "", errors.Errorf(ctx, "title is required")
+	}
+	slug := slugify(title)
+	if slug == "" {
+		return "", errors.Errorf(ctx, "title %q yields an empty filename", title)
+	}
+	return slug, nil
+}
+
+// usedPromptNumbers returns the NNN prefixes already taken in the queue and completed directories.
+func (pm *Manager) usedPromptNumbers(ctx context.Context) (map[int]bool, error) {
+	used := make(map[int]bool)
+	for _, dir := range []string{pm.inProgressDir, pm.completedDir} {
+		if dir == "" {
+			continue
+		}
+		entries, err := readNumberedDir(dir, dir == pm.inProgressDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(ctx, err, "read directory %s", dir)
+		}
+		_, numbers := scanPromptFiles(entries)
+		for n := range numbers {
+			used[n] = true
+		}
+	}
+	for n := range prunedNumbers(pm.completedDir) {
+		used[n] = true
+	}
+	return used, nil
+}
+
+// readNumberedDir lists dir with its queue subdirectories when queue is set and
+// with its YYYY-MM subdirectories otherwise.
+func readNumberedDir(dir string, queue bool) ([]promptDirEntry, error) {
+	if queue {
+		return readQueueDir(dir)
+	}
+	entries, err := readCompletedDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return inDir(dir, entries), nil
+}
+
+// writeBatchEntry writes entry as an approved prompt file at path.
+func (pm *Manager) writeBatchEntry(ctx context.Context, path string, entry BatchEntry) error {
+	if _, err := os.Stat(path); err == nil {
+		return errors.Errorf(ctx, "file already exists: %s", path)
+	}
+	body := "\n# " + strings.TrimSpace(entry.Title) + "\n"
+	if text := strings.TrimSpace(entry.Body); text != "" {
+		body += "\n" + text + "\n"
+	}
+	pf := NewPromptFile(
+		path,
+		Frontmatter{Tags: entry.Tags, Priority: entry.Priority},
+		[]byte(body),
+		pm.currentDateTimeGetter,
+	)
+	pf.MarkApproved()
+	return pf.Save(ctx)
+}
+
+// removeBatchFiles deletes files written earlier in a failed batch.
+func removeBatchFiles(paths []string) {
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			slog.Warn("failed to roll back batch file", "path", path, "error", err)
+		}
+	}
+}
+
+// slugify lowercases title and joins its alphanumeric runs with dashes.
+func slugify(title string) string {
+	return strings.Trim(nonSlugCharsRegexp.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("EnqueueBatch", func() {
+	var (
+		ctx          context.Context
+		queueDir     string
+		completedDir string
+		mgr          *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "queue")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.Mkdir(queueDir, 0o755)).To(Succeed())
+		Expect(os.Mkdir(completedDir, 0o755)).To(Succeed())
+		mgr = prompt.NewManager(
+			"", queueDir, completedDir, "",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("writes every entry with sequential numbers after the used ones", func() {
+		createPromptFile(completedDir, "001-done.md", "completed")
+		createPromptFile(queueDir, "002-queued.md", "approved")
+
+		paths, err := mgr.EnqueueBatch(ctx, []prompt.BatchEntry{
+			{Title: "Fix Login Bug", Body: "Fix it.", Tags: []string{"bugfix"}, Priority: 5},
+			{Title: "Update docs!", Body: "Write docs."},
+			{Title: "Refactor parser", Body: "Split it.", Tags: []string{"refactor", "parser"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{
+			filepath.Join(queueDir, "003-fix-login-bug.md"),
+			filepath.Join(queueDir, "004-update-docs.md"),
+			filepath.Join(queueDir, "005-refactor-parser.md"),
+		}))
+
+		pf, err := mgr.Load(ctx, paths[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Frontmatter.Status).To(Equal(string(prompt.ApprovedPromptStatus)))
+		Expect(pf.Frontmatter.Tags).To(Equal([]string{"bugfix"}))
+		Expect(pf.Frontmatter.Priority).To(Equal(5))
+		Expect(pf.Frontmatter.Queued).NotTo(BeEmpty())
+		Expect(string(pf.Body)).To(ContainSubstring("# Fix Login Bug\n\nFix it.\n"))
+
+		pf, err = mgr.Load(ctx, paths[2])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Frontmatter.Tags).To(Equal([]string{"refactor", "parser"}))
+	})
+
+	It("fills gaps in the numbering", func() {
+		createPromptFile(queueDir, "002-queued.md", "approved")
+
+		paths, err := mgr.EnqueueBatch(ctx, []prompt.BatchEntry{
+			{Title: "First", Body: "a"},
+			{Title: "Second", Body: "b"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{
+			filepath.Join(queueDir, "001-first.md"),
+			filepath.Join(queueDir, "003-second.md"),
+		}))
+	})
+
+	It("writes nothing when any entry is invalid", func() {
+		_, err := mgr.EnqueueBatch(ctx, []prompt.BatchEntry{
+			{Title: "Valid", Body: "ok"},
+			{Title: "No body", Body: "  "},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("entry 2"))
+
+		entries, err := os.ReadDir(queueDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("rejects a title without usable characters", func() {
+		_, err := mgr.EnqueueBatch(ctx, []prompt.BatchEntry{{Title: "???", Body: "x"}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects an empty batch", func() {
+		_, err := mgr.EnqueueBatch(ctx, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Enqueue", func() {
+	var (
+		ctx          context.Context
+		queueDir     string
+		completedDir string
+		mgr          *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "queue")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.Mkdir(completedDir, 0o755)).To(Succeed())
+		mgr = prompt.NewManager(
+			"", queueDir, completedDir, "",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("writes an approved prompt with the next free number and the title heading", func() {
+		createPromptFile(completedDir, "001-done.md", "completed")
+
+		path, err := mgr.Enqueue(ctx, "Add Retry Logic!", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(filepath.Join(queueDir, "002-add-retry-logic.md")))
+
+		pf, err := mgr.Load(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Frontmatter.Status).To(Equal(string(prompt.ApprovedPromptStatus)))
+		Expect(pf.Title()).To(Equal("Add Retry Logic!"))
+		Expect(string(pf.Body)).To(Equal("\n# Add Retry Logic!\n"))
+	})
+
+	It("writes the body below the heading", func() {
+		path, err := mgr.Enqueue(ctx, "Fix parser", "Handle empty input.\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Base(path)).To(Equal("001-fix-parser.md"))
+
+		pf, err := mgr.Load(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(pf.Body)).To(ContainSubstring("# Fix parser\n\nHandle empty input.\n"))
+	})
+
+	It("rejects an empty title", func() {
+		_, err := mgr.Enqueue(ctx, "  ", "body")
+		Expect(err).To(MatchError(ContainSubstring("title is required")))
+	})
+})
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bborbe/collection"
+	"github.com/bborbe/errors"
+	"github.com/bborbe/validation"
+)
+
+const (
+	// CompletedLayoutFlat moves completed prompts directly into the completed directory.
+	CompletedLayoutFlat CompletedLayout = "flat"
+	// CompletedLayoutMonthly moves completed prompts into a YYYY-MM subdirectory of the
+	// completed directory, named after the month (UTC) the prompt was completed in.
+	CompletedLayoutMonthly CompletedLayout = "monthly"
+)
+
+// AvailableCompletedLayouts contains all valid completed layout values.
+var AvailableCompletedLayouts = CompletedLayouts{CompletedLayoutFlat, CompletedLayoutMonthly}
+
+// CompletedLayout selects where MoveToCompleted places prompt files.
+type CompletedLayout string
+
+// String returns the string representation of the CompletedLayout.
+func (l CompletedLayout) String() string {
+	return string(l)
+}
+
+// Validate checks that the CompletedLayout is a known value.
+func (l CompletedLayout) Validate(ctx context.Context) error {
+	// Empty string is valid — means the field was not set and the flat layout applies.
+	if l == "" {
+		return nil
+	}
+	if !AvailableCompletedLayouts.Contains(l) {
+		validValues := make([]string, len(AvailableCompletedLayouts))
+		for i, v := range AvailableCompletedLayouts {
+			validValues[i] = string(v)
+		}
+		return errors.Wrapf(
+			ctx,
+			validation.Error,
+			"unknown completed layout %q, valid values: %s",
+			l,
+			strings.Join(validValues, ", "),
+		)
+	}
+	return nil
+}
+
+// CompletedLayouts is a collection of CompletedLayout values.
+type CompletedLayouts []CompletedLayout
+
+// Contains reports whether layout is in the collection.
+func (l CompletedLayouts) Contains(layout CompletedLayout) bool {
+	return collection.Contains(l, layout)
+}
+
+// WithCompletedLayout sets where MoveToCompleted places prompt files.
+func WithCompletedLayout(layout CompletedLayout) ManagerOption {
+	return func(m *Manager) {
+		if layout != "" {
+			m.completedLayout = layout
+		}
+	}
+}
+
+// monthDirRegexp matches the YYYY-MM subdirectories of the monthly layout.
+var monthDirRegexp = regexp.MustCompile(`^\d{4}-\d{2}$`)
+
+// CompletedPath returns the path the prompt at promptPath gets in completedDir.
+// Under the monthly layout the month is taken from the prompt's completed timestamp,
+// or from the current time while the prompt is not completed yet.
+func (pf *PromptFile) CompletedPath(
+	completedDir string,
+	layout CompletedLayout,
+	promptPath string,
+) string {
+	if layout != CompletedLayoutMonthly {
+		return filepath.Join(completedDir, filepath.Base(promptPath))
+	}
+	completedAt := pf.now()
+	if t, err := time.Parse(time.RFC3339, pf.Frontmatter.Completed); err == nil {
+		completedAt = t
+	}
+	return filepath.Join(
+		completedDir,
+		completedAt.UTC().Format("2006-01"),
+		filepath.Base(promptPath),
+	)
+}
+
+// IsInCompletedDir reports whether path lies directly in completedDir or in one of
+// its YYYY-MM subdirectories.
+func IsInCompletedDir(completedDir string, path string) bool {
+	dir := filepath.Clean(filepath.Dir(path))
+	completedDir = filepath.Clean(completedDir)
+	if dir == completedDir {
+		return true
+	}
+	return filepath.Dir(dir) == completedDir && monthDirRegexp.MatchString(filepath.Base(dir))
+}
+
+// ListCompletedFiles returns the .md files in completedDir and its YYYY-MM
+// subdirectories. Both layouts are always read, so switching the layout keeps
+// earlier completed prompts visible. A missing completedDir yields no files.
+func ListCompletedFiles(ctx context.Context, completedDir string) ([]string, error) {
+	paths, err := listMarkdownFiles(ctx, completedDir)
+	if err != nil {
+		return nil, err
+	}
+	months, err := monthDirs(completedDir)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "read completed directory")
+	}
+	for _, month := range months {
+		monthPaths, err := listMarkdownFiles(ctx, month)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "read %s", month)
+		}
+		paths = append(paths, monthPaths...)
+	}
+	return paths, nil
+}
+
+// FindCompletedFile returns the path of the file named name in completedDir or one
+// of its YYYY-MM subdirectories, or "" when there is none.
+func FindCompletedFile(completedDir string, name string) string {
+	candidate := filepath.Join(completedDir, name)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate
+	}
+	months, err := monthDirs(completedDir)
+	if err != nil {
+		return ""
+	}
+	for i := len(months) - 1; i >= 0; i-- {
+		candidate := filepath.Join(months[i], name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// CompletedFilePath returns where the file named name lives after MoveToCompleted:
+// the FindCompletedFile match, or the flat completedDir/name when there is none.
+func CompletedFilePath(completedDir string, name string) string {
+	if path := FindCompletedFile(completedDir, name); path != "" {
+		return path
+	}
+	return filepath.Join(completedDir, name)
+}
+
+// readCompletedDir returns the entries of completedDir followed by the entries of
+// its YYYY-MM subdirectories. The month directories themselves are left out.
+// Errors reading completedDir are returned unchanged so callers keep their
+// os.IsNotExist handling.
+func readCompletedDir(completedDir string) ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(completedDir)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]os.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			result = append(result, entry)
+			continue
+		}
+		if !monthDirRegexp.MatchString(entry.Name()) {
+			continue
+		}
+		monthEntries, err := os.ReadDir(filepath.Join(completedDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, monthEntries...)
+	}
+	return result, nil
+}
+
+// monthDirs returns the YYYY-MM subdirectories of completedDir in ascending order.
+func monthDirs(completedDir string) ([]string, error) {
+	if completedDir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(completedDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() && monthDirRegexp.MatchString(entry.Name()) {
+			dirs = append(dirs, filepath.Join(completedDir, entry.Name()))
+		}
+	}
+	return dirs, nil
+}
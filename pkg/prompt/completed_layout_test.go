@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("CompletedLayout", func() {
+	var (
+		ctx          context.Context
+		tempDir      string
+		queueDir     string
+		completedDir string
+		now          time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "in-progress")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		now = time.Date(2026, 10, 1, 0, 30, 0, 0, time.UTC)
+	})
+
+	newManager := func(layout prompt.CompletedLayout) *prompt.Manager {
+		currentDateTime := libtime.NewCurrentDateTime()
+		currentDateTime.SetNow(libtime.DateTime(now))
+		return prompt.NewManager(
+			"", queueDir, completedDir, "",
+			&simpleMover{},
+			currentDateTime,
+			prompt.WithCompletedLayout(layout),
+		)
+	}
+
+	DescribeTable("Validate",
+		func(layout prompt.CompletedLayout, expectErr bool) {
+			err := layout.Validate(ctx)
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).To(BeNil())
+			}
+		},
+		Entry("empty", prompt.CompletedLayout(""), false),
+		Entry("flat", prompt.CompletedLayoutFlat, false),
+		Entry("monthly", prompt.CompletedLayoutMonthly, false),
+		Entry("unknown", prompt.CompletedLayout("yearly"), true),
+	)
+
+	Describe("MoveToCompleted", func() {
+		It("moves into the completed directory with the flat layout", func() {
+			path := createPromptFile(queueDir, "001-flat.md", "approved")
+
+			Expect(newManager(prompt.CompletedLayoutFlat).MoveToCompleted(ctx, path)).To(Succeed())
+
+			_, err := os.Stat(filepath.Join(completedDir, "001-flat.md"))
+			Expect(err).To(BeNil())
+		})
+
+		It("moves into a YYYY-MM subdirectory with the monthly layout", func() {
+			path := createPromptFile(queueDir, "001-monthly.md", "approved")
+
+			Expect(newManager(prompt.CompletedLayoutMonthly).MoveToCompleted(ctx, path)).To(Succeed())
+
+			content, err := os.ReadFile(filepath.Join(completedDir, "2026-10", "001-monthly.md"))
+			Expect(err).To(BeNil())
+			Expect(string(content)).To(ContainSubstring("status: completed"))
+			_, err = os.Stat(filepath.Join(completedDir, "001-monthly.md"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Describe("AllPreviousCompleted", func() {
+		It("sees predecessors completed in earlier months", func() {
+			september := filepath.Join(completedDir, "2026-09")
+			Expect(os.MkdirAll(september, 0750)).To(Succeed())
+			createPromptFile(september, "001-first.md", "completed")
+
+			manager := newManager(prompt.CompletedLayoutMonthly)
+			path := createPromptFile(queueDir, "002-second.md", "approved")
+			Expect(manager.MoveToCompleted(ctx, path)).To(Succeed())
+
+			Expect(manager.AllPreviousCompleted(ctx, 3)).To(BeTrue())
+		})
+
+		It("still reports a gap across month boundaries", func() {
+			september := filepath.Join(completedDir, "2026-09")
+			october := filepath.Join(completedDir, "2026-10")
+			Expect(os.MkdirAll(september, 0750)).To(Succeed())
+			Expect(os.MkdirAll(october, 0750)).To(Succeed())
+			createPromptFile(september, "001-first.md", "completed")
+			createPromptFile(october, "003-third.md", "completed")
+
+			Expect(newManager(prompt.CompletedLayoutMonthly).AllPreviousCompleted(ctx, 4)).
+				To(BeFalse())
+		})
+
+		It("reads flat files after switching to the monthly layout", func() {
+			Expect(os.MkdirAll(completedDir, 0750)).To(Succeed())
+			createPromptFile(completedDir, "001-first.md", "completed")
+			october := filepath.Join(completedDir, "2026-10")
+			Expect(os.MkdirAll(october, 0750)).To(Succeed())
+			createPromptFile(october, "002-second.md", "completed")
+
+			Expect(newManager(prompt.CompletedLayoutMonthly).AllPreviousCompleted(ctx, 3)).
+				To(BeTrue())
+		})
+	})
+
+	Describe("CompletedFilePath", func() {
+		It("finds a file in a month subdirectory", func() {
+			october := filepath.Join(completedDir, "2026-10")
+			Expect(os.MkdirAll(october, 0750)).To(Succeed())
+			createPromptFile(october, "001-first.md", "completed")
+
+			Expect(prompt.CompletedFilePath(completedDir, "001-first.md")).
+				To(Equal(filepath.Join(october, "001-first.md")))
+		})
+
+		It("falls back to the flat path", func() {
+			Expect(prompt.CompletedFilePath(completedDir, "001-missing.md")).
+				To(Equal(filepath.Join(completedDir, "001-missing.md")))
+		})
+	})
+})
@@ -4,10 +4,17 @@
 
 package prompt
 
-import "regexp"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+)
 
 var sanitizeContainerNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
 
+// MaxContainerNameLength bounds the names returned by SanitizeUnique.
+const MaxContainerNameLength = 128
+
 // BaseName is the prompt filename without the .md extension and with special characters replaced.
 type BaseName string
 
@@ -22,5 +29,23 @@ func (n ContainerName) Sanitize() ContainerName {
 	return ContainerName(sanitizeContainerNameRegexp.ReplaceAllString(string(n), "-"))
 }
 
+// SanitizeUnique is Sanitize without collisions: when sanitizing changes the name
+// or it exceeds MaxContainerNameLength, a short hash of the original name is appended
+// (truncating the sanitized name if needed), so "001-a@b" and "001-a-b" stay distinct.
+// Names that are already valid and short enough are returned unchanged.
+func (n ContainerName) SanitizeUnique() ContainerName {
+	sanitized := n.Sanitize()
+	if sanitized == n && len(n) <= MaxContainerNameLength {
+		return n
+	}
+	sum := sha256.Sum256([]byte(n))
+	suffix := "-" + hex.EncodeToString(sum[:4])
+	head := string(sanitized)
+	if len(head) > MaxContainerNameLength-len(suffix) {
+		head = head[:MaxContainerNameLength-len(suffix)]
+	}
+	return ContainerName(head + suffix)
+}
+
 // String returns the underlying string for use with exec / docker.
 func (n ContainerName) String() string { return string(n) }
@@ -6,6 +6,7 @@ package prompt_test
 
 import (
 	"regexp"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -55,6 +56,42 @@ var _ = Describe("ContainerName", func() {
 		})
 	})
 
+	Describe("SanitizeUnique", func() {
+		It("keeps names that need no sanitizing", func() {
+			name := prompt.ContainerName("proj-exec-001-fix-bug").SanitizeUnique()
+			Expect(name.String()).To(Equal("proj-exec-001-fix-bug"))
+		})
+
+		It("keeps inputs that collide after Sanitize distinct", func() {
+			a := prompt.ContainerName("proj-exec-001-a@b").SanitizeUnique()
+			b := prompt.ContainerName("proj-exec-001-a-b").SanitizeUnique()
+			c := prompt.ContainerName("proj-exec-001-a#b").SanitizeUnique()
+			Expect(prompt.ContainerName("proj-exec-001-a@b").Sanitize()).To(
+				Equal(prompt.ContainerName("proj-exec-001-a-b").Sanitize()),
+			)
+			Expect(a).NotTo(Equal(b))
+			Expect(a).NotTo(Equal(c))
+			Expect(b).NotTo(Equal(c))
+			Expect(a.String()).To(MatchRegexp(`^proj-exec-001-a-b-[0-9a-f]{8}$`))
+		})
+
+		It("is deterministic", func() {
+			Expect(prompt.ContainerName("proj-exec-002-x y").SanitizeUnique()).To(
+				Equal(prompt.ContainerName("proj-exec-002-x y").SanitizeUnique()),
+			)
+		})
+
+		It("bounds long names and keeps them distinct", func() {
+			long := strings.Repeat("a", 200)
+			a := prompt.ContainerName("proj-exec-001-" + long + "-one").SanitizeUnique()
+			b := prompt.ContainerName("proj-exec-001-" + long + "-two").SanitizeUnique()
+			Expect(a.String()).To(HaveLen(prompt.MaxContainerNameLength))
+			Expect(a.String()).To(HavePrefix("proj-exec-001-"))
+			Expect(a).NotTo(Equal(b))
+			Expect(dockerNameRegexp.MatchString(a.String())).To(BeTrue())
+		})
+	})
+
 	Describe("String", func() {
 		It("returns the underlying string", func() {
 			name := prompt.ContainerName("my-container")
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// missingDependencies returns the entries of deps that are not completed. An entry
+// is a prompt number ("2", "002") matching any completed prompt with that number,
+// or a filename with or without .md ("002-add-api") matching that completed file.
+// Prompts deleted by completed retention count as completed. An unreadable
+// completed directory leaves every entry missing.
+func missingDependencies(_ context.Context, completedDir string, deps []string) []string {
+	if len(deps) == 0 {
+		return nil
+	}
+	entries, err := readCompletedDir(completedDir)
+	if err != nil {
+		return append([]string(nil), deps...)
+	}
+	pruned := prunedNumbers(completedDir)
+	numbers := make(map[int]bool, len(pruned)+len(entries))
+	for num := range pruned {
+		numbers[num] = true
+	}
+	names := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		names[entry.Name()] = true
+		if num := extractNumberFromFilename(entry.Name()); num != -1 {
+			numbers[num] = true
+		}
+	}
+	var missing []string
+	for _, dep := range deps {
+		dep = strings.TrimSpace(dep)
+		if num, err := strconv.Atoi(dep); err == nil {
+			if !numbers[num] {
+				missing = append(missing, dep)
+			}
+			continue
+		}
+		name := strings.TrimSuffix(dep, ".md") + ".md"
+		if names[name] {
+			continue
+		}
+		if num := extractNumberFromFilename(name); num != -1 && pruned[num] {
+			continue
+		}
+		missing = append(missing, dep)
+	}
+	return missing
+}
+
+// dependencyNumber returns the prompt number a depends_on entry refers to, 0 when
+// the entry names no number.
+func dependencyNumber(dep string) int {
+	dep = strings.TrimSpace(dep)
+	if num, err := strconv.Atoi(dep); err == nil {
+		return num
+	}
+	if num := extractNumberFromFilename(dep); num != -1 {
+		return num
+	}
+	return 0
+}
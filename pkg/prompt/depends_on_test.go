@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("MissingDependencies", func() {
+	var (
+		ctx          context.Context
+		queueDir     string
+		completedDir string
+		mgr          *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "queue")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(completedDir, "2026-03"), 0750)).To(Succeed())
+		createPromptFile(completedDir, "002-add-api.md", "completed")
+		createPromptFile(filepath.Join(completedDir, "2026-03"), "003-add-ui.md", "completed")
+		mgr = prompt.NewManager("", queueDir, completedDir, "", nil, nil)
+	})
+
+	DescribeTable("reports only dependencies that are not completed",
+		func(deps []string, expected []string) {
+			Expect(mgr.MissingDependencies(ctx, deps)).To(Equal(expected))
+		},
+		Entry("number", []string{"2"}, nil),
+		Entry("zero-padded number", []string{"002"}, nil),
+		Entry("filename", []string{"002-add-api.md"}, nil),
+		Entry("filename without extension", []string{"002-add-api"}, nil),
+		Entry("monthly layout", []string{"003-add-ui.md"}, nil),
+		Entry("missing number", []string{"2", "4"}, []string{"4"}),
+		Entry("missing filename", []string{"004-docs.md"}, []string{"004-docs.md"}),
+	)
+
+	It("reports the prompt as blocked by its dependency, not by lower numbers", func() {
+		Expect(os.WriteFile(
+			filepath.Join(queueDir, "005-dependent.md"),
+			[]byte("---\nstatus: approved\ndepends_on: [\"004\"]\n---\n# Dependent\n"),
+			0600,
+		)).To(Succeed())
+
+		number, reason, missing, blocked := mgr.GetBlockedPrompt(ctx)
+		Expect(blocked).To(BeTrue())
+		Expect(number).To(Equal(5))
+		Expect(reason).To(Equal(prompt.ReasonDependencyNotCompleted))
+		Expect(missing).To(Equal(4))
+	})
+
+	It("does not report a prompt whose dependencies are completed", func() {
+		Expect(os.WriteFile(
+			filepath.Join(queueDir, "005-dependent.md"),
+			[]byte("---\nstatus: approved\ndepends_on: [\"002\"]\n---\n# Dependent\n"),
+			0600,
+		)).To(Succeed())
+
+		_, _, _, blocked := mgr.GetBlockedPrompt(ctx)
+		Expect(blocked).To(BeFalse())
+	})
+})
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = DescribeTable("Frontmatter.ValidateCommand",
+	func(command []string, expectErr bool) {
+		err := prompt.Frontmatter{Command: command}.ValidateCommand(context.Background())
+		if expectErr {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+	Entry("no override", nil, false),
+	Entry("argument list", []string{"make", "test", "--verbose"}, false),
+	Entry("single argument without whitespace", []string{"/entrypoint.sh"}, false),
+	Entry("argument with space in a longer list", []string{"sh", "-c", "make test"}, false),
+	Entry("empty argument", []string{"make", ""}, true),
+	Entry("newline in argument", []string{"make", "test\nrm -rf /"}, true),
+	Entry("single shell string", []string{"make test"}, true),
+)
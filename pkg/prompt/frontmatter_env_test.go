@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = DescribeTable("Frontmatter.ValidateEnv",
+	func(env map[string]string, expectErr bool) {
+		err := prompt.Frontmatter{Env: env}.ValidateEnv(context.Background())
+		if expectErr {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+	Entry("no env", nil, false),
+	Entry("feature flags", map[string]string{"FEATURE_X": "on", "API_KEY": "secret"}, false),
+	Entry("lower-case key", map[string]string{"feature": "on"}, true),
+	Entry("empty key", map[string]string{"": "on"}, true),
+	Entry("reserved prompt file", map[string]string{"YOLO_PROMPT_FILE": "/etc/passwd"}, true),
+	Entry("reserved model", map[string]string{"ANTHROPIC_MODEL": "other"}, true),
+	Entry("reserved output", map[string]string{"YOLO_OUTPUT": "print"}, true),
+	Entry("newline in value", map[string]string{"FEATURE_X": "on\noff"}, true),
+)
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Frontmatter.Extra", func() {
+	const extraContent = "---\n" +
+		"status: executing\n" +
+		"review:\n" +
+		"  approvers:\n" +
+		"    - alice\n" +
+		"    - bob\n" +
+		"  required: 2\n" +
+		"checklist:\n" +
+		"  - name: docs\n" +
+		"    done: true\n" +
+		"---\n\n# Extra keys\n\nBody.\n"
+
+	var (
+		ctx     context.Context
+		tempDir string
+		mgr     *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		mgr = prompt.NewManager("", tempDir, "", "", nil, libtime.NewCurrentDateTime())
+	})
+
+	writePrompt := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	It("loads a prompt with nested unknown keys", func() {
+		path := writePrompt("001-extra.md", extraContent)
+
+		pf, err := mgr.Load(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Frontmatter.Status).To(Equal("executing"))
+		Expect(pf.Frontmatter.Extra).To(HaveKey("review"))
+		Expect(pf.Frontmatter.Extra).To(HaveKey("checklist"))
+	})
+
+	It("does not skip the prompt in HasExecuting", func() {
+		writePrompt("001-extra.md", extraContent)
+
+		Expect(mgr.HasExecuting(ctx)).To(BeTrue())
+	})
+
+	It("keeps unknown keys when the prompt is saved", func() {
+		path := writePrompt("001-extra.md", extraContent)
+		pf, err := mgr.Load(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+
+		pf.Frontmatter.Status = string(prompt.ApprovedPromptStatus)
+		Expect(pf.Save(ctx)).To(Succeed())
+
+		reloaded, err := mgr.Load(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reloaded.Frontmatter.Status).To(Equal("approved"))
+		Expect(reloaded.Frontmatter.Extra).To(Equal(pf.Frontmatter.Extra))
+	})
+
+	Describe("setters", func() {
+		const customContent = "---\n" +
+			"status: approved\n" +
+			"author: jane\n" +
+			"notes:\n" +
+			"  - check the migration first\n" +
+			"---\n\n# Custom keys\n\nBody.\n"
+
+		expectCustomKeys := func(path string) {
+			content, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("author: jane\n"))
+			Expect(string(content)).To(ContainSubstring("    - check the migration first\n"))
+		}
+
+		It("SetStatus keeps custom keys", func() {
+			path := writePrompt("001-custom.md", customContent)
+
+			Expect(mgr.SetStatus(ctx, path, string(prompt.ExecutingPromptStatus))).To(Succeed())
+
+			fm, err := mgr.ReadFrontmatter(ctx, path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fm.Status).To(Equal("executing"))
+			expectCustomKeys(path)
+		})
+
+		It("SetContainer keeps custom keys", func() {
+			path := writePrompt("001-custom.md", customContent)
+
+			Expect(mgr.SetContainer(ctx, path, "exec-001-custom")).To(Succeed())
+
+			fm, err := mgr.ReadFrontmatter(ctx, path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fm.Container).To(Equal("exec-001-custom"))
+			expectCustomKeys(path)
+		})
+	})
+
+	It("leaves Extra empty when every key is known", func() {
+		path := writePrompt("001-plain.md", "---\nstatus: approved\ncontainer: old\n---\n# Plain\n")
+
+		pf, err := mgr.Load(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Frontmatter.Extra).To(BeNil())
+	})
+
+	It("still rejects malformed YAML", func() {
+		path := writePrompt("001-broken.md", "---\nstatus: [approved\nreview: {\n---\n# Broken\n")
+
+		problems, err := prompt.Lint(ctx, path, libtime.NewCurrentDateTime())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(ContainElement(ContainSubstring("unparseable frontmatter YAML")))
+	})
+})
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = DescribeTable("Frontmatter.ValidateImage",
+	func(image string, expectErr bool) {
+		err := prompt.Frontmatter{Image: image}.ValidateImage(context.Background())
+		if expectErr {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+	Entry("no override", "", false),
+	Entry("name only", "node", false),
+	Entry("name and tag", "node:22-alpine", false),
+	Entry("registry path and tag", "ghcr.io/org/agent-python:1.2", false),
+	Entry("registry with port", "localhost:5000/agent:latest", false),
+	Entry("digest", "node@sha256:"+strings.Repeat("a", 64), false),
+	Entry("whitespace", "node 22", true),
+	Entry("shell metacharacter", "node;rm", true),
+	Entry("leading dash", "--privileged", true),
+	Entry("uppercase repository path", "ghcr.io/Org/agent", true),
+	Entry("empty tag", "node:", true),
+)
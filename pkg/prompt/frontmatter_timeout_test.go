@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = DescribeTable("Frontmatter.ValidateTimeout",
+	func(timeout string, expectErr bool) {
+		err := prompt.Frontmatter{Timeout: timeout}.ValidateTimeout(context.Background())
+		if expectErr {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+	Entry("no override", "", false),
+	Entry("minutes", "30m", false),
+	Entry("hours and minutes", "1h30m", false),
+	Entry("missing unit", "30", true),
+	Entry("zero", "0s", true),
+	Entry("negative", "-5m", true),
+	Entry("garbage", "soon", true),
+)
+
+var _ = DescribeTable("Frontmatter.EffectiveTimeout",
+	func(timeout string, maxPromptDuration time.Duration, expected time.Duration) {
+		Expect(
+			prompt.Frontmatter{Timeout: timeout}.EffectiveTimeout(maxPromptDuration),
+		).To(Equal(expected))
+	},
+	Entry("no override keeps global", "", 90*time.Minute, 90*time.Minute),
+	Entry("no override and no global", "", time.Duration(0), time.Duration(0)),
+	Entry("override shorter than global", "30m", 90*time.Minute, 30*time.Minute),
+	Entry("override longer than global", "3h", 90*time.Minute, 3*time.Hour),
+	Entry("override without global", "30m", time.Duration(0), 30*time.Minute),
+	Entry("invalid override keeps global", "soon", 90*time.Minute, 90*time.Minute),
+)
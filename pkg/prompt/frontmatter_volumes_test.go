@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = DescribeTable("Frontmatter.ValidateVolumes",
+	func(volumes []string, expectErr bool) {
+		err := prompt.Frontmatter{Volumes: volumes}.ValidateVolumes(context.Background())
+		if expectErr {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+	Entry("no volumes", nil, false),
+	Entry("read-write mount", []string{"testdata:/data"}, false),
+	Entry("read-only mount", []string{"./fixtures/api:/fixtures:ro"}, false),
+	Entry("single part", []string{"testdata"}, true),
+	Entry("too many parts", []string{"a:/b:ro:extra"}, true),
+	Entry("empty host", []string{":/data"}, true),
+	Entry("empty container", []string{"testdata:"}, true),
+	Entry("unknown mode", []string{"testdata:/data:rw"}, true),
+	Entry("absolute host", []string{"/etc:/data"}, true),
+	Entry("home host", []string{"~/.ssh:/data:ro"}, true),
+	Entry("host escaping the project", []string{"../other:/data"}, true),
+	Entry("nested host escaping the project", []string{"docs/../../other:/data"}, true),
+	Entry("relative container", []string{"testdata:data"}, true),
+	Entry("container with ..", []string{"testdata:/data/../etc"}, true),
+)
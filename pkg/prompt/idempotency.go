@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o ../../mocks/idempotency-key-finder.go --fake-name IdempotencyKeyFinder . IdempotencyKeyFinder
+
+// IdempotencyKeyFinder looks up an already queued or completed prompt by its
+// idempotency key. Manager implements it.
+type IdempotencyKeyFinder interface {
+	FindByIdempotencyKey(ctx context.Context, key string) (string, error)
+}
+
+// FindByIdempotencyKey returns the path of a queued or completed prompt whose
+// frontmatter carries key. Returns "" when key is empty or no prompt matches.
+// Unreadable prompt files are skipped.
+func (pm *Manager) FindByIdempotencyKey(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+	queued, err := listMarkdownFiles(ctx, pm.inProgressDir)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "list queue")
+	}
+	completed, err := ListCompletedFiles(ctx, pm.completedDir)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "list completed")
+	}
+	for _, path := range append(queued, completed...) {
+		fm, err := pm.ReadFrontmatter(ctx, path)
+		if err != nil || fm == nil {
+			continue
+		}
+		if fm.IdempotencyKey == key {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// FindDuplicateByIdempotencyKey returns the path of an already queued or completed
+// prompt that carries the same idempotency key as the prompt at path.
+// A prompt that cannot be loaded is treated as having no key — the caller's
+// subsequent move reports the real error.
+func FindDuplicateByIdempotencyKey(
+	ctx context.Context,
+	path string,
+	pm ApproveManager,
+	keys IdempotencyKeyFinder,
+) (string, error) {
+	pf, err := pm.Load(ctx, path)
+	if err != nil || pf == nil || pf.Frontmatter.IdempotencyKey == "" {
+		return "", nil
+	}
+	existing, err := keys.FindByIdempotencyKey(ctx, pf.Frontmatter.IdempotencyKey)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "find prompt by idempotency key")
+	}
+	if existing != "" {
+		slog.Info(
+			"prompt with same idempotency key already enqueued, skipping",
+			"file", filepath.Base(path),
+			"existing", filepath.Base(existing),
+			"idempotency_key", pf.Frontmatter.IdempotencyKey,
+		)
+	}
+	return existing, nil
+}
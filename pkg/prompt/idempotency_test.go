@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("FindByIdempotencyKey", func() {
+	var (
+		ctx          context.Context
+		queueDir     string
+		completedDir string
+		mgr          *prompt.Manager
+	)
+
+	writeKeyed := func(dir, name, key string) string {
+		path := filepath.Join(dir, name)
+		content := "---\nstatus: approved\nidempotency_key: " + key + "\n---\n\n# Keyed\n"
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "queue")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.Mkdir(queueDir, 0o755)).To(Succeed())
+		Expect(os.Mkdir(completedDir, 0o755)).To(Succeed())
+		mgr = prompt.NewManager(
+			"", queueDir, completedDir, "",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	It("finds a queued prompt", func() {
+		path := writeKeyed(queueDir, "001-queued.md", "job-1")
+
+		found, err := mgr.FindByIdempotencyKey(ctx, "job-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(Equal(path))
+	})
+
+	It("finds a completed prompt", func() {
+		path := writeKeyed(completedDir, "001-done.md", "job-2")
+
+		found, err := mgr.FindByIdempotencyKey(ctx, "job-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(Equal(path))
+	})
+
+	It("finds a completed prompt in a month directory", func() {
+		monthDir := filepath.Join(completedDir, "2026-02")
+		Expect(os.Mkdir(monthDir, 0o755)).To(Succeed())
+		path := writeKeyed(monthDir, "001-archived.md", "job-4")
+
+		found, err := mgr.FindByIdempotencyKey(ctx, "job-4")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(Equal(path))
+	})
+
+	It("reads only the top level of the queue", func() {
+		monthDir := filepath.Join(queueDir, "2026-02")
+		Expect(os.Mkdir(monthDir, 0o755)).To(Succeed())
+		writeKeyed(monthDir, "001-nested.md", "job-5")
+
+		found, err := mgr.FindByIdempotencyKey(ctx, "job-5")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeEmpty())
+	})
+
+	It("returns empty when no prompt carries the key", func() {
+		writeKeyed(queueDir, "001-queued.md", "job-1")
+
+		found, err := mgr.FindByIdempotencyKey(ctx, "job-3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeEmpty())
+	})
+
+	It("ignores an empty key", func() {
+		createPromptFile(queueDir, "001-unkeyed.md", "approved")
+
+		found, err := mgr.FindByIdempotencyKey(ctx, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeEmpty())
+	})
+})
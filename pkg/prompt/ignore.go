@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the file in a prompt directory listing glob patterns of
+// files the queue scan skips, one pattern per line.
+const IgnoreFileName = ".darkfactoryignore"
+
+// ignorePatterns holds the patterns of a .darkfactoryignore file.
+type ignorePatterns []string
+
+// loadIgnorePatterns reads the .darkfactoryignore file in dir.
+// A missing file yields no patterns. Blank lines and lines starting with #
+// are skipped; invalid patterns are logged and dropped.
+func loadIgnorePatterns(dir string) ignorePatterns {
+	// #nosec G304 -- dir is the configured prompt directory
+	content, err := os.ReadFile(filepath.Join(dir, IgnoreFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("read ignore file failed", "dir", dir, "error", err)
+		}
+		return nil
+	}
+
+	var patterns ignorePatterns
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := filepath.Match(line, ""); err != nil {
+			slog.Warn("skipping invalid ignore pattern", "pattern", line, "error", err)
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+// Matches reports whether name, a base filename, matches any pattern.
+func (p ignorePatterns) Matches(name string) bool {
+	for _, pattern := range p {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
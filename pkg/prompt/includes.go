@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// ExpandedContent returns Content with the files listed in the includes
+// frontmatter appended, each after a "--- include: <path> ---" separator.
+// Paths are relative to inboxDir, where the prompt was written, and must stay
+// inside it — the prompt itself has moved to the queue directory by the time it
+// runs. An empty inboxDir falls back to the directory of the prompt file.
+// Returns ErrEmptyPrompt like Content when the body itself is empty.
+func (pf *PromptFile) ExpandedContent(ctx context.Context, inboxDir string) (string, error) {
+	content, err := pf.Content()
+	if err != nil {
+		return "", err
+	}
+	if len(pf.Frontmatter.Includes) == 0 {
+		return content, nil
+	}
+	dir := inboxDir
+	if dir == "" {
+		dir = filepath.Dir(pf.Path)
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(content, "\n"))
+	for _, include := range pf.Frontmatter.Includes {
+		path, err := resolveInclude(ctx, dir, include)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path checked by resolveInclude
+		if err != nil {
+			return "", errors.Wrapf(ctx, err, "read include %s", include)
+		}
+		b.WriteString("\n\n--- include: ")
+		b.WriteString(include)
+		b.WriteString(" ---\n\n")
+		b.WriteString(strings.TrimRight(string(data), "\n"))
+	}
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// resolveInclude joins include to dir and rejects absolute paths and paths
+// that leave dir, also through a symlink.
+func resolveInclude(ctx context.Context, dir string, include string) (string, error) {
+	cleaned := filepath.Clean(include)
+	if include == "" || filepath.IsAbs(cleaned) || !isInside(cleaned) {
+		return "", errors.Errorf(ctx, "include %q must stay inside the prompts directory", include)
+	}
+	path := filepath.Join(dir, cleaned)
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "read include %s", include)
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "resolve prompts directory %s", dir)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || !isInside(rel) {
+		return "", errors.Errorf(ctx, "include %q must stay inside the prompts directory", include)
+	}
+	return resolved, nil
+}
+
+// isInside reports whether the clean relative path rel stays below its base.
+func isInside(rel string) bool {
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
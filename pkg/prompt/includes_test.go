@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("PromptFile.ExpandedContent", func() {
+	var (
+		ctx      context.Context
+		tempDir  string
+		inboxDir string
+		queueDir string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		inboxDir = filepath.Join(tempDir, "inbox")
+		queueDir = filepath.Join(tempDir, "in-progress")
+		Expect(os.MkdirAll(filepath.Join(inboxDir, "shared"), 0750)).To(Succeed())
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+	})
+
+	writeFile := func(name, content string) {
+		Expect(os.WriteFile(filepath.Join(inboxDir, name), []byte(content), 0600)).To(Succeed())
+	}
+
+	newPromptFile := func(body string, includes ...string) *prompt.PromptFile {
+		return prompt.NewPromptFile(
+			filepath.Join(queueDir, "001-feature.md"),
+			prompt.Frontmatter{Status: string(prompt.ApprovedPromptStatus), Includes: includes},
+			[]byte(body),
+			libtime.NewCurrentDateTime(),
+		)
+	}
+
+	It("returns the body unchanged without includes", func() {
+		content, err := newPromptFile("# Feature\n\nDo it.\n").ExpandedContent(ctx, inboxDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal("# Feature\n\nDo it.\n"))
+	})
+
+	It("appends one include after a separator", func() {
+		writeFile("schema.sql", "CREATE TABLE users (id INT);\n")
+
+		content, err := newPromptFile("# Feature\n\nDo it.\n", "schema.sql").ExpandedContent(ctx, inboxDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(
+			"# Feature\n\nDo it.\n\n--- include: schema.sql ---\n\nCREATE TABLE users (id INT);\n",
+		))
+	})
+
+	It("appends multiple includes in order", func() {
+		writeFile("schema.sql", "CREATE TABLE users (id INT);\n")
+		writeFile(filepath.Join("shared", "style.md"), "Use tabs.\n")
+
+		pf := newPromptFile("# Feature\n", "shared/style.md", "schema.sql")
+		content, err := pf.ExpandedContent(ctx, inboxDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal("# Feature\n" +
+			"\n--- include: shared/style.md ---\n\nUse tabs.\n" +
+			"\n--- include: schema.sql ---\n\nCREATE TABLE users (id INT);\n"))
+	})
+
+	DescribeTable("rejects includes outside the prompts directory",
+		func(include string) {
+			_, err := newPromptFile("# Feature\n", include).ExpandedContent(ctx, inboxDir)
+			Expect(err).To(MatchError(ContainSubstring("must stay inside the prompts directory")))
+		},
+		Entry("parent directory", "../secret.txt"),
+		Entry("nested traversal", "shared/../../secret.txt"),
+		Entry("absolute path", "/etc/passwd"),
+	)
+
+	It("rejects a symlink leaving the prompts directory", func() {
+		secret := filepath.Join(tempDir, "secret.txt")
+		Expect(os.WriteFile(secret, []byte("token\n"), 0600)).To(Succeed())
+		Expect(os.Symlink(secret, filepath.Join(inboxDir, "link.txt"))).To(Succeed())
+
+		_, err := newPromptFile("# Feature\n", "link.txt").ExpandedContent(ctx, inboxDir)
+		Expect(err).To(MatchError(ContainSubstring("must stay inside the prompts directory")))
+	})
+
+	It("resolves includes against the inbox after the prompt moved to the queue", func() {
+		writeFile(filepath.Join("shared", "style.md"), "Use tabs.\n")
+		Expect(os.WriteFile(
+			filepath.Join(inboxDir, "001-feature.md"),
+			[]byte("---\nstatus: draft\nincludes: [\"shared/style.md\"]\n---\n# Feature\n"),
+			0600,
+		)).To(Succeed())
+		mgr := prompt.NewManager(
+			inboxDir, queueDir, "", "",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		)
+
+		queuedPath, err := prompt.ApproveFromInbox(
+			ctx, filepath.Join(inboxDir, "001-feature.md"), queueDir, mgr, mgr,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		pf, err := mgr.Load(ctx, queuedPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		content, err := pf.ExpandedContent(ctx, inboxDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal("# Feature\n\n--- include: shared/style.md ---\n\nUse tabs.\n"))
+	})
+
+	It("reports a missing include", func() {
+		_, err := newPromptFile("# Feature\n", "missing.md").ExpandedContent(ctx, inboxDir)
+		Expect(err).To(MatchError(ContainSubstring("read include missing.md")))
+	})
+
+	It("returns ErrEmptyPrompt for an empty body", func() {
+		writeFile("schema.sql", "CREATE TABLE users (id INT);\n")
+
+		_, err := newPromptFile("  \n", "schema.sql").ExpandedContent(ctx, inboxDir)
+		Expect(err).To(MatchError(prompt.ErrEmptyPrompt))
+	})
+})
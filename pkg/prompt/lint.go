@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"os"
+
+	"github.com/adrg/frontmatter"
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"gopkg.in/yaml.v3"
+)
+
+// Lint checks the prompt file at path and returns one message per problem found:
+// unparseable frontmatter YAML, every failing Prompt.Validate check (invalid status,
+// missing NNN- prefix) and an empty body. A valid file yields no messages.
+// The error is only set when the file cannot be read.
+func Lint(
+	ctx context.Context,
+	path string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ([]string, error) {
+	// #nosec G304 -- path is from the prompts directory listing
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "read file")
+	}
+
+	var problems []string
+	var fm Frontmatter
+	yamlV3Format := frontmatter.NewFormat("---", "---", yaml.Unmarshal)
+	if _, err := frontmatter.Parse(bytes.NewReader(content), &fm, yamlV3Format); err != nil {
+		problems = append(problems, "unparseable frontmatter YAML: "+err.Error())
+	}
+
+	p := Prompt{Path: path, Status: PromptStatus(fm.Status)}
+	for _, v := range p.validations() {
+		if err := v.Validate(ctx); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	pf, err := load(ctx, path, currentDateTimeGetter)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "load prompt")
+	}
+	if _, err := pf.Content(); stderrors.Is(err, ErrEmptyPrompt) {
+		problems = append(problems, "empty body")
+	}
+	return problems, nil
+}
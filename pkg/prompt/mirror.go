@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+)
+
+// WithMirrorCompletedTo copies every prompt moved to completed/ into dir as well.
+// An empty dir disables mirroring.
+func WithMirrorCompletedTo(dir string) ManagerOption {
+	return func(m *Manager) {
+		m.mirrorCompletedTo = dir
+	}
+}
+
+// mirrorCompleted copies the completed prompt at completedPath into the mirror dir.
+// Failures are logged, never returned — the prompt is already completed and the
+// mirror is a reporting side channel.
+func (pm *Manager) mirrorCompleted(ctx context.Context, completedPath string) {
+	if pm.mirrorCompletedTo == "" {
+		return
+	}
+	if err := copyFileAtomic(ctx, completedPath, pm.mirrorCompletedTo); err != nil {
+		slog.WarnContext(ctx, "mirror completed prompt failed",
+			"file", filepath.Base(completedPath),
+			"mirror_dir", pm.mirrorCompletedTo,
+			"error", err,
+		)
+		return
+	}
+	slog.DebugContext(ctx, "mirrored completed prompt",
+		"file", filepath.Base(completedPath),
+		"mirror_dir", pm.mirrorCompletedTo,
+	)
+}
+
+// copyFileAtomic copies src into dir under the same base name. The content is
+// written to a temp file in dir and renamed into place so readers of the mirror
+// never observe a partially written file.
+func copyFileAtomic(ctx context.Context, src string, dir string) error {
+	// #nosec G304 -- src is a prompt file path inside the configured completed dir
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return errors.Wrap(ctx, err, "read completed prompt")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrap(ctx, err, "create mirror directory")
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(src)+".tmp-*")
+	if err != nil {
+		return errors.Wrap(ctx, err, "create mirror temp file")
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(ctx, err, "write mirror temp file")
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(ctx, err, "close mirror temp file")
+	}
+	if err := os.Rename(tmpPath, filepath.Join(dir, filepath.Base(src))); err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(ctx, err, "rename mirror temp file")
+	}
+	return nil
+}
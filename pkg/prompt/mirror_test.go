@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("MirrorCompletedTo", func() {
+	var (
+		ctx          context.Context
+		tempDir      string
+		completedDir string
+		mirrorDir    string
+		path         string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		completedDir = filepath.Join(tempDir, "completed")
+		mirrorDir = filepath.Join(tempDir, "reports", "completed")
+		path = createPromptFile(tempDir, "001-mirror.md", "approved")
+	})
+
+	It("copies the completed prompt into the mirror directory", func() {
+		err := prompt.NewManager(
+			"",
+			"",
+			completedDir,
+			"",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+			prompt.WithMirrorCompletedTo(mirrorDir),
+		).MoveToCompleted(ctx, path)
+		Expect(err).To(BeNil())
+
+		completed, err := os.ReadFile(filepath.Join(completedDir, "001-mirror.md"))
+		Expect(err).To(BeNil())
+		mirrored, err := os.ReadFile(filepath.Join(mirrorDir, "001-mirror.md"))
+		Expect(err).To(BeNil())
+		Expect(mirrored).To(Equal(completed))
+		Expect(string(mirrored)).To(ContainSubstring("status: completed"))
+
+		entries, err := os.ReadDir(mirrorDir)
+		Expect(err).To(BeNil())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("does not mirror when no mirror directory is configured", func() {
+		err := prompt.NewManager(
+			"",
+			"",
+			completedDir,
+			"",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		).MoveToCompleted(ctx, path)
+		Expect(err).To(BeNil())
+
+		_, err = os.Stat(mirrorDir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("completes the prompt even when the mirror directory is not writable", func() {
+		blocker := filepath.Join(tempDir, "blocker")
+		Expect(os.WriteFile(blocker, []byte("file, not dir"), 0600)).To(Succeed())
+
+		err := prompt.NewManager(
+			"",
+			"",
+			completedDir,
+			"",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+			prompt.WithMirrorCompletedTo(filepath.Join(blocker, "mirror")),
+		).MoveToCompleted(ctx, path)
+		Expect(err).To(BeNil())
+
+		_, err = os.Stat(filepath.Join(completedDir, "001-mirror.md"))
+		Expect(err).To(BeNil())
+	})
+})
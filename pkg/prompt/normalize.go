@@ -24,12 +24,15 @@ var (
 
 // fileInfo represents information about a prompt file.
 type fileInfo struct {
+	dir    string
 	name   string
 	number int
 	slug   string
 }
 
-// normalizeFilenames scans a directory for .md files and ensures they follow the NNN-slug.md naming convention.
+// normalizeFilenames scans a directory and its queue subdirectories for .md files and ensures
+// they follow the NNN-slug.md naming convention. Numbers are unique across all subdirectories;
+// a file is renamed within its own subdirectory.
 // Files are renamed if they:
 // - Have no numeric prefix (gets next available number)
 // - Have a duplicate number (later file gets next available number)
@@ -41,7 +44,7 @@ func normalizeFilenames(
 	completedDir string,
 	mover FileMover,
 ) ([]Rename, error) {
-	entries, err := os.ReadDir(dir)
+	entries, err := readQueueDir(dir)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, "read directory")
 	}
@@ -49,24 +52,36 @@ func normalizeFilenames(
 	files, usedNumbers := scanPromptFiles(entries)
 
 	// Also collect numbers used in completed/ so we don't assign duplicates.
-	completedEntries, err := os.ReadDir(completedDir)
+	completedEntries, err := readCompletedDir(completedDir)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, errors.Wrap(ctx, err, "read completed directory")
 	}
-	_, completedNumbers := scanPromptFiles(completedEntries)
+	_, completedNumbers := scanPromptFiles(inDir(completedDir, completedEntries))
 	for n := range completedNumbers {
 		usedNumbers[n] = true
 	}
+	for n := range prunedNumbers(completedDir) {
+		usedNumbers[n] = true
+	}
 
 	sort.Slice(files, func(i, j int) bool {
 		return files[i].name < files[j].name
 	})
 
-	return renameInvalidFiles(ctx, dir, files, usedNumbers, mover)
+	return renameInvalidFiles(ctx, files, usedNumbers, mover)
+}
+
+// inDir wraps the entries of dir for scanPromptFiles.
+func inDir(dir string, entries []os.DirEntry) []promptDirEntry {
+	result := make([]promptDirEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = promptDirEntry{DirEntry: entry, dir: dir}
+	}
+	return result
 }
 
 // scanPromptFiles scans directory entries and extracts file information.
-func scanPromptFiles(entries []os.DirEntry) ([]fileInfo, map[int]bool) {
+func scanPromptFiles(entries []promptDirEntry) ([]fileInfo, map[int]bool) {
 	files := make([]fileInfo, 0, len(entries))
 	usedNumbers := make(map[int]bool)
 
@@ -76,6 +91,7 @@ func scanPromptFiles(entries []os.DirEntry) ([]fileInfo, map[int]bool) {
 		}
 
 		info := parseFilename(entry.Name(), validPatternRegexp, numericPatternRegexp)
+		info.dir = entry.dir
 		files = append(files, info)
 
 		// Only claim the number if the file is already properly formatted (NNN-slug.md).
@@ -116,7 +132,6 @@ func parseFilename(
 // renameInvalidFiles processes files and renames those that don't meet the naming convention.
 func renameInvalidFiles(
 	ctx context.Context,
-	dir string,
 	files []fileInfo,
 	usedNumbers map[int]bool,
 	mover FileMover,
@@ -128,7 +143,7 @@ func renameInvalidFiles(
 		newNumber, needsRename := determineRename(f, seenNumbers, usedNumbers)
 
 		if needsRename {
-			rename, err := performRename(ctx, dir, f, newNumber, mover)
+			rename, err := performRename(ctx, f, newNumber, mover)
 			if err != nil {
 				return nil, err
 			}
@@ -189,14 +204,13 @@ func findNextAvailableNumber(usedNumbers map[int]bool) int {
 // performRename renames a file to match the naming convention.
 func performRename(
 	ctx context.Context,
-	dir string,
 	f fileInfo,
 	newNumber int,
 	mover FileMover,
 ) (Rename, error) {
-	oldPath := filepath.Join(dir, f.name)
+	oldPath := filepath.Join(f.dir, f.name)
 	newName := fmt.Sprintf("%03d-%s.md", newNumber, f.slug)
-	newPath := filepath.Join(dir, newName)
+	newPath := filepath.Join(f.dir, newName)
 
 	slog.Debug("normalizing filename", "from", f.name, "to", newName, "number", newNumber)
 
@@ -14,10 +14,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/adrg/frontmatter"
 	"github.com/bborbe/collection"
@@ -27,6 +29,8 @@ import (
 	"github.com/golang/glog"
 	"gopkg.in/yaml.v3"
 
+	"github.com/bborbe/dark-factory/pkg/claudeargv"
+	"github.com/bborbe/dark-factory/pkg/git"
 	"github.com/bborbe/dark-factory/pkg/specnum"
 )
 
@@ -37,6 +41,14 @@ var (
 	hasNumberPrefixRegexp     = regexp.MustCompile(`^\d{3}-`)
 	extractNumberPrefixRegexp = regexp.MustCompile(`^(\d{3})-`)
 	anyNumberPrefixRegexp     = regexp.MustCompile(`^\d+-`)
+	// imageReferenceRegexp matches a docker image reference:
+	// [registry[:port]/]name[/name...][:tag][@sha256:digest].
+	imageReferenceRegexp = regexp.MustCompile(
+		`^[a-zA-Z0-9]+(?:[._-][a-zA-Z0-9]+)*(?::[0-9]+)?` +
+			`(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+			`(?::[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?` +
+			`(?:@sha256:[a-f0-9]{64})?$`,
+	)
 )
 
 // StripNumberPrefix removes any leading numeric prefix (e.g. "200-foo.md" → "foo.md", "1-bar.md" → "bar.md").
@@ -182,6 +194,11 @@ type Prompt struct {
 
 // Validate validates the Prompt struct.
 func (p Prompt) Validate(ctx context.Context) error {
+	return p.validations().Validate(ctx)
+}
+
+// validations returns the checks run by Validate, one per field.
+func (p Prompt) validations() validation.All {
 	return validation.All{
 		validation.Name("path", validation.NotEmptyString(p.Path)),
 		validation.Name("status", p.Status),
@@ -191,7 +208,7 @@ func (p Prompt) Validate(ctx context.Context) error {
 			}
 			return nil
 		})),
-	}.Validate(ctx)
+	}
 }
 
 // ValidateForExecution validates that a prompt is ready to execute.
@@ -259,10 +276,204 @@ type Frontmatter struct {
 	Branch             string `yaml:"branch,omitempty"`
 	Issue              string `yaml:"issue,omitempty"`
 	RetryCount         int    `yaml:"retryCount,omitempty"`
+	Priority           int    `yaml:"priority,omitempty"`
 	LastFailReason     string `yaml:"lastFailReason,omitempty"`
 	Rejected           string `yaml:"rejected,omitempty"`
 	RejectedReason     string `yaml:"rejectedReason,omitempty"`
 	Cancelled          string `yaml:"cancelled,omitempty"`
+	// IdempotencyKey lets automation retry an enqueue safely: a prompt whose key
+	// matches an already queued or completed prompt is not enqueued again.
+	IdempotencyKey string `yaml:"idempotency_key,omitempty"`
+	// Tags groups prompts by theme (docs, refactor, bugfix).
+	Tags []string `yaml:"tags,omitempty"`
+	// Command overrides the container image's default command for this prompt.
+	Command []string `yaml:"command,omitempty,flow"`
+	// Owner names the person responsible for the prompt; mentioned in notifications.
+	Owner string `yaml:"owner,omitempty"`
+	// Image overrides the configured containerImage for this prompt.
+	Image string `yaml:"image,omitempty"`
+	// Workflow overrides the configured workflow for this prompt (direct, branch,
+	// worktree, clone, or pr for clone with a pull request).
+	Workflow string `yaml:"workflow,omitempty"`
+	// Env adds environment variables to the container of this prompt. Entries
+	// win over the configured env; reserved executor keys are rejected.
+	Env map[string]string `yaml:"env,omitempty"`
+	// Volumes mounts additional project directories into the container of this
+	// prompt, each as "host:container[:ro]" with host relative to the project root.
+	Volumes []string `yaml:"volumes,omitempty"`
+	// RetryBackoff overrides the configured retryBackoff for this prompt.
+	RetryBackoff string `yaml:"retry_backoff,omitempty"`
+	// MaxRetries overrides the configured autoRetryLimit for this prompt.
+	// 0 disables automatic retries; nil keeps the configured limit.
+	MaxRetries *int `yaml:"max_retries,omitempty"`
+	// Timeout overrides the configured maxPromptDuration for this prompt (e.g. "30m").
+	Timeout string `yaml:"timeout,omitempty"`
+	// Bump forces the release version bump (patch, minor or major) instead of
+	// the bump derived from the changelog.
+	Bump string `yaml:"bump,omitempty"`
+	// SkipChangelog commits the prompt without tagging a release, even when
+	// the project has a CHANGELOG.md and autoRelease is enabled.
+	SkipChangelog bool `yaml:"skip_changelog,omitempty"`
+	// NotBefore is the RFC3339 time before which the scanner must not start
+	// the prompt. Set by the failure handler when a retry is backed off.
+	NotBefore string `yaml:"not_before,omitempty"`
+	// SourceURL points at the prompt body hosted elsewhere. The body is fetched
+	// at execution time and replaces the local body.
+	SourceURL string `yaml:"source_url,omitempty"`
+	// PRBody is a text/template for the pull request body of this prompt.
+	// It takes precedence over the prBodyTemplate config.
+	PRBody string `yaml:"pr_body,omitempty"`
+	// Draft opens the pull request of this prompt as a draft, even when the
+	// prDraft config is off.
+	Draft bool `yaml:"draft,omitempty"`
+	// Reviewers are requested on the pull request of this prompt, in addition
+	// to the prReviewers config.
+	Reviewers []string `yaml:"reviewers,omitempty"`
+	// Labels are added to the pull request of this prompt, in addition to the
+	// prLabels config.
+	Labels []string `yaml:"labels,omitempty"`
+	// DependsOn lists the prompts (numbers like "2" or filenames like
+	// "002-add-api.md") that must be completed before this prompt runs.
+	// When set it replaces the rule that every lower-numbered prompt is completed.
+	DependsOn []string `yaml:"depends_on,omitempty"`
+	// Includes lists companion files, relative to the prompts inbox dir, whose
+	// contents are appended to the body at execution time (see ExpandedContent).
+	Includes []string `yaml:"includes,omitempty"`
+	// ExitCode is the exit code of the last execution: 0 on success, the code
+	// of the container or subprocess on failure. Unset when it is not known.
+	ExitCode *int `yaml:"exit_code,omitempty"`
+	// Extra holds frontmatter keys dark-factory does not know, including nested
+	// maps and lists, so Save writes them back instead of dropping them.
+	Extra map[string]any `yaml:",inline"`
+}
+
+// ValidateImage checks that Image, when set, is a syntactically valid docker
+// image reference (e.g. "ghcr.io/org/agent-python:1.2" or "node@sha256:...").
+func (f Frontmatter) ValidateImage(ctx context.Context) error {
+	if f.Image == "" {
+		return nil
+	}
+	if len(f.Image) > 255 || !imageReferenceRegexp.MatchString(f.Image) {
+		return errors.Errorf(ctx, "image %q is not a valid image reference", f.Image)
+	}
+	return nil
+}
+
+// VersionBump returns the bump named by Bump, or false when Bump is empty or
+// names no known bump.
+func (f Frontmatter) VersionBump() (git.VersionBump, bool) {
+	return git.ParseVersionBump(f.Bump)
+}
+
+// ValidateTimeout checks that Timeout, when set, is a positive Go duration.
+func (f Frontmatter) ValidateTimeout(ctx context.Context) error {
+	if f.Timeout == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(f.Timeout)
+	if err != nil || d <= 0 {
+		return errors.Errorf(ctx, "timeout %q is not a positive duration", f.Timeout)
+	}
+	return nil
+}
+
+// EffectiveTimeout returns the prompt's Timeout when it parses as a positive
+// duration, otherwise maxPromptDuration. 0 means no timeout.
+func (f Frontmatter) EffectiveTimeout(maxPromptDuration time.Duration) time.Duration {
+	if f.Timeout == "" {
+		return maxPromptDuration
+	}
+	d, err := time.ParseDuration(f.Timeout)
+	if err != nil || d <= 0 {
+		return maxPromptDuration
+	}
+	return d
+}
+
+// promptEnvKeyRegexp is the required format for prompt env key names.
+var promptEnvKeyRegexp = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// reservedPromptEnvKeys are set by the executor for every prompt container and
+// cannot be overridden from frontmatter.
+var reservedPromptEnvKeys = []string{
+	claudeargv.EnvAnthropicModel,
+	claudeargv.EnvYoloPromptFile,
+	claudeargv.EnvYoloPrompt,
+	claudeargv.EnvYoloOutput,
+}
+
+// ValidateEnv checks that every Env key is an upper-case variable name that is
+// not reserved by the executor, and that no value contains a control character.
+func (f Frontmatter) ValidateEnv(ctx context.Context) error {
+	for k, v := range f.Env {
+		if !promptEnvKeyRegexp.MatchString(k) {
+			return errors.Errorf(ctx, "env key %q is not a valid variable name", k)
+		}
+		if slices.Contains(reservedPromptEnvKeys, k) {
+			return errors.Errorf(ctx, "env key %q is reserved and cannot be overridden", k)
+		}
+		if strings.ContainsAny(v, "\x00\n\r") {
+			return errors.Errorf(ctx, "env value for %q contains invalid characters", k)
+		}
+	}
+	return nil
+}
+
+// ValidateVolumes checks that every Volumes entry has the form host:container[:ro],
+// that host is a relative path inside the project and that container is an
+// absolute path without ".." segments.
+func (f Frontmatter) ValidateVolumes(ctx context.Context) error {
+	for _, volume := range f.Volumes {
+		parts := strings.Split(volume, ":")
+		if len(parts) != 2 && len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf(ctx, "volume %q must have the form host:container[:ro]", volume)
+		}
+		if len(parts) == 3 && parts[2] != "ro" {
+			return errors.Errorf(
+				ctx,
+				"volume %q has unknown mode %q, only ro is allowed",
+				volume,
+				parts[2],
+			)
+		}
+		if strings.ContainsAny(volume, "\x00\n\r") {
+			return errors.Errorf(ctx, "volume %q contains a control character", volume)
+		}
+		host := filepath.Clean(parts[0])
+		if filepath.IsAbs(host) || strings.HasPrefix(host, "~") || host == ".." ||
+			strings.HasPrefix(host, ".."+string(filepath.Separator)) {
+			return errors.Errorf(ctx, "volume %q host path must stay inside the project", volume)
+		}
+		container := parts[1]
+		if !strings.HasPrefix(container, "/") ||
+			slices.Contains(strings.Split(container, "/"), "..") {
+			return errors.Errorf(ctx, "volume %q container path must be absolute", volume)
+		}
+	}
+	return nil
+}
+
+// ValidateCommand checks that Command is an argument list, not a shell string.
+// Empty arguments, control characters and a single argument containing whitespace
+// (e.g. ["make test"]) are rejected — the list is passed to docker verbatim and is
+// never interpreted by a shell.
+func (f Frontmatter) ValidateCommand(ctx context.Context) error {
+	for i, arg := range f.Command {
+		if arg == "" {
+			return errors.Errorf(ctx, "command argument %d is empty", i+1)
+		}
+		if strings.ContainsAny(arg, "\x00\n\r") {
+			return errors.Errorf(ctx, "command argument %d contains a control character", i+1)
+		}
+	}
+	if len(f.Command) == 1 && strings.ContainsAny(f.Command[0], " \t") {
+		return errors.Errorf(
+			ctx,
+			"command %q looks like a shell string; write it as a list of arguments",
+			f.Command[0],
+		)
+	}
+	return nil
 }
 
 // HasSpec returns true if the given spec ID is in the Specs list.
@@ -354,6 +565,7 @@ func load(
 	// the same field. Writes always emit `execution_id:`. When both keys are present,
 	// `execution_id:` wins (it was already populated by the main parse above).
 	applyLegacyContainerKey(ctx, content, path, yamlV3Format, &fm)
+	dropLegacyKeys(&fm)
 
 	pf := &PromptFile{
 		Path:                  path,
@@ -396,6 +608,16 @@ func applyLegacyContainerKey(
 	}
 }
 
+// dropLegacyKeys removes the legacy keys handled above from Extra, so Save
+// emits only their current names.
+func dropLegacyKeys(fm *Frontmatter) {
+	delete(fm.Extra, "container")
+	delete(fm.Extra, "rejected_reason")
+	if len(fm.Extra) == 0 {
+		fm.Extra = nil
+	}
+}
+
 // Save writes the prompt file back to disk: frontmatter + body.
 // Body is always preserved exactly as loaded.
 func (pf *PromptFile) Save(ctx context.Context) error {
@@ -463,6 +685,28 @@ func (pf *PromptFile) Title() string {
 	return ""
 }
 
+// InstructionLength returns the number of characters of the body without the
+// heading returned by Title, ignoring surrounding whitespace.
+func (pf *PromptFile) InstructionLength() int {
+	var rest strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(pf.Body))
+	inFence := false
+	headingSeen := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		} else if !inFence && !headingSeen && strings.HasPrefix(trimmed, "# ") {
+			headingSeen = true
+			continue
+		}
+		rest.WriteString(line)
+		rest.WriteString("\n")
+	}
+	return utf8.RuneCountInString(strings.TrimSpace(rest.String()))
+}
+
 // now returns the current time from the injected getter.
 func (pf *PromptFile) now() time.Time {
 	return time.Time(pf.currentDateTimeGetter.Now())
@@ -476,6 +720,7 @@ func (pf *PromptFile) PrepareForExecution(container, version string) {
 	pf.Frontmatter.Container = container
 	pf.Frontmatter.DarkFactoryVersion = version
 	pf.Frontmatter.Started = now
+	pf.Frontmatter.ExitCode = nil
 	// Ensure created/queued timestamps exist
 	if pf.Frontmatter.Created == "" {
 		pf.Frontmatter.Created = now
@@ -485,6 +730,16 @@ func (pf *PromptFile) PrepareForExecution(container, version string) {
 	}
 }
 
+// Elapsed returns the time since the prompt started executing, or 0 when the
+// started timestamp is missing or unparseable.
+func (pf *PromptFile) Elapsed() time.Duration {
+	started, err := time.Parse(time.RFC3339, pf.Frontmatter.Started)
+	if err != nil {
+		return 0
+	}
+	return pf.now().Sub(started)
+}
+
 // MarkCompleted sets status to completed with timestamp and clears any
 // previously recorded lastFailReason so a successful retry leaves no stale
 // failure data in the frontmatter. The YAML tag is lastFailReason,omitempty
@@ -506,6 +761,11 @@ func (pf *PromptFile) SetLastFailReason(reason string) {
 	pf.Frontmatter.LastFailReason = reason
 }
 
+// SetExitCode records the exit code of the execution.
+func (pf *PromptFile) SetExitCode(code int) {
+	pf.Frontmatter.ExitCode = &code
+}
+
 // StampRejected sets the rejected timestamp and reason, then marks status as rejected.
 func (pf *PromptFile) StampRejected(reason string) {
 	if pf.Frontmatter.Rejected == "" {
@@ -596,6 +856,11 @@ func (pf *PromptFile) SetSummary(summary string) {
 	pf.Frontmatter.Summary = summary
 }
 
+// PRBody returns the pr_body template from frontmatter.
+func (pf *PromptFile) PRBody() string {
+	return pf.Frontmatter.PRBody
+}
+
 // PRURL returns the pr-url field from frontmatter.
 func (pf *PromptFile) PRURL() string {
 	return pf.Frontmatter.PRURL
@@ -611,6 +876,33 @@ func (pf *PromptFile) RetryCount() int {
 	return pf.Frontmatter.RetryCount
 }
 
+// SetPriority sets the priority field in frontmatter.
+func (pf *PromptFile) SetPriority(priority int) {
+	pf.Frontmatter.Priority = priority
+}
+
+// DeferFor sets not_before to now + delay. A zero or negative delay clears it.
+func (pf *PromptFile) DeferFor(delay time.Duration) {
+	if delay <= 0 {
+		pf.Frontmatter.NotBefore = ""
+		return
+	}
+	pf.Frontmatter.NotBefore = pf.now().Add(delay).UTC().Format(time.RFC3339)
+}
+
+// Deferred reports whether not_before lies in the future. An empty or
+// unparseable not_before never defers the prompt.
+func (pf *PromptFile) Deferred() bool {
+	if pf.Frontmatter.NotBefore == "" {
+		return false
+	}
+	notBefore, err := time.Parse(time.RFC3339, pf.Frontmatter.NotBefore)
+	if err != nil {
+		return false
+	}
+	return pf.now().Before(notBefore)
+}
+
 // Branch returns the branch field from frontmatter.
 func (pf *PromptFile) Branch() string {
 	return pf.Frontmatter.Branch
@@ -653,6 +945,15 @@ func (pf *PromptFile) Specs() []string {
 	return []string(pf.Frontmatter.Specs)
 }
 
+// DependsOn returns the prompts that must be completed before this prompt runs.
+// A nil PromptFile has none.
+func (pf *PromptFile) DependsOn() []string {
+	if pf == nil {
+		return nil
+	}
+	return pf.Frontmatter.DependsOn
+}
+
 //counterfeiter:generate -o ../../mocks/file-mover.go --fake-name FileMover . FileMover
 
 // FileMover handles file move operations with git awareness.
@@ -668,6 +969,7 @@ func NewManager(
 	cancelledDir string,
 	mover FileMover,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+	opts ...ManagerOption,
 ) *Manager {
 	m := &Manager{
 		inboxDir:              inboxDir,
@@ -676,13 +978,24 @@ func NewManager(
 		cancelledDir:          cancelledDir,
 		mover:                 mover,
 		currentDateTimeGetter: currentDateTimeGetter,
+		queueOrder:            QueueOrderPriority,
+		completedLayout:       CompletedLayoutFlat,
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
 	m.promptStatusManager = NewPromptStatusManager(currentDateTimeGetter)
-	m.promptScanner = NewPromptScanner(inProgressDir, completedDir, currentDateTimeGetter)
+	m.promptScanner = NewPromptScanner(
+		inProgressDir,
+		completedDir,
+		m.queueOrder,
+		currentDateTimeGetter,
+	)
 	m.promptMover = NewPromptMover(
 		inProgressDir,
 		completedDir,
 		cancelledDir,
+		m.completedLayout,
 		mover,
 		currentDateTimeGetter,
 	)
@@ -698,6 +1011,10 @@ type Manager struct {
 	cancelledDir          string
 	mover                 FileMover
 	currentDateTimeGetter libtime.CurrentDateTimeGetter
+	queueOrder            QueueOrder
+	mirrorCompletedTo     string
+	completedRetention    time.Duration
+	completedLayout       CompletedLayout
 
 	promptStatusManager PromptStatusManager
 	promptScanner       PromptScanner
@@ -744,6 +1061,11 @@ func (p PromptStatusManager) SetBranch(ctx context.Context, path string, branch
 	return setBranch(ctx, path, branch, p.currentDateTimeGetter)
 }
 
+// SetPriority updates the priority field in a prompt file's frontmatter.
+func (p PromptStatusManager) SetPriority(ctx context.Context, path string, priority int) error {
+	return setPriority(ctx, path, priority, p.currentDateTimeGetter)
+}
+
 // IncrementRetryCount increments the retryCount field in a prompt file's frontmatter.
 func (p PromptStatusManager) IncrementRetryCount(ctx context.Context, path string) error {
 	return incrementRetryCount(ctx, path, p.currentDateTimeGetter)
@@ -753,24 +1075,32 @@ func (p PromptStatusManager) IncrementRetryCount(ctx context.Context, path strin
 type PromptScanner struct {
 	inProgressDir         string
 	completedDir          string
+	queueOrder            QueueOrder
 	currentDateTimeGetter libtime.CurrentDateTimeGetter
 }
 
 // NewPromptScanner creates a PromptScanner.
 func NewPromptScanner(
 	inProgressDir, completedDir string,
+	queueOrder QueueOrder,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) PromptScanner {
 	return PromptScanner{
 		inProgressDir:         inProgressDir,
 		completedDir:          completedDir,
+		queueOrder:            queueOrder,
 		currentDateTimeGetter: currentDateTimeGetter,
 	}
 }
 
 // ListQueued scans the in-progress directory for .md files ready to be picked up.
 func (p PromptScanner) ListQueued(ctx context.Context) ([]Prompt, error) {
-	return listQueued(ctx, p.inProgressDir, p.currentDateTimeGetter)
+	return listQueued(ctx, p.inProgressDir, p.queueOrder, p.currentDateTimeGetter)
+}
+
+// ListQueuedByTag returns the queued prompts tagged with tag, in queue order.
+func (p PromptScanner) ListQueuedByTag(ctx context.Context, tag string) ([]Prompt, error) {
+	return listQueuedByTag(ctx, p.inProgressDir, p.queueOrder, tag, p.currentDateTimeGetter)
 }
 
 // HasExecuting returns true if any prompt in the directory has status "executing".
@@ -796,6 +1126,11 @@ func (p PromptScanner) AllPreviousCompleted(ctx context.Context, n int) bool {
 	return allPreviousCompleted(ctx, p.completedDir, n)
 }
 
+// MissingDependencies returns the depends_on entries not yet in completed/.
+func (p PromptScanner) MissingDependencies(ctx context.Context, deps []string) []string {
+	return missingDependencies(ctx, p.completedDir, deps)
+}
+
 // FindMissingCompleted returns prompt numbers less than n that are NOT in completed/.
 func (p PromptScanner) FindMissingCompleted(ctx context.Context, n int) []int {
 	return findMissingCompleted(ctx, p.completedDir, n)
@@ -845,6 +1180,7 @@ type PromptMover struct {
 	inProgressDir         string
 	completedDir          string
 	cancelledDir          string
+	completedLayout       CompletedLayout
 	mover                 FileMover
 	currentDateTimeGetter libtime.CurrentDateTimeGetter
 }
@@ -854,6 +1190,7 @@ func NewPromptMover(
 	inProgressDir string,
 	completedDir string,
 	cancelledDir string,
+	completedLayout CompletedLayout,
 	mover FileMover,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) PromptMover {
@@ -861,6 +1198,7 @@ func NewPromptMover(
 		inProgressDir:         inProgressDir,
 		completedDir:          completedDir,
 		cancelledDir:          cancelledDir,
+		completedLayout:       completedLayout,
 		mover:                 mover,
 		currentDateTimeGetter: currentDateTimeGetter,
 	}
@@ -868,7 +1206,20 @@ func NewPromptMover(
 
 // MoveToCompleted sets status to "completed" and moves a prompt file to the completed directory.
 func (p PromptMover) MoveToCompleted(ctx context.Context, path string) error {
-	return moveToCompleted(ctx, path, p.completedDir, p.mover, p.currentDateTimeGetter)
+	_, err := p.moveToCompleted(ctx, path)
+	return err
+}
+
+// moveToCompleted is MoveToCompleted returning the path the file was moved to.
+func (p PromptMover) moveToCompleted(ctx context.Context, path string) (string, error) {
+	return moveToCompleted(
+		ctx,
+		path,
+		p.completedDir,
+		p.completedLayout,
+		p.mover,
+		p.currentDateTimeGetter,
+	)
 }
 
 // MoveToCancelled sets status to "cancelled" (with timestamp) and moves a prompt file to the cancelled directory.
@@ -963,6 +1314,12 @@ func (pm *Manager) ListQueued(ctx context.Context) ([]Prompt, error) {
 	return pm.promptScanner.ListQueued(ctx)
 }
 
+// ListQueuedByTag returns the queued prompts whose tags include tag, in queue order.
+// An empty tag returns every queued prompt.
+func (pm *Manager) ListQueuedByTag(ctx context.Context, tag string) ([]Prompt, error) {
+	return pm.promptScanner.ListQueuedByTag(ctx, tag)
+}
+
 // FindCommitting returns paths of all prompt files in in-progress/ with status "committing".
 func (pm *Manager) FindCommitting(ctx context.Context) ([]string, error) {
 	return pm.promptScanner.FindCommitting(ctx)
@@ -1003,6 +1360,12 @@ func (pm *Manager) SetBranch(ctx context.Context, path string, branch string) er
 	return pm.promptStatusManager.SetBranch(ctx, path, branch)
 }
 
+// SetPriority updates the priority field in a prompt file's frontmatter.
+// It affects the queue position unless queueOrder is number or mtime.
+func (pm *Manager) SetPriority(ctx context.Context, path string, priority int) error {
+	return pm.promptStatusManager.SetPriority(ctx, path, priority)
+}
+
 // IncrementRetryCount increments the retryCount field in a prompt file's frontmatter.
 func (pm *Manager) IncrementRetryCount(ctx context.Context, path string) error {
 	return pm.promptStatusManager.IncrementRetryCount(ctx, path)
@@ -1018,9 +1381,16 @@ func (pm *Manager) Title(ctx context.Context, path string) (string, error) {
 	return pm.promptFileLoader.Title(ctx, path)
 }
 
-// MoveToCompleted sets status to "completed" and moves a prompt file to the completed/ subdirectory.
+// MoveToCompleted sets status to "completed" and moves a prompt file to the completed/ subdirectory
+// (a YYYY-MM subdirectory of it under WithCompletedLayout(CompletedLayoutMonthly)).
+// With WithMirrorCompletedTo the completed file is also copied to the mirror directory.
 func (pm *Manager) MoveToCompleted(ctx context.Context, path string) error {
-	return pm.promptMover.MoveToCompleted(ctx, path)
+	dest, err := pm.promptMover.moveToCompleted(ctx, path)
+	if err != nil {
+		return err
+	}
+	pm.mirrorCompleted(ctx, dest)
+	return nil
 }
 
 // MoveToCancelled sets status to "cancelled" (with timestamp) and moves a prompt file to the cancelled/ subdirectory.
@@ -1039,6 +1409,11 @@ func (pm *Manager) AllPreviousCompleted(ctx context.Context, n int) bool {
 	return pm.promptScanner.AllPreviousCompleted(ctx, n)
 }
 
+// MissingDependencies returns the depends_on entries not yet in completed/.
+func (pm *Manager) MissingDependencies(ctx context.Context, deps []string) []string {
+	return pm.promptScanner.MissingDependencies(ctx, deps)
+}
+
 // FindMissingCompleted returns prompt numbers less than n that are NOT in completed/.
 func (pm *Manager) FindMissingCompleted(ctx context.Context, n int) []int {
 	return pm.promptScanner.FindMissingCompleted(ctx, n)
@@ -1052,7 +1427,7 @@ func (pm *Manager) FindPromptStatusInProgress(ctx context.Context, number int) s
 // Reason tokens for blocked-prompt notifications. These strings are
 // canonical: the scanner's blocked-log line and `dark-factory status` both
 // emit them verbatim. Drift between the two surfaces is a regression (spec
-// 094 AC "scanner-log-enum"). All five tokens are defined here so the parity
+// 094 AC "scanner-log-enum"). All tokens are defined here so the parity
 // test in pkg/status can derive its expectation from these constants rather
 // than duplicating a hand-written literal.
 const (
@@ -1061,6 +1436,8 @@ const (
 	ReasonPromptFrontmatterParseError = "prompt-frontmatter-parse-error"
 	ReasonPromptFileReadError         = "prompt-file-read-error"
 	ReasonProjectLockTimeout          = "project-lock-timeout"
+	ReasonRetryBackoff                = "retry-backoff"
+	ReasonDependencyNotCompleted      = "dependency-not-completed"
 )
 
 // GetBlockedPrompt scans queued prompts and returns the first one whose per-spec
@@ -1084,6 +1461,12 @@ func (pm *Manager) GetBlockedPrompt(ctx context.Context) (int, string, int, bool
 		if err != nil {
 			return number, ReasonPromptFileReadError, 0, true
 		}
+		if deps := pf.DependsOn(); len(deps) > 0 {
+			if missing := pm.promptScanner.MissingDependencies(ctx, deps); len(missing) > 0 {
+				return number, ReasonDependencyNotCompleted, dependencyNumber(missing[0]), true
+			}
+			continue
+		}
 		specs := pf.Specs()
 		if len(specs) == 0 {
 			if !pm.promptScanner.AllPreviousCompleted(ctx, number) {
@@ -1148,26 +1531,71 @@ func (pm *Manager) HasQueuedPromptsOnBranch(
 	return false, nil
 }
 
+// ListQueuedByTag returns the queued prompts whose tags include tag, in queue order.
+// An empty tag returns every queued prompt.
+func listQueuedByTag(
+	ctx context.Context,
+	dir string,
+	queueOrder QueueOrder,
+	tag string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ([]Prompt, error) {
+	queued, err := listQueuedEntries(ctx, dir, queueOrder, currentDateTimeGetter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Prompt, 0, len(queued))
+	for _, q := range queued {
+		if tag == "" || slices.Contains(q.tags, tag) {
+			result = append(result, q.prompt)
+		}
+	}
+	return result, nil
+}
+
 // ListQueued scans a directory for .md files that should be picked up.
-// Files are picked up UNLESS they have an explicit skip status (executing, completed, failed).
-// Sorted alphabetically by filename.
+// Files are picked up UNLESS they have an explicit skip status (executing, completed, failed)
+// or match a pattern in the directory's .darkfactoryignore file.
+// Sorted by queueOrder: by priority descending (priority, the default),
+// alphabetically by filename (number) or by modification time ascending (mtime).
 func listQueued(
 	ctx context.Context,
 	dir string,
+	queueOrder QueueOrder,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) ([]Prompt, error) {
-	entries, err := os.ReadDir(dir)
+	queued, err := listQueuedEntries(ctx, dir, queueOrder, currentDateTimeGetter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Prompt, len(queued))
+	for i, q := range queued {
+		result[i] = q.prompt
+	}
+	return result, nil
+}
+
+// listQueuedEntries returns the sorted queue with the sort keys and tags of each prompt.
+func listQueuedEntries(
+	ctx context.Context,
+	dir string,
+	queueOrder QueueOrder,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ([]queuedEntry, error) {
+	entries, err := readQueueDir(dir)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, "read directory")
 	}
+	ignored := loadIgnorePatterns(dir)
 
-	queued := make([]Prompt, 0, len(entries))
+	queued := make([]queuedEntry, 0, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+		if ignored.Matches(entry.Name()) {
+			slog.Debug("skipping ignored prompt", "file", entry.Name())
 			continue
 		}
 
-		path := filepath.Join(dir, entry.Name())
+		path := entry.path()
 		fm, err := readFrontmatter(ctx, path, currentDateTimeGetter)
 		if err != nil {
 			// Skip files with read errors
@@ -1192,17 +1620,22 @@ func listQueued(
 		if fm.Status == "" {
 			status = ApprovedPromptStatus
 		}
-		queued = append(queued, Prompt{
-			Path:   path,
-			Status: status,
+		var modTime time.Time
+		if info, err := entry.Info(); err == nil {
+			modTime = info.ModTime()
+		}
+		queued = append(queued, queuedEntry{
+			prompt: Prompt{
+				Path:   path,
+				Status: status,
+			},
+			modTime:  modTime,
+			priority: fm.Priority,
+			tags:     fm.Tags,
 		})
 	}
 
-	// Sort alphabetically by filename
-	sort.Slice(queued, func(i, j int) bool {
-		return filepath.Base(queued[i].Path) < filepath.Base(queued[j].Path)
-	})
-
+	sortQueued(queued, queueOrder)
 	return queued, nil
 }
 
@@ -1213,17 +1646,13 @@ func resetExecuting(
 	dir string,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) error {
-	entries, err := os.ReadDir(dir)
+	entries, err := readQueueDir(dir)
 	if err != nil {
 		return errors.Wrap(ctx, err, "read directory")
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-
-		path := filepath.Join(dir, entry.Name())
+		path := entry.path()
 		pf, err := load(ctx, path, currentDateTimeGetter)
 		if err != nil {
 			continue
@@ -1247,17 +1676,13 @@ func resetFailed(
 	dir string,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) error {
-	entries, err := os.ReadDir(dir)
+	entries, err := readQueueDir(dir)
 	if err != nil {
 		return errors.Wrap(ctx, err, "read directory")
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-
-		path := filepath.Join(dir, entry.Name())
+		path := entry.path()
 		pf, err := load(ctx, path, currentDateTimeGetter)
 		if err != nil {
 			continue
@@ -1284,21 +1709,20 @@ func findCommitting(
 ) ([]string, error) {
 	var paths []string
 	for _, dir := range dirs {
-		entries, err := os.ReadDir(dir)
+		// ListCompletedFiles also covers the YYYY-MM subdirectories of completed/;
+		// the queue directory has none, so it lists just the queue there.
+		candidates, err := ListCompletedFiles(ctx, dir)
 		if err != nil {
-			if os.IsNotExist(err) {
-				continue
-			}
 			return nil, errors.Wrap(ctx, err, "read directory")
 		}
-		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-				continue
-			}
-			path := filepath.Join(dir, entry.Name())
+		for _, path := range candidates {
 			fm, err := readFrontmatter(ctx, path, currentDateTimeGetter)
 			if err != nil {
-				slog.Warn("skipping prompt in FindCommitting", "file", entry.Name(), "error", err)
+				slog.Warn(
+					"skipping prompt in FindCommitting",
+					"file", filepath.Base(path),
+					"error", err,
+				)
 				continue
 			}
 			if fm.Status == string(CommittingPromptStatus) {
@@ -1418,6 +1842,22 @@ func setBranch(
 	return pf.Save(ctx)
 }
 
+// SetPriority updates the priority field in a prompt file's frontmatter.
+func setPriority(
+	ctx context.Context,
+	path string,
+	priority int,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) error {
+	pf, err := load(ctx, path, currentDateTimeGetter)
+	if err != nil {
+		return errors.Wrap(ctx, err, "load prompt")
+	}
+
+	pf.SetPriority(priority)
+	return pf.Save(ctx)
+}
+
 // IncrementRetryCount increments the retryCount field in a prompt file's frontmatter by 1.
 // If the file has no frontmatter, adds frontmatter with retryCount set to 1.
 func incrementRetryCount(
@@ -1502,42 +1942,42 @@ func (pm *Manager) RollbackMoveToCompleted(
 	return nil
 }
 
-// MoveToCompleted sets status to "completed" and moves a prompt file to the completed directory.
+// MoveToCompleted sets status to "completed" and moves a prompt file to the completed directory,
+// returning the destination path (see PromptFile.CompletedPath for the layout).
 // This ensures files in completed/ always have the correct status.
 func moveToCompleted(
 	ctx context.Context,
 	path string,
 	completedDir string,
+	layout CompletedLayout,
 	mover FileMover,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
-) error {
+) (string, error) {
 	// Load, mark completed, and save before moving
 	pf, err := load(ctx, path, currentDateTimeGetter)
 	if err != nil {
-		return errors.Wrap(ctx, err, "load prompt")
+		return "", errors.Wrap(ctx, err, "load prompt")
 	}
 
 	pf.MarkCompleted()
 	if err := pf.Save(ctx); err != nil {
-		return errors.Wrap(ctx, err, "set completed status")
+		return "", errors.Wrap(ctx, err, "set completed status")
 	}
 
-	// Ensure completed directory exists
-	if err := os.MkdirAll(completedDir, 0750); err != nil {
-		return errors.Wrap(ctx, err, "create completed directory")
-	}
+	dest := pf.CompletedPath(completedDir, layout, path)
 
-	// Move file
-	filename := filepath.Base(path)
-	dest := filepath.Join(completedDir, filename)
+	// Ensure completed directory (or its month subdirectory) exists
+	if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+		return "", errors.Wrap(ctx, err, "create completed directory")
+	}
 
 	slog.Debug("moving to completed", "from", path, "to", dest)
 
 	if err := mover.MoveFile(ctx, path, dest); err != nil {
-		return errors.Wrap(ctx, err, "move file")
+		return "", errors.Wrap(ctx, err, "move file")
 	}
 
-	return nil
+	return dest, nil
 }
 
 // moveToCancelled sets status to "cancelled" (with timestamp) and moves a prompt file to the cancelled directory.
@@ -1580,15 +2020,16 @@ func hasExecuting(
 	dir string,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) bool {
-	entries, err := os.ReadDir(dir)
+	entries, err := readQueueDir(dir)
 	if err != nil {
 		return false
 	}
+	ignored := loadIgnorePatterns(dir)
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+		if ignored.Matches(entry.Name()) {
 			continue
 		}
-		fm, err := readFrontmatter(ctx, filepath.Join(dir, entry.Name()), currentDateTimeGetter)
+		fm, err := readFrontmatter(ctx, entry.path(), currentDateTimeGetter)
 		if err != nil {
 			continue
 		}
@@ -1639,13 +2080,13 @@ func allPreviousCompleted(_ context.Context, completedDir string, n int) bool {
 		return true // No previous prompts to check
 	}
 
-	completedEntries, err := os.ReadDir(completedDir)
+	completedEntries, err := readCompletedDir(completedDir)
 	if err != nil {
 		return false // completed directory doesn't exist or can't be read
 	}
 
-	// Collect all completed numbers
-	completedNumbers := make(map[int]bool)
+	// Collect all completed numbers, including those deleted by retention
+	completedNumbers := prunedNumbers(completedDir)
 	for _, entry := range completedEntries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
 			continue
@@ -1673,7 +2114,7 @@ func findMissingCompleted(_ context.Context, completedDir string, n int) []int {
 		return nil
 	}
 
-	completedEntries, err := os.ReadDir(completedDir)
+	completedEntries, err := readCompletedDir(completedDir)
 	if err != nil {
 		// completed directory doesn't exist or can't be read — all are missing
 		missing := make([]int, 0, n-1)
@@ -1683,7 +2124,7 @@ func findMissingCompleted(_ context.Context, completedDir string, n int) []int {
 		return missing
 	}
 
-	completedNumbers := make(map[int]bool)
+	completedNumbers := prunedNumbers(completedDir)
 	for _, entry := range completedEntries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
 			continue
@@ -1778,7 +2219,11 @@ func findPredecessorInSpec(
 ) (int, bool) {
 	highest := -1
 	for _, dir := range []string{scanDir, completedDir} {
-		entries, err := os.ReadDir(dir)
+		list := listMarkdownFiles
+		if dir == completedDir {
+			list = ListCompletedFiles
+		}
+		paths, err := list(ctx, dir)
 		if err != nil {
 			// Logged at V(1) but treated as "directory empty for this spec":
 			// the safety-vs-noise tradeoff is intentional — a transient ENOENT
@@ -1792,15 +2237,11 @@ func findPredecessorInSpec(
 			)
 			continue
 		}
-		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-				continue
-			}
-			num := extractNumberFromFilename(entry.Name())
+		for _, path := range paths {
+			num := extractNumberFromFilename(filepath.Base(path))
 			if num < 0 || num >= n {
 				continue
 			}
-			path := filepath.Join(dir, entry.Name())
 			fm, err := readFrontmatter(ctx, path, currentDateTimeGetter)
 			if err != nil {
 				// Same safety-vs-noise tradeoff: a parse failure on one
@@ -1831,12 +2272,14 @@ func findPredecessorInSpec(
 	return highest, highest >= 0
 }
 
-// isNumberInCompletedDir returns true if a file with the given number exists in completedDir.
+// isNumberInCompletedDir returns true if a file with the given number exists in completedDir
+// (or one of its YYYY-MM subdirectories)
+// or was deleted from it by retention (see PrunedManifestFileName).
 // Returns false on filesystem error (fail-closed: caller treats false as "not completed" →
 // queue-advance guard blocks). The error is logged at V(1) so operators can distinguish a
 // real read failure from a legitimate "not in completed yet" answer.
 func isNumberInCompletedDir(completedDir string, num int) bool {
-	entries, err := os.ReadDir(completedDir)
+	entries, err := readCompletedDir(completedDir)
 	if err != nil {
 		glog.V(1).Infof(
 			"isNumberInCompletedDir: ReadDir failed for dir=%s num=%d: %v",
@@ -1853,7 +2296,7 @@ func isNumberInCompletedDir(completedDir string, num int) bool {
 			return true
 		}
 	}
-	return false
+	return prunedNumbers(completedDir)[num]
 }
 
 // specListContains returns true if specID matches any entry in the spec list.
@@ -1878,20 +2321,17 @@ func specListContains(specs SpecList, specID string) bool {
 // FindPromptStatus looks up a prompt by number in the given directory and returns its frontmatter status.
 // Returns empty string if not found.
 func findPromptStatus(_ context.Context, dir string, number int) string {
-	entries, err := os.ReadDir(dir)
+	entries, err := readQueueDir(dir)
 	if err != nil {
 		return ""
 	}
 	prefix := fmt.Sprintf("%03d-", number)
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
 		if !strings.HasPrefix(entry.Name(), prefix) {
 			continue
 		}
-		path := filepath.Join(dir, entry.Name())
-		// #nosec G304 -- path is constructed from os.ReadDir of a trusted directory
+		path := entry.path()
+		// #nosec G304 -- path is constructed from a listing of a trusted directory
 		data, err := os.ReadFile(path)
 		if err != nil {
 			return ""
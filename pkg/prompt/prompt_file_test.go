@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	libtime "github.com/bborbe/time"
 	. "github.com/onsi/ginkgo/v2"
@@ -72,6 +73,53 @@ var _ = Describe("Prompt", func() {
 			Expect(err).To(BeNil())
 			Expect(fm.Status).To(Equal("completed"))
 		})
+
+		It("records an RFC3339 completed timestamp and keeps the execution fields", func() {
+			executingPath := filepath.Join(tempDir, "002-executing.md")
+			content := "---\nstatus: executing\nexecution_id: proj-002-executing\n" +
+				"dark-factory-version: v1.2.3\nstarted: \"2026-01-02T03:04:05Z\"\n---\n\n# Test\n"
+			Expect(os.WriteFile(executingPath, []byte(content), 0600)).To(Succeed())
+
+			completedDir := filepath.Join(tempDir, "completed")
+			err := prompt.NewManager("", "", completedDir, "", mover, libtime.NewCurrentDateTime()).
+				MoveToCompleted(ctx, executingPath)
+			Expect(err).To(BeNil())
+
+			fm, err := prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime()).
+				ReadFrontmatter(ctx, filepath.Join(completedDir, "002-executing.md"))
+			Expect(err).To(BeNil())
+			Expect(fm.Status).To(Equal("completed"))
+			Expect(fm.Container).To(Equal("proj-002-executing"))
+			Expect(fm.DarkFactoryVersion).To(Equal("v1.2.3"))
+			Expect(fm.Started).To(Equal("2026-01-02T03:04:05Z"))
+			_, err = time.Parse(time.RFC3339, fm.Completed)
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("PromptFile.PrepareForExecution", func() {
+		It("records an RFC3339 started timestamp and keeps existing fields", func() {
+			path := filepath.Join(tempDir, "001-test.md")
+			content := "---\nstatus: approved\npriority: 5\ncreated: \"2026-01-01T00:00:00Z\"\n---\n\n# Test\n"
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+
+			manager := prompt.NewManager("", "", "", "", nil, libtime.NewCurrentDateTime())
+			pf, err := manager.Load(ctx, path)
+			Expect(err).To(BeNil())
+			pf.PrepareForExecution("proj-001-test", "v1.2.3")
+			Expect(pf.Save(ctx)).To(Succeed())
+
+			fm, err := manager.ReadFrontmatter(ctx, path)
+			Expect(err).To(BeNil())
+			Expect(fm.Status).To(Equal("executing"))
+			Expect(fm.Container).To(Equal("proj-001-test"))
+			Expect(fm.DarkFactoryVersion).To(Equal("v1.2.3"))
+			Expect(fm.Priority).To(Equal(5))
+			Expect(fm.Created).To(Equal("2026-01-01T00:00:00Z"))
+			_, err = time.Parse(time.RFC3339, fm.Started)
+			Expect(err).To(BeNil())
+			Expect(fm.Completed).To(BeEmpty())
+		})
 	})
 
 	Describe("HasExecuting", func() {
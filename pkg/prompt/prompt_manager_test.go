@@ -52,6 +52,26 @@ var _ = Describe("Prompt", func() {
 			})
 		})
 
+		Context("with approved prompts under completed/ and log/", func() {
+			BeforeEach(func() {
+				createPromptFile(tempDir, "001-first.md", "approved")
+				for _, sub := range []string{"completed", "log"} {
+					dir := filepath.Join(tempDir, sub)
+					Expect(os.MkdirAll(dir, 0750)).To(Succeed())
+					createPromptFile(dir, "002-nested.md", "approved")
+				}
+			})
+
+			It("never queues the nested files", func() {
+				completedDir := filepath.Join(tempDir, "completed")
+				prompts, err := prompt.NewManager("", tempDir, completedDir, "", nil, libtime.NewCurrentDateTime()).
+					ListQueued(ctx)
+				Expect(err).To(BeNil())
+				Expect(prompts).To(HaveLen(1))
+				Expect(filepath.Base(prompts[0].Path)).To(Equal("001-first.md"))
+			})
+		})
+
 		Context("with no frontmatter at all", func() {
 			BeforeEach(func() {
 				// Plain markdown file with no frontmatter
@@ -140,6 +160,111 @@ var _ = Describe("Prompt", func() {
 				Expect(prompts).To(HaveLen(1))
 			})
 		})
+
+		Context("with a .darkfactoryignore file", func() {
+			BeforeEach(func() {
+				createPromptFile(tempDir, "001-first.md", "approved")
+				createPromptFile(tempDir, "002-second.md", "approved")
+				createPromptFile(tempDir, "draft-idea.md", "approved")
+				createPromptFile(tempDir, "scratch.md", "approved")
+				ignore := "# scratch files\n\ndraft-*.md\n  scratch.md  \n"
+				err := os.WriteFile(
+					filepath.Join(tempDir, prompt.IgnoreFileName),
+					[]byte(ignore),
+					0600,
+				)
+				Expect(err).To(BeNil())
+			})
+
+			It("skips files matching an ignore pattern", func() {
+				prompts, err := prompt.NewManager("", tempDir, "", "", nil, libtime.NewCurrentDateTime()).
+					ListQueued(ctx)
+				Expect(err).To(BeNil())
+				Expect(prompts).To(HaveLen(2))
+				Expect(filepath.Base(prompts[0].Path)).To(Equal("001-first.md"))
+				Expect(filepath.Base(prompts[1].Path)).To(Equal("002-second.md"))
+			})
+
+			It("ignores an executing prompt matching an ignore pattern", func() {
+				createPromptFile(tempDir, "draft-running.md", "executing")
+				manager := prompt.NewManager("", tempDir, "", "", nil, libtime.NewCurrentDateTime())
+				Expect(manager.HasExecuting(ctx)).To(BeFalse())
+
+				createPromptFile(tempDir, "003-running.md", "executing")
+				Expect(manager.HasExecuting(ctx)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("ListQueuedByTag", func() {
+		writeTagged := func(name string, tags string) string {
+			content := "---\nstatus: approved\n" + tags + "---\n\n# Tagged\n"
+			path := filepath.Join(tempDir, name)
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+
+		listNames := func(tag string) []string {
+			prompts, err := prompt.NewManager("", tempDir, "", "", nil, libtime.NewCurrentDateTime()).
+				ListQueuedByTag(ctx, tag)
+			Expect(err).To(BeNil())
+			names := make([]string, len(prompts))
+			for i, p := range prompts {
+				names[i] = filepath.Base(p.Path)
+			}
+			return names
+		}
+
+		BeforeEach(func() {
+			writeTagged("001-untagged.md", "")
+			writeTagged("002-bugfix.md", "tags:\n  - bugfix\n")
+			writeTagged("003-docs-bugfix.md", "tags:\n  - docs\n  - bugfix\n")
+			createPromptFile(tempDir, "004-done.md", "completed")
+		})
+
+		It("returns every queued prompt for an empty tag", func() {
+			Expect(listNames("")).To(Equal([]string{
+				"001-untagged.md",
+				"002-bugfix.md",
+				"003-docs-bugfix.md",
+			}))
+		})
+
+		It("returns prompts with a single matching tag", func() {
+			Expect(listNames("docs")).To(Equal([]string{"003-docs-bugfix.md"}))
+		})
+
+		It("returns prompts with the tag among multiple tags", func() {
+			Expect(listNames("bugfix")).To(Equal([]string{
+				"002-bugfix.md",
+				"003-docs-bugfix.md",
+			}))
+		})
+
+		It("returns nothing for an unknown tag", func() {
+			Expect(listNames("refactor")).To(BeEmpty())
+		})
+
+		It("keeps tags through SetStatus and MoveToCompleted", func() {
+			completedDir := filepath.Join(tempDir, "completed")
+			Expect(os.MkdirAll(completedDir, 0750)).To(Succeed())
+			manager := prompt.NewManager(
+				"",
+				tempDir,
+				completedDir,
+				"",
+				mover,
+				libtime.NewCurrentDateTime(),
+			)
+			path := filepath.Join(tempDir, "003-docs-bugfix.md")
+
+			Expect(manager.SetStatus(ctx, path, "executing")).To(Succeed())
+			Expect(manager.MoveToCompleted(ctx, path)).To(Succeed())
+
+			pf, err := manager.Load(ctx, filepath.Join(completedDir, "003-docs-bugfix.md"))
+			Expect(err).To(BeNil())
+			Expect(pf.Frontmatter.Tags).To(Equal([]string{"docs", "bugfix"}))
+		})
 	})
 
 	Describe("SetStatus", func() {
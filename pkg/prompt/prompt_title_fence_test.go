@@ -114,3 +114,17 @@ var _ = Describe("Title with fenced code blocks", func() {
 		})
 	})
 })
+
+var _ = DescribeTable("PromptFile.InstructionLength",
+	func(body string, expected int) {
+		pf := prompt.NewPromptFile("001-x.md", prompt.Frontmatter{}, []byte(body), nil)
+		Expect(pf.InstructionLength()).To(Equal(expected))
+	},
+	Entry("empty body", "", 0),
+	Entry("heading only", "# Add the feature\n\n", 0),
+	Entry("heading and text", "# Add the feature\n\nAdd the flag.\n", 13),
+	Entry("text without heading", "Add the flag.\n", 13),
+	Entry("only the first heading is dropped", "# One\n# Two\n", 5),
+	Entry("heading inside a fence is kept", "```\n# not a heading\n```\n", 23),
+	Entry("multibyte characters count once", "# Titel\n\nÄnderung\n", 8),
+)
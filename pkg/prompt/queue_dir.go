@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lifecycleDirNames are the directory names of the prompt lifecycle layout. They hold
+// prompts in other states (or logs) and are never part of a queue.
+var lifecycleDirNames = map[string]bool{
+	"inbox":       true,
+	"in-progress": true,
+	"completed":   true,
+	"rejected":    true,
+	"cancelled":   true,
+	"log":         true,
+	"ideas":       true,
+}
+
+// IsQueueSubdir reports whether a subdirectory of a prompts directory is scanned
+// for prompts. completed/, log/, the other lifecycle directories and hidden
+// directories are not.
+func IsQueueSubdir(name string) bool {
+	return !lifecycleDirNames[name] && !strings.HasPrefix(name, ".")
+}
+
+// promptDirEntry is a .md file found in a prompts directory or one of its subdirectories.
+type promptDirEntry struct {
+	os.DirEntry
+	dir string
+}
+
+// path returns the full path of the entry.
+func (e promptDirEntry) path() string {
+	return filepath.Join(e.dir, e.Name())
+}
+
+// readQueueDir returns the .md files of dir and, recursively, of its queue
+// subdirectories (see IsQueueSubdir). The error of reading dir itself is returned
+// unchanged; unreadable subdirectories are skipped.
+func readQueueDir(dir string) ([]promptDirEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []promptDirEntry
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if !IsQueueSubdir(entry.Name()) {
+				continue
+			}
+			nested, err := readQueueDir(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				slog.Debug("skipping unreadable prompt subdirectory", "dir", entry.Name(), "error", err)
+				continue
+			}
+			result = append(result, nested...)
+			continue
+		}
+		if strings.HasSuffix(entry.Name(), ".md") {
+			result = append(result, promptDirEntry{DirEntry: entry, dir: dir})
+		}
+	}
+	return result, nil
+}
+
+// ListQueueFiles returns the paths of the .md files in dir and its queue subdirectories.
+func ListQueueFiles(dir string) ([]string, error) {
+	entries, err := readQueueDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.path()
+	}
+	return paths, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Queue subdirectories", func() {
+	var (
+		ctx       context.Context
+		tempDir   string
+		nestedDir string
+		manager   *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		nestedDir = filepath.Join(tempDir, "feature", "api")
+		Expect(os.MkdirAll(nestedDir, 0750)).To(Succeed())
+		manager = prompt.NewManager(
+			"",
+			tempDir,
+			"",
+			"",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	Describe("IsQueueSubdir", func() {
+		It("excludes lifecycle and hidden directories", func() {
+			Expect(prompt.IsQueueSubdir("feature")).To(BeTrue())
+			Expect(prompt.IsQueueSubdir("completed")).To(BeFalse())
+			Expect(prompt.IsQueueSubdir("log")).To(BeFalse())
+			Expect(prompt.IsQueueSubdir("inbox")).To(BeFalse())
+			Expect(prompt.IsQueueSubdir(".git")).To(BeFalse())
+		})
+	})
+
+	Describe("ListQueued", func() {
+		It("picks up prompts in nested folders", func() {
+			createPromptFile(tempDir, "001-top.md", "approved")
+			createPromptFile(nestedDir, "002-nested.md", "approved")
+
+			prompts, err := manager.ListQueued(ctx)
+			Expect(err).To(BeNil())
+			Expect(prompts).To(HaveLen(2))
+			Expect(prompts[1].Path).To(Equal(filepath.Join(nestedDir, "002-nested.md")))
+		})
+
+		It("ignores completed/ and log/", func() {
+			for _, name := range []string{"completed", "log"} {
+				dir := filepath.Join(tempDir, name)
+				Expect(os.MkdirAll(dir, 0750)).To(Succeed())
+				createPromptFile(dir, "001-skipped.md", "approved")
+			}
+
+			prompts, err := manager.ListQueued(ctx)
+			Expect(err).To(BeNil())
+			Expect(prompts).To(BeEmpty())
+		})
+	})
+
+	Describe("NormalizeFilenames", func() {
+		It("numbers nested prompts across the tree and keeps them in their folder", func() {
+			createPromptFile(tempDir, "001-top.md", "approved")
+			createPromptFile(nestedDir, "nested.md", "approved")
+
+			renames, err := manager.NormalizeFilenames(ctx, tempDir)
+			Expect(err).To(BeNil())
+			Expect(renames).To(HaveLen(1))
+			Expect(renames[0].NewPath).To(Equal(filepath.Join(nestedDir, "002-nested.md")))
+			Expect(filepath.Join(nestedDir, "002-nested.md")).To(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bborbe/collection"
+	"github.com/bborbe/errors"
+	"github.com/bborbe/validation"
+)
+
+const (
+	// QueueOrderNumber picks queued prompts in filename (= prompt number) order.
+	QueueOrderNumber QueueOrder = "number"
+	// QueueOrderMtime picks the oldest queued file first (modification time ascending).
+	QueueOrderMtime QueueOrder = "mtime"
+	// QueueOrderPriority picks the highest frontmatter priority first, then by filename.
+	// It is the default order; without priorities it is filename order.
+	QueueOrderPriority QueueOrder = "priority"
+)
+
+// AvailableQueueOrders contains all valid queue order values.
+var AvailableQueueOrders = QueueOrders{QueueOrderNumber, QueueOrderMtime, QueueOrderPriority}
+
+// QueueOrder selects the order in which ListQueued returns queued prompts.
+type QueueOrder string
+
+// String returns the string representation of the QueueOrder.
+func (q QueueOrder) String() string {
+	return string(q)
+}
+
+// Validate checks that the QueueOrder is a known value.
+func (q QueueOrder) Validate(ctx context.Context) error {
+	// Empty string is valid — means the field was not set and priority order applies.
+	if q == "" {
+		return nil
+	}
+	if !AvailableQueueOrders.Contains(q) {
+		validValues := make([]string, len(AvailableQueueOrders))
+		for i, v := range AvailableQueueOrders {
+			validValues[i] = string(v)
+		}
+		return errors.Wrapf(
+			ctx,
+			validation.Error,
+			"unknown queue order %q, valid values: %s",
+			q,
+			strings.Join(validValues, ", "),
+		)
+	}
+	return nil
+}
+
+// QueueOrders is a collection of QueueOrder values.
+type QueueOrders []QueueOrder
+
+func (q QueueOrders) Contains(order QueueOrder) bool {
+	return collection.Contains(q, order)
+}
+
+// ManagerOption is a functional option for configuring a Manager.
+type ManagerOption func(*Manager)
+
+// WithQueueOrder sets the order in which ListQueued returns queued prompts.
+func WithQueueOrder(order QueueOrder) ManagerOption {
+	return func(m *Manager) {
+		if order != "" {
+			m.queueOrder = order
+		}
+	}
+}
+
+// queuedEntry carries the sort keys of a queued prompt.
+type queuedEntry struct {
+	prompt   Prompt
+	modTime  time.Time
+	priority int
+	tags     []string
+}
+
+// sortQueued sorts entries according to order. Ties always fall back to filename
+// so the result is deterministic regardless of directory read order.
+func sortQueued(entries []queuedEntry, order QueueOrder) {
+	byName := func(i, j int) bool {
+		return filepath.Base(entries[i].prompt.Path) < filepath.Base(entries[j].prompt.Path)
+	}
+	switch order {
+	case QueueOrderMtime:
+		sort.Slice(entries, func(i, j int) bool {
+			if !entries[i].modTime.Equal(entries[j].modTime) {
+				return entries[i].modTime.Before(entries[j].modTime)
+			}
+			return byName(i, j)
+		})
+	case QueueOrderNumber:
+		sort.Slice(entries, byName)
+	default:
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].priority != entries[j].priority {
+				return entries[i].priority > entries[j].priority
+			}
+			return byName(i, j)
+		})
+	}
+}
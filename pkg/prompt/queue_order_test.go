@@ -0,0 +1,213 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("QueueOrder", func() {
+	var (
+		ctx     context.Context
+		tempDir string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		tempDir, err = os.MkdirTemp("", "queue-order-test-*")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	listNames := func(order prompt.QueueOrder) []string {
+		prompts, err := prompt.NewManager(
+			"",
+			tempDir,
+			"",
+			"",
+			nil,
+			libtime.NewCurrentDateTime(),
+			prompt.WithQueueOrder(order),
+		).ListQueued(ctx)
+		Expect(err).To(BeNil())
+		names := make([]string, len(prompts))
+		for i, p := range prompts {
+			names[i] = filepath.Base(p.Path)
+		}
+		return names
+	}
+
+	touch := func(name string, modTime time.Time) {
+		path := createPromptFile(tempDir, name, "approved")
+		Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+	}
+
+	Describe("Validate", func() {
+		It("accepts empty", func() {
+			Expect(prompt.QueueOrder("").Validate(ctx)).To(Succeed())
+		})
+
+		It("accepts all available values", func() {
+			for _, order := range prompt.AvailableQueueOrders {
+				Expect(order.Validate(ctx)).To(Succeed())
+			}
+		})
+
+		It("rejects unknown values", func() {
+			err := prompt.QueueOrder("random").Validate(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown queue order"))
+		})
+	})
+
+	Describe("ListQueued", func() {
+		var base time.Time
+
+		BeforeEach(func() {
+			base = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			// Created in a known sequence: 003 first, then 001, then 002.
+			touch("003-third.md", base)
+			touch("001-first.md", base.Add(time.Minute))
+			touch("002-second.md", base.Add(2*time.Minute))
+		})
+
+		It("sorts by filename with number order", func() {
+			Expect(listNames(prompt.QueueOrderNumber)).To(Equal([]string{
+				"001-first.md",
+				"002-second.md",
+				"003-third.md",
+			}))
+		})
+
+		It("defaults to filename order when no prompt has a priority", func() {
+			Expect(listNames("")).To(Equal([]string{
+				"001-first.md",
+				"002-second.md",
+				"003-third.md",
+			}))
+		})
+
+		It("sorts oldest file first with mtime order", func() {
+			Expect(listNames(prompt.QueueOrderMtime)).To(Equal([]string{
+				"003-third.md",
+				"001-first.md",
+				"002-second.md",
+			}))
+		})
+
+		It("breaks mtime ties by filename", func() {
+			touch("000-tie.md", base)
+
+			Expect(listNames(prompt.QueueOrderMtime)).To(Equal([]string{
+				"000-tie.md",
+				"003-third.md",
+				"001-first.md",
+				"002-second.md",
+			}))
+		})
+
+		It("sorts a mixed-priority queue by priority then filename", func() {
+			write := func(name string, priority int) {
+				content := fmt.Sprintf("---\nstatus: approved\npriority: %d\n---\n\n# P\n", priority)
+				Expect(
+					os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600),
+				).To(Succeed())
+			}
+			write("004-low.md", -1)
+			write("005-high.md", 10)
+			write("006-tie-high.md", 10)
+
+			Expect(listNames(prompt.QueueOrderPriority)).To(Equal([]string{
+				"005-high.md",
+				"006-tie-high.md",
+				"001-first.md",
+				"002-second.md",
+				"003-third.md",
+				"004-low.md",
+			}))
+		})
+
+		It("ignores priority with number order", func() {
+			content := "---\nstatus: approved\npriority: 10\n---\n\n# Urgent\n"
+			Expect(
+				os.WriteFile(filepath.Join(tempDir, "004-urgent.md"), []byte(content), 0600),
+			).To(Succeed())
+
+			Expect(listNames(prompt.QueueOrderNumber)).To(Equal([]string{
+				"001-first.md",
+				"002-second.md",
+				"003-third.md",
+				"004-urgent.md",
+			}))
+		})
+
+		It("sorts by priority then filename when no option is given", func() {
+			content := "---\nstatus: approved\npriority: 10\n---\n\n# Urgent\n"
+			Expect(
+				os.WriteFile(filepath.Join(tempDir, "004-urgent.md"), []byte(content), 0600),
+			).To(Succeed())
+
+			Expect(listNames("")).To(Equal([]string{
+				"004-urgent.md",
+				"001-first.md",
+				"002-second.md",
+				"003-third.md",
+			}))
+		})
+
+		It("moves a prompt ahead after SetPriority with priority order", func() {
+			completedDir := filepath.Join(tempDir, "completed")
+			Expect(os.MkdirAll(completedDir, 0750)).To(Succeed())
+			manager := prompt.NewManager(
+				"",
+				tempDir,
+				completedDir,
+				"",
+				nil,
+				libtime.NewCurrentDateTime(),
+				prompt.WithQueueOrder(prompt.QueueOrderPriority),
+			)
+			path := filepath.Join(tempDir, "003-third.md")
+
+			Expect(manager.SetPriority(ctx, path, 10)).To(Succeed())
+
+			pf, err := manager.Load(ctx, path)
+			Expect(err).To(BeNil())
+			Expect(pf.Frontmatter.Priority).To(Equal(10))
+			Expect(listNames(prompt.QueueOrderPriority)[0]).To(Equal("003-third.md"))
+			// The ordering guard still uses the numeric prefix, not the queue position.
+			Expect(manager.AllPreviousCompleted(ctx, 3)).To(BeFalse())
+			Expect(manager.FindMissingCompleted(ctx, 3)).To(Equal([]int{1, 2}))
+		})
+
+		It("sorts by priority descending then filename with priority order", func() {
+			content := "---\nstatus: approved\npriority: 5\n---\n\n# Urgent\n"
+			Expect(
+				os.WriteFile(filepath.Join(tempDir, "004-urgent.md"), []byte(content), 0600),
+			).To(Succeed())
+
+			Expect(listNames(prompt.QueueOrderPriority)).To(Equal([]string{
+				"004-urgent.md",
+				"001-first.md",
+				"002-second.md",
+				"003-third.md",
+			}))
+		})
+	})
+})
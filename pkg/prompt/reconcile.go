@@ -0,0 +1,214 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+)
+
+// ReconcileAction describes how Reconcile fixed a status/location mismatch.
+type ReconcileAction string
+
+const (
+	// ReconcileActionSetCompleted marks a file in completed/ as completed.
+	ReconcileActionSetCompleted ReconcileAction = "set-completed"
+	// ReconcileActionMoveToCompleted moves a queue file marked completed into completed/.
+	ReconcileActionMoveToCompleted ReconcileAction = "move-to-completed"
+	// ReconcileActionSetCancelled marks a file in cancelled/ as cancelled.
+	ReconcileActionSetCancelled ReconcileAction = "set-cancelled"
+)
+
+// ReconcileChange records a single fix applied by Reconcile.
+type ReconcileChange struct {
+	Path       string
+	FromStatus string
+	Action     ReconcileAction
+}
+
+// Reconcile scans the queue, completed and cancelled directories and fixes
+// files whose frontmatter status does not match their location:
+//   - completed/ files with a status other than completed or rejected are set to completed
+//   - queue files with status completed are moved to completed/
+//   - cancelled/ files with a status other than cancelled are set to cancelled
+//
+// Every applied fix is returned so the caller can report it.
+func (pm *Manager) Reconcile(ctx context.Context) ([]ReconcileChange, error) {
+	return reconcile(
+		ctx,
+		pm.inProgressDir,
+		pm.completedDir,
+		pm.cancelledDir,
+		pm.completedLayout,
+		pm.mover,
+		pm.currentDateTimeGetter,
+	)
+}
+
+func reconcile(
+	ctx context.Context,
+	inProgressDir string,
+	completedDir string,
+	cancelledDir string,
+	completedLayout CompletedLayout,
+	mover FileMover,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ([]ReconcileChange, error) {
+	var changes []ReconcileChange
+
+	completedChanges, err := reconcileDir(
+		ctx,
+		ListCompletedFiles,
+		completedDir,
+		PromptStatuses{CompletedPromptStatus, RejectedPromptStatus},
+		ReconcileActionSetCompleted,
+		(*PromptFile).MarkCompleted,
+		currentDateTimeGetter,
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "reconcile completed directory")
+	}
+	changes = append(changes, completedChanges...)
+
+	cancelledChanges, err := reconcileDir(
+		ctx,
+		listMarkdownFiles,
+		cancelledDir,
+		PromptStatuses{CancelledPromptStatus},
+		ReconcileActionSetCancelled,
+		(*PromptFile).MarkCancelled,
+		currentDateTimeGetter,
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "reconcile cancelled directory")
+	}
+	changes = append(changes, cancelledChanges...)
+
+	queueChanges, err := reconcileQueue(
+		ctx,
+		inProgressDir,
+		completedDir,
+		completedLayout,
+		mover,
+		currentDateTimeGetter,
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "reconcile queue directory")
+	}
+	changes = append(changes, queueChanges...)
+
+	return changes, nil
+}
+
+// reconcileDir applies mark to every prompt list returns for dir whose status is not in allowed.
+func reconcileDir(
+	ctx context.Context,
+	list func(ctx context.Context, dir string) ([]string, error),
+	dir string,
+	allowed PromptStatuses,
+	action ReconcileAction,
+	mark func(*PromptFile),
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ([]ReconcileChange, error) {
+	paths, err := list(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []ReconcileChange
+	for _, path := range paths {
+		pf, err := load(ctx, path, currentDateTimeGetter)
+		if err != nil {
+			slog.Warn("skipping prompt in reconcile", "file", filepath.Base(path), "error", err)
+			continue
+		}
+		if allowed.Contains(PromptStatus(pf.Frontmatter.Status)) {
+			continue
+		}
+		fromStatus := pf.Frontmatter.Status
+		mark(pf)
+		if err := pf.Save(ctx); err != nil {
+			return nil, errors.Wrap(ctx, err, "save prompt")
+		}
+		changes = append(changes, ReconcileChange{
+			Path:       path,
+			FromStatus: fromStatus,
+			Action:     action,
+		})
+	}
+	return changes, nil
+}
+
+// reconcileQueue moves queue files marked completed into completedDir.
+func reconcileQueue(
+	ctx context.Context,
+	inProgressDir string,
+	completedDir string,
+	completedLayout CompletedLayout,
+	mover FileMover,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ([]ReconcileChange, error) {
+	paths, err := listMarkdownFiles(ctx, inProgressDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []ReconcileChange
+	for _, path := range paths {
+		fm, err := readFrontmatter(ctx, path, currentDateTimeGetter)
+		if err != nil {
+			slog.Warn("skipping prompt in reconcile", "file", filepath.Base(path), "error", err)
+			continue
+		}
+		if fm.Status != string(CompletedPromptStatus) {
+			continue
+		}
+		dest, err := moveToCompleted(
+			ctx,
+			path,
+			completedDir,
+			completedLayout,
+			mover,
+			currentDateTimeGetter,
+		)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, "move to completed")
+		}
+		changes = append(changes, ReconcileChange{
+			Path:       dest,
+			FromStatus: fm.Status,
+			Action:     ReconcileActionMoveToCompleted,
+		})
+	}
+	return changes, nil
+}
+
+// listMarkdownFiles returns the .md files in dir. A missing dir yields no files.
+func listMarkdownFiles(ctx context.Context, dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, "read directory")
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	return paths, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Reconcile", func() {
+	var (
+		ctx          context.Context
+		tempDir      string
+		queueDir     string
+		completedDir string
+		cancelledDir string
+		manager      *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		tempDir, err = os.MkdirTemp("", "reconcile-test-*")
+		Expect(err).To(BeNil())
+		queueDir = filepath.Join(tempDir, "in-progress")
+		completedDir = filepath.Join(tempDir, "completed")
+		cancelledDir = filepath.Join(tempDir, "cancelled")
+		for _, dir := range []string{queueDir, completedDir, cancelledDir} {
+			Expect(os.MkdirAll(dir, 0750)).To(Succeed())
+		}
+		manager = prompt.NewManager(
+			"",
+			queueDir,
+			completedDir,
+			cancelledDir,
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("returns no changes when every file matches its directory", func() {
+		createPromptFile(queueDir, "003-queued.md", "approved")
+		createPromptFile(completedDir, "001-done.md", "completed")
+		createPromptFile(completedDir, "002-rejected.md", "rejected")
+		createPromptFile(cancelledDir, "004-cancelled.md", "cancelled")
+
+		changes, err := manager.Reconcile(ctx)
+		Expect(err).To(BeNil())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("sets status completed on completed/ files with another status", func() {
+		path := createPromptFile(completedDir, "001-drifted.md", "approved")
+
+		changes, err := manager.Reconcile(ctx)
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Path).To(Equal(path))
+		Expect(changes[0].FromStatus).To(Equal("approved"))
+		Expect(changes[0].Action).To(Equal(prompt.ReconcileActionSetCompleted))
+
+		pf, err := manager.Load(ctx, path)
+		Expect(err).To(BeNil())
+		Expect(pf.Frontmatter.Status).To(Equal(string(prompt.CompletedPromptStatus)))
+		Expect(pf.Frontmatter.Completed).NotTo(BeEmpty())
+	})
+
+	It("moves queue files marked completed into completed/", func() {
+		createPromptFile(queueDir, "002-finished.md", "completed")
+
+		changes, err := manager.Reconcile(ctx)
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action).To(Equal(prompt.ReconcileActionMoveToCompleted))
+		Expect(changes[0].Path).To(Equal(filepath.Join(completedDir, "002-finished.md")))
+
+		_, err = os.Stat(filepath.Join(queueDir, "002-finished.md"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		pf, err := manager.Load(ctx, filepath.Join(completedDir, "002-finished.md"))
+		Expect(err).To(BeNil())
+		Expect(pf.Frontmatter.Status).To(Equal(string(prompt.CompletedPromptStatus)))
+	})
+
+	It("sets status cancelled on cancelled/ files with another status", func() {
+		path := createPromptFile(cancelledDir, "005-stopped.md", "executing")
+
+		changes, err := manager.Reconcile(ctx)
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action).To(Equal(prompt.ReconcileActionSetCancelled))
+		Expect(changes[0].FromStatus).To(Equal("executing"))
+
+		pf, err := manager.Load(ctx, path)
+		Expect(err).To(BeNil())
+		Expect(pf.Frontmatter.Status).To(Equal(string(prompt.CancelledPromptStatus)))
+	})
+
+	It("leaves non-completed queue files in place", func() {
+		path := createPromptFile(queueDir, "006-failed.md", "failed")
+
+		changes, err := manager.Reconcile(ctx)
+		Expect(err).To(BeNil())
+		Expect(changes).To(BeEmpty())
+		_, err = os.Stat(path)
+		Expect(err).To(BeNil())
+	})
+
+	It("tolerates missing directories", func() {
+		Expect(os.RemoveAll(cancelledDir)).To(Succeed())
+
+		changes, err := manager.Reconcile(ctx)
+		Expect(err).To(BeNil())
+		Expect(changes).To(BeEmpty())
+	})
+})
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+)
+
+// Remove deletes the prompt file at path. Only queued (approved) and failed
+// prompts can be removed: an executing prompt still has a container running,
+// and completed prompts are the historical record.
+func (pm *Manager) Remove(ctx context.Context, path string) error {
+	pf, err := pm.Load(ctx, path)
+	if err != nil {
+		return errors.Wrap(ctx, err, "load prompt")
+	}
+	switch PromptStatus(pf.Frontmatter.Status) {
+	case ApprovedPromptStatus, FailedPromptStatus:
+	default:
+		return errors.Errorf(
+			ctx,
+			"cannot remove %s with status %q: only %s (queued) and %s prompts can be removed",
+			filepath.Base(path),
+			pf.Frontmatter.Status,
+			ApprovedPromptStatus,
+			FailedPromptStatus,
+		)
+	}
+	if err := os.Remove(path); err != nil {
+		return errors.Wrap(ctx, err, "remove prompt file")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Remove", func() {
+	var (
+		ctx     context.Context
+		tempDir string
+		manager *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		tempDir, err = os.MkdirTemp("", "remove-test-*")
+		Expect(err).To(BeNil())
+		manager = prompt.NewManager("", tempDir, "", "", nil, libtime.NewCurrentDateTime())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	DescribeTable("removable statuses",
+		func(status prompt.PromptStatus) {
+			path := createPromptFile(tempDir, "001-prompt.md", string(status))
+
+			Expect(manager.Remove(ctx, path)).To(Succeed())
+
+			_, err := os.Stat(path)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		},
+		Entry("approved", prompt.ApprovedPromptStatus),
+		Entry("failed", prompt.FailedPromptStatus),
+	)
+
+	DescribeTable("refused statuses",
+		func(status prompt.PromptStatus) {
+			path := createPromptFile(tempDir, "001-prompt.md", string(status))
+
+			err := manager.Remove(ctx, path)
+			Expect(err).To(MatchError(ContainSubstring("cannot remove 001-prompt.md")))
+			Expect(err).To(MatchError(ContainSubstring(string(status))))
+
+			_, err = os.Stat(path)
+			Expect(err).To(BeNil())
+		},
+		Entry("executing", prompt.ExecutingPromptStatus),
+		Entry("committing", prompt.CommittingPromptStatus),
+		Entry("completed", prompt.CompletedPromptStatus),
+		Entry("draft", prompt.DraftPromptStatus),
+	)
+
+	It("returns an error for a missing file", func() {
+		Expect(manager.Remove(ctx, tempDir+"/404-missing.md")).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+)
+
+// reorderSuffix marks a prompt file between the two rename passes of Reorder.
+// Files without the .md suffix are invisible to the queue scanner.
+const reorderSuffix = ".reorder"
+
+// Reorder renumbers the prompts in the queue directory to a contiguous sequence,
+// keeping their current number order. The sequence starts after the highest
+// completed number, so it is 001..NNN when nothing is completed yet. Completed
+// prompts are not touched and depends_on references are not rewritten. Reorder
+// refuses to run while a prompt is executing.
+func (pm *Manager) Reorder(ctx context.Context) ([]Rename, error) {
+	return reorder(ctx, pm.inProgressDir, pm.completedDir, pm.mover, pm.currentDateTimeGetter)
+}
+
+func reorder(
+	ctx context.Context,
+	dir string,
+	completedDir string,
+	mover FileMover,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) ([]Rename, error) {
+	entries, err := readQueueDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, "read directory")
+	}
+	ignored := loadIgnorePatterns(dir)
+	var files []fileInfo
+	for _, entry := range entries {
+		if ignored.Matches(entry.Name()) {
+			continue
+		}
+		fm, err := readFrontmatter(ctx, entry.path(), currentDateTimeGetter)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "read frontmatter %s", entry.Name())
+		}
+		if fm.Status == string(ExecutingPromptStatus) {
+			return nil, errors.Errorf(
+				ctx,
+				"cannot reorder while %s is executing",
+				entry.Name(),
+			)
+		}
+		info := parseFilename(entry.Name(), validPatternRegexp, numericPatternRegexp)
+		info.dir = entry.dir
+		files = append(files, info)
+	}
+	sortForReorder(files)
+
+	next, err := firstFreeNumber(ctx, completedDir)
+	if err != nil {
+		return nil, err
+	}
+	var renames []Rename
+	for _, f := range files {
+		newName := fmt.Sprintf("%03d-%s.md", next, f.slug)
+		next++
+		if newName == f.name {
+			continue
+		}
+		renames = append(renames, Rename{
+			OldPath: filepath.Join(f.dir, f.name),
+			NewPath: filepath.Join(f.dir, newName),
+		})
+	}
+
+	// Two passes so a new name never collides with a file that is not renamed yet.
+	for _, r := range renames {
+		if err := mover.MoveFile(ctx, r.OldPath, r.OldPath+reorderSuffix); err != nil {
+			return nil, errors.Wrapf(ctx, err, "move %s aside", filepath.Base(r.OldPath))
+		}
+	}
+	for _, r := range renames {
+		slog.Debug("reordering prompt", "from", filepath.Base(r.OldPath), "to", filepath.Base(r.NewPath))
+		if err := mover.MoveFile(ctx, r.OldPath+reorderSuffix, r.NewPath); err != nil {
+			return nil, errors.Wrapf(ctx, err, "rename %s", filepath.Base(r.OldPath))
+		}
+	}
+	return renames, nil
+}
+
+// sortForReorder sorts files by number, then name. Files without a number go last.
+func sortForReorder(files []fileInfo) {
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i], files[j]
+		if (a.number == -1) != (b.number == -1) {
+			return b.number == -1
+		}
+		if a.number != b.number {
+			return a.number < b.number
+		}
+		return a.name < b.name
+	})
+}
+
+// firstFreeNumber returns one above the highest number in completedDir,
+// including numbers whose files were pruned by retention.
+func firstFreeNumber(ctx context.Context, completedDir string) (int, error) {
+	completedEntries, err := readCompletedDir(completedDir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, errors.Wrap(ctx, err, "read completed directory")
+	}
+	_, completedNumbers := scanPromptFiles(inDir(completedDir, completedEntries))
+	for n := range prunedNumbers(completedDir) {
+		completedNumbers[n] = true
+	}
+	highest := 0
+	for n := range completedNumbers {
+		if n > highest {
+			highest = n
+		}
+	}
+	return highest + 1, nil
+}
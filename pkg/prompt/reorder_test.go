@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Reorder", func() {
+	var (
+		ctx          context.Context
+		tempDir      string
+		queueDir     string
+		completedDir string
+		manager      *prompt.Manager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir = GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "in-progress")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.MkdirAll(queueDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(completedDir, 0750)).To(Succeed())
+		manager = prompt.NewManager(
+			"",
+			queueDir,
+			completedDir,
+			"",
+			&simpleMover{},
+			libtime.NewCurrentDateTime(),
+		)
+	})
+
+	listNames := func(dir string) []string {
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	It("closes gaps", func() {
+		createPromptFile(queueDir, "002-second.md", "approved")
+		createPromptFile(queueDir, "005-fifth.md", "approved")
+		createPromptFile(queueDir, "009-ninth.md", "failed")
+
+		renames, err := manager.Reorder(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(renames).To(HaveLen(3))
+		Expect(listNames(queueDir)).To(Equal([]string{
+			"001-second.md",
+			"002-fifth.md",
+			"003-ninth.md",
+		}))
+	})
+
+	It("shifts prompts up without collisions", func() {
+		createPromptFile(queueDir, "001-a.md", "approved")
+		createPromptFile(queueDir, "001-b.md", "approved")
+		createPromptFile(queueDir, "002-c.md", "approved")
+		createPromptFile(queueDir, "003-d.md", "approved")
+
+		_, err := manager.Reorder(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listNames(queueDir)).To(Equal([]string{
+			"001-a.md",
+			"002-b.md",
+			"003-c.md",
+			"004-d.md",
+		}))
+	})
+
+	It("orders duplicates by name and unnumbered files last", func() {
+		createPromptFile(queueDir, "004-zeta.md", "approved")
+		createPromptFile(queueDir, "004-alpha.md", "approved")
+		createPromptFile(queueDir, "7-short.md", "approved")
+		createPromptFile(queueDir, "extra.md", "approved")
+
+		_, err := manager.Reorder(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listNames(queueDir)).To(Equal([]string{
+			"001-alpha.md",
+			"002-zeta.md",
+			"003-short.md",
+			"004-extra.md",
+		}))
+	})
+
+	It("does nothing for an already contiguous queue", func() {
+		createPromptFile(queueDir, "001-first.md", "approved")
+		createPromptFile(queueDir, "002-second.md", "approved")
+
+		renames, err := manager.Reorder(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(renames).To(BeEmpty())
+		Expect(listNames(queueDir)).To(Equal([]string{"001-first.md", "002-second.md"}))
+	})
+
+	It("continues after the highest completed number without touching completed", func() {
+		createPromptFile(completedDir, "001-done.md", "completed")
+		createPromptFile(completedDir, "003-done.md", "completed")
+		createPromptFile(queueDir, "002-queued.md", "approved")
+		createPromptFile(queueDir, "010-later.md", "approved")
+
+		_, err := manager.Reorder(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listNames(queueDir)).To(Equal([]string{"004-queued.md", "005-later.md"}))
+		Expect(listNames(completedDir)).To(Equal([]string{"001-done.md", "003-done.md"}))
+	})
+
+	It("refuses while a prompt is executing", func() {
+		createPromptFile(queueDir, "002-running.md", "executing")
+		createPromptFile(queueDir, "005-next.md", "approved")
+
+		_, err := manager.Reorder(ctx)
+		Expect(err).To(MatchError(ContainSubstring("002-running.md is executing")))
+		Expect(listNames(queueDir)).To(Equal([]string{"002-running.md", "005-next.md"}))
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// PrunedManifestFileName is the file in completed/ that lists the basenames of
+// completed prompts deleted by retention. Ordering gates and number allocation
+// treat every listed number as completed, so pruning never reopens the queue.
+const PrunedManifestFileName = ".pruned-prompts"
+
+// WithCompletedRetention deletes completed prompts older than retention on each
+// PruneCompleted call. Zero or negative retention keeps completed prompts forever.
+func WithCompletedRetention(retention time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.completedRetention = retention
+	}
+}
+
+// PruneCompleted deletes completed prompt files older than the configured retention
+// and returns their former paths. Age is taken from the `completed` frontmatter
+// timestamp, falling back to the file's mtime. Each file is recorded in the pruned
+// manifest before it is removed, so a crash between the two never loses a number.
+func (pm *Manager) PruneCompleted(ctx context.Context) ([]string, error) {
+	if pm.completedRetention <= 0 || pm.completedDir == "" {
+		return nil, nil
+	}
+	paths, err := ListCompletedFiles(ctx, pm.completedDir)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "list completed prompts")
+	}
+	cutoff := time.Time(pm.currentDateTimeGetter.Now()).Add(-pm.completedRetention)
+	var pruned []string
+	for _, path := range paths {
+		completedAt, ok := pm.completedAt(ctx, path)
+		if !ok || !completedAt.Before(cutoff) {
+			continue
+		}
+		if err := appendPrunedManifest(ctx, pm.completedDir, filepath.Base(path)); err != nil {
+			return pruned, errors.Wrap(ctx, err, "record pruned prompt")
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return pruned, errors.Wrapf(ctx, err, "delete %s", path)
+		}
+		pruned = append(pruned, path)
+	}
+	if len(pruned) > 0 {
+		slog.InfoContext(ctx, "pruned completed prompts",
+			"count", len(pruned),
+			"retention", pm.completedRetention.String(),
+		)
+	}
+	return pruned, nil
+}
+
+// completedAt returns when the prompt at path was completed.
+func (pm *Manager) completedAt(ctx context.Context, path string) (time.Time, bool) {
+	if fm, err := pm.ReadFrontmatter(ctx, path); err == nil && fm != nil && fm.Completed != "" {
+		if t, err := time.Parse(time.RFC3339, fm.Completed); err == nil {
+			return t, true
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
+
+// appendPrunedManifest adds name to the pruned manifest in completedDir.
+func appendPrunedManifest(ctx context.Context, completedDir string, name string) error {
+	path := filepath.Join(completedDir, PrunedManifestFileName)
+	// #nosec G304 -- path is the fixed manifest name inside the configured completed dir
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(ctx, err, "open pruned manifest")
+	}
+	if _, err := fmt.Fprintln(f, name); err != nil {
+		_ = f.Close()
+		return errors.Wrap(ctx, err, "write pruned manifest")
+	}
+	return f.Close()
+}
+
+// prunedNumbers returns the prompt numbers listed in the pruned manifest of completedDir.
+// A missing or unreadable manifest yields an empty set.
+func prunedNumbers(completedDir string) map[int]bool {
+	numbers := make(map[int]bool)
+	if completedDir == "" {
+		return numbers
+	}
+	// #nosec G304 -- path is the fixed manifest name inside the configured completed dir
+	f, err := os.Open(filepath.Join(completedDir, PrunedManifestFileName))
+	if err != nil {
+		return numbers
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if num := extractNumberFromFilename(strings.TrimSpace(scanner.Text())); num != -1 {
+			numbers[num] = true
+		}
+	}
+	return numbers
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("PruneCompleted", func() {
+	var (
+		ctx          context.Context
+		queueDir     string
+		completedDir string
+		now          time.Time
+		retention    time.Duration
+	)
+
+	writeCompleted := func(name string, completedAt time.Time) string {
+		path := filepath.Join(completedDir, name)
+		content := "---\nstatus: completed\ncompleted: " + completedAt.UTC().Format(time.RFC3339) +
+			"\n---\n\n# Done\n"
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	newManager := func() *prompt.Manager {
+		currentDateTime := libtime.NewCurrentDateTime()
+		currentDateTime.SetNow(libtime.DateTime(now))
+		return prompt.NewManager(
+			"", queueDir, completedDir, "",
+			&simpleMover{},
+			currentDateTime,
+			prompt.WithCompletedRetention(retention),
+		)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir := GinkgoT().TempDir()
+		queueDir = filepath.Join(tempDir, "in-progress")
+		completedDir = filepath.Join(tempDir, "completed")
+		Expect(os.Mkdir(queueDir, 0o755)).To(Succeed())
+		Expect(os.Mkdir(completedDir, 0o755)).To(Succeed())
+		now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		retention = 30 * 24 * time.Hour
+	})
+
+	It("deletes only prompts completed before the retention window", func() {
+		old := writeCompleted("001-old.md", now.Add(-31*24*time.Hour))
+		recent := writeCompleted("002-recent.md", now.Add(-29*24*time.Hour))
+
+		pruned, err := newManager().PruneCompleted(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(Equal([]string{old}))
+
+		_, err = os.Stat(old)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, err = os.Stat(recent)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifest, err := os.ReadFile(filepath.Join(completedDir, prompt.PrunedManifestFileName))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(manifest)).To(Equal("001-old.md\n"))
+	})
+
+	It("keeps everything when retention is disabled", func() {
+		old := writeCompleted("001-old.md", now.Add(-365*24*time.Hour))
+		retention = 0
+
+		pruned, err := newManager().PruneCompleted(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(BeEmpty())
+		_, err = os.Stat(old)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("falls back to mtime when the completed timestamp is missing", func() {
+		path := createPromptFile(completedDir, "001-legacy.md", "completed")
+		mtime := now.Add(-60 * 24 * time.Hour)
+		Expect(os.Chtimes(path, mtime, mtime)).To(Succeed())
+
+		pruned, err := newManager().PruneCompleted(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(Equal([]string{path}))
+	})
+
+	It("keeps the ordering gate satisfied for pruned numbers", func() {
+		writeCompleted("001-old.md", now.Add(-40*24*time.Hour))
+		writeCompleted("002-old.md", now.Add(-35*24*time.Hour))
+		writeCompleted("003-recent.md", now.Add(-1*time.Hour))
+		mgr := newManager()
+		Expect(mgr.AllPreviousCompleted(ctx, 4)).To(BeTrue())
+
+		pruned, err := mgr.PruneCompleted(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(HaveLen(2))
+
+		Expect(mgr.AllPreviousCompleted(ctx, 4)).To(BeTrue())
+		Expect(mgr.FindMissingCompleted(ctx, 4)).To(BeEmpty())
+		Expect(mgr.AllPreviousCompleted(ctx, 5)).To(BeFalse())
+		Expect(mgr.FindMissingCompleted(ctx, 5)).To(Equal([]int{4}))
+	})
+
+	It("never reuses a pruned number when normalizing", func() {
+		writeCompleted("001-old.md", now.Add(-40*24*time.Hour))
+		mgr := newManager()
+		_, err := mgr.PruneCompleted(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		createPromptFile(queueDir, "new-prompt.md", "approved")
+		renames, err := mgr.NormalizeFilenames(ctx, queueDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(renames).To(HaveLen(1))
+		Expect(filepath.Base(renames[0].NewPath)).To(Equal("002-new-prompt.md"))
+	})
+})
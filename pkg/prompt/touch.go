@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+)
+
+// Touch moves the queued prompt at path to the front of ListQueued without
+// renumbering it. With queueOrder mtime it sets the modification time one
+// second before the oldest other queued prompt; with queueOrder priority (the
+// default) it sets the priority one above the highest other queued prompt. The
+// number order is fixed by filename, so Touch returns an error there.
+func (pm *Manager) Touch(ctx context.Context, path string) error {
+	return touch(ctx, path, pm.inProgressDir, pm.queueOrder, pm.currentDateTimeGetter)
+}
+
+func touch(
+	ctx context.Context,
+	path string,
+	dir string,
+	queueOrder QueueOrder,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) error {
+	if queueOrder == QueueOrderNumber {
+		return errors.Errorf(
+			ctx,
+			"queueOrder %q sorts by filename, set queueOrder to %q or %q to bump prompts",
+			queueOrder,
+			QueueOrderPriority,
+			QueueOrderMtime,
+		)
+	}
+	queued, err := listQueued(ctx, dir, queueOrder, currentDateTimeGetter)
+	if err != nil {
+		return errors.Wrap(ctx, err, "list queued")
+	}
+	if !containsPath(queued, path) {
+		return errors.Errorf(ctx, "prompt %s is not queued", path)
+	}
+	if queueOrder == QueueOrderMtime {
+		return touchMtime(ctx, path, queued)
+	}
+	return touchPriority(ctx, path, queued, currentDateTimeGetter)
+}
+
+// touchPriority raises the priority of path above every other queued prompt.
+func touchPriority(
+	ctx context.Context,
+	path string,
+	queued []Prompt,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) error {
+	var highest *int
+	for _, q := range queued {
+		if q.Path == path {
+			continue
+		}
+		fm, err := readFrontmatter(ctx, q.Path, currentDateTimeGetter)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "read frontmatter %s", q.Path)
+		}
+		if highest == nil || fm.Priority > *highest {
+			highest = &fm.Priority
+		}
+	}
+	pf, err := load(ctx, path, currentDateTimeGetter)
+	if err != nil {
+		return errors.Wrap(ctx, err, "load prompt")
+	}
+	if highest == nil || pf.Frontmatter.Priority > *highest {
+		return nil
+	}
+	pf.SetPriority(*highest + 1)
+	if err := pf.Save(ctx); err != nil {
+		return errors.Wrap(ctx, err, "save prompt")
+	}
+	return nil
+}
+
+// touchMtime sets the modification time of path before every other queued prompt.
+func touchMtime(ctx context.Context, path string, queued []Prompt) error {
+	var oldest time.Time
+	for _, q := range queued {
+		if q.Path == path {
+			continue
+		}
+		info, err := os.Stat(q.Path)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "stat %s", q.Path)
+		}
+		if oldest.IsZero() || info.ModTime().Before(oldest) {
+			oldest = info.ModTime()
+		}
+	}
+	if oldest.IsZero() {
+		return nil
+	}
+	bumped := oldest.Add(-time.Second)
+	if err := os.Chtimes(path, bumped, bumped); err != nil {
+		return errors.Wrapf(ctx, err, "chtimes %s", path)
+	}
+	return nil
+}
+
+func containsPath(prompts []Prompt, path string) bool {
+	for _, p := range prompts {
+		if p.Path == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
+)
+
+var _ = Describe("Touch", func() {
+	var (
+		ctx     context.Context
+		tempDir string
+		base    time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		tempDir, err = os.MkdirTemp("", "touch-test-*")
+		Expect(err).To(BeNil())
+
+		base = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		for i, name := range []string{"001-first.md", "002-second.md", "003-third.md"} {
+			path := createPromptFile(tempDir, name, "approved")
+			modTime := base.Add(time.Duration(i) * time.Minute)
+			Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	newManager := func(order prompt.QueueOrder) *prompt.Manager {
+		return prompt.NewManager(
+			"",
+			tempDir,
+			"",
+			"",
+			nil,
+			libtime.NewCurrentDateTime(),
+			prompt.WithQueueOrder(order),
+		)
+	}
+
+	firstQueued := func(manager *prompt.Manager) string {
+		prompts, err := manager.ListQueued(ctx)
+		Expect(err).To(BeNil())
+		Expect(prompts).NotTo(BeEmpty())
+		return filepath.Base(prompts[0].Path)
+	}
+
+	It("sorts the touched prompt first with priority order", func() {
+		content := "---\nstatus: approved\npriority: 5\n---\n\n# Urgent\n"
+		Expect(
+			os.WriteFile(filepath.Join(tempDir, "002-second.md"), []byte(content), 0600),
+		).To(Succeed())
+		manager := newManager(prompt.QueueOrderPriority)
+
+		Expect(manager.Touch(ctx, filepath.Join(tempDir, "003-third.md"))).To(Succeed())
+
+		Expect(firstQueued(manager)).To(Equal("003-third.md"))
+		pf, err := manager.Load(ctx, filepath.Join(tempDir, "003-third.md"))
+		Expect(err).To(BeNil())
+		Expect(pf.Frontmatter.Priority).To(Equal(6))
+	})
+
+	It("sorts the touched prompt first with mtime order", func() {
+		manager := newManager(prompt.QueueOrderMtime)
+
+		Expect(manager.Touch(ctx, filepath.Join(tempDir, "003-third.md"))).To(Succeed())
+
+		Expect(firstQueued(manager)).To(Equal("003-third.md"))
+	})
+
+	It("leaves the prompt numbers unchanged", func() {
+		manager := newManager(prompt.QueueOrderMtime)
+
+		Expect(manager.Touch(ctx, filepath.Join(tempDir, "003-third.md"))).To(Succeed())
+
+		Expect(filepath.Join(tempDir, "003-third.md")).To(BeAnExistingFile())
+	})
+
+	It("fails with number order", func() {
+		manager := newManager(prompt.QueueOrderNumber)
+
+		err := manager.Touch(ctx, filepath.Join(tempDir, "003-third.md"))
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("sorts by filename"))
+	})
+
+	It("fails for a prompt that is not queued", func() {
+		path := createPromptFile(tempDir, "004-failed.md", "failed")
+		manager := newManager(prompt.QueueOrderPriority)
+
+		err := manager.Touch(ctx, path)
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not queued"))
+	})
+})
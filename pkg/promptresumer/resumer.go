@@ -16,6 +16,7 @@ import (
 
 	"github.com/bborbe/dark-factory/pkg/completionreport"
 	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/git"
 	log "github.com/bborbe/dark-factory/pkg/log"
 	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/prompt"
@@ -64,9 +65,14 @@ func NewResumer(
 	failureNotifier FailureNotifier,
 	queueDir string,
 	completedDir string,
+	completedLayout prompt.CompletedLayout,
 	logDir string,
 	projectName project.Name,
 	maxPromptDuration time.Duration,
+	// promptTrees applies the changes a reattached prompt made in its own worktree
+	// (see git.PromptTreePath) before the commit. Pass nil when prompts run in the
+	// project tree.
+	promptTrees git.Worktreer,
 ) Resumer {
 	return &resumer{
 		promptManager:             promptManager,
@@ -76,9 +82,11 @@ func NewResumer(
 		failureNotifier:           failureNotifier,
 		queueDir:                  queueDir,
 		completedDir:              completedDir,
+		completedLayout:           completedLayout,
 		logDir:                    logDir,
 		projectName:               projectName,
 		maxPromptDuration:         maxPromptDuration,
+		promptTrees:               promptTrees,
 	}
 }
 
@@ -90,25 +98,23 @@ type resumer struct {
 	failureNotifier           FailureNotifier
 	queueDir                  string
 	completedDir              string
+	completedLayout           prompt.CompletedLayout
 	logDir                    string
 	projectName               project.Name
 	maxPromptDuration         time.Duration
+	promptTrees               git.Worktreer
 }
 
 // ResumeAll scans the queue directory and reattaches to any prompts in "executing" state.
 func (r *resumer) ResumeAll(ctx context.Context) error {
-	entries, err := os.ReadDir(r.queueDir)
+	promptPaths, err := prompt.ListQueueFiles(r.queueDir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
 		}
 		return errors.Wrap(ctx, err, "read queue dir for resume")
 	}
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-		promptPath := filepath.Join(r.queueDir, entry.Name())
+	for _, promptPath := range promptPaths {
 		if err := r.resumePrompt(ctx, promptPath); err != nil {
 			return errors.Wrap(ctx, err, "resume prompt")
 		}
@@ -122,6 +128,7 @@ func (r *resumer) resumePrompt(ctx context.Context, promptPath string) error {
 	if err != nil || pf == nil {
 		return err
 	}
+	defer r.removePromptTree(ctx, executionID)
 
 	canResume, err := r.workflowExecutor.ReconstructState(ctx, baseName, pf)
 	if err != nil {
@@ -145,7 +152,11 @@ func (r *resumer) resumePrompt(ctx context.Context, promptPath string) error {
 		"container", executionID,
 	)
 
-	remainingDuration, elapsed, exceeded := r.computeReattachDuration(ctx, pf.Frontmatter.Started)
+	remainingDuration, elapsed, exceeded := r.computeReattachDuration(
+		ctx,
+		pf.Frontmatter.Started,
+		pf.Frontmatter.EffectiveTimeout(r.maxPromptDuration),
+	)
 	if exceeded {
 		return r.killTimedOutContainer(ctx, pf, executionID, elapsed)
 	}
@@ -179,7 +190,7 @@ func (r *resumer) resumePrompt(ctx context.Context, promptPath string) error {
 	}
 
 	gitCtx := context.WithoutCancel(ctx)
-	completedPath := filepath.Join(r.completedDir, filepath.Base(promptPath))
+	completedPath := pf.CompletedPath(r.completedDir, r.completedLayout, promptPath)
 
 	completionReport, err := r.completionReportValidator.Validate(ctx, logFile)
 	if err != nil {
@@ -193,9 +204,41 @@ func (r *resumer) resumePrompt(ctx context.Context, promptPath string) error {
 		}
 	}
 
+	if err := r.applyPromptTree(ctx, executionID); err != nil {
+		return err
+	}
 	return r.workflowExecutor.Complete(gitCtx, ctx, pf, title, promptPath, completedPath)
 }
 
+// applyPromptTree applies the changes of the prompt's own worktree to the project
+// tree. It is a no-op without promptTrees or when the prompt ran in the project tree.
+func (r *resumer) applyPromptTree(ctx context.Context, executionID string) error {
+	if r.promptTrees == nil {
+		return nil
+	}
+	path := git.PromptTreePath(executionID)
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	if err := r.promptTrees.ApplyChanges(ctx, path); err != nil {
+		return errors.Wrap(ctx, err, "apply prompt tree changes")
+	}
+	return nil
+}
+
+// removePromptTree removes the prompt's own worktree left behind by the previous
+// daemon, if any.
+func (r *resumer) removePromptTree(ctx context.Context, executionID string) {
+	if r.promptTrees == nil {
+		return
+	}
+	path := git.PromptTreePath(executionID)
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	_ = r.promptTrees.Remove(context.WithoutCancel(ctx), path)
+}
+
 // prepareResume loads and validates the prompt for resume, returning nil pf when the prompt
 // should not be resumed (not executing, or missing container — caller should return err).
 func (r *resumer) prepareResume(
@@ -264,13 +307,15 @@ func (r *resumer) killTimedOutContainer(
 // computeReattachDuration computes the remaining allowed run time for a reattached container.
 // Returns (remaining, elapsed, exceeded) where exceeded=true means the container has already
 // run past maxPromptDuration and should be killed without reattaching.
+// maxPromptDuration is the prompt's effective limit (its timeout frontmatter or the configured value).
 // When maxPromptDuration is 0 or started is empty, remaining equals maxPromptDuration and exceeded is false.
 func (r *resumer) computeReattachDuration(
 	ctx context.Context,
 	started string,
+	maxPromptDuration time.Duration,
 ) (time.Duration, time.Duration, bool) {
-	if r.maxPromptDuration == 0 || started == "" {
-		return r.maxPromptDuration, 0, false
+	if maxPromptDuration == 0 || started == "" {
+		return maxPromptDuration, 0, false
 	}
 	t, err := time.Parse(time.RFC3339, started)
 	if err != nil {
@@ -279,16 +324,16 @@ func (r *resumer) computeReattachDuration(
 			"started", started,
 			"error", err,
 		)
-		return r.maxPromptDuration, 0, false
+		return maxPromptDuration, 0, false
 	}
 	elapsed := time.Since(t)
-	remaining := r.maxPromptDuration - elapsed
+	remaining := maxPromptDuration - elapsed
 	if remaining <= 0 {
 		return 0, elapsed, true
 	}
 	log.From(ctx).Info("computed remaining timeout for reattach",
 		"remaining", remaining,
 		"elapsed", elapsed,
-		"max_prompt_duration", r.maxPromptDuration)
+		"max_prompt_duration", maxPromptDuration)
 	return remaining, elapsed, false
 }
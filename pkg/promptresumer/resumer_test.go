@@ -15,7 +15,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/dark-factory/mocks"
 	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/git"
 	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/promptresumer"
@@ -97,6 +99,8 @@ func (s *stubExecutor) StopAndRemoveContainer(_ context.Context, containerName s
 	s.stopContainerArg = containerName
 }
 
+func (s *stubExecutor) TailLines(_ int) []string { return nil }
+
 type stubFailureNotifier struct {
 	notifyCallCount int
 }
@@ -187,7 +191,8 @@ var _ = Describe("Resumer", func() {
 	newResumer := func(maxDur time.Duration) promptresumer.Resumer {
 		return promptresumer.NewResumer(
 			mgr, fakeExec, we, noOpValidator{}, notifier,
-			queueDir, completedDir, logDir, project.Name("test-project"), maxDur,
+			queueDir, completedDir, prompt.CompletedLayoutFlat, logDir, project.Name("test-project"), maxDur,
+			nil,
 		)
 	}
 
@@ -196,7 +201,8 @@ var _ = Describe("Resumer", func() {
 			r := promptresumer.NewResumer(
 				mgr, fakeExec, we, noOpValidator{}, notifier,
 				filepath.Join(tempDir, "nonexistent"),
-				completedDir, logDir, project.Name("test-project"), 0,
+				completedDir, prompt.CompletedLayoutFlat, logDir, project.Name("test-project"), 0,
+				nil,
 			)
 			Expect(r.ResumeAll(ctx)).To(Succeed())
 			Expect(fakeExec.reattachCallCount).To(Equal(0))
@@ -459,7 +465,8 @@ var _ = Describe("Resumer", func() {
 		It("notifies failure and returns error wrapping validate completion report", func() {
 			r := promptresumer.NewResumer(
 				mgr, fakeExec, we, errValidator{}, notifier,
-				queueDir, completedDir, logDir, project.Name("test-project"), 0,
+				queueDir, completedDir, prompt.CompletedLayoutFlat, logDir, project.Name("test-project"), 0,
+				nil,
 			)
 			err := r.ResumeAll(ctx)
 			Expect(err).To(HaveOccurred())
@@ -489,6 +496,67 @@ var _ = Describe("Resumer", func() {
 			Expect(fakeExec.reattachCallCount).To(Equal(1))
 			Expect(we.completeCallCount).To(Equal(1))
 		})
+
+		Context("with prompt trees", func() {
+			var trees *mocks.Worktreer
+
+			newTreeResumer := func() promptresumer.Resumer {
+				return promptresumer.NewResumer(
+					mgr, fakeExec, we, noOpValidator{}, notifier,
+					queueDir, completedDir, prompt.CompletedLayoutFlat, logDir,
+					project.Name("test-project"), 0, trees,
+				)
+			}
+
+			BeforeEach(func() {
+				trees = &mocks.Worktreer{}
+			})
+
+			It("applies the changes of the prompt's tree before Complete and removes it", func() {
+				treePath := git.PromptTreePath("test-project-001-success")
+				Expect(os.MkdirAll(treePath, 0750)).To(Succeed())
+				DeferCleanup(func() { _ = os.RemoveAll(treePath) })
+				appliedBeforeComplete := 0
+				we.completeFunc = func(
+					_ context.Context,
+					_ context.Context,
+					_ *prompt.PromptFile,
+					_, _, _ string,
+				) error {
+					appliedBeforeComplete = trees.ApplyChangesCallCount()
+					return nil
+				}
+
+				Expect(newTreeResumer().ResumeAll(ctx)).To(Succeed())
+
+				Expect(appliedBeforeComplete).To(Equal(1))
+				_, appliedPath := trees.ApplyChangesArgsForCall(0)
+				Expect(appliedPath).To(Equal(treePath))
+				Expect(trees.RemoveCallCount()).To(Equal(1))
+				_, removedPath := trees.RemoveArgsForCall(0)
+				Expect(removedPath).To(Equal(treePath))
+			})
+
+			It("does not complete when the changes do not apply", func() {
+				treePath := git.PromptTreePath("test-project-001-success")
+				Expect(os.MkdirAll(treePath, 0750)).To(Succeed())
+				DeferCleanup(func() { _ = os.RemoveAll(treePath) })
+				trees.ApplyChangesReturns(errSentinel)
+
+				err := newTreeResumer().ResumeAll(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("apply prompt tree changes"))
+				Expect(we.completeCallCount).To(Equal(0))
+			})
+
+			It("completes in the project tree when the prompt has no tree", func() {
+				Expect(newTreeResumer().ResumeAll(ctx)).To(Succeed())
+
+				Expect(trees.ApplyChangesCallCount()).To(Equal(0))
+				Expect(trees.RemoveCallCount()).To(Equal(0))
+				Expect(we.completeCallCount).To(Equal(1))
+			})
+		})
 	})
 
 	Context("log dir path computation", func() {
@@ -546,8 +614,10 @@ var _ = Describe("computeReattachDuration (via ResumeAll timeout path)", func()
 	newResumerWithDur := func(maxDur time.Duration) promptresumer.Resumer {
 		return promptresumer.NewResumer(
 			mgr, fakeExec, we, noOpValidator{}, &stubFailureNotifier{},
-			queueDir, filepath.Join(tempDir, "completed"), logDir,
+			queueDir, filepath.Join(tempDir, "completed"),
+			prompt.CompletedLayoutFlat, logDir,
 			project.Name("proj"), maxDur,
+			nil,
 		)
 	}
 
@@ -685,4 +755,55 @@ var _ = Describe("computeReattachDuration (via ResumeAll timeout path)", func()
 			Expect(string(content)).To(ContainSubstring("status: failed"))
 		})
 	})
+
+	Context("when exceeded timeout frontmatter within maxPromptDuration", func() {
+		It("kills container without reattaching", func() {
+			started := time.Now().Add(-30 * time.Minute).UTC().Format(time.RFC3339)
+			name := "001-prompt-timeout"
+			writeExecutingPrompt(name, started)
+			mgr.loadFunc = func(_ context.Context, p string) (*prompt.PromptFile, error) {
+				return prompt.NewPromptFile(
+					p,
+					prompt.Frontmatter{
+						Status:    string(prompt.ExecutingPromptStatus),
+						Container: "proj-" + name,
+						Started:   started,
+						Timeout:   "10m",
+					},
+					nil,
+					libtime.NewCurrentDateTime(),
+				), nil
+			}
+			r := newResumerWithDur(time.Hour)
+			Expect(r.ResumeAll(ctx)).To(Succeed())
+			Expect(fakeExec.reattachCallCount).To(Equal(0))
+			Expect(fakeExec.stopCallCount).To(Equal(1))
+		})
+	})
+
+	Context("when timeout frontmatter extends maxPromptDuration", func() {
+		It("calls Reattach without killing container", func() {
+			started := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+			name := "001-prompt-extended"
+			writeExecutingPrompt(name, started)
+			mgr.loadFunc = func(_ context.Context, p string) (*prompt.PromptFile, error) {
+				return prompt.NewPromptFile(
+					p,
+					prompt.Frontmatter{
+						Status:    string(prompt.ExecutingPromptStatus),
+						Container: "proj-" + name,
+						Started:   started,
+						Timeout:   "3h",
+					},
+					nil,
+					libtime.NewCurrentDateTime(),
+				), nil
+			}
+			fakeExec.reattachErr = errSentinel
+			r := newResumerWithDur(time.Hour)
+			_ = r.ResumeAll(ctx)
+			Expect(fakeExec.reattachCallCount).To(Equal(1))
+			Expect(fakeExec.stopCallCount).To(Equal(0))
+		})
+	})
 })
@@ -0,0 +1,7 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package promptsource fetches prompt bodies hosted outside the repository,
+// referenced by a prompt's source_url frontmatter.
+package promptsource
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package promptsource
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+const (
+	// DefaultTimeout bounds a single fetch, including reading the body.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxBytes is the largest prompt body accepted from a source URL.
+	DefaultMaxBytes = 1 << 20
+)
+
+//counterfeiter:generate -o ../../mocks/promptsource-fetcher.go --fake-name PromptSourceFetcher . Fetcher
+
+// Fetcher downloads a prompt body from a source URL.
+type Fetcher interface {
+	Fetch(ctx context.Context, sourceURL string) (string, error)
+}
+
+// NewFetcher creates a Fetcher. httpClient may be nil — http.DefaultClient is
+// then used. Each fetch is bounded by timeout and bodies larger than maxBytes
+// are rejected.
+func NewFetcher(httpClient *http.Client, timeout time.Duration, maxBytes int64) Fetcher {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &fetcher{
+		httpClient: httpClient,
+		timeout:    timeout,
+		maxBytes:   maxBytes,
+	}
+}
+
+// fetcher implements Fetcher.
+type fetcher struct {
+	httpClient *http.Client
+	timeout    time.Duration
+	maxBytes   int64
+}
+
+// Fetch GETs sourceURL and returns the response body.
+// Only http and https URLs are accepted; non-2xx responses are errors.
+func (f *fetcher) Fetch(ctx context.Context, sourceURL string) (string, error) {
+	parsed, err := url.Parse(sourceURL)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "parse source_url %q", sourceURL)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", errors.Errorf(ctx, "source_url %q must use http or https", sourceURL)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "create source request")
+	}
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "fetch %s", sourceURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", errors.Errorf(ctx, "fetch %s failed with status %d", sourceURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "read %s", sourceURL)
+	}
+	if int64(len(body)) > f.maxBytes {
+		return "", errors.Errorf(ctx, "source %s exceeds %d bytes", sourceURL, f.maxBytes)
+	}
+	return string(body), nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package promptsource_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/promptsource"
+)
+
+var _ = Describe("Fetcher", func() {
+	var (
+		ctx     context.Context
+		server  *httptest.Server
+		handler http.HandlerFunc
+		fetcher promptsource.Fetcher
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		handler = func(resp http.ResponseWriter, _ *http.Request) {
+			_, _ = resp.Write([]byte("# Remote prompt\n\nDo the thing.\n"))
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			handler(resp, req)
+		}))
+		fetcher = promptsource.NewFetcher(server.Client(), time.Second, 64)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("returns the response body", func() {
+		body, err := fetcher.Fetch(ctx, server.URL+"/prompt.md")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(Equal("# Remote prompt\n\nDo the thing.\n"))
+	})
+
+	It("fails on a non-2xx status", func() {
+		handler = func(resp http.ResponseWriter, _ *http.Request) {
+			http.Error(resp, "gone", http.StatusNotFound)
+		}
+		_, err := fetcher.Fetch(ctx, server.URL+"/prompt.md")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("status 404"))
+	})
+
+	It("fails when the body exceeds the size limit", func() {
+		handler = func(resp http.ResponseWriter, _ *http.Request) {
+			_, _ = resp.Write([]byte(strings.Repeat("x", 65)))
+		}
+		_, err := fetcher.Fetch(ctx, server.URL+"/prompt.md")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exceeds 64 bytes"))
+	})
+
+	It("fails when the server is slower than the timeout", func() {
+		handler = func(resp http.ResponseWriter, req *http.Request) {
+			select {
+			case <-req.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+		}
+		fetcher = promptsource.NewFetcher(server.Client(), 50*time.Millisecond, 64)
+		_, err := fetcher.Fetch(ctx, server.URL+"/prompt.md")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects non-http schemes", func() {
+		_, err := fetcher.Fetch(ctx, "file:///etc/passwd")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("http or https"))
+	})
+})
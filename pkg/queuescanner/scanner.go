@@ -11,14 +11,17 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bborbe/errors"
 	libtime "github.com/bborbe/time"
 
+	"github.com/bborbe/dark-factory/pkg/canary"
 	"github.com/bborbe/dark-factory/pkg/failurehandler"
 	"github.com/bborbe/dark-factory/pkg/lock"
 	log "github.com/bborbe/dark-factory/pkg/log"
+	"github.com/bborbe/dark-factory/pkg/pause"
 	"github.com/bborbe/dark-factory/pkg/preflightconditions"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/promptstate"
@@ -41,6 +44,8 @@ type PromptManager interface {
 	Load(ctx context.Context, path string) (*prompt.PromptFile, error)
 	AllPreviousCompleted(ctx context.Context, n int) bool
 	FindMissingCompleted(ctx context.Context, n int) []int
+	// MissingDependencies returns the depends_on entries that are not completed.
+	MissingDependencies(ctx context.Context, deps []string) []string
 	FindPromptStatusInProgress(ctx context.Context, number int) string
 	SetStatus(ctx context.Context, path string, status string) error
 	// Per-spec predecessor lookup (spec 092)
@@ -68,6 +73,12 @@ type scanner struct {
 	queueDir        string
 	fileLockFactory func(path string) lock.DirLock
 	lockTimeout     time.Duration
+	pauseSentinel   pause.Sentinel
+	canaryGate      canary.Gate
+	shutdown        <-chan struct{}
+	// paused remembers whether the last check saw the pause sentinel so
+	// "queue paused" / "queue resumed" are logged once per transition.
+	paused bool
 	// blockedMsgKeys tracks which (file|spec|reason|missing) tuples have
 	// already been logged in the current run. Replaces the single-slot
 	// lastBlockedMsg field that could only dedupe one blocked spec at a
@@ -76,6 +87,29 @@ type scanner struct {
 	// entries are removed when the corresponding blocker resolves.
 	blockedMsgKeys map[string]struct{}
 	skippedPrompts map[string]libtime.DateTime // filename → mod time when skipped
+	// mu guards inFlight, the paths currently handed to the processor. A scan
+	// started while another one is still processing (startup scan racing a
+	// watcher wakeup) skips those paths instead of running them twice.
+	mu       sync.Mutex
+	inFlight map[string]struct{}
+	// concurrency bounds how many prompts a scan runs side by side.
+	concurrency int
+	// strictOrdering requires the lower-numbered predecessors of a prompt to be
+	// completed before it starts. When false the first queued prompt runs.
+	strictOrdering bool
+	// dirLocks holds the status-directory locks taken for in-flight prompts.
+	// Concurrent workers share one flock per directory — a second flock on
+	// the same directory would block on the first — so an external `prompt
+	// reject` stays excluded until the last worker is done. Guarded by mu.
+	dirLocks map[string]*sharedDirLock
+	// outcomeMu serializes failure handling and canary observation between workers.
+	outcomeMu sync.Mutex
+}
+
+// sharedDirLock is a status-directory lock with the number of prompts holding it.
+type sharedDirLock struct {
+	lock    lock.DirLock
+	holders int
 }
 
 // NewScanner creates a new Scanner.
@@ -86,7 +120,12 @@ type scanner struct {
 // "concurrent-reject-advance") cannot interleave its own save/rename with
 // our processing. lockTimeout may be zero — it defaults to 5 seconds; on
 // timeout the advance emits the `project-lock-timeout` blocked reason and
-// re-polls on the next cycle.
+// re-polls on the next cycle. pauseSentinel may be nil — the pause check
+// is then disabled. canaryGate may be nil — the canary gate is then disabled.
+// concurrency bounds how many prompts with completed predecessors run in
+// parallel; 0 and 1 process one prompt at a time. strictOrdering false drops the
+// predecessor guards, so a failed or missing earlier prompt blocks nothing;
+// depends_on frontmatter is still honoured.
 func NewScanner(
 	promptManager PromptManager,
 	promptProcessor PromptProcessor,
@@ -94,6 +133,12 @@ func NewScanner(
 	queueDir string,
 	fileLockFactory func(path string) lock.DirLock,
 	lockTimeout time.Duration,
+	pauseSentinel pause.Sentinel,
+	canaryGate canary.Gate,
+	// shutdown stops the scan before the next prompt once it fired. Pass nil to disable.
+	shutdown <-chan struct{},
+	concurrency int,
+	strictOrdering bool,
 ) Scanner {
 	if fileLockFactory == nil {
 		fileLockFactory = lock.NewDirLock
@@ -108,8 +153,15 @@ func NewScanner(
 		queueDir:        queueDir,
 		fileLockFactory: fileLockFactory,
 		lockTimeout:     lockTimeout,
+		pauseSentinel:   pauseSentinel,
+		canaryGate:      canaryGate,
+		shutdown:        shutdown,
 		blockedMsgKeys:  make(map[string]struct{}),
 		skippedPrompts:  make(map[string]libtime.DateTime),
+		inFlight:        make(map[string]struct{}),
+		concurrency:     concurrency,
+		strictOrdering:  strictOrdering,
+		dirLocks:        make(map[string]*sharedDirLock),
 	}
 }
 
@@ -120,18 +172,20 @@ func (s *scanner) ClearSkippedCache() {
 
 // ScanAndProcess scans for and processes any existing queued prompts.
 // Returns the count of prompts successfully processed and any fatal error.
+// With a concurrency above 1 up to that many ready prompts run side by side.
 func (s *scanner) ScanAndProcess(ctx context.Context) (int, error) {
 	if s.HasPendingVerification(ctx) {
 		log.From(ctx).Info("queue blocked: prompt pending verification")
 		return 0, nil
 	}
+	if s.concurrency > 1 {
+		return s.scanConcurrently(ctx)
+	}
 
 	completed := 0
 	for {
-		select {
-		case <-ctx.Done():
+		if s.stopRequested(ctx) {
 			return completed, nil
-		default:
 		}
 
 		done, processed, err := s.processSingleQueued(ctx)
@@ -151,48 +205,199 @@ func (s *scanner) ScanAndProcess(ctx context.Context) (int, error) {
 	}
 }
 
+// workerResult is what a concurrent worker reports back to the dispatch loop.
+type workerResult struct {
+	processed bool
+	err       error
+}
+
+// scanConcurrently is the worker-pool variant of ScanAndProcess. The calling
+// goroutine stays the only one selecting candidates, so the predecessor guards
+// see every prompt a worker still runs as not completed: a prompt never starts
+// before its lower-numbered siblings are done, while prompts of unrelated specs
+// run in parallel. Returns once the queue has nothing more to start and all
+// workers are finished; a queue whose only candidates were skipped is retried on
+// the next poll cycle rather than rescanned in a loop.
+func (s *scanner) scanConcurrently(ctx context.Context) (int, error) {
+	finished := make(chan workerResult, s.concurrency)
+	running := 0
+	completed := 0
+	var firstErr error
+	for {
+		if firstErr == nil && running < s.concurrency && !s.stopRequested(ctx) {
+			pr, specID, _, err := s.nextCandidate(ctx)
+			if err != nil {
+				firstErr = err
+			} else if pr.Path != "" {
+				release, stop := s.prepareCandidate(ctx, &pr, specID)
+				if release != nil {
+					running++
+					go func() {
+						processed, err := s.processCandidate(ctx, pr)
+						release()
+						finished <- workerResult{processed: processed, err: err}
+					}()
+					continue
+				}
+				if !stop {
+					continue
+				}
+			}
+		}
+		if running == 0 {
+			return completed, firstErr
+		}
+		// Wait for a worker: its completion may unblock the next prompt.
+		result := <-finished
+		running--
+		if result.processed {
+			completed++
+		}
+		if result.err != nil && firstErr == nil {
+			firstErr = result.err
+		}
+	}
+}
+
+// stopRequested reports whether the scan must not start another prompt.
+// Checked before every prompt so a pause or shutdown issued mid-scan lets the
+// running prompt finish but starts nothing new.
+func (s *scanner) stopRequested(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-s.shutdown:
+		return true
+	default:
+	}
+	return s.isPaused(ctx)
+}
+
+// isPaused reports whether the pause sentinel is present and logs the
+// paused/resumed transitions once.
+func (s *scanner) isPaused(ctx context.Context) bool {
+	if s.pauseSentinel == nil {
+		return false
+	}
+	paused := s.pauseSentinel.IsPaused()
+	if paused && !s.paused {
+		log.From(ctx).Info("queue paused, waiting for resume")
+	}
+	if !paused && s.paused {
+		log.From(ctx).Info("queue resumed")
+	}
+	s.paused = paused
+	return paused
+}
+
 // processSingleQueued picks the next queued prompt and processes it.
 // Returns done=true when the scan loop should stop (queue empty, blocked,
 // lock timeout, or preflight broken). done=false continues scanning for the
 // next prompt; processed reports whether a prompt was genuinely processed
 // (skips and failures continue the scan with processed=false). A non-nil
 // error requires the daemon to stop.
-//
-//nolint:gocognit,funlen // per-spec filter loop + multi-stage guard checks + log gating + lock acquire/re-read path (spec 092); refactor candidate tracked separately
 func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
+	pr, specID, done, err := s.nextCandidate(ctx)
+	if err != nil || pr.Path == "" {
+		return done, false, err
+	}
+	release, stop := s.prepareCandidate(ctx, &pr, specID)
+	if release == nil {
+		return stop, false, nil
+	}
+	defer release()
+	processed, err := s.processCandidate(ctx, pr)
+	if err != nil {
+		return true, false, err
+	}
+	return false, processed, nil
+}
+
+// nextCandidate returns the first queued prompt whose predecessors are
+// completed, together with its spec id. An empty prompt path means no
+// candidate is ready; done then reports whether the scan should stop
+// (false when a skipped candidate warrants another pass).
+//
+//nolint:gocognit,funlen // per-spec filter loop + multi-stage guard checks + log gating (spec 092); refactor candidate tracked separately
+func (s *scanner) nextCandidate(ctx context.Context) (prompt.Prompt, string, bool, error) {
 	queued, err := s.promptManager.ListQueued(ctx)
 	if err != nil {
-		return true, false, errors.Wrap(ctx, err, "list queued prompts")
+		return prompt.Prompt{}, "", true, errors.Wrap(ctx, err, "list queued prompts")
 	}
 
 	if len(queued) == 0 {
 		log.From(ctx).Debug("queue scan complete", "queued_count", 0)
-		return true, false, nil
+		return prompt.Prompt{}, "", true, nil
 	}
 
-	log.From(ctx).Debug("queue scan complete", "queued_count", len(queued))
+	log.From(ctx).Debug(
+		"queue scan complete",
+		"queued_count", len(queued),
+		"queued", queuedNames(queued),
+	)
 
-	// Determinism: ListQueued already returns entries sorted alphabetically by
-	// filename (pkg/prompt/prompt.go:1021). For prompts with a fixed-width
-	// numeric prefix this corresponds to numeric order, so the alphabetic
-	// order resolves cross-spec ties by lowest global prompt number.
+	// Determinism: ListQueued already returns entries sorted per the
+	// configured queueOrder (filename by default, see pkg/prompt/queue_order.go).
+	// For prompts with a fixed-width numeric prefix filename order corresponds
+	// to numeric order, so it resolves cross-spec ties by lowest global prompt
+	// number; mtime and priority orders fall back to filename on ties.
 
 	var pr prompt.Prompt
 	var selectedSpecID string
 	skipped := false
 	for _, candidate := range queued {
 		if err := s.autoSetQueuedStatus(ctx, &candidate); err != nil {
-			return true, false, errors.Wrap(ctx, err, "auto-set queued status")
+			return prompt.Prompt{}, "", true, errors.Wrap(ctx, err, "auto-set queued status")
+		}
+		if s.isInFlight(candidate.Path) {
+			log.From(ctx).Debug(
+				"prompt already in flight, skipping",
+				"prompt_id", filepath.Base(candidate.Path),
+			)
+			continue
 		}
 		if s.shouldSkipPrompt(ctx, candidate) {
 			skipped = true
 			continue
 		}
-		specID, err := s.readSpecID(ctx, candidate)
+		// A Load error leaves pf nil; readSpecID then falls back to the
+		// legacy global guard.
+		pf, err := s.promptManager.Load(ctx, candidate.Path)
+		if err != nil {
+			pf = nil
+		}
+		if s.isDeferred(ctx, candidate, pf) {
+			// Retry backoff pending — not ready yet. Not marked skipped so
+			// the scan ends and the next poll cycle re-checks not_before.
+			continue
+		}
+		specID, err := readSpecID(ctx, pf)
 		if err != nil {
 			// Malformed prompt frontmatter — treat as blocked, surface via logBlockedOnce
 			s.logBlockedOnce(ctx, candidate, "", prompt.ReasonPromptFrontmatterParseError, "")
-			return true, false, nil
+			return prompt.Prompt{}, "", true, nil
+		}
+		if deps := pf.DependsOn(); len(deps) > 0 {
+			// Explicit depends_on replaces the numeric predecessor guards.
+			missing := s.promptManager.MissingDependencies(ctx, deps)
+			if len(missing) == 0 {
+				pr = candidate
+				selectedSpecID = specID
+				break
+			}
+			s.logBlockedOnce(
+				ctx,
+				candidate,
+				specID,
+				prompt.ReasonDependencyNotCompleted,
+				strings.Join(missing, ","),
+			)
+			continue
+		}
+		if !s.strictOrdering {
+			pr = candidate
+			selectedSpecID = specID
+			break
 		}
 		if specID == "" {
 			// No spec field — fall back to global guard. Prompts without a spec
@@ -202,6 +407,10 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 				selectedSpecID = specID
 				break
 			}
+			log.From(ctx).Debug(
+				"skipping prompt, lower-numbered prompts not completed",
+				"prompt_id", filepath.Base(candidate.Path),
+			)
 			continue
 		}
 		if s.promptManager.AllPreviousInSpecCompleted(ctx, candidate.Number(), specID) {
@@ -231,7 +440,28 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 	if pr.Path == "" {
 		// If at least one candidate was skipped, re-poll to allow other prompts
 		// to be picked up on the next cycle. Otherwise no candidate is ready.
-		return !skipped, false, nil
+		return prompt.Prompt{}, "", !skipped, nil
+	}
+	return pr, selectedSpecID, false, nil
+}
+
+// prepareCandidate claims pr, takes the status-directory lock and re-reads the
+// prompt. On success it returns the func releasing lock and claim once the
+// prompt is processed. A nil func means the candidate must not run; stop then
+// reports whether the scan should end (claim lost or lock timeout) rather than
+// continue (stale candidate).
+//
+//nolint:funlen // lock acquire/re-read path (spec 092)
+func (s *scanner) prepareCandidate(
+	ctx context.Context,
+	pr *prompt.Prompt,
+	specID string,
+) (func(), bool) {
+	// Claim the path before anything else so a concurrent scan that listed
+	// the same prompt skips it. Released once the processor and failure
+	// handler are done — the prompt has then left the queue or been re-queued.
+	if !s.claim(pr.Path) {
+		return nil, true
 	}
 
 	// Acquire the status-directory lock right before handing the candidate
@@ -239,8 +469,8 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 	// `prompt reject` on the same file (spec 092 AC "concurrent-reject-
 	// advance"): the loser of the race observes the winner's post-lock
 	// state via the re-read below and skips the now-stale candidate.
-	fl := s.fileLockFactory(filepath.Dir(pr.Path))
-	if err := fl.Acquire(ctx, s.lockTimeout); err != nil {
+	unlock, err := s.acquireDirLock(ctx, filepath.Dir(pr.Path))
+	if err != nil {
 		// Could not take the lock in time. Surface via the existing
 		// blocked-log path with the project-lock-timeout reason so the
 		// operator sees a stable token in `dark-factory status` (this
@@ -252,12 +482,13 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 		// daemon's next poll cycle retries instead.
 		s.logBlockedOnce(
 			ctx,
-			pr,
-			selectedSpecID,
+			*pr,
+			specID,
 			prompt.ReasonProjectLockTimeout,
 			"",
 		)
-		return true, false, nil
+		s.release(pr.Path)
+		return nil, true
 	}
 
 	// Clear the dedupe entry for the prompt we are about to process so a
@@ -266,17 +497,11 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 	// successful acquire — clearing before a failed acquire would wipe the
 	// project-lock-timeout dedupe key the branch above just set.
 	s.clearBlockedKey(pr.Path)
-	defer func() {
-		if relErr := fl.Release(ctx); relErr != nil {
-			log.From(ctx).Warn(
-				"scanner: file lock release failed",
-				"prompt_id",
-				filepath.Base(pr.Path),
-				"error",
-				relErr.Error(),
-			)
-		}
-	}()
+	path := pr.Path
+	release := func() {
+		unlock()
+		s.release(path)
+	}
 	log.From(ctx).Info("lock acquired", "prompt_id", filepath.Base(pr.Path))
 
 	// Post-lock re-read. The reject command takes the same lock, so if
@@ -292,7 +517,8 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 			"prompt_id",
 			filepath.Base(pr.Path),
 		)
-		return false, false, nil
+		release()
+		return nil, false
 	}
 	if !promptstate.IsPreExecutionStatus(pf.Frontmatter.Status) {
 		log.From(ctx).Info(
@@ -302,7 +528,8 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 			"status",
 			pf.Frontmatter.Status,
 		)
-		return false, false, nil
+		release()
+		return nil, false
 	}
 	// Mirror the candidate's now-fresh status into the struct the
 	// processor will receive, so downstream code does not act on a stale
@@ -310,33 +537,141 @@ func (s *scanner) processSingleQueued(ctx context.Context) (bool, bool, error) {
 	// terminal to approved by this point, so a re-read that returns
 	// idea/draft is an operator-rolled-back state worth honoring.
 	pr.Status = promptstate.StatusFromRaw(pf.Frontmatter.Status)
+	return release, false
+}
 
-	log.From(ctx).Info("found queued prompt", "prompt_id", filepath.Base(pr.Path))
+// processCandidate hands a prepared prompt to the processor and handles its
+// outcome. processed reports a genuine completion. A non-nil error requires
+// the daemon to stop.
+func (s *scanner) processCandidate(ctx context.Context, pr prompt.Prompt) (bool, error) {
+	log.From(ctx).
+		Info("found queued prompt", log.Event(log.EventQueued), "prompt_id", filepath.Base(pr.Path))
 
 	if err := s.promptProcessor.ProcessPrompt(ctx, pr); err != nil {
 		if stderrors.Is(err, preflightconditions.ErrPreflightFailed) {
 			// Baseline is broken — propagate so the runner terminates dark-factory.
-			return false, false, err
+			return false, err
 		}
+		// Failure handling and the canary gate are not safe for concurrent
+		// use; outcomeMu serializes them between workers.
+		s.outcomeMu.Lock()
+		defer s.outcomeMu.Unlock()
 		if stopErr := s.failureHandler.Handle(ctx, pr.Path, err); stopErr != nil {
-			return true, false, stopErr
+			return false, stopErr
 		}
-		return false, false, nil // re-queued or permanently failed — keep scanning, NOT progress
+		// A failed canary pauses the queue; the isPaused check at the top of
+		// the scan loop then stops before the next prompt starts.
+		s.observeCanary(ctx, pr.Path, err)
+		return false, nil // re-queued or permanently failed — keep scanning, NOT progress
 	}
+	s.outcomeMu.Lock()
+	s.observeCanary(ctx, pr.Path, nil)
+	s.outcomeMu.Unlock()
 
 	log.From(ctx).Info("watching for queued prompts", "dir", s.queueDir)
-	return false, true, nil
+	return true, nil
 }
 
-// readSpecID loads the prompt and returns its spec id. If the frontmatter has
+// isInFlight reports whether path is currently being processed.
+func (s *scanner) isInFlight(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.inFlight[path]
+	return ok
+}
+
+// claim marks path as in flight. Returns false when it already was.
+func (s *scanner) claim(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.inFlight[path]; ok {
+		return false
+	}
+	s.inFlight[path] = struct{}{}
+	return true
+}
+
+// release removes path from the in-flight set.
+func (s *scanner) release(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inFlight, path)
+}
+
+// acquireDirLock takes the status-directory lock of dir, or joins it when a
+// prompt in flight already holds it. The returned func drops this holder and
+// releases the lock once no holder is left.
+func (s *scanner) acquireDirLock(ctx context.Context, dir string) (func(), error) {
+	s.mu.Lock()
+	held, ok := s.dirLocks[dir]
+	if ok {
+		held.holders++
+	}
+	s.mu.Unlock()
+	if !ok {
+		fl := s.fileLockFactory(dir)
+		if err := fl.Acquire(ctx, s.lockTimeout); err != nil {
+			return nil, err
+		}
+		held = &sharedDirLock{lock: fl, holders: 1}
+		s.mu.Lock()
+		s.dirLocks[dir] = held
+		s.mu.Unlock()
+	}
+	return func() {
+		s.mu.Lock()
+		held.holders--
+		last := held.holders == 0
+		if last {
+			delete(s.dirLocks, dir)
+		}
+		s.mu.Unlock()
+		if !last {
+			return
+		}
+		if err := held.lock.Release(ctx); err != nil {
+			log.From(ctx).Warn(
+				"scanner: file lock release failed",
+				"dir",
+				dir,
+				"error",
+				err.Error(),
+			)
+		}
+	}, nil
+}
+
+// observeCanary forwards a prompt outcome to the canary gate when one is configured.
+func (s *scanner) observeCanary(ctx context.Context, promptPath string, err error) {
+	if s.canaryGate == nil {
+		return
+	}
+	s.canaryGate.Observe(ctx, promptPath, err)
+}
+
+// isDeferred reports whether the prompt's not_before lies in the future
+// (a backed-off retry) and logs the deferral once.
+func (s *scanner) isDeferred(ctx context.Context, pr prompt.Prompt, pf *prompt.PromptFile) bool {
+	if pf == nil || !pf.Deferred() {
+		return false
+	}
+	s.logBlockedOnce(ctx, pr, "", prompt.ReasonRetryBackoff, "")
+	log.From(ctx).Debug(
+		"prompt deferred by retry backoff",
+		"prompt_id", filepath.Base(pr.Path),
+		"not_before", pf.Frontmatter.NotBefore,
+	)
+	return true
+}
+
+// readSpecID returns the spec id of the loaded prompt. If the frontmatter has
 // no spec field, returns ("", nil) so the scanner can fall back to the global
 // guard. If the frontmatter has more than one spec id, returns an error — the
-// spec does not define a tie-break, so we fail closed. A Load error is also
-// treated as "no spec field" so the scanner falls back to the legacy global
+// spec does not define a tie-break, so we fail closed. A nil pf (Load error) is
+// also treated as "no spec field" so the scanner falls back to the legacy global
 // guard instead of refusing to advance the queue on a transient read failure.
-func (s *scanner) readSpecID(ctx context.Context, pr prompt.Prompt) (string, error) {
-	pf, err := s.promptManager.Load(ctx, pr.Path)
-	if err != nil || pf == nil {
+func readSpecID(ctx context.Context, pf *prompt.PromptFile) (string, error) {
+	if pf == nil {
 		return "", nil
 	}
 	specs := pf.Frontmatter.Specs
@@ -353,6 +688,15 @@ func (s *scanner) readSpecID(ctx context.Context, pr prompt.Prompt) (string, err
 	return string(specs[0]), nil
 }
 
+// queuedNames returns the file names of queued for log output.
+func queuedNames(queued []prompt.Prompt) []string {
+	names := make([]string, 0, len(queued))
+	for _, pr := range queued {
+		names = append(names, filepath.Base(pr.Path))
+	}
+	return names
+}
+
 // missingStr formats a missing prompt number for log output. Empty input
 // returns "".
 func missingStr(missing int) string {
@@ -466,15 +810,12 @@ func (s *scanner) autoSetQueuedStatus(ctx context.Context, pr *prompt.Prompt) er
 
 // HasPendingVerification returns true if any prompt in queueDir has pending_verification status.
 func (s *scanner) HasPendingVerification(ctx context.Context) bool {
-	entries, err := os.ReadDir(s.queueDir)
+	paths, err := prompt.ListQueueFiles(s.queueDir)
 	if err != nil {
 		return false
 	}
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-		pf, err := s.promptManager.Load(ctx, filepath.Join(s.queueDir, entry.Name()))
+	for _, path := range paths {
+		pf, err := s.promptManager.Load(ctx, path)
 		if err != nil || pf == nil {
 			continue
 		}
@@ -24,6 +24,7 @@ import (
 	"github.com/bborbe/dark-factory/mocks"
 	"github.com/bborbe/dark-factory/pkg/cmd"
 	lockpkg "github.com/bborbe/dark-factory/pkg/lock"
+	"github.com/bborbe/dark-factory/pkg/log"
 	"github.com/bborbe/dark-factory/pkg/preflightconditions"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/queuescanner"
@@ -84,7 +85,7 @@ var _ = Describe("Scanner", func() {
 			), nil
 		}
 
-		s = queuescanner.NewScanner(mgr, pp, failureHandler, queueDir, nil, 0)
+		s = queuescanner.NewScanner(mgr, pp, failureHandler, queueDir, nil, 0, nil, nil, nil, 0, true)
 	})
 
 	AfterEach(func() {
@@ -153,6 +154,423 @@ var _ = Describe("Scanner", func() {
 			})
 		})
 
+		Context("scan started while a prompt is in flight", func() {
+			BeforeEach(func() {
+				writeFile(
+					"001-my-prompt.md",
+					"---\nstatus: approved\n---\n# Test prompt\ncontent\n",
+				)
+				pr := makeApprovedPrompt("001-my-prompt.md")
+				processed := false
+				mgr.ListQueuedStub = func(_ context.Context) ([]prompt.Prompt, error) {
+					if processed {
+						return []prompt.Prompt{}, nil
+					}
+					return []prompt.Prompt{pr}, nil
+				}
+				mgr.AllPreviousCompletedReturns(true)
+				pp.ProcessPromptStub = func(ctx context.Context, _ prompt.Prompt) error {
+					// A watcher event for the same file lands mid-execution and
+					// triggers another scan before the prompt leaves the queue.
+					writeFile(
+						"001-my-prompt.md",
+						"---\nstatus: executing\n---\n# Test prompt\ncontent\n",
+					)
+					completed, err := s.ScanAndProcess(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(completed).To(Equal(0))
+					processed = true
+					return nil
+				}
+			})
+
+			It("does not process the in-flight prompt a second time", func() {
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+			})
+
+			It("processes the prompt again once it is re-queued", func() {
+				_, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				pp.ProcessPromptStub = nil
+				mgr.ListQueuedReturnsOnCall(mgr.ListQueuedCallCount(), []prompt.Prompt{
+					makeApprovedPrompt("001-my-prompt.md"),
+				}, nil)
+				mgr.ListQueuedStub = nil
+				mgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("retry backoff", func() {
+			loadWithNotBefore := func(notBefore time.Time) {
+				mgr.LoadStub = func(
+					_ context.Context, path string,
+				) (*prompt.PromptFile, error) {
+					return prompt.NewPromptFile(
+						path,
+						prompt.Frontmatter{
+							Status:    string(prompt.ApprovedPromptStatus),
+							NotBefore: notBefore.UTC().Format(time.RFC3339),
+						},
+						[]byte("# Test\n"),
+						libtime.NewCurrentDateTime(),
+					), nil
+				}
+			}
+
+			BeforeEach(func() {
+				writeFile(
+					"001-my-prompt.md",
+					"---\nstatus: approved\n---\n# Test prompt\ncontent\n",
+				)
+				pr := makeApprovedPrompt("001-my-prompt.md")
+				mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{pr}, nil)
+				mgr.ListQueuedReturnsOnCall(1, []prompt.Prompt{}, nil)
+				mgr.AllPreviousCompletedReturns(true)
+				pp.ProcessPromptReturns(nil)
+			})
+
+			It("does not start a prompt before not_before", func() {
+				loadWithNotBefore(time.Now().Add(time.Hour))
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(0))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(0))
+				Expect(mgr.ListQueuedCallCount()).To(Equal(1))
+			})
+
+			It("starts the prompt once not_before has passed", func() {
+				loadWithNotBefore(time.Now().Add(-time.Minute))
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("pause sentinel", func() {
+			var sentinel *mocks.PauseSentinel
+
+			BeforeEach(func() {
+				writeFile(
+					"001-my-prompt.md",
+					"---\nstatus: approved\n---\n# Test prompt\ncontent\n",
+				)
+				pr := makeApprovedPrompt("001-my-prompt.md")
+				mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{pr}, nil)
+				mgr.ListQueuedReturnsOnCall(1, []prompt.Prompt{}, nil)
+				mgr.AllPreviousCompletedReturns(true)
+				pp.ProcessPromptReturns(nil)
+
+				sentinel = &mocks.PauseSentinel{}
+				s = queuescanner.NewScanner(
+					mgr, pp, failureHandler, queueDir, nil, 0, sentinel, nil, nil, 0,
+					true,
+				)
+			})
+
+			It("starts no prompt while paused", func() {
+				sentinel.IsPausedReturns(true)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(0))
+				Expect(mgr.ListQueuedCallCount()).To(Equal(0))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(0))
+			})
+
+			It("processes the queue again after resume", func() {
+				sentinel.IsPausedReturnsOnCall(0, true)
+				sentinel.IsPausedReturns(false)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(0))
+
+				completed, err = s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+			})
+
+			It("lets the running prompt finish when paused mid-scan", func() {
+				pp.ProcessPromptStub = func(_ context.Context, _ prompt.Prompt) error {
+					sentinel.IsPausedReturns(true)
+					return nil
+				}
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+				Expect(mgr.ListQueuedCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("shutdown", func() {
+			var shutdown chan struct{}
+
+			BeforeEach(func() {
+				writeFile(
+					"001-first.md",
+					"---\nstatus: approved\n---\n# First\ncontent\n",
+				)
+				writeFile(
+					"002-second.md",
+					"---\nstatus: approved\n---\n# Second\ncontent\n",
+				)
+				first := makeApprovedPrompt("001-first.md")
+				second := makeApprovedPrompt("002-second.md")
+				mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{first, second}, nil)
+				mgr.ListQueuedReturnsOnCall(1, []prompt.Prompt{second}, nil)
+				mgr.ListQueuedReturnsOnCall(2, []prompt.Prompt{}, nil)
+				mgr.AllPreviousCompletedReturns(true)
+
+				shutdown = make(chan struct{})
+				s = queuescanner.NewScanner(
+					mgr, pp, failureHandler, queueDir, nil, 0, nil, nil, shutdown,
+					0,
+					true,
+				)
+			})
+
+			It("lets the running prompt finish but starts no further prompt", func() {
+				pp.ProcessPromptStub = func(_ context.Context, _ prompt.Prompt) error {
+					close(shutdown)
+					return nil
+				}
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("concurrency", func() {
+			var (
+				stateMu   sync.Mutex
+				queued    []prompt.Prompt
+				specOf    map[string]string
+				done      map[string]bool
+				active    int
+				maxActive int
+				started   []string
+			)
+
+			// queue writes the prompts and backs the manager with a small
+			// in-memory queue: processed prompts leave it and count as
+			// completed for the per-spec predecessor guard.
+			queue := func(specByName map[string]string, names ...string) {
+				for _, name := range names {
+					path := writeFile(
+						name,
+						promptFrontmatterWithSpec(
+							prompt.ApprovedPromptStatus,
+							[]string{specByName[name]},
+						),
+					)
+					queued = append(queued, prompt.Prompt{
+						Path:   path,
+						Status: prompt.ApprovedPromptStatus,
+					})
+					specOf[path] = specByName[name]
+				}
+			}
+
+			BeforeEach(func() {
+				queued = nil
+				specOf = map[string]string{}
+				done = map[string]bool{}
+				active = 0
+				maxActive = 0
+				started = nil
+
+				mgr.ListQueuedStub = func(_ context.Context) ([]prompt.Prompt, error) {
+					stateMu.Lock()
+					defer stateMu.Unlock()
+					var result []prompt.Prompt
+					for _, pr := range queued {
+						if !done[pr.Path] {
+							result = append(result, pr)
+						}
+					}
+					return result, nil
+				}
+				mgr.LoadStub = func(_ context.Context, path string) (*prompt.PromptFile, error) {
+					stateMu.Lock()
+					defer stateMu.Unlock()
+					return prompt.NewPromptFile(
+						path,
+						prompt.Frontmatter{
+							Status: string(prompt.ApprovedPromptStatus),
+							Specs:  prompt.SpecList{specOf[path]},
+						},
+						[]byte("# Test\n"),
+						nil,
+					), nil
+				}
+				mgr.AllPreviousInSpecCompletedStub = func(
+					_ context.Context, n int, specID string,
+				) bool {
+					stateMu.Lock()
+					defer stateMu.Unlock()
+					for _, pr := range queued {
+						if pr.Number() < n && specOf[pr.Path] == specID && !done[pr.Path] {
+							return false
+						}
+					}
+					return true
+				}
+				mgr.FindMissingInSpecCompletedReturns(-1)
+
+				// Each prompt runs for a moment, so an overlap is observed
+				// whenever the scanner allows one.
+				pp.ProcessPromptStub = func(_ context.Context, pr prompt.Prompt) error {
+					stateMu.Lock()
+					active++
+					if active > maxActive {
+						maxActive = active
+					}
+					started = append(started, filepath.Base(pr.Path))
+					stateMu.Unlock()
+
+					time.Sleep(50 * time.Millisecond)
+
+					stateMu.Lock()
+					defer stateMu.Unlock()
+					active--
+					done[pr.Path] = true
+					return nil
+				}
+
+				s = queuescanner.NewScanner(
+					mgr, pp, failureHandler, queueDir, nil, 0, nil, nil, nil, 2,
+					true,
+				)
+			})
+
+			It("runs prompts of independent specs concurrently", func() {
+				queue(
+					map[string]string{"001-a.md": "A", "002-b.md": "B"},
+					"001-a.md", "002-b.md",
+				)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(2))
+				Expect(maxActive).To(Equal(2))
+			})
+
+			It("returns when the only candidate is skipped", func() {
+				queue(map[string]string{"001-failed.md": "A"}, "001-failed.md")
+				queued[0].Status = prompt.FailedPromptStatus
+
+				result := make(chan error, 1)
+				go func() {
+					_, err := s.ScanAndProcess(ctx)
+					result <- err
+				}()
+
+				Eventually(result, time.Second).Should(Receive(BeNil()))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(0))
+				Expect(mgr.ListQueuedCallCount()).To(Equal(1))
+			})
+
+			It("keeps an ordered pair of the same spec sequential", func() {
+				queue(
+					map[string]string{"001-first.md": "A", "002-second.md": "A"},
+					"001-first.md", "002-second.md",
+				)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(2))
+				Expect(maxActive).To(Equal(1))
+				Expect(started).To(Equal([]string{"001-first.md", "002-second.md"}))
+			})
+		})
+
+		Context("canary gate", func() {
+			var (
+				sentinel   *mocks.PauseSentinel
+				canaryGate *mocks.CanaryGate
+			)
+
+			BeforeEach(func() {
+				writeFile(
+					"001-first.md",
+					"---\nstatus: approved\n---\n# First\ncontent\n",
+				)
+				writeFile(
+					"002-second.md",
+					"---\nstatus: approved\n---\n# Second\ncontent\n",
+				)
+				first := makeApprovedPrompt("001-first.md")
+				second := makeApprovedPrompt("002-second.md")
+				mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{first, second}, nil)
+				mgr.ListQueuedReturnsOnCall(1, []prompt.Prompt{second}, nil)
+				mgr.ListQueuedReturnsOnCall(2, []prompt.Prompt{}, nil)
+				mgr.AllPreviousCompletedReturns(true)
+
+				sentinel = &mocks.PauseSentinel{}
+				canaryGate = &mocks.CanaryGate{}
+				s = queuescanner.NewScanner(
+					mgr,
+					pp,
+					failureHandler,
+					queueDir,
+					nil,
+					0,
+					sentinel,
+					canaryGate,
+					nil,
+					0,
+					true,
+				)
+			})
+
+			It("observes a successful canary and keeps processing", func() {
+				pp.ProcessPromptReturns(nil)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(2))
+				Expect(canaryGate.ObserveCallCount()).To(Equal(2))
+				_, path, observedErr := canaryGate.ObserveArgsForCall(0)
+				Expect(filepath.Base(path)).To(Equal("001-first.md"))
+				Expect(observedErr).To(BeNil())
+			})
+
+			It("stops before the next prompt when the canary pauses the queue", func() {
+				pp.ProcessPromptReturns(stderrors.New("boom"))
+				canaryGate.ObserveStub = func(_ context.Context, _ string, err error) {
+					if err != nil {
+						sentinel.IsPausedReturns(true)
+					}
+				}
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(0))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+				Expect(failureHandler.HandleCallCount()).To(Equal(1))
+				Expect(canaryGate.ObserveCallCount()).To(Equal(1))
+				_, _, observedErr := canaryGate.ObserveArgsForCall(0)
+				Expect(observedErr).To(MatchError("boom"))
+			})
+		})
+
 		Context("prompt validation fails (no numeric prefix in filename)", func() {
 			BeforeEach(func() {
 				// bad-prompt.md has no NNN- prefix — ValidateForExecution will fail
@@ -271,6 +689,76 @@ var _ = Describe("Scanner", func() {
 				_, _ = s.ScanAndProcess(ctx)
 				Expect(pp.ProcessPromptCallCount()).To(Equal(0))
 			})
+
+			It("processes the prompt when strict ordering is disabled", func() {
+				pr := makeApprovedPrompt("002-blocked.md")
+				mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{pr}, nil)
+				mgr.ListQueuedReturnsOnCall(1, []prompt.Prompt{}, nil)
+				s = queuescanner.NewScanner(
+					mgr, pp, failureHandler, queueDir, nil, 0, nil, nil, nil, 0, false,
+				)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+				Expect(mgr.AllPreviousCompletedCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("log level", func() {
+			var logBuf *bytes.Buffer
+
+			useLevel := func(level log.Level) {
+				original := slog.Default()
+				slog.SetDefault(slog.New(log.NewHandler(logBuf, log.FormatText, level.SlogLevel())))
+				DeferCleanup(func() { slog.SetDefault(original) })
+			}
+
+			BeforeEach(func() {
+				logBuf = &bytes.Buffer{}
+				writeFile("001-first.md", "---\nstatus: approved\n---\n# First\ncontent\n")
+				writeFile("003-blocked.md", "---\nstatus: approved\n---\n# Blocked\ncontent\n")
+				mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{
+					makeApprovedPrompt("001-first.md"),
+				}, nil)
+				mgr.ListQueuedReturnsOnCall(1, []prompt.Prompt{
+					makeApprovedPrompt("003-blocked.md"),
+				}, nil)
+				mgr.AllPreviousCompletedStub = func(_ context.Context, n int) bool {
+					return n == 1
+				}
+				pp.ProcessPromptReturns(nil)
+			})
+
+			It("suppresses the informational watching line at error level", func() {
+				useLevel(log.LevelError)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(logBuf.String()).NotTo(ContainSubstring("watching for queued prompts"))
+			})
+
+			It("logs the watching line at info level", func() {
+				useLevel(log.LevelInfo)
+
+				_, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logBuf.String()).To(ContainSubstring("watching for queued prompts"))
+				Expect(logBuf.String()).NotTo(ContainSubstring("queue scan complete"))
+			})
+
+			It("logs scanned and skipped prompts with the reason at debug level", func() {
+				useLevel(log.LevelDebug)
+
+				_, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				output := logBuf.String()
+				Expect(output).To(ContainSubstring("queue scan complete"))
+				Expect(output).To(ContainSubstring("003-blocked.md"))
+				Expect(output).To(ContainSubstring("lower-numbered prompts not completed"))
+			})
 		})
 
 		Context("prior completed — unblocks on next scan", func() {
@@ -306,6 +794,55 @@ var _ = Describe("Scanner", func() {
 			})
 		})
 
+		Context("explicit depends_on", func() {
+			var pr prompt.Prompt
+
+			BeforeEach(func() {
+				writeFile("005-dependent.md", "---\nstatus: approved\n---\n# Dependent\ncontent\n")
+				pr = makeApprovedPrompt("005-dependent.md")
+				mgr.ListQueuedReturnsOnCall(0, []prompt.Prompt{pr}, nil)
+				mgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+				mgr.LoadStub = func(
+					_ context.Context, path string,
+				) (*prompt.PromptFile, error) {
+					return prompt.NewPromptFile(
+						path,
+						prompt.Frontmatter{
+							Status:    string(prompt.ApprovedPromptStatus),
+							DependsOn: []string{"002"},
+						},
+						[]byte("# Dependent\n"),
+						nil,
+					), nil
+				}
+				pp.ProcessPromptReturns(nil)
+			})
+
+			It("processes the prompt once its dependencies are completed", func() {
+				mgr.AllPreviousCompletedReturns(false)
+				mgr.MissingDependenciesReturns(nil)
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(1))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(1))
+				_, deps := mgr.MissingDependenciesArgsForCall(0)
+				Expect(deps).To(Equal([]string{"002"}))
+				Expect(mgr.AllPreviousCompletedCallCount()).To(Equal(0))
+			})
+
+			It("skips the prompt while a dependency is not completed", func() {
+				mgr.AllPreviousCompletedReturns(true)
+				mgr.MissingDependenciesReturns([]string{"002"})
+
+				completed, err := s.ScanAndProcess(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(completed).To(Equal(0))
+				Expect(pp.ProcessPromptCallCount()).To(Equal(0))
+				Expect(failureHandler.HandleCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("preflight failure propagates as error and stops scan", func() {
 			BeforeEach(func() {
 				writeFile("001-preflight.md", "---\nstatus: approved\n---\n# Preflight\ncontent\n")
@@ -425,6 +962,11 @@ var _ = Describe("Scanner", func() {
 					mgr, pp, failureHandler, queueDir,
 					func(string) lockpkg.DirLock { return lockMock },
 					10*time.Millisecond,
+					nil,
+					nil,
+					nil,
+					0,
+					true,
 				)
 
 				var logBuf bytes.Buffer
@@ -828,7 +1370,11 @@ var _ = Describe("Scanner", func() {
 
 		Context("queue dir does not exist", func() {
 			BeforeEach(func() {
-				s = queuescanner.NewScanner(mgr, pp, failureHandler, "/nonexistent/path", nil, 0)
+				s = queuescanner.NewScanner(
+					mgr, pp, failureHandler, "/nonexistent/path", nil, 0, nil, nil, nil,
+					0,
+					true,
+				)
 			})
 
 			It("returns false gracefully", func() {
@@ -1073,6 +1619,11 @@ var _ = Describe("ConcurrentRejectAndAdvance", func() {
 				inProgressDir,
 				dirLockFactory,
 				5*time.Second,
+				nil,
+				nil,
+				nil,
+				0,
+				true,
 			)
 
 			// Real reject command against the temp dirs, using the
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resultcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/log"
+)
+
+//counterfeiter:generate -o ../../mocks/result-cache.go --fake-name ResultCache . Cache
+
+// Cache stores successful prompt executions on the host filesystem.
+type Cache interface {
+	// Lookup returns the recorded entry for an identical prior execution of content
+	// under version and the prompt's launch overrides. A missing, unreadable or
+	// malformed cache file is a miss.
+	Lookup(
+		ctx context.Context,
+		content string,
+		version string,
+		overrides executor.LaunchOverrides,
+	) (*Entry, bool)
+	// Record stores entry for content under version and overrides. Errors are logged,
+	// not returned — a cache-write failure must not fail an otherwise successful prompt.
+	Record(
+		ctx context.Context,
+		content string,
+		version string,
+		overrides executor.LaunchOverrides,
+		entry Entry,
+	)
+}
+
+// Entry is the JSON record of a successful execution.
+type Entry struct {
+	// PromptFile is the base name of the prompt that produced the result.
+	PromptFile string `json:"promptFile"`
+	// Summary is the completion report summary of that execution.
+	Summary string `json:"summary,omitempty"`
+	// Commit is the hash of the commit holding the changes of that execution,
+	// empty when it produced no commit.
+	Commit     string `json:"commit,omitempty"`
+	RecordedAt string `json:"recordedAt"`
+}
+
+// NewFileCache returns a Cache rooted at the given directory.
+// containerImage is part of every key so a different image never reuses a result.
+func NewFileCache(
+	root string,
+	containerImage string,
+	currentDateTimeGetter libtime.CurrentDateTimeGetter,
+) Cache {
+	return &fileCache{
+		root:                  root,
+		containerImage:        containerImage,
+		currentDateTimeGetter: currentDateTimeGetter,
+	}
+}
+
+// fileCache implements Cache using the host filesystem.
+type fileCache struct {
+	root                  string
+	containerImage        string
+	currentDateTimeGetter libtime.CurrentDateTimeGetter
+}
+
+// Lookup reads the cache file for content, version and overrides.
+func (f *fileCache) Lookup(
+	ctx context.Context,
+	content string,
+	version string,
+	overrides executor.LaunchOverrides,
+) (*Entry, bool) {
+	path := f.cachePath(content, version, overrides)
+	// #nosec G304 -- path derived from internal root + hex-digest key, not user input
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.From(ctx).Warn("result cache read failed, executing", "path", path, "error", err)
+		}
+		return nil, false
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		log.From(ctx).Warn("result cache corrupt JSON, executing", "path", path, "error", err)
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Record writes entry for content, version and overrides. Errors are logged, never returned.
+func (f *fileCache) Record(
+	ctx context.Context,
+	content string,
+	version string,
+	overrides executor.LaunchOverrides,
+	entry Entry,
+) {
+	if err := os.MkdirAll(f.root, 0750); err != nil {
+		log.From(ctx).Error("result cache: mkdir failed", "root", f.root, "error", err)
+		return
+	}
+	entry.RecordedAt = time.Time(f.currentDateTimeGetter.Now()).UTC().Format(time.RFC3339)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.From(ctx).Error("result cache: marshal failed", "error", err)
+		return
+	}
+	path := f.cachePath(content, version, overrides)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		log.From(ctx).Error("result cache: write failed", "path", path, "error", err)
+	}
+}
+
+// cachePath returns the full path of the cache file for content, version and overrides.
+func (f *fileCache) cachePath(
+	content string,
+	version string,
+	overrides executor.LaunchOverrides,
+) string {
+	return filepath.Join(f.root, Key(content, f.containerImage, version, overrides)+".json")
+}
+
+// Key returns the SHA256 hex digest of the prompt content, the effective container
+// image, the dark-factory version and the remaining launch overrides. A change to
+// any of them yields a different key. An override image replaces containerImage, so
+// a prompt naming the configured image shares the key of one that names none.
+func Key(content, containerImage, version string, overrides executor.LaunchOverrides) string {
+	image := containerImage
+	if overrides.Image != "" {
+		image = overrides.Image
+	}
+	overrides.Image = ""
+	// json.Marshal sorts map keys, so the Env order never changes the key.
+	launch, _ := json.Marshal(overrides)
+	h := sha256.New()
+	for _, part := range []string{image, version, string(launch), content} {
+		// Length-prefix each part so "a"+"bc" and "ab"+"c" never collide.
+		_, _ = fmt.Fprintf(h, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
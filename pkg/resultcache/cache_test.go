@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resultcache_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/resultcache"
+)
+
+var _ = Describe("FileCache", func() {
+	var (
+		ctx   context.Context
+		root  string
+		cache resultcache.Cache
+		none  executor.LaunchOverrides
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		root = filepath.Join(GinkgoT().TempDir(), "result-cache")
+		cache = resultcache.NewFileCache(root, "image:v1", libtime.NewCurrentDateTime())
+	})
+
+	It("misses when nothing was recorded", func() {
+		entry, ok := cache.Lookup(ctx, "# Prompt", "v0.1.0", none)
+		Expect(ok).To(BeFalse())
+		Expect(entry).To(BeNil())
+	})
+
+	It("hits after Record with identical content and version", func() {
+		cache.Record(ctx, "# Prompt", "v0.1.0", none, resultcache.Entry{
+			PromptFile: "001-first.md",
+			Summary:    "did the thing",
+			Commit:     "4f2c9e1",
+		})
+
+		entry, ok := cache.Lookup(ctx, "# Prompt", "v0.1.0", none)
+		Expect(ok).To(BeTrue())
+		Expect(entry.PromptFile).To(Equal("001-first.md"))
+		Expect(entry.Summary).To(Equal("did the thing"))
+		Expect(entry.Commit).To(Equal("4f2c9e1"))
+		Expect(entry.RecordedAt).NotTo(BeEmpty())
+	})
+
+	It("misses for different content", func() {
+		cache.Record(ctx, "# Prompt", "v0.1.0", none, resultcache.Entry{PromptFile: "001-first.md"})
+
+		_, ok := cache.Lookup(ctx, "# Other prompt", "v0.1.0", none)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("misses for a different version", func() {
+		cache.Record(ctx, "# Prompt", "v0.1.0", none, resultcache.Entry{PromptFile: "001-first.md"})
+
+		_, ok := cache.Lookup(ctx, "# Prompt", "v0.2.0", none)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("misses for a different image", func() {
+		cache.Record(ctx, "# Prompt", "v0.1.0", none, resultcache.Entry{PromptFile: "001-first.md"})
+
+		other := resultcache.NewFileCache(root, "image:v2", libtime.NewCurrentDateTime())
+		_, ok := other.Lookup(ctx, "# Prompt", "v0.1.0", none)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("misses for a different override image", func() {
+		cache.Record(ctx, "# Prompt", "v0.1.0", none, resultcache.Entry{PromptFile: "001-first.md"})
+
+		overrides := executor.LaunchOverrides{Image: "image:v2"}
+		_, ok := cache.Lookup(ctx, "# Prompt", "v0.1.0", overrides)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("hits when the override image is the configured image", func() {
+		cache.Record(ctx, "# Prompt", "v0.1.0", none, resultcache.Entry{PromptFile: "001-first.md"})
+
+		overrides := executor.LaunchOverrides{Image: "image:v1"}
+		_, ok := cache.Lookup(ctx, "# Prompt", "v0.1.0", overrides)
+		Expect(ok).To(BeTrue())
+	})
+
+	DescribeTable("misses for different launch overrides",
+		func(overrides executor.LaunchOverrides) {
+			cache.Record(ctx, "# Prompt", "v0.1.0", none, resultcache.Entry{PromptFile: "001-first.md"})
+
+			_, ok := cache.Lookup(ctx, "# Prompt", "v0.1.0", overrides)
+			Expect(ok).To(BeFalse())
+		},
+		Entry("command", executor.LaunchOverrides{Command: []string{"make", "test"}}),
+		Entry("env", executor.LaunchOverrides{Env: map[string]string{"MODE": "fast"}}),
+		Entry("volumes", executor.LaunchOverrides{Volumes: []string{"./data:/data:ro"}}),
+		Entry("timeout", executor.LaunchOverrides{MaxPromptDuration: time.Hour}),
+	)
+
+	It("treats a corrupt cache file as a miss", func() {
+		Expect(os.MkdirAll(root, 0750)).To(Succeed())
+		path := filepath.Join(root, resultcache.Key("# Prompt", "image:v1", "v0.1.0", none)+".json")
+		Expect(os.WriteFile(path, []byte("not json"), 0600)).To(Succeed())
+
+		_, ok := cache.Lookup(ctx, "# Prompt", "v0.1.0", none)
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("Key", func() {
+	var none executor.LaunchOverrides
+
+	It("is stable for identical input", func() {
+		Expect(resultcache.Key("a", "b", "c", none)).To(Equal(resultcache.Key("a", "b", "c", none)))
+	})
+
+	It("does not collide when parts shift boundaries", func() {
+		Expect(resultcache.Key("bc", "a", "v", none)).
+			NotTo(Equal(resultcache.Key("c", "ab", "v", none)))
+	})
+
+	It("ignores the order env entries were added in", func() {
+		first := map[string]string{"A": "1"}
+		first["B"] = "2"
+		second := map[string]string{"B": "2"}
+		second["A"] = "1"
+		Expect(resultcache.Key("a", "b", "c", executor.LaunchOverrides{Env: first})).
+			To(Equal(resultcache.Key("a", "b", "c", executor.LaunchOverrides{Env: second})))
+	})
+})
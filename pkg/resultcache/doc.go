@@ -0,0 +1,8 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package resultcache records successful prompt executions keyed by prompt
+// content, container image and dark-factory version so identical prompts
+// can skip the container run.
+package resultcache
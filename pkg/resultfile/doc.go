@@ -0,0 +1,7 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package resultfile reads the optional result file a container writes into
+// the workspace to decide the outcome of a prompt independently of its exit code.
+package resultfile
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resultfile
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+)
+
+// FileName is the result file written by the container at the workspace root.
+const FileName = ".dark-factory-result.json"
+
+const (
+	// StatusSuccess completes the prompt.
+	StatusSuccess = "success"
+	// StatusPartial fails the prompt; the work is incomplete.
+	StatusPartial = "partial"
+	// StatusFailed fails the prompt.
+	StatusFailed = "failed"
+)
+
+// Result is the content of the result file.
+type Result struct {
+	// Status is success, partial or failed.
+	Status string `json:"status"`
+	// Message replaces the prompt summary, used for the commit and PR body.
+	Message string `json:"message,omitempty"`
+	// Bump overrides the version bump derived from the changelog: patch, minor or major.
+	Bump string `json:"bump,omitempty"`
+}
+
+// Validate checks status and bump against the known values.
+func (r Result) Validate(ctx context.Context) error {
+	switch r.Status {
+	case StatusSuccess, StatusPartial, StatusFailed:
+	default:
+		return errors.Errorf(ctx, "unknown status %q", r.Status)
+	}
+	if _, ok := git.ParseVersionBump(r.Bump); r.Bump != "" && !ok {
+		return errors.Errorf(ctx, "unknown bump %q", r.Bump)
+	}
+	return nil
+}
+
+// Succeeded reports whether the result completes the prompt.
+func (r Result) Succeeded() bool {
+	return r.Status == StatusSuccess
+}
+
+// VersionBump returns the bump override, or false when the result leaves the
+// bump to the changelog.
+func (r Result) VersionBump() (git.VersionBump, bool) {
+	return git.ParseVersionBump(r.Bump)
+}
+
+//counterfeiter:generate -o ../../mocks/resultfile-reader.go --fake-name ResultFileReader . Reader
+
+// Reader consumes the result file of a finished container.
+type Reader interface {
+	// Consume reads and removes FileName in dir. It returns nil when the file
+	// does not exist. The file is removed even when it is invalid, so it is
+	// never committed and never leaks into the next execution.
+	Consume(ctx context.Context, dir string) (*Result, error)
+}
+
+// NewReader creates a Reader.
+func NewReader() Reader {
+	return &reader{}
+}
+
+// reader implements Reader.
+type reader struct{}
+
+// Consume reads, validates and removes the result file in dir.
+func (r *reader) Consume(ctx context.Context, dir string) (*Result, error) {
+	path := filepath.Join(dir, FileName)
+	// #nosec G304 -- path is the fixed result file name inside the workspace dir
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read %s", FileName)
+	}
+	if err := os.Remove(path); err != nil {
+		return nil, errors.Wrapf(ctx, err, "remove %s", FileName)
+	}
+	var result Result
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, errors.Wrapf(ctx, err, "parse %s", FileName)
+	}
+	if err := result.Validate(ctx); err != nil {
+		return nil, errors.Wrapf(ctx, err, "validate %s", FileName)
+	}
+	return &result, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resultfile_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/git"
+	"github.com/bborbe/dark-factory/pkg/resultfile"
+)
+
+var _ = Describe("Reader", func() {
+	var (
+		ctx    context.Context
+		dir    string
+		reader resultfile.Reader
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = GinkgoT().TempDir()
+		reader = resultfile.NewReader()
+	})
+
+	write := func(content string) {
+		Expect(
+			os.WriteFile(filepath.Join(dir, resultfile.FileName), []byte(content), 0600),
+		).To(Succeed())
+	}
+
+	It("returns nil without a result file", func() {
+		result, err := reader.Consume(ctx, dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("reads and removes the result file", func() {
+		write(`{"status":"failed","message":"tests still red","bump":"minor"}`)
+
+		result, err := reader.Consume(ctx, dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(&resultfile.Result{
+			Status:  resultfile.StatusFailed,
+			Message: "tests still red",
+			Bump:    "minor",
+		}))
+		Expect(result.Succeeded()).To(BeFalse())
+		Expect(filepath.Join(dir, resultfile.FileName)).NotTo(BeAnExistingFile())
+	})
+
+	It("rejects an unknown status and still removes the file", func() {
+		write(`{"status":"maybe"}`)
+
+		_, err := reader.Consume(ctx, dir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown status "maybe"`))
+		Expect(filepath.Join(dir, resultfile.FileName)).NotTo(BeAnExistingFile())
+	})
+
+	It("rejects an unknown bump", func() {
+		write(`{"status":"success","bump":"huge"}`)
+
+		_, err := reader.Consume(ctx, dir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown bump "huge"`))
+	})
+
+	It("rejects malformed JSON", func() {
+		write(`{"status":`)
+
+		_, err := reader.Consume(ctx, dir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("parse"))
+	})
+
+	DescribeTable("VersionBump",
+		func(bump string, expected git.VersionBump, expectedOK bool) {
+			result := resultfile.Result{Status: resultfile.StatusSuccess, Bump: bump}
+			actual, ok := result.VersionBump()
+			Expect(ok).To(Equal(expectedOK))
+			Expect(actual).To(Equal(expected))
+		},
+		Entry("empty leaves the bump to the changelog", "", git.PatchBump, false),
+		Entry("patch", "patch", git.PatchBump, true),
+		Entry("minor", "minor", git.MinorBump, true),
+		Entry("major", "major", git.MajorBump, true),
+	)
+})
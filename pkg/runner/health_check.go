@@ -7,6 +7,7 @@ package runner
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -23,7 +24,8 @@ import (
 )
 
 // runHealthCheckLoop runs periodic container health checks every interval.
-// It checks prompts in executing state and specs in generating state.
+// It checks prompts in executing state and specs in generating state;
+// an empty specsInProgressDir skips the spec check.
 // Returns nil when ctx is cancelled (clean shutdown).
 // skipContainerReconcile is true under backend: local, where the "container gone
 // → reset to approved" reconciliation must be a no-op: a local subprocess runs
@@ -57,6 +59,9 @@ func runHealthCheckLoop(
 			if err := checkExecutingPrompts(ctx, inProgressDir, checker, mgr, n, projectName, maxPromptDuration, stopper, currentDateTimeGetter, skipContainerReconcile); err != nil {
 				slog.Warn("health check for executing prompts failed", "error", err)
 			}
+			if specsInProgressDir == "" {
+				continue
+			}
 			if err := checkGeneratingSpecs(ctx, specsInProgressDir, checker, currentDateTimeGetter, projectName, skipContainerReconcile); err != nil {
 				slog.Warn("health check for generating specs failed", "error", err)
 			}
@@ -86,21 +91,22 @@ func checkExecutingPrompts(
 	if skipContainerReconcile {
 		return nil
 	}
-	entries, err := os.ReadDir(inProgressDir)
+	paths, err := prompt.ListQueueFiles(inProgressDir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
 		}
 		return errors.Wrap(ctx, err, "read in-progress dir for health check")
 	}
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
 			continue
 		}
 		checkExecutingPrompt(
 			ctx,
-			inProgressDir,
-			entry,
+			filepath.Dir(path),
+			fs.FileInfoToDirEntry(info),
 			checker,
 			mgr,
 			n,
@@ -161,19 +167,21 @@ func checkExecutingPrompt(
 			n,
 			projectName,
 			stopper,
-			maxPromptDuration,
+			pf.Frontmatter.EffectiveTimeout(maxPromptDuration),
 		)
 	}
 }
 
-// isTimedOut returns true when maxPromptDuration is set and the prompt has exceeded it.
+// isTimedOut returns true when the prompt has exceeded its timeout frontmatter or,
+// without one, maxPromptDuration. A zero limit disables the check.
 func isTimedOut(
 	ctx context.Context,
 	pf *prompt.PromptFile,
 	maxPromptDuration time.Duration,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) bool {
-	if maxPromptDuration == 0 || pf.Frontmatter.Started == "" {
+	limit := pf.Frontmatter.EffectiveTimeout(maxPromptDuration)
+	if limit == 0 || pf.Frontmatter.Started == "" {
 		return false
 	}
 	started, err := libtime.ParseDateTime(ctx, pf.Frontmatter.Started)
@@ -182,7 +190,7 @@ func isTimedOut(
 	}
 	now := currentDateTimeGetter.Now()
 	elapsed := time.Time(now).Sub(time.Time(*started))
-	return elapsed > maxPromptDuration
+	return elapsed > limit
 }
 
 // stopTimedOutPrompt stops the container and marks the prompt failed.
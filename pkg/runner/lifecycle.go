@@ -16,6 +16,7 @@ import (
 
 	"github.com/bborbe/dark-factory/pkg/executor"
 	"github.com/bborbe/dark-factory/pkg/notifier"
+	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/promptstate"
 	"github.com/bborbe/dark-factory/pkg/slugmigrator"
 	"github.com/bborbe/dark-factory/pkg/spec"
@@ -147,18 +148,17 @@ func resumeOrResetExecuting(
 	n notifier.Notifier,
 	projectName string,
 ) error {
-	entries, err := os.ReadDir(inProgressDir)
+	paths, err := prompt.ListQueueFiles(inProgressDir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
 		}
 		return errors.Wrap(ctx, err, "read in-progress dir")
 	}
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-		if err := resumeOrResetExecutingEntry(ctx, inProgressDir, entry.Name(), mgr, checker, n, projectName); err != nil {
+	for _, path := range paths {
+		if err := resumeOrResetExecutingEntry(
+			ctx, filepath.Dir(path), filepath.Base(path), mgr, checker, n, projectName,
+		); err != nil {
 			return errors.Wrap(ctx, err, "resume or reset executing entry")
 		}
 	}
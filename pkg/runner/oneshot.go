@@ -26,6 +26,8 @@ type OneShotRunner interface {
 // NewOneShotRunner creates a new OneShotRunner.
 // startupLogger is an optional func called after lock acquisition to emit the effective-config log line.
 // Pass nil to skip the startup log.
+// dryRun skips the lock and the startup steps, which rename and reset prompt files,
+// and leaves the queue to the processor's dry-run.
 func NewOneShotRunner(
 	inboxDir string,
 	inProgressDir string,
@@ -44,6 +46,7 @@ func NewOneShotRunner(
 	autoApprove bool,
 	slugMigrator slugmigrator.Migrator,
 	hideGit bool,
+	dryRun bool,
 	startupLogger func(),
 ) OneShotRunner {
 	return &oneShotRunner{
@@ -64,6 +67,7 @@ func NewOneShotRunner(
 		autoApprove:           autoApprove,
 		slugMigrator:          slugMigrator,
 		hideGit:               hideGit,
+		dryRun:                dryRun,
 		startupLogger:         startupLogger,
 	}
 }
@@ -87,12 +91,18 @@ type oneShotRunner struct {
 	autoApprove           bool
 	slugMigrator          slugmigrator.Migrator
 	hideGit               bool
+	dryRun                bool
 	startupLogger         func()
 }
 
 // Run acquires the lock, runs startup steps, then delegates to processor.Process.
 // The onIdle callback injected into the processor drives exit for one-shot mode.
+// In dry-run mode only processor.Process runs; it reports the queue and returns.
 func (r *oneShotRunner) Run(ctx context.Context) error {
+	if r.dryRun {
+		return r.processor.Process(ctx)
+	}
+
 	// Acquire instance lock
 	if err := r.locker.Acquire(ctx); err != nil {
 		return errors.Wrap(ctx, err, "acquire lock")
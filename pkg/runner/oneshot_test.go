@@ -76,6 +76,7 @@ var _ = Describe("OneShotRunner", func() {
 			false,
 			&mocks.SpecSlugMigrator{},
 			false, // hideGit
+			false, // dryRun
 			nil,
 		)
 	}
@@ -128,6 +129,39 @@ var _ = Describe("OneShotRunner", func() {
 		Expect(err).To(BeNil())
 	})
 
+	It("should skip lock and startup steps in dry-run and only call Process", func() {
+		setupMocks()
+		inboxDir := filepath.Join(tempDir, "inbox-not-created")
+		r := runner.NewOneShotRunner(
+			inboxDir,
+			promptsDir,
+			filepath.Join(promptsDir, "completed"),
+			filepath.Join(promptsDir, "logs"),
+			filepath.Join(specsDir, "inbox"),
+			filepath.Join(specsDir, "in-progress"),
+			filepath.Join(specsDir, "completed"),
+			filepath.Join(specsDir, "logs"),
+			manager,
+			locker,
+			processor,
+			nil,
+			libtime.NewCurrentDateTime(),
+			containerChecker,
+			false,
+			&mocks.SpecSlugMigrator{},
+			false, // hideGit
+			true,  // dryRun
+			nil,
+		)
+
+		Expect(r.Run(ctx)).To(Succeed())
+
+		Expect(processor.ProcessCallCount()).To(Equal(1))
+		Expect(locker.AcquireCallCount()).To(Equal(0))
+		Expect(manager.NormalizeFilenamesCallCount()).To(Equal(0))
+		Expect(inboxDir).NotTo(BeADirectory())
+	})
+
 	It("should call Process (not ProcessQueue)", func() {
 		setupMocks()
 		r := newTestOneShotRunner(promptsDir, promptsDir, filepath.Join(promptsDir, "completed"))
@@ -279,6 +313,7 @@ var _ = Describe("OneShotRunner", func() {
 				false,
 				&mocks.SpecSlugMigrator{},
 				false, // hideGit
+				false, // dryRun
 				nil,
 			)
 
@@ -324,6 +359,7 @@ var _ = Describe("OneShotRunner", func() {
 				false,
 				&mocks.SpecSlugMigrator{},
 				false, // hideGit
+				false, // dryRun
 				nil,
 			)
 
@@ -6,6 +6,7 @@ package runner
 
 import (
 	"context"
+	stderrors "errors"
 	"io"
 	"log/slog"
 	"os"
@@ -21,6 +22,7 @@ import (
 	"github.com/bborbe/dark-factory/pkg/executor"
 	"github.com/bborbe/dark-factory/pkg/healthcheckgate"
 	"github.com/bborbe/dark-factory/pkg/lock"
+	"github.com/bborbe/dark-factory/pkg/log"
 	"github.com/bborbe/dark-factory/pkg/notifier"
 	"github.com/bborbe/dark-factory/pkg/preflight"
 	"github.com/bborbe/dark-factory/pkg/processor"
@@ -36,9 +38,23 @@ type Runner interface {
 	Run(ctx context.Context) error
 }
 
+// PromptDir is an additional prompts directory watched by the daemon alongside the
+// primary one. Each directory has its own lifecycle subdirectories, prompt manager,
+// watcher and processor; all of them run under the runner's single instance lock.
+type PromptDir struct {
+	InboxDir      string
+	InProgressDir string
+	CompletedDir  string
+	LogDir        string
+	PromptManager PromptManager
+	Watcher       watcher.Watcher
+	Processor     processor.Processor
+}
+
 // NewRunner creates a new Runner.
 // startupLogger is an optional func called after lock acquisition to emit the effective-config log line.
 // Pass nil to skip the startup log.
+// promptDirs lists additional prompts directories to watch; pass nil for a single directory.
 func NewRunner(
 	inboxDir string,
 	inProgressDir string,
@@ -65,8 +81,10 @@ func NewRunner(
 	hideGit bool,
 	preflightChecker preflight.Checker,
 	logWriter io.Writer,
+	logFormat log.Format,
 	healthcheckGate healthcheckgate.Gate,
 	skipContainerReconcile bool,
+	promptDirs []PromptDir,
 ) Runner {
 	return &runner{
 		inboxDir:               inboxDir,
@@ -94,8 +112,10 @@ func NewRunner(
 		hideGit:                hideGit,
 		preflightChecker:       preflightChecker,
 		logWriter:              logWriter,
+		logFormat:              logFormat,
 		healthcheckGate:        healthcheckGate,
 		skipContainerReconcile: skipContainerReconcile,
+		promptDirs:             promptDirs,
 	}
 }
 
@@ -126,6 +146,7 @@ type runner struct {
 	hideGit               bool
 	preflightChecker      preflight.Checker
 	logWriter             io.Writer
+	logFormat             log.Format
 	healthcheckGate       healthcheckgate.Gate
 	// skipContainerReconcile disables the health-check "container gone → reset to
 	// approved" reconciliation under backend: local (spec 104 follow-up), where the
@@ -133,6 +154,7 @@ type runner struct {
 	// reconcile; restart recovery is handled by the resumer's ErrReattachUnsupported
 	// re-queue path instead.
 	skipContainerReconcile bool
+	promptDirs             []PromptDir
 }
 
 // Run executes the main processing loop:
@@ -165,12 +187,16 @@ func (r *runner) Run(ctx context.Context) error {
 		if closer, ok := r.logWriter.(io.Closer); ok {
 			defer closer.Close()
 		}
+		// Keep the level of the handler installed by main (-debug, logLevel).
 		level := slog.LevelInfo
-		if slog.Default().Enabled(ctx, slog.LevelDebug) {
+		switch {
+		case slog.Default().Enabled(ctx, slog.LevelDebug):
 			level = slog.LevelDebug
+		case !slog.Default().Enabled(ctx, slog.LevelInfo):
+			level = slog.LevelWarn
 		}
 		w := io.MultiWriter(os.Stderr, r.logWriter)
-		slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+		slog.SetDefault(slog.New(log.NewHandler(w, r.logFormat, level)))
 	}
 
 	if r.startupLogger != nil {
@@ -182,12 +208,20 @@ func (r *runner) Run(ctx context.Context) error {
 	defer stop()
 
 	slog.Info("watching for queued prompts", "dir", r.inProgressDir)
+	for _, d := range r.promptDirs {
+		slog.Info("watching for queued prompts", "dir", d.InProgressDir)
+	}
 
 	// Run the five shared startup steps (migrateQueueDir, createDirectories,
 	// resumeOrResetExecuting, normalizeFilenames, migrateSpecSlugs).
 	if err := startupSequence(ctx, r.startupDeps()); err != nil {
 		return errors.Wrap(ctx, err, "startup sequence")
 	}
+	for _, d := range r.promptDirs {
+		if err := startupSequence(ctx, r.promptDirStartupDeps(d)); err != nil {
+			return errors.Wrapf(ctx, err, "startup sequence for %s", d.InboxDir)
+		}
+	}
 
 	// Daemon-only: reset specs left in generating state if their container is gone.
 	// Not in startupSequence because this step has no counterpart in the one-shot runner.
@@ -200,12 +234,26 @@ func (r *runner) Run(ctx context.Context) error {
 	if err := r.processor.ResumeExecuting(ctx); err != nil {
 		return errors.Wrap(ctx, err, "resume executing prompts")
 	}
+	for _, d := range r.promptDirs {
+		if err := d.Processor.ResumeExecuting(ctx); err != nil {
+			return errors.Wrapf(ctx, err, "resume executing prompts in %s", d.InboxDir)
+		}
+	}
 
 	// Daemon-only: retry git commits for any prompts left in "committing" state.
 	if err := r.processor.ResumeCommitting(ctx); err != nil {
 		slog.Warn("resume committing failed on startup, will retry on next cycle", "error", err)
 		// non-fatal — continue startup
 	}
+	for _, d := range r.promptDirs {
+		if err := d.Processor.ResumeCommitting(ctx); err != nil {
+			slog.Warn(
+				"resume committing failed on startup, will retry on next cycle",
+				"dir", d.InboxDir,
+				"error", err,
+			)
+		}
+	}
 
 	// Startup preflight: verify baseline is green before the watcher loop begins.
 	if err := r.runStartupPreflight(ctx); err != nil {
@@ -223,6 +271,14 @@ func (r *runner) Run(ctx context.Context) error {
 		r.watcher.Watch,
 		r.processor.Process,
 	}
+	for _, d := range r.promptDirs {
+		runners = append(
+			runners,
+			d.Watcher.Watch,
+			d.Processor.Process,
+			r.promptDirHealthCheckLoop(d),
+		)
+	}
 	if r.server != nil {
 		runners = append(runners, r.server.ListenAndServe)
 	}
@@ -230,7 +286,15 @@ func (r *runner) Run(ctx context.Context) error {
 		runners = append(runners, r.specWatcher.Watch)
 	}
 	runners = append(runners, r.healthCheckLoop)
-	return run.CancelOnFirstError(ctx, runners...)
+	if err := run.CancelOnFirstError(ctx, runners...); err != nil {
+		// A shutdown request stops the processor between prompts; exit cleanly.
+		if stderrors.Is(err, processor.ErrShutdownRequested) {
+			slog.Info("daemon stopped on shutdown request")
+			return nil
+		}
+		return err
+	}
+	return nil
 }
 
 // runStartupHealthcheck runs the healthcheck startup gate before the watcher loop.
@@ -285,6 +349,18 @@ func (r *runner) startupDeps() StartupDeps {
 	}
 }
 
+// promptDirStartupDeps builds a StartupDeps for an additional prompts directory.
+// The spec directories are shared with the primary directory.
+func (r *runner) promptDirStartupDeps(d PromptDir) StartupDeps {
+	deps := r.startupDeps()
+	deps.InboxDir = d.InboxDir
+	deps.InProgressDir = d.InProgressDir
+	deps.CompletedDir = d.CompletedDir
+	deps.LogDir = d.LogDir
+	deps.PromptManager = d.PromptManager
+	return deps
+}
+
 // CheckGitSafety verifies git safety conditions before starting either
 // the daemon or a one-shot run:
 //  1. Refuse to start from a worktree or submodule CWD — the .git pointer
@@ -327,6 +403,27 @@ func (r *runner) healthCheckLoop(ctx context.Context) error {
 	)
 }
 
+// promptDirHealthCheckLoop returns the periodic health check for an additional
+// prompts directory. Generating specs are checked by healthCheckLoop only.
+func (r *runner) promptDirHealthCheckLoop(d PromptDir) run.Func {
+	return func(ctx context.Context) error {
+		return runHealthCheckLoop(
+			ctx,
+			30*time.Second,
+			d.InProgressDir,
+			"",
+			r.executionChecker,
+			d.PromptManager,
+			r.notifier,
+			r.projectName.String(),
+			r.currentDateTimeGetter,
+			r.maxPromptDuration,
+			r.executionStopper,
+			r.skipContainerReconcile,
+		)
+	}
+}
+
 // resumeOrResetGenerating selectively resumes or resets generating specs based on container liveness.
 func (r *runner) resumeOrResetGenerating(ctx context.Context) error {
 	return resumeOrResetGenerating(
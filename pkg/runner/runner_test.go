@@ -17,6 +17,7 @@ import (
 
 	"github.com/bborbe/dark-factory/mocks"
 	"github.com/bborbe/dark-factory/pkg/healthcheckgate"
+	"github.com/bborbe/dark-factory/pkg/log"
 	"github.com/bborbe/dark-factory/pkg/notifier"
 	pkgprocessor "github.com/bborbe/dark-factory/pkg/processor"
 	"github.com/bborbe/dark-factory/pkg/prompt"
@@ -94,8 +95,10 @@ var _ = Describe("Runner", func() {
 			false, // hideGit
 			nil,   // preflightChecker: no preflight in tests
 			nil,   // logWriter: no file in tests
+			log.FormatText,
 			nil,   // healthcheckGate: no gate in tests
 			false, // skipContainerReconcile
+			nil,   // promptDirs
 		)
 	}
 
@@ -132,6 +135,91 @@ var _ = Describe("Runner", func() {
 		Expect(locker.ReleaseCallCount()).To(Equal(1))
 	})
 
+	It("should watch and process every prompts directory under one lock", func() {
+		locker.AcquireReturns(nil)
+		locker.ReleaseReturns(nil)
+		manager.NormalizeFilenamesReturns(nil, nil)
+		watcher.WatchStub = func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+		processor.ProcessStub = func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+		server.ListenAndServeStub = func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+
+		secondDir := filepath.Join(tempDir, "more-prompts")
+		secondManager := &mocks.RunnerPromptManager{}
+		secondWatcher := &mocks.Watcher{}
+		secondWatcher.WatchStub = func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+		secondProcessor := &mocks.Processor{}
+		secondProcessor.ProcessStub = func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+
+		r := runner.NewRunner(
+			promptsDir,
+			filepath.Join(promptsDir, "in-progress"),
+			filepath.Join(promptsDir, "completed"),
+			filepath.Join(promptsDir, "log"),
+			filepath.Join(specsDir, "inbox"),
+			filepath.Join(specsDir, "in-progress"),
+			filepath.Join(specsDir, "completed"),
+			filepath.Join(specsDir, "logs"),
+			manager,
+			locker,
+			watcher,
+			processor,
+			server,
+			nil, // no specWatcher
+			"",
+			containerChecker,
+			notifier.NewMultiNotifier(),
+			&mocks.SpecSlugMigrator{},
+			libtime.NewCurrentDateTime(),
+			0,
+			nil,
+			nil,
+			false, // hideGit
+			nil,   // preflightChecker: no preflight in tests
+			nil,   // logWriter: no file in tests
+			log.FormatText,
+			nil,   // healthcheckGate: no gate in tests
+			false, // skipContainerReconcile
+			[]runner.PromptDir{{
+				InboxDir:      secondDir,
+				InProgressDir: filepath.Join(secondDir, "in-progress"),
+				CompletedDir:  filepath.Join(secondDir, "completed"),
+				LogDir:        filepath.Join(secondDir, "log"),
+				PromptManager: secondManager,
+				Watcher:       secondWatcher,
+				Processor:     secondProcessor,
+			}},
+		)
+
+		runCtx, runCancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer runCancel()
+
+		Expect(r.Run(runCtx)).To(Succeed())
+
+		Expect(locker.AcquireCallCount()).To(Equal(1))
+		Expect(processor.ProcessCallCount()).To(Equal(1))
+		Expect(secondProcessor.ProcessCallCount()).To(Equal(1))
+		Expect(secondWatcher.WatchCallCount()).To(Equal(1))
+		Expect(secondProcessor.ResumeExecutingCallCount()).To(Equal(1))
+		Expect(secondManager.NormalizeFilenamesCallCount()).To(Equal(1))
+		Expect(filepath.Join(secondDir, "completed")).To(BeADirectory())
+		Expect(filepath.Join(secondDir, "log")).To(BeADirectory())
+	})
+
 	It("should process executing prompts on startup", func() {
 		inProgressDir := filepath.Join(promptsDir, "in-progress")
 		Expect(os.MkdirAll(inProgressDir, 0750)).To(Succeed())
@@ -277,6 +365,32 @@ var _ = Describe("Runner", func() {
 		Eventually(errCh, 2*time.Second).Should(Receive(BeNil()))
 	})
 
+	It("should exit cleanly when the processor stops on a shutdown request", func() {
+		locker.AcquireReturns(nil)
+		locker.ReleaseReturns(nil)
+		manager.NormalizeFilenamesReturns(nil, nil)
+
+		watcher.WatchStub = func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+		processor.ProcessReturns(pkgprocessor.ErrShutdownRequested)
+		server.ListenAndServeStub = func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}
+
+		r := newTestRunner(promptsDir, promptsDir, filepath.Join(promptsDir, "completed"))
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- r.Run(ctx)
+		}()
+
+		Eventually(errCh, 2*time.Second).Should(Receive(BeNil()))
+		Expect(locker.ReleaseCallCount()).To(Equal(1))
+	})
+
 	It("should return error when normalization fails", func() {
 		inboxDir := filepath.Join(promptsDir, "inbox")
 		inProgressDir := filepath.Join(promptsDir, "in-progress")
@@ -383,8 +497,10 @@ var _ = Describe("Runner", func() {
 			false, // hideGit
 			nil,   // preflightChecker: no preflight in tests
 			nil,   // logWriter: no file in tests
+			log.FormatText,
 			nil,   // healthcheckGate: no gate in tests
 			false, // skipContainerReconcile
+			nil,   // promptDirs
 		)
 
 		runCtx, runCancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -452,8 +568,10 @@ var _ = Describe("Runner", func() {
 				false, // hideGit
 				nil,   // preflightChecker: no preflight in tests
 				nil,   // logWriter: no file in tests
+				log.FormatText,
 				nil,   // healthcheckGate: no gate in tests
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 
 			runCtx, runCancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -671,8 +789,10 @@ var _ = Describe("Runner", func() {
 				false, // hideGit
 				nil,   // preflightChecker: no preflight in tests
 				nil,   // logWriter: no file in tests
+				log.FormatText,
 				nil,   // healthcheckGate: no gate in tests
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 
 			runCtx, runCancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -755,8 +875,10 @@ var _ = Describe("Runner", func() {
 				false, // hideGit
 				nil,   // preflightChecker: no preflight in tests
 				nil,   // logWriter: no file in tests
+				log.FormatText,
 				nil,   // healthcheckGate: no gate in tests
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 
 			runCtx, runCancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -849,8 +971,10 @@ var _ = Describe("Runner", func() {
 					false, // hideGit
 					nil,   // preflightChecker: no preflight in tests
 					nil,   // logWriter: no file in tests
+					log.FormatText,
 					nil,   // healthcheckGate: no gate in tests
 					false, // skipContainerReconcile
+					nil,   // promptDirs
 				)
 
 				runCtx, runCancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -939,8 +1063,10 @@ var _ = Describe("Runner", func() {
 				false, // hideGit
 				nil,   // preflightChecker: no preflight in tests
 				nil,   // logWriter: no file in tests
+				log.FormatText,
 				nil,   // healthcheckGate: no gate in tests
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 
 			runCtx, runCancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -1003,8 +1129,10 @@ var _ = Describe("Runner", func() {
 				false, // hideGit
 				nil,   // preflightChecker: no preflight in tests
 				nil,   // logWriter: no file in tests
+				log.FormatText,
 				nil,   // healthcheckGate: no gate in tests
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 		}
 
@@ -1315,10 +1443,12 @@ var _ = Describe("Runner", func() {
 				nil, // containerStopper
 				nil, // startupLogger
 				hideGit,
-				nil,   // preflightChecker
-				nil,   // logWriter
+				nil, // preflightChecker
+				nil, // logWriter
+				log.FormatText,
 				nil,   // healthcheckGate
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 		}
 
@@ -1460,7 +1590,8 @@ var _ = Describe("Runner", func() {
 				false, // autoApprove
 				&mocks.SpecSlugMigrator{},
 				hideGit,
-				nil, // startupLogger
+				false, // dryRun
+				nil,   // startupLogger
 			)
 		}
 
@@ -1589,9 +1720,11 @@ var _ = Describe("Runner", func() {
 				nil,   // startupLogger
 				false, // hideGit
 				preflightChecker,
-				nil,   // logWriter
+				nil, // logWriter
+				log.FormatText,
 				nil,   // healthcheckGate
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 		}
 
@@ -1678,8 +1811,10 @@ var _ = Describe("Runner", func() {
 				false, // hideGit
 				nil,   // preflightChecker
 				nil,   // logWriter
+				log.FormatText,
 				gate,
 				false, // skipContainerReconcile
+				nil,   // promptDirs
 			)
 		}
 
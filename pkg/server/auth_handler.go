@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Credentials are the secrets accepted by NewAuthHandler. An empty Token
+// disables bearer auth; an empty Username or Password disables basic auth.
+// When both are disabled every request is rejected.
+type Credentials struct {
+	Token    string
+	Username string
+	Password string
+}
+
+// NewAuthHandler wraps next and answers 401 unless the request carries a
+// matching "Authorization: Bearer <token>" or basic-auth header.
+func NewAuthHandler(next http.Handler, credentials Credentials) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if !credentials.authorized(req) {
+			resp.Header().Set("WWW-Authenticate", credentials.challenge())
+			http.Error(resp, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(resp, req)
+	})
+}
+
+// authorized reports whether req carries valid bearer or basic credentials.
+// Comparisons are constant-time.
+func (c Credentials) authorized(req *http.Request) bool {
+	if c.Token != "" {
+		if token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok &&
+			secureEqual(token, c.Token) {
+			return true
+		}
+	}
+	if c.Username != "" && c.Password != "" {
+		if username, password, ok := req.BasicAuth(); ok &&
+			secureEqual(username, c.Username) && secureEqual(password, c.Password) {
+			return true
+		}
+	}
+	return false
+}
+
+// challenge returns the WWW-Authenticate value for the configured scheme.
+func (c Credentials) challenge() string {
+	if c.Token == "" && c.Username != "" {
+		return `Basic realm="dark-factory"`
+	}
+	return `Bearer realm="dark-factory"`
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/dark-factory/pkg/server"
+)
+
+var _ = Describe("AuthHandler", func() {
+	var next http.Handler
+
+	BeforeEach(func() {
+		next = http.HandlerFunc(func(resp http.ResponseWriter, _ *http.Request) {
+			resp.WriteHeader(http.StatusOK)
+		})
+	})
+
+	serve := func(credentials server.Credentials, mutate func(req *http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+		mutate(req)
+		w := httptest.NewRecorder()
+		server.NewAuthHandler(next, credentials).ServeHTTP(w, req)
+		return w
+	}
+
+	Context("bearer token", func() {
+		credentials := server.Credentials{Token: "s3cret"}
+
+		It("accepts the configured token", func() {
+			w := serve(credentials, func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer s3cret")
+			})
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+
+		It("rejects a missing token", func() {
+			w := serve(credentials, func(*http.Request) {})
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(w.Header().Get("WWW-Authenticate")).To(HavePrefix("Bearer"))
+		})
+
+		It("rejects a wrong token", func() {
+			w := serve(credentials, func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer wrong")
+			})
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("basic auth", func() {
+		credentials := server.Credentials{Username: "admin", Password: "pw"}
+
+		It("accepts the configured user and password", func() {
+			w := serve(credentials, func(req *http.Request) {
+				req.SetBasicAuth("admin", "pw")
+			})
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+
+		It("rejects a wrong password", func() {
+			w := serve(credentials, func(req *http.Request) {
+				req.SetBasicAuth("admin", "nope")
+			})
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(w.Header().Get("WWW-Authenticate")).To(HavePrefix("Basic"))
+		})
+	})
+
+	It("rejects every request when no credential is resolved", func() {
+		w := serve(server.Credentials{Username: "admin"}, func(req *http.Request) {
+			req.SetBasicAuth("admin", "")
+			req.Header.Set("Authorization", "Bearer ")
+		})
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+	})
+})
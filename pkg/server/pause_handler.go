@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bborbe/errors"
+	libhttp "github.com/bborbe/http"
+
+	"github.com/bborbe/dark-factory/pkg/pause"
+)
+
+// NewPauseHandler creates a handler for POST /pause.
+// It creates the pause sentinel; the prompt in flight finishes, no new prompt starts.
+func NewPauseHandler(sentinel pause.Sentinel) libhttp.WithError {
+	return newPauseToggleHandler(sentinel.Pause, "paused")
+}
+
+// NewResumeHandler creates a handler for POST /resume.
+// It removes the pause sentinel so the daemon picks up queued prompts again.
+func NewResumeHandler(sentinel pause.Sentinel) libhttp.WithError {
+	return newPauseToggleHandler(sentinel.Resume, "resumed")
+}
+
+func newPauseToggleHandler(
+	toggle func(ctx context.Context) error,
+	state string,
+) libhttp.WithError {
+	return libhttp.WithErrorFunc(
+		func(ctx context.Context, resp http.ResponseWriter, req *http.Request) error {
+			if req.Method != http.MethodPost {
+				return libhttp.WrapWithStatusCode(
+					errors.New(ctx, "method not allowed"),
+					http.StatusMethodNotAllowed,
+				)
+			}
+
+			if err := toggle(ctx); err != nil {
+				return errors.Wrapf(ctx, err, "set queue %s", state)
+			}
+
+			resp.Header().Set("Content-Type", "application/json")
+			resp.WriteHeader(http.StatusOK)
+			_, _ = resp.Write([]byte(`{"status":"` + state + `"}`))
+			return nil
+		},
+	)
+}
@@ -16,4 +16,5 @@ import (
 type PromptManager interface {
 	Load(ctx context.Context, path string) (*prompt.PromptFile, error)
 	NormalizeFilenames(ctx context.Context, dir string) ([]prompt.Rename, error)
+	FindByIdempotencyKey(ctx context.Context, key string) (string, error)
 }
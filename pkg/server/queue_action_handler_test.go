@@ -96,6 +96,48 @@ var _ = Describe("QueueActionHandler", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("returns the existing prompt for a repeated idempotency key", func() {
+			testFile := filepath.Join(inboxDir, "retry.md")
+			err := os.WriteFile(testFile, []byte("# Retry"), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			promptManager.LoadStub = func(ctx context.Context, path string) (*prompt.PromptFile, error) {
+				return prompt.NewPromptFile(
+					path,
+					prompt.Frontmatter{IdempotencyKey: "job-42"},
+					nil,
+					libtime.NewCurrentDateTime(),
+				), nil
+			}
+			promptManager.FindByIdempotencyKeyReturns(filepath.Join(queueDir, "001-original.md"), nil)
+
+			body, err := json.Marshal(server.QueueRequest{File: "retry.md"})
+			Expect(err).NotTo(HaveOccurred())
+			req := httptest.NewRequest("POST", "/api/v1/queue/action", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler := libhttp.NewErrorHandler(
+				server.NewQueueActionHandler(
+					inboxDir,
+					queueDir,
+					promptManager,
+				),
+			)
+			handler.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(200))
+			var response server.QueueResponse
+			Expect(json.NewDecoder(w.Body).Decode(&response)).To(Succeed())
+			Expect(response.Queued).To(HaveLen(1))
+			Expect(response.Queued[0].New).To(Equal("001-original.md"))
+
+			_, key := promptManager.FindByIdempotencyKeyArgsForCall(0)
+			Expect(key).To(Equal("job-42"))
+			_, err = os.Stat(filepath.Join(queueDir, "retry.md"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			Expect(promptManager.NormalizeFilenamesCallCount()).To(Equal(0))
+		})
+
 		It("returns 404 for nonexistent file", func() {
 			reqBody := server.QueueRequest{File: "nonexistent.md"}
 			body, err := json.Marshal(reqBody)
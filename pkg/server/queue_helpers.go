@@ -11,6 +11,8 @@ import (
 	"strings"
 
 	"github.com/bborbe/errors"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
 // queueSingleFile moves a single file from inbox to queue.
@@ -89,6 +91,18 @@ func moveToQueue(
 ) (string, error) {
 	oldPath := filepath.Join(inboxDir, filename)
 
+	// A retried enqueue of a prompt whose idempotency key is already queued or
+	// completed returns the existing prompt instead of queueing a duplicate.
+	existing, err := prompt.FindDuplicateByIdempotencyKey(
+		ctx, oldPath, promptManager, promptManager,
+	)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "check idempotency key")
+	}
+	if existing != "" {
+		return filepath.Base(existing), nil
+	}
+
 	// Move file to queue directory with same name
 	newPath := filepath.Join(queueDir, filename)
 	if err := os.Rename(oldPath, newPath); err != nil {
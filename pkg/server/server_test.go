@@ -7,6 +7,7 @@ package server_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http/httptest"
 	"time"
 
@@ -70,6 +71,85 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Describe("Shutdown endpoint", func() {
+		It("fires the trigger and returns 202", func() {
+			trigger := run.NewTrigger()
+			req := httptest.NewRequest("POST", "/shutdown", nil)
+			w := httptest.NewRecorder()
+
+			handler := libhttp.NewErrorHandler(server.NewShutdownHandler(trigger))
+			handler.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(202))
+			Expect(w.Body.String()).To(Equal(`{"status":"shutting down"}`))
+			Expect(trigger.Done()).To(BeClosed())
+		})
+
+		It("returns method not allowed for GET without firing", func() {
+			trigger := run.NewTrigger()
+			req := httptest.NewRequest("GET", "/shutdown", nil)
+			w := httptest.NewRecorder()
+
+			handler := libhttp.NewErrorHandler(server.NewShutdownHandler(trigger))
+			handler.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(405))
+			Expect(trigger.Done()).NotTo(BeClosed())
+		})
+	})
+
+	Describe("Pause endpoints", func() {
+		var sentinel *mocks.PauseSentinel
+
+		BeforeEach(func() {
+			sentinel = &mocks.PauseSentinel{}
+		})
+
+		It("pauses the queue on POST /pause", func() {
+			req := httptest.NewRequest("POST", "/pause", nil)
+			w := httptest.NewRecorder()
+
+			libhttp.NewErrorHandler(server.NewPauseHandler(sentinel)).ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(200))
+			Expect(w.Body.String()).To(Equal(`{"status":"paused"}`))
+			Expect(sentinel.PauseCallCount()).To(Equal(1))
+			Expect(sentinel.ResumeCallCount()).To(Equal(0))
+		})
+
+		It("resumes the queue on POST /resume", func() {
+			req := httptest.NewRequest("POST", "/resume", nil)
+			w := httptest.NewRecorder()
+
+			libhttp.NewErrorHandler(server.NewResumeHandler(sentinel)).ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(200))
+			Expect(w.Body.String()).To(Equal(`{"status":"resumed"}`))
+			Expect(sentinel.ResumeCallCount()).To(Equal(1))
+			Expect(sentinel.PauseCallCount()).To(Equal(0))
+		})
+
+		It("returns method not allowed for GET without pausing", func() {
+			req := httptest.NewRequest("GET", "/pause", nil)
+			w := httptest.NewRecorder()
+
+			libhttp.NewErrorHandler(server.NewPauseHandler(sentinel)).ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(405))
+			Expect(sentinel.PauseCallCount()).To(Equal(0))
+		})
+
+		It("returns 500 when the sentinel cannot be written", func() {
+			sentinel.PauseReturns(errors.New("read-only"))
+			req := httptest.NewRequest("POST", "/pause", nil)
+			w := httptest.NewRecorder()
+
+			libhttp.NewErrorHandler(server.NewPauseHandler(sentinel)).ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(500))
+		})
+	})
+
 	Describe("Status endpoint", func() {
 		It("returns status from StatusChecker", func() {
 			expectedStatus := &status.Status{
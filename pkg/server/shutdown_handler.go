@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bborbe/errors"
+	libhttp "github.com/bborbe/http"
+	"github.com/bborbe/run"
+)
+
+// NewShutdownHandler creates a handler for POST /shutdown.
+// It fires trigger and answers 202; the daemon exits after the prompt in flight.
+func NewShutdownHandler(trigger run.Fire) libhttp.WithError {
+	return libhttp.WithErrorFunc(
+		func(ctx context.Context, resp http.ResponseWriter, req *http.Request) error {
+			if req.Method != http.MethodPost {
+				return libhttp.WrapWithStatusCode(
+					errors.New(ctx, "method not allowed"),
+					http.StatusMethodNotAllowed,
+				)
+			}
+
+			trigger.Fire()
+
+			resp.Header().Set("Content-Type", "application/json")
+			resp.WriteHeader(http.StatusAccepted)
+			_, _ = resp.Write([]byte(`{"status":"shutting down"}`))
+			return nil
+		},
+	)
+}
@@ -93,8 +93,10 @@ func (w *specWatcher) Watch(ctx context.Context) error {
 			if !ok {
 				return errors.Errorf(ctx, "watcher error channel closed")
 			}
-			slog.Warn("spec watcher error", "error", err)
-			continue
+			// Events may have been dropped (fsnotify.ErrEventOverflow); rescan so
+			// no spec is missed.
+			slog.Warn("spec watcher error, rescanning", "error", err)
+			w.scanExistingInProgress(ctx, absInProgressDir)
 
 		case event, ok := <-fsWatcher.Events:
 			if !ok {
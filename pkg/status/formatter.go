@@ -52,6 +52,9 @@ func (f *formatter) Format(st *Status) string {
 	} else {
 		fmt.Fprintf(&b, "  Daemon:     %s\n", st.Daemon)
 	}
+	if st.Paused {
+		b.WriteString("  Paused:     yes (no new prompts start until resume)\n")
+	}
 
 	// Current prompt
 	if st.CurrentPrompt != "" {
@@ -67,8 +70,12 @@ func (f *formatter) Format(st *Status) string {
 
 	// Queue
 	if st.QueueCount > 0 {
-		fmt.Fprintf(&b, "  Queue:      %d prompts\n", st.QueueCount)
+		fmt.Fprintf(&b, "  Queue:      %d prompts%s\n", st.QueueCount, formatSize(st.QueueBytes))
 		for _, p := range st.QueuedPrompts {
+			if owner := st.QueuedOwners[p]; owner != "" {
+				fmt.Fprintf(&b, "    - %s (owner: %s)\n", p, owner)
+				continue
+			}
 			fmt.Fprintf(&b, "    - %s\n", p)
 		}
 	} else {
@@ -96,7 +103,19 @@ func (f *formatter) Format(st *Status) string {
 	}
 
 	// Completed
-	fmt.Fprintf(&b, "  Completed:  %d prompts\n", st.CompletedCount)
+	fmt.Fprintf(&b, "  Completed:  %d prompts%s\n", st.CompletedCount, formatSize(st.CompletedBytes))
+	if st.IdeasCount > 0 {
+		fmt.Fprintf(&b, "  Ideas:      %d\n", st.IdeasCount)
+	}
+
+	// Average duration and ETA — only rendered with historical data.
+	if st.AverageDuration != "" {
+		averageLine := fmt.Sprintf("  Average:    %s per prompt", st.AverageDuration)
+		if st.EstimatedRemaining != "" {
+			averageLine += fmt.Sprintf(" (%s remaining)", st.EstimatedRemaining)
+		}
+		b.WriteString(averageLine + "\n")
+	}
 
 	// Daemon log file
 	if st.DaemonLogFile != "" {
@@ -258,3 +277,11 @@ func formatBytes(b int64) string {
 
 	return fmt.Sprintf("%.1f %s", float64(b)/float64(div), units[exp])
 }
+
+// formatSize returns " (<bytes>)" for a non-zero size and "" otherwise.
+func formatSize(b int64) string {
+	if b <= 0 {
+		return ""
+	}
+	return " (" + formatBytes(b) + ")"
+}
@@ -63,6 +63,59 @@ var _ = Describe("Formatter", func() {
 			Expect(output).To(ContainSubstring("Last log:   prompts/log/001-test.log"))
 		})
 
+		It("shows the paused line only when paused", func() {
+			st := &status.Status{Daemon: "running", Paused: true}
+			Expect(formatter.Format(st)).To(ContainSubstring("  Paused:     yes"))
+
+			st.Paused = false
+			Expect(formatter.Format(st)).NotTo(ContainSubstring("Paused:"))
+		})
+
+		It("shows the owner next to queued prompts that have one", func() {
+			st := &status.Status{
+				Daemon:        "running",
+				QueueCount:    2,
+				QueuedPrompts: []string{"002-next.md", "003-after.md"},
+				QueuedOwners:  map[string]string{"002-next.md": "alice"},
+			}
+
+			output := formatter.Format(st)
+			Expect(output).To(ContainSubstring("    - 002-next.md (owner: alice)\n"))
+			Expect(output).To(ContainSubstring("    - 003-after.md\n"))
+		})
+
+		It("shows queue and completed sizes when known", func() {
+			st := &status.Status{
+				Daemon:         "running",
+				QueueCount:     1,
+				QueuedPrompts:  []string{"002-next.md"},
+				QueueBytes:     2048,
+				CompletedCount: 3,
+				CompletedBytes: 512,
+			}
+
+			output := formatter.Format(st)
+			Expect(output).To(ContainSubstring("Queue:      1 prompts (2.0 KB)"))
+			Expect(output).To(ContainSubstring("Completed:  3 prompts (512 B)"))
+		})
+
+		It("shows the average duration and remaining time when known", func() {
+			st := &status.Status{
+				Daemon:             "running",
+				CompletedCount:     3,
+				AverageDuration:    "4m30s",
+				EstimatedRemaining: "9m",
+			}
+
+			output := formatter.Format(st)
+			Expect(output).To(ContainSubstring("Average:    4m30s per prompt (9m remaining)\n"))
+		})
+
+		It("omits the average line without historical data", func() {
+			output := formatter.Format(&status.Status{Daemon: "running"})
+			Expect(output).NotTo(ContainSubstring("Average:"))
+		})
+
 		It("formats container not running status", func() {
 			st := &status.Status{
 				Daemon:           "running",
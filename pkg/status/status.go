@@ -7,6 +7,7 @@ package status
 import (
 	"context"
 	"log/slog"
+	"net"
 	"os"
 	"path/filepath"
 	"sort"
@@ -19,11 +20,19 @@ import (
 	libtime "github.com/bborbe/time"
 
 	"github.com/bborbe/dark-factory/pkg/executor"
+	"github.com/bborbe/dark-factory/pkg/lock"
+	"github.com/bborbe/dark-factory/pkg/pause"
 	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/subproc"
 )
 
+// serverDialTimeout bounds the TCP connect used to detect a daemon by its serverPort.
+const serverDialTimeout = 500 * time.Millisecond
+
+// averageDurationSamples is the number of completed prompts averaged for AverageDuration.
+const averageDurationSamples = 10
+
 // Status represents the current daemon status.
 type Status struct {
 	ProjectDir          string   `json:"project_dir,omitempty"`
@@ -37,21 +46,38 @@ type Status struct {
 	GeneratingContainer string   `json:"generating_container,omitempty"`
 	QueueCount          int      `json:"queue_count"`
 	QueuedPrompts       []string `json:"queued_prompts"`
+	// QueueBytes and CompletedBytes sum the file sizes of queued and completed
+	// prompts, for spotting unexpectedly large prompts.
+	QueueBytes     int64 `json:"queue_bytes"`
+	CompletedBytes int64 `json:"completed_bytes"`
 	// Blocked describes the queue-advance guard's refusal to advance (spec 092).
 	// Omitted from JSON and text output when no blocker is active.
 	Blocked            *Blocked `json:"blocked,omitempty"`
 	CommittingPrompts  []string `json:"committing_prompts,omitempty"`
 	CommittingCount    int      `json:"committing_count,omitempty"`
 	CompletedCount     int      `json:"completed_count"`
+	IdeasCount         int      `json:"ideas_count,omitempty"`
 	ContainerCount     int      `json:"container_count,omitempty"`
 	ContainerMax       int      `json:"container_max,omitempty"`
 	DaemonLogFile      string   `json:"daemon_log_file,omitempty"`
 	LastLogFile        string   `json:"last_log_file,omitempty"`
 	LastLogSize        int64    `json:"last_log_size,omitempty"`
 	GitIndexLock       bool     `json:"git_index_lock,omitempty"`
+	Paused             bool     `json:"paused,omitempty"`
 	DirtyFileCount     int      `json:"dirty_file_count,omitempty"`
 	DirtyFileThreshold int      `json:"dirty_file_threshold,omitempty"`
 
+	// AverageDuration is the mean started→completed time of the last
+	// averageDurationSamples completed prompts. EstimatedRemaining extrapolates it
+	// over the rest of the executing prompt and every queued prompt.
+	// Both are omitted when no completed prompt has both timestamps.
+	AverageDuration    string `json:"average_duration,omitempty"`
+	EstimatedRemaining string `json:"estimated_remaining,omitempty"`
+
+	// QueuedOwners maps queued prompt file names to their frontmatter owner.
+	// Prompts without an owner are absent.
+	QueuedOwners map[string]string `json:"queued_owners,omitempty"`
+
 	// Skipped flags — true when the corresponding subprocess call was
 	// cancelled at timeout. Callers should NOT treat the zero value of
 	// related fields as authoritative when the matching Skipped flag is true.
@@ -66,6 +92,7 @@ type QueuedPrompt struct {
 	Name  string `json:"name"`
 	Title string `json:"title"`
 	Size  int64  `json:"size"`
+	Owner string `json:"owner,omitempty"`
 }
 
 // Blocked describes a queue-advance guard refusal (spec 092).
@@ -97,6 +124,7 @@ type checker struct {
 	queueDir              string
 	completedDir          string
 	logDir                string
+	ideasDir              string
 	lockFilePath          string
 	serverPort            int
 	promptMgr             PromptManager
@@ -105,6 +133,7 @@ type checker struct {
 	dirtyFileThreshold    int
 	currentDateTimeGetter libtime.CurrentDateTimeGetter
 	subprocRunner         subproc.Runner
+	pauseSentinel         pause.Sentinel
 }
 
 // NewChecker creates a new Checker with additional options.
@@ -114,6 +143,7 @@ func NewChecker(
 	queueDir string,
 	completedDir string,
 	logDir string,
+	ideasDir string,
 	lockFilePath string,
 	serverPort int,
 	promptMgr PromptManager,
@@ -122,6 +152,7 @@ func NewChecker(
 	dirtyFileThreshold int,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 	subprocRunner subproc.Runner,
+	pauseSentinel pause.Sentinel,
 ) Checker {
 	return &checker{
 		projectName:           projectName,
@@ -129,6 +160,7 @@ func NewChecker(
 		queueDir:              queueDir,
 		completedDir:          completedDir,
 		logDir:                logDir,
+		ideasDir:              ideasDir,
 		lockFilePath:          lockFilePath,
 		serverPort:            serverPort,
 		promptMgr:             promptMgr,
@@ -137,6 +169,7 @@ func NewChecker(
 		dirtyFileThreshold:    dirtyFileThreshold,
 		currentDateTimeGetter: currentDateTimeGetter,
 		subprocRunner:         subprocRunner,
+		pauseSentinel:         pauseSentinel,
 	}
 }
 
@@ -151,9 +184,11 @@ func (s *checker) GetStatus(ctx context.Context) (*Status, error) {
 
 	// Check if daemon is running
 	s.populateDaemonStatus(status)
+	status.Paused = s.pauseSentinel != nil && s.pauseSentinel.IsPaused()
 
 	// Check for executing prompt
-	if err := s.populateExecutingPrompt(ctx, status); err != nil {
+	executingFor, err := s.populateExecutingPrompt(ctx, status)
+	if err != nil {
 		return nil, errors.Wrap(ctx, err, "populate executing prompt")
 	}
 
@@ -174,7 +209,17 @@ func (s *checker) GetStatus(ctx context.Context) (*Status, error) {
 	}
 
 	for _, p := range queued {
-		status.QueuedPrompts = append(status.QueuedPrompts, filepath.Base(p.Path))
+		name := filepath.Base(p.Path)
+		status.QueuedPrompts = append(status.QueuedPrompts, name)
+		if info, err := os.Stat(p.Path); err == nil {
+			status.QueueBytes += info.Size()
+		}
+		if owner := s.readOwner(ctx, p.Path); owner != "" {
+			if status.QueuedOwners == nil {
+				status.QueuedOwners = make(map[string]string)
+			}
+			status.QueuedOwners[name] = owner
+		}
 	}
 	status.QueueCount = len(queued)
 
@@ -190,11 +235,18 @@ func (s *checker) GetStatus(ctx context.Context) (*Status, error) {
 	}
 
 	// Count completed prompts
-	completedCount, err := s.countMarkdownFiles(s.completedDir)
+	completedCount, completedBytes, err := s.countCompletedFiles(ctx)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, "count completed prompts")
 	}
 	status.CompletedCount = completedCount
+	status.CompletedBytes = completedBytes
+	status.IdeasCount = s.countIdeas()
+
+	// Estimate how long the executing and queued prompts take
+	if err := s.populateEstimates(ctx, status, executingFor); err != nil {
+		return nil, errors.Wrap(ctx, err, "populate estimates")
+	}
 
 	// Find latest log file
 	if err := s.populateLogInfo(ctx, status); err != nil {
@@ -244,26 +296,35 @@ func (s *checker) GetQueuedPrompts(ctx context.Context) ([]QueuedPrompt, error)
 			Name:  filepath.Base(p.Path),
 			Title: title,
 			Size:  size,
+			Owner: s.readOwner(ctx, p.Path),
 		})
 	}
 
 	return result, nil
 }
 
-// GetCompletedPrompts returns recent completed prompts.
+// readOwner returns the owner frontmatter field of the prompt at path,
+// or "" when the file has none or cannot be read.
+func (s *checker) readOwner(ctx context.Context, path string) string {
+	fm, err := s.promptMgr.ReadFrontmatter(ctx, path)
+	if err != nil || fm == nil {
+		return ""
+	}
+	return fm.Owner
+}
+
+// GetCompletedPrompts returns recent completed prompts, including those in the
+// YYYY-MM subdirectories of the monthly completed layout.
 func (s *checker) GetCompletedPrompts(
 	ctx context.Context,
 	limit int,
 ) ([]CompletedPrompt, error) {
-	entries, err := os.ReadDir(s.completedDir)
+	paths, err := prompt.ListCompletedFiles(ctx, s.completedDir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return []CompletedPrompt{}, nil
-		}
 		return nil, errors.Wrap(ctx, err, "read completed directory")
 	}
 
-	prompts := s.collectCompletedPrompts(ctx, entries)
+	prompts := s.collectCompletedPrompts(ctx, paths)
 	sortPromptsByTimeDescending(prompts)
 	prompts = applyLimit(prompts, limit)
 
@@ -279,21 +340,17 @@ type promptWithTime struct {
 // collectCompletedPrompts collects completed prompts with their completion times.
 func (s *checker) collectCompletedPrompts(
 	ctx context.Context,
-	entries []os.DirEntry,
+	paths []string,
 ) []promptWithTime {
 	prompts := make([]promptWithTime, 0)
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-
-		completedTime := s.getCompletionTime(ctx, entry)
+	for _, path := range paths {
+		completedTime := s.getCompletionTime(ctx, path)
 		if time.Time(completedTime).IsZero() {
 			continue
 		}
 
 		prompts = append(prompts, promptWithTime{
-			name:          entry.Name(),
+			name:          filepath.Base(path),
 			completedTime: completedTime,
 		})
 	}
@@ -301,8 +358,7 @@ func (s *checker) collectCompletedPrompts(
 }
 
 // getCompletionTime extracts completion time from frontmatter or file mod time.
-func (s *checker) getCompletionTime(ctx context.Context, entry os.DirEntry) libtime.DateTime {
-	path := filepath.Join(s.completedDir, entry.Name())
+func (s *checker) getCompletionTime(ctx context.Context, path string) libtime.DateTime {
 	fm, err := s.promptMgr.ReadFrontmatter(ctx, path)
 
 	// Try frontmatter timestamp first
@@ -313,7 +369,7 @@ func (s *checker) getCompletionTime(ctx context.Context, entry os.DirEntry) libt
 	}
 
 	// Fall back to file mod time
-	info, err := entry.Info()
+	info, err := os.Stat(path)
 	if err != nil {
 		return libtime.DateTime{}
 	}
@@ -356,17 +412,12 @@ type executingPrompt struct {
 
 // findExecutingPrompt finds the currently executing prompt.
 func (s *checker) findExecutingPrompt(ctx context.Context) (*executingPrompt, error) {
-	entries, err := os.ReadDir(s.queueDir)
+	paths, err := prompt.ListQueueFiles(s.queueDir)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, "read queue directory")
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-
-		path := filepath.Join(s.queueDir, entry.Name())
+	for _, path := range paths {
 		fm, err := s.promptMgr.ReadFrontmatter(ctx, path)
 		if err != nil {
 			continue
@@ -392,46 +443,62 @@ func (s *checker) findExecutingPrompt(ctx context.Context) (*executingPrompt, er
 	return nil, nil
 }
 
-// countMarkdownFiles counts .md files in a directory.
-func (s *checker) countMarkdownFiles(dir string) (int, error) {
-	entries, err := os.ReadDir(dir)
+// countCompletedFiles counts completed prompts and sums their sizes, including those
+// in the YYYY-MM subdirectories of the monthly completed layout.
+func (s *checker) countCompletedFiles(ctx context.Context) (int, int64, error) {
+	paths, err := prompt.ListCompletedFiles(ctx, s.completedDir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return 0, nil
+		return 0, 0, err
+	}
+	var size int64
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
 		}
-		return 0, err
 	}
+	return len(paths), size, nil
+}
 
+// countIdeas returns the number of .md files in the ideas directory; 0 when it does not exist.
+func (s *checker) countIdeas() int {
+	if s.ideasDir == "" {
+		return 0
+	}
+	entries, err := os.ReadDir(s.ideasDir)
+	if err != nil {
+		return 0
+	}
 	count := 0
 	for _, entry := range entries {
 		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
 			count++
 		}
 	}
-
-	return count, nil
+	return count
 }
 
-// populateExecutingPrompt populates executing prompt info in the status.
-func (s *checker) populateExecutingPrompt(ctx context.Context, st *Status) error {
+// populateExecutingPrompt populates executing prompt info in the status and returns
+// how long the prompt has been executing (0 when unknown or nothing executes).
+func (s *checker) populateExecutingPrompt(ctx context.Context, st *Status) (time.Duration, error) {
 	if !s.promptMgr.HasExecuting(ctx) {
-		return nil
+		return 0, nil
 	}
 
 	executing, err := s.findExecutingPrompt(ctx)
 	if err != nil {
-		return errors.Wrap(ctx, err, "find executing prompt")
+		return 0, errors.Wrap(ctx, err, "find executing prompt")
 	}
 
 	if executing == nil {
-		return nil
+		return 0, nil
 	}
 
 	st.CurrentPrompt = filepath.Base(executing.Path)
 	st.Container = executing.Container
 
+	var duration time.Duration
 	if !time.Time(executing.StartedTime).IsZero() {
-		duration := time.Time(s.currentDateTimeGetter.Now()).Sub(time.Time(executing.StartedTime))
+		duration = time.Time(s.currentDateTimeGetter.Now()).Sub(time.Time(executing.StartedTime))
 		st.ExecutingSince = formatDuration(duration)
 	}
 
@@ -440,9 +507,89 @@ func (s *checker) populateExecutingPrompt(ctx context.Context, st *Status) error
 	st.ContainerRunning = running
 	st.ContainerRunningSkipped = skipped
 
+	return duration, nil
+}
+
+// populateEstimates sets AverageDuration and EstimatedRemaining from the durations
+// of recently completed prompts. Both stay empty without historical data.
+func (s *checker) populateEstimates(
+	ctx context.Context,
+	st *Status,
+	executingFor time.Duration,
+) error {
+	average, ok, err := s.averageCompletedDuration(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	st.AverageDuration = formatDuration(average)
+
+	remaining := time.Duration(st.QueueCount) * average
+	if st.CurrentPrompt != "" && executingFor < average {
+		remaining += average - executingFor
+	}
+	if st.CurrentPrompt != "" || st.QueueCount > 0 {
+		st.EstimatedRemaining = formatDuration(remaining)
+	}
 	return nil
 }
 
+// averageCompletedDuration returns the mean started→completed duration of the
+// averageDurationSamples highest-numbered completed prompts that carry both
+// timestamps. ok is false when no completed prompt does.
+func (s *checker) averageCompletedDuration(ctx context.Context) (time.Duration, bool, error) {
+	paths, err := prompt.ListCompletedFiles(ctx, s.completedDir)
+	if err != nil {
+		return 0, false, errors.Wrap(ctx, err, "list completed prompts")
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return filepath.Base(paths[i]) > filepath.Base(paths[j])
+	})
+
+	durations := make([]time.Duration, 0, averageDurationSamples)
+	for _, path := range paths {
+		if len(durations) == averageDurationSamples {
+			break
+		}
+		fm, err := s.promptMgr.ReadFrontmatter(ctx, path)
+		if err != nil || fm == nil {
+			continue
+		}
+		if duration, ok := executionDuration(fm); ok {
+			durations = append(durations, duration)
+		}
+	}
+	average, ok := averageDuration(durations)
+	return average, ok, nil
+}
+
+// executionDuration returns the time between the started and completed timestamps.
+func executionDuration(fm *prompt.Frontmatter) (time.Duration, bool) {
+	started, err := time.Parse(time.RFC3339, fm.Started)
+	if err != nil {
+		return 0, false
+	}
+	completed, err := time.Parse(time.RFC3339, fm.Completed)
+	if err != nil || completed.Before(started) {
+		return 0, false
+	}
+	return completed.Sub(started), true
+}
+
+// averageDuration returns the mean of durations; ok is false when there are none.
+func averageDuration(durations []time.Duration) (time.Duration, bool) {
+	if len(durations) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations)), true
+}
+
 // populateCommittingPrompts populates CommittingPrompts and CommittingCount in the status.
 func (s *checker) populateCommittingPrompts(ctx context.Context, st *Status) error {
 	paths, err := s.promptMgr.FindCommitting(ctx)
@@ -457,25 +604,42 @@ func (s *checker) populateCommittingPrompts(ctx context.Context, st *Status) err
 }
 
 // populateDaemonStatus checks if daemon is running via lock file PID.
+// When the PID cannot be confirmed (e.g. the daemon runs in another PID namespace),
+// a server accepting connections on serverPort counts as a running daemon.
 func (s *checker) populateDaemonStatus(st *Status) {
 	pid, err := s.readLockFilePID()
-	if err != nil || pid <= 0 {
+	if err == nil && pid > 0 && s.isProcessAlive(pid) {
+		st.Daemon = "running"
+		st.DaemonPID = pid
 		return
 	}
-	if s.isProcessAlive(pid) {
+	if s.isServerListening() {
 		st.Daemon = "running"
-		st.DaemonPID = pid
 	}
 }
 
+// isServerListening reports whether a TCP connect to the daemon's serverPort succeeds.
+// Always false when the server is disabled (serverPort 0).
+func (s *checker) isServerListening() bool {
+	if s.serverPort <= 0 {
+		return false
+	}
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(s.serverPort))
+	conn, err := net.DialTimeout("tcp", addr, serverDialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
 // readLockFilePID reads the PID from the lock file.
 func (s *checker) readLockFilePID() (int, error) {
-	data, err := os.ReadFile(s.lockFilePath)
+	info, err := lock.ReadInfo(s.lockFilePath)
 	if err != nil {
 		return 0, err
 	}
-	pidStr := strings.TrimSpace(string(data))
-	return strconv.Atoi(pidStr)
+	return info.PID, nil
 }
 
 // isProcessAlive checks if a process with the given PID is alive.
@@ -530,6 +694,8 @@ func (s *checker) populateLogInfo(ctx context.Context, st *Status) error {
 }
 
 // isContainerRunning checks if a Docker container is running.
+// docker's name filter matches substrings, so only an exact name in the output counts.
+// A docker failure (e.g. docker not installed) reports not running.
 // Returns (running, skipped). When skipped is true the caller MUST treat
 // the running value as unknown and surface the skip to the user.
 func (s *checker) isContainerRunning(
@@ -557,7 +723,12 @@ func (s *checker) isContainerRunning(
 		slog.Debug("docker ps failed", "container", containerName, "err", err)
 		return false, false
 	}
-	return strings.Contains(string(out), containerName), false
+	for _, name := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(name) == containerName {
+			return true, false
+		}
+	}
+	return false, false
 }
 
 // populateGeneratingSpec checks for running spec generation containers and populates status.
@@ -8,8 +8,10 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	libtime "github.com/bborbe/time"
@@ -17,6 +19,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/bborbe/dark-factory/mocks"
+	"github.com/bborbe/dark-factory/pkg/config"
+	"github.com/bborbe/dark-factory/pkg/lock"
 	"github.com/bborbe/dark-factory/pkg/project"
 	"github.com/bborbe/dark-factory/pkg/prompt"
 	"github.com/bborbe/dark-factory/pkg/status"
@@ -64,14 +68,16 @@ var _ = Describe("StatusChecker", func() {
 			queueDir,
 			completedDir,
 			"prompts/log",
+			"",
 			lockFilePath,
-			8080,
+			0, // serverPort disabled: daemon detection relies on the lock file only
 			promptMgr,
 			nil,
 			0,
 			0,
 			libtime.NewCurrentDateTime(),
 			newSubprocRunner(),
+			nil,
 		)
 	})
 
@@ -129,6 +135,34 @@ var _ = Describe("StatusChecker", func() {
 			Expect(st.CompletedCount).To(Equal(2))
 		})
 
+		It("sums queued and completed prompt sizes", func() {
+			queuedA := filepath.Join(queueDir, "003-queued.md")
+			queuedB := filepath.Join(queueDir, "004-queued.md")
+			Expect(os.WriteFile(queuedA, []byte("12345"), 0600)).To(Succeed())
+			Expect(os.WriteFile(queuedB, []byte("1234567890"), 0600)).To(Succeed())
+			Expect(
+				os.WriteFile(filepath.Join(completedDir, "001-done.md"), []byte("abc"), 0600),
+			).To(Succeed())
+			Expect(
+				os.WriteFile(filepath.Join(completedDir, "002-done.md"), []byte("abcdefg"), 0600),
+			).To(Succeed())
+			// Non-markdown files are not prompts and are not counted.
+			Expect(
+				os.WriteFile(filepath.Join(completedDir, "notes.txt"), []byte("ignored"), 0600),
+			).To(Succeed())
+
+			promptMgr.HasExecutingReturns(false)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{
+				{Path: queuedA, Status: prompt.ApprovedPromptStatus},
+				{Path: queuedB, Status: prompt.ApprovedPromptStatus},
+			}, nil)
+
+			st, err := statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.QueueBytes).To(Equal(int64(15)))
+			Expect(st.CompletedBytes).To(Equal(int64(10)))
+		})
+
 		It("includes executing prompt info", func() {
 			// Create an executing prompt
 			execPath := filepath.Join(queueDir, "003-executing.md")
@@ -182,6 +216,178 @@ status: executing
 		})
 	})
 
+	Describe("GetStatus average duration", func() {
+		var frontmatters map[string]*prompt.Frontmatter
+
+		writeCompleted := func(name string, started time.Time, took time.Duration) {
+			path := filepath.Join(completedDir, name)
+			Expect(os.WriteFile(path, []byte("done"), 0600)).To(Succeed())
+			frontmatters[path] = &prompt.Frontmatter{
+				Status:    "completed",
+				Started:   started.Format(time.RFC3339),
+				Completed: started.Add(took).Format(time.RFC3339),
+			}
+		}
+
+		BeforeEach(func() {
+			frontmatters = map[string]*prompt.Frontmatter{}
+			promptMgr.ReadFrontmatterStub = func(
+				_ context.Context,
+				path string,
+			) (*prompt.Frontmatter, error) {
+				if fm, ok := frontmatters[path]; ok {
+					return fm, nil
+				}
+				return &prompt.Frontmatter{}, nil
+			}
+			promptMgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+		})
+
+		It("leaves the average and estimate empty without completed prompts", func() {
+			st, err := statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.AverageDuration).To(BeEmpty())
+			Expect(st.EstimatedRemaining).To(BeEmpty())
+		})
+
+		It("ignores completed prompts without timestamps", func() {
+			Expect(os.WriteFile(filepath.Join(completedDir, "001-old.md"), []byte("done"), 0600)).
+				To(Succeed())
+
+			st, err := statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.AverageDuration).To(BeEmpty())
+		})
+
+		It("averages the completed prompts and estimates the queue", func() {
+			base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+			writeCompleted("001-a.md", base, 2*time.Minute)
+			writeCompleted("002-b.md", base, 4*time.Minute)
+			writeCompleted("003-c.md", base, 6*time.Minute)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{
+				{Path: filepath.Join(queueDir, "004-d.md"), Status: prompt.ApprovedPromptStatus},
+				{Path: filepath.Join(queueDir, "005-e.md"), Status: prompt.ApprovedPromptStatus},
+			}, nil)
+
+			st, err := statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.AverageDuration).To(Equal("4m"))
+			Expect(st.EstimatedRemaining).To(Equal("8m"))
+		})
+
+		It("averages only the last 10 completed prompts", func() {
+			base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+			writeCompleted("001-slow.md", base, 10*time.Hour)
+			writeCompleted("002-slow.md", base, 10*time.Hour)
+			for i := 3; i <= 12; i++ {
+				writeCompleted(fmt.Sprintf("%03d-fast.md", i), base, time.Minute)
+			}
+
+			st, err := statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.AverageDuration).To(Equal("1m"))
+			Expect(st.EstimatedRemaining).To(BeEmpty())
+		})
+
+		It("subtracts the elapsed time of the executing prompt", func() {
+			now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+			currentDateTime := libtime.NewCurrentDateTime()
+			currentDateTime.SetNow(libtime.DateTime(now))
+			execPath := filepath.Join(queueDir, "004-executing.md")
+			Expect(os.WriteFile(execPath, []byte("executing"), 0600)).To(Succeed())
+			frontmatters[execPath] = &prompt.Frontmatter{
+				Status:  "executing",
+				Started: now.Add(-3 * time.Minute).Format(time.RFC3339),
+			}
+			writeCompleted("001-a.md", now.Add(-time.Hour), 10*time.Minute)
+			promptMgr.HasExecutingReturns(true)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{
+				{Path: filepath.Join(queueDir, "005-e.md"), Status: prompt.ApprovedPromptStatus},
+			}, nil)
+			checker := status.NewChecker(
+				project.Name("test-project"),
+				"",
+				queueDir,
+				completedDir,
+				"prompts/log",
+				"",
+				lockFilePath,
+				0,
+				promptMgr,
+				nil,
+				0,
+				0,
+				currentDateTime,
+				newSubprocRunner(),
+				nil,
+			)
+
+			st, err := checker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.ExecutingSince).To(Equal("3m"))
+			Expect(st.AverageDuration).To(Equal("10m"))
+			Expect(st.EstimatedRemaining).To(Equal("17m"))
+		})
+	})
+
+	DescribeTable("container running detection",
+		func(dockerOut string, dockerErr error, expectRunning bool, expectSkipped bool) {
+			execPath := filepath.Join(queueDir, "003-executing.md")
+			Expect(os.WriteFile(execPath, []byte("---\nstatus: executing\n---\n# Test\n"), 0600)).
+				To(Succeed())
+			promptMgr.HasExecutingReturns(true)
+			promptMgr.ReadFrontmatterReturns(&prompt.Frontmatter{
+				Status:    "executing",
+				Container: "proj-003-executing",
+			}, nil)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+
+			runner := newSubprocRunner()
+			runner.RunWithWarnAndTimeoutStub = func(
+				_ context.Context, _ string, _ string, args ...string,
+			) ([]byte, error) {
+				if slices.Contains(args, "name=proj-003-executing") {
+					return []byte(dockerOut), dockerErr
+				}
+				return []byte{}, nil
+			}
+			checker := status.NewChecker(
+				project.Name("proj"),
+				"",
+				queueDir,
+				completedDir,
+				"prompts/log",
+				"",
+				lockFilePath,
+				0,
+				promptMgr,
+				nil,
+				0,
+				0,
+				libtime.NewCurrentDateTime(),
+				runner,
+				nil,
+			)
+
+			st, err := checker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.ContainerRunning).To(Equal(expectRunning))
+			Expect(st.ContainerRunningSkipped).To(Equal(expectSkipped))
+		},
+		Entry("running", "proj-003-executing\n", nil, true, false),
+		Entry("running among others", "proj-gen-x\nproj-003-executing\n", nil, true, false),
+		Entry("not running", "", nil, false, false),
+		Entry("only a longer name matches the filter", "proj-003-executing-old\n", nil, false, false),
+		Entry(
+			"docker not installed",
+			"",
+			stderrors.New(`exec: "docker": executable file not found in $PATH`),
+			false,
+			false,
+		),
+		Entry("docker timed out", "", context.DeadlineExceeded, false, true),
+	)
+
 	Describe("Daemon detection", func() {
 		It("shows not running when lock file is missing", func() {
 			promptMgr.HasExecutingReturns(false)
@@ -221,6 +427,25 @@ status: executing
 			Expect(st.DaemonPID).To(Equal(pid))
 		})
 
+		It("shows the PID of a daemon holding the lock and drops it after release", func() {
+			locker := lock.NewLocker(tempDir)
+			Expect(locker.Acquire(ctx)).To(Succeed())
+			promptMgr.HasExecutingReturns(false)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+
+			st, err := statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.Daemon).To(Equal("running"))
+			Expect(st.DaemonPID).To(Equal(os.Getpid()))
+
+			Expect(locker.Release(ctx)).To(Succeed())
+
+			st, err = statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.Daemon).To(Equal("not running"))
+			Expect(st.DaemonPID).To(Equal(0))
+		})
+
 		It("shows not running when lock file is empty", func() {
 			err := os.WriteFile(lockFilePath, []byte(""), 0600)
 			Expect(err).NotTo(HaveOccurred())
@@ -246,6 +471,70 @@ status: executing
 		})
 	})
 
+	Describe("GetStatus daemon detection via serverPort", func() {
+		newCheckerWithPort := func(port int) status.Checker {
+			return status.NewChecker(
+				project.Name("test-project"),
+				"",
+				queueDir,
+				completedDir,
+				"prompts/log",
+				"",
+				lockFilePath,
+				port,
+				promptMgr,
+				nil,
+				0,
+				0,
+				libtime.NewCurrentDateTime(),
+				newSubprocRunner(),
+				nil,
+			)
+		}
+
+		BeforeEach(func() {
+			promptMgr.HasExecutingReturns(false)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+		})
+
+		It("shows running without PID when the server port accepts connections", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = listener.Close() }()
+			port := listener.Addr().(*net.TCPAddr).Port
+
+			st, err := newCheckerWithPort(port).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.Daemon).To(Equal("running"))
+			Expect(st.DaemonPID).To(Equal(0))
+		})
+
+		It("shows not running when nothing listens on the server port", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			port := listener.Addr().(*net.TCPAddr).Port
+			Expect(listener.Close()).To(Succeed())
+
+			st, err := newCheckerWithPort(port).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.Daemon).To(Equal("not running"))
+		})
+
+		It("prefers the lock file PID over the server port", func() {
+			pid := os.Getpid()
+			Expect(os.WriteFile(lockFilePath, []byte(fmt.Sprintf("%d\n", pid)), 0600)).To(Succeed())
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = listener.Close() }()
+			port := listener.Addr().(*net.TCPAddr).Port
+
+			st, err := newCheckerWithPort(port).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.Daemon).To(Equal("running"))
+			Expect(st.DaemonPID).To(Equal(pid))
+		})
+	})
+
 	Describe("GetQueuedPrompts", func() {
 		It("returns queued prompts with metadata", func() {
 			queuedPath1 := filepath.Join(queueDir, "001-test.md")
@@ -272,6 +561,23 @@ status: executing
 			Expect(queued[0].Title).To(Equal("Test Prompt"))
 			Expect(queued[0].Size).To(BeNumerically(">", 0))
 		})
+
+		It("includes the owner from frontmatter", func() {
+			queuedPath := filepath.Join(queueDir, "001-owned.md")
+			promptMgr.ListQueuedReturns([]prompt.Prompt{
+				{Path: queuedPath, Status: prompt.ApprovedPromptStatus},
+			}, nil)
+			promptMgr.ReadFrontmatterReturns(&prompt.Frontmatter{Owner: "alice"}, nil)
+
+			queued, err := statusChecker.GetQueuedPrompts(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queued).To(HaveLen(1))
+			Expect(queued[0].Owner).To(Equal("alice"))
+
+			st, err := statusChecker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.QueuedOwners).To(Equal(map[string]string{"001-owned.md": "alice"}))
+		})
 	})
 
 	Describe("GetStatus with log files", func() {
@@ -301,6 +607,7 @@ status: executing
 				queueDir,
 				completedDir,
 				logDir,
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -309,6 +616,7 @@ status: executing
 				0,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			st, err := checkerWithLogs.GetStatus(ctx)
@@ -328,6 +636,7 @@ status: executing
 				queueDir,
 				completedDir,
 				"/nonexistent/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -336,6 +645,7 @@ status: executing
 				0,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			st, err := checkerWithLogs.GetStatus(ctx)
@@ -464,6 +774,22 @@ container: dark-factory-nonexistent-container-xyz
 			Expect(completed[0].Name).To(Equal("001-empty-fm.md"))
 			Expect(completed[0].CompletedAt).NotTo(BeZero())
 		})
+		It("includes prompts in monthly subdirectories", func() {
+			monthDir := filepath.Join(completedDir, "2026-09")
+			Expect(os.MkdirAll(monthDir, 0750)).To(Succeed())
+			content := "---\nstatus: completed\ncompleted: 2026-09-30T23:00:00Z\n---\n\n# Old\n"
+			err := os.WriteFile(filepath.Join(monthDir, "001-september.md"), []byte(content), 0600)
+			Expect(err).NotTo(HaveOccurred())
+			content = "---\nstatus: completed\ncompleted: 2026-10-01T01:00:00Z\n---\n\n# New\n"
+			err = os.WriteFile(filepath.Join(completedDir, "002-october.md"), []byte(content), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			completed, err := statusChecker.GetCompletedPrompts(ctx, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(HaveLen(2))
+			Expect(completed[0].Name).To(Equal("002-october.md"))
+			Expect(completed[1].Name).To(Equal("001-september.md"))
+		})
 	})
 
 	Describe("GetStatus committing prompts", func() {
@@ -659,6 +985,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -667,6 +994,7 @@ container: dark-factory-nonexistent-container-xyz
 				0,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -688,6 +1016,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -696,6 +1025,7 @@ container: dark-factory-nonexistent-container-xyz
 				0,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -715,6 +1045,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -723,6 +1054,7 @@ container: dark-factory-nonexistent-container-xyz
 				0,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -735,6 +1067,141 @@ container: dark-factory-nonexistent-container-xyz
 		})
 	})
 
+	Describe("GetStatus configured log dir", func() {
+		It("reads the last log from the log dir the executor writes to", func() {
+			cfg := config.Defaults()
+			cfg.Prompts = config.NewPromptsConfig(filepath.Join(tempDir, "prompts"))
+			config.ApplyLogDir(&cfg, "agent-logs")
+			Expect(os.MkdirAll(cfg.Prompts.LogDir, 0750)).To(Succeed())
+			logFile := filepath.Join(cfg.Prompts.LogDir, "006-log-dir.log")
+			Expect(os.WriteFile(logFile, []byte("log"), 0600)).To(Succeed())
+			promptMgr.HasExecutingReturns(false)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+
+			checker := status.NewChecker(
+				project.Name("test-project"),
+				"",
+				queueDir,
+				completedDir,
+				cfg.Prompts.LogDir,
+				"",
+				lockFilePath,
+				0,
+				promptMgr,
+				nil,
+				0,
+				0,
+				libtime.NewCurrentDateTime(),
+				newSubprocRunner(),
+				nil,
+			)
+
+			st, err := checker.GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.LastLogFile).
+				To(Equal(filepath.Join(tempDir, "prompts", "agent-logs", "006-log-dir.log")))
+		})
+	})
+
+	Describe("GetStatus ideas", func() {
+		newIdeasChecker := func(ideasDir string) status.Checker {
+			return status.NewChecker(
+				project.Name("test-project"),
+				"",
+				queueDir,
+				completedDir,
+				"prompts/log",
+				ideasDir,
+				lockFilePath,
+				0,
+				promptMgr,
+				nil,
+				0,
+				0,
+				libtime.NewCurrentDateTime(),
+				newSubprocRunner(),
+				nil,
+			)
+		}
+
+		BeforeEach(func() {
+			promptMgr.HasExecutingReturns(false)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+		})
+
+		It("counts the markdown files in a custom ideas directory", func() {
+			ideasDir := filepath.Join(tempDir, "backlog", "ideas")
+			Expect(os.MkdirAll(filepath.Join(ideasDir, "drafts"), 0750)).To(Succeed())
+			for _, name := range []string{"dark-mode.md", "export.md", "notes.txt"} {
+				Expect(os.WriteFile(filepath.Join(ideasDir, name), []byte("idea"), 0600)).
+					To(Succeed())
+			}
+
+			st, err := newIdeasChecker(ideasDir).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.IdeasCount).To(Equal(2))
+		})
+
+		It("counts the default prompts/ideas directory", func() {
+			ideasDir := filepath.Join(tempDir, "prompts", "ideas")
+			Expect(os.MkdirAll(ideasDir, 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ideasDir, "cache.md"), []byte("idea"), 0600)).
+				To(Succeed())
+
+			st, err := newIdeasChecker(ideasDir).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.IdeasCount).To(Equal(1))
+		})
+
+		It("reports 0 when the ideas directory does not exist", func() {
+			st, err := newIdeasChecker(filepath.Join(tempDir, "missing")).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.IdeasCount).To(Equal(0))
+		})
+	})
+
+	Describe("GetStatus paused", func() {
+		newPausedChecker := func(sentinel *mocks.PauseSentinel) status.Checker {
+			return status.NewChecker(
+				project.Name("test-project"),
+				"",
+				queueDir,
+				completedDir,
+				"prompts/log",
+				"",
+				lockFilePath,
+				0,
+				promptMgr,
+				nil,
+				0,
+				0,
+				libtime.NewCurrentDateTime(),
+				newSubprocRunner(),
+				sentinel,
+			)
+		}
+
+		BeforeEach(func() {
+			promptMgr.HasExecutingReturns(false)
+			promptMgr.ListQueuedReturns([]prompt.Prompt{}, nil)
+		})
+
+		It("sets Paused when the sentinel reports paused", func() {
+			sentinel := &mocks.PauseSentinel{}
+			sentinel.IsPausedReturns(true)
+
+			st, err := newPausedChecker(sentinel).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.Paused).To(BeTrue())
+		})
+
+		It("leaves Paused false when the queue is not paused", func() {
+			st, err := newPausedChecker(&mocks.PauseSentinel{}).GetStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(st.Paused).To(BeFalse())
+		})
+	})
+
 	Describe("GetStatus git warnings", func() {
 		It("sets GitIndexLock true when .git/index.lock exists", func() {
 			gitDir := filepath.Join(tempDir, ".git")
@@ -749,6 +1216,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -757,6 +1225,7 @@ container: dark-factory-nonexistent-container-xyz
 				0,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -774,6 +1243,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -782,6 +1252,7 @@ container: dark-factory-nonexistent-container-xyz
 				0,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -799,6 +1270,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -807,6 +1279,7 @@ container: dark-factory-nonexistent-container-xyz
 				42,
 				libtime.NewCurrentDateTime(),
 				newSubprocRunner(),
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -830,6 +1303,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -838,6 +1312,7 @@ container: dark-factory-nonexistent-container-xyz
 				0,
 				libtime.NewCurrentDateTime(),
 				runner,
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -860,6 +1335,7 @@ container: dark-factory-nonexistent-container-xyz
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -868,6 +1344,7 @@ container: dark-factory-nonexistent-container-xyz
 				0,
 				libtime.NewCurrentDateTime(),
 				runner,
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -906,6 +1383,7 @@ container: dark-factory-006
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -914,6 +1392,7 @@ container: dark-factory-006
 				0,
 				libtime.NewCurrentDateTime(),
 				runner,
+				nil,
 			)
 
 			st, err := checker.GetStatus(ctx)
@@ -936,6 +1415,7 @@ container: dark-factory-006
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -944,6 +1424,7 @@ container: dark-factory-006
 				0,
 				libtime.NewCurrentDateTime(),
 				runner,
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -970,6 +1451,7 @@ container: dark-factory-006
 				queueDir,
 				completedDir,
 				"prompts/log",
+				"",
 				lockFilePath,
 				8080,
 				promptMgr,
@@ -978,6 +1460,7 @@ container: dark-factory-006
 				0,
 				libtime.NewCurrentDateTime(),
 				runner,
+				nil,
 			)
 
 			promptMgr.HasExecutingReturns(false)
@@ -0,0 +1,21 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package watcher
+
+import (
+	"context"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchLoopForTest exposes watcher.watchLoop so tests can inject fsnotify events and errors.
+func WatchLoopForTest(
+	ctx context.Context,
+	w Watcher,
+	events <-chan fsnotify.Event,
+	errs <-chan error,
+) error {
+	return w.(*watcher).watchLoop(ctx, events, errs, func(string) error { return nil })
+}
@@ -6,6 +6,7 @@ package watcher
 
 import (
 	"context"
+	stderrors "errors"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -16,6 +17,8 @@ import (
 	"github.com/bborbe/errors"
 	libtime "github.com/bborbe/time"
 	"github.com/fsnotify/fsnotify"
+
+	"github.com/bborbe/dark-factory/pkg/prompt"
 )
 
 //counterfeiter:generate -o ../../mocks/watcher.go --fake-name Watcher . Watcher
@@ -25,7 +28,11 @@ type Watcher interface {
 	Watch(ctx context.Context) error
 }
 
+// DefaultDebounce is the debounce used when NewWatcher gets a zero or negative duration.
+const DefaultDebounce = 500 * time.Millisecond
+
 // NewWatcher creates a new Watcher with the specified debounce duration.
+// A zero or negative debounce falls back to DefaultDebounce.
 func NewWatcher(
 	inProgressDir string,
 	inboxDir string,
@@ -34,6 +41,9 @@ func NewWatcher(
 	debounce time.Duration,
 	currentDateTimeGetter libtime.CurrentDateTimeGetter,
 ) Watcher {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
 	return &watcher{
 		inProgressDir:         inProgressDir,
 		inboxDir:              inboxDir,
@@ -67,13 +77,49 @@ func (w *watcher) Watch(ctx context.Context) error {
 	// Get absolute path for in-progress directory
 	absInProgressDir := w.getInProgressDir()
 
-	// Watch the in-progress directory
-	if err := fsWatcher.Add(absInProgressDir); err != nil {
+	// Watch the in-progress directory and its queue subdirectories
+	if err := addDirTree(fsWatcher.Add, absInProgressDir); err != nil {
 		return errors.Wrap(ctx, err, "add watch path")
 	}
 
 	slog.Info("watcher started", "dir", absInProgressDir)
 
+	return w.watchLoop(ctx, fsWatcher.Events, fsWatcher.Errors, fsWatcher.Add)
+}
+
+// addDirTree adds dir and, recursively, every queue subdirectory below it (see
+// prompt.IsQueueSubdir) using add. Only a failure to add dir itself is returned.
+func addDirTree(add func(string) error, dir string) error {
+	if err := add(dir); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		slog.Debug("cannot list directory for watching", "dir", dir, "error", err)
+		return nil
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !prompt.IsQueueSubdir(entry.Name()) {
+			continue
+		}
+		sub := filepath.Join(dir, entry.Name())
+		if err := addDirTree(add, sub); err != nil {
+			slog.Warn("failed to watch prompt subdirectory", "dir", sub, "error", err)
+		}
+	}
+	return nil
+}
+
+// watchLoop dispatches fsnotify events until ctx is cancelled or a channel closes.
+// Watcher errors (including fsnotify.ErrEventOverflow, where events were dropped)
+// are not fatal: they trigger a full rescan so no prompt is missed.
+// New queue subdirectories are registered with addDir.
+func (w *watcher) watchLoop(
+	ctx context.Context,
+	events <-chan fsnotify.Event,
+	errs <-chan error,
+	addDir func(string) error,
+) error {
 	// Debounce map: file path -> timer (protected by mutex)
 	var debounceMu sync.Mutex
 	debounceTimers := make(map[string]*time.Timer)
@@ -84,50 +130,145 @@ func (w *watcher) Watch(ctx context.Context) error {
 			slog.Info("watcher shutting down")
 			return nil
 
-		case err, ok := <-fsWatcher.Errors:
+		case err, ok := <-errs:
 			if !ok {
 				return errors.Errorf(ctx, "watcher error channel closed")
 			}
-			slog.Warn("watcher error", "error", err)
-			continue
+			if stderrors.Is(err, fsnotify.ErrEventOverflow) {
+				slog.Warn("watcher event queue overflow, rescanning", "error", err)
+			} else {
+				slog.Warn("watcher error, rescanning", "error", err)
+			}
+			w.handleFileEvent(ctx)
 
-		case event, ok := <-fsWatcher.Events:
+		case event, ok := <-events:
 			if !ok {
 				return errors.Errorf(ctx, "watcher events channel closed")
 			}
 
+			if w.handleDirCreate(event, addDir) {
+				w.debounceEvent(ctx, event.Name, &debounceMu, debounceTimers)
+				continue
+			}
 			w.handleWatchEvent(ctx, event, &debounceMu, debounceTimers)
 		}
 	}
 }
 
 // handleWatchEvent processes a file system event with debouncing.
+// Editors that save atomically write a temporary file and rename it over the
+// prompt, or rename the prompt away and recreate it. Events of such a save are
+// keyed by the prompt path (see promptPath), and Rename or Remove drops the
+// pending timer of the vanished path; the Create of the new file starts a fresh one.
 func (w *watcher) handleWatchEvent(
 	ctx context.Context,
 	event fsnotify.Event,
 	debounceMu *sync.Mutex,
 	debounceTimers map[string]*time.Timer,
 ) {
-	// Only process .md files on Write or Create events
+	// Only process .md files on Write, Create, Chmod, Rename or Remove events
 	if !strings.HasSuffix(event.Name, ".md") {
 		return
 	}
-	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) &&
-		!event.Has(fsnotify.Chmod) {
+	changed := event.Has(fsnotify.Write) || event.Has(fsnotify.Create) ||
+		event.Has(fsnotify.Chmod)
+	gone := event.Has(fsnotify.Rename) || event.Has(fsnotify.Remove)
+	if !changed && !gone {
+		return
+	}
+	// Only files in the watched directory or its queue subdirectories are prompt
+	// sources; never react to completed/ or log/ even if a backend reports nested events.
+	if !w.isQueueDir(filepath.Dir(event.Name)) {
+		slog.Debug("ignoring event outside watched directory", "path", event.Name)
 		return
 	}
 
 	slog.Debug("file event received", "operation", event.Op.String(), "path", event.Name)
 
+	path := promptPath(event.Name)
+	if !changed {
+		cancelDebounce(path, debounceMu, debounceTimers)
+		return
+	}
+	w.debounceEvent(ctx, path, debounceMu, debounceTimers)
+}
+
+// promptPath maps the temporary names editors save through (".name.md",
+// ".#name.md") to the prompt path name they replace.
+func promptPath(name string) string {
+	base := strings.TrimPrefix(strings.TrimPrefix(filepath.Base(name), ".#"), ".")
+	return filepath.Join(filepath.Dir(name), base)
+}
+
+// cancelDebounce stops and forgets the pending timer for name, if any.
+func cancelDebounce(
+	name string,
+	debounceMu *sync.Mutex,
+	debounceTimers map[string]*time.Timer,
+) {
+	debounceMu.Lock()
+	defer debounceMu.Unlock()
+	timer, exists := debounceTimers[name]
+	if !exists {
+		return
+	}
+	timer.Stop()
+	delete(debounceTimers, name)
+	slog.Debug("debounce timer cancelled", "path", name)
+}
+
+// handleDirCreate registers a newly created queue subdirectory, and everything
+// below it, with addDir. It reports whether the event was such a directory.
+func (w *watcher) handleDirCreate(event fsnotify.Event, addDir func(string) error) bool {
+	if !event.Has(fsnotify.Create) || !w.isQueueDir(event.Name) {
+		return false
+	}
+	info, err := os.Stat(event.Name)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	if err := addDirTree(addDir, event.Name); err != nil {
+		slog.Warn("failed to watch new prompt subdirectory", "dir", event.Name, "error", err)
+	}
+	slog.Debug("watching new prompt subdirectory", "dir", event.Name)
+	return true
+}
+
+// isQueueDir reports whether dir is the in-progress directory or one of its
+// queue subdirectories.
+func (w *watcher) isQueueDir(dir string) bool {
+	rel, err := filepath.Rel(w.getInProgressDir(), dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	if rel == "." {
+		return true
+	}
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		if !prompt.IsQueueSubdir(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// debounceEvent schedules handleFileEvent once no further event for name arrived
+// within the debounce duration.
+func (w *watcher) debounceEvent(
+	ctx context.Context,
+	name string,
+	debounceMu *sync.Mutex,
+	debounceTimers map[string]*time.Timer,
+) {
 	// Debounce: cancel existing timer for this file
 	debounceMu.Lock()
-	if timer, exists := debounceTimers[event.Name]; exists {
+	if timer, exists := debounceTimers[name]; exists {
 		timer.Stop()
-		slog.Debug("debounce timer reset", "path", event.Name)
+		slog.Debug("debounce timer reset", "path", name)
 	}
 
 	// Set new timer
-	eventName := event.Name // Capture for closure
+	eventName := name // Capture for closure
 	debounceTimers[eventName] = time.AfterFunc(w.debounce, func() {
 		debounceMu.Lock()
 		delete(debounceTimers, eventName)
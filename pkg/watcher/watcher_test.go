@@ -11,6 +11,7 @@ import (
 	"time"
 
 	libtime "github.com/bborbe/time"
+	"github.com/fsnotify/fsnotify"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -168,6 +169,53 @@ var _ = Describe("Watcher", func() {
 		cancel()
 	})
 
+	Describe("debounce duration", func() {
+		startWatcher := func(debounce time.Duration) *mocks.WatcherPromptManager {
+			promptManager := &mocks.WatcherPromptManager{}
+			promptManager.NormalizeFilenamesReturns([]prompt.Rename{}, nil)
+			w := watcher.NewWatcher(
+				promptsDir,
+				inboxDir,
+				promptManager,
+				ready,
+				debounce,
+				libtime.NewCurrentDateTime(),
+			)
+			go func() {
+				_ = w.Watch(ctx)
+			}()
+			time.Sleep(200 * time.Millisecond)
+			Expect(os.WriteFile(filepath.Join(promptsDir, "timed.md"), []byte("# Test"), 0600)).
+				To(Succeed())
+			return promptManager
+		}
+
+		It("fires quickly with a tiny debounce", func() {
+			promptManager := startWatcher(10 * time.Millisecond)
+
+			Eventually(promptManager.NormalizeFilenamesCallCount, 150*time.Millisecond).
+				Should(Equal(1))
+		})
+
+		It("waits for a large debounce before firing", func() {
+			promptManager := startWatcher(1500 * time.Millisecond)
+
+			Consistently(promptManager.NormalizeFilenamesCallCount, time.Second).
+				Should(Equal(0))
+			Eventually(promptManager.NormalizeFilenamesCallCount, 2*time.Second).
+				Should(Equal(1))
+		})
+
+		It("falls back to the default for a zero debounce", func() {
+			promptManager := startWatcher(0)
+
+			Consistently(promptManager.NormalizeFilenamesCallCount, 300*time.Millisecond).
+				Should(Equal(0))
+			Eventually(promptManager.NormalizeFilenamesCallCount, time.Second).
+				Should(Equal(1))
+		})
+	})
+
 	It("should ignore non-markdown files", func() {
 		promptManager := &mocks.WatcherPromptManager{}
 		promptManager.NormalizeFilenamesReturns([]prompt.Rename{}, nil)
@@ -202,6 +250,120 @@ var _ = Describe("Watcher", func() {
 		cancel()
 	})
 
+	It("should ignore files created under completed/ and log/", func() {
+		promptManager := &mocks.WatcherPromptManager{}
+		promptManager.NormalizeFilenamesReturns([]prompt.Rename{}, nil)
+		completedDir := filepath.Join(promptsDir, "completed")
+		logDir := filepath.Join(promptsDir, "log")
+		Expect(os.MkdirAll(completedDir, 0750)).To(Succeed())
+		Expect(os.MkdirAll(logDir, 0750)).To(Succeed())
+
+		w := watcher.NewWatcher(
+			promptsDir,
+			inboxDir,
+			promptManager,
+			ready,
+			100*time.Millisecond,
+			libtime.NewCurrentDateTime(),
+		)
+
+		go func() {
+			_ = w.Watch(ctx)
+		}()
+
+		time.Sleep(200 * time.Millisecond)
+
+		Expect(os.WriteFile(
+			filepath.Join(completedDir, "001-done.md"),
+			[]byte("---\nstatus: approved\n---\n# Done\n"),
+			0600,
+		)).To(Succeed())
+		Expect(os.WriteFile(
+			filepath.Join(logDir, "001-done.md"),
+			[]byte("# log\n"),
+			0600,
+		)).To(Succeed())
+
+		Consistently(func() int {
+			return promptManager.NormalizeFilenamesCallCount()
+		}, 1*time.Second, 100*time.Millisecond).Should(Equal(0))
+
+		cancel()
+	})
+
+	It("should normalize filenames when a file is created in a nested folder", func() {
+		promptManager := &mocks.WatcherPromptManager{}
+		promptManager.NormalizeFilenamesReturns([]prompt.Rename{}, nil)
+		nestedDir := filepath.Join(promptsDir, "feature", "api")
+		Expect(os.MkdirAll(nestedDir, 0750)).To(Succeed())
+
+		w := watcher.NewWatcher(
+			promptsDir,
+			inboxDir,
+			promptManager,
+			ready,
+			100*time.Millisecond,
+			libtime.NewCurrentDateTime(),
+		)
+
+		go func() {
+			_ = w.Watch(ctx)
+		}()
+
+		time.Sleep(200 * time.Millisecond)
+
+		Expect(os.WriteFile(
+			filepath.Join(nestedDir, "nested.md"),
+			[]byte("# Nested"),
+			0600,
+		)).To(Succeed())
+
+		Eventually(func() int {
+			return promptManager.NormalizeFilenamesCallCount()
+		}, 2*time.Second, 100*time.Millisecond).Should(BeNumerically(">=", 1))
+
+		cancel()
+	})
+
+	It("should watch folders created while running", func() {
+		promptManager := &mocks.WatcherPromptManager{}
+		promptManager.NormalizeFilenamesReturns([]prompt.Rename{}, nil)
+
+		w := watcher.NewWatcher(
+			promptsDir,
+			inboxDir,
+			promptManager,
+			ready,
+			100*time.Millisecond,
+			libtime.NewCurrentDateTime(),
+		)
+
+		go func() {
+			_ = w.Watch(ctx)
+		}()
+
+		time.Sleep(200 * time.Millisecond)
+
+		newDir := filepath.Join(promptsDir, "feature")
+		Expect(os.MkdirAll(newDir, 0750)).To(Succeed())
+		Eventually(func() int {
+			return promptManager.NormalizeFilenamesCallCount()
+		}, 2*time.Second, 100*time.Millisecond).Should(BeNumerically(">=", 1))
+		callsAfterMkdir := promptManager.NormalizeFilenamesCallCount()
+
+		Expect(os.WriteFile(
+			filepath.Join(newDir, "nested.md"),
+			[]byte("# Nested"),
+			0600,
+		)).To(Succeed())
+
+		Eventually(func() int {
+			return promptManager.NormalizeFilenamesCallCount()
+		}, 2*time.Second, 100*time.Millisecond).Should(BeNumerically(">", callsAfterMkdir))
+
+		cancel()
+	})
+
 	It("should handle normalization errors gracefully", func() {
 		promptManager := &mocks.WatcherPromptManager{}
 		promptManager.NormalizeFilenamesReturns(nil, os.ErrPermission)
@@ -251,6 +413,119 @@ var _ = Describe("Watcher", func() {
 		}
 	})
 
+	Context("watcher errors", func() {
+		var (
+			promptManager *mocks.WatcherPromptManager
+			w             watcher.Watcher
+			events        chan fsnotify.Event
+			errs          chan error
+			errCh         chan error
+		)
+
+		BeforeEach(func() {
+			promptManager = &mocks.WatcherPromptManager{}
+			promptManager.NormalizeFilenamesReturns([]prompt.Rename{}, nil)
+			w = watcher.NewWatcher(
+				promptsDir,
+				inboxDir,
+				promptManager,
+				ready,
+				50*time.Millisecond,
+				libtime.NewCurrentDateTime(),
+			)
+			events = make(chan fsnotify.Event)
+			errs = make(chan error)
+			errCh = make(chan error, 1)
+			go func() {
+				errCh <- watcher.WatchLoopForTest(ctx, w, events, errs)
+			}()
+		})
+
+		It("rescans and keeps running on event queue overflow", func() {
+			errs <- fsnotify.ErrEventOverflow
+
+			Eventually(ready, 1*time.Second).Should(Receive())
+			Expect(promptManager.NormalizeFilenamesCallCount()).To(Equal(1))
+			Consistently(errCh, 200*time.Millisecond).ShouldNot(Receive())
+
+			errs <- os.ErrInvalid
+
+			Eventually(ready, 1*time.Second).Should(Receive())
+			Expect(promptManager.NormalizeFilenamesCallCount()).To(Equal(2))
+			Consistently(errCh, 200*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("returns an error when the error channel is closed", func() {
+			close(errs)
+
+			Eventually(errCh, 1*time.Second).Should(Receive(HaveOccurred()))
+		})
+	})
+
+	Context("atomic saves", func() {
+		var (
+			promptManager *mocks.WatcherPromptManager
+			events        chan fsnotify.Event
+			promptFile    string
+		)
+
+		BeforeEach(func() {
+			promptManager = &mocks.WatcherPromptManager{}
+			promptManager.NormalizeFilenamesReturns([]prompt.Rename{}, nil)
+			w := watcher.NewWatcher(
+				promptsDir,
+				inboxDir,
+				promptManager,
+				ready,
+				100*time.Millisecond,
+				libtime.NewCurrentDateTime(),
+			)
+			events = make(chan fsnotify.Event)
+			go func() {
+				_ = watcher.WatchLoopForTest(ctx, w, events, make(chan error))
+			}()
+			promptFile = filepath.Join(promptsDir, "001-edit.md")
+		})
+
+		send := func(name string, op fsnotify.Op) {
+			events <- fsnotify.Event{Name: name, Op: op}
+		}
+
+		expectProcessedOnce := func() {
+			Eventually(promptManager.NormalizeFilenamesCallCount, time.Second).Should(Equal(1))
+			Consistently(promptManager.NormalizeFilenamesCallCount, 300*time.Millisecond).
+				Should(Equal(1))
+			Expect(ready).To(HaveLen(1))
+		}
+
+		It("processes a rename-over save from a temporary file once", func() {
+			tmpFile := filepath.Join(promptsDir, ".001-edit.md")
+			send(tmpFile, fsnotify.Create)
+			send(tmpFile, fsnotify.Write)
+			send(tmpFile, fsnotify.Rename)
+			send(promptFile, fsnotify.Create)
+
+			expectProcessedOnce()
+		})
+
+		It("processes a rename-away and recreate save once", func() {
+			send(promptFile, fsnotify.Write)
+			send(promptFile, fsnotify.Rename)
+			send(promptFile, fsnotify.Create)
+			send(promptFile, fsnotify.Write)
+
+			expectProcessedOnce()
+		})
+
+		It("drops the pending timer of a removed file", func() {
+			send(promptFile, fsnotify.Write)
+			send(promptFile, fsnotify.Remove)
+
+			Consistently(promptManager.NormalizeFilenamesCallCount, 300*time.Millisecond).
+				Should(Equal(0))
+		})
+	})
+
 	It("should send ready signal after normalization", func() {
 		promptManager := &mocks.WatcherPromptManager{}
 		promptManager.NormalizeFilenamesReturns([]prompt.Rename{